@@ -920,6 +920,7 @@ func restore_v1alpha3_VirtualMachineNetworkSpec(
 	dstNetwork.Disabled = srcNetwork.Disabled
 	dstNetwork.Nameservers = srcNetwork.Nameservers
 	dstNetwork.SearchDomains = srcNetwork.SearchDomains
+	dstNetwork.Ports = srcNetwork.Ports
 
 	if len(dstNetwork.Interfaces) == 0 {
 		// No interfaces so nothing to fixup (the interfaces were removed): we ignore the restored interfaces.
@@ -951,6 +952,10 @@ func restore_v1alpha3_VirtualMachineReadinessProbeSpec(
 			dst.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{}
 		}
 		dst.Spec.ReadinessProbe.GuestInfo = src.Spec.ReadinessProbe.GuestInfo
+		dst.Spec.ReadinessProbe.HTTPGet = src.Spec.ReadinessProbe.HTTPGet
+		dst.Spec.ReadinessProbe.InitialDelaySeconds = src.Spec.ReadinessProbe.InitialDelaySeconds
+		dst.Spec.ReadinessProbe.SuccessThreshold = src.Spec.ReadinessProbe.SuccessThreshold
+		dst.Spec.ReadinessProbe.FailureThreshold = src.Spec.ReadinessProbe.FailureThreshold
 	}
 }
 
@@ -993,6 +998,18 @@ func restore_v1alpha3_VirtualMachineCdrom(dst, src *vmopv1.VirtualMachine) {
 	dst.Spec.Cdrom = src.Spec.Cdrom
 }
 
+func restore_v1alpha3_VirtualMachineCurrentSnapshot(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.CurrentSnapshot = src.Spec.CurrentSnapshot
+}
+
+func restore_v1alpha3_VirtualMachineLivenessProbe(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.LivenessProbe = src.Spec.LivenessProbe
+}
+
+func restore_v1alpha3_VirtualMachineNextRelocateTime(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.NextRelocateTime = src.Spec.NextRelocateTime
+}
+
 func convert_v1alpha1_PreReqsReadyCondition_to_v1alpha3_Conditions(
 	dst *vmopv1.VirtualMachine) []metav1.Condition {
 
@@ -1245,6 +1262,9 @@ func (src *VirtualMachine) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	restore_v1alpha3_VirtualMachineGuestID(dst, restored)
 	restore_v1alpha3_VirtualMachineCdrom(dst, restored)
 	restore_v1alpha3_VirtualMachineCryptoSpec(dst, restored)
+	restore_v1alpha3_VirtualMachineCurrentSnapshot(dst, restored)
+	restore_v1alpha3_VirtualMachineLivenessProbe(dst, restored)
+	restore_v1alpha3_VirtualMachineNextRelocateTime(dst, restored)
 
 	// END RESTORE
 
@@ -4,31 +4,111 @@
 package v1alpha1
 
 import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrlconversion "sigs.k8s.io/controller-runtime/pkg/conversion"
 
-	"github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/api/utilconversion"
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 )
 
+func Convert_v1alpha3_VirtualMachineServiceSpec_To_v1alpha1_VirtualMachineServiceSpec(
+	in *vmopv1.VirtualMachineServiceSpec, out *VirtualMachineServiceSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha3_VirtualMachineServiceSpec_To_v1alpha1_VirtualMachineServiceSpec(in, out, s)
+}
+
+func Convert_v1alpha1_VirtualMachineServicePort_To_v1alpha3_VirtualMachineServicePort(
+	in *VirtualMachineServicePort, out *vmopv1.VirtualMachineServicePort, s apiconversion.Scope) error {
+
+	if err := autoConvert_v1alpha1_VirtualMachineServicePort_To_v1alpha3_VirtualMachineServicePort(in, out, s); err != nil {
+		return err
+	}
+	out.TargetPort = intstr.FromInt32(in.TargetPort)
+
+	return nil
+}
+
+func Convert_v1alpha3_VirtualMachineServicePort_To_v1alpha1_VirtualMachineServicePort(
+	in *vmopv1.VirtualMachineServicePort, out *VirtualMachineServicePort, s apiconversion.Scope) error {
+
+	if err := autoConvert_v1alpha3_VirtualMachineServicePort_To_v1alpha1_VirtualMachineServicePort(in, out, s); err != nil {
+		return err
+	}
+	// A named target port cannot be represented and is restored on up-conversion.
+	if in.TargetPort.Type == intstr.Int {
+		out.TargetPort = in.TargetPort.IntVal
+	}
+
+	return nil
+}
+
+func restore_v1alpha3_VirtualMachineServiceIPFamilies(dst, src *vmopv1.VirtualMachineService) {
+	dst.Spec.IPFamilies = src.Spec.IPFamilies
+	dst.Spec.IPFamilyPolicy = src.Spec.IPFamilyPolicy
+}
+
+func restore_v1alpha3_VirtualMachineServiceTrafficPolicies(dst, src *vmopv1.VirtualMachineService) {
+	dst.Spec.SessionAffinity = src.Spec.SessionAffinity
+	dst.Spec.SessionAffinityConfig = src.Spec.SessionAffinityConfig
+	dst.Spec.ExternalTrafficPolicy = src.Spec.ExternalTrafficPolicy
+	dst.Spec.InternalTrafficPolicy = src.Spec.InternalTrafficPolicy
+	dst.Spec.HealthCheckNodePort = src.Spec.HealthCheckNodePort
+}
+
+func restore_v1alpha3_VirtualMachineServicePortTargetPorts(dst, src *vmopv1.VirtualMachineService) {
+	for i := range dst.Spec.Ports {
+		if i >= len(src.Spec.Ports) {
+			break
+		}
+		dstPort, srcPort := &dst.Spec.Ports[i], src.Spec.Ports[i]
+		if dstPort.Name == srcPort.Name && srcPort.TargetPort.Type == intstr.String &&
+			dstPort.TargetPort.Type == intstr.Int && dstPort.TargetPort.IntVal == 0 {
+
+			dstPort.TargetPort = srcPort.TargetPort
+		}
+	}
+}
+
 // ConvertTo converts this VirtualMachineService to the Hub version.
 func (src *VirtualMachineService) ConvertTo(dstRaw ctrlconversion.Hub) error {
-	dst := dstRaw.(*v1alpha3.VirtualMachineService)
-	return Convert_v1alpha1_VirtualMachineService_To_v1alpha3_VirtualMachineService(src, dst, nil)
+	dst := dstRaw.(*vmopv1.VirtualMachineService)
+	if err := Convert_v1alpha1_VirtualMachineService_To_v1alpha3_VirtualMachineService(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &vmopv1.VirtualMachineService{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	restore_v1alpha3_VirtualMachineServiceIPFamilies(dst, restored)
+	restore_v1alpha3_VirtualMachineServiceTrafficPolicies(dst, restored)
+	restore_v1alpha3_VirtualMachineServicePortTargetPorts(dst, restored)
+
+	return nil
 }
 
 // ConvertFrom converts the hub version to this VirtualMachineService.
 func (dst *VirtualMachineService) ConvertFrom(srcRaw ctrlconversion.Hub) error {
-	src := srcRaw.(*v1alpha3.VirtualMachineService)
-	return Convert_v1alpha3_VirtualMachineService_To_v1alpha1_VirtualMachineService(src, dst, nil)
+	src := srcRaw.(*vmopv1.VirtualMachineService)
+	if err := Convert_v1alpha3_VirtualMachineService_To_v1alpha1_VirtualMachineService(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dst)
 }
 
 // ConvertTo converts this VirtualMachineServiceList to the Hub version.
 func (src *VirtualMachineServiceList) ConvertTo(dstRaw ctrlconversion.Hub) error {
-	dst := dstRaw.(*v1alpha3.VirtualMachineServiceList)
+	dst := dstRaw.(*vmopv1.VirtualMachineServiceList)
 	return Convert_v1alpha1_VirtualMachineServiceList_To_v1alpha3_VirtualMachineServiceList(src, dst, nil)
 }
 
 // ConvertFrom converts the hub version to this VirtualMachineServiceList.
 func (dst *VirtualMachineServiceList) ConvertFrom(srcRaw ctrlconversion.Hub) error {
-	src := srcRaw.(*v1alpha3.VirtualMachineServiceList)
+	src := srcRaw.(*vmopv1.VirtualMachineServiceList)
 	return Convert_v1alpha3_VirtualMachineServiceList_To_v1alpha1_VirtualMachineServiceList(src, dst, nil)
 }
@@ -4,6 +4,7 @@
 package v1alpha3
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
@@ -272,7 +273,49 @@ type VirtualMachineNetworkSpec struct {
 	//
 	// The maximum number of network interface allowed is 10 because a vSphere
 	// virtual machine may not have more than 10 virtual ethernet card devices.
+	//
+	// Interfaces may be added to or removed from an existing VM, including
+	// a powered on VM, when the cluster supports network interface hot-plug.
+	// The network of an existing interface may not be changed.
 	Interfaces []VirtualMachineNetworkInterfaceSpec `json:"interfaces,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +listMapKey=protocol
+
+	// Ports is the list of named ports exposed by the guest.
+	//
+	// A named port may be referenced by name from the TargetPort of a
+	// VirtualMachineService port or from the Port of a readiness probe. This
+	// allows VMs deployed from different images to expose the same service on
+	// different port numbers. A name is resolved together with the protocol of
+	// the referencing port, so the same name may be used for a TCP and a UDP
+	// port.
+	Ports []VirtualMachineNetworkPort `json:"ports,omitempty"`
+}
+
+// VirtualMachineNetworkPort describes a named port exposed by the guest.
+type VirtualMachineNetworkPort struct {
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +kubebuilder:validation:MaxLength=15
+
+	// Name is the name of the port. It must be an IANA_SVC_NAME and, together
+	// with Protocol, unique within the VM.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+
+	// Port is the number of the port in the guest.
+	Port int32 `json:"port"`
+
+	// +optional
+	// +kubebuilder:default=TCP
+	// +kubebuilder:validation:Enum=TCP;UDP;SCTP
+
+	// Protocol is the protocol of the port and defaults to TCP.
+	Protocol corev1.Protocol `json:"protocol,omitempty"`
 }
 
 // VirtualMachineNetworkDNSStatus describes the observed state of the guest's
@@ -20,6 +20,11 @@ type VirtualMachineReadinessProbeSpec struct {
 
 	// +optional
 
+	// HTTPGet specifies an action involving an HTTP GET request.
+	HTTPGet *HTTPGetAction `json:"httpGet,omitempty"`
+
+	// +optional
+
 	// GuestHeartbeat specifies an action involving the guest heartbeat status.
 	GuestHeartbeat *GuestHeartbeatAction `json:"guestHeartbeat,omitempty"`
 
@@ -65,6 +70,94 @@ type VirtualMachineReadinessProbeSpec struct {
 	// PeriodSeconds specifics how often (in seconds) to perform the probe.
 	// Defaults to 10 seconds. Minimum value is 1.
 	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+
+	// InitialDelaySeconds specifies the number of seconds after the VM is
+	// powered on or restarted before the probe is started.
+	// Defaults to 0 seconds. Minimum value is 0.
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+
+	// SuccessThreshold specifies the number of consecutive times the probe
+	// must succeed for the VM to be considered ready after having failed.
+	// Defaults to 1. Minimum value is 1.
+	SuccessThreshold int32 `json:"successThreshold,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+
+	// FailureThreshold specifies the number of consecutive times the probe
+	// must fail for the VM to be considered not ready after having succeeded.
+	// Defaults to 1, so a VM is considered not ready after a single failure,
+	// as it was before this field was added. Minimum value is 1.
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// VirtualMachineLivenessProbeSpec describes a probe used to determine if a VM
+// is alive. The VM is restarted after the probe fails FailureThreshold
+// consecutive times. All probe actions are mutually exclusive.
+type VirtualMachineLivenessProbeSpec struct {
+	// +optional
+
+	// TCPSocket specifies an action involving a TCP port.
+	TCPSocket *TCPSocketAction `json:"tcpSocket,omitempty"`
+
+	// +optional
+
+	// HTTPGet specifies an action involving an HTTP GET request.
+	HTTPGet *HTTPGetAction `json:"httpGet,omitempty"`
+
+	// +optional
+
+	// GuestHeartbeat specifies an action involving the guest heartbeat status.
+	GuestHeartbeat *GuestHeartbeatAction `json:"guestHeartbeat,omitempty"`
+
+	// +optional
+
+	// GuestInfo specifies an action involving key/value pairs from GuestInfo.
+	//
+	// The elements are evaluated with the logical AND operator, meaning
+	// all expressions must evaluate as true for the probe to succeed.
+	//
+	// Please refer to VirtualMachineReadinessProbeSpec.GuestInfo for more
+	// information.
+	GuestInfo []GuestInfoAction `json:"guestInfo,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=60
+
+	// TimeoutSeconds specifies a number of seconds after which the probe times out.
+	// Defaults to 10 seconds. Minimum value is 1.
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+
+	// PeriodSeconds specifics how often (in seconds) to perform the probe.
+	// Defaults to 10 seconds. Minimum value is 1.
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+
+	// InitialDelaySeconds specifies the number of seconds after the VM is
+	// powered on or restarted before the probe is started, giving the guest
+	// time to boot before a failure may restart the VM.
+	// Defaults to 0 seconds. Minimum value is 0.
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+
+	// FailureThreshold specifies the number of consecutive times the probe
+	// must fail before the VM is restarted.
+	// Defaults to 3. Minimum value is 1.
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
 }
 
 // TCPSocketAction describes an action based on opening a socket.
@@ -80,6 +173,83 @@ type TCPSocketAction struct {
 	Host string `json:"host,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=HTTP;HTTPS
+
+// URIScheme identifies the scheme used for connection to a host for Get
+// actions.
+type URIScheme string
+
+const (
+	// URISchemeHTTP means that the scheme used will be http://.
+	URISchemeHTTP URIScheme = "HTTP"
+	// URISchemeHTTPS means that the scheme used will be https://.
+	URISchemeHTTPS URIScheme = "HTTPS"
+)
+
+// HTTPHeader describes a custom header to be used in HTTP probes.
+type HTTPHeader struct {
+	// Name is the header field name.
+	// This will be canonicalized upon output, so case-variant names will be
+	// understood as the same header.
+	Name string `json:"name"`
+
+	// Value is the header field value.
+	Value string `json:"value"`
+}
+
+// HTTPStatusRange describes an inclusive range of HTTP status codes.
+type HTTPStatusRange struct {
+	// +kubebuilder:validation:Minimum:=100
+	// +kubebuilder:validation:Maximum:=599
+
+	// Min is the lowest status code in the range.
+	Min int32 `json:"min"`
+
+	// +kubebuilder:validation:Minimum:=100
+	// +kubebuilder:validation:Maximum:=599
+
+	// Max is the highest status code in the range.
+	Max int32 `json:"max"`
+}
+
+// HTTPGetAction describes an action based on HTTP GET requests.
+type HTTPGetAction struct {
+	// +optional
+
+	// Path is the path to access on the HTTP server. Defaults to "/".
+	Path string `json:"path,omitempty"`
+
+	// Port specifies the number or name of the port to access on the VM.
+	// A number must be in the range 1 to 65535. A name must be an IANA_SVC_NAME
+	// and is resolved from the ports of the VM's network interfaces.
+	Port intstr.IntOrString `json:"port"`
+
+	// +optional
+
+	// Host is an optional host name to connect to. Host defaults to the VM IP.
+	Host string `json:"host,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=HTTP
+
+	// Scheme is the scheme used to connect to the host. Defaults to HTTP.
+	//
+	// Please note that when HTTPS is used the server's certificate is not
+	// verified.
+	Scheme URIScheme `json:"scheme,omitempty"`
+
+	// +optional
+
+	// HTTPHeaders are the custom headers to set in the request.
+	HTTPHeaders []HTTPHeader `json:"httpHeaders,omitempty"`
+
+	// +optional
+
+	// ExpectedStatus is the range of HTTP status codes that indicate the
+	// probe succeeded. Defaults to 200-399 when omitted.
+	ExpectedStatus *HTTPStatusRange `json:"expectedStatus,omitempty"`
+}
+
 // GuestHeartbeatStatus is the guest heartbeat status.
 type GuestHeartbeatStatus string
 
@@ -182,4 +182,12 @@ type VirtualMachineStorageStatus struct {
 	// Unshared is the total storage space occupied by this VirtualMachine that
 	// is not shared with any other VirtualMachine.
 	Unshared *resource.Quantity `json:"unshared,omitempty"`
+
+	// +optional
+
+	// StorageClass is the name of the storage class whose storage policy is
+	// applied to the VirtualMachine's home and classic disks. This differs
+	// from spec.storageClass while the VM's storage is being relocated to a
+	// new storage class.
+	StorageClass string `json:"storageClass,omitempty"`
 }
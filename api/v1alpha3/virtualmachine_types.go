@@ -141,6 +141,12 @@ const (
 	// be removed once set until the VM is deleted.
 	FirstBootDoneAnnotation = "virtualmachine." + GroupName + "/first-boot-done"
 
+	// LastRevertedSnapshotAnnotation is an annotation that records the name
+	// of the snapshot in spec.currentSnapshot that the VM was last reverted
+	// to, so the VM is only reverted once for each change of
+	// spec.currentSnapshot. This annotation is managed by VM Operator.
+	LastRevertedSnapshotAnnotation = GroupName + "/last-reverted-snapshot"
+
 	// V1alpha1ConfigMapTransportAnnotation is an annotation that indicates that the VM
 	// was created with the v1alpha1 API and specifies a configMap as the metadata transport resource type.
 	V1alpha1ConfigMapTransportAnnotation = GroupName + "/v1a1-configmap-md-transport"
@@ -158,6 +164,57 @@ const (
 	ManagedByExtensionType = "VirtualMachine"
 )
 
+const (
+	// VirtualMachineRelocatedCondition exposes the status of relocating the
+	// VirtualMachine to a different zone, cluster, or host. The condition is
+	// only present once a relocation has been needed.
+	VirtualMachineRelocatedCondition = "VirtualMachineRelocated"
+
+	// VirtualMachineRelocatePlacementFailedReason documents that no placement
+	// could be found for the relocated VirtualMachine.
+	VirtualMachineRelocatePlacementFailedReason = "PlacementFailed"
+
+	// VirtualMachineRelocateFailedReason documents that the relocate operation
+	// failed.
+	VirtualMachineRelocateFailedReason = "RelocateFailed"
+)
+
+const (
+	// VirtualMachineStorageRelocatedCondition exposes the status of moving
+	// the VirtualMachine's home and classic disks to the storage policy of a
+	// new storage class. The condition is only present once the VM's storage
+	// class has been changed.
+	VirtualMachineStorageRelocatedCondition = "VirtualMachineStorageRelocated"
+
+	// VirtualMachineStorageRelocatePlacementFailedReason documents that no
+	// datastore compatible with the new storage class's storage policy could
+	// be found.
+	VirtualMachineStorageRelocatePlacementFailedReason = "StoragePlacementFailed"
+
+	// VirtualMachineStorageRelocateFailedReason documents that the storage
+	// relocate operation failed.
+	VirtualMachineStorageRelocateFailedReason = "StorageRelocateFailed"
+)
+
+const (
+	// VirtualMachineRebuiltCondition exposes the status of rebuilding the
+	// VirtualMachine's boot disk from a new VirtualMachineImage. The condition
+	// is only present once the VM's image has been changed.
+	VirtualMachineRebuiltCondition = "VirtualMachineRebuilt"
+
+	// VirtualMachineRebuildInProgressReason documents that the VirtualMachine
+	// is being rebuilt from a new image.
+	VirtualMachineRebuildInProgressReason = "RebuildInProgress"
+
+	// VirtualMachineRebuildImageNotSupportedReason documents that the new
+	// image cannot be used to rebuild the VirtualMachine.
+	VirtualMachineRebuildImageNotSupportedReason = "ImageNotSupported"
+
+	// VirtualMachineRebuildFailedReason documents that the rebuild operation
+	// failed.
+	VirtualMachineRebuildFailedReason = "RebuildFailed"
+)
+
 const (
 	// VirtualMachineBackupUpToDateCondition exposes the status of the latest VirtualMachine Backup, when available.
 	VirtualMachineBackupUpToDateCondition = "VirtualMachineBackupUpToDate"
@@ -421,6 +478,17 @@ type VirtualMachineSpec struct {
 	// deployed by VM Operator. An imported VirtualMachine resource references
 	// an existing VM on the underlying platform that was not deployed from a
 	// VM image.
+	//
+	// Changing the image of an existing VM rebuilds the VM in place: the VM's
+	// boot disk is replaced with the boot disk of the new image, while the
+	// VM's BIOS and instance UUIDs, network interfaces and PVC volumes are
+	// preserved, and the bootstrap provider is run again when the VM is next
+	// powered on. A VM that is powered on is powered off to be rebuilt. The
+	// VM's status.image field and VirtualMachineRebuilt condition reflect the
+	// progress of the rebuild, and a failed rebuild is retried after a delay.
+	// Please note that a VM with instance storage, or with classic disks that
+	// are not part of its image, may not be rebuilt, and that the image may
+	// not be changed until the VM reports its deployed image in status.image.
 	Image *VirtualMachineImageRef `json:"image,omitempty"`
 
 	// +optional
@@ -475,6 +543,13 @@ type VirtualMachineSpec struct {
 	// StorageClass describes the name of a Kubernetes StorageClass resource
 	// used to configure this VM's storage-related attributes.
 	//
+	// Changing the storage class of an existing VM relocates the VM's home
+	// and classic disks to storage that is compatible with the new storage
+	// class's storage policy. The VM's status.storage.storageClass field and
+	// VirtualMachineStorageRelocated condition reflect the progress of the
+	// relocation. Please note that the storage class of a VM with instance
+	// storage may not be changed.
+	//
 	// Please see https://kubernetes.io/docs/concepts/storage/storage-classes/
 	// for more information on Kubernetes storage classes.
 	StorageClass string `json:"storageClass,omitempty"`
@@ -575,6 +650,23 @@ type VirtualMachineSpec struct {
 	// If omitted, the mode defaults to TrySoft.
 	RestartMode VirtualMachinePowerOpMode `json:"restartMode,omitempty"`
 
+	// +optional
+
+	// NextRelocateTime may be used to relocate the VM to a different host or
+	// cluster in the VM's zone by setting the value of this field to "now"
+	// (case-insensitive).
+	//
+	// A mutating webhook changes this value to the current time (UTC), which
+	// the VM controller then uses to determine the VM should be relocated by
+	// comparing the value to the timestamp of the last time the VM was
+	// relocated.
+	//
+	// Please note that a VM is relocated to a different zone by changing the
+	// value of the VM's topology.kubernetes.io/zone label, and it is not
+	// possible to schedule future relocations using this field. The only value
+	// that users may set is the string "now" (case-insensitive).
+	NextRelocateTime string `json:"nextRelocateTime,omitempty"`
+
 	// +optional
 	// +listType=map
 	// +listMapKey=name
@@ -589,6 +681,13 @@ type VirtualMachineSpec struct {
 
 	// +optional
 
+	// LivenessProbe describes a probe used to determine if the VM is alive.
+	// The VM is restarted, in accordance with RestartMode, when the probe
+	// fails.
+	LivenessProbe *VirtualMachineLivenessProbeSpec `json:"livenessProbe,omitempty"`
+
+	// +optional
+
 	// Advanced describes a set of optional, advanced VM configuration options.
 	Advanced *VirtualMachineAdvancedSpec `json:"advanced,omitempty"`
 
@@ -684,6 +783,25 @@ type VirtualMachineSpec struct {
 	//
 	// This field is required when the VM has any CD-ROM devices attached.
 	GuestID string `json:"guestID,omitempty"`
+
+	// +optional
+
+	// CurrentSnapshot represents the snapshot that the VM should be
+	// reverted to.
+	//
+	// When this field is set to a VirtualMachineSnapshot resource that is
+	// different from the snapshot described by status.currentSnapshot, the
+	// VM is reverted to the state captured by that snapshot. The snapshot
+	// must be ready and must belong to this VM.
+	//
+	// The VM is reverted once each time this field is changed. Snapshots taken
+	// after the revert do not cause the VM to be reverted again. To revert
+	// the VM to the same snapshot again, clear this field and then set it.
+	//
+	// Please note that reverting a VM to a snapshot discards the VM's current
+	// state. The VM's power state after the revert matches the power state
+	// the VM had when the snapshot was taken.
+	CurrentSnapshot *vmopv1common.LocalObjectRef `json:"currentSnapshot,omitempty"`
 }
 
 // VirtualMachineReservedSpec describes a set of VM configuration options
@@ -804,6 +922,16 @@ type VirtualMachineStatus struct {
 
 	// +optional
 
+	// Image is a reference to the VirtualMachineImage or
+	// ClusterVirtualMachineImage resource from which the VM's boot disk was
+	// deployed.
+	//
+	// This field differs from spec.image while the VM is being rebuilt from a
+	// new image.
+	Image *vmopv1common.LocalObjectRef `json:"image,omitempty"`
+
+	// +optional
+
 	// UniqueID describes a unique identifier that is provided by the underlying
 	// infrastructure provider, such as vSphere.
 	UniqueID string `json:"uniqueID,omitempty"`
@@ -851,6 +979,12 @@ type VirtualMachineStatus struct {
 
 	// +optional
 
+	// RestartCount describes the number of times the VM has been restarted
+	// because its liveness probe failed.
+	RestartCount int32 `json:"restartCount,omitempty"`
+
+	// +optional
+
 	// HardwareVersion describes the VirtualMachine resource's observed
 	// hardware version.
 	//
@@ -862,6 +996,21 @@ type VirtualMachineStatus struct {
 
 	// Storage describes the observed state of the VirtualMachine's storage.
 	Storage *VirtualMachineStorageStatus `json:"storage,omitempty"`
+
+	// +optional
+
+	// CurrentSnapshot describes the observed working snapshot of the
+	// VirtualMachine, i.e. the snapshot from which the VM's current state is
+	// derived.
+	CurrentSnapshot *vmopv1common.LocalObjectRef `json:"currentSnapshot,omitempty"`
+
+	// +optional
+
+	// RootSnapshots describes the observed root snapshots of the
+	// VirtualMachine's snapshot tree. The children of each snapshot are
+	// described by the status.children field of the referenced
+	// VirtualMachineSnapshot resource.
+	RootSnapshots []vmopv1common.LocalObjectRef `json:"rootSnapshots,omitempty"`
 }
 
 // +kubebuilder:object:root=true
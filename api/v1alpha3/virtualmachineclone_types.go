@@ -0,0 +1,212 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachineCloneConditionSourceValid is the Type for a
+	// VirtualMachineClone resource's status condition.
+	//
+	// The condition's status is set to true only when the source
+	// VirtualMachine exists, has been created on the underlying
+	// infrastructure, and can be cloned.
+	VirtualMachineCloneConditionSourceValid = "SourceValid"
+
+	// VirtualMachineCloneConditionTargetValid is the Type for a
+	// VirtualMachineClone resource's status condition.
+	//
+	// The condition's status is set to true only when the target
+	// VirtualMachine does not exist or was created by this clone.
+	VirtualMachineCloneConditionTargetValid = "TargetValid"
+
+	// VirtualMachineCloneConditionComplete is the Type for a
+	// VirtualMachineClone resource's status condition.
+	//
+	// The condition's status is set to true only when the target
+	// VirtualMachine has been created on the underlying infrastructure.
+	VirtualMachineCloneConditionComplete = "Complete"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineClone.
+const (
+	// SourceVirtualMachineNotSupportedReason documents that the source VM of
+	// the VirtualMachineClone cannot be cloned, for example because it uses
+	// instance storage.
+	SourceVirtualMachineNotSupportedReason = "SourceVirtualMachineNotSupported"
+
+	// TargetVirtualMachineAlreadyExistsReason documents that a VM with the
+	// same name as the VirtualMachineClone's target already exists and was
+	// not created by the VirtualMachineClone.
+	TargetVirtualMachineAlreadyExistsReason = "TargetVirtualMachineAlreadyExists"
+
+	// TargetVirtualMachineNotCreatedReason documents that the target VM of
+	// the VirtualMachineClone has not yet been created on the underlying
+	// infrastructure.
+	TargetVirtualMachineNotCreatedReason = "TargetVirtualMachineNotCreated"
+)
+
+const (
+	// CloneSourceAnnotation is an annotation set on a VirtualMachine created
+	// by a VirtualMachineClone. Its value is the name of the source
+	// VirtualMachine, which must be in the same namespace.
+	//
+	// This annotation cannot be set by users.
+	CloneSourceAnnotation = GroupName + "/clone-source"
+
+	// CloneTypeAnnotation is an annotation set on a VirtualMachine created by
+	// a VirtualMachineClone. Its value is the VirtualMachineCloneType used to
+	// create the VM.
+	//
+	// This annotation cannot be set by users.
+	CloneTypeAnnotation = GroupName + "/clone-type"
+)
+
+// VirtualMachineCloneType describes how the disks of the source
+// VirtualMachine are cloned.
+type VirtualMachineCloneType string
+
+const (
+	// VirtualMachineCloneTypeFull indicates the clone's disks are full copies
+	// of the source VM's disks.
+	VirtualMachineCloneTypeFull VirtualMachineCloneType = "Full"
+
+	// VirtualMachineCloneTypeLinked indicates the clone's disks are delta
+	// disks backed by the source VM's current snapshot. The source VM must
+	// have a snapshot.
+	VirtualMachineCloneTypeLinked VirtualMachineCloneType = "Linked"
+)
+
+// VirtualMachineCloneSource is the source of a VirtualMachineClone.
+type VirtualMachineCloneSource struct {
+	// Name is the name of the VirtualMachine to clone. The VirtualMachine must
+	// be in the same namespace as the VirtualMachineClone.
+	Name string `json:"name"`
+}
+
+// VirtualMachineCloneTarget is the target of a VirtualMachineClone.
+type VirtualMachineCloneTarget struct {
+	// +optional
+
+	// Name is the name of the VirtualMachine created by the clone.
+	//
+	// If omitted this value defaults to the name of the VirtualMachineClone
+	// resource.
+	Name string `json:"name,omitempty"`
+}
+
+// VirtualMachineCloneSpec defines the desired state of a VirtualMachineClone.
+type VirtualMachineCloneSpec struct {
+	// Source is the VirtualMachine to clone.
+	Source VirtualMachineCloneSource `json:"source"`
+
+	// +optional
+
+	// Target describes the VirtualMachine created by the clone.
+	Target VirtualMachineCloneTarget `json:"target,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=Full
+	// +kubebuilder:validation:Enum=Full;Linked
+
+	// Type describes how the disks of the source VirtualMachine are cloned.
+	//
+	// Please note, a Linked clone requires the source VM to have a snapshot.
+	// Only the source VM's classic disks are cloned. Volumes backed by
+	// PersistentVolumeClaims are never copied to the clone.
+	Type VirtualMachineCloneType `json:"type,omitempty"`
+
+	// +optional
+
+	// PowerState is the desired power state of the clone.
+	//
+	// If omitted this value defaults to the power state of the source
+	// VirtualMachine's spec.
+	PowerState VirtualMachinePowerState `json:"powerState,omitempty"`
+
+	// +optional
+
+	// Bootstrap, when specified, replaces the source VirtualMachine's
+	// bootstrap configuration on the clone, and the guest is re-customized
+	// when the clone is powered on.
+	//
+	// If omitted the clone uses the source VirtualMachine's bootstrap
+	// configuration. For Cloud-Init the source's instance ID is retained so
+	// the clone's guest is not re-customized.
+	Bootstrap *VirtualMachineBootstrapSpec `json:"bootstrap,omitempty"`
+}
+
+// VirtualMachineCloneStatus defines the observed state of a
+// VirtualMachineClone.
+type VirtualMachineCloneStatus struct {
+	// +optional
+
+	// TargetRef is the name of the VirtualMachine created by the clone.
+	TargetRef string `json:"targetRef,omitempty"`
+
+	// +optional
+
+	// StartTime represents the time when the clone was first reconciled.
+	StartTime metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+
+	// CompletionTime represents the time when the clone was completed.
+	CompletionTime metav1.Time `json:"completionTime,omitempty"`
+
+	// +optional
+
+	// Ready is set to true only when the clone has completed successfully.
+	Ready bool `json:"ready,omitempty"`
+
+	// +optional
+
+	// Conditions is a list of the latest, available observations of the
+	// clone's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (c *VirtualMachineClone) GetConditions() []metav1.Condition {
+	return c.Status.Conditions
+}
+
+func (c *VirtualMachineClone) SetConditions(conditions []metav1.Condition) {
+	c.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmclone
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.source.name"
+// +kubebuilder:printcolumn:name="Target",type="string",JSONPath=".status.targetRef"
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+// +kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineClone is the schema for the virtualmachineclones API and
+// creates a new VirtualMachine as a full or linked clone of an existing
+// VirtualMachine in the same namespace.
+type VirtualMachineClone struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineCloneSpec   `json:"spec,omitempty"`
+	Status VirtualMachineCloneStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineCloneList contains a list of VirtualMachineClone.
+type VirtualMachineCloneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineClone `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &VirtualMachineClone{}, &VirtualMachineCloneList{})
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// VirtualMachineDeploymentAvailableCondition documents that the
+	// VirtualMachineDeployment has at least the minimum number of ready
+	// replicas required by its rollout strategy.
+	VirtualMachineDeploymentAvailableCondition = "Available"
+
+	// VirtualMachineDeploymentRolledOutCondition documents that all of the
+	// replicas of the VirtualMachineDeployment have been created from its
+	// current template and are ready, and that no replicas remain from older
+	// revisions.
+	VirtualMachineDeploymentRolledOutCondition = "RolledOut"
+
+	// MinimumReplicasUnavailableReason documents a VirtualMachineDeployment
+	// that does not have the minimum number of ready replicas.
+	MinimumReplicasUnavailableReason = "MinimumReplicasUnavailable"
+
+	// RollingOutReason documents a VirtualMachineDeployment that is replacing
+	// replicas from older revisions with replicas from its current template.
+	RollingOutReason = "RollingOut"
+
+	// DeploymentPausedReason documents a VirtualMachineDeployment whose
+	// rollout is paused.
+	DeploymentPausedReason = "DeploymentPaused"
+)
+
+const (
+	// VirtualMachineDeploymentNameLabel is the key of the label applied to the
+	// VirtualMachineReplicaSets owned by a VirtualMachineDeployment, and to
+	// the replicas of those VirtualMachineReplicaSets. The value of this label
+	// is the name of the VirtualMachineDeployment.
+	VirtualMachineDeploymentNameLabel = "vmoperator.vmware.com/deployment-name"
+
+	// VirtualMachineTemplateHashLabel is the key of the label applied to the
+	// VirtualMachineReplicaSets owned by a VirtualMachineDeployment, and to
+	// the replicas of those VirtualMachineReplicaSets. The value of this label
+	// is a hash of the template the VirtualMachineReplicaSet was created from
+	// and ensures the selectors of the VirtualMachineReplicaSets do not
+	// overlap.
+	VirtualMachineTemplateHashLabel = "vmoperator.vmware.com/template-hash"
+
+	// VirtualMachineDeploymentRevisionAnnotation is the key of the annotation
+	// applied to the VirtualMachineReplicaSets owned by a
+	// VirtualMachineDeployment. The value of this annotation is the revision
+	// of the VirtualMachineDeployment the VirtualMachineReplicaSet represents.
+	VirtualMachineDeploymentRevisionAnnotation = "vmoperator.vmware.com/deployment-revision"
+)
+
+// VirtualMachineDeploymentStrategyType describes how existing replicas are
+// replaced with new ones.
+//
+// +kubebuilder:validation:Enum=Recreate;RollingUpdate
+type VirtualMachineDeploymentStrategyType string
+
+const (
+	// RecreateVirtualMachineDeploymentStrategyType deletes all of the existing
+	// replicas before creating new ones.
+	RecreateVirtualMachineDeploymentStrategyType VirtualMachineDeploymentStrategyType = "Recreate"
+
+	// RollingUpdateVirtualMachineDeploymentStrategyType gradually replaces the
+	// existing replicas with new ones.
+	RollingUpdateVirtualMachineDeploymentStrategyType VirtualMachineDeploymentStrategyType = "RollingUpdate"
+)
+
+// RollingUpdateVirtualMachineDeployment controls the rate at which replicas
+// are replaced during a rolling update.
+type RollingUpdateVirtualMachineDeployment struct {
+	// +optional
+
+	// MaxUnavailable is the maximum number of replicas that may be unavailable
+	// during the update. The value may be an absolute number (ex. 5) or a
+	// percentage of the desired replicas (ex. 10%). An absolute number is
+	// calculated from a percentage by rounding down. This may not be zero if
+	// MaxSurge is zero.
+	//
+	// Defaults to 25%.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// +optional
+
+	// MaxSurge is the maximum number of replicas that may be created above the
+	// desired number of replicas during the update. The value may be an
+	// absolute number (ex. 5) or a percentage of the desired replicas
+	// (ex. 10%). An absolute number is calculated from a percentage by
+	// rounding up. This may not be zero if MaxUnavailable is zero.
+	//
+	// Defaults to 25%.
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+}
+
+// VirtualMachineDeploymentStrategy describes how to replace existing replicas
+// with new ones.
+type VirtualMachineDeploymentStrategy struct {
+	// +optional
+	// +kubebuilder:default=RollingUpdate
+
+	// Type of the deployment strategy. Can be "Recreate" or "RollingUpdate".
+	//
+	// Defaults to RollingUpdate.
+	Type VirtualMachineDeploymentStrategyType `json:"type,omitempty"`
+
+	// +optional
+
+	// RollingUpdate contains the parameters used when Type is RollingUpdate.
+	RollingUpdate *RollingUpdateVirtualMachineDeployment `json:"rollingUpdate,omitempty"`
+}
+
+// VirtualMachineDeploymentRollbackConfig describes the revision to which a
+// VirtualMachineDeployment is rolled back.
+type VirtualMachineDeploymentRollbackConfig struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+
+	// Revision is the revision to roll back to. If set to 0, the
+	// VirtualMachineDeployment is rolled back to the revision prior to the
+	// current one.
+	Revision int64 `json:"revision,omitempty"`
+}
+
+// VirtualMachineDeploymentSpec is the specification of a
+// VirtualMachineDeployment.
+type VirtualMachineDeploymentSpec struct {
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+
+	// Replicas is the number of desired replicas.
+	// This is a pointer to distinguish between explicit zero and unspecified.
+	// Defaults to 1.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Selector is a label query over the virtual machines that are managed by
+	// this VirtualMachineDeployment.
+	//
+	// It must match the VirtualMachine template's labels.
+	Selector *metav1.LabelSelector `json:"selector"`
+
+	// Template is the object that describes the virtual machines that will be
+	// created by this VirtualMachineDeployment. A change to the template
+	// results in a new revision of the VirtualMachineDeployment.
+	Template VirtualMachineTemplateSpec `json:"template"`
+
+	// +optional
+
+	// Strategy describes how existing replicas are replaced with new ones
+	// when the template changes.
+	Strategy VirtualMachineDeploymentStrategy `json:"strategy,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+
+	// RevisionHistoryLimit is the number of old VirtualMachineReplicaSets to
+	// retain in order to allow a rollback. Old VirtualMachineReplicaSets are
+	// only removed once they have been scaled down to zero replicas.
+	// Defaults to 10.
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// +optional
+
+	// Paused indicates that the rollout of template changes is paused. Changes
+	// to the template made while the VirtualMachineDeployment is paused are
+	// not rolled out until it is resumed.
+	Paused bool `json:"paused,omitempty"`
+
+	// +optional
+
+	// RollbackTo is the revision to which the VirtualMachineDeployment is
+	// rolled back. The template of the VirtualMachineDeployment is replaced
+	// with the template of the requested revision and this field is cleared
+	// once the rollback has been processed.
+	RollbackTo *VirtualMachineDeploymentRollbackConfig `json:"rollbackTo,omitempty"`
+}
+
+// VirtualMachineDeploymentStatus represents the observed state of a
+// VirtualMachineDeployment resource.
+type VirtualMachineDeploymentStatus struct {
+	// +optional
+
+	// ObservedGeneration reflects the generation of the most recently observed
+	// VirtualMachineDeployment.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+
+	// Revision is the revision of the VirtualMachineDeployment's current
+	// template.
+	Revision int64 `json:"revision,omitempty"`
+
+	// +optional
+
+	// Replicas is the total number of replicas targeted by this
+	// VirtualMachineDeployment.
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// +optional
+
+	// UpdatedReplicas is the total number of replicas targeted by this
+	// VirtualMachineDeployment that have the desired template.
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
+	// +optional
+
+	// ReadyReplicas is the total number of replicas targeted by this
+	// VirtualMachineDeployment that are ready. A virtual machine is
+	// considered ready when its "Ready" condition is marked as true.
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// +optional
+
+	// UnavailableReplicas is the number of desired replicas that are not
+	// ready.
+	UnavailableReplicas int32 `json:"unavailableReplicas,omitempty"`
+
+	// +optional
+
+	// Conditions represents the latest available observations of a
+	// VirtualMachineDeployment's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (d *VirtualMachineDeployment) GetConditions() []metav1.Condition {
+	return d.Status.Conditions
+}
+
+func (d *VirtualMachineDeployment) SetConditions(conditions []metav1.Condition) {
+	d.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmdeploy;vmdeployment
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas",description="Total number of non-terminated virtual machines targeted by this VirtualMachineDeployment"
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyReplicas",description="Total number of ready virtual machines targeted by this VirtualMachineDeployment"
+// +kubebuilder:printcolumn:name="Updated",type="integer",JSONPath=".status.updatedReplicas",description="Total number of virtual machines targeted by this VirtualMachineDeployment that have the desired template"
+// +kubebuilder:printcolumn:name="Revision",type="integer",JSONPath=".status.revision",description="Current revision of this VirtualMachineDeployment"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of VirtualMachineDeployment"
+
+// VirtualMachineDeployment is the schema for the virtualmachinedeployments API
+// and manages the rollout of VirtualMachineReplicaSets.
+type VirtualMachineDeployment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineDeploymentSpec   `json:"spec,omitempty"`
+	Status VirtualMachineDeploymentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineDeploymentList contains a list of VirtualMachineDeployment.
+type VirtualMachineDeploymentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineDeployment `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &VirtualMachineDeployment{}, &VirtualMachineDeploymentList{})
+}
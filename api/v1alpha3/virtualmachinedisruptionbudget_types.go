@@ -0,0 +1,136 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// VirtualMachineDisruptionAllowedCondition documents that at least one of
+	// the virtual machines selected by a VirtualMachineDisruptionBudget may be
+	// disrupted without violating the budget.
+	VirtualMachineDisruptionAllowedCondition = "DisruptionAllowed"
+
+	// InsufficientHealthyVirtualMachinesReason documents a
+	// VirtualMachineDisruptionBudget that does not allow any disruptions
+	// because too few of the selected virtual machines are healthy.
+	InsufficientHealthyVirtualMachinesReason = "InsufficientHealthyVirtualMachines"
+
+	// InvalidDisruptionBudgetReason documents a VirtualMachineDisruptionBudget
+	// whose spec cannot be evaluated.
+	InvalidDisruptionBudgetReason = "InvalidDisruptionBudget"
+)
+
+// VirtualMachineDisruptionBudgetSpec is the specification of a
+// VirtualMachineDisruptionBudget.
+type VirtualMachineDisruptionBudgetSpec struct {
+	// Selector is a label query over the virtual machines protected by this
+	// budget.
+	Selector *metav1.LabelSelector `json:"selector"`
+
+	// +optional
+	// +kubebuilder:validation:XIntOrString
+
+	// MinAvailable is the number of the selected virtual machines that must
+	// remain healthy after a disruption. It may be an absolute number or a
+	// percentage of the selected virtual machines, rounded up.
+	//
+	// Only one of MinAvailable and MaxUnavailable may be specified.
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:XIntOrString
+
+	// MaxUnavailable is the number of the selected virtual machines that may
+	// be unhealthy after a disruption. It may be an absolute number or a
+	// percentage of the selected virtual machines, rounded up.
+	//
+	// Only one of MinAvailable and MaxUnavailable may be specified.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// VirtualMachineDisruptionBudgetStatus represents the observed state of a
+// VirtualMachineDisruptionBudget resource.
+type VirtualMachineDisruptionBudgetStatus struct {
+	// +optional
+
+	// ObservedGeneration reflects the generation of the most recently observed
+	// VirtualMachineDisruptionBudget.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+
+	// ExpectedVMs is the number of virtual machines selected by this budget
+	// that are not being deleted.
+	ExpectedVMs int32 `json:"expectedVMs,omitempty"`
+
+	// +optional
+
+	// CurrentHealthy is the number of selected virtual machines that are
+	// healthy. A virtual machine is healthy when it is ready.
+	CurrentHealthy int32 `json:"currentHealthy,omitempty"`
+
+	// +optional
+
+	// DesiredHealthy is the minimum number of selected virtual machines that
+	// must be healthy.
+	DesiredHealthy int32 `json:"desiredHealthy,omitempty"`
+
+	// +optional
+
+	// DisruptionsAllowed is the number of healthy virtual machines that may
+	// currently be disrupted, i.e. deleted, powered off, or suspended.
+	DisruptionsAllowed int32 `json:"disruptionsAllowed,omitempty"`
+
+	// +optional
+
+	// Conditions represents the latest available observations of a
+	// VirtualMachineDisruptionBudget's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (b *VirtualMachineDisruptionBudget) GetConditions() []metav1.Condition {
+	return b.Status.Conditions
+}
+
+func (b *VirtualMachineDisruptionBudget) SetConditions(conditions []metav1.Condition) {
+	b.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmdb
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Min-Available",type="string",JSONPath=".spec.minAvailable"
+// +kubebuilder:printcolumn:name="Max-Unavailable",type="string",JSONPath=".spec.maxUnavailable"
+// +kubebuilder:printcolumn:name="Allowed-Disruptions",type="integer",JSONPath=".status.disruptionsAllowed"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineDisruptionBudget is the schema for the
+// virtualmachinedisruptionbudgets API and limits the number of selected
+// virtual machines that may be deleted, powered off, or suspended at the
+// same time.
+type VirtualMachineDisruptionBudget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineDisruptionBudgetSpec   `json:"spec,omitempty"`
+	Status VirtualMachineDisruptionBudgetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineDisruptionBudgetList contains a list of
+// VirtualMachineDisruptionBudget.
+type VirtualMachineDisruptionBudgetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineDisruptionBudget `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &VirtualMachineDisruptionBudget{}, &VirtualMachineDisruptionBudgetList{})
+}
@@ -0,0 +1,276 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachineExportConditionSourceValid is the Type for a
+	// VirtualMachineExport resource's status condition.
+	//
+	// The condition's status is set to true only when the source
+	// VirtualMachine exists, has been created on the underlying
+	// infrastructure, and is powered off.
+	VirtualMachineExportConditionSourceValid = "SourceValid"
+
+	// VirtualMachineExportConditionTargetValid is the Type for a
+	// VirtualMachineExport resource's status condition.
+	//
+	// The condition's status is set to true only when the target of the
+	// export exists and the server that receives the exported files is
+	// ready.
+	VirtualMachineExportConditionTargetValid = "TargetValid"
+
+	// VirtualMachineExportConditionExported is the Type for a
+	// VirtualMachineExport resource's status condition.
+	//
+	// The condition's status is set to true only when the VM's OVF descriptor
+	// and disks have been written to the target.
+	VirtualMachineExportConditionExported = "Exported"
+
+	// VirtualMachineExportConditionComplete is the Type for a
+	// VirtualMachineExport resource's status condition.
+	//
+	// The condition's status is set to true only when all other conditions
+	// present on the resource have a truthy status.
+	VirtualMachineExportConditionComplete = "Complete"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineExport.
+const (
+	// SourceVirtualMachinePoweredOnReason documents that the source VM of the
+	// VirtualMachineExport is not powered off.
+	SourceVirtualMachinePoweredOnReason = "SourceVirtualMachinePoweredOn"
+
+	// TargetPersistentVolumeClaimNotExistReason documents that the target
+	// PersistentVolumeClaim of the VirtualMachineExport does not exist.
+	TargetPersistentVolumeClaimNotExistReason = "TargetPersistentVolumeClaimNotExist"
+
+	// TargetNotSupportedReason documents that the target of the
+	// VirtualMachineExport is not supported by this installation of VM
+	// Operator.
+	TargetNotSupportedReason = "TargetNotSupported"
+
+	// TargetServerNotReadyReason documents that the server that receives the
+	// exported files is not yet ready.
+	TargetServerNotReadyReason = "TargetServerNotReady"
+
+	// ExportingReason documents that the VM is being exported.
+	ExportingReason = "Exporting"
+
+	// ExportFailureReason documents that exporting the VM failed.
+	ExportFailureReason = "ExportFailure"
+
+	// DownloadExpiredReason documents that the download URL of the
+	// VirtualMachineExport has expired and the exported files were deleted.
+	DownloadExpiredReason = "DownloadExpired"
+)
+
+const (
+	// VirtualMachineExportTokenSecretKey is the key in the data of the Secret
+	// referenced by a VirtualMachineExport's status whose value is the bearer
+	// token used to authenticate requests to the download URL.
+	VirtualMachineExportTokenSecretKey = "token"
+)
+
+// VirtualMachineExportSource is the source of a VirtualMachineExport.
+type VirtualMachineExportSource struct {
+	// Name is the name of the VirtualMachine to export. The VirtualMachine
+	// must be in the same namespace as the VirtualMachineExport and powered
+	// off.
+	Name string `json:"name"`
+}
+
+// VirtualMachineExportTargetPersistentVolumeClaim describes a
+// PersistentVolumeClaim to which a VM is exported.
+type VirtualMachineExportTargetPersistentVolumeClaim struct {
+	// ClaimName is the name of the PersistentVolumeClaim. The
+	// PersistentVolumeClaim must be in the same namespace as the
+	// VirtualMachineExport.
+	ClaimName string `json:"claimName"`
+
+	// +optional
+
+	// Path is the directory in the volume to which the VM's files are
+	// written. The path is relative to the root of the volume.
+	//
+	// If omitted the files are written to the root of the volume.
+	Path string `json:"path,omitempty"`
+}
+
+// VirtualMachineExportTargetDownload describes a download URL from which an
+// exported VM may be downloaded.
+type VirtualMachineExportTargetDownload struct {
+	// +optional
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:validation:Maximum=86400
+
+	// TTLSeconds is the number of seconds after the export completes that the
+	// download URL is available. Once the URL expires the exported files are
+	// deleted.
+	TTLSeconds int64 `json:"ttlSeconds,omitempty"`
+}
+
+// VirtualMachineExportTarget is the target of a VirtualMachineExport. Exactly
+// one of the fields must be specified.
+type VirtualMachineExportTarget struct {
+	// +optional
+
+	// PersistentVolumeClaim exports the VM's files to a
+	// PersistentVolumeClaim.
+	PersistentVolumeClaim *VirtualMachineExportTargetPersistentVolumeClaim `json:"persistentVolumeClaim,omitempty"`
+
+	// +optional
+
+	// Download exports the VM's files to a short-lived, authenticated URL
+	// from which they may be downloaded.
+	Download *VirtualMachineExportTargetDownload `json:"download,omitempty"`
+}
+
+// VirtualMachineExportSpec defines the desired state of a
+// VirtualMachineExport.
+type VirtualMachineExportSpec struct {
+	// Source is the VirtualMachine to export.
+	Source VirtualMachineExportSource `json:"source"`
+
+	// Target describes where the VM is exported.
+	Target VirtualMachineExportTarget `json:"target"`
+
+	// +optional
+
+	// TTLSecondsAfterFinished is the time-to-live duration for how long this
+	// resource will be allowed to exist once the export operation
+	// completes. After the TTL expires, the resource will be automatically
+	// deleted without the user having to take any direct action.
+	//
+	// If this field is unset then the export resource will not be
+	// automatically deleted. If this field is set to zero then the export
+	// resource is eligible for deletion immediately after it finishes.
+	TTLSecondsAfterFinished *int64 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// VirtualMachineExportDownloadStatus describes the URL from which an exported
+// VM may be downloaded.
+type VirtualMachineExportDownloadStatus struct {
+	// +optional
+
+	// URL is the base HTTPS URL from which the exported files may be
+	// downloaded. Each file listed in the status is available at the URL
+	// joined with the file's name. The URL is on the address of a
+	// LoadBalancer Service so the files may be downloaded from outside of the
+	// cluster.
+	URL string `json:"url,omitempty"`
+
+	// +optional
+
+	// CACertificate is the PEM-encoded, self-signed certificate of the server
+	// at the URL. Clients must use it to verify the server when downloading
+	// the exported files.
+	CACertificate string `json:"caCertificate,omitempty"`
+
+	// +optional
+
+	// TokenSecretName is the name of the Secret that contains the bearer
+	// token required to download the exported files. The token is the value
+	// of the "token" key in the Secret's data.
+	TokenSecretName string `json:"tokenSecretName,omitempty"`
+
+	// +optional
+
+	// ExpirationTime is the time at which the URL expires and the exported
+	// files are deleted.
+	ExpirationTime metav1.Time `json:"expirationTime,omitempty"`
+}
+
+// VirtualMachineExportStatus defines the observed state of a
+// VirtualMachineExport.
+type VirtualMachineExportStatus struct {
+	// +optional
+
+	// Files is the list of the names of the exported files. The OVF
+	// descriptor is always the first file.
+	Files []string `json:"files,omitempty"`
+
+	// +optional
+
+	// Download describes the URL from which the exported files may be
+	// downloaded when the target is a download.
+	Download *VirtualMachineExportDownloadStatus `json:"download,omitempty"`
+
+	// +optional
+
+	// StartTime represents the time when the export was first reconciled.
+	StartTime metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+
+	// CompletionTime represents the time when the export was completed.
+	CompletionTime metav1.Time `json:"completionTime,omitempty"`
+
+	// +optional
+
+	// Attempts represents the number of times the VM has been exported.
+	Attempts int64 `json:"attempts,omitempty"`
+
+	// +optional
+
+	// LastAttemptTime represents the time when the latest export attempt
+	// started.
+	LastAttemptTime metav1.Time `json:"lastAttemptTime,omitempty"`
+
+	// +optional
+
+	// Ready is set to true only when the export has completed successfully.
+	Ready bool `json:"ready,omitempty"`
+
+	// +optional
+
+	// Conditions is a list of the latest, available observations of the
+	// export's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (e *VirtualMachineExport) GetConditions() []metav1.Condition {
+	return e.Status.Conditions
+}
+
+func (e *VirtualMachineExport) SetConditions(conditions []metav1.Condition) {
+	e.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmexport
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.source.name"
+// +kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="URL",type="string",priority=1,JSONPath=".status.download.url"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineExport is the schema for the virtualmachineexports API and
+// exports the OVF descriptor and disks of a powered off VirtualMachine to a
+// PersistentVolumeClaim or a short-lived download URL.
+type VirtualMachineExport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineExportSpec   `json:"spec,omitempty"`
+	Status VirtualMachineExportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineExportList contains a list of VirtualMachineExport.
+type VirtualMachineExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineExport `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &VirtualMachineExport{}, &VirtualMachineExportList{})
+}
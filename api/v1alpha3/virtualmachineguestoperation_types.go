@@ -0,0 +1,221 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+)
+
+const (
+	// GuestOperationsAnnotation is the annotation that must be set to "true"
+	// on a VirtualMachine to allow VirtualMachineGuestOperations to be run in
+	// the VM's guest.
+	GuestOperationsAnnotation = GroupName + "/guest-operations"
+)
+
+const (
+	// VirtualMachineGuestOperationConditionCompleted is the Type for a
+	// VirtualMachineGuestOperation resource's status condition.
+	//
+	// The condition's status is set to true only when the operation has
+	// completed in the guest. A command that completes with a non-zero exit
+	// code is still considered completed.
+	VirtualMachineGuestOperationConditionCompleted = "Completed"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineGuestOperation.
+const (
+	// GuestOperationNotAllowedReason documents that the VM has not opted in
+	// to guest operations with the GuestOperationsAnnotation.
+	GuestOperationNotAllowedReason = "NotAllowed"
+
+	// GuestOperationInProgressReason documents that the command is running
+	// in the guest.
+	GuestOperationInProgressReason = "InProgress"
+
+	// GuestOperationTimedOutReason documents that the command did not
+	// complete before the timeout and was terminated.
+	GuestOperationTimedOutReason = "TimedOut"
+
+	// GuestOperationProcessNotFoundReason documents that the command's
+	// process is no longer found in the guest, ex. because the guest was
+	// rebooted, so the command's result is not known.
+	GuestOperationProcessNotFoundReason = "ProcessNotFound"
+
+	// GuestOperationFailedReason documents that the operation could not be
+	// run in the guest, ex. because VMware Tools is not running or the
+	// credentials are invalid. The operation is retried.
+	GuestOperationFailedReason = "Failed"
+)
+
+const (
+	// VirtualMachineGuestOperationUsernameKey is the key in the data of the
+	// Secret referenced by a VirtualMachineGuestOperation whose value is the
+	// name of the guest user.
+	VirtualMachineGuestOperationUsernameKey = "username"
+
+	// VirtualMachineGuestOperationPasswordKey is the key in the data of the
+	// Secret referenced by a VirtualMachineGuestOperation whose value is the
+	// password of the guest user.
+	VirtualMachineGuestOperationPasswordKey = "password"
+)
+
+// VirtualMachineGuestOperationCommand describes a command run in the guest.
+type VirtualMachineGuestOperationCommand struct {
+	// Path is the absolute path in the guest of the program to run.
+	Path string `json:"path"`
+
+	// +optional
+
+	// Args is the list of arguments passed to the program.
+	Args []string `json:"args,omitempty"`
+
+	// +optional
+
+	// WorkingDirectory is the absolute path in the guest of the directory in
+	// which the program is run.
+	//
+	// If omitted the program is run in the guest user's home directory.
+	WorkingDirectory string `json:"workingDirectory,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// Env is the list of environment variables set for the program.
+	Env []vmopv1common.NameValuePair `json:"env,omitempty"`
+}
+
+// VirtualMachineGuestOperationCopyFile describes a file copied into the
+// guest.
+type VirtualMachineGuestOperationCopyFile struct {
+	// Path is the absolute path in the guest to which the file is copied.
+	Path string `json:"path"`
+
+	// Content is the content of the file, specified either directly or from
+	// a Secret.
+	Content vmopv1common.ValueOrSecretKeySelector `json:"content"`
+
+	// +optional
+
+	// Overwrite specifies whether an existing file at the path is
+	// overwritten.
+	Overwrite bool `json:"overwrite,omitempty"`
+}
+
+// VirtualMachineGuestOperationSpec defines the desired state of a
+// VirtualMachineGuestOperation. Exactly one of Command and CopyFile must be
+// specified.
+type VirtualMachineGuestOperationSpec struct {
+	// VirtualMachineName is the name of the VirtualMachine in whose guest the
+	// operation is run. The VirtualMachine must be in the same namespace as
+	// the VirtualMachineGuestOperation and have the GuestOperationsAnnotation
+	// set to "true".
+	VirtualMachineName string `json:"virtualMachineName"`
+
+	// CredentialsSecretName is the name of the Secret that contains the
+	// credentials used to authenticate with the guest. The Secret must be in
+	// the same namespace and have the "username" and "password" keys.
+	CredentialsSecretName string `json:"credentialsSecretName"`
+
+	// +optional
+
+	// Command runs a program in the guest.
+	Command *VirtualMachineGuestOperationCommand `json:"command,omitempty"`
+
+	// +optional
+
+	// CopyFile copies a file into the guest.
+	CopyFile *VirtualMachineGuestOperationCopyFile `json:"copyFile,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=1
+
+	// TimeoutSeconds is the number of seconds after the command is started
+	// that it is terminated if it has not completed.
+	TimeoutSeconds int64 `json:"timeoutSeconds,omitempty"`
+}
+
+// VirtualMachineGuestOperationStatus defines the observed state of a
+// VirtualMachineGuestOperation.
+type VirtualMachineGuestOperationStatus struct {
+	// +optional
+
+	// StartTime represents the time when the operation was started in the
+	// guest.
+	StartTime metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+
+	// CompletionTime represents the time when the operation completed in the
+	// guest.
+	CompletionTime metav1.Time `json:"completionTime,omitempty"`
+
+	// +optional
+
+	// ProcessID is the ID of the command's process in the guest.
+	ProcessID int64 `json:"processID,omitempty"`
+
+	// +optional
+
+	// ExitCode is the exit code of the command.
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// +optional
+
+	// Stdout is the combined standard output and standard error of the
+	// command. The output is truncated to the first 16KiB.
+	Stdout string `json:"stdout,omitempty"`
+
+	// +optional
+
+	// Conditions is a list of the latest, available observations of the
+	// operation's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (o *VirtualMachineGuestOperation) GetConditions() []metav1.Condition {
+	return o.Status.Conditions
+}
+
+func (o *VirtualMachineGuestOperation) SetConditions(conditions []metav1.Condition) {
+	o.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmguestop
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="VirtualMachine",type="string",JSONPath=".spec.virtualMachineName"
+// +kubebuilder:printcolumn:name="Completed",type="string",JSONPath=".status.conditions[?(@.type=='Completed')].status"
+// +kubebuilder:printcolumn:name="ExitCode",type="integer",JSONPath=".status.exitCode"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineGuestOperation is the schema for the
+// virtualmachineguestoperations API and runs a command in, or copies a file
+// into, the guest of a VirtualMachine with VMware Tools guest operations.
+type VirtualMachineGuestOperation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineGuestOperationSpec   `json:"spec,omitempty"`
+	Status VirtualMachineGuestOperationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineGuestOperationList contains a list of
+// VirtualMachineGuestOperation.
+type VirtualMachineGuestOperationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineGuestOperation `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &VirtualMachineGuestOperation{}, &VirtualMachineGuestOperationList{})
+}
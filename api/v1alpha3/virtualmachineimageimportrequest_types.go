@@ -0,0 +1,305 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachineImageImportRequestConditionSourceValid is the Type for a
+	// VirtualMachineImageImportRequest resource's status condition.
+	//
+	// The condition's status is set to true only when the source of the
+	// import can be downloaded.
+	VirtualMachineImageImportRequestConditionSourceValid = "SourceValid"
+
+	// VirtualMachineImageImportRequestConditionTargetValid is the Type for a
+	// VirtualMachineImageImportRequest resource's status condition.
+	//
+	// The condition's status is set to true only when the target content
+	// library is ready and writable, and does not contain an item with the
+	// target item's name.
+	VirtualMachineImageImportRequestConditionTargetValid = "TargetValid"
+
+	// VirtualMachineImageImportRequestConditionUploaded is the Type for a
+	// VirtualMachineImageImportRequest resource's status condition.
+	//
+	// The condition's status is set to true only when the OVF or OVA has
+	// been downloaded, validated, and uploaded to the target content library.
+	VirtualMachineImageImportRequestConditionUploaded = "Uploaded"
+
+	// VirtualMachineImageImportRequestConditionImageAvailable is the Type for
+	// a VirtualMachineImageImportRequest resource's status condition.
+	//
+	// The condition's status is set to true only when a new
+	// VirtualMachineImage resource has been realized from the imported item.
+	VirtualMachineImageImportRequestConditionImageAvailable = "ImageAvailable"
+
+	// VirtualMachineImageImportRequestConditionComplete is the Type for a
+	// VirtualMachineImageImportRequest resource's status condition.
+	//
+	// The condition's status is set to true only when all other conditions
+	// present on the resource have a truthy status.
+	VirtualMachineImageImportRequestConditionComplete = "Complete"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineImageImportRequest.
+const (
+	// SourcePersistentVolumeClaimNotExistReason documents that the
+	// PersistentVolumeClaim that is the source of the
+	// VirtualMachineImageImportRequest doesn't exist.
+	SourcePersistentVolumeClaimNotExistReason = "SourcePersistentVolumeClaimNotExist"
+
+	// SourceNotSupportedReason documents that the source of the
+	// VirtualMachineImageImportRequest is not supported by this installation
+	// of VM Operator.
+	SourceNotSupportedReason = "SourceNotSupported"
+
+	// SourceServerNotReadyReason documents that the Pod that serves the
+	// contents of the source PersistentVolumeClaim of the
+	// VirtualMachineImageImportRequest is not yet ready.
+	SourceServerNotReadyReason = "SourceServerNotReady"
+
+	// InvalidOVFReason documents that the OVF or OVA downloaded from the
+	// source of the VirtualMachineImageImportRequest is not valid.
+	InvalidOVFReason = "InvalidOVF"
+)
+
+// VirtualMachineImageImportRequestSourcePersistentVolumeClaim describes an
+// OVF or OVA stored in a PersistentVolumeClaim.
+type VirtualMachineImageImportRequestSourcePersistentVolumeClaim struct {
+	// ClaimName is the name of a PersistentVolumeClaim in the same namespace
+	// as the VirtualMachineImageImportRequest.
+	ClaimName string `json:"claimName"`
+
+	// Path is the path of the OVF or OVA relative to the root of the volume,
+	// ex. images/my-vm.ova.
+	//
+	// When the path refers to an OVF descriptor, the files referenced by the
+	// descriptor are read relative to the descriptor's directory.
+	Path string `json:"path"`
+}
+
+// VirtualMachineImageImportRequestSource is the source of an import request.
+//
+// Exactly one of URL or PersistentVolumeClaim must be specified.
+type VirtualMachineImageImportRequestSource struct {
+	// +optional
+
+	// URL is the HTTP or HTTPS URL of the OVF or OVA to import, ex.
+	// https://example.com/images/my-vm.ova.
+	//
+	// When the URL refers to an OVF descriptor, the files referenced by the
+	// descriptor are downloaded relative to the URL.
+	//
+	// The URL, and any URL it redirects to, must not resolve to a loopback,
+	// private, shared (100.64.0.0/10), link-local, multicast or unspecified
+	// address.
+	URL string `json:"url,omitempty"`
+
+	// +optional
+
+	// InsecureSkipTLSVerify indicates the certificate of the server at the URL
+	// is not verified.
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// +optional
+
+	// PersistentVolumeClaim describes an OVF or OVA stored in a
+	// PersistentVolumeClaim.
+	PersistentVolumeClaim *VirtualMachineImageImportRequestSourcePersistentVolumeClaim `json:"persistentVolumeClaim,omitempty"`
+}
+
+// VirtualMachineImageImportRequestTargetItem is the item part of an import
+// request's target.
+type VirtualMachineImageImportRequestTargetItem struct {
+	// +optional
+
+	// Name is the name of the content library item created by the import.
+	// This is the name that will show up in vCenter Content Library, not the
+	// name of the VirtualMachineImage resource in the namespace.
+	//
+	// If omitted then the controller will use the name of the
+	// VirtualMachineImageImportRequest.
+	Name string `json:"name,omitempty"`
+
+	// +optional
+
+	// Description is the description to assign to the content library item.
+	Description string `json:"description,omitempty"`
+}
+
+// VirtualMachineImageImportRequestTargetLocation is the location part of an
+// import request's target.
+type VirtualMachineImageImportRequestTargetLocation struct {
+	// Name is the name of the referenced object.
+	Name string `json:"name"`
+
+	// +optional
+	// +kubebuilder:default=imageregistry.vmware.com/v1alpha1
+
+	// APIVersion is the API version of the referenced object.
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=ContentLibrary
+
+	// Kind is the kind of referenced object.
+	Kind string `json:"kind,omitempty"`
+}
+
+// VirtualMachineImageImportRequestTarget is the target of an import request,
+// typically a ContentLibrary resource.
+type VirtualMachineImageImportRequestTarget struct {
+	// +optional
+
+	// Item contains information about the content library item created by
+	// the import.
+	Item VirtualMachineImageImportRequestTargetItem `json:"item,omitempty"`
+
+	// Location contains information about the location into which the OVF or
+	// OVA is imported.
+	Location VirtualMachineImageImportRequestTargetLocation `json:"location"`
+}
+
+// VirtualMachineImageImportRequestSpec defines the desired state of a
+// VirtualMachineImageImportRequest.
+type VirtualMachineImageImportRequestSpec struct {
+	// Source is the source of the OVF or OVA to import.
+	Source VirtualMachineImageImportRequestSource `json:"source"`
+
+	// Target is the target of the import request, ex. item information and a
+	// ContentLibrary resource.
+	Target VirtualMachineImageImportRequestTarget `json:"target"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+
+	// TTLSecondsAfterFinished is the time-to-live duration for how long this
+	// resource will be allowed to exist once the import completes. After the
+	// TTL expires, the resource will be automatically deleted without the
+	// user having to take any direct action.
+	//
+	// If this field is unset then the request resource will not be
+	// automatically deleted. If this field is set to zero then the request
+	// resource is eligible for deletion immediately after it finishes.
+	TTLSecondsAfterFinished *int64 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// VirtualMachineImageImportRequestStatus defines the observed state of a
+// VirtualMachineImageImportRequest.
+type VirtualMachineImageImportRequestStatus struct {
+	// +optional
+
+	// ItemName is the name of the content library item created by the
+	// import.
+	ItemName string `json:"itemName,omitempty"`
+
+	// +optional
+
+	// ItemID is the ID of the content library item created by the import.
+	ItemID string `json:"itemID,omitempty"`
+
+	// +optional
+
+	// StartTime represents time when the request was acknowledged by the
+	// controller.
+	StartTime metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+
+	// CompletionTime represents time when the request was completed.
+	//
+	// The value of this field should be equal to the value of the
+	// LastTransitionTime for the status condition Type=Complete.
+	CompletionTime metav1.Time `json:"completionTime,omitempty"`
+
+	// +optional
+
+	// Attempts represents the number of times the import has been attempted.
+	Attempts int64 `json:"attempts,omitempty"`
+
+	// +optional
+
+	// LastAttemptTime represents the time when the latest attempt was
+	// started.
+	LastAttemptTime metav1.Time `json:"lastAttemptTime,omitempty"`
+
+	// +optional
+
+	// ImageName is the name of the VirtualMachineImage resource that is
+	// eventually realized in the same namespace as the import request after
+	// the import completes.
+	//
+	// This field will not be set until the VirtualMachineImage resource
+	// is realized.
+	ImageName string `json:"imageName,omitempty"`
+
+	// +optional
+
+	// Ready is set to true only when the OVF or OVA has been imported
+	// successfully and the new VirtualMachineImage resource is ready.
+	//
+	// Readiness is determined by waiting until there is status condition
+	// Type=Complete and ensuring it and all other status conditions present
+	// have a Status=True. The conditions present will be:
+	//
+	//   * SourceValid
+	//   * TargetValid
+	//   * Uploaded
+	//   * ImageAvailable
+	//   * Complete
+	Ready bool `json:"ready,omitempty"`
+
+	// +optional
+
+	// Conditions is a list of the latest, available observations of the
+	// request's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmimport
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Item",type="string",JSONPath=".status.itemName"
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".status.imageName"
+// +kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineImageImportRequest defines the information necessary to
+// import an OVF or OVA into a content library as a VirtualMachineImage.
+type VirtualMachineImageImportRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineImageImportRequestSpec   `json:"spec,omitempty"`
+	Status VirtualMachineImageImportRequestStatus `json:"status,omitempty"`
+}
+
+func (r *VirtualMachineImageImportRequest) GetConditions() []metav1.Condition {
+	return r.Status.Conditions
+}
+
+func (r *VirtualMachineImageImportRequest) SetConditions(conditions []metav1.Condition) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineImageImportRequestList contains a list of
+// VirtualMachineImageImportRequest resources.
+type VirtualMachineImageImportRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineImageImportRequest `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachineImageImportRequest{},
+		&VirtualMachineImageImportRequestList{},
+	)
+}
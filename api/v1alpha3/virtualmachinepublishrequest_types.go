@@ -56,6 +56,11 @@ const (
 	// the VirtualMachinePublishRequest hasn't been created.
 	SourceVirtualMachineNotCreatedReason = "SourceVirtualMachineNotCreated"
 
+	// SourceVirtualMachineNotPoweredOffReason documents that the source VM of
+	// the VirtualMachinePublishRequest is not powered off, which is required
+	// to publish the VM to an OCI registry.
+	SourceVirtualMachineNotPoweredOffReason = "SourceVirtualMachineNotPoweredOff"
+
 	// TargetContentLibraryNotExistReason documents that the target content
 	// library of the VirtualMachinePublishRequest doesn't exist.
 	TargetContentLibraryNotExistReason = "TargetContentLibraryNotExist"
@@ -105,6 +110,11 @@ const (
 	// hasn't been completed because the expected VirtualMachineImage resource
 	// isn't available yet.
 	ImageUnavailableReason = "ImageUnavailable"
+
+	// TargetOCIRegistryCredentialsInvalidReason documents that the Secret
+	// with the credentials for the target OCI registry of the
+	// VirtualMachinePublishRequest doesn't exist or is invalid.
+	TargetOCIRegistryCredentialsInvalidReason = "TargetOCIRegistryCredentialsInvalid"
 )
 
 // VirtualMachinePublishRequestSource is the source of a publication request,
@@ -182,6 +192,39 @@ type VirtualMachinePublishRequestTargetLocation struct {
 	Kind string `json:"kind,omitempty"`
 }
 
+// VirtualMachinePublishRequestTargetOCIRegistry describes an OCI registry
+// repository to which a VM is published as an OCI artifact.
+type VirtualMachinePublishRequestTargetOCIRegistry struct {
+	// Repository is the reference to the repository to which the VM is
+	// pushed, ex. registry.example.com/images/my-vm.
+	//
+	// Please note the repository must not include a tag or digest.
+	Repository string `json:"repository"`
+
+	// +optional
+	// +kubebuilder:default=latest
+
+	// Tag is the tag assigned to the pushed artifact.
+	//
+	// If omitted this value defaults to "latest".
+	Tag string `json:"tag,omitempty"`
+
+	// +optional
+
+	// CredentialsSecretName is the name of a Secret in the same namespace as
+	// the VirtualMachinePublishRequest that contains the credentials used to
+	// authenticate to the registry. The Secret must have the keys "username"
+	// and "password", ex. a Secret of type kubernetes.io/basic-auth.
+	//
+	// If omitted the registry is accessed anonymously.
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+
+	// +optional
+
+	// Insecure indicates the registry is accessed over plain HTTP.
+	Insecure bool `json:"insecure,omitempty"`
+}
+
 // VirtualMachinePublishRequestTarget is the target of a publication request,
 // typically a ContentLibrary resource.
 type VirtualMachinePublishRequestTarget struct {
@@ -200,6 +243,20 @@ type VirtualMachinePublishRequestTarget struct {
 	// Location contains information about the location to which to publish
 	// the VM.
 	Location VirtualMachinePublishRequestTargetLocation `json:"location,omitempty"`
+
+	// +optional
+
+	// OCIRegistry contains information about the OCI registry repository to
+	// which to publish the VM. When set, the VM is exported as an OVF and
+	// pushed to the repository as an OCI artifact instead of being published
+	// to a content library.
+	//
+	// Please note the source VM must be powered off to be published to an
+	// OCI registry.
+	//
+	// Please note this field and spec.target.location.name are mutually
+	// exclusive.
+	OCIRegistry *VirtualMachinePublishRequestTargetOCIRegistry `json:"ociRegistry,omitempty"`
 }
 
 // VirtualMachinePublishRequestSpec defines the desired state of a
@@ -310,6 +367,14 @@ type VirtualMachinePublishRequestStatus struct {
 
 	// +optional
 
+	// ArtifactRef is the digest reference of the OCI artifact pushed to the
+	// target OCI registry, ex. registry.example.com/images/my-vm@sha256:....
+	//
+	// This field is only set when the VM is published to an OCI registry.
+	ArtifactRef string `json:"artifactRef,omitempty"`
+
+	// +optional
+
 	// Ready is set to true only when the VM has been published successfully
 	// and the new VirtualMachineImage resource is ready.
 	//
@@ -322,6 +387,9 @@ type VirtualMachinePublishRequestStatus struct {
 	//   * Uploaded
 	//   * ImageAvailable
 	//   * Complete
+	//
+	// When the VM is published to an OCI registry, no VirtualMachineImage
+	// resource is realized and the ImageAvailable condition is not present.
 	Ready bool `json:"ready,omitempty"`
 
 	// +optional
@@ -0,0 +1,275 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachinePublishScheduleConditionScheduleValid is the Type for a
+	// VirtualMachinePublishSchedule resource's status condition.
+	//
+	// The condition's status is set to true only when the schedule and the
+	// target name templates are valid.
+	VirtualMachinePublishScheduleConditionScheduleValid = "ScheduleValid"
+)
+
+// Condition.Reason for Conditions related to VirtualMachinePublishSchedule.
+const (
+	// InvalidScheduleReason documents that the cron expression of the
+	// VirtualMachinePublishSchedule is invalid.
+	InvalidScheduleReason = "InvalidSchedule"
+
+	// InvalidTargetTemplateReason documents that the template for the name
+	// of the published item or the OCI registry tag of the
+	// VirtualMachinePublishSchedule is invalid.
+	InvalidTargetTemplateReason = "InvalidTargetTemplate"
+
+	// PublishFailedReason documents that the VirtualMachinePublishRequest
+	// created by the VirtualMachinePublishSchedule failed.
+	PublishFailedReason = "PublishFailed"
+
+	// PublishDeadlineExceededReason documents that the
+	// VirtualMachinePublishRequest created by the
+	// VirtualMachinePublishSchedule did not complete before the schedule's
+	// active deadline.
+	PublishDeadlineExceededReason = "PublishDeadlineExceeded"
+)
+
+const (
+	// VirtualMachinePublishScheduleLabel is a label set on the
+	// VirtualMachinePublishRequest resources created by a
+	// VirtualMachinePublishSchedule. Its value is the name of the schedule.
+	VirtualMachinePublishScheduleLabel = GroupName + "/publish-schedule"
+)
+
+// VirtualMachinePublishScheduleSpec defines the desired state of a
+// VirtualMachinePublishSchedule.
+type VirtualMachinePublishScheduleSpec struct {
+	// Schedule is the cron expression that describes when the VM is
+	// published, ex. "0 2 * * *" publishes the VM daily at 02:00.
+	//
+	// The standard five field format is supported, along with the macros
+	// @yearly, @annually, @monthly, @weekly, @daily, @midnight, and @hourly.
+	// The schedule is evaluated in UTC.
+	Schedule string `json:"schedule"`
+
+	// +optional
+
+	// Suspend indicates no new VirtualMachinePublishRequest resources are
+	// created by the schedule. A publication that is already in progress is
+	// not affected.
+	Suspend bool `json:"suspend,omitempty"`
+
+	// +optional
+
+	// Source is the source of the publication requests created by the
+	// schedule, ex. a VirtualMachine resource.
+	//
+	// If the source name is omitted then it defaults to the name of the
+	// VirtualMachinePublishSchedule resource.
+	Source VirtualMachinePublishRequestSource `json:"source,omitempty"`
+
+	// +optional
+
+	// Target is the target of the publication requests created by the
+	// schedule, ex. item information and a ContentLibrary resource, or an
+	// OCI registry.
+	//
+	// The spec.target.item.name and spec.target.ociRegistry.tag fields are
+	// Go templates that are rendered each time the VM is published so each
+	// publication results in a new, versioned item. The following values
+	// may be used in the templates:
+	//
+	//   * .SourceName - the name of the source VM
+	//   * .Revision   - the number of times the schedule has published the
+	//                   VM, starting with 1
+	//   * .Timestamp  - the time at which the publication was scheduled, in
+	//                   the format YYYYMMDDhhmmss
+	//   * .Time       - the time at which the publication was scheduled
+	//
+	// If the item name is omitted then it defaults to
+	// "{{ .SourceName }}-image-{{ .Timestamp }}".
+	Target VirtualMachinePublishRequestTarget `json:"target,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+
+	// RetentionCount is the number of published items that are retained.
+	// When a publication completes and the number of items published by the
+	// schedule exceeds this value, the oldest items are deleted from the
+	// target location.
+	//
+	// If omitted then all published items are retained.
+	RetentionCount *int32 `json:"retentionCount,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+
+	// ActiveDeadlineSeconds is the number of seconds a publication may be in
+	// progress before it is considered failed. A publication also fails when
+	// uploading the VM fails, or when the target item already exists. A
+	// failed publication's request is deleted so the schedule's next
+	// publication is not skipped.
+	//
+	// If omitted then a publication may be in progress for 24 hours.
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// VirtualMachinePublishScheduleItem describes an item published by a
+// VirtualMachinePublishSchedule.
+type VirtualMachinePublishScheduleItem struct {
+	// PublishRequestName is the name of the VirtualMachinePublishRequest that
+	// published the item.
+	PublishRequestName string `json:"publishRequestName"`
+
+	// +optional
+
+	// ItemName is the name of the published item in the target location.
+	ItemName string `json:"itemName,omitempty"`
+
+	// +optional
+
+	// ImageName is the name of the VirtualMachineImage resource realized
+	// from the published item.
+	//
+	// This field is not set when the VM is published to an OCI registry.
+	ImageName string `json:"imageName,omitempty"`
+
+	// +optional
+
+	// ArtifactRef is the digest reference of the OCI artifact pushed to the
+	// target OCI registry.
+	//
+	// This field is only set when the VM is published to an OCI registry.
+	ArtifactRef string `json:"artifactRef,omitempty"`
+
+	// +optional
+
+	// CompletionTime represents the time when the item was published.
+	CompletionTime metav1.Time `json:"completionTime,omitempty"`
+}
+
+// VirtualMachinePublishScheduleFailure describes a publication by a
+// VirtualMachinePublishSchedule that failed.
+type VirtualMachinePublishScheduleFailure struct {
+	// PublishRequestName is the name of the VirtualMachinePublishRequest that
+	// failed.
+	PublishRequestName string `json:"publishRequestName"`
+
+	// Reason is the reason the publication failed, ex. PublishFailed or
+	// PublishDeadlineExceeded.
+	Reason string `json:"reason"`
+
+	// +optional
+
+	// Message is a human readable description of the failure.
+	Message string `json:"message,omitempty"`
+
+	// FailureTime is the time when the publication was found to have failed.
+	FailureTime metav1.Time `json:"failureTime"`
+}
+
+// VirtualMachinePublishScheduleStatus defines the observed state of a
+// VirtualMachinePublishSchedule.
+type VirtualMachinePublishScheduleStatus struct {
+	// +optional
+
+	// LastScheduleTime is the last time the schedule was due to publish the
+	// VM.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// +optional
+
+	// NextScheduleTime is the next time the schedule is due to publish the
+	// VM.
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// +optional
+
+	// Revision is the number of VirtualMachinePublishRequest resources that
+	// have been created by the schedule.
+	Revision int64 `json:"revision,omitempty"`
+
+	// +optional
+
+	// Active is the name of the VirtualMachinePublishRequest that is in
+	// progress.
+	//
+	// Please note the schedule does not create a new publication request
+	// while one is in progress, and a scheduled publication that occurs
+	// while one is in progress is skipped. A publication that fails or
+	// exceeds spec.activeDeadlineSeconds is no longer in progress.
+	Active string `json:"active,omitempty"`
+
+	// +optional
+
+	// Published is the list of items published by the schedule that are
+	// retained, ordered from oldest to newest.
+	Published []VirtualMachinePublishScheduleItem `json:"published,omitempty"`
+
+	// +optional
+
+	// LastFailure describes the most recent publication that failed.
+	LastFailure *VirtualMachinePublishScheduleFailure `json:"lastFailure,omitempty"`
+
+	// +optional
+
+	// Failed is the number of publications by the schedule that failed.
+	Failed int64 `json:"failed,omitempty"`
+
+	// +optional
+
+	// Conditions is a list of the latest, available observations of the
+	// schedule's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (s *VirtualMachinePublishSchedule) GetConditions() []metav1.Condition {
+	return s.Status.Conditions
+}
+
+func (s *VirtualMachinePublishSchedule) SetConditions(conditions []metav1.Condition) {
+	s.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmpubsched
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.source.name"
+// +kubebuilder:printcolumn:name="Suspend",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="Active",type="string",JSONPath=".status.active"
+// +kubebuilder:printcolumn:name="Last-Schedule",type="date",JSONPath=".status.lastScheduleTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachinePublishSchedule is the schema for the
+// virtualmachinepublishschedules API and periodically publishes a
+// VirtualMachine by creating VirtualMachinePublishRequest resources
+// according to a cron schedule.
+type VirtualMachinePublishSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachinePublishScheduleSpec   `json:"spec,omitempty"`
+	Status VirtualMachinePublishScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachinePublishScheduleList contains a list of
+// VirtualMachinePublishSchedule.
+type VirtualMachinePublishScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachinePublishSchedule `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachinePublishSchedule{},
+		&VirtualMachinePublishScheduleList{})
+}
@@ -45,6 +45,27 @@ const (
 	// replicas VirtualMachine objects that it owns.  The value of this label is the
 	// name of the VirtualMachineReplicaSet.
 	VirtualMachineReplicaSetNameLabel = "vmoperator.vmware.com/replicaset-name"
+
+	// VirtualMachineReplicaSetDeletePriorityAnnotation is the key of the
+	// annotation that may be applied to a replica VirtualMachine to mark it
+	// as preferred for deletion when its VirtualMachineReplicaSet is scaled
+	// down, regardless of the replica set's DeletePolicy. The value of the
+	// annotation is ignored.
+	VirtualMachineReplicaSetDeletePriorityAnnotation = "vmoperator.vmware.com/delete-priority"
+)
+
+const (
+	// RandomVirtualMachineReplicaSetDeletePolicy prioritizes VMs that are
+	// not ready, and otherwise deletes VMs in an arbitrary order.
+	RandomVirtualMachineReplicaSetDeletePolicy = "Random"
+
+	// NewestVirtualMachineReplicaSetDeletePolicy prioritizes VMs that are not
+	// ready, and otherwise deletes the most recently created VMs first.
+	NewestVirtualMachineReplicaSetDeletePolicy = "Newest"
+
+	// OldestVirtualMachineReplicaSetDeletePolicy prioritizes VMs that are not
+	// ready, and otherwise deletes the least recently created VMs first.
+	OldestVirtualMachineReplicaSetDeletePolicy = "Oldest"
 )
 
 // VirtualMachineTemplateSpec describes the data needed to create a VirtualMachine
@@ -73,10 +94,15 @@ type VirtualMachineReplicaSetSpec struct {
 	Replicas *int32 `json:"replicas,omitempty"`
 
 	// +optional
-	// +kubebuilder:validation:Enum=Random
+	// +kubebuilder:validation:Enum=Random;Newest;Oldest
+	//
+	// DeletePolicy defines the policy used to identify VMs to delete when
+	// downscaling. Valid values are "Random", "Newest" and "Oldest". Defaults
+	// to "Random".
 	//
-	// DeletePolicy defines the policy used to identify nodes to delete when downscaling.
-	// Only supported deletion policy is "Random".
+	// Regardless of the policy, VMs that are being deleted are chosen first,
+	// followed by VMs with the vmoperator.vmware.com/delete-priority annotation
+	// and then VMs that are not ready.
 	DeletePolicy string `json:"deletePolicy,omitempty"`
 
 	// +optional
@@ -94,6 +120,44 @@ type VirtualMachineReplicaSetSpec struct {
 	// Template is the object that describes the virtual machine that will be
 	// created if insufficient replicas are detected.
 	Template VirtualMachineTemplateSpec `json:"template,omitempty"`
+
+	// +optional
+	//
+	// TopologySpread describes how the replicas are spread across availability
+	// zones. When set, each new replica is assigned a zone by setting the
+	// topology.kubernetes.io/zone label on the VirtualMachine, and the zones of
+	// the replicas are taken into account when choosing which replicas to
+	// delete on scale-down.
+	//
+	// When omitted, the placement of each replica is left to the VirtualMachine
+	// controller.
+	TopologySpread *VirtualMachineReplicaSetTopologySpread `json:"topologySpread,omitempty"`
+}
+
+// VirtualMachineReplicaSetTopologySpread describes how the replicas of a
+// VirtualMachineReplicaSet are spread across availability zones.
+type VirtualMachineReplicaSetTopologySpread struct {
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	//
+	// MaxSkew is the maximum permitted difference between the number of
+	// replicas in any two zones. Defaults to 1.
+	MaxSkew int32 `json:"maxSkew,omitempty"`
+
+	// +optional
+	// +listType=set
+	//
+	// Zones is the list of zones, in order of preference, across which the
+	// replicas are spread. A new replica is placed in the most preferred zone
+	// that does not cause MaxSkew to be exceeded, and on scale-down replicas
+	// are removed from the least preferred zone that does not cause MaxSkew to
+	// be exceeded. Replicas in a zone that is not in this list are removed
+	// first.
+	//
+	// When omitted, the replicas are spread across all the zones available to
+	// the namespace, in order of their names.
+	Zones []string `json:"zones,omitempty"`
 }
 
 // VirtualMachineReplicaSetStatus represents the observed state of a
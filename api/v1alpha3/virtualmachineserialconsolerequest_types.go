@@ -0,0 +1,109 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SerialConsoleAnnotation is the annotation set on a VirtualMachine when
+	// a serial console has been requested for the VM. A network-backed serial
+	// port is added to a VM with this annotation the next time the VM is
+	// powered on.
+	SerialConsoleAnnotation = GroupName + "/serial-console"
+)
+
+// Condition.Reason for the ReadyConditionType Condition of a
+// VirtualMachineSerialConsoleRequest. The condition's status is set to true
+// only when the VM has a serial port connected to the serial port
+// concentrator and the request's response has been set.
+const (
+	// SerialConsoleNotSupportedReason documents that serial consoles are not
+	// supported by this installation of VM Operator.
+	SerialConsoleNotSupportedReason = "SerialConsoleNotSupported"
+
+	// SerialPortNotReadyReason documents that the VM does not yet have a
+	// serial port connected to the serial port concentrator. The serial port
+	// is added the next time the VM is powered on.
+	SerialPortNotReadyReason = "SerialPortNotReady"
+)
+
+// VirtualMachineSerialConsoleRequestSpec describes the desired state for a
+// serial console request to a VM.
+type VirtualMachineSerialConsoleRequestSpec struct {
+	// Name is the name of a VM in the same Namespace as this serial console
+	// request.
+	Name string `json:"name"`
+
+	// PublicKey is used to encrypt the status.response. This is expected to
+	// be a RSA OAEP public key in X.509 PEM format.
+	PublicKey string `json:"publicKey"`
+}
+
+// VirtualMachineSerialConsoleRequestStatus describes the observed state of
+// the request.
+type VirtualMachineSerialConsoleRequestStatus struct {
+	// +optional
+
+	// Response is the URI of the VM's serial console, encrypted with the
+	// public key from the spec. The URI includes the parameters used to
+	// authenticate the connection to the serial port concentrator.
+	Response string `json:"response,omitempty"`
+
+	// +optional
+
+	// ExpiryTime is the time at which access via this request will expire.
+	ExpiryTime metav1.Time `json:"expiryTime,omitempty"`
+
+	// +optional
+
+	// Conditions describes the observed conditions of the serial console
+	// request.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (r *VirtualMachineSerialConsoleRequest) GetConditions() []metav1.Condition {
+	return r.Status.Conditions
+}
+
+func (r *VirtualMachineSerialConsoleRequest) SetConditions(conditions []metav1.Condition) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="VirtualMachine",type="string",JSONPath=".spec.name"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Expiry",type="date",JSONPath=".status.expiryTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineSerialConsoleRequest allows the creation of a one-time,
+// serial console connection to a VM.
+type VirtualMachineSerialConsoleRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineSerialConsoleRequestSpec   `json:"spec,omitempty"`
+	Status VirtualMachineSerialConsoleRequestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineSerialConsoleRequestList contains a list of
+// VirtualMachineSerialConsoleRequests.
+type VirtualMachineSerialConsoleRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineSerialConsoleRequest `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachineSerialConsoleRequest{},
+		&VirtualMachineSerialConsoleRequestList{},
+	)
+}
@@ -5,6 +5,7 @@ package v1alpha3
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // VirtualMachineServiceType string describes ingress methods for a service.
@@ -28,6 +29,112 @@ const (
 	VirtualMachineServiceTypeExternalName VirtualMachineServiceType = "ExternalName"
 )
 
+// IPFamily represents the IP Family (IPv4 or IPv6) of a
+// VirtualMachineService.
+// +kubebuilder:validation:Enum=IPv4;IPv6
+type IPFamily string
+
+// These types correspond to the core Service IPFamily values.
+const (
+	// IPv4Protocol indicates that this IP is IPv4 protocol.
+	IPv4Protocol IPFamily = "IPv4"
+
+	// IPv6Protocol indicates that this IP is IPv6 protocol.
+	IPv6Protocol IPFamily = "IPv6"
+)
+
+// IPFamilyPolicy represents the dual-stack-ness requested or required by a
+// VirtualMachineService.
+// +kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+type IPFamilyPolicy string
+
+// These types correspond to the core Service IPFamilyPolicy values.
+const (
+	// IPFamilyPolicySingleStack indicates that this service is required to
+	// have a single IPFamily.
+	IPFamilyPolicySingleStack IPFamilyPolicy = "SingleStack"
+
+	// IPFamilyPolicyPreferDualStack indicates that this service prefers
+	// dual-stack when the cluster is configured for dual-stack. If the cluster
+	// is not configured for dual-stack the service will be assigned a single
+	// IPFamily.
+	IPFamilyPolicyPreferDualStack IPFamilyPolicy = "PreferDualStack"
+
+	// IPFamilyPolicyRequireDualStack indicates that this service requires
+	// dual-stack. Creating the service fails if the cluster is not configured
+	// for dual-stack.
+	IPFamilyPolicyRequireDualStack IPFamilyPolicy = "RequireDualStack"
+)
+
+// ServiceAffinity is the session affinity of a VirtualMachineService.
+// +kubebuilder:validation:Enum=ClientIP;None
+type ServiceAffinity string
+
+// These types correspond to the core Service ServiceAffinity values.
+const (
+	// ServiceAffinityClientIP is the Client IP based session affinity.
+	ServiceAffinityClientIP ServiceAffinity = "ClientIP"
+
+	// ServiceAffinityNone means no session affinity.
+	ServiceAffinityNone ServiceAffinity = "None"
+)
+
+// SessionAffinityConfig represents the configurations of session affinity.
+type SessionAffinityConfig struct {
+	// +optional
+
+	// ClientIP contains the configurations of Client IP based session
+	// affinity.
+	ClientIP *ClientIPConfig `json:"clientIP,omitempty"`
+}
+
+// ClientIPConfig represents the configurations of Client IP based session
+// affinity.
+type ClientIPConfig struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=86400
+
+	// TimeoutSeconds specifies the seconds of ClientIP type session sticky
+	// time. The value must be >0 && <=86400 (for 1 day) if ServiceAffinity ==
+	// "ClientIP". Default value is 10800 (for 3 hours).
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// ServiceExternalTrafficPolicy describes how nodes distribute service traffic
+// they receive on one of the VirtualMachineService's "externally-facing"
+// addresses.
+// +kubebuilder:validation:Enum=Cluster;Local
+type ServiceExternalTrafficPolicy string
+
+// These types correspond to the core Service ServiceExternalTrafficPolicy
+// values.
+const (
+	// ServiceExternalTrafficPolicyCluster routes traffic to all endpoints.
+	ServiceExternalTrafficPolicyCluster ServiceExternalTrafficPolicy = "Cluster"
+
+	// ServiceExternalTrafficPolicyLocal preserves the source IP of the
+	// traffic by routing only to endpoints on the same node as the traffic
+	// was received on.
+	ServiceExternalTrafficPolicyLocal ServiceExternalTrafficPolicy = "Local"
+)
+
+// ServiceInternalTrafficPolicy describes how nodes distribute service traffic
+// they receive on the VirtualMachineService's ClusterIP.
+// +kubebuilder:validation:Enum=Cluster;Local
+type ServiceInternalTrafficPolicy string
+
+// These types correspond to the core Service ServiceInternalTrafficPolicy
+// values.
+const (
+	// ServiceInternalTrafficPolicyCluster routes traffic to all endpoints.
+	ServiceInternalTrafficPolicyCluster ServiceInternalTrafficPolicy = "Cluster"
+
+	// ServiceInternalTrafficPolicyLocal routes traffic only to endpoints on
+	// the same node as the client pod.
+	ServiceInternalTrafficPolicyLocal ServiceInternalTrafficPolicy = "Local"
+)
+
 // VirtualMachineServicePort describes the specification of a service port to
 // be exposed by a VirtualMachineService. This VirtualMachineServicePort
 // specification includes attributes that define the external and internal
@@ -46,7 +153,11 @@ type VirtualMachineServicePort struct {
 
 	// TargetPort describes the internal port open on a VirtualMachine that
 	// should be mapped to the external Port.
-	TargetPort int32 `json:"targetPort"`
+	//
+	// This may be a port number or the name of a port in the VirtualMachine's
+	// spec.network.ports. A named port allows VirtualMachines to expose the
+	// service on different port numbers.
+	TargetPort intstr.IntOrString `json:"targetPort"`
 }
 
 // LoadBalancerStatus represents the status of a load balancer.
@@ -137,6 +248,82 @@ type VirtualMachineServiceSpec struct {
 	// Must be a valid RFC-1123 hostname (https://tools.ietf.org/html/rfc1123)
 	// and requires Type to be ExternalName.
 	ExternalName string `json:"externalName,omitempty"`
+
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=2
+
+	// IPFamilies is a list of IP families (e.g. IPv4, IPv6) assigned to this
+	// service. The first family is the service's primary family and is used
+	// for the ClusterIP, while the VirtualMachines' addresses of each family
+	// are published as the service's endpoints.
+	//
+	// If omitted, the family or families are assigned by the cluster based on
+	// the IPFamilyPolicy. This field may hold a maximum of two entries
+	// (dual-stack families, in either order) and only applies to types
+	// ClusterIP and LoadBalancer. The primary family may not be changed once
+	// it is set or assigned by the cluster, but a secondary family may be
+	// added or removed.
+	IPFamilies []IPFamily `json:"ipFamilies,omitempty"`
+
+	// +optional
+
+	// IPFamilyPolicy represents the dual-stack-ness requested or required by
+	// this VirtualMachineService. If omitted, the cluster defaults to
+	// SingleStack. Valid values are SingleStack, PreferDualStack, and
+	// RequireDualStack.
+	IPFamilyPolicy *IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// +optional
+
+	// SessionAffinity is used to maintain session affinity. Enable client IP
+	// based session affinity with ClientIP. Defaults to None.
+	SessionAffinity ServiceAffinity `json:"sessionAffinity,omitempty"`
+
+	// +optional
+
+	// SessionAffinityConfig contains the configurations of session affinity
+	// and may only be set when SessionAffinity is ClientIP.
+	SessionAffinityConfig *SessionAffinityConfig `json:"sessionAffinityConfig,omitempty"`
+
+	// +optional
+
+	// ExternalTrafficPolicy describes how nodes distribute service traffic
+	// they receive on the load balancer's addresses. Local preserves the
+	// client source IP, while Cluster, the default, obscures the client
+	// source IP but spreads the traffic across all endpoints. Only applies
+	// to type LoadBalancer.
+	//
+	// VirtualMachine endpoints are not on a node, so Local is only allowed
+	// when the load balancer provider bypasses kube-proxy, ex. NSX-T.
+	//
+	// This field takes precedence over the
+	// virtualmachineservice.vmoperator.vmware.com/service.externalTrafficPolicy
+	// annotation.
+	ExternalTrafficPolicy ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+
+	// +optional
+
+	// InternalTrafficPolicy describes how nodes distribute service traffic
+	// they receive on the ClusterIP. Local only routes the traffic to
+	// endpoints on the same node as the client, while Cluster, the default,
+	// routes the traffic to all endpoints.
+	//
+	// VirtualMachine endpoints are not on a node, so kube-proxy drops the
+	// traffic when this is Local. Therefore Local is not allowed.
+	InternalTrafficPolicy *ServiceInternalTrafficPolicy `json:"internalTrafficPolicy,omitempty"`
+
+	// +optional
+
+	// HealthCheckNodePort specifies the health check node port for the
+	// service. If not specified, the port is allocated by the cluster. Only
+	// applies to type LoadBalancer when ExternalTrafficPolicy is Local. The
+	// port may not be changed once set.
+	//
+	// This field takes precedence over the
+	// virtualmachineservice.vmoperator.vmware.com/service.healthCheckNodePort
+	// annotation.
+	HealthCheckNodePort int32 `json:"healthCheckNodePort,omitempty"`
 }
 
 // VirtualMachineServiceStatus defines the observed state of
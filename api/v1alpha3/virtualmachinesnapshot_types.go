@@ -0,0 +1,175 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+)
+
+const (
+	// VirtualMachineSnapshotReadyCondition represents the condition that the
+	// virtual machine snapshot has been taken and is ready to be used.
+	VirtualMachineSnapshotReadyCondition = "VirtualMachineSnapshotReady"
+
+	// VirtualMachineSnapshotVMNotFoundReason documents that the
+	// VirtualMachine referenced by the snapshot does not exist.
+	VirtualMachineSnapshotVMNotFoundReason = "VirtualMachineNotFound"
+
+	// VirtualMachineSnapshotVMNotCreatedReason documents that the
+	// VirtualMachine referenced by the snapshot has not been created on the
+	// underlying infrastructure yet.
+	VirtualMachineSnapshotVMNotCreatedReason = "VirtualMachineNotCreated"
+
+	// VirtualMachineSnapshotCreateFailedReason documents that the snapshot
+	// could not be taken.
+	VirtualMachineSnapshotCreateFailedReason = "SnapshotCreateFailed"
+)
+
+const (
+	// VirtualMachineSnapshotRevertedCondition is added to a VirtualMachine
+	// when it is reverted to the snapshot referenced by
+	// spec.currentSnapshot.
+	VirtualMachineSnapshotRevertedCondition = "VirtualMachineSnapshotReverted"
+
+	// VirtualMachineSnapshotRevertFailedReason documents that the
+	// VirtualMachine could not be reverted to the requested snapshot.
+	VirtualMachineSnapshotRevertFailedReason = "SnapshotRevertFailed"
+
+	// VirtualMachineSnapshotNotReadyReason documents that the snapshot
+	// referenced by a VirtualMachine's spec.currentSnapshot is not ready.
+	VirtualMachineSnapshotNotReadyReason = "SnapshotNotReady"
+)
+
+// QuiesceSpec represents specifications that will be used to quiesce
+// the guest when taking a snapshot.
+type QuiesceSpec struct {
+	// +optional
+
+	// Timeout represents the maximum time allowed to quiesce the guest when
+	// taking the snapshot. The timeout is rounded down to whole minutes and
+	// may not be less than 5 minutes or more than 240 minutes.
+	//
+	// Defaults to 15 minutes, the vSphere default, when omitted.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// VirtualMachineSnapshotSpec defines the desired state of
+// VirtualMachineSnapshot.
+type VirtualMachineSnapshotSpec struct {
+	// +optional
+
+	// Memory represents whether the snapshot includes the VM's
+	// memory. If true, a dump of the internal state of the virtual
+	// machine (a memory dump) is included in the snapshot. Memory
+	// snapshots consume time and resources and thus, take longer to
+	// create.
+	//
+	// The virtual machine must support this capability.
+	// When set to false, the power state of the snapshot is set to
+	// powered off.
+	// For a VM in suspended state, memory is always included
+	// in the snapshot.
+	Memory bool `json:"memory,omitempty"`
+
+	// +optional
+
+	// Quiesce represents the spec used for granular control over
+	// quiesce details. If quiesceSpec is set and the virtual machine
+	// is powered on when the snapshot is taken, VMware Tools is used
+	// to quiesce the file system in the virtual machine. This assures
+	// that a disk snapshot represents a consistent state of the guest
+	// file systems. If the virtual machine is powered off or VMware
+	// Tools are not available, the quiesce spec is ignored.
+	Quiesce *QuiesceSpec `json:"quiesce,omitempty"`
+
+	// +optional
+
+	// Description represents a description of the snapshot.
+	Description string `json:"description,omitempty"`
+
+	// VMRef represents the name of the virtual machine for which the
+	// snapshot is requested. The virtual machine must be in the same
+	// namespace as the snapshot.
+	VMRef *vmopv1common.LocalObjectRef `json:"vmRef"`
+}
+
+// VirtualMachineSnapshotStatus defines the observed state of
+// VirtualMachineSnapshot.
+type VirtualMachineSnapshotStatus struct {
+	// +optional
+
+	// PowerState represents the observed power state of the virtual
+	// machine when the snapshot was taken.
+	PowerState VirtualMachinePowerState `json:"powerState,omitempty"`
+
+	// +optional
+
+	// Quiesced represents whether or not the snapshot was created
+	// with the quiesce option to ensure a snapshot with a consistent
+	// state of the guest file system.
+	Quiesced bool `json:"quiesced,omitempty"`
+
+	// +optional
+
+	// UniqueID describes a unique identifier provided by the backing
+	// infrastructure (e.g., vSphere) that can be used to distinguish
+	// this snapshot from other snapshots of this virtual machine.
+	UniqueID string `json:"uniqueID,omitempty"`
+
+	// +optional
+
+	// Children represents the snapshots for which this snapshot is
+	// the parent.
+	Children []vmopv1common.LocalObjectRef `json:"children,omitempty"`
+
+	// +optional
+
+	// Conditions describes the observed conditions of the
+	// VirtualMachineSnapshot.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (vmSnapshot *VirtualMachineSnapshot) GetConditions() []metav1.Condition {
+	return vmSnapshot.Status.Conditions
+}
+
+func (vmSnapshot *VirtualMachineSnapshot) SetConditions(conditions []metav1.Condition) {
+	vmSnapshot.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmsnapshot
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="VirtualMachine",type="string",JSONPath=".spec.vmRef.name"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='VirtualMachineSnapshotReady')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineSnapshot is the schema for the virtualmachinesnapshot API.
+type VirtualMachineSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineSnapshotSpec   `json:"spec,omitempty"`
+	Status VirtualMachineSnapshotStatus `json:"status,omitempty"`
+}
+
+func (vmSnapshot *VirtualMachineSnapshot) NamespacedName() string {
+	return vmSnapshot.Namespace + "/" + vmSnapshot.Name
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineSnapshotList contains a list of VirtualMachineSnapshot.
+type VirtualMachineSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineSnapshot `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &VirtualMachineSnapshot{}, &VirtualMachineSnapshotList{})
+}
@@ -7,6 +7,22 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// VirtualMachineWebConsoleRequestConditionActive is the Type for a
+	// VirtualMachineWebConsoleRequest resource's status condition.
+	//
+	// The condition's status is set to true only when a ticket has been
+	// acquired for the request and the request has not been revoked.
+	VirtualMachineWebConsoleRequestConditionActive = "Active"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineWebConsoleRequest.
+const (
+	// WebConsoleRequestRevokedReason documents that the web console request
+	// was revoked and its ticket may no longer be used.
+	WebConsoleRequestRevokedReason = "Revoked"
+)
+
 // VirtualMachineWebConsoleRequestSpec describes the desired state for a web
 // console request to a VM.
 type VirtualMachineWebConsoleRequestSpec struct {
@@ -15,6 +31,15 @@ type VirtualMachineWebConsoleRequestSpec struct {
 	Name string `json:"name"`
 	// PublicKey is used to encrypt the status.response. This is expected to be a RSA OAEP public key in X.509 PEM format.
 	PublicKey string `json:"publicKey"`
+
+	// +optional
+
+	// Revoked may be set to true to revoke the web console request. Once
+	// revoked, connections that use the request's ticket are refused even if
+	// the ticket has not yet expired.
+	//
+	// Please note, once set to true, this field cannot be set back to false.
+	Revoked bool `json:"revoked,omitempty"`
 }
 
 // VirtualMachineWebConsoleRequestStatus describes the observed state of the
@@ -50,12 +75,37 @@ type VirtualMachineWebConsoleRequestStatus struct {
 	// by Go's https://pkg.go.dev/net#ResolveIPAddr and
 	// https://pkg.go.dev/net#ParseIP functions.
 	ProxyAddr string `json:"proxyAddr,omitempty"`
+
+	// +optional
+
+	// Requester is the name of the user that created the web console
+	// request.
+	Requester string `json:"requester,omitempty"`
+
+	// +optional
+
+	// Conditions describes the observed conditions of the web console
+	// request.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (r *VirtualMachineWebConsoleRequest) GetConditions() []metav1.Condition {
+	return r.Status.Conditions
+}
+
+func (r *VirtualMachineWebConsoleRequest) SetConditions(conditions []metav1.Condition) {
+	r.Status.Conditions = conditions
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Namespaced
 // +kubebuilder:storageversion
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="VirtualMachine",type="string",JSONPath=".spec.name"
+// +kubebuilder:printcolumn:name="Requester",type="string",JSONPath=".status.requester"
+// +kubebuilder:printcolumn:name="Active",type="string",JSONPath=".status.conditions[?(@.type=='Active')].status"
+// +kubebuilder:printcolumn:name="Expiry",type="date",JSONPath=".status.expiryTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // VirtualMachineWebConsoleRequest allows the creation of a one-time, web
 // console connection to a VM.
@@ -0,0 +1,85 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"flag"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	klog "k8s.io/klog/v2"
+	"k8s.io/klog/v2/textlogger"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/vmware-tanzu/vm-operator/pkg"
+	"github.com/vmware-tanzu/vm-operator/pkg/exportserver"
+)
+
+var (
+	defaultServerPort    = 8080
+	defaultServerTLSPort = 8443
+	defaultDataDir       = "/data"
+	defaultTLSDir        = "/etc/export-server/tls"
+)
+
+func main() {
+	// Using the same type of logger as in the controller-manager.
+	klog.InitFlags(nil)
+	ctrllog.SetLogger(textlogger.NewLogger(textlogger.NewConfig()))
+	logger := ctrllog.Log.WithName("entrypoint")
+
+	logger.Info("VM Operator export server info", "version", pkg.BuildVersion,
+		"buildnumber", pkg.BuildNumber, "buildtype", pkg.BuildType, "commit", pkg.BuildCommit)
+
+	serverPort := flag.Int(
+		"server-port",
+		defaultServerPort,
+		"The port on which the export server listens for uploads and downloads over HTTP.",
+	)
+	serverTLSPort := flag.Int(
+		"server-tls-port",
+		defaultServerTLSPort,
+		"The port on which the export server listens for downloads over HTTPS.",
+	)
+	dataDir := flag.String(
+		"data-dir",
+		defaultDataDir,
+		"The directory to which the exported files are written.",
+	)
+	tlsDir := flag.String(
+		"tls-dir",
+		defaultTLSDir,
+		"The directory with the tls.crt and tls.key of the HTTPS server. HTTPS is disabled when the files do not exist.",
+	)
+
+	flag.Parse()
+
+	var tlsAddr string
+	certFile := filepath.Join(*tlsDir, "tls.crt")
+	keyFile := filepath.Join(*tlsDir, "tls.key")
+	if _, err := os.Stat(certFile); err == nil {
+		tlsAddr = ":" + strconv.Itoa(*serverTLSPort)
+	}
+
+	server, err := exportserver.NewServer(
+		":"+strconv.Itoa(*serverPort),
+		tlsAddr,
+		*dataDir,
+		os.Getenv("EXPORT_TOKEN"),
+		certFile,
+		keyFile,
+	)
+	if err != nil {
+		logger.Error(err, "Failed to initialize the export server")
+		os.Exit(1)
+	}
+
+	logger.Info("Starting the export server", "port", *serverPort, "tlsAddr", tlsAddr, "dataDir", *dataDir)
+	if err := server.Run(); err != nil && err != http.ErrServerClosed {
+		logger.Error(err, "Failed to run the export server")
+		os.Exit(1)
+	}
+}
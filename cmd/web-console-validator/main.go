@@ -9,6 +9,7 @@ import (
 	"os"
 	"strconv"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	klog "k8s.io/klog/v2"
 	"k8s.io/klog/v2/textlogger"
@@ -16,6 +17,7 @@ import (
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 
 	vmopv1a1 "github.com/vmware-tanzu/vm-operator/api/v1alpha1"
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	"github.com/vmware-tanzu/vm-operator/pkg"
 	"github.com/vmware-tanzu/vm-operator/pkg/webconsolevalidation"
 )
@@ -60,7 +62,7 @@ func main() {
 		":"+strconv.Itoa(*serverPort),
 		*serverPath,
 		rest.InClusterConfig,
-		vmopv1a1.AddToScheme,
+		addToScheme,
 		ctrlclient.New,
 	)
 	if err != nil {
@@ -74,3 +76,12 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// addToScheme adds the types of both the v1alpha1 WebConsoleRequest and the
+// VirtualMachineWebConsoleRequest to the scheme.
+func addToScheme(scheme *runtime.Scheme) error {
+	if err := vmopv1a1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	return vmopv1.AddToScheme(scheme)
+}
@@ -14,10 +14,19 @@ import (
 	spq "github.com/vmware-tanzu/vm-operator/controllers/storagepolicyquota"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachine"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineclass"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineclone"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinedeployment"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinedisruptionbudget"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineexport"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineguestoperation"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimageimportrequest"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinepublishrequest"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinepublishschedule"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinereplicaset"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineserialconsolerequest"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinesetresourcepolicy"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinesnapshot"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinewebconsolerequest"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
@@ -59,6 +68,54 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		if err := virtualmachinereplicaset.AddToManager(ctx, mgr); err != nil {
 			return fmt.Errorf("failed to initialize VirtualMachineReplicaSet controller: %w", err)
 		}
+		if err := virtualmachinedeployment.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineDeployment controller: %w", err)
+		}
+		if err := virtualmachinedisruptionbudget.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineDisruptionBudget controller: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMClone {
+		if err := virtualmachineclone.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineClone controller: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMPublishSchedule {
+		if err := virtualmachinepublishschedule.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachinePublishSchedule controller: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMImageImport {
+		if err := virtualmachineimageimportrequest.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineImageImportRequest controller: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMExport {
+		if err := virtualmachineexport.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineExport controller: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMSerialConsole {
+		if err := virtualmachineserialconsolerequest.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineSerialConsoleRequest controller: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMGuestOperations {
+		if err := virtualmachineguestoperation.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineGuestOperation controller: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMSnapshots {
+		if err := virtualmachinesnapshot.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineSnapshot controller: %w", err)
+		}
 	}
 
 	if pkgcfg.FromContext(ctx).Features.BringYourOwnEncryptionKey {
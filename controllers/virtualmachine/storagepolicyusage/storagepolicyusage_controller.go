@@ -135,6 +135,21 @@ func (r *Reconciler) ReconcileNormal(
 			continue
 		}
 
+		if from := relocatingFromStorageClass(vm); from != "" {
+			switch obj.Spec.StorageClassName {
+			case from:
+				// The VM's storage still uses the storage class it is being
+				// relocated from, so report the VM's actual usage as Used.
+				reportUsed(vm, &totalUsed)
+			case vm.Spec.StorageClass:
+				// The VM's storage is being relocated to this storage class,
+				// so report the VM's actual usage as Reserved until the
+				// relocation completes.
+				reportUsed(vm, &totalReserved)
+			}
+			continue
+		}
+
 		if vm.Status.Storage == nil ||
 			!conditions.IsTrue(&vm, vmopv1.VirtualMachineConditionCreated) {
 
@@ -177,6 +192,19 @@ func (r *Reconciler) ReconcileNormal(
 	return nil
 }
 
+// relocatingFromStorageClass returns the name of the storage class the VM's
+// storage is being relocated from after the VM's storage class was changed.
+// An empty string is returned if the VM's storage is not being relocated.
+func relocatingFromStorageClass(vm vmopv1.VirtualMachine) string {
+	if vm.Status.Storage == nil ||
+		vm.Status.Storage.StorageClass == "" ||
+		vm.Status.Storage.StorageClass == vm.Spec.StorageClass {
+
+		return ""
+	}
+	return vm.Status.Storage.StorageClass
+}
+
 func reportUsed(
 	vm vmopv1.VirtualMachine,
 	total *resource.Quantity) {
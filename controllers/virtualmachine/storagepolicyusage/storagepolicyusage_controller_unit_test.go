@@ -418,6 +418,35 @@ func unitTestsReconcile() {
 					assertReportedTotals(spu, err, nil, zeroQuantity, resource.MustParse("1.5Gi"))
 				})
 			})
+			Context("that have their storage relocated to a new storage class", func() {
+				When("the VM is relocated to the storage class", func() {
+					BeforeEach(func() {
+						vm1.Spec.StorageClass = name
+						vm1.Status.Storage.StorageClass = fake
+					})
+					Specify("the reported reserved data should include the relocated VM", func() {
+						assertReportedTotals(spu, err, nil, resource.MustParse("3Gi"), resource.MustParse("1.5Gi"))
+					})
+				})
+				When("the VM is relocated from the storage class", func() {
+					BeforeEach(func() {
+						vm1.Spec.StorageClass = fake
+						vm1.Status.Storage.StorageClass = name
+					})
+					Specify("the reported used data should include the relocated VM", func() {
+						assertReportedTotals(spu, err, nil, zeroQuantity, resource.MustParse("4.5Gi"))
+					})
+				})
+				When("the VM is relocated between other storage classes", func() {
+					BeforeEach(func() {
+						vm1.Spec.StorageClass = fake
+						vm1.Status.Storage.StorageClass = fake + "-2"
+					})
+					Specify("the reported information should not include the relocated VM", func() {
+						assertReportedTotals(spu, err, nil, zeroQuantity, resource.MustParse("1.5Gi"))
+					})
+				})
+			})
 			Context("that do not have a true created condition", func() {
 				BeforeEach(func() {
 					vm1.Status.Conditions[0].Status = metav1.ConditionFalse
@@ -13,6 +13,7 @@ import (
 
 	"github.com/go-logr/logr"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -256,6 +257,7 @@ type Reconciler struct {
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineclasses,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesnapshots,verbs=get;list;watch
 // +kubebuilder:rbac:groups=vmware.com,resources=virtualnetworkinterfaces;virtualnetworkinterfaces/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=netoperator.vmware.com,resources=networkinterfaces,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
@@ -296,12 +298,26 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", vmCtx, err)
 	}
 
+	// The storage class the VM's storage used before this reconcile. This
+	// differs from spec.storageClass while the VM's storage is relocated to a
+	// new storage class.
+	var lastStorageClass string
+	if vm.Status.Storage != nil {
+		lastStorageClass = vm.Status.Storage.StorageClass
+	}
+
 	defer func() {
 		if pkgcfg.FromContext(ctx).Features.UnifiedStorageQuota {
 			vmopv1util.SyncStorageUsageForNamespace(
 				ctx,
 				vm.Namespace,
 				vm.Spec.StorageClass)
+			if lastStorageClass != "" && lastStorageClass != vm.Spec.StorageClass {
+				vmopv1util.SyncStorageUsageForNamespace(
+					ctx,
+					vm.Namespace,
+					lastStorageClass)
+			}
 		}
 		if err := patchHelper.Patch(ctx, vm); err != nil {
 			if reterr == nil {
@@ -439,6 +455,10 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineContext) (reterr
 	// Upgrade schema fields where needed
 	upgradeSchema(ctx)
 
+	if err := r.reconcileCurrentSnapshot(ctx); err != nil {
+		return err
+	}
+
 	err := r.VMProvider.CreateOrUpdateVirtualMachine(ctx, ctx.VM)
 
 	switch {
@@ -462,6 +482,93 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineContext) (reterr
 	return nil
 }
 
+// reconcileCurrentSnapshot reverts the VM to the snapshot specified by
+// spec.currentSnapshot when it differs from the VM's current snapshot.
+func (r *Reconciler) reconcileCurrentSnapshot(ctx *pkgctx.VirtualMachineContext) error {
+	if !pkgcfg.FromContext(ctx).Features.VMSnapshots {
+		return nil
+	}
+
+	vm := ctx.VM
+	desired := vm.Spec.CurrentSnapshot
+	if desired == nil || desired.Name == "" {
+		// Forget the last revert so setting the field again reverts the VM.
+		delete(vm.Annotations, vmopv1.LastRevertedSnapshotAnnotation)
+		return nil
+	}
+	if vm.Status.UniqueID == "" {
+		return nil
+	}
+
+	// The revert is edge-triggered: status.currentSnapshot changes whenever a
+	// new snapshot is taken, which must not revert the VM again.
+	if vm.Annotations[vmopv1.LastRevertedSnapshotAnnotation] == desired.Name {
+		return nil
+	}
+	if current := vm.Status.CurrentSnapshot; current != nil && current.Name == desired.Name {
+		setLastRevertedSnapshot(vm, desired.Name)
+		return nil
+	}
+
+	vmSnapshot := &vmopv1.VirtualMachineSnapshot{}
+	key := client.ObjectKey{Namespace: vm.Namespace, Name: desired.Name}
+	if err := r.Client.Get(ctx, key, vmSnapshot); err != nil {
+		if apierrors.IsNotFound(err) {
+			conditions.MarkFalse(
+				vm,
+				vmopv1.VirtualMachineSnapshotRevertedCondition,
+				vmopv1.VirtualMachineSnapshotNotReadyReason,
+				"VirtualMachineSnapshot %s not found", desired.Name)
+			return nil
+		}
+		return err
+	}
+
+	if vmSnapshot.Spec.VMRef == nil || vmSnapshot.Spec.VMRef.Name != vm.Name {
+		conditions.MarkFalse(
+			vm,
+			vmopv1.VirtualMachineSnapshotRevertedCondition,
+			vmopv1.VirtualMachineSnapshotRevertFailedReason,
+			"VirtualMachineSnapshot %s does not belong to this VM", desired.Name)
+		return nil
+	}
+
+	if !conditions.IsTrue(vmSnapshot, vmopv1.VirtualMachineSnapshotReadyCondition) {
+		conditions.MarkFalse(
+			vm,
+			vmopv1.VirtualMachineSnapshotRevertedCondition,
+			vmopv1.VirtualMachineSnapshotNotReadyReason,
+			"VirtualMachineSnapshot %s is not ready", desired.Name)
+		return nil
+	}
+
+	ctx.Logger.Info("Reverting VirtualMachine to snapshot", "snapshotName", desired.Name)
+
+	err := r.VMProvider.RevertVirtualMachineToSnapshot(ctx, vm, vmSnapshot)
+	r.Recorder.EmitEvent(vm, "RevertSnapshot", err, false)
+	if err != nil {
+		conditions.MarkFalse(
+			vm,
+			vmopv1.VirtualMachineSnapshotRevertedCondition,
+			vmopv1.VirtualMachineSnapshotRevertFailedReason,
+			"%v", err)
+		return err
+	}
+
+	vm.Status.CurrentSnapshot = desired.DeepCopy()
+	setLastRevertedSnapshot(vm, desired.Name)
+	conditions.MarkTrue(vm, vmopv1.VirtualMachineSnapshotRevertedCondition)
+
+	return nil
+}
+
+func setLastRevertedSnapshot(vm *vmopv1.VirtualMachine, name string) {
+	if vm.Annotations == nil {
+		vm.Annotations = map[string]string{}
+	}
+	vm.Annotations[vmopv1.LastRevertedSnapshotAnnotation] = name
+}
+
 func getIsDefaultVMClassController(ctx context.Context) bool {
 	if v := pkgcfg.FromContext(ctx).DefaultVMClassControllerName; v == "" || v == vmClassControllerName {
 		return true
@@ -16,8 +16,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
 
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachine/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
@@ -227,6 +229,137 @@ func unitTestsReconcile() {
 			Expect(reconciler.ReconcileNormal(vmCtx)).ShouldNot(Succeed())
 			expectEvents(ctx, "ReconcileNormalFailure")
 		})
+		Context("Snapshot revert", func() {
+			var (
+				vmSnapshot     *vmopv1.VirtualMachineSnapshot
+				revertSnapshot string
+			)
+
+			BeforeEach(func() {
+				revertSnapshot = ""
+				vm.Status.UniqueID = "dummy-id"
+				vm.Spec.CurrentSnapshot = &vmopv1common.LocalObjectRef{
+					APIVersion: vmopv1.GroupVersion.String(),
+					Kind:       "VirtualMachineSnapshot",
+					Name:       "dummy-snapshot",
+				}
+
+				vmSnapshot = builder.DummyVirtualMachineSnapshot(vm.Namespace, "dummy-snapshot", vm.Name)
+				vmSnapshot.Status.UniqueID = "snapshot-1"
+				conditions.MarkTrue(vmSnapshot, vmopv1.VirtualMachineSnapshotReadyCondition)
+				initObjects = append(initObjects, vmSnapshot)
+			})
+
+			JustBeforeEach(func() {
+				pkgcfg.SetContext(vmCtx, func(config *pkgcfg.Config) {
+					config.Features.VMSnapshots = true
+				})
+				fakeVMProvider.RevertVirtualMachineToSnapshotFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachine,
+					snap *vmopv1.VirtualMachineSnapshot) error {
+					revertSnapshot = snap.Name
+					return nil
+				}
+			})
+
+			It("reverts the VM to the snapshot", func() {
+				Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+				Expect(revertSnapshot).To(Equal(vmSnapshot.Name))
+				Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineSnapshotRevertedCondition)).To(BeTrue())
+				Expect(vm.Status.CurrentSnapshot).ToNot(BeNil())
+				Expect(vm.Status.CurrentSnapshot.Name).To(Equal(vmSnapshot.Name))
+				Expect(vm.Annotations).To(HaveKeyWithValue(vmopv1.LastRevertedSnapshotAnnotation, vmSnapshot.Name))
+				expectEvents(ctx, "RevertSnapshotSuccess")
+			})
+
+			It("does not revert the VM again after a new snapshot is taken", func() {
+				Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+				Expect(revertSnapshot).To(Equal(vmSnapshot.Name))
+				expectEvents(ctx, "RevertSnapshotSuccess")
+
+				revertSnapshot = ""
+				vm.Status.CurrentSnapshot = &vmopv1common.LocalObjectRef{
+					APIVersion: vmopv1.GroupVersion.String(),
+					Kind:       "VirtualMachineSnapshot",
+					Name:       "new-snapshot",
+				}
+
+				Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+				Expect(revertSnapshot).To(BeEmpty())
+			})
+
+			When("the VM is already at the snapshot", func() {
+				BeforeEach(func() {
+					vm.Status.CurrentSnapshot = vm.Spec.CurrentSnapshot.DeepCopy()
+				})
+
+				It("does not revert the VM", func() {
+					Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+					Expect(revertSnapshot).To(BeEmpty())
+					Expect(vm.Annotations).To(HaveKeyWithValue(vmopv1.LastRevertedSnapshotAnnotation, vmSnapshot.Name))
+				})
+			})
+
+			When("spec.currentSnapshot is cleared", func() {
+				BeforeEach(func() {
+					vm.Spec.CurrentSnapshot = nil
+					vm.Annotations[vmopv1.LastRevertedSnapshotAnnotation] = vmSnapshot.Name
+				})
+
+				It("forgets the last revert", func() {
+					Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+					Expect(revertSnapshot).To(BeEmpty())
+					Expect(vm.Annotations).ToNot(HaveKey(vmopv1.LastRevertedSnapshotAnnotation))
+				})
+			})
+
+			When("the snapshot belongs to a different VM", func() {
+				BeforeEach(func() {
+					vmSnapshot.Spec.VMRef.Name = "other-vm"
+				})
+
+				It("does not revert the VM", func() {
+					Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+					Expect(revertSnapshot).To(BeEmpty())
+					Expect(conditions.GetReason(vm, vmopv1.VirtualMachineSnapshotRevertedCondition)).To(
+						Equal(vmopv1.VirtualMachineSnapshotRevertFailedReason))
+					Expect(conditions.GetMessage(vm, vmopv1.VirtualMachineSnapshotRevertedCondition)).To(
+						ContainSubstring("does not belong to this VM"))
+				})
+			})
+
+			When("the snapshot is not ready", func() {
+				BeforeEach(func() {
+					vmSnapshot.Status.Conditions = nil
+				})
+
+				It("does not revert the VM", func() {
+					Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+					Expect(revertSnapshot).To(BeEmpty())
+					Expect(conditions.GetReason(vm, vmopv1.VirtualMachineSnapshotRevertedCondition)).To(
+						Equal(vmopv1.VirtualMachineSnapshotNotReadyReason))
+				})
+			})
+
+			When("the revert fails", func() {
+				JustBeforeEach(func() {
+					fakeVMProvider.RevertVirtualMachineToSnapshotFn = func(
+						_ context.Context,
+						_ *vmopv1.VirtualMachine,
+						_ *vmopv1.VirtualMachineSnapshot) error {
+						return errors.New(providerError)
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(reconciler.ReconcileNormal(vmCtx)).ToNot(Succeed())
+					Expect(conditions.GetReason(vm, vmopv1.VirtualMachineSnapshotRevertedCondition)).To(
+						Equal(vmopv1.VirtualMachineSnapshotRevertFailedReason))
+					expectEvents(ctx, "RevertSnapshotFailure")
+				})
+			})
+		})
 	})
 
 	Context("ReconcileDelete", func() {
@@ -0,0 +1,341 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineclone
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/instancestorage"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineClone{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		Watches(&vmopv1.VirtualMachine{},
+			handler.EnqueueRequestsFromMapFunc(r.VMToClones(ctx)),
+		).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+// VMToClones is a mapper function to be used to enqueue requests for
+// reconciliation for the VirtualMachineClones whose source or target is a VM.
+func (r *Reconciler) VMToClones(
+	ctx *pkgctx.ControllerManagerContext) func(_ context.Context, o client.Object) []reconcile.Request {
+
+	return func(_ context.Context, o client.Object) []reconcile.Request {
+		vm, ok := o.(*vmopv1.VirtualMachine)
+		if !ok {
+			panic(fmt.Sprintf("Expected a VirtualMachine, but got a %T", o))
+		}
+
+		cloneList := &vmopv1.VirtualMachineCloneList{}
+		if err := r.Client.List(ctx, cloneList, client.InNamespace(vm.Namespace)); err != nil {
+			ctx.Logger.Error(err, "Failed listing VirtualMachineClones for VM")
+			return nil
+		}
+
+		var result []reconcile.Request
+		for _, c := range cloneList.Items {
+			if c.Spec.Source.Name == vm.Name || targetName(&c) == vm.Name {
+				result = append(result, reconcile.Request{
+					NamespacedName: client.ObjectKey{Name: c.Name, Namespace: c.Namespace},
+				})
+			}
+		}
+
+		return result
+	}
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder) *Reconciler {
+
+	return &Reconciler{
+		Context:  ctx,
+		Client:   client,
+		Logger:   logger,
+		Recorder: recorder,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineClone object.
+type Reconciler struct {
+	client.Client
+	Context  context.Context
+	Logger   logr.Logger
+	Recorder record.Recorder
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineclones,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineclones/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch;create
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	vmClone := &vmopv1.VirtualMachineClone{}
+	if err := r.Get(ctx, req.NamespacedName, vmClone); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !vmClone.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	cloneCtx := &pkgctx.VirtualMachineCloneContext{
+		Context: ctx,
+		Logger:  ctrl.Log.WithName("VirtualMachineClone").WithValues("namespace", vmClone.Namespace, "name", vmClone.Name),
+		VMClone: vmClone,
+	}
+
+	patchHelper, err := patch.NewHelper(vmClone, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", cloneCtx.String(), err)
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, vmClone); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			cloneCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if err := r.ReconcileNormal(cloneCtx); err != nil {
+		cloneCtx.Logger.Error(err, "Failed to reconcile VirtualMachineClone")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineCloneContext) error {
+	ctx.Logger.V(4).Info("Reconciling VirtualMachineClone")
+
+	vmClone := ctx.VMClone
+
+	// A completed clone is never reconciled again, even if the target VM is
+	// later deleted.
+	if vmClone.Status.Ready {
+		return nil
+	}
+
+	if vmClone.Status.StartTime.IsZero() {
+		vmClone.Status.StartTime = metav1.Now()
+	}
+	vmClone.Status.TargetRef = targetName(vmClone)
+
+	if ok, err := r.reconcileSource(ctx); err != nil || !ok {
+		return err
+	}
+
+	targetVM, err := r.reconcileTarget(ctx)
+	if err != nil || targetVM == nil {
+		return err
+	}
+
+	if targetVM.Status.UniqueID == "" {
+		conditions.MarkFalse(
+			vmClone,
+			vmopv1.VirtualMachineCloneConditionComplete,
+			vmopv1.TargetVirtualMachineNotCreatedReason,
+			"VM %s has not been created yet", targetVM.Name)
+		return nil
+	}
+
+	conditions.MarkTrue(vmClone, vmopv1.VirtualMachineCloneConditionComplete)
+	vmClone.Status.Ready = true
+	vmClone.Status.CompletionTime = metav1.Now()
+	r.Recorder.EmitEvent(vmClone, "Clone", nil, false)
+
+	return nil
+}
+
+// reconcileSource validates the source VM and stores it in the context. It
+// returns false when the source VM cannot be cloned yet.
+func (r *Reconciler) reconcileSource(ctx *pkgctx.VirtualMachineCloneContext) (bool, error) {
+	vmClone := ctx.VMClone
+
+	sourceVM := &vmopv1.VirtualMachine{}
+	key := client.ObjectKey{Namespace: vmClone.Namespace, Name: vmClone.Spec.Source.Name}
+	if err := r.Get(ctx, key, sourceVM); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		conditions.MarkFalse(
+			vmClone,
+			vmopv1.VirtualMachineCloneConditionSourceValid,
+			vmopv1.SourceVirtualMachineNotExistReason,
+			"VM %s does not exist", key.Name)
+		return false, nil
+	}
+
+	if sourceVM.Status.UniqueID == "" {
+		conditions.MarkFalse(
+			vmClone,
+			vmopv1.VirtualMachineCloneConditionSourceValid,
+			vmopv1.SourceVirtualMachineNotCreatedReason,
+			"VM %s has not been created yet", key.Name)
+		return false, nil
+	}
+
+	var notSupportedMsg string
+	switch {
+	case instancestorage.IsPresent(sourceVM):
+		notSupportedMsg = "VM %s has instance storage"
+	case vmClone.Spec.Type == vmopv1.VirtualMachineCloneTypeLinked && sourceVM.Status.CurrentSnapshot == nil:
+		notSupportedMsg = "VM %s does not have a snapshot required for a linked clone"
+	}
+	if notSupportedMsg != "" {
+		conditions.MarkFalse(
+			vmClone,
+			vmopv1.VirtualMachineCloneConditionSourceValid,
+			vmopv1.SourceVirtualMachineNotSupportedReason,
+			notSupportedMsg, key.Name)
+		return false, nil
+	}
+
+	conditions.MarkTrue(vmClone, vmopv1.VirtualMachineCloneConditionSourceValid)
+	ctx.SourceVM = sourceVM
+
+	return true, nil
+}
+
+// reconcileTarget returns the target VM, creating it if it does not exist.
+// It returns nil when the target name is used by a VM that was not created
+// by this clone.
+func (r *Reconciler) reconcileTarget(ctx *pkgctx.VirtualMachineCloneContext) (*vmopv1.VirtualMachine, error) {
+	vmClone := ctx.VMClone
+
+	targetVM := &vmopv1.VirtualMachine{}
+	key := client.ObjectKey{Namespace: vmClone.Namespace, Name: targetName(vmClone)}
+	if err := r.Get(ctx, key, targetVM); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		targetVM = newTargetVM(vmClone, ctx.SourceVM)
+		if err := r.Create(ctx, targetVM); err != nil {
+			return nil, err
+		}
+		ctx.Logger.Info("Created clone VM", "targetVM", key.Name)
+	} else if targetVM.Annotations[vmopv1.CloneSourceAnnotation] != ctx.SourceVM.Name {
+		conditions.MarkFalse(
+			vmClone,
+			vmopv1.VirtualMachineCloneConditionTargetValid,
+			vmopv1.TargetVirtualMachineAlreadyExistsReason,
+			"VM %s already exists", key.Name)
+		return nil, nil
+	}
+
+	conditions.MarkTrue(vmClone, vmopv1.VirtualMachineCloneConditionTargetValid)
+
+	return targetVM, nil
+}
+
+// newTargetVM returns the VM to create for the clone. The provider clones the
+// VM from the source VM identified by the CloneSourceAnnotation.
+func newTargetVM(
+	vmClone *vmopv1.VirtualMachineClone,
+	sourceVM *vmopv1.VirtualMachine) *vmopv1.VirtualMachine {
+
+	cloneType := vmClone.Spec.Type
+	if cloneType == "" {
+		cloneType = vmopv1.VirtualMachineCloneTypeFull
+	}
+
+	vm := &vmopv1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      targetName(vmClone),
+			Namespace: vmClone.Namespace,
+			Annotations: map[string]string{
+				vmopv1.CloneSourceAnnotation: sourceVM.Name,
+				vmopv1.CloneTypeAnnotation:   string(cloneType),
+			},
+		},
+		Spec: *sourceVM.Spec.DeepCopy(),
+	}
+
+	// The clone is placed in the same zone as the source VM since it is
+	// cloned from the source VM's disks.
+	if zone := sourceVM.Labels[topology.KubernetesTopologyZoneLabelKey]; zone != "" {
+		vm.Labels = map[string]string{
+			topology.KubernetesTopologyZoneLabelKey: zone,
+		}
+	}
+
+	// The clone gets its own identity. The UUIDs are defaulted when the VM is
+	// created.
+	vm.Spec.BiosUUID = ""
+	vm.Spec.InstanceUUID = ""
+	vm.Spec.CurrentSnapshot = nil
+
+	// Volumes backed by PVCs belong to the source VM.
+	vm.Spec.Volumes = nil
+
+	// Static addresses cannot be shared with the source VM.
+	if vm.Spec.Network != nil {
+		for i := range vm.Spec.Network.Interfaces {
+			vm.Spec.Network.Interfaces[i].Addresses = nil
+		}
+	}
+
+	if vmClone.Spec.PowerState != "" {
+		vm.Spec.PowerState = vmClone.Spec.PowerState
+	}
+
+	if vmClone.Spec.Bootstrap != nil {
+		vm.Spec.Bootstrap = vmClone.Spec.Bootstrap.DeepCopy()
+	}
+
+	return vm
+}
+
+func targetName(vmClone *vmopv1.VirtualMachineClone) string {
+	if name := vmClone.Spec.Target.Name; name != "" {
+		return name
+	}
+	return vmClone.Name
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineclone_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.V1Alpha3,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx *builder.IntegrationTestContext
+
+		vmClone  *vmopv1.VirtualMachineClone
+		cloneKey types.NamespacedName
+		sourceVM *vmopv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		sourceVM = builder.DummyVirtualMachine()
+		sourceVM.Name = "dummy-source-vm"
+		sourceVM.Namespace = ctx.Namespace
+
+		vmClone = builder.DummyVirtualMachineClone("dummy-clone", ctx.Namespace, sourceVM.Name)
+		cloneKey = types.NamespacedName{Name: vmClone.Name, Namespace: vmClone.Namespace}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	getClone := func(g Gomega) *vmopv1.VirtualMachineClone {
+		c := &vmopv1.VirtualMachineClone{}
+		g.Expect(ctx.Client.Get(ctx, cloneKey, c)).To(Succeed())
+		return c
+	}
+
+	// setUniqueID updates the status of the VM as if it was created on the
+	// underlying infrastructure.
+	setUniqueID := func(vm *vmopv1.VirtualMachine, id string) {
+		Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), vm)).To(Succeed())
+		vm.Status.UniqueID = id
+		Expect(ctx.Client.Status().Update(ctx, vm)).To(Succeed())
+	}
+
+	Context("Reconcile", func() {
+		BeforeEach(func() {
+			Expect(ctx.Client.Create(ctx, sourceVM)).To(Succeed())
+			Expect(ctx.Client.Create(ctx, vmClone)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(client.IgnoreNotFound(ctx.Client.Delete(ctx, vmClone))).To(Succeed())
+			Expect(client.IgnoreNotFound(ctx.Client.Delete(ctx, sourceVM))).To(Succeed())
+		})
+
+		It("Creates the clone once the source VM has been created", func() {
+			Eventually(func(g Gomega) {
+				c := getClone(g)
+				g.Expect(conditions.GetReason(c, vmopv1.VirtualMachineCloneConditionSourceValid)).To(
+					Equal(vmopv1.SourceVirtualMachineNotCreatedReason))
+			}).Should(Succeed())
+
+			By("The source VM being created", func() {
+				setUniqueID(sourceVM, "vm-42")
+			})
+
+			targetVM := &vmopv1.VirtualMachine{}
+			Eventually(func(g Gomega) {
+				g.Expect(ctx.Client.Get(ctx, cloneKey, targetVM)).To(Succeed())
+				g.Expect(targetVM.Annotations).To(HaveKeyWithValue(vmopv1.CloneSourceAnnotation, sourceVM.Name))
+			}).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				c := getClone(g)
+				g.Expect(conditions.GetReason(c, vmopv1.VirtualMachineCloneConditionComplete)).To(
+					Equal(vmopv1.TargetVirtualMachineNotCreatedReason))
+			}).Should(Succeed())
+
+			By("The target VM being created", func() {
+				setUniqueID(targetVM, "vm-43")
+			})
+
+			Eventually(func(g Gomega) {
+				c := getClone(g)
+				g.Expect(c.Status.Ready).To(BeTrue())
+				g.Expect(conditions.IsTrue(c, vmopv1.VirtualMachineCloneConditionComplete)).To(BeTrue())
+			}).Should(Succeed())
+
+			Expect(ctx.Client.Delete(ctx, targetVM)).To(Succeed())
+		})
+	})
+}
@@ -0,0 +1,329 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineclone_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineclone"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.V1Alpha3,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+
+		reconciler *virtualmachineclone.Reconciler
+		vmClone    *vmopv1.VirtualMachineClone
+		cloneCtx   *pkgctx.VirtualMachineCloneContext
+		sourceVM   *vmopv1.VirtualMachine
+	)
+
+	getTargetVM := func() *vmopv1.VirtualMachine {
+		vm := &vmopv1.VirtualMachine{}
+		key := client.ObjectKey{Namespace: vmClone.Namespace, Name: vmClone.Status.TargetRef}
+		Expect(ctx.Client.Get(ctx, key, vm)).To(Succeed())
+		return vm
+	}
+
+	BeforeEach(func() {
+		sourceVM = builder.DummyVirtualMachine()
+		sourceVM.Name = "dummy-source-vm"
+		sourceVM.Namespace = "dummy-ns"
+		sourceVM.Labels[topology.KubernetesTopologyZoneLabelKey] = "zone-1"
+		sourceVM.Labels["app"] = "dummy"
+		sourceVM.Spec.BiosUUID = "dummy-bios-uuid"
+		sourceVM.Spec.InstanceUUID = "dummy-instance-uuid"
+		sourceVM.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+			CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{
+				InstanceID: "dummy-bios-uuid",
+			},
+		}
+		sourceVM.Spec.Network.Interfaces[0].Addresses = []string{"192.168.1.10/24"}
+		sourceVM.Status.UniqueID = "vm-42"
+
+		vmClone = builder.DummyVirtualMachineClone("dummy-clone", sourceVM.Namespace, sourceVM.Name)
+
+		initObjects = nil
+	})
+
+	JustBeforeEach(func() {
+		initObjects = append(initObjects, vmClone)
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = virtualmachineclone.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+		)
+		cloneCtx = &pkgctx.VirtualMachineCloneContext{
+			Context: ctx,
+			Logger:  ctx.Logger.WithName(vmClone.Name),
+			VMClone: vmClone,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+	})
+
+	Context("ReconcileNormal", func() {
+
+		When("the source VM does not exist", func() {
+			It("marks the source as invalid", func() {
+				Expect(reconciler.ReconcileNormal(cloneCtx)).To(Succeed())
+
+				Expect(vmClone.Status.StartTime.IsZero()).To(BeFalse())
+				Expect(vmClone.Status.TargetRef).To(Equal(vmClone.Name))
+				c := conditions.Get(vmClone, vmopv1.VirtualMachineCloneConditionSourceValid)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.SourceVirtualMachineNotExistReason))
+			})
+		})
+
+		When("the source VM has not been created", func() {
+			BeforeEach(func() {
+				sourceVM.Status.UniqueID = ""
+				initObjects = append(initObjects, sourceVM)
+			})
+
+			It("marks the source as invalid", func() {
+				Expect(reconciler.ReconcileNormal(cloneCtx)).To(Succeed())
+
+				c := conditions.Get(vmClone, vmopv1.VirtualMachineCloneConditionSourceValid)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Reason).To(Equal(vmopv1.SourceVirtualMachineNotCreatedReason))
+			})
+		})
+
+		When("the source VM has instance storage", func() {
+			BeforeEach(func() {
+				builder.AddDummyInstanceStorageVolume(sourceVM)
+				initObjects = append(initObjects, sourceVM)
+			})
+
+			It("marks the source as not supported", func() {
+				Expect(reconciler.ReconcileNormal(cloneCtx)).To(Succeed())
+
+				c := conditions.Get(vmClone, vmopv1.VirtualMachineCloneConditionSourceValid)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Reason).To(Equal(vmopv1.SourceVirtualMachineNotSupportedReason))
+			})
+		})
+
+		When("a linked clone is requested", func() {
+			BeforeEach(func() {
+				vmClone.Spec.Type = vmopv1.VirtualMachineCloneTypeLinked
+				initObjects = append(initObjects, sourceVM)
+			})
+
+			It("requires the source VM to have a snapshot", func() {
+				Expect(reconciler.ReconcileNormal(cloneCtx)).To(Succeed())
+
+				c := conditions.Get(vmClone, vmopv1.VirtualMachineCloneConditionSourceValid)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Reason).To(Equal(vmopv1.SourceVirtualMachineNotSupportedReason))
+			})
+
+			When("the source VM has a snapshot", func() {
+				BeforeEach(func() {
+					sourceVM.Status.CurrentSnapshot = &vmopv1common.LocalObjectRef{
+						Kind: "VirtualMachineSnapshot",
+						Name: "dummy-snapshot",
+					}
+				})
+
+				It("creates the target VM", func() {
+					Expect(reconciler.ReconcileNormal(cloneCtx)).To(Succeed())
+
+					Expect(conditions.IsTrue(vmClone, vmopv1.VirtualMachineCloneConditionSourceValid)).To(BeTrue())
+					vm := getTargetVM()
+					Expect(vm.Annotations).To(HaveKeyWithValue(vmopv1.CloneTypeAnnotation, string(vmopv1.VirtualMachineCloneTypeLinked)))
+				})
+			})
+		})
+
+		When("the source VM can be cloned", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, sourceVM)
+			})
+
+			It("creates the target VM from the source VM", func() {
+				Expect(reconciler.ReconcileNormal(cloneCtx)).To(Succeed())
+
+				Expect(conditions.IsTrue(vmClone, vmopv1.VirtualMachineCloneConditionSourceValid)).To(BeTrue())
+				Expect(conditions.IsTrue(vmClone, vmopv1.VirtualMachineCloneConditionTargetValid)).To(BeTrue())
+				c := conditions.Get(vmClone, vmopv1.VirtualMachineCloneConditionComplete)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Reason).To(Equal(vmopv1.TargetVirtualMachineNotCreatedReason))
+				Expect(vmClone.Status.Ready).To(BeFalse())
+
+				vm := getTargetVM()
+				Expect(vm.Annotations).To(HaveKeyWithValue(vmopv1.CloneSourceAnnotation, sourceVM.Name))
+				Expect(vm.Annotations).To(HaveKeyWithValue(vmopv1.CloneTypeAnnotation, string(vmopv1.VirtualMachineCloneTypeFull)))
+				Expect(vm.Labels).To(Equal(map[string]string{topology.KubernetesTopologyZoneLabelKey: "zone-1"}))
+				Expect(vm.Spec.ClassName).To(Equal(sourceVM.Spec.ClassName))
+				Expect(vm.Spec.Image).To(Equal(sourceVM.Spec.Image))
+				Expect(vm.Spec.StorageClass).To(Equal(sourceVM.Spec.StorageClass))
+				Expect(vm.Spec.PowerState).To(Equal(sourceVM.Spec.PowerState))
+				Expect(vm.Spec.BiosUUID).To(BeEmpty())
+				Expect(vm.Spec.InstanceUUID).To(BeEmpty())
+				Expect(vm.Spec.Volumes).To(BeEmpty())
+				Expect(vm.Spec.Network.Interfaces).To(HaveLen(len(sourceVM.Spec.Network.Interfaces)))
+				Expect(vm.Spec.Network.Interfaces[0].Addresses).To(BeEmpty())
+				Expect(vm.Spec.Bootstrap).To(Equal(sourceVM.Spec.Bootstrap))
+			})
+
+			When("the clone specifies the power state and bootstrap", func() {
+				BeforeEach(func() {
+					vmClone.Spec.Target.Name = "my-clone"
+					vmClone.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+					vmClone.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+						CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{},
+					}
+				})
+
+				It("creates the target VM with the overrides", func() {
+					Expect(reconciler.ReconcileNormal(cloneCtx)).To(Succeed())
+
+					Expect(vmClone.Status.TargetRef).To(Equal("my-clone"))
+					vm := getTargetVM()
+					Expect(vm.Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+					Expect(vm.Spec.Bootstrap).To(Equal(vmClone.Spec.Bootstrap))
+				})
+			})
+
+			When("the target VM has been created", func() {
+				BeforeEach(func() {
+					targetVM := builder.DummyVirtualMachine()
+					targetVM.Name = vmClone.Name
+					targetVM.Namespace = vmClone.Namespace
+					targetVM.Annotations = map[string]string{vmopv1.CloneSourceAnnotation: sourceVM.Name}
+					targetVM.Status.UniqueID = "vm-43"
+					initObjects = append(initObjects, targetVM)
+				})
+
+				It("completes the clone", func() {
+					Expect(reconciler.ReconcileNormal(cloneCtx)).To(Succeed())
+
+					Expect(conditions.IsTrue(vmClone, vmopv1.VirtualMachineCloneConditionComplete)).To(BeTrue())
+					Expect(vmClone.Status.Ready).To(BeTrue())
+					Expect(vmClone.Status.CompletionTime.IsZero()).To(BeFalse())
+				})
+			})
+
+			When("a different VM with the target name exists", func() {
+				BeforeEach(func() {
+					targetVM := builder.DummyVirtualMachine()
+					targetVM.Name = vmClone.Name
+					targetVM.Namespace = vmClone.Namespace
+					initObjects = append(initObjects, targetVM)
+				})
+
+				It("marks the target as invalid", func() {
+					Expect(reconciler.ReconcileNormal(cloneCtx)).To(Succeed())
+
+					c := conditions.Get(vmClone, vmopv1.VirtualMachineCloneConditionTargetValid)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Status).To(Equal(metav1.ConditionFalse))
+					Expect(c.Reason).To(Equal(vmopv1.TargetVirtualMachineAlreadyExistsReason))
+					Expect(vmClone.Status.Ready).To(BeFalse())
+				})
+			})
+		})
+
+		When("the clone has completed", func() {
+			BeforeEach(func() {
+				vmClone.Status.Ready = true
+				initObjects = append(initObjects, sourceVM)
+			})
+
+			It("does not recreate the target VM", func() {
+				Expect(reconciler.ReconcileNormal(cloneCtx)).To(Succeed())
+
+				vm := &vmopv1.VirtualMachine{}
+				key := client.ObjectKey{Namespace: vmClone.Namespace, Name: vmClone.Name}
+				Expect(ctx.Client.Get(ctx, key, vm)).ToNot(Succeed())
+			})
+		})
+	})
+
+	Context("VMToClones", func() {
+		var (
+			vm     *vmopv1.VirtualMachine
+			mapper func(context.Context, client.Object) []reconcile.Request
+		)
+
+		BeforeEach(func() {
+			vm = sourceVM
+		})
+
+		JustBeforeEach(func() {
+			mapper = reconciler.VMToClones(&pkgctx.ControllerManagerContext{
+				Context: ctx,
+				Logger:  ctx.Logger,
+			})
+		})
+
+		It("returns the clones of the source VM", func() {
+			Expect(mapper(ctx, vm)).To(ConsistOf(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: vmClone.Namespace, Name: vmClone.Name},
+			}))
+		})
+
+		When("the VM is the target of the clone", func() {
+			BeforeEach(func() {
+				vm = builder.DummyVirtualMachine()
+				vm.Name = vmClone.Name
+				vm.Namespace = vmClone.Namespace
+			})
+
+			It("returns the clone", func() {
+				Expect(mapper(ctx, vm)).To(HaveLen(1))
+			})
+		})
+
+		When("the VM is not related to the clone", func() {
+			BeforeEach(func() {
+				vm = builder.DummyVirtualMachine()
+				vm.Name = "other-vm"
+				vm.Namespace = vmClone.Namespace
+			})
+
+			It("returns no requests", func() {
+				Expect(mapper(ctx, vm)).To(BeEmpty())
+			})
+		})
+	})
+}
@@ -0,0 +1,449 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinedeployment
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
+)
+
+var (
+	// deploymentKind contains the schema.GroupVersionKind for the VirtualMachineDeployment type.
+	deploymentKind = vmopv1.GroupVersion.WithKind("VirtualMachineDeployment")
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineDeployment{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		Owns(&vmopv1.VirtualMachineReplicaSet{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder) *Reconciler {
+
+	return &Reconciler{
+		Context:  ctx,
+		Client:   client,
+		Logger:   logger,
+		Recorder: recorder,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineDeployment object.
+type Reconciler struct {
+	client.Client
+	Context  context.Context
+	Logger   logr.Logger
+	Recorder record.Recorder
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinedeployments,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinedeployments/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinereplicasets,verbs=get;list;watch;create;update;patch;delete
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	deployment := &vmopv1.VirtualMachineDeployment{}
+	if err := r.Get(ctx, req.NamespacedName, deployment); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// The VirtualMachineReplicaSets are garbage collected via their owner
+	// reference, so there is nothing to do once the deployment is deleted.
+	if !deployment.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	deploymentCtx := &pkgctx.VirtualMachineDeploymentContext{
+		Context:    ctx,
+		Logger:     ctrl.Log.WithName("VirtualMachineDeployment").WithValues("namespace", deployment.Namespace, "name", deployment.Name),
+		Deployment: deployment,
+	}
+
+	patchHelper, err := patch.NewHelper(deployment, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", deploymentCtx.String(), err)
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, deployment); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			deploymentCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if err := r.ReconcileNormal(deploymentCtx); err != nil {
+		deploymentCtx.Logger.Error(err, "Failed to reconcile VirtualMachineDeployment")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineDeploymentContext) error {
+	ctx.Logger.Info("Reconciling VirtualMachineDeployment")
+
+	d := ctx.Deployment
+
+	rsList, err := r.getReplicaSetsForDeployment(ctx)
+	if err != nil {
+		return err
+	}
+
+	// A rollback only updates the template of the deployment. The new
+	// template is rolled out once the deployment has been patched.
+	if d.Spec.RollbackTo != nil {
+		r.rollback(ctx, rsList)
+		return nil
+	}
+
+	newRS, oldRSs := findNewReplicaSet(d, rsList)
+
+	if d.Spec.Paused {
+		// Only scale the current revision while paused so the replica
+		// count can be changed without rolling out the template.
+		if newRS != nil && sumReplicas(oldRSs) == 0 {
+			if err := r.scaleReplicaSet(ctx, newRS, getDesiredReplicas(d)); err != nil {
+				return err
+			}
+		}
+
+		r.updateStatus(ctx, newRS, oldRSs)
+		return nil
+	}
+
+	if newRS, err = r.getOrCreateNewReplicaSet(ctx, newRS, oldRSs); err != nil {
+		return err
+	}
+
+	var rolloutErr error
+	if d.Spec.Strategy.Type == vmopv1.RecreateVirtualMachineDeploymentStrategyType {
+		rolloutErr = r.rolloutRecreate(ctx, newRS, oldRSs)
+	} else {
+		rolloutErr = r.rolloutRolling(ctx, newRS, oldRSs)
+	}
+
+	// Update the status of the VirtualMachineDeployment even in case of error
+	// since the rollout might have scaled some of the replica sets.
+	r.updateStatus(ctx, newRS, oldRSs)
+
+	if rolloutErr != nil {
+		return fmt.Errorf("failed to roll out VirtualMachineDeployment: %w", rolloutErr)
+	}
+
+	return r.cleanupOldReplicaSets(ctx, oldRSs)
+}
+
+// getReplicaSetsForDeployment returns the VirtualMachineReplicaSets that are
+// controlled by the deployment.
+func (r *Reconciler) getReplicaSetsForDeployment(
+	ctx *pkgctx.VirtualMachineDeploymentContext) ([]*vmopv1.VirtualMachineReplicaSet, error) {
+
+	rsList := &vmopv1.VirtualMachineReplicaSetList{}
+	if err := r.Client.List(ctx, rsList, client.InNamespace(ctx.Deployment.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list VirtualMachineReplicaSets: %w", err)
+	}
+
+	var rss []*vmopv1.VirtualMachineReplicaSet
+	for i := range rsList.Items {
+		rs := &rsList.Items[i]
+		if metav1.IsControlledBy(rs, ctx.Deployment) {
+			rss = append(rss, rs)
+		}
+	}
+
+	return rss, nil
+}
+
+// rollback replaces the template of the deployment with the template of the
+// revision requested in spec.rollbackTo and clears spec.rollbackTo.
+func (r *Reconciler) rollback(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	rsList []*vmopv1.VirtualMachineReplicaSet) {
+
+	d := ctx.Deployment
+
+	revision := d.Spec.RollbackTo.Revision
+	if revision == 0 {
+		revision = previousRevision(rsList)
+	}
+
+	d.Spec.RollbackTo = nil
+
+	for _, rs := range rsList {
+		if revision == 0 || getRevision(rs) != revision {
+			continue
+		}
+
+		template := templateWithoutHash(&rs.Spec.Template)
+		if equalTemplates(&d.Spec.Template, template) {
+			ctx.Logger.Info("Template of the rollback revision is already in use", "revision", revision)
+			r.Recorder.Eventf(d, "RollbackTemplateUnchanged", "The rollback revision %d contains the current template", revision)
+			return
+		}
+
+		ctx.Logger.Info("Rolling back VirtualMachineDeployment", "revision", revision)
+		d.Spec.Template = *template
+		r.Recorder.Eventf(d, "RollbackDone", "Rolled back to revision %d", revision)
+		return
+	}
+
+	ctx.Logger.Info("Unable to find the rollback revision", "revision", revision)
+	r.Recorder.Warnf(d, "RollbackRevisionNotFound", "Unable to find the revision %d to roll back to", revision)
+}
+
+// getOrCreateNewReplicaSet returns the VirtualMachineReplicaSet for the
+// current template of the deployment, creating it if it does not exist. The
+// revision of the returned VirtualMachineReplicaSet is always newer than the
+// revisions of the old VirtualMachineReplicaSets.
+func (r *Reconciler) getOrCreateNewReplicaSet(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet) (*vmopv1.VirtualMachineReplicaSet, error) {
+
+	d := ctx.Deployment
+	revision := maxRevision(oldRSs) + 1
+
+	if newRS != nil {
+		// The template of an old revision is used again, for example as a
+		// result of a rollback, so it becomes the newest revision.
+		if getRevision(newRS) < revision {
+			patch := client.MergeFrom(newRS.DeepCopy())
+			setRevision(newRS, revision)
+			if err := r.Client.Patch(ctx, newRS, patch); err != nil {
+				return nil, fmt.Errorf("failed to update the revision of VirtualMachineReplicaSet %q: %w", newRS.Name, err)
+			}
+		}
+
+		return newRS, nil
+	}
+
+	hash := computeTemplateHash(&d.Spec.Template)
+
+	template := d.Spec.Template.DeepCopy()
+	if template.Labels == nil {
+		template.Labels = make(map[string]string)
+	}
+	template.Labels[vmopv1.VirtualMachineTemplateHashLabel] = hash
+
+	selector := d.Spec.Selector.DeepCopy()
+	if selector.MatchLabels == nil {
+		selector.MatchLabels = make(map[string]string)
+	}
+	selector.MatchLabels[vmopv1.VirtualMachineTemplateHashLabel] = hash
+
+	labels := make(map[string]string, len(template.Labels)+1)
+	maps.Copy(labels, template.Labels)
+	labels[vmopv1.VirtualMachineDeploymentNameLabel] = util.MustFormatValue(d.Name)
+
+	rs := &vmopv1.VirtualMachineReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            fmt.Sprintf("%s-%s", d.Name, hash),
+			Namespace:       d.Namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(d, deploymentKind)},
+		},
+		Spec: vmopv1.VirtualMachineReplicaSetSpec{
+			// The new replica set is scaled up by the rollout.
+			Replicas: new(int32),
+			Selector: selector,
+			Template: *template,
+		},
+	}
+	setRevision(rs, revision)
+
+	if err := r.Client.Create(ctx, rs); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			// Either the cache has not observed the replica set yet or the
+			// hash of the template collides with another replica set.
+			return nil, fmt.Errorf("VirtualMachineReplicaSet %q already exists: %w", rs.Name, err)
+		}
+		r.Recorder.Warnf(d, "FailedCreate", "Failed to create VirtualMachineReplicaSet %q: %v", rs.Name, err)
+		return nil, fmt.Errorf("failed to create VirtualMachineReplicaSet %q: %w", rs.Name, err)
+	}
+
+	ctx.Logger.Info("Created VirtualMachineReplicaSet", "replicaSet", rs.Name, "revision", revision)
+	r.Recorder.Eventf(d, "SuccessfulCreate", "Created VirtualMachineReplicaSet %q with revision %d", rs.Name, revision)
+
+	return rs, nil
+}
+
+// scaleReplicaSet sets the replicas of the VirtualMachineReplicaSet.
+func (r *Reconciler) scaleReplicaSet(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	rs *vmopv1.VirtualMachineReplicaSet,
+	replicas int32) error {
+
+	oldReplicas := getReplicas(rs)
+	if oldReplicas == replicas {
+		return nil
+	}
+
+	patch := client.MergeFrom(rs.DeepCopy())
+	rs.Spec.Replicas = &replicas
+	if err := r.Client.Patch(ctx, rs, patch); err != nil {
+		r.Recorder.Warnf(ctx.Deployment, "FailedScale", "Failed to scale VirtualMachineReplicaSet %q: %v", rs.Name, err)
+		return fmt.Errorf("failed to scale VirtualMachineReplicaSet %q: %w", rs.Name, err)
+	}
+
+	direction := "up"
+	if replicas < oldReplicas {
+		direction = "down"
+	}
+
+	ctx.Logger.Info("Scaled VirtualMachineReplicaSet",
+		"replicaSet", rs.Name, "oldReplicas", oldReplicas, "newReplicas", replicas)
+	r.Recorder.Eventf(ctx.Deployment, "ScalingReplicaSet", "Scaled %s VirtualMachineReplicaSet %q from %d to %d",
+		direction, rs.Name, oldReplicas, replicas)
+
+	return nil
+}
+
+// cleanupOldReplicaSets deletes the old VirtualMachineReplicaSets that have
+// been scaled down and exceed the revision history limit of the deployment.
+func (r *Reconciler) cleanupOldReplicaSets(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet) error {
+
+	limit := defaultRevisionHistoryLimit
+	if ctx.Deployment.Spec.RevisionHistoryLimit != nil {
+		limit = *ctx.Deployment.Spec.RevisionHistoryLimit
+	}
+
+	rssToDelete := getReplicaSetsToCleanup(oldRSs, int(limit))
+	for _, rs := range rssToDelete {
+		ctx.Logger.Info("Deleting old VirtualMachineReplicaSet", "replicaSet", rs.Name, "revision", getRevision(rs))
+
+		if err := r.Client.Delete(ctx, rs); err != nil && !apierrors.IsNotFound(err) {
+			r.Recorder.Warnf(ctx.Deployment, "FailedDelete", "Failed to delete VirtualMachineReplicaSet %q: %v", rs.Name, err)
+			return fmt.Errorf("failed to delete VirtualMachineReplicaSet %q: %w", rs.Name, err)
+		}
+
+		r.Recorder.Eventf(ctx.Deployment, "SuccessfulDelete", "Deleted VirtualMachineReplicaSet %q", rs.Name)
+	}
+
+	return nil
+}
+
+// updateStatus updates the Status field of the VirtualMachineDeployment.
+func (r *Reconciler) updateStatus(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet) {
+
+	d := ctx.Deployment
+	desiredReplicas := getDesiredReplicas(d)
+
+	allRSs := oldRSs
+	if newRS != nil {
+		allRSs = append(allRSs, newRS)
+	}
+
+	var replicas, readyReplicas int32
+	for _, rs := range allRSs {
+		replicas += rs.Status.Replicas
+		readyReplicas += rs.Status.ReadyReplicas
+	}
+
+	var updatedReplicas int32
+	d.Status.Revision = 0
+	if newRS != nil {
+		updatedReplicas = newRS.Status.Replicas
+		d.Status.Revision = getRevision(newRS)
+	}
+
+	d.Status.ObservedGeneration = d.Generation
+	d.Status.Replicas = replicas
+	d.Status.UpdatedReplicas = updatedReplicas
+	d.Status.ReadyReplicas = readyReplicas
+	d.Status.UnavailableReplicas = max(desiredReplicas-readyReplicas, 0)
+
+	_, maxUnavailable, err := resolveFenceposts(d)
+	if err != nil {
+		// The webhook does not allow an invalid strategy, so fall back to
+		// requiring all of the replicas to be ready.
+		maxUnavailable = 0
+	}
+
+	if readyReplicas >= desiredReplicas-maxUnavailable {
+		conditions.MarkTrue(d, vmopv1.VirtualMachineDeploymentAvailableCondition)
+	} else {
+		conditions.MarkFalse(
+			d,
+			vmopv1.VirtualMachineDeploymentAvailableCondition,
+			vmopv1.MinimumReplicasUnavailableReason,
+			"VirtualMachineDeployment has %d ready replicas and requires at least %d",
+			readyReplicas,
+			desiredReplicas-maxUnavailable)
+	}
+
+	switch {
+	case d.Spec.Paused:
+		conditions.MarkFalse(
+			d,
+			vmopv1.VirtualMachineDeploymentRolledOutCondition,
+			vmopv1.DeploymentPausedReason,
+			"VirtualMachineDeployment is paused")
+	case newRS == nil || updatedReplicas != desiredReplicas ||
+		replicas != desiredReplicas || readyReplicas < desiredReplicas:
+
+		conditions.MarkFalse(
+			d,
+			vmopv1.VirtualMachineDeploymentRolledOutCondition,
+			vmopv1.RollingOutReason,
+			"Rolling out revision %d (%d of %d replicas updated, %d ready)",
+			d.Status.Revision,
+			updatedReplicas,
+			desiredReplicas,
+			readyReplicas)
+	default:
+		if conditions.IsFalse(d, vmopv1.VirtualMachineDeploymentRolledOutCondition) {
+			ctx.Logger.Info("VirtualMachineDeployment rolled out", "revision", d.Status.Revision)
+		}
+		conditions.MarkTrue(d, vmopv1.VirtualMachineDeploymentRolledOutCondition)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinedeployment_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.V1Alpha3,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx *builder.IntegrationTestContext
+
+		deployment    *vmopv1.VirtualMachineDeployment
+		deploymentKey types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		deployment = builder.DummyVirtualMachineDeployment()
+		deployment.GenerateName = ""
+		deployment.Name = "dummy-deployment"
+		deployment.Namespace = ctx.Namespace
+		deployment.Spec.Replicas = ptr.To(int32(2))
+		deploymentKey = types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	getDeployment := func() *vmopv1.VirtualMachineDeployment {
+		d := &vmopv1.VirtualMachineDeployment{}
+		if err := ctx.Client.Get(ctx, deploymentKey, d); err != nil {
+			return nil
+		}
+		return d
+	}
+
+	// getReplicaSetsByRevision returns the replica sets of the deployment keyed
+	// by their revision.
+	getReplicaSetsByRevision := func(g Gomega) map[string]vmopv1.VirtualMachineReplicaSet {
+		rsList := &vmopv1.VirtualMachineReplicaSetList{}
+		g.Expect(ctx.Client.List(ctx, rsList, client.InNamespace(ctx.Namespace))).To(Succeed())
+
+		rss := map[string]vmopv1.VirtualMachineReplicaSet{}
+		for _, rs := range rsList.Items {
+			rss[rs.Annotations[vmopv1.VirtualMachineDeploymentRevisionAnnotation]] = rs
+		}
+		return rss
+	}
+
+	Context("Reconcile", func() {
+		BeforeEach(func() {
+			Expect(ctx.Client.Create(ctx, deployment)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(client.IgnoreNotFound(ctx.Client.Delete(ctx, deployment))).To(Succeed())
+		})
+
+		It("Creates a VirtualMachineReplicaSet for the template", func() {
+			Eventually(func(g Gomega) {
+				rss := getReplicaSetsByRevision(g)
+				g.Expect(rss).To(HaveLen(1))
+				g.Expect(rss).To(HaveKey("1"))
+
+				rs := rss["1"]
+				g.Expect(rs.Spec.Replicas).To(HaveValue(Equal(int32(2))))
+				g.Expect(metav1.IsControlledBy(&rs, getDeployment())).To(BeTrue())
+			}).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				d := getDeployment()
+				g.Expect(d).ToNot(BeNil())
+				g.Expect(d.Status.Revision).To(Equal(int64(1)))
+			}).Should(Succeed())
+		})
+
+		It("Rolls out a template change and rolls it back", func() {
+			By("Waiting for the first revision", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(getReplicaSetsByRevision(g)).To(HaveKey("1"))
+				}).Should(Succeed())
+			})
+
+			By("Changing the template", func() {
+				Eventually(func(g Gomega) {
+					d := getDeployment()
+					g.Expect(d).ToNot(BeNil())
+					d.Spec.Template.Spec.ImageName = "new-image"
+					g.Expect(ctx.Client.Update(ctx, d)).To(Succeed())
+				}).Should(Succeed())
+			})
+
+			By("Surging the second revision while the first revision is not ready", func() {
+				Eventually(func(g Gomega) {
+					rss := getReplicaSetsByRevision(g)
+					g.Expect(rss).To(HaveLen(2))
+					g.Expect(rss).To(HaveKey("2"))
+					g.Expect(rss["2"].Spec.Template.Spec.ImageName).To(Equal("new-image"))
+					g.Expect(rss["2"].Spec.Replicas).To(HaveValue(Equal(int32(1))))
+					g.Expect(rss["1"].Spec.Replicas).To(HaveValue(Equal(int32(2))))
+				}).Should(Succeed())
+			})
+
+			By("Rolling back to the previous revision", func() {
+				Eventually(func(g Gomega) {
+					d := getDeployment()
+					g.Expect(d).ToNot(BeNil())
+					d.Spec.RollbackTo = &vmopv1.VirtualMachineDeploymentRollbackConfig{}
+					g.Expect(ctx.Client.Update(ctx, d)).To(Succeed())
+				}).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					d := getDeployment()
+					g.Expect(d).ToNot(BeNil())
+					g.Expect(d.Spec.RollbackTo).To(BeNil())
+					g.Expect(d.Spec.Template.Spec.ImageName).To(Equal(builder.DummyImageName))
+					g.Expect(d.Status.Revision).To(Equal(int64(3)))
+				}).Should(Succeed())
+			})
+		})
+	})
+}
@@ -0,0 +1,355 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinedeployment_test
+
+import (
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinedeployment"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.V1Alpha3,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+
+		reconciler    *virtualmachinedeployment.Reconciler
+		deployment    *vmopv1.VirtualMachineDeployment
+		deploymentCtx *pkgctx.VirtualMachineDeploymentContext
+	)
+
+	// newReplicaSet returns a replica set owned by the deployment that was
+	// created from the deployment's current template.
+	newReplicaSet := func(name string, revision int64, replicas, readyReplicas int32) *vmopv1.VirtualMachineReplicaSet {
+		rs := &vmopv1.VirtualMachineReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: deployment.Namespace,
+				Annotations: map[string]string{
+					vmopv1.VirtualMachineDeploymentRevisionAnnotation: strconv.FormatInt(revision, 10),
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(deployment, vmopv1.GroupVersion.WithKind("VirtualMachineDeployment")),
+				},
+			},
+			Spec: vmopv1.VirtualMachineReplicaSetSpec{
+				Replicas: ptr.To(replicas),
+				Selector: deployment.Spec.Selector.DeepCopy(),
+				Template: *deployment.Spec.Template.DeepCopy(),
+			},
+			Status: vmopv1.VirtualMachineReplicaSetStatus{
+				Replicas:      replicas,
+				ReadyReplicas: readyReplicas,
+			},
+		}
+		rs.Spec.Template.Labels[vmopv1.VirtualMachineTemplateHashLabel] = name
+		return rs
+	}
+
+	getReplicaSet := func(name string) *vmopv1.VirtualMachineReplicaSet {
+		rs := &vmopv1.VirtualMachineReplicaSet{}
+		key := types.NamespacedName{Namespace: deployment.Namespace, Name: name}
+		if err := ctx.Client.Get(ctx, key, rs); err != nil {
+			return nil
+		}
+		return rs
+	}
+
+	listReplicaSets := func() []vmopv1.VirtualMachineReplicaSet {
+		rsList := &vmopv1.VirtualMachineReplicaSetList{}
+		Expect(ctx.Client.List(ctx, rsList, client.InNamespace(deployment.Namespace))).To(Succeed())
+		return rsList.Items
+	}
+
+	BeforeEach(func() {
+		deployment = builder.DummyVirtualMachineDeployment()
+		deployment.GenerateName = ""
+		deployment.Name = "dummy-deployment"
+		deployment.Namespace = "dummy-ns"
+		deployment.UID = "dummy-deployment-uid"
+		deployment.Spec.Replicas = ptr.To(int32(3))
+
+		initObjects = nil
+	})
+
+	JustBeforeEach(func() {
+		initObjects = append(initObjects, deployment)
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = virtualmachinedeployment.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+		)
+		deploymentCtx = &pkgctx.VirtualMachineDeploymentContext{
+			Context:    ctx,
+			Logger:     ctx.Logger.WithName(deployment.Name),
+			Deployment: deployment,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+	})
+
+	Context("ReconcileNormal", func() {
+
+		When("the deployment does not have a replica set", func() {
+			It("creates the first revision with all of the replicas", func() {
+				Expect(reconciler.ReconcileNormal(deploymentCtx)).To(Succeed())
+
+				rsList := listReplicaSets()
+				Expect(rsList).To(HaveLen(1))
+
+				rs := rsList[0]
+				Expect(metav1.IsControlledBy(&rs, deployment)).To(BeTrue())
+				Expect(rs.Annotations).To(HaveKeyWithValue(vmopv1.VirtualMachineDeploymentRevisionAnnotation, "1"))
+				Expect(rs.Labels).To(HaveKeyWithValue(vmopv1.VirtualMachineDeploymentNameLabel, deployment.Name))
+				Expect(rs.Spec.Template.Labels).To(HaveKey(vmopv1.VirtualMachineTemplateHashLabel))
+				Expect(rs.Spec.Selector.MatchLabels).To(HaveKeyWithValue(vmopv1.VirtualMachineTemplateHashLabel,
+					rs.Spec.Template.Labels[vmopv1.VirtualMachineTemplateHashLabel]))
+				Expect(rs.Spec.Replicas).To(HaveValue(Equal(int32(3))))
+
+				Expect(deployment.Status.Revision).To(Equal(int64(1)))
+				Expect(conditions.IsFalse(deployment, vmopv1.VirtualMachineDeploymentRolledOutCondition)).To(BeTrue())
+				Expect(conditions.GetReason(deployment, vmopv1.VirtualMachineDeploymentRolledOutCondition)).To(
+					Equal(vmopv1.RollingOutReason))
+			})
+		})
+
+		When("all of the replicas of the current revision are ready", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, newReplicaSet("rs-1", 1, 3, 3))
+			})
+
+			It("marks the deployment as rolled out", func() {
+				Expect(reconciler.ReconcileNormal(deploymentCtx)).To(Succeed())
+
+				Expect(listReplicaSets()).To(HaveLen(1))
+				Expect(deployment.Status.Replicas).To(Equal(int32(3)))
+				Expect(deployment.Status.UpdatedReplicas).To(Equal(int32(3)))
+				Expect(deployment.Status.ReadyReplicas).To(Equal(int32(3)))
+				Expect(deployment.Status.UnavailableReplicas).To(BeZero())
+				Expect(conditions.IsTrue(deployment, vmopv1.VirtualMachineDeploymentAvailableCondition)).To(BeTrue())
+				Expect(conditions.IsTrue(deployment, vmopv1.VirtualMachineDeploymentRolledOutCondition)).To(BeTrue())
+			})
+		})
+
+		When("only some of the replicas of the current revision are ready", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, newReplicaSet("rs-1", 1, 3, 1))
+			})
+
+			It("counts the unavailable replicas and marks the deployment unavailable", func() {
+				Expect(reconciler.ReconcileNormal(deploymentCtx)).To(Succeed())
+
+				Expect(deployment.Status.Replicas).To(Equal(int32(3)))
+				Expect(deployment.Status.UpdatedReplicas).To(Equal(int32(3)))
+				Expect(deployment.Status.ReadyReplicas).To(Equal(int32(1)))
+				Expect(deployment.Status.UnavailableReplicas).To(Equal(int32(2)))
+				Expect(conditions.IsFalse(deployment, vmopv1.VirtualMachineDeploymentAvailableCondition)).To(BeTrue())
+				Expect(conditions.GetReason(deployment, vmopv1.VirtualMachineDeploymentAvailableCondition)).To(
+					Equal(vmopv1.MinimumReplicasUnavailableReason))
+				Expect(conditions.IsFalse(deployment, vmopv1.VirtualMachineDeploymentRolledOutCondition)).To(BeTrue())
+			})
+		})
+
+		When("the template is changed", func() {
+			var oldRS *vmopv1.VirtualMachineReplicaSet
+
+			BeforeEach(func() {
+				oldRS = newReplicaSet("rs-1", 1, 3, 3)
+				initObjects = append(initObjects, oldRS)
+				deployment.Spec.Template.Spec.ImageName = "new-image"
+			})
+
+			It("surges the new revision without scaling down the old revision", func() {
+				Expect(reconciler.ReconcileNormal(deploymentCtx)).To(Succeed())
+
+				rsList := listReplicaSets()
+				Expect(rsList).To(HaveLen(2))
+
+				// The default maxSurge of 25% of 3 replicas rounds up to 1, and
+				// the default maxUnavailable of 25% rounds down to 0.
+				for _, rs := range rsList {
+					if rs.Name == oldRS.Name {
+						Expect(rs.Spec.Replicas).To(HaveValue(Equal(int32(3))))
+						continue
+					}
+					Expect(rs.Annotations).To(HaveKeyWithValue(vmopv1.VirtualMachineDeploymentRevisionAnnotation, "2"))
+					Expect(rs.Spec.Template.Spec.ImageName).To(Equal("new-image"))
+					Expect(rs.Spec.Replicas).To(HaveValue(Equal(int32(1))))
+				}
+
+				Expect(deployment.Status.Revision).To(Equal(int64(2)))
+				Expect(conditions.IsTrue(deployment, vmopv1.VirtualMachineDeploymentAvailableCondition)).To(BeTrue())
+				Expect(conditions.IsFalse(deployment, vmopv1.VirtualMachineDeploymentRolledOutCondition)).To(BeTrue())
+			})
+
+			When("the strategy is Recreate", func() {
+				BeforeEach(func() {
+					deployment.Spec.Strategy.Type = vmopv1.RecreateVirtualMachineDeploymentStrategyType
+				})
+
+				It("scales down the old revision before scaling up the new revision", func() {
+					Expect(reconciler.ReconcileNormal(deploymentCtx)).To(Succeed())
+
+					Expect(getReplicaSet(oldRS.Name).Spec.Replicas).To(HaveValue(BeZero()))
+					for _, rs := range listReplicaSets() {
+						Expect(rs.Spec.Replicas).To(HaveValue(BeZero()))
+					}
+				})
+			})
+
+			When("the deployment is paused", func() {
+				BeforeEach(func() {
+					deployment.Spec.Paused = true
+				})
+
+				It("does not roll out the new template", func() {
+					Expect(reconciler.ReconcileNormal(deploymentCtx)).To(Succeed())
+
+					Expect(listReplicaSets()).To(HaveLen(1))
+					Expect(conditions.IsFalse(deployment, vmopv1.VirtualMachineDeploymentRolledOutCondition)).To(BeTrue())
+					Expect(conditions.GetReason(deployment, vmopv1.VirtualMachineDeploymentRolledOutCondition)).To(
+						Equal(vmopv1.DeploymentPausedReason))
+				})
+			})
+		})
+
+		When("the new revision has ready replicas", func() {
+			var oldRS *vmopv1.VirtualMachineReplicaSet
+
+			BeforeEach(func() {
+				deployment.Spec.Strategy.RollingUpdate = &vmopv1.RollingUpdateVirtualMachineDeployment{
+					MaxSurge:       ptr.To(intstr.FromInt(1)),
+					MaxUnavailable: ptr.To(intstr.FromInt(0)),
+				}
+
+				oldRS = newReplicaSet("rs-1", 1, 3, 3)
+				oldRS.Spec.Template.Spec.ImageName = "old-image"
+				initObjects = append(initObjects, oldRS, newReplicaSet("rs-2", 2, 1, 1))
+			})
+
+			It("scales down the old revision", func() {
+				Expect(reconciler.ReconcileNormal(deploymentCtx)).To(Succeed())
+
+				Expect(getReplicaSet(oldRS.Name).Spec.Replicas).To(HaveValue(Equal(int32(2))))
+				Expect(getReplicaSet("rs-2").Spec.Replicas).To(HaveValue(Equal(int32(1))))
+			})
+
+			When("the new replicas are not ready", func() {
+				BeforeEach(func() {
+					initObjects = []client.Object{oldRS, newReplicaSet("rs-2", 2, 1, 0)}
+				})
+
+				It("does not scale down the old revision", func() {
+					Expect(reconciler.ReconcileNormal(deploymentCtx)).To(Succeed())
+
+					Expect(getReplicaSet(oldRS.Name).Spec.Replicas).To(HaveValue(Equal(int32(3))))
+					Expect(getReplicaSet("rs-2").Spec.Replicas).To(HaveValue(Equal(int32(1))))
+				})
+			})
+		})
+
+		When("the deployment is rolled back", func() {
+			var oldRS *vmopv1.VirtualMachineReplicaSet
+
+			BeforeEach(func() {
+				oldRS = newReplicaSet("rs-1", 1, 0, 0)
+				oldRS.Spec.Template.Spec.ImageName = "old-image"
+				initObjects = append(initObjects, oldRS, newReplicaSet("rs-2", 2, 3, 3))
+			})
+
+			When("to the previous revision", func() {
+				BeforeEach(func() {
+					deployment.Spec.RollbackTo = &vmopv1.VirtualMachineDeploymentRollbackConfig{}
+				})
+
+				It("restores the template of the previous revision", func() {
+					Expect(reconciler.ReconcileNormal(deploymentCtx)).To(Succeed())
+
+					Expect(deployment.Spec.RollbackTo).To(BeNil())
+					Expect(deployment.Spec.Template.Spec.ImageName).To(Equal("old-image"))
+					Expect(deployment.Spec.Template.Labels).ToNot(HaveKey(vmopv1.VirtualMachineTemplateHashLabel))
+				})
+
+				It("makes the previous revision the newest revision", func() {
+					Expect(reconciler.ReconcileNormal(deploymentCtx)).To(Succeed())
+					Expect(reconciler.ReconcileNormal(deploymentCtx)).To(Succeed())
+
+					rs := getReplicaSet(oldRS.Name)
+					Expect(rs.Annotations).To(HaveKeyWithValue(vmopv1.VirtualMachineDeploymentRevisionAnnotation, "3"))
+					Expect(rs.Spec.Replicas).To(HaveValue(Equal(int32(1))))
+					Expect(deployment.Status.Revision).To(Equal(int64(3)))
+				})
+			})
+
+			When("to a revision that does not exist", func() {
+				BeforeEach(func() {
+					deployment.Spec.RollbackTo = &vmopv1.VirtualMachineDeploymentRollbackConfig{
+						Revision: 5,
+					}
+				})
+
+				It("clears the rollback without changing the template", func() {
+					Expect(reconciler.ReconcileNormal(deploymentCtx)).To(Succeed())
+
+					Expect(deployment.Spec.RollbackTo).To(BeNil())
+					Expect(deployment.Spec.Template.Spec.ImageName).To(Equal(builder.DummyImageName))
+				})
+			})
+		})
+
+		When("there are more old revisions than the revision history limit", func() {
+			BeforeEach(func() {
+				deployment.Spec.RevisionHistoryLimit = ptr.To(int32(1))
+
+				for i, name := range []string{"rs-1", "rs-2", "rs-3"} {
+					rs := newReplicaSet(name, int64(i+1), 0, 0)
+					rs.Spec.Template.Spec.ImageName = name
+					initObjects = append(initObjects, rs)
+				}
+				initObjects = append(initObjects, newReplicaSet("rs-4", 4, 3, 3))
+			})
+
+			It("deletes the oldest revisions", func() {
+				Expect(reconciler.ReconcileNormal(deploymentCtx)).To(Succeed())
+
+				Expect(getReplicaSet("rs-1")).To(BeNil())
+				Expect(getReplicaSet("rs-2")).To(BeNil())
+				Expect(getReplicaSet("rs-3")).ToNot(BeNil())
+				Expect(getReplicaSet("rs-4")).ToNot(BeNil())
+			})
+		})
+	})
+}
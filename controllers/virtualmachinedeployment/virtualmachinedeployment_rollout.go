@@ -0,0 +1,368 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinedeployment
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/rand"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+const (
+	// defaultRevisionHistoryLimit is the number of old replica sets retained
+	// when the deployment does not specify a revision history limit.
+	defaultRevisionHistoryLimit int32 = 10
+)
+
+var (
+	// defaultMaxSurge and defaultMaxUnavailable are used when the rolling
+	// update parameters of the deployment are not specified.
+	defaultMaxSurge       = intstr.FromString("25%")
+	defaultMaxUnavailable = intstr.FromString("25%")
+)
+
+// rolloutRolling gradually replaces the replicas of the old replica sets with
+// replicas of the new replica set. The old replica sets are only scaled down
+// once enough replicas are ready to satisfy the deployment's maxUnavailable.
+func (r *Reconciler) rolloutRolling(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet) error {
+
+	d := ctx.Deployment
+	desiredReplicas := getDesiredReplicas(d)
+
+	maxSurge, maxUnavailable, err := resolveFenceposts(d)
+	if err != nil {
+		return err
+	}
+
+	totalReplicas := getReplicas(newRS) + sumReplicas(oldRSs)
+	newReplicas := newReplicaSetReplicas(desiredReplicas, maxSurge, getReplicas(newRS), totalReplicas)
+	if err := r.scaleReplicaSet(ctx, newRS, newReplicas); err != nil {
+		return err
+	}
+
+	oldReplicas := oldReplicaSetsReplicas(desiredReplicas, maxUnavailable, newRS, oldRSs)
+	for _, rs := range oldRSs {
+		if replicas, ok := oldReplicas[rs]; ok {
+			if err := r.scaleReplicaSet(ctx, rs, replicas); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// rolloutRecreate scales down all of the old replica sets and only scales up
+// the new replica set once all of the old replicas have been deleted.
+func (r *Reconciler) rolloutRecreate(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet) error {
+
+	for _, rs := range oldRSs {
+		if err := r.scaleReplicaSet(ctx, rs, 0); err != nil {
+			return err
+		}
+	}
+
+	for _, rs := range oldRSs {
+		if rs.Status.Replicas > 0 {
+			ctx.Logger.V(4).Info("Waiting for old replicas to be deleted",
+				"replicaSet", rs.Name, "replicas", rs.Status.Replicas)
+			return nil
+		}
+	}
+
+	return r.scaleReplicaSet(ctx, newRS, getDesiredReplicas(ctx.Deployment))
+}
+
+// resolveFenceposts returns the maximum number of replicas that may be created
+// above, and that may be unavailable below, the desired number of replicas
+// during a rolling update.
+func resolveFenceposts(d *vmopv1.VirtualMachineDeployment) (int32, int32, error) {
+	desiredReplicas := int(getDesiredReplicas(d))
+
+	maxSurge, maxUnavailable := defaultMaxSurge, defaultMaxUnavailable
+	if ru := d.Spec.Strategy.RollingUpdate; ru != nil {
+		if ru.MaxSurge != nil {
+			maxSurge = *ru.MaxSurge
+		}
+		if ru.MaxUnavailable != nil {
+			maxUnavailable = *ru.MaxUnavailable
+		}
+	}
+
+	surge, err := intstr.GetScaledValueFromIntOrPercent(&maxSurge, desiredReplicas, true)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid maxSurge %q: %w", maxSurge.String(), err)
+	}
+
+	unavailable, err := intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, desiredReplicas, false)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid maxUnavailable %q: %w", maxUnavailable.String(), err)
+	}
+
+	// Rounding a percentage down may result in both values being zero, which
+	// would prevent the rollout from ever making progress.
+	if surge == 0 && unavailable == 0 {
+		unavailable = 1
+	}
+
+	return int32(surge), int32(min(unavailable, desiredReplicas)), nil
+}
+
+// newReplicaSetReplicas returns the number of replicas for the new replica set
+// given the current replicas of the new replica set and the total replicas of
+// all of the replica sets.
+func newReplicaSetReplicas(desiredReplicas, maxSurge, newReplicas, totalReplicas int32) int32 {
+	if newReplicas >= desiredReplicas {
+		return desiredReplicas
+	}
+
+	maxTotalReplicas := desiredReplicas + maxSurge
+	if totalReplicas >= maxTotalReplicas {
+		return newReplicas
+	}
+
+	return newReplicas + min(maxTotalReplicas-totalReplicas, desiredReplicas-newReplicas)
+}
+
+// oldReplicaSetsReplicas returns the number of replicas for each of the old
+// replica sets that must be scaled down. Unready replicas are scaled down
+// first since doing so does not reduce the availability of the deployment.
+// Ready replicas are then scaled down as long as the number of ready replicas
+// across all of the replica sets does not fall below the minimum available.
+func oldReplicaSetsReplicas(
+	desiredReplicas, maxUnavailable int32,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet) map[*vmopv1.VirtualMachineReplicaSet]int32 {
+
+	result := map[*vmopv1.VirtualMachineReplicaSet]int32{}
+
+	oldReplicas := make(map[*vmopv1.VirtualMachineReplicaSet]int32, len(oldRSs))
+	var totalOldReplicas int32
+	for _, rs := range oldRSs {
+		oldReplicas[rs] = getReplicas(rs)
+		totalOldReplicas += oldReplicas[rs]
+	}
+	if totalOldReplicas == 0 {
+		return result
+	}
+
+	minAvailable := desiredReplicas - maxUnavailable
+	newUnavailable := max(getReplicas(newRS)-newRS.Status.ReadyReplicas, 0)
+	maxScaledDown := totalOldReplicas + getReplicas(newRS) - minAvailable - newUnavailable
+	if maxScaledDown <= 0 {
+		return result
+	}
+
+	sorted := sortedByRevision(oldRSs)
+
+	// Scale down the unready replicas of the old replica sets.
+	for _, rs := range sorted {
+		if maxScaledDown <= 0 {
+			break
+		}
+		unready := oldReplicas[rs] - rs.Status.ReadyReplicas
+		if unready <= 0 {
+			continue
+		}
+		scaleDown := min(unready, maxScaledDown)
+		oldReplicas[rs] -= scaleDown
+		maxScaledDown -= scaleDown
+		result[rs] = oldReplicas[rs]
+	}
+
+	// Scale down the ready replicas of the old replica sets.
+	totalReady := newRS.Status.ReadyReplicas
+	for _, rs := range oldRSs {
+		totalReady += min(rs.Status.ReadyReplicas, oldReplicas[rs])
+	}
+	totalScaleDown := totalReady - minAvailable
+	for _, rs := range sorted {
+		if totalScaleDown <= 0 {
+			break
+		}
+		if oldReplicas[rs] == 0 {
+			continue
+		}
+		scaleDown := min(oldReplicas[rs], totalScaleDown)
+		oldReplicas[rs] -= scaleDown
+		totalScaleDown -= scaleDown
+		result[rs] = oldReplicas[rs]
+	}
+
+	return result
+}
+
+// getReplicaSetsToCleanup returns the old replica sets that have been scaled
+// down and exceed the revision history limit, oldest first.
+func getReplicaSetsToCleanup(
+	oldRSs []*vmopv1.VirtualMachineReplicaSet,
+	limit int) []*vmopv1.VirtualMachineReplicaSet {
+
+	var cleanable []*vmopv1.VirtualMachineReplicaSet
+	for _, rs := range sortedByRevision(oldRSs) {
+		if getReplicas(rs) == 0 && rs.Status.Replicas == 0 && rs.DeletionTimestamp.IsZero() {
+			cleanable = append(cleanable, rs)
+		}
+	}
+
+	if diff := len(cleanable) - limit; diff > 0 {
+		return cleanable[:diff]
+	}
+
+	return nil
+}
+
+// findNewReplicaSet returns the replica set whose template matches the
+// template of the deployment, and the remaining replica sets.
+func findNewReplicaSet(
+	d *vmopv1.VirtualMachineDeployment,
+	rsList []*vmopv1.VirtualMachineReplicaSet) (*vmopv1.VirtualMachineReplicaSet, []*vmopv1.VirtualMachineReplicaSet) {
+
+	var (
+		newRS  *vmopv1.VirtualMachineReplicaSet
+		oldRSs []*vmopv1.VirtualMachineReplicaSet
+	)
+
+	// Replica sets are sorted so the newest one is used should more than one
+	// replica set have the same template.
+	sorted := sortedByRevision(rsList)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		rs := sorted[i]
+		if newRS == nil && equalTemplates(&d.Spec.Template, templateWithoutHash(&rs.Spec.Template)) {
+			newRS = rs
+			continue
+		}
+		oldRSs = append(oldRSs, rs)
+	}
+
+	return newRS, oldRSs
+}
+
+// computeTemplateHash returns a hash of the template that is safe to use as a
+// label value and as part of a name.
+func computeTemplateHash(template *vmopv1.VirtualMachineTemplateSpec) string {
+	hasher := fnv.New32a()
+
+	// The template only contains types that can be marshaled.
+	data, _ := json.Marshal(template)
+	_, _ = hasher.Write(data)
+
+	return rand.SafeEncodeString(fmt.Sprint(hasher.Sum32()))
+}
+
+// templateWithoutHash returns a copy of the template without the template
+// hash label.
+func templateWithoutHash(template *vmopv1.VirtualMachineTemplateSpec) *vmopv1.VirtualMachineTemplateSpec {
+	t := template.DeepCopy()
+	delete(t.Labels, vmopv1.VirtualMachineTemplateHashLabel)
+	return t
+}
+
+// equalTemplates returns true if the templates are semantically equal.
+func equalTemplates(a, b *vmopv1.VirtualMachineTemplateSpec) bool {
+	// Treat nil and empty maps the same since either may be the result of
+	// removing the template hash label.
+	a, b = a.DeepCopy(), b.DeepCopy()
+	for _, t := range []*vmopv1.VirtualMachineTemplateSpec{a, b} {
+		if len(t.Labels) == 0 {
+			t.Labels = nil
+		}
+		if len(t.Annotations) == 0 {
+			t.Annotations = nil
+		}
+	}
+
+	return apiequality.Semantic.DeepEqual(a, b)
+}
+
+// getRevision returns the revision of the replica set, or zero if the replica
+// set does not have a valid revision.
+func getRevision(rs *vmopv1.VirtualMachineReplicaSet) int64 {
+	v, err := strconv.ParseInt(rs.Annotations[vmopv1.VirtualMachineDeploymentRevisionAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// setRevision sets the revision of the replica set.
+func setRevision(rs *vmopv1.VirtualMachineReplicaSet, revision int64) {
+	if rs.Annotations == nil {
+		rs.Annotations = make(map[string]string)
+	}
+	rs.Annotations[vmopv1.VirtualMachineDeploymentRevisionAnnotation] = strconv.FormatInt(revision, 10)
+}
+
+// maxRevision returns the highest revision of the replica sets.
+func maxRevision(rss []*vmopv1.VirtualMachineReplicaSet) int64 {
+	var revision int64
+	for _, rs := range rss {
+		revision = max(revision, getRevision(rs))
+	}
+	return revision
+}
+
+// previousRevision returns the second highest revision of the replica sets,
+// or zero if there are less than two revisions.
+func previousRevision(rss []*vmopv1.VirtualMachineReplicaSet) int64 {
+	var current, previous int64
+	for _, rs := range rss {
+		switch revision := getRevision(rs); {
+		case revision > current:
+			previous, current = current, revision
+		case revision < current && revision > previous:
+			previous = revision
+		}
+	}
+	return previous
+}
+
+// sortedByRevision returns a copy of the replica sets sorted by revision,
+// oldest first.
+func sortedByRevision(rss []*vmopv1.VirtualMachineReplicaSet) []*vmopv1.VirtualMachineReplicaSet {
+	sorted := make([]*vmopv1.VirtualMachineReplicaSet, len(rss))
+	copy(sorted, rss)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if ri, rj := getRevision(sorted[i]), getRevision(sorted[j]); ri != rj {
+			return ri < rj
+		}
+		return sorted[i].CreationTimestamp.Before(&sorted[j].CreationTimestamp)
+	})
+	return sorted
+}
+
+// getReplicas returns the desired replicas of the replica set.
+func getReplicas(rs *vmopv1.VirtualMachineReplicaSet) int32 {
+	return ptr.Deref(rs.Spec.Replicas)
+}
+
+// sumReplicas returns the sum of the desired replicas of the replica sets.
+func sumReplicas(rss []*vmopv1.VirtualMachineReplicaSet) int32 {
+	var total int32
+	for _, rs := range rss {
+		total += getReplicas(rs)
+	}
+	return total
+}
+
+// getDesiredReplicas returns the desired replicas of the deployment.
+func getDesiredReplicas(d *vmopv1.VirtualMachineDeployment) int32 {
+	return ptr.DerefWithDefault(d.Spec.Replicas, 1)
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinedisruptionbudget
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineDisruptionBudget{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		Watches(&vmopv1.VirtualMachine{},
+			handler.EnqueueRequestsFromMapFunc(r.VMToDisruptionBudgets(ctx)),
+		).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+// VMToDisruptionBudgets is a mapper function to be used to enqueue requests
+// for reconciliation for the VirtualMachineDisruptionBudgets that select a VM.
+func (r *Reconciler) VMToDisruptionBudgets(
+	ctx *pkgctx.ControllerManagerContext) func(_ context.Context, o client.Object) []reconcile.Request {
+
+	return func(_ context.Context, o client.Object) []reconcile.Request {
+		vm, ok := o.(*vmopv1.VirtualMachine)
+		if !ok {
+			panic(fmt.Sprintf("Expected a VirtualMachine, but got a %T", o))
+		}
+
+		budgets, err := vmopv1util.GetDisruptionBudgetsForVirtualMachine(ctx, r.Client, vm)
+		if err != nil {
+			ctx.Logger.Error(err, "Failed getting VirtualMachineDisruptionBudgets for VM")
+			return nil
+		}
+
+		var result []reconcile.Request
+		for _, b := range budgets {
+			result = append(result, reconcile.Request{
+				NamespacedName: client.ObjectKey{Name: b.Name, Namespace: b.Namespace},
+			})
+		}
+
+		return result
+	}
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder) *Reconciler {
+
+	return &Reconciler{
+		Context:  ctx,
+		Client:   client,
+		Logger:   logger,
+		Recorder: recorder,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineDisruptionBudget object.
+type Reconciler struct {
+	client.Client
+	Context  context.Context
+	Logger   logr.Logger
+	Recorder record.Recorder
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinedisruptionbudgets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinedisruptionbudgets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	budget := &vmopv1.VirtualMachineDisruptionBudget{}
+	if err := r.Get(ctx, req.NamespacedName, budget); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !budget.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	budgetCtx := &pkgctx.VirtualMachineDisruptionBudgetContext{
+		Context: ctx,
+		Logger:  ctrl.Log.WithName("VirtualMachineDisruptionBudget").WithValues("namespace", budget.Namespace, "name", budget.Name),
+		Budget:  budget,
+	}
+
+	patchHelper, err := patch.NewHelper(budget, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", budgetCtx.String(), err)
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, budget); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			budgetCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if err := r.ReconcileNormal(budgetCtx); err != nil {
+		budgetCtx.Logger.Error(err, "Failed to reconcile VirtualMachineDisruptionBudget")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineDisruptionBudgetContext) error {
+	ctx.Logger.V(4).Info("Reconciling VirtualMachineDisruptionBudget")
+
+	budget := ctx.Budget
+
+	vms, err := vmopv1util.GetVirtualMachinesForDisruptionBudget(ctx, r.Client, budget)
+	if err != nil {
+		return err
+	}
+
+	status, err := vmopv1util.ComputeDisruptionBudgetStatus(budget, vms)
+	if err != nil {
+		// The budget cannot be fixed by retrying, so report the error in the
+		// status instead of returning it.
+		budget.Status.DisruptionsAllowed = 0
+		budget.Status.ObservedGeneration = budget.Generation
+		conditions.MarkFalse(
+			budget,
+			vmopv1.VirtualMachineDisruptionAllowedCondition,
+			vmopv1.InvalidDisruptionBudgetReason,
+			err.Error())
+		return nil
+	}
+
+	budget.Status.ExpectedVMs = status.ExpectedVMs
+	budget.Status.CurrentHealthy = status.CurrentHealthy
+	budget.Status.DesiredHealthy = status.DesiredHealthy
+	budget.Status.DisruptionsAllowed = status.DisruptionsAllowed
+	budget.Status.ObservedGeneration = budget.Generation
+
+	if status.DisruptionsAllowed > 0 {
+		conditions.MarkTrue(budget, vmopv1.VirtualMachineDisruptionAllowedCondition)
+	} else {
+		conditions.MarkFalse(
+			budget,
+			vmopv1.VirtualMachineDisruptionAllowedCondition,
+			vmopv1.InsufficientHealthyVirtualMachinesReason,
+			"%d of %d VMs are healthy and %d must be healthy",
+			status.CurrentHealthy, status.ExpectedVMs, status.DesiredHealthy)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinedisruptionbudget_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.V1Alpha3,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx *builder.IntegrationTestContext
+
+		budget    *vmopv1.VirtualMachineDisruptionBudget
+		budgetKey types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		budget = builder.DummyVirtualMachineDisruptionBudget()
+		budget.GenerateName = ""
+		budget.Name = "dummy-budget"
+		budget.Namespace = ctx.Namespace
+		budgetKey = types.NamespacedName{Name: budget.Name, Namespace: budget.Namespace}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	getBudget := func(g Gomega) *vmopv1.VirtualMachineDisruptionBudget {
+		b := &vmopv1.VirtualMachineDisruptionBudget{}
+		g.Expect(ctx.Client.Get(ctx, budgetKey, b)).To(Succeed())
+		return b
+	}
+
+	// setVMReady updates the status of the VM so it is ready if ready is true.
+	setVMReady := func(vm *vmopv1.VirtualMachine, ready bool) {
+		Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), vm)).To(Succeed())
+		conditions.MarkTrue(vm, vmopv1.VirtualMachineConditionCreated)
+		if ready {
+			vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+		} else {
+			vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+		}
+		Expect(ctx.Client.Status().Update(ctx, vm)).To(Succeed())
+	}
+
+	Context("Reconcile", func() {
+		var vms []*vmopv1.VirtualMachine
+
+		BeforeEach(func() {
+			Expect(ctx.Client.Create(ctx, budget)).To(Succeed())
+
+			vms = nil
+			for i := 0; i < 2; i++ {
+				vm := builder.DummyVirtualMachine()
+				vm.Name = fmt.Sprintf("dummy-vm-%d", i)
+				vm.Namespace = ctx.Namespace
+				vm.Labels["app"] = "dummy"
+				Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+				setVMReady(vm, true)
+				vms = append(vms, vm)
+			}
+		})
+
+		AfterEach(func() {
+			for _, vm := range vms {
+				Expect(client.IgnoreNotFound(ctx.Client.Delete(ctx, vm))).To(Succeed())
+			}
+			Expect(client.IgnoreNotFound(ctx.Client.Delete(ctx, budget))).To(Succeed())
+		})
+
+		It("Tracks the healthy VMs selected by the budget", func() {
+			Eventually(func(g Gomega) {
+				b := getBudget(g)
+				g.Expect(b.Status.ExpectedVMs).To(Equal(int32(2)))
+				g.Expect(b.Status.CurrentHealthy).To(Equal(int32(2)))
+				g.Expect(b.Status.DesiredHealthy).To(Equal(int32(1)))
+				g.Expect(b.Status.DisruptionsAllowed).To(Equal(int32(1)))
+				g.Expect(conditions.IsTrue(b, vmopv1.VirtualMachineDisruptionAllowedCondition)).To(BeTrue())
+			}).Should(Succeed())
+
+			By("A VM becoming unhealthy", func() {
+				setVMReady(vms[0], false)
+			})
+
+			Eventually(func(g Gomega) {
+				b := getBudget(g)
+				g.Expect(b.Status.CurrentHealthy).To(Equal(int32(1)))
+				g.Expect(b.Status.DisruptionsAllowed).To(BeZero())
+				g.Expect(conditions.IsFalse(b, vmopv1.VirtualMachineDisruptionAllowedCondition)).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+}
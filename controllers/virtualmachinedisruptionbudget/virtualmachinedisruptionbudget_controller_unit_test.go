@@ -0,0 +1,222 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinedisruptionbudget_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinedisruptionbudget"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.V1Alpha3,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+
+		reconciler *virtualmachinedisruptionbudget.Reconciler
+		budget     *vmopv1.VirtualMachineDisruptionBudget
+		budgetCtx  *pkgctx.VirtualMachineDisruptionBudgetContext
+	)
+
+	// newVM returns a VM selected by the budget that is ready if ready is true.
+	newVM := func(name string, ready bool) *vmopv1.VirtualMachine {
+		vm := builder.DummyVirtualMachine()
+		vm.Name = name
+		vm.Namespace = budget.Namespace
+		vm.Labels["app"] = "dummy"
+		conditions.MarkTrue(vm, vmopv1.VirtualMachineConditionCreated)
+		if ready {
+			vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+		} else {
+			vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+		}
+		return vm
+	}
+
+	BeforeEach(func() {
+		budget = builder.DummyVirtualMachineDisruptionBudget()
+		budget.GenerateName = ""
+		budget.Name = "dummy-budget"
+		budget.Namespace = "dummy-ns"
+		budget.Generation = 2
+
+		initObjects = nil
+	})
+
+	JustBeforeEach(func() {
+		initObjects = append(initObjects, budget)
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = virtualmachinedisruptionbudget.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+		)
+		budgetCtx = &pkgctx.VirtualMachineDisruptionBudgetContext{
+			Context: ctx,
+			Logger:  ctx.Logger.WithName(budget.Name),
+			Budget:  budget,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+	})
+
+	Context("ReconcileNormal", func() {
+
+		When("the budget does not select any VMs", func() {
+			It("does not allow disruptions", func() {
+				Expect(reconciler.ReconcileNormal(budgetCtx)).To(Succeed())
+
+				Expect(budget.Status.ObservedGeneration).To(Equal(int64(2)))
+				Expect(budget.Status.ExpectedVMs).To(BeZero())
+				Expect(budget.Status.CurrentHealthy).To(BeZero())
+				Expect(budget.Status.DesiredHealthy).To(Equal(int32(1)))
+				Expect(budget.Status.DisruptionsAllowed).To(BeZero())
+
+				c := conditions.Get(budget, vmopv1.VirtualMachineDisruptionAllowedCondition)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.InsufficientHealthyVirtualMachinesReason))
+			})
+		})
+
+		When("the budget selects healthy and unhealthy VMs", func() {
+			BeforeEach(func() {
+				other := newVM("vm-other", true)
+				other.Labels["app"] = "other"
+
+				deleting := newVM("vm-deleting", true)
+				deleting.Finalizers = []string{"test"}
+				deleting.DeletionTimestamp = ptr.To(metav1.Now())
+
+				initObjects = append(initObjects,
+					newVM("vm-1", true),
+					newVM("vm-2", true),
+					newVM("vm-3", false),
+					other,
+					deleting,
+				)
+			})
+
+			It("allows disruptions of the healthy VMs above minAvailable", func() {
+				Expect(reconciler.ReconcileNormal(budgetCtx)).To(Succeed())
+
+				Expect(budget.Status.ExpectedVMs).To(Equal(int32(3)))
+				Expect(budget.Status.CurrentHealthy).To(Equal(int32(2)))
+				Expect(budget.Status.DesiredHealthy).To(Equal(int32(1)))
+				Expect(budget.Status.DisruptionsAllowed).To(Equal(int32(1)))
+				Expect(conditions.IsTrue(budget, vmopv1.VirtualMachineDisruptionAllowedCondition)).To(BeTrue())
+			})
+
+			When("minAvailable is a percentage", func() {
+				BeforeEach(func() {
+					budget.Spec.MinAvailable = ptr.To(intstr.FromString("50%"))
+				})
+
+				It("rounds the desired healthy VMs up", func() {
+					Expect(reconciler.ReconcileNormal(budgetCtx)).To(Succeed())
+
+					Expect(budget.Status.DesiredHealthy).To(Equal(int32(2)))
+					Expect(budget.Status.DisruptionsAllowed).To(BeZero())
+					Expect(conditions.IsTrue(budget, vmopv1.VirtualMachineDisruptionAllowedCondition)).To(BeFalse())
+				})
+			})
+
+			When("maxUnavailable is specified", func() {
+				BeforeEach(func() {
+					budget.Spec.MinAvailable = nil
+					budget.Spec.MaxUnavailable = ptr.To(intstr.FromInt32(2))
+				})
+
+				It("allows disruptions of the healthy VMs above the expected VMs less maxUnavailable", func() {
+					Expect(reconciler.ReconcileNormal(budgetCtx)).To(Succeed())
+
+					Expect(budget.Status.DesiredHealthy).To(Equal(int32(1)))
+					Expect(budget.Status.DisruptionsAllowed).To(Equal(int32(1)))
+				})
+			})
+		})
+
+		When("the budget specifies neither minAvailable nor maxUnavailable", func() {
+			BeforeEach(func() {
+				budget.Spec.MinAvailable = nil
+				budget.Status.DisruptionsAllowed = 3
+			})
+
+			It("marks the budget as invalid", func() {
+				Expect(reconciler.ReconcileNormal(budgetCtx)).To(Succeed())
+
+				Expect(budget.Status.DisruptionsAllowed).To(BeZero())
+				c := conditions.Get(budget, vmopv1.VirtualMachineDisruptionAllowedCondition)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Reason).To(Equal(vmopv1.InvalidDisruptionBudgetReason))
+			})
+		})
+	})
+
+	Context("VMToDisruptionBudgets", func() {
+		var (
+			vm     *vmopv1.VirtualMachine
+			mapper func(context.Context, client.Object) []reconcile.Request
+		)
+
+		BeforeEach(func() {
+			vm = newVM("vm-1", true)
+		})
+
+		JustBeforeEach(func() {
+			mapper = reconciler.VMToDisruptionBudgets(&pkgctx.ControllerManagerContext{
+				Context: ctx,
+				Logger:  ctx.Logger,
+			})
+		})
+
+		It("returns the budgets that select the VM", func() {
+			Expect(mapper(ctx, vm)).To(ConsistOf(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: budget.Namespace, Name: budget.Name},
+			}))
+		})
+
+		When("the VM is not selected by the budget", func() {
+			BeforeEach(func() {
+				vm.Labels["app"] = "other"
+			})
+
+			It("returns no requests", func() {
+				Expect(mapper(ctx, vm)).To(BeEmpty())
+			})
+		})
+	})
+}
@@ -0,0 +1,976 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineexport
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+const (
+	// ServerPort is the port on which the Pod that receives the exported
+	// files listens. The port is only reachable from within the cluster.
+	ServerPort = 8080
+
+	// ServerTLSPort is the port on which the Pod that receives the exported
+	// files serves them for download over HTTPS.
+	ServerTLSPort = 8443
+
+	// DownloadPort is the port of the LoadBalancer Service through which the
+	// exported files are downloaded.
+	DownloadPort = 443
+
+	// ServerLabelKey is the label on the Pod that receives the exported files
+	// whose value is the name of the VirtualMachineExport.
+	ServerLabelKey = "vmoperator.vmware.com/virtualmachineexport"
+
+	// serverDataDir is the directory to which the exported files are written
+	// in the Pod that receives them.
+	serverDataDir = "/data"
+
+	// serverTLSDir is the directory to which the certificate and key of the
+	// HTTPS server are mounted in the Pod that receives the exported files.
+	serverTLSDir = "/etc/export-server/tls"
+
+	// serverTokenEnvVar is the environment variable in the Pod that receives
+	// the exported files whose value is the bearer token.
+	serverTokenEnvVar = "EXPORT_TOKEN"
+
+	// serverCommand is the export server binary in VM Operator's image, which
+	// is used when no server image is configured.
+	serverCommand = "/export-server"
+
+	// managerContainerName is the name of VM Operator's container in its Pod.
+	managerContainerName = "manager"
+
+	// serverCertValidity is how long the certificate of the HTTPS server is
+	// valid. The server is deleted once the download URL expires, which is
+	// well before the certificate does.
+	serverCertValidity = 30 * 24 * time.Hour
+
+	// exportRetryDelay is the minimum time between the start of two attempts
+	// to export the VM.
+	exportRetryDelay = 1 * time.Minute
+
+	// serverUserID is the ID of the unprivileged user, nobody, that runs the
+	// server in the Pod that receives the exported files.
+	serverUserID = 65534
+
+	// serverDataOverhead is the space reserved for the OVF descriptor and
+	// manifest in addition to the capacity of the VM's disks when the
+	// exported files are written to an EmptyDir volume.
+	serverDataOverhead = "1Gi"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineExport{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		mgr.GetAPIReader(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProvider,
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	apiReader client.Reader,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider providers.VirtualMachineProviderInterface) *Reconciler {
+
+	return &Reconciler{
+		Context:    ctx,
+		Client:     client,
+		apiReader:  apiReader,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineExport object.
+type Reconciler struct {
+	client.Client
+	Context    context.Context
+	apiReader  client.Reader
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider providers.VirtualMachineProviderInterface
+
+	// exports tracks the exports in progress by the UID of the
+	// VirtualMachineExport. There is no vCenter task to query for the result
+	// of an export since the files are uploaded by VM Operator.
+	exports sync.Map
+}
+
+// exportResult is the result of exporting a VM.
+type exportResult struct {
+	done  bool
+	files []string
+	err   error
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineexports,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineexports/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;create;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;create;delete
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	vmExport := &vmopv1.VirtualMachineExport{}
+	if err := r.Get(ctx, req.NamespacedName, vmExport); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !vmExport.DeletionTimestamp.IsZero() {
+		r.exports.Delete(vmExport.UID)
+		return ctrl.Result{}, nil
+	}
+
+	exportCtx := &pkgctx.VirtualMachineExportContext{
+		Context:  ctx,
+		Logger:   ctrl.Log.WithName("VirtualMachineExport").WithValues("name", req.NamespacedName),
+		VMExport: vmExport,
+	}
+
+	patchHelper, err := patch.NewHelper(vmExport, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", exportCtx.String(), err)
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, vmExport); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			exportCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	return r.ReconcileNormal(exportCtx)
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineExportContext) (ctrl.Result, error) {
+	ctx.Logger.V(4).Info("Reconciling VirtualMachineExport")
+
+	vmExport := ctx.VMExport
+
+	if vmExport.Status.Ready {
+		if requeueAfter, err := r.reconcileDownloadExpiration(ctx); err != nil || requeueAfter > 0 {
+			return ctrl.Result{RequeueAfter: requeueAfter}, err
+		}
+		return r.reconcileTTL(ctx)
+	}
+
+	if vmExport.Status.StartTime.IsZero() {
+		vmExport.Status.StartTime = metav1.Now()
+	}
+
+	if !conditions.IsTrue(vmExport, vmopv1.VirtualMachineExportConditionExported) {
+		if ok, err := r.reconcileSource(ctx); err != nil {
+			return ctrl.Result{}, err
+		} else if !ok {
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+
+		if ok, err := r.reconcileTarget(ctx); err != nil {
+			return ctrl.Result{}, err
+		} else if !ok {
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+
+		if requeueAfter := r.reconcileExport(ctx); requeueAfter > 0 {
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+	}
+
+	if vmExport.Spec.Target.Download != nil {
+		download, err := r.downloadStatus(ctx)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		vmExport.Status.Download = download
+	} else if err := r.deleteServer(ctx); err != nil {
+		// The files are in the PersistentVolumeClaim so the server is no
+		// longer needed.
+		return ctrl.Result{}, err
+	}
+
+	conditions.MarkTrue(vmExport, vmopv1.VirtualMachineExportConditionComplete)
+	vmExport.Status.Ready = true
+	vmExport.Status.CompletionTime = metav1.Now()
+	ctx.Logger.Info("VM export completed", "files", vmExport.Status.Files)
+
+	if vmExport.Status.Download != nil {
+		return ctrl.Result{RequeueAfter: time.Until(vmExport.Status.Download.ExpirationTime.Time)}, nil
+	}
+
+	return r.reconcileTTL(ctx)
+}
+
+// reconcileSource validates the source VM of the export and stores it in the
+// context. It returns false when the VM cannot be exported yet.
+func (r *Reconciler) reconcileSource(ctx *pkgctx.VirtualMachineExportContext) (bool, error) {
+	vmExport := ctx.VMExport
+
+	vm := &vmopv1.VirtualMachine{}
+	vmKey := client.ObjectKey{Namespace: vmExport.Namespace, Name: vmExport.Spec.Source.Name}
+	if err := r.Get(ctx, vmKey, vm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		conditions.MarkFalse(vmExport,
+			vmopv1.VirtualMachineExportConditionSourceValid,
+			vmopv1.SourceVirtualMachineNotExistReason,
+			"VirtualMachine %s does not exist", vmKey.Name)
+		return false, nil
+	}
+
+	if vm.Status.UniqueID == "" {
+		conditions.MarkFalse(vmExport,
+			vmopv1.VirtualMachineExportConditionSourceValid,
+			vmopv1.SourceVirtualMachineNotCreatedReason,
+			"VirtualMachine %s has not been created", vmKey.Name)
+		return false, nil
+	}
+
+	if vm.Status.PowerState != vmopv1.VirtualMachinePowerStateOff {
+		conditions.MarkFalse(vmExport,
+			vmopv1.VirtualMachineExportConditionSourceValid,
+			vmopv1.SourceVirtualMachinePoweredOnReason,
+			"VirtualMachine %s must be powered off", vmKey.Name)
+		return false, nil
+	}
+
+	ctx.VM = vm
+	conditions.MarkTrue(vmExport, vmopv1.VirtualMachineExportConditionSourceValid)
+
+	return true, nil
+}
+
+// reconcileTarget ensures the server that receives the exported files exists
+// and sets its URL and token in the context. It returns false when the server
+// is not yet ready.
+func (r *Reconciler) reconcileTarget(ctx *pkgctx.VirtualMachineExportContext) (bool, error) {
+	vmExport := ctx.VMExport
+	target := vmExport.Spec.Target
+
+	image, command, err := r.serverImage(ctx)
+	if err != nil {
+		return false, err
+	}
+	if image == "" {
+		conditions.MarkFalse(vmExport,
+			vmopv1.VirtualMachineExportConditionTargetValid,
+			vmopv1.TargetNotSupportedReason,
+			"exporting a VM is not supported")
+		return false, nil
+	}
+
+	if target.PersistentVolumeClaim != nil {
+		pvc := &corev1.PersistentVolumeClaim{}
+		pvcKey := client.ObjectKey{Namespace: vmExport.Namespace, Name: target.PersistentVolumeClaim.ClaimName}
+		if err := r.Get(ctx, pvcKey, pvc); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return false, err
+			}
+			conditions.MarkFalse(vmExport,
+				vmopv1.VirtualMachineExportConditionTargetValid,
+				vmopv1.TargetPersistentVolumeClaimNotExistReason,
+				"PersistentVolumeClaim %s does not exist", pvcKey.Name)
+			return false, nil
+		}
+	}
+
+	token, err := r.reconcileTokenSecret(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// The files are downloaded over HTTPS through a LoadBalancer Service, so
+	// the certificate of the server is created for the Service's address
+	// before the server is started.
+	if target.Download != nil {
+		svc, err := r.reconcileServerService(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		host := loadBalancerHost(svc)
+		if host == "" {
+			conditions.MarkFalse(vmExport,
+				vmopv1.VirtualMachineExportConditionTargetValid,
+				vmopv1.TargetServerNotReadyReason,
+				"Service %s does not have a load balancer address", svc.Name)
+			return false, nil
+		}
+
+		if err := r.reconcileTLSSecret(ctx, host); err != nil {
+			return false, err
+		}
+	}
+
+	pod, err := r.reconcileServerPod(ctx, image, command)
+	if err != nil {
+		return false, err
+	}
+
+	if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+		conditions.MarkFalse(vmExport,
+			vmopv1.VirtualMachineExportConditionTargetValid,
+			vmopv1.TargetServerNotReadyReason,
+			"Pod %s is not running", pod.Name)
+		return false, nil
+	}
+
+	u := url.URL{
+		Scheme: "http",
+		Host:   net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(ServerPort)),
+		Path:   "/",
+	}
+	ctx.TargetURL = u.String()
+	ctx.Token = token
+	conditions.MarkTrue(vmExport, vmopv1.VirtualMachineExportConditionTargetValid)
+
+	return true, nil
+}
+
+// reconcileTokenSecret ensures the Secret with the bearer token used to
+// authenticate requests to the server exists, and returns the token.
+func (r *Reconciler) reconcileTokenSecret(ctx *pkgctx.VirtualMachineExportContext) (string, error) {
+	vmExport := ctx.VMExport
+
+	// Secrets are read directly from the API server since they are not
+	// cached.
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{Namespace: vmExport.Namespace, Name: TokenSecretName(vmExport)}
+	if err := r.apiReader.Get(ctx, secretKey, secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", err
+		}
+
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretKey.Name,
+				Namespace: secretKey.Namespace,
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				vmopv1.VirtualMachineExportTokenSecretKey: []byte(hex.EncodeToString(b)),
+			},
+		}
+		if err := controllerutil.SetControllerReference(vmExport, secret, r.Scheme()); err != nil {
+			return "", err
+		}
+		if err := r.Create(ctx, secret); err != nil {
+			return "", err
+		}
+	}
+
+	token := string(secret.Data[vmopv1.VirtualMachineExportTokenSecretKey])
+	if token == "" {
+		return "", fmt.Errorf("secret %s does not have a %s", secretKey.Name, vmopv1.VirtualMachineExportTokenSecretKey)
+	}
+
+	return token, nil
+}
+
+// reconcileServerPod ensures the Pod that receives the exported files exists.
+func (r *Reconciler) reconcileServerPod(
+	ctx *pkgctx.VirtualMachineExportContext,
+	image string,
+	command []string) (*corev1.Pod, error) {
+
+	vmExport := ctx.VMExport
+
+	// Pods are read directly from the API server so they are not cached.
+	pod := &corev1.Pod{}
+	podKey := client.ObjectKey{Namespace: vmExport.Namespace, Name: ServerName(vmExport)}
+	if err := r.apiReader.Get(ctx, podKey, pod); err == nil || !apierrors.IsNotFound(err) {
+		return pod, err
+	}
+
+	pod = newServerPod(vmExport, ctx.VM, image, command)
+	if err := controllerutil.SetControllerReference(vmExport, pod, r.Scheme()); err != nil {
+		return nil, err
+	}
+	if err := r.Create(ctx, pod); err != nil {
+		return nil, err
+	}
+	ctx.Logger.Info("Created export server Pod", "pod", podKey.Name)
+
+	return pod, nil
+}
+
+// reconcileServerService ensures the LoadBalancer Service through which the
+// exported files are downloaded over HTTPS exists. Only the HTTPS port of the
+// server is exposed.
+func (r *Reconciler) reconcileServerService(ctx *pkgctx.VirtualMachineExportContext) (*corev1.Service, error) {
+	vmExport := ctx.VMExport
+
+	svc := &corev1.Service{}
+	svcKey := client.ObjectKey{Namespace: vmExport.Namespace, Name: ServerName(vmExport)}
+	if err := r.Get(ctx, svcKey, svc); err == nil || !apierrors.IsNotFound(err) {
+		return svc, err
+	}
+
+	svc = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      svcKey.Name,
+			Namespace: svcKey.Namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeLoadBalancer,
+			Selector: map[string]string{ServerLabelKey: vmExport.Name},
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "https",
+					Port:       DownloadPort,
+					TargetPort: intstr.FromInt32(ServerTLSPort),
+				},
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(vmExport, svc, r.Scheme()); err != nil {
+		return nil, err
+	}
+	if err := r.Create(ctx, svc); err != nil {
+		return nil, err
+	}
+	ctx.Logger.Info("Created export server Service", "service", svcKey.Name)
+
+	return svc, nil
+}
+
+// reconcileTLSSecret ensures the Secret with the certificate and key of the
+// HTTPS server exists. The certificate is self-signed for the load balancer
+// address of the Service and the Service's DNS names.
+func (r *Reconciler) reconcileTLSSecret(ctx *pkgctx.VirtualMachineExportContext, host string) error {
+	vmExport := ctx.VMExport
+
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{Namespace: vmExport.Namespace, Name: TLSSecretName(vmExport)}
+	if err := r.apiReader.Get(ctx, secretKey, secret); err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	svcName := ServerName(vmExport)
+	certPEM, keyPEM, err := newServerCertificate(
+		svcName,
+		[]string{
+			host,
+			fmt.Sprintf("%s.%s.svc", svcName, vmExport.Namespace),
+		},
+		time.Now().Add(serverCertValidity))
+	if err != nil {
+		return err
+	}
+
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretKey.Name,
+			Namespace: secretKey.Namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+	if err := controllerutil.SetControllerReference(vmExport, secret, r.Scheme()); err != nil {
+		return err
+	}
+
+	return r.Create(ctx, secret)
+}
+
+// serverImage returns the image, and the command if it is not the image's
+// entrypoint, of the Pod that receives the exported files. When no image is
+// configured, the export server binary in the image of VM Operator's own
+// container is used. An empty image is returned when neither is available.
+func (r *Reconciler) serverImage(ctx *pkgctx.VirtualMachineExportContext) (string, []string, error) {
+	cfg := pkgcfg.FromContext(ctx)
+	if cfg.VMExportServerImage != "" {
+		return cfg.VMExportServerImage, nil, nil
+	}
+
+	pod := &corev1.Pod{}
+	podKey := client.ObjectKey{Namespace: cfg.PodNamespace, Name: cfg.PodName}
+	if err := r.apiReader.Get(ctx, podKey, pod); err != nil {
+		return "", nil, client.IgnoreNotFound(err)
+	}
+
+	for _, c := range pod.Spec.Containers {
+		if c.Name == managerContainerName {
+			return c.Image, []string{serverCommand}, nil
+		}
+	}
+
+	return "", nil, nil
+}
+
+// downloadStatus returns the status of the URL from which the exported files
+// are downloaded.
+func (r *Reconciler) downloadStatus(
+	ctx *pkgctx.VirtualMachineExportContext) (*vmopv1.VirtualMachineExportDownloadStatus, error) {
+
+	vmExport := ctx.VMExport
+
+	svc := &corev1.Service{}
+	svcKey := client.ObjectKey{Namespace: vmExport.Namespace, Name: ServerName(vmExport)}
+	if err := r.Get(ctx, svcKey, svc); err != nil {
+		return nil, err
+	}
+	host := loadBalancerHost(svc)
+	if host == "" {
+		return nil, fmt.Errorf("service %s does not have a load balancer address", svcKey.Name)
+	}
+
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{Namespace: vmExport.Namespace, Name: TLSSecretName(vmExport)}
+	if err := r.apiReader.Get(ctx, secretKey, secret); err != nil {
+		return nil, err
+	}
+
+	return &vmopv1.VirtualMachineExportDownloadStatus{
+		URL:             downloadURL(host),
+		CACertificate:   string(secret.Data[corev1.TLSCertKey]),
+		TokenSecretName: TokenSecretName(vmExport),
+		ExpirationTime: metav1.NewTime(
+			time.Now().Add(time.Duration(vmExport.Spec.Target.Download.TTLSeconds) * time.Second)),
+	}, nil
+}
+
+// reconcileExport checks the result of a prior export, and exports the VM if
+// there is no export in progress and no prior export succeeded. The returned
+// duration is greater than zero when the export has not completed.
+//
+// An export is tracked in memory, so if VM Operator restarts while an export
+// is in progress, the VM is exported again.
+func (r *Reconciler) reconcileExport(ctx *pkgctx.VirtualMachineExportContext) time.Duration {
+	vmExport := ctx.VMExport
+
+	if obj, ok := r.exports.Load(vmExport.UID); ok {
+		res := obj.(exportResult)
+		if !res.done {
+			conditions.MarkFalse(vmExport,
+				vmopv1.VirtualMachineExportConditionExported,
+				vmopv1.ExportingReason,
+				"Exporting VM.")
+			return 10 * time.Second
+		}
+
+		r.exports.Delete(vmExport.UID)
+
+		if res.err == nil {
+			vmExport.Status.Files = res.files
+			conditions.MarkTrue(vmExport, vmopv1.VirtualMachineExportConditionExported)
+			return 0
+		}
+
+		ctx.Logger.Error(res.err, "VM export failed, will retry this operation")
+		conditions.MarkFalse(vmExport,
+			vmopv1.VirtualMachineExportConditionExported,
+			vmopv1.ExportFailureReason,
+			res.err.Error())
+	}
+
+	if vmExport.Status.Attempts > 0 {
+		if d := exportRetryDelay - time.Since(vmExport.Status.LastAttemptTime.Time); d > 0 {
+			return d
+		}
+	}
+
+	vmExport.Status.Attempts++
+	vmExport.Status.LastAttemptTime = metav1.Now()
+	conditions.MarkFalse(vmExport,
+		vmopv1.VirtualMachineExportConditionExported,
+		vmopv1.ExportingReason,
+		"Exporting VM.")
+
+	uid := vmExport.UID
+	r.exports.Store(uid, exportResult{})
+
+	vmExportCopy := vmExport.DeepCopy()
+	vm, targetURL, token := ctx.VM.DeepCopy(), ctx.TargetURL, ctx.Token
+	go func() {
+		files, err := r.VMProvider.ExportVirtualMachine(ctx, vm, vmExportCopy, targetURL, token)
+		if err != nil {
+			ctx.Logger.Error(err, "failed to export VM")
+		} else {
+			ctx.Logger.Info("exported VM", "files", files)
+		}
+		r.exports.Store(uid, exportResult{done: true, files: files, err: err})
+		r.Recorder.EmitEvent(vmExportCopy, "Export", err, false)
+	}()
+
+	return 10 * time.Second
+}
+
+// reconcileDownloadExpiration deletes the server once the download URL
+// expires. The returned duration is greater than zero when the URL has not yet
+// expired.
+func (r *Reconciler) reconcileDownloadExpiration(ctx *pkgctx.VirtualMachineExportContext) (time.Duration, error) {
+	vmExport := ctx.VMExport
+
+	download := vmExport.Status.Download
+	if download == nil || download.URL == "" {
+		return 0, nil
+	}
+
+	if d := time.Until(download.ExpirationTime.Time); d > 0 {
+		return d, nil
+	}
+
+	if err := r.deleteServer(ctx); err != nil {
+		return 0, err
+	}
+
+	download.URL = ""
+	conditions.MarkFalse(vmExport,
+		vmopv1.VirtualMachineExportConditionTargetValid,
+		vmopv1.DownloadExpiredReason,
+		"The download URL expired at %s", download.ExpirationTime.UTC().Format(time.RFC3339))
+	ctx.Logger.Info("VM export download URL expired")
+
+	return 0, nil
+}
+
+// reconcileTTL deletes the export once the TTL after it finished expires.
+func (r *Reconciler) reconcileTTL(ctx *pkgctx.VirtualMachineExportContext) (ctrl.Result, error) {
+	vmExport := ctx.VMExport
+
+	ttl := vmExport.Spec.TTLSecondsAfterFinished
+	if ttl == nil {
+		return ctrl.Result{}, nil
+	}
+
+	expireTime := vmExport.Status.CompletionTime.Add(time.Duration(*ttl) * time.Second)
+	if d := time.Until(expireTime); d > 0 {
+		return ctrl.Result{RequeueAfter: d}, nil
+	}
+
+	ctx.Logger.Info("Deleting VM export")
+	return ctrl.Result{}, client.IgnoreNotFound(r.Delete(ctx, vmExport))
+}
+
+// deleteServer deletes the Pod that receives the exported files, and the
+// Service and certificate through which they are downloaded.
+func (r *Reconciler) deleteServer(ctx *pkgctx.VirtualMachineExportContext) error {
+	vmExport := ctx.VMExport
+
+	objMeta := metav1.ObjectMeta{
+		Name:      ServerName(vmExport),
+		Namespace: vmExport.Namespace,
+	}
+
+	if err := r.Delete(ctx, &corev1.Pod{ObjectMeta: objMeta}); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	if vmExport.Spec.Target.Download != nil {
+		if err := r.Delete(ctx, &corev1.Service{ObjectMeta: objMeta}); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      TLSSecretName(vmExport),
+				Namespace: vmExport.Namespace,
+			},
+		}
+		if err := r.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ServerName returns the name of the Pod that receives the exported files,
+// and of the Service through which they are downloaded.
+func ServerName(vmExport *vmopv1.VirtualMachineExport) string {
+	return vmExport.Name + "-server"
+}
+
+// TokenSecretName returns the name of the Secret that contains the bearer
+// token used to authenticate requests to the server.
+func TokenSecretName(vmExport *vmopv1.VirtualMachineExport) string {
+	return vmExport.Name + "-token"
+}
+
+// TLSSecretName returns the name of the Secret that contains the certificate
+// and key of the HTTPS server from which the exported files are downloaded.
+func TLSSecretName(vmExport *vmopv1.VirtualMachineExport) string {
+	return vmExport.Name + "-tls"
+}
+
+// loadBalancerHost returns the IP address or hostname of the Service's load
+// balancer, or an empty string if one has not been assigned yet.
+func loadBalancerHost(svc *corev1.Service) string {
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			return ingress.IP
+		}
+		if ingress.Hostname != "" {
+			return ingress.Hostname
+		}
+	}
+	return ""
+}
+
+// downloadURL returns the URL from which the exported files are downloaded.
+func downloadURL(host string) string {
+	u := url.URL{
+		Scheme: "https",
+		Host:   net.JoinHostPort(host, strconv.Itoa(DownloadPort)),
+		Path:   "/",
+	}
+	return u.String()
+}
+
+// newServerCertificate returns a PEM-encoded, self-signed certificate and its
+// key for the provided hosts, which may be IP addresses or DNS names.
+func newServerCertificate(commonName string, hosts []string, notAfter time.Time) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-5 * time.Minute),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		nil
+}
+
+// newServerPod returns the Pod that receives the exported files over HTTP, and
+// serves them for download over HTTPS when the target is a download URL. The
+// server runs as an unprivileged user with a read-only root filesystem.
+func newServerPod(
+	vmExport *vmopv1.VirtualMachineExport,
+	vm *vmopv1.VirtualMachine,
+	image string,
+	command []string) *corev1.Pod {
+
+	volume := corev1.Volume{
+		Name: "data",
+	}
+	volumeMount := corev1.VolumeMount{
+		Name:      "data",
+		MountPath: serverDataDir,
+	}
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+		},
+	}
+
+	if pvc := vmExport.Spec.Target.PersistentVolumeClaim; pvc != nil {
+		volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: pvc.ClaimName,
+		}
+		volumeMount.SubPath = pvc.Path
+	} else {
+		// The node's ephemeral storage is requested so the Pod is only
+		// scheduled to a node with room for the exported files, and the Pod
+		// is evicted rather than filling the node's disk.
+		sizeLimit := serverDataSize(vm)
+		volume.EmptyDir = &corev1.EmptyDirVolumeSource{
+			SizeLimit: &sizeLimit,
+		}
+		resources.Requests[corev1.ResourceEphemeralStorage] = sizeLimit
+		resources.Limits[corev1.ResourceEphemeralStorage] = sizeLimit
+	}
+
+	container := corev1.Container{
+		Name:    "server",
+		Image:   image,
+		Command: command,
+		Env: []corev1.EnvVar{
+			{
+				Name: serverTokenEnvVar,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: TokenSecretName(vmExport),
+						},
+						Key: vmopv1.VirtualMachineExportTokenSecretKey,
+					},
+				},
+			},
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "http",
+				ContainerPort: ServerPort,
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{volumeMount},
+		Resources:    resources,
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			ReadOnlyRootFilesystem:   ptr.To(true),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+		},
+	}
+	volumes := []corev1.Volume{volume}
+
+	if vmExport.Spec.Target.Download != nil {
+		container.Ports = append(container.Ports, corev1.ContainerPort{
+			Name:          "https",
+			ContainerPort: ServerTLSPort,
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "tls",
+			MountPath: serverTLSDir,
+			ReadOnly:  true,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: "tls",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: TLSSecretName(vmExport),
+				},
+			},
+		})
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ServerName(vmExport),
+			Namespace: vmExport.Namespace,
+			Labels: map[string]string{
+				ServerLabelKey: vmExport.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyAlways,
+			Containers:    []corev1.Container{container},
+			Volumes:       volumes,
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot: ptr.To(true),
+				RunAsUser:    ptr.To[int64](serverUserID),
+				RunAsGroup:   ptr.To[int64](serverUserID),
+				FSGroup:      ptr.To[int64](serverUserID),
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeRuntimeDefault,
+				},
+			},
+		},
+	}
+}
+
+// serverDataSize returns the size of the EmptyDir volume to which the exported
+// files are written. The exported disks are compressed, so they do not exceed
+// the capacity of the VM's disks.
+func serverDataSize(vm *vmopv1.VirtualMachine) resource.Quantity {
+	size := resource.MustParse(serverDataOverhead)
+	for _, v := range vm.Status.Volumes {
+		if v.Limit != nil {
+			size.Add(*v.Limit)
+		}
+	}
+	return size
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineexport_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineexport"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.V1Alpha3,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx      *builder.IntegrationTestContext
+		vm       *vmopv1.VirtualMachine
+		vmExport *vmopv1.VirtualMachineExport
+	)
+
+	getVirtualMachineExport := func(ctx *builder.IntegrationTestContext, objKey client.ObjectKey) *vmopv1.VirtualMachineExport {
+		vmExport := &vmopv1.VirtualMachineExport{}
+		if err := ctx.Client.Get(ctx, objKey, vmExport); err != nil {
+			return nil
+		}
+		return vmExport
+	}
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: ctx.Namespace,
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ImageName:  "dummy-image",
+				ClassName:  "dummy-class",
+				PowerState: vmopv1.VirtualMachinePowerStateOff,
+			},
+		}
+
+		vmExport = builder.DummyVirtualMachineExport("dummy-export", ctx.Namespace, vm.Name)
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		intgFakeVMProvider.Reset()
+	})
+
+	When("the source VM is powered off", func() {
+		BeforeEach(func() {
+			Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+			vm.Status.UniqueID = "dummy-vm-unique-id"
+			vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+			Expect(ctx.Client.Status().Update(ctx, vm)).To(Succeed())
+
+			Expect(ctx.Client.Create(ctx, vmExport)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			err := ctx.Client.Delete(ctx, vmExport)
+			Expect(client.IgnoreNotFound(err)).ToNot(HaveOccurred())
+			err = ctx.Client.Delete(ctx, vm)
+			Expect(client.IgnoreNotFound(err)).ToNot(HaveOccurred())
+		})
+
+		It("creates the server and waits for it to be ready", func() {
+			Eventually(func(g Gomega) {
+				vmExport := getVirtualMachineExport(ctx, client.ObjectKeyFromObject(vmExport))
+				g.Expect(vmExport).ToNot(BeNil())
+				g.Expect(conditions.IsTrue(vmExport, vmopv1.VirtualMachineExportConditionSourceValid)).To(BeTrue())
+				g.Expect(conditions.GetReason(vmExport,
+					vmopv1.VirtualMachineExportConditionTargetValid)).To(Equal(vmopv1.TargetServerNotReadyReason))
+			}).Should(Succeed())
+
+			serverKey := client.ObjectKey{Namespace: ctx.Namespace, Name: virtualmachineexport.ServerName(vmExport)}
+			svc := &corev1.Service{}
+			Expect(ctx.Client.Get(ctx, serverKey, svc)).To(Succeed())
+			Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+
+			secretKey := client.ObjectKey{Namespace: ctx.Namespace, Name: virtualmachineexport.TokenSecretName(vmExport)}
+			Expect(ctx.Client.Get(ctx, secretKey, &corev1.Secret{})).To(Succeed())
+
+			By("the Service is assigned a load balancer address", func() {
+				svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.10"}}
+				Expect(ctx.Client.Status().Update(ctx, svc)).To(Succeed())
+			})
+
+			Eventually(func(g Gomega) {
+				g.Expect(ctx.Client.Get(ctx, serverKey, &corev1.Pod{})).To(Succeed())
+			}).Should(Succeed())
+
+			tlsSecretKey := client.ObjectKey{Namespace: ctx.Namespace, Name: virtualmachineexport.TLSSecretName(vmExport)}
+			Expect(ctx.Client.Get(ctx, tlsSecretKey, &corev1.Secret{})).To(Succeed())
+		})
+	})
+
+	When("the source VM is powered on", func() {
+		BeforeEach(func() {
+			vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+			Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+			vm.Status.UniqueID = "dummy-vm-unique-id"
+			vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+			Expect(ctx.Client.Status().Update(ctx, vm)).To(Succeed())
+
+			Expect(ctx.Client.Create(ctx, vmExport)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			err := ctx.Client.Delete(ctx, vmExport)
+			Expect(client.IgnoreNotFound(err)).ToNot(HaveOccurred())
+			err = ctx.Client.Delete(ctx, vm)
+			Expect(client.IgnoreNotFound(err)).ToNot(HaveOccurred())
+		})
+
+		It("marks SourceValid false", func() {
+			Eventually(func(g Gomega) {
+				vmExport := getVirtualMachineExport(ctx, client.ObjectKeyFromObject(vmExport))
+				g.Expect(vmExport).ToNot(BeNil())
+				g.Expect(conditions.GetReason(vmExport,
+					vmopv1.VirtualMachineExportConditionSourceValid)).To(Equal(vmopv1.SourceVirtualMachinePoweredOnReason))
+				g.Expect(vmExport.Status.Attempts).To(BeZero())
+			}).Should(Succeed())
+		})
+	})
+}
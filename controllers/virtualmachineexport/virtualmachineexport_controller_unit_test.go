@@ -0,0 +1,484 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineexport_test
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineexport"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.V1Alpha3,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+
+		reconciler     *virtualmachineexport.Reconciler
+		fakeVMProvider *providerfake.VMProvider
+
+		vm          *vmopv1.VirtualMachine
+		vmExport    *vmopv1.VirtualMachineExport
+		serverPod   *corev1.Pod
+		serverSvc   *corev1.Service
+		vmExportCtx *pkgctx.VirtualMachineExportContext
+		serverImage string
+	)
+
+	BeforeEach(func() {
+		serverImage = "dummy-server-image"
+
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				UniqueID:   "dummy-id",
+				PowerState: vmopv1.VirtualMachinePowerStateOff,
+			},
+		}
+
+		vmExport = builder.DummyVirtualMachineExport("dummy-export", vm.Namespace, vm.Name)
+		vmExport.UID = types.UID("dummy-export-uid")
+
+		serverPod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      virtualmachineexport.ServerName(vmExport),
+				Namespace: vmExport.Namespace,
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				PodIP: "192.168.1.10",
+			},
+		}
+
+		serverSvc = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      virtualmachineexport.ServerName(vmExport),
+				Namespace: vmExport.Namespace,
+			},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeLoadBalancer,
+			},
+			Status: corev1.ServiceStatus{
+				LoadBalancer: corev1.LoadBalancerStatus{
+					Ingress: []corev1.LoadBalancerIngress{{IP: "10.0.0.10"}},
+				},
+			},
+		}
+
+		initObjects = []client.Object{vm, vmExport}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+			config.VMExportServerImage = serverImage
+		})
+
+		reconciler = virtualmachineexport.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProvider,
+		)
+		fakeVMProvider = ctx.VMProvider.(*providerfake.VMProvider)
+		fakeVMProvider.Reset()
+
+		vmExportCtx = &pkgctx.VirtualMachineExportContext{
+			Context:  ctx,
+			Logger:   ctx.Logger.WithName(vmExport.Name),
+			VMExport: vmExport,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+	})
+
+	getObj := func(name string, obj client.Object) error {
+		return ctx.Client.Get(ctx, client.ObjectKey{Namespace: vmExport.Namespace, Name: name}, obj)
+	}
+
+	// reconcileUntilExported reconciles the export until the export started
+	// by the first reconcile is complete.
+	reconcileUntilExported := func() {
+		_, err := reconciler.ReconcileNormal(vmExportCtx)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			_, err := reconciler.ReconcileNormal(vmExportCtx)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(conditions.GetReason(vmExport,
+				vmopv1.VirtualMachineExportConditionExported)).ToNot(Equal(vmopv1.ExportingReason))
+		}).Should(Succeed())
+	}
+
+	Context("ReconcileNormal", func() {
+		It("creates the token Secret and the LoadBalancer Service, and waits for its address", func() {
+			_, err := reconciler.ReconcileNormal(vmExportCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conditions.IsTrue(vmExport,
+				vmopv1.VirtualMachineExportConditionSourceValid)).To(BeTrue())
+			Expect(conditions.GetReason(vmExport,
+				vmopv1.VirtualMachineExportConditionTargetValid)).To(Equal(vmopv1.TargetServerNotReadyReason))
+
+			secret := &corev1.Secret{}
+			Expect(getObj(virtualmachineexport.TokenSecretName(vmExport), secret)).To(Succeed())
+			Expect(secret.Data).To(HaveKey(vmopv1.VirtualMachineExportTokenSecretKey))
+			Expect(secret.Data[vmopv1.VirtualMachineExportTokenSecretKey]).ToNot(BeEmpty())
+			Expect(secret.OwnerReferences).To(HaveLen(1))
+
+			svc := &corev1.Service{}
+			Expect(getObj(virtualmachineexport.ServerName(vmExport), svc)).To(Succeed())
+			Expect(svc.OwnerReferences).To(HaveLen(1))
+			Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+			Expect(svc.Spec.Selector).To(HaveKeyWithValue(virtualmachineexport.ServerLabelKey, vmExport.Name))
+			Expect(svc.Spec.Ports).To(HaveLen(1))
+			Expect(svc.Spec.Ports[0].Port).To(BeEquivalentTo(virtualmachineexport.DownloadPort))
+			Expect(svc.Spec.Ports[0].TargetPort.IntValue()).To(Equal(virtualmachineexport.ServerTLSPort))
+
+			// The server is not started until its certificate is created for
+			// the address of the Service.
+			err = getObj(virtualmachineexport.ServerName(vmExport), &corev1.Pod{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			Expect(vmExport.Status.Attempts).To(BeZero())
+		})
+
+		When("the Service has a load balancer address", func() {
+			BeforeEach(func() {
+				vm.Status.Volumes = []vmopv1.VirtualMachineVolumeStatus{
+					{Name: "disk-1", Limit: ptr.To(resource.MustParse("10Gi"))},
+					{Name: "disk-2", Limit: ptr.To(resource.MustParse("5Gi"))},
+				}
+				initObjects = append(initObjects, serverSvc)
+			})
+
+			It("creates the TLS Secret and the server Pod", func() {
+				_, err := reconciler.ReconcileNormal(vmExportCtx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conditions.GetReason(vmExport,
+					vmopv1.VirtualMachineExportConditionTargetValid)).To(Equal(vmopv1.TargetServerNotReadyReason))
+
+				tlsSecret := &corev1.Secret{}
+				Expect(getObj(virtualmachineexport.TLSSecretName(vmExport), tlsSecret)).To(Succeed())
+				Expect(tlsSecret.Type).To(Equal(corev1.SecretTypeTLS))
+				Expect(tlsSecret.Data).To(HaveKey(corev1.TLSPrivateKeyKey))
+				Expect(tlsSecret.OwnerReferences).To(HaveLen(1))
+
+				block, _ := pem.Decode(tlsSecret.Data[corev1.TLSCertKey])
+				Expect(block).ToNot(BeNil())
+				cert, err := x509.ParseCertificate(block.Bytes)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cert.VerifyHostname("10.0.0.10")).To(Succeed())
+				Expect(cert.VerifyHostname("dummy-export-server.dummy-ns.svc")).To(Succeed())
+
+				pod := &corev1.Pod{}
+				Expect(getObj(virtualmachineexport.ServerName(vmExport), pod)).To(Succeed())
+				Expect(pod.OwnerReferences).To(HaveLen(1))
+				Expect(pod.Labels).To(HaveKeyWithValue(virtualmachineexport.ServerLabelKey, vmExport.Name))
+				Expect(pod.Spec.Containers).To(HaveLen(1))
+				Expect(pod.Spec.Containers[0].Image).To(Equal(serverImage))
+				Expect(pod.Spec.Containers[0].Command).To(BeEmpty())
+				Expect(pod.Spec.Containers[0].Env).To(HaveLen(1))
+				Expect(pod.Spec.Containers[0].Env[0].ValueFrom.SecretKeyRef.Name).To(
+					Equal(virtualmachineexport.TokenSecretName(vmExport)))
+				Expect(pod.Spec.Containers[0].Ports).To(HaveLen(2))
+				Expect(pod.Spec.Volumes).To(HaveLen(2))
+				Expect(pod.Spec.Volumes[0].EmptyDir).ToNot(BeNil())
+				Expect(pod.Spec.Volumes[0].EmptyDir.SizeLimit).To(HaveValue(Equal(resource.MustParse("16Gi"))))
+				Expect(pod.Spec.Containers[0].Resources.Limits).To(HaveKeyWithValue(
+					corev1.ResourceEphemeralStorage, resource.MustParse("16Gi")))
+				Expect(pod.Spec.Volumes[1].Secret).ToNot(BeNil())
+				Expect(pod.Spec.Volumes[1].Secret.SecretName).To(Equal(tlsSecret.Name))
+
+				Expect(pod.Spec.SecurityContext).ToNot(BeNil())
+				Expect(pod.Spec.SecurityContext.RunAsNonRoot).To(HaveValue(BeTrue()))
+				Expect(pod.Spec.Containers[0].SecurityContext).ToNot(BeNil())
+				Expect(pod.Spec.Containers[0].SecurityContext.AllowPrivilegeEscalation).To(HaveValue(BeFalse()))
+				Expect(pod.Spec.Containers[0].SecurityContext.Capabilities.Drop).To(ConsistOf(corev1.Capability("ALL")))
+			})
+		})
+
+		When("the server Pod is running", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, serverPod, serverSvc)
+			})
+
+			It("exports the VM to the server and completes with a download URL", func() {
+				var (
+					targetURL, token string
+				)
+				fakeVMProvider.Lock()
+				fakeVMProvider.ExportVirtualMachineFn = func(_ context.Context,
+					_ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineExport, u, t string) ([]string, error) {
+					targetURL, token = u, t
+					return []string{"dummy-vm.ovf", "dummy-vm-disk-0.vmdk"}, nil
+				}
+				fakeVMProvider.Unlock()
+
+				reconcileUntilExported()
+				Expect(conditions.IsTrue(vmExport,
+					vmopv1.VirtualMachineExportConditionExported)).To(BeTrue())
+
+				secret := &corev1.Secret{}
+				Expect(getObj(virtualmachineexport.TokenSecretName(vmExport), secret)).To(Succeed())
+
+				fakeVMProvider.Lock()
+				Expect(targetURL).To(Equal("http://192.168.1.10:8080/"))
+				Expect(token).To(Equal(string(secret.Data[vmopv1.VirtualMachineExportTokenSecretKey])))
+				fakeVMProvider.Unlock()
+
+				Expect(vmExport.Status.Files).To(Equal([]string{"dummy-vm.ovf", "dummy-vm-disk-0.vmdk"}))
+				Expect(vmExport.Status.Attempts).To(BeEquivalentTo(1))
+				Expect(vmExport.Status.Ready).To(BeTrue())
+				Expect(conditions.IsTrue(vmExport,
+					vmopv1.VirtualMachineExportConditionComplete)).To(BeTrue())
+				Expect(vmExport.Status.Download).ToNot(BeNil())
+				Expect(vmExport.Status.Download.URL).To(Equal("https://10.0.0.10:443/"))
+				Expect(vmExport.Status.Download.TokenSecretName).To(Equal(secret.Name))
+
+				tlsSecret := &corev1.Secret{}
+				Expect(getObj(virtualmachineexport.TLSSecretName(vmExport), tlsSecret)).To(Succeed())
+				Expect(vmExport.Status.Download.CACertificate).To(Equal(string(tlsSecret.Data[corev1.TLSCertKey])))
+				Expect(vmExport.Status.Download.ExpirationTime.Time).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+
+				// The server is kept until the download URL expires.
+				Expect(getObj(serverPod.Name, &corev1.Pod{})).To(Succeed())
+			})
+
+			When("the target is a PersistentVolumeClaim", func() {
+				BeforeEach(func() {
+					vmExport.Spec.Target = vmopv1.VirtualMachineExportTarget{
+						PersistentVolumeClaim: &vmopv1.VirtualMachineExportTargetPersistentVolumeClaim{
+							ClaimName: "dummy-pvc",
+							Path:      "exports",
+						},
+					}
+					initObjects = append(initObjects, &corev1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "dummy-pvc",
+							Namespace: vmExport.Namespace,
+						},
+					})
+				})
+
+				It("exports the VM and deletes the server Pod", func() {
+					reconcileUntilExported()
+					Expect(vmExport.Status.Ready).To(BeTrue())
+					Expect(vmExport.Status.Download).To(BeNil())
+
+					err := getObj(serverPod.Name, &corev1.Pod{})
+					Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+
+			When("the export fails", func() {
+				JustBeforeEach(func() {
+					fakeVMProvider.Lock()
+					fakeVMProvider.ExportVirtualMachineFn = func(_ context.Context,
+						_ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineExport, _, _ string) ([]string, error) {
+						return nil, errors.New("export failed")
+					}
+					fakeVMProvider.Unlock()
+				})
+
+				It("marks Exported false and waits before exporting the VM again", func() {
+					reconcileUntilExported()
+					Expect(conditions.GetReason(vmExport,
+						vmopv1.VirtualMachineExportConditionExported)).To(Equal(vmopv1.ExportFailureReason))
+
+					result, err := reconciler.ReconcileNormal(vmExportCtx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+					Expect(vmExport.Status.Attempts).To(BeEquivalentTo(1))
+					Expect(vmExport.Status.Ready).To(BeFalse())
+				})
+			})
+		})
+
+		When("the source VM does not exist", func() {
+			BeforeEach(func() {
+				initObjects = []client.Object{vmExport}
+			})
+
+			It("marks SourceValid false", func() {
+				_, err := reconciler.ReconcileNormal(vmExportCtx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conditions.GetReason(vmExport,
+					vmopv1.VirtualMachineExportConditionSourceValid)).To(Equal(vmopv1.SourceVirtualMachineNotExistReason))
+			})
+		})
+
+		When("the source VM is powered on", func() {
+			BeforeEach(func() {
+				vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+			})
+
+			It("marks SourceValid false and does not create the server Pod", func() {
+				_, err := reconciler.ReconcileNormal(vmExportCtx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conditions.GetReason(vmExport,
+					vmopv1.VirtualMachineExportConditionSourceValid)).To(Equal(vmopv1.SourceVirtualMachinePoweredOnReason))
+
+				err = getObj(virtualmachineexport.ServerName(vmExport), &corev1.Pod{})
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		When("the server image is not configured", func() {
+			BeforeEach(func() {
+				serverImage = ""
+			})
+
+			It("marks TargetValid false", func() {
+				_, err := reconciler.ReconcileNormal(vmExportCtx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conditions.GetReason(vmExport,
+					vmopv1.VirtualMachineExportConditionTargetValid)).To(Equal(vmopv1.TargetNotSupportedReason))
+			})
+
+			When("the VM Operator Pod exists", func() {
+				BeforeEach(func() {
+					cfg := pkgcfg.Default()
+					initObjects = append(initObjects, serverSvc, &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      cfg.PodName,
+							Namespace: cfg.PodNamespace,
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "manager",
+									Image: "vmoperator-controller:latest",
+								},
+							},
+						},
+					})
+				})
+
+				It("uses the export server in VM Operator's image", func() {
+					_, err := reconciler.ReconcileNormal(vmExportCtx)
+					Expect(err).NotTo(HaveOccurred())
+
+					pod := &corev1.Pod{}
+					Expect(getObj(virtualmachineexport.ServerName(vmExport), pod)).To(Succeed())
+					Expect(pod.Spec.Containers).To(HaveLen(1))
+					Expect(pod.Spec.Containers[0].Image).To(Equal("vmoperator-controller:latest"))
+					Expect(pod.Spec.Containers[0].Command).To(Equal([]string{"/export-server"}))
+				})
+			})
+		})
+
+		When("the target PersistentVolumeClaim does not exist", func() {
+			BeforeEach(func() {
+				vmExport.Spec.Target = vmopv1.VirtualMachineExportTarget{
+					PersistentVolumeClaim: &vmopv1.VirtualMachineExportTargetPersistentVolumeClaim{
+						ClaimName: "dummy-pvc",
+					},
+				}
+			})
+
+			It("marks TargetValid false", func() {
+				_, err := reconciler.ReconcileNormal(vmExportCtx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conditions.GetReason(vmExport,
+					vmopv1.VirtualMachineExportConditionTargetValid)).To(Equal(vmopv1.TargetPersistentVolumeClaimNotExistReason))
+			})
+		})
+
+		When("the download URL has expired", func() {
+			BeforeEach(func() {
+				vmExport.Status.Ready = true
+				vmExport.Status.CompletionTime = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+				vmExport.Status.Download = &vmopv1.VirtualMachineExportDownloadStatus{
+					URL:             "https://10.0.0.10:443/",
+					TokenSecretName: virtualmachineexport.TokenSecretName(vmExport),
+					ExpirationTime:  metav1.NewTime(time.Now().Add(-time.Hour)),
+				}
+				initObjects = append(initObjects, serverPod, serverSvc, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      virtualmachineexport.TLSSecretName(vmExport),
+						Namespace: vmExport.Namespace,
+					},
+				})
+			})
+
+			It("deletes the server and clears the URL", func() {
+				_, err := reconciler.ReconcileNormal(vmExportCtx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vmExport.Status.Download.URL).To(BeEmpty())
+				Expect(conditions.GetReason(vmExport,
+					vmopv1.VirtualMachineExportConditionTargetValid)).To(Equal(vmopv1.DownloadExpiredReason))
+
+				err = getObj(serverPod.Name, &corev1.Pod{})
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				err = getObj(serverSvc.Name, &corev1.Service{})
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				err = getObj(virtualmachineexport.TLSSecretName(vmExport), &corev1.Secret{})
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		When("the export is complete and the TTL has expired", func() {
+			BeforeEach(func() {
+				vmExport.Spec.Target = vmopv1.VirtualMachineExportTarget{
+					PersistentVolumeClaim: &vmopv1.VirtualMachineExportTargetPersistentVolumeClaim{
+						ClaimName: "dummy-pvc",
+					},
+				}
+				vmExport.Spec.TTLSecondsAfterFinished = ptr.To[int64](0)
+				vmExport.Status.Ready = true
+				vmExport.Status.CompletionTime = metav1.Now()
+			})
+
+			It("deletes the export", func() {
+				_, err := reconciler.ReconcileNormal(vmExportCtx)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ctx.Client.Get(ctx, client.ObjectKeyFromObject(vmExport), &vmopv1.VirtualMachineExport{})
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineguestoperation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+)
+
+const (
+	// InProgressRequeueDelay is how often an operation is reconciled while
+	// its command is running in the guest.
+	InProgressRequeueDelay = time.Second * 5
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineGuestOperation{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProvider,
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider providers.VirtualMachineProviderInterface) *Reconciler {
+	return &Reconciler{
+		Context:    ctx,
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineGuestOperation object.
+type Reconciler struct {
+	client.Client
+	Context    context.Context
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider providers.VirtualMachineProviderInterface
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineguestoperations,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineguestoperations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	op := &vmopv1.VirtualMachineGuestOperation{}
+	if err := r.Get(ctx, req.NamespacedName, op); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	opCtx := &pkgctx.VirtualMachineGuestOperationContext{
+		Context:        ctx,
+		Logger:         ctrl.Log.WithName("VirtualMachineGuestOperation").WithValues("name", req.NamespacedName),
+		GuestOperation: op,
+		VM:             &vmopv1.VirtualMachine{},
+	}
+
+	if !op.DeletionTimestamp.IsZero() || IsDone(op) {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(op, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", opCtx, err)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, op); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			opCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	err = r.Get(ctx, client.ObjectKey{Name: op.Spec.VirtualMachineName, Namespace: op.Namespace}, opCtx.VM)
+	if err != nil {
+		r.Recorder.Warn(op, "VirtualMachine Not Found", "")
+		return ctrl.Result{}, fmt.Errorf("failed to get vm %s: %w", op.Spec.VirtualMachineName, err)
+	}
+
+	if err := r.ReconcileNormal(opCtx); err != nil {
+		opCtx.Logger.Error(err, "failed to reconcile VirtualMachineGuestOperation")
+		return ctrl.Result{}, err
+	}
+
+	if c := conditions.Get(op, vmopv1.VirtualMachineGuestOperationConditionCompleted); c != nil &&
+		c.Reason == vmopv1.GuestOperationInProgressReason {
+		return ctrl.Result{RequeueAfter: InProgressRequeueDelay}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// IsDone returns true if the operation has completed, timed out, lost its
+// process, or is not allowed to run. Such an operation is not reconciled
+// again.
+func IsDone(op *vmopv1.VirtualMachineGuestOperation) bool {
+	c := conditions.Get(op, vmopv1.VirtualMachineGuestOperationConditionCompleted)
+	if c == nil {
+		return false
+	}
+	if c.Status == metav1.ConditionTrue {
+		return true
+	}
+	return c.Reason == vmopv1.GuestOperationTimedOutReason ||
+		c.Reason == vmopv1.GuestOperationProcessNotFoundReason ||
+		c.Reason == vmopv1.GuestOperationNotAllowedReason
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineGuestOperationContext) error {
+	ctx.Logger.Info("Reconciling VirtualMachineGuestOperation")
+	defer func() {
+		ctx.Logger.Info("Finished reconciling VirtualMachineGuestOperation")
+	}()
+
+	op := ctx.GuestOperation
+
+	r.ReconcileOwnerReferences(ctx)
+
+	if ctx.VM.Annotations[vmopv1.GuestOperationsAnnotation] != "true" {
+		conditions.MarkFalse(
+			op,
+			vmopv1.VirtualMachineGuestOperationConditionCompleted,
+			vmopv1.GuestOperationNotAllowedReason,
+			"VirtualMachine %s does not have the %s annotation set to true",
+			ctx.VM.Name, vmopv1.GuestOperationsAnnotation)
+		r.Recorder.Warn(op, "Guest Operations Not Allowed", "")
+		return nil
+	}
+
+	username, password, err := r.getCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	var content []byte
+	if op.Spec.CopyFile != nil {
+		if content, err = r.getCopyFileContent(ctx); err != nil {
+			return err
+		}
+	}
+
+	wasRunning := op.Status.ProcessID != 0
+
+	if err := r.VMProvider.RunVirtualMachineGuestOperation(ctx, ctx.VM, op, username, password, content); err != nil {
+		conditions.MarkFalse(
+			op,
+			vmopv1.VirtualMachineGuestOperationConditionCompleted,
+			vmopv1.GuestOperationFailedReason,
+			"%s",
+			err)
+		return fmt.Errorf("failed to run guest operation: %w", err)
+	}
+
+	switch {
+	case !wasRunning && op.Status.ProcessID != 0:
+		r.Recorder.EmitEvent(op, "Started", nil, false)
+	case conditions.IsTrue(op, vmopv1.VirtualMachineGuestOperationConditionCompleted):
+		r.Recorder.EmitEvent(op, "Completed", nil, false)
+	case conditions.GetReason(op, vmopv1.VirtualMachineGuestOperationConditionCompleted) == vmopv1.GuestOperationTimedOutReason:
+		r.Recorder.Warn(op, "Timed Out", "")
+	case conditions.GetReason(op, vmopv1.VirtualMachineGuestOperationConditionCompleted) == vmopv1.GuestOperationProcessNotFoundReason:
+		r.Recorder.Warn(op, "Process Not Found", "")
+	}
+
+	return nil
+}
+
+func (r *Reconciler) getCredentials(
+	ctx *pkgctx.VirtualMachineGuestOperationContext) (string, string, error) {
+
+	op := ctx.GuestOperation
+
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Name: op.Spec.CredentialsSecretName, Namespace: op.Namespace}
+	if err := r.Get(ctx, key, secret); err != nil {
+		return "", "", fmt.Errorf("failed to get credentials secret %s: %w", key.Name, err)
+	}
+
+	username := secret.Data[vmopv1.VirtualMachineGuestOperationUsernameKey]
+	if len(username) == 0 {
+		return "", "", fmt.Errorf("credentials secret %s does not have the %q key",
+			key.Name, vmopv1.VirtualMachineGuestOperationUsernameKey)
+	}
+
+	return string(username), string(secret.Data[vmopv1.VirtualMachineGuestOperationPasswordKey]), nil
+}
+
+func (r *Reconciler) getCopyFileContent(
+	ctx *pkgctx.VirtualMachineGuestOperationContext) ([]byte, error) {
+
+	op := ctx.GuestOperation
+	content := op.Spec.CopyFile.Content
+
+	if content.From == nil {
+		if content.Value == nil {
+			return nil, nil
+		}
+		return []byte(*content.Value), nil
+	}
+
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Name: content.From.Name, Namespace: op.Namespace}
+	if err := r.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("failed to get content secret %s: %w", key.Name, err)
+	}
+
+	data, ok := secret.Data[content.From.Key]
+	if !ok {
+		return nil, fmt.Errorf("content secret %s does not have the %q key", key.Name, content.From.Key)
+	}
+
+	return data, nil
+}
+
+func (r *Reconciler) ReconcileOwnerReferences(ctx *pkgctx.VirtualMachineGuestOperationContext) {
+	isController := true
+	ownerRef := metav1.OwnerReference{
+		APIVersion: vmopv1.GroupVersion.String(),
+		Kind:       "VirtualMachine",
+		Name:       ctx.VM.Name,
+		UID:        ctx.VM.UID,
+		Controller: &isController,
+	}
+
+	ctx.GuestOperation.SetOwnerReferences([]metav1.OwnerReference{ownerRef})
+}
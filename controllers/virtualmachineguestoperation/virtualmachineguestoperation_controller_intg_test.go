@@ -0,0 +1,146 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineguestoperation_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.V1Alpha3,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx    *builder.IntegrationTestContext
+		op     *vmopv1.VirtualMachineGuestOperation
+		vm     *vmopv1.VirtualMachine
+		secret *corev1.Secret
+	)
+
+	getGuestOperation := func() *vmopv1.VirtualMachineGuestOperation {
+		obj := &vmopv1.VirtualMachineGuestOperation{}
+		if err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(op), obj); err != nil {
+			return nil
+		}
+		return obj
+	}
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: ctx.Namespace,
+				Annotations: map[string]string{
+					vmopv1.GuestOperationsAnnotation: "true",
+				},
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ImageName:  "dummy-image",
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+			},
+		}
+
+		op = builder.DummyVirtualMachineGuestOperation("dummy-op", ctx.Namespace, vm.Name)
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      op.Spec.CredentialsSecretName,
+				Namespace: ctx.Namespace,
+			},
+			Data: map[string][]byte{
+				vmopv1.VirtualMachineGuestOperationUsernameKey: []byte("root"),
+				vmopv1.VirtualMachineGuestOperationPasswordKey: []byte("password"),
+			},
+		}
+
+		intgFakeVMProvider.Lock()
+		defer intgFakeVMProvider.Unlock()
+		intgFakeVMProvider.RunVirtualMachineGuestOperationFn = func(
+			_ context.Context, _ *vmopv1.VirtualMachine, op *vmopv1.VirtualMachineGuestOperation,
+			_, _ string, _ []byte) error {
+			op.Status.StartTime = metav1.Now()
+			op.Status.CompletionTime = metav1.Now()
+			op.Status.ProcessID = 42
+			op.Status.ExitCode = ptr.To[int32](0)
+			op.Status.Stdout = "hello\n"
+			conditions.MarkTrue(op, vmopv1.VirtualMachineGuestOperationConditionCompleted)
+			return nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(ctx.Client.Create(ctx, secret)).To(Succeed())
+		Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+		Expect(ctx.Client.Create(ctx, op)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		err := ctx.Client.Delete(ctx, op)
+		Expect(err == nil || apierrors.IsNotFound(err)).To(BeTrue())
+		err = ctx.Client.Delete(ctx, vm)
+		Expect(err == nil || apierrors.IsNotFound(err)).To(BeTrue())
+		err = ctx.Client.Delete(ctx, secret)
+		Expect(err == nil || apierrors.IsNotFound(err)).To(BeTrue())
+
+		ctx.AfterEach()
+		ctx = nil
+		intgFakeVMProvider.Reset()
+	})
+
+	It("runs the operation and records the result", func() {
+		var obj *vmopv1.VirtualMachineGuestOperation
+		Eventually(func(g Gomega) {
+			obj = getGuestOperation()
+			g.Expect(obj).ToNot(BeNil())
+			g.Expect(conditions.IsTrue(obj, vmopv1.VirtualMachineGuestOperationConditionCompleted)).To(BeTrue())
+		}).Should(Succeed(), "waiting for operation to complete")
+
+		Expect(obj.Status.ExitCode).To(HaveValue(BeEquivalentTo(0)))
+		Expect(obj.Status.Stdout).To(Equal("hello\n"))
+		Expect(obj.OwnerReferences).To(HaveLen(1))
+		Expect(obj.OwnerReferences[0].Name).To(Equal(vm.Name))
+	})
+
+	When("the VM does not allow guest operations", func() {
+		BeforeEach(func() {
+			vm.Annotations = nil
+		})
+
+		It("marks the operation not allowed", func() {
+			Eventually(func(g Gomega) {
+				obj := getGuestOperation()
+				g.Expect(obj).ToNot(BeNil())
+				c := conditions.Get(obj, vmopv1.VirtualMachineGuestOperationConditionCompleted)
+				g.Expect(c).ToNot(BeNil())
+				g.Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(c.Reason).To(Equal(vmopv1.GuestOperationNotAllowedReason))
+			}).Should(Succeed(), "waiting for operation to be not allowed")
+		})
+	})
+}
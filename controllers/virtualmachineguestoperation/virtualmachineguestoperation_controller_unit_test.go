@@ -0,0 +1,284 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineguestoperation_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineguestoperation"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.V1Alpha3,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	var (
+		initObjects    []client.Object
+		ctx            *builder.UnitTestContextForController
+		fakeVMProvider *providerfake.VMProvider
+
+		reconciler *virtualmachineguestoperation.Reconciler
+		opCtx      *pkgctx.VirtualMachineGuestOperationContext
+		op         *vmopv1.VirtualMachineGuestOperation
+		vm         *vmopv1.VirtualMachine
+		secret     *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+				UID:       types.UID("dummy-vm-uid"),
+				Annotations: map[string]string{
+					vmopv1.GuestOperationsAnnotation: "true",
+				},
+			},
+		}
+
+		op = builder.DummyVirtualMachineGuestOperation("dummy-op", vm.Namespace, vm.Name)
+		op.UID = types.UID("dummy-op-uid")
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      op.Spec.CredentialsSecretName,
+				Namespace: op.Namespace,
+			},
+			Data: map[string][]byte{
+				vmopv1.VirtualMachineGuestOperationUsernameKey: []byte("root"),
+				vmopv1.VirtualMachineGuestOperationPasswordKey: []byte("password"),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+
+		reconciler = virtualmachineguestoperation.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProvider,
+		)
+		fakeVMProvider = ctx.VMProvider.(*providerfake.VMProvider)
+
+		opCtx = &pkgctx.VirtualMachineGuestOperationContext{
+			Context:        ctx,
+			Logger:         ctx.Logger.WithName(op.Name),
+			GuestOperation: op,
+			VM:             vm,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+		fakeVMProvider.Reset()
+	})
+
+	Context("ReconcileNormal", func() {
+		var (
+			username, password string
+			content            []byte
+		)
+
+		BeforeEach(func() {
+			initObjects = append(initObjects, op, vm, secret)
+			username, password, content = "", "", nil
+		})
+
+		JustBeforeEach(func() {
+			fakeVMProvider.RunVirtualMachineGuestOperationFn = func(
+				_ context.Context, _ *vmopv1.VirtualMachine, op *vmopv1.VirtualMachineGuestOperation,
+				u, p string, c []byte) error {
+				username, password, content = u, p, c
+				op.Status.ProcessID = 42
+				conditions.MarkFalse(
+					op,
+					vmopv1.VirtualMachineGuestOperationConditionCompleted,
+					vmopv1.GuestOperationInProgressReason,
+					"")
+				return nil
+			}
+		})
+
+		It("runs the operation with the credentials", func() {
+			Expect(reconciler.ReconcileNormal(opCtx)).To(Succeed())
+
+			Expect(username).To(Equal("root"))
+			Expect(password).To(Equal("password"))
+			Expect(content).To(BeNil())
+			Expect(op.Status.ProcessID).To(BeEquivalentTo(42))
+			Expect(op.OwnerReferences).To(HaveLen(1))
+			Expect(op.OwnerReferences[0].UID).To(Equal(vm.UID))
+			Expect(ctx.Events).To(Receive(ContainSubstring("Started")))
+		})
+
+		When("the operation copies a file from a Secret", func() {
+			BeforeEach(func() {
+				secret.Data["file"] = []byte("hello")
+				op.Spec.Command = nil
+				op.Spec.CopyFile = &vmopv1.VirtualMachineGuestOperationCopyFile{
+					Path: "/tmp/hello.txt",
+					Content: vmopv1common.ValueOrSecretKeySelector{
+						From: &vmopv1common.SecretKeySelector{
+							Name: secret.Name,
+							Key:  "file",
+						},
+					},
+				}
+			})
+
+			It("runs the operation with the content", func() {
+				Expect(reconciler.ReconcileNormal(opCtx)).To(Succeed())
+				Expect(content).To(Equal([]byte("hello")))
+			})
+
+			When("the Secret does not have the key", func() {
+				BeforeEach(func() {
+					op.Spec.CopyFile.Content.From.Key = "missing"
+				})
+
+				It("returns an error", func() {
+					err := reconciler.ReconcileNormal(opCtx)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(`does not have the "missing" key`))
+				})
+			})
+		})
+
+		When("the VM does not allow guest operations", func() {
+			BeforeEach(func() {
+				vm.Annotations = nil
+			})
+
+			It("marks the operation not allowed", func() {
+				Expect(reconciler.ReconcileNormal(opCtx)).To(Succeed())
+
+				c := conditions.Get(op, vmopv1.VirtualMachineGuestOperationConditionCompleted)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.GuestOperationNotAllowedReason))
+				Expect(virtualmachineguestoperation.IsDone(op)).To(BeTrue())
+				Expect(username).To(BeEmpty())
+			})
+		})
+
+		When("the credentials Secret does not have a username", func() {
+			BeforeEach(func() {
+				delete(secret.Data, vmopv1.VirtualMachineGuestOperationUsernameKey)
+			})
+
+			It("returns an error", func() {
+				err := reconciler.ReconcileNormal(opCtx)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(`does not have the "username" key`))
+			})
+		})
+
+		When("the command's process is not found in the guest", func() {
+			BeforeEach(func() {
+				op.Status.ProcessID = 42
+			})
+
+			JustBeforeEach(func() {
+				fakeVMProvider.RunVirtualMachineGuestOperationFn = func(
+					_ context.Context, _ *vmopv1.VirtualMachine, op *vmopv1.VirtualMachineGuestOperation,
+					_, _ string, _ []byte) error {
+					conditions.MarkFalse(
+						op,
+						vmopv1.VirtualMachineGuestOperationConditionCompleted,
+						vmopv1.GuestOperationProcessNotFoundReason,
+						"")
+					return nil
+				}
+			})
+
+			It("marks the operation done", func() {
+				Expect(reconciler.ReconcileNormal(opCtx)).To(Succeed())
+
+				Expect(virtualmachineguestoperation.IsDone(op)).To(BeTrue())
+				Expect(ctx.Events).To(Receive(ContainSubstring("Process Not Found")))
+			})
+		})
+
+		When("running the operation fails", func() {
+			JustBeforeEach(func() {
+				fakeVMProvider.RunVirtualMachineGuestOperationFn = func(
+					_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineGuestOperation,
+					_, _ string, _ []byte) error {
+					return errors.New("fake error")
+				}
+			})
+
+			It("marks the operation failed and returns an error", func() {
+				err := reconciler.ReconcileNormal(opCtx)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake error"))
+
+				c := conditions.Get(op, vmopv1.VirtualMachineGuestOperationConditionCompleted)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Reason).To(Equal(vmopv1.GuestOperationFailedReason))
+				Expect(c.Message).To(ContainSubstring("fake error"))
+				Expect(virtualmachineguestoperation.IsDone(op)).To(BeFalse())
+			})
+		})
+	})
+
+	Context("IsDone", func() {
+		It("is not done without the condition", func() {
+			Expect(virtualmachineguestoperation.IsDone(op)).To(BeFalse())
+		})
+
+		It("is not done while in progress", func() {
+			conditions.MarkFalse(op, vmopv1.VirtualMachineGuestOperationConditionCompleted,
+				vmopv1.GuestOperationInProgressReason, "")
+			Expect(virtualmachineguestoperation.IsDone(op)).To(BeFalse())
+		})
+
+		It("is done when timed out", func() {
+			conditions.MarkFalse(op, vmopv1.VirtualMachineGuestOperationConditionCompleted,
+				vmopv1.GuestOperationTimedOutReason, "")
+			Expect(virtualmachineguestoperation.IsDone(op)).To(BeTrue())
+		})
+
+		It("is done when the process is not found", func() {
+			conditions.MarkFalse(op, vmopv1.VirtualMachineGuestOperationConditionCompleted,
+				vmopv1.GuestOperationProcessNotFoundReason, "")
+			Expect(virtualmachineguestoperation.IsDone(op)).To(BeTrue())
+		})
+
+		It("is done when completed", func() {
+			conditions.MarkTrue(op, vmopv1.VirtualMachineGuestOperationConditionCompleted)
+			Expect(virtualmachineguestoperation.IsDone(op)).To(BeTrue())
+		})
+	})
+}
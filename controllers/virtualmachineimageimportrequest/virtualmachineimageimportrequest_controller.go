@@ -0,0 +1,621 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimageimportrequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/go-logr/logr"
+
+	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/contentlibrary"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+)
+
+const (
+	// SourceServerPort is the port on which the Pod that serves the contents
+	// of a source PersistentVolumeClaim listens.
+	SourceServerPort = 8080
+
+	// sourceServerDataDir is the directory in which the source
+	// PersistentVolumeClaim is mounted in the Pod that serves its contents.
+	sourceServerDataDir = "/data"
+
+	// importRetryDelay is the minimum time between the start of two attempts
+	// to import the image.
+	importRetryDelay = 1 * time.Minute
+)
+
+// MaxConcurrentImports is the maximum number of images imported at the same
+// time. Each import holds a connection to the source and to vCenter for the
+// duration of the upload, so further imports wait until one completes.
+const MaxConcurrentImports = 4
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineImageImportRequest{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		mgr.GetAPIReader(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProvider,
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Watches(&vmopv1.VirtualMachineImage{},
+			handler.EnqueueRequestsFromMapFunc(vmiToVMImportMapperFn(ctx, r.Client))).
+		Complete(r)
+}
+
+// vmiToVMImportMapperFn returns a mapper function that can be used to queue a
+// reconcile request for the VirtualMachineImageImportRequests in response to
+// an event on the VirtualMachineImage resource.
+func vmiToVMImportMapperFn(
+	ctx *pkgctx.ControllerManagerContext,
+	c client.Client) func(_ context.Context, o client.Object) []reconcile.Request {
+
+	return func(_ context.Context, o client.Object) []reconcile.Request {
+		vmi := o.(*vmopv1.VirtualMachineImage)
+		if vmi.Status.ProviderItemID == "" {
+			return nil
+		}
+
+		vmImportList := &vmopv1.VirtualMachineImageImportRequestList{}
+		if err := c.List(ctx, vmImportList, client.InNamespace(vmi.Namespace)); err != nil {
+			ctx.Logger.Error(err, "Failed to list VirtualMachineImageImportRequests for VirtualMachineImage",
+				"name", vmi.Name, "namespace", vmi.Namespace)
+			return nil
+		}
+
+		var reconcileRequests []reconcile.Request
+		for _, vmImport := range vmImportList.Items {
+			if vmImport.Status.ItemID == vmi.Status.ProviderItemID {
+				key := client.ObjectKey{Namespace: vmImport.Namespace, Name: vmImport.Name}
+				reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: key})
+			}
+		}
+
+		return reconcileRequests
+	}
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	apiReader client.Reader,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider providers.VirtualMachineProviderInterface) *Reconciler {
+
+	return &Reconciler{
+		Context:     ctx,
+		Client:      client,
+		apiReader:   apiReader,
+		Logger:      logger,
+		Recorder:    recorder,
+		VMProvider:  vmProvider,
+		importSlots: make(chan struct{}, MaxConcurrentImports),
+	}
+}
+
+// Reconciler reconciles a VirtualMachineImageImportRequest object.
+type Reconciler struct {
+	client.Client
+	Context    context.Context
+	apiReader  client.Reader
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider providers.VirtualMachineProviderInterface
+
+	// imports tracks the imports in progress by the UID of the
+	// VirtualMachineImageImportRequest. There is no vCenter task to query for
+	// the result of an import since the OVF is uploaded by VM Operator.
+	imports sync.Map
+
+	// importSlots limits the number of imports in progress. A slot is held
+	// by each import until it completes.
+	importSlots chan struct{}
+}
+
+// importResult is the result of importing an image.
+type importResult struct {
+	done   bool
+	itemID string
+	err    error
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimageimportrequests,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimageimportrequests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimages,verbs=get;list;watch
+// +kubebuilder:rbac:groups=imageregistry.vmware.com,resources=contentlibraries,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;create;delete
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	vmImport := &vmopv1.VirtualMachineImageImportRequest{}
+	if err := r.Get(ctx, req.NamespacedName, vmImport); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !vmImport.DeletionTimestamp.IsZero() {
+		r.imports.Delete(vmImport.UID)
+		return ctrl.Result{}, nil
+	}
+
+	importCtx := &pkgctx.VirtualMachineImageImportRequestContext{
+		Context:  ctx,
+		Logger:   ctrl.Log.WithName("VirtualMachineImageImportRequest").WithValues("name", req.NamespacedName),
+		VMImport: vmImport,
+	}
+
+	patchHelper, err := patch.NewHelper(vmImport, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", importCtx.String(), err)
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, vmImport); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			importCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	return r.ReconcileNormal(importCtx)
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineImageImportRequestContext) (ctrl.Result, error) {
+	ctx.Logger.V(4).Info("Reconciling VirtualMachineImageImportRequest")
+
+	vmImport := ctx.VMImport
+
+	if vmImport.Status.Ready {
+		if err := r.deleteSourceServer(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.reconcileTTL(ctx)
+	}
+
+	if vmImport.Status.StartTime.IsZero() {
+		vmImport.Status.StartTime = metav1.Now()
+	}
+	if vmImport.Status.ItemName == "" {
+		vmImport.Status.ItemName = vmImport.Spec.Target.Item.Name
+		if vmImport.Status.ItemName == "" {
+			vmImport.Status.ItemName = vmImport.Name
+		}
+	}
+
+	if !conditions.IsTrue(vmImport, vmopv1.VirtualMachineImageImportRequestConditionUploaded) {
+		if ok, err := r.reconcileSource(ctx); err != nil {
+			return ctrl.Result{}, err
+		} else if !ok {
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+
+		if ok, err := r.reconcileTarget(ctx); err != nil || !ok {
+			return ctrl.Result{}, err
+		}
+
+		if requeueAfter := r.reconcileUpload(ctx); requeueAfter > 0 {
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+	}
+
+	if !conditions.IsTrue(vmImport, vmopv1.VirtualMachineImageImportRequestConditionUploaded) {
+		// The image is not valid. Importing it again will not succeed.
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.reconcileImage(ctx); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !conditions.IsTrue(vmImport, vmopv1.VirtualMachineImageImportRequestConditionImageAvailable) {
+		conditions.MarkFalse(vmImport,
+			vmopv1.VirtualMachineImageImportRequestConditionComplete,
+			vmopv1.ImageUnavailableReason,
+			"VirtualMachineImage is not available")
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	conditions.MarkTrue(vmImport, vmopv1.VirtualMachineImageImportRequestConditionComplete)
+	vmImport.Status.Ready = true
+	vmImport.Status.CompletionTime = metav1.Now()
+	ctx.Logger.Info("VM image import request completed", "imageName", vmImport.Status.ImageName)
+
+	if err := r.deleteSourceServer(ctx); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return r.reconcileTTL(ctx)
+}
+
+// reconcileSource validates the source of the import and sets the URL from
+// which the image is downloaded in the context. It returns false when the
+// image cannot be downloaded yet.
+func (r *Reconciler) reconcileSource(ctx *pkgctx.VirtualMachineImageImportRequestContext) (bool, error) {
+	vmImport := ctx.VMImport
+	source := vmImport.Spec.Source
+
+	if source.PersistentVolumeClaim == nil {
+		ctx.SourceURL = source.URL
+		conditions.MarkTrue(vmImport, vmopv1.VirtualMachineImageImportRequestConditionSourceValid)
+		return true, nil
+	}
+
+	image := pkgcfg.FromContext(ctx).ImageImportServerImage
+	if image == "" {
+		conditions.MarkFalse(vmImport,
+			vmopv1.VirtualMachineImageImportRequestConditionSourceValid,
+			vmopv1.SourceNotSupportedReason,
+			"importing an image from a PersistentVolumeClaim is not supported")
+		return false, nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	pvcKey := client.ObjectKey{Namespace: vmImport.Namespace, Name: source.PersistentVolumeClaim.ClaimName}
+	if err := r.Get(ctx, pvcKey, pvc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		conditions.MarkFalse(vmImport,
+			vmopv1.VirtualMachineImageImportRequestConditionSourceValid,
+			vmopv1.SourcePersistentVolumeClaimNotExistReason,
+			"PersistentVolumeClaim %s does not exist", pvcKey.Name)
+		return false, nil
+	}
+
+	// Pods are read directly from the API server so they are not cached.
+	pod := &corev1.Pod{}
+	podKey := client.ObjectKey{Namespace: vmImport.Namespace, Name: SourceServerName(vmImport)}
+	if err := r.apiReader.Get(ctx, podKey, pod); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+
+		pod = newSourceServer(vmImport, image)
+		if err := controllerutil.SetControllerReference(vmImport, pod, r.Scheme()); err != nil {
+			return false, err
+		}
+		if err := r.Create(ctx, pod); err != nil {
+			return false, err
+		}
+		ctx.Logger.Info("Created source server Pod", "pod", podKey.Name)
+	}
+
+	if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+		conditions.MarkFalse(vmImport,
+			vmopv1.VirtualMachineImageImportRequestConditionSourceValid,
+			vmopv1.SourceServerNotReadyReason,
+			"Pod %s is not running", podKey.Name)
+		return false, nil
+	}
+
+	u := url.URL{
+		Scheme: "http",
+		Host:   net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(SourceServerPort)),
+		Path:   path.Join("/", source.PersistentVolumeClaim.Path),
+	}
+	ctx.SourceURL = u.String()
+	conditions.MarkTrue(vmImport, vmopv1.VirtualMachineImageImportRequestConditionSourceValid)
+
+	return true, nil
+}
+
+// reconcileTarget validates the target content library and stores it in the
+// context. It returns false when the image cannot be imported into it.
+func (r *Reconciler) reconcileTarget(ctx *pkgctx.VirtualMachineImageImportRequestContext) (bool, error) {
+	vmImport := ctx.VMImport
+
+	cl := &imgregv1a1.ContentLibrary{}
+	clKey := client.ObjectKey{Namespace: vmImport.Namespace, Name: vmImport.Spec.Target.Location.Name}
+	if err := r.Get(ctx, clKey, cl); err != nil {
+		if apierrors.IsNotFound(err) {
+			conditions.MarkFalse(vmImport,
+				vmopv1.VirtualMachineImageImportRequestConditionTargetValid,
+				vmopv1.TargetContentLibraryNotExistReason,
+				err.Error())
+		}
+		return false, err
+	}
+
+	if !cl.Spec.Writable {
+		err := fmt.Errorf("target location %s is not writable", cl.Status.Name)
+		conditions.MarkFalse(vmImport,
+			vmopv1.VirtualMachineImageImportRequestConditionTargetValid,
+			vmopv1.TargetContentLibraryNotWritableReason,
+			err.Error())
+		return false, err
+	}
+
+	isReady := false
+	for _, condition := range cl.Status.Conditions {
+		if condition.Type == imgregv1a1.ReadyCondition {
+			isReady = condition.Status == corev1.ConditionTrue
+			break
+		}
+	}
+	if !isReady {
+		err := fmt.Errorf("target location %s is not ready", cl.Status.Name)
+		conditions.MarkFalse(vmImport,
+			vmopv1.VirtualMachineImageImportRequestConditionTargetValid,
+			vmopv1.TargetContentLibraryNotReadyReason,
+			err.Error())
+		return false, err
+	}
+
+	ctx.ContentLibrary = cl
+
+	// An item may only exist while this request is importing it.
+	if _, ok := r.imports.Load(vmImport.UID); !ok {
+		itemName := vmImport.Status.ItemName
+		item, err := r.VMProvider.GetItemFromLibraryByName(ctx, string(cl.Spec.UUID), itemName)
+		if err != nil {
+			return false, err
+		}
+		if item != nil {
+			// Do not requeue since the item must be deleted or the request
+			// recreated with another item name.
+			conditions.MarkFalse(vmImport,
+				vmopv1.VirtualMachineImageImportRequestConditionTargetValid,
+				vmopv1.TargetItemAlreadyExistsReason,
+				"item with name %s already exists in the content library %s", itemName, cl.Status.Name)
+			return false, nil
+		}
+	}
+
+	conditions.MarkTrue(vmImport, vmopv1.VirtualMachineImageImportRequestConditionTargetValid)
+	return true, nil
+}
+
+// reconcileUpload checks the result of a prior import, and imports the image
+// if there is no import in progress and no prior import succeeded. The
+// returned duration is greater than zero when the upload has not completed.
+//
+// An import is tracked in memory, so if VM Operator restarts while an import
+// is in progress, the target item already exists and the request must be
+// recreated.
+func (r *Reconciler) reconcileUpload(ctx *pkgctx.VirtualMachineImageImportRequestContext) time.Duration {
+	vmImport := ctx.VMImport
+
+	if obj, ok := r.imports.Load(vmImport.UID); ok {
+		res := obj.(importResult)
+		if !res.done {
+			conditions.MarkFalse(vmImport,
+				vmopv1.VirtualMachineImageImportRequestConditionUploaded,
+				vmopv1.UploadingReason,
+				"Importing OVF into the content library.")
+			return 10 * time.Second
+		}
+
+		r.imports.Delete(vmImport.UID)
+
+		switch {
+		case res.err == nil:
+			vmImport.Status.ItemID = res.itemID
+			conditions.MarkTrue(vmImport, vmopv1.VirtualMachineImageImportRequestConditionUploaded)
+			return 0
+		case errors.Is(res.err, contentlibrary.ErrInvalidOVF):
+			conditions.MarkFalse(vmImport,
+				vmopv1.VirtualMachineImageImportRequestConditionUploaded,
+				vmopv1.InvalidOVFReason,
+				res.err.Error())
+			return 0
+		default:
+			ctx.Logger.Error(res.err, "VM image import failed, will retry this operation")
+			conditions.MarkFalse(vmImport,
+				vmopv1.VirtualMachineImageImportRequestConditionUploaded,
+				vmopv1.UploadFailureReason,
+				res.err.Error())
+		}
+	}
+
+	if conditions.GetReason(vmImport, vmopv1.VirtualMachineImageImportRequestConditionUploaded) == vmopv1.InvalidOVFReason {
+		return 0
+	}
+
+	if vmImport.Status.Attempts > 0 {
+		if d := importRetryDelay - time.Since(vmImport.Status.LastAttemptTime.Time); d > 0 {
+			return d
+		}
+	}
+
+	select {
+	case r.importSlots <- struct{}{}:
+	default:
+		conditions.MarkFalse(vmImport,
+			vmopv1.VirtualMachineImageImportRequestConditionUploaded,
+			vmopv1.UploadingReason,
+			"Waiting for other imports to complete.")
+		return 10 * time.Second
+	}
+
+	vmImport.Status.Attempts++
+	vmImport.Status.LastAttemptTime = metav1.Now()
+	conditions.MarkFalse(vmImport,
+		vmopv1.VirtualMachineImageImportRequestConditionUploaded,
+		vmopv1.UploadingReason,
+		"Importing OVF into the content library.")
+
+	uid := vmImport.UID
+	r.imports.Store(uid, importResult{})
+
+	vmImportCopy := vmImport.DeepCopy()
+	cl, sourceURL := ctx.ContentLibrary, ctx.SourceURL
+	go func() {
+		defer func() {
+			<-r.importSlots
+		}()
+
+		itemID, err := r.VMProvider.ImportVirtualMachineImage(ctx, vmImportCopy, cl, sourceURL)
+		if err != nil {
+			ctx.Logger.Error(err, "failed to import VM image")
+		} else {
+			ctx.Logger.Info("imported VM image", "itemID", itemID)
+		}
+		r.imports.Store(uid, importResult{done: true, itemID: itemID, err: err})
+		r.Recorder.EmitEvent(vmImportCopy, "Import", err, false)
+	}()
+
+	return 10 * time.Second
+}
+
+// reconcileImage checks if the VirtualMachineImage realized from the imported
+// item is available in the namespace.
+func (r *Reconciler) reconcileImage(ctx *pkgctx.VirtualMachineImageImportRequestContext) error {
+	vmImport := ctx.VMImport
+
+	if conditions.IsTrue(vmImport, vmopv1.VirtualMachineImageImportRequestConditionImageAvailable) {
+		return nil
+	}
+
+	vmiList := &vmopv1.VirtualMachineImageList{}
+	if err := r.List(ctx, vmiList, client.InNamespace(vmImport.Namespace)); err != nil {
+		return err
+	}
+
+	for _, vmi := range vmiList.Items {
+		if vmi.Status.ProviderItemID == vmImport.Status.ItemID {
+			vmImport.Status.ImageName = vmi.Name
+			conditions.MarkTrue(vmImport, vmopv1.VirtualMachineImageImportRequestConditionImageAvailable)
+			return nil
+		}
+	}
+
+	conditions.MarkFalse(vmImport,
+		vmopv1.VirtualMachineImageImportRequestConditionImageAvailable,
+		vmopv1.TargetVirtualMachineImageNotFoundReason,
+		"VirtualMachineImage not found")
+
+	return nil
+}
+
+// reconcileTTL deletes the request once the TTL after it finished expires.
+func (r *Reconciler) reconcileTTL(ctx *pkgctx.VirtualMachineImageImportRequestContext) (ctrl.Result, error) {
+	vmImport := ctx.VMImport
+
+	ttl := vmImport.Spec.TTLSecondsAfterFinished
+	if ttl == nil {
+		return ctrl.Result{}, nil
+	}
+
+	expireTime := vmImport.Status.CompletionTime.Add(time.Duration(*ttl) * time.Second)
+	if d := time.Until(expireTime); d > 0 {
+		return ctrl.Result{RequeueAfter: d}, nil
+	}
+
+	ctx.Logger.Info("Deleting VM image import request")
+	return ctrl.Result{}, client.IgnoreNotFound(r.Delete(ctx, vmImport))
+}
+
+// deleteSourceServer deletes the Pod that serves the contents of the source
+// PersistentVolumeClaim once it is no longer needed.
+func (r *Reconciler) deleteSourceServer(ctx *pkgctx.VirtualMachineImageImportRequestContext) error {
+	vmImport := ctx.VMImport
+	if vmImport.Spec.Source.PersistentVolumeClaim == nil {
+		return nil
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SourceServerName(vmImport),
+			Namespace: vmImport.Namespace,
+		},
+	}
+
+	return client.IgnoreNotFound(r.Delete(ctx, pod))
+}
+
+// SourceServerName returns the name of the Pod that serves the contents of
+// the source PersistentVolumeClaim.
+func SourceServerName(vmImport *vmopv1.VirtualMachineImageImportRequest) string {
+	return vmImport.Name + "-source"
+}
+
+// newSourceServer returns the Pod that serves the contents of the source
+// PersistentVolumeClaim over HTTP.
+func newSourceServer(vmImport *vmopv1.VirtualMachineImageImportRequest, image string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SourceServerName(vmImport),
+			Namespace: vmImport.Namespace,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyAlways,
+			Containers: []corev1.Container{
+				{
+					Name:  "server",
+					Image: image,
+					Ports: []corev1.ContainerPort{
+						{
+							Name:          "http",
+							ContainerPort: SourceServerPort,
+						},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "data",
+							MountPath: sourceServerDataDir,
+							ReadOnly:  true,
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "data",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: vmImport.Spec.Source.PersistentVolumeClaim.ClaimName,
+							ReadOnly:  true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimageimportrequest_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.V1Alpha3,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx      *builder.IntegrationTestContext
+		vmImport *vmopv1.VirtualMachineImageImportRequest
+		cl       *imgregv1a1.ContentLibrary
+	)
+
+	getVirtualMachineImageImportRequest := func(ctx *builder.IntegrationTestContext, objKey client.ObjectKey) *vmopv1.VirtualMachineImageImportRequest {
+		req := &vmopv1.VirtualMachineImageImportRequest{}
+		if err := ctx.Client.Get(ctx, objKey, req); err != nil {
+			return nil
+		}
+		return req
+	}
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		cl = builder.DummyContentLibrary("dummy-cl", ctx.Namespace, "dummy-cl-uuid")
+		vmImport = builder.DummyVirtualMachineImageImportRequest("dummy-import", ctx.Namespace, sourceURL, cl.Name)
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	Context("Successfully reconcile a VirtualMachineImageImportRequest", func() {
+		var (
+			itemID string
+		)
+
+		BeforeEach(func() {
+			itemID = uuid.New().String()
+
+			intgFakeVMProvider.Lock()
+			intgFakeVMProvider.ImportVirtualMachineImageFn = func(_ context.Context,
+				_ *vmopv1.VirtualMachineImageImportRequest, _ *imgregv1a1.ContentLibrary, _ string) (string, error) {
+				return itemID, nil
+			}
+			intgFakeVMProvider.Unlock()
+
+			Expect(ctx.Client.Create(ctx, cl)).To(Succeed())
+			cl.Status.Conditions = []imgregv1a1.Condition{
+				{
+					Type:               imgregv1a1.ReadyCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.Now(),
+				},
+			}
+			Expect(ctx.Client.Status().Update(ctx, cl)).To(Succeed())
+
+			Expect(ctx.Client.Create(ctx, vmImport)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			err := ctx.Client.Delete(ctx, vmImport)
+			Expect(client.IgnoreNotFound(err)).ToNot(HaveOccurred())
+			err = ctx.Client.Delete(ctx, cl)
+			Expect(client.IgnoreNotFound(err)).ToNot(HaveOccurred())
+
+			intgFakeVMProvider.Reset()
+		})
+
+		It("VirtualMachineImageImportRequest completed", func() {
+			By("Image should be uploaded", func() {
+				Eventually(func(g Gomega) {
+					req := getVirtualMachineImageImportRequest(ctx, client.ObjectKeyFromObject(vmImport))
+					g.Expect(req).ToNot(BeNil())
+					g.Expect(conditions.IsTrue(req, vmopv1.VirtualMachineImageImportRequestConditionSourceValid)).To(BeTrue())
+					g.Expect(conditions.IsTrue(req, vmopv1.VirtualMachineImageImportRequestConditionTargetValid)).To(BeTrue())
+					g.Expect(conditions.IsTrue(req, vmopv1.VirtualMachineImageImportRequestConditionUploaded)).To(BeTrue())
+					g.Expect(req.Status.ItemID).To(Equal(itemID))
+					g.Expect(req.Status.Attempts).To(BeEquivalentTo(1))
+					g.Expect(req.Status.Ready).To(BeFalse())
+				}).Should(Succeed())
+			})
+
+			By("Simulate VM Image reconcile", func() {
+				vmi := builder.DummyVirtualMachineImage("dummy-image")
+				vmi.Namespace = ctx.Namespace
+				Expect(ctx.Client.Create(ctx, vmi)).To(Succeed())
+				vmi.Status.ProviderItemID = itemID
+				Expect(ctx.Client.Status().Update(ctx, vmi)).To(Succeed())
+			})
+
+			By("Request should be complete", func() {
+				Eventually(func(g Gomega) {
+					req := getVirtualMachineImageImportRequest(ctx, client.ObjectKeyFromObject(vmImport))
+					g.Expect(req).ToNot(BeNil())
+					g.Expect(conditions.IsTrue(req, vmopv1.VirtualMachineImageImportRequestConditionImageAvailable)).To(BeTrue())
+					g.Expect(conditions.IsTrue(req, vmopv1.VirtualMachineImageImportRequestConditionComplete)).To(BeTrue())
+					g.Expect(req.Status.Ready).To(BeTrue())
+					g.Expect(req.Status.ImageName).To(Equal("dummy-image"))
+					g.Expect(req.Status.CompletionTime).NotTo(BeZero())
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the target content library does not exist", func() {
+		BeforeEach(func() {
+			Expect(ctx.Client.Create(ctx, vmImport)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			err := ctx.Client.Delete(ctx, vmImport)
+			Expect(client.IgnoreNotFound(err)).ToNot(HaveOccurred())
+		})
+
+		It("marks TargetValid false", func() {
+			Eventually(func(g Gomega) {
+				req := getVirtualMachineImageImportRequest(ctx, client.ObjectKeyFromObject(vmImport))
+				g.Expect(req).ToNot(BeNil())
+				g.Expect(conditions.GetReason(req,
+					vmopv1.VirtualMachineImageImportRequestConditionTargetValid)).To(Equal(vmopv1.TargetContentLibraryNotExistReason))
+				g.Expect(req.Status.Attempts).To(BeZero())
+			}).Should(Succeed())
+		})
+	})
+}
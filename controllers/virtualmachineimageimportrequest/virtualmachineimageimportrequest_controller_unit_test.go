@@ -0,0 +1,470 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimageimportrequest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware/govmomi/vapi/library"
+
+	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimageimportrequest"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/contentlibrary"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+const sourceURL = "https://example.com/images/dummy.ova"
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.V1Alpha3,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+
+		reconciler     *virtualmachineimageimportrequest.Reconciler
+		fakeVMProvider *providerfake.VMProvider
+
+		vmImport    *vmopv1.VirtualMachineImageImportRequest
+		cl          *imgregv1a1.ContentLibrary
+		vmImportCtx *pkgctx.VirtualMachineImageImportRequestContext
+	)
+
+	BeforeEach(func() {
+		vmImport = builder.DummyVirtualMachineImageImportRequest("dummy-import", "dummy-ns", sourceURL, "dummy-cl")
+		vmImport.UID = types.UID("dummy-import-uid")
+		cl = builder.DummyContentLibrary("dummy-cl", vmImport.Namespace, "dummy-cl-uuid")
+		initObjects = []client.Object{cl, vmImport}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = virtualmachineimageimportrequest.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProvider,
+		)
+		fakeVMProvider = ctx.VMProvider.(*providerfake.VMProvider)
+		fakeVMProvider.Reset()
+
+		vmImportCtx = &pkgctx.VirtualMachineImageImportRequestContext{
+			Context:  ctx,
+			Logger:   ctx.Logger.WithName(vmImport.Name),
+			VMImport: vmImport,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+	})
+
+	// reconcileUntilUploaded reconciles the request until the import started
+	// by the first reconcile is complete.
+	reconcileUntilUploaded := func() {
+		_, err := reconciler.ReconcileNormal(vmImportCtx)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			_, err := reconciler.ReconcileNormal(vmImportCtx)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(conditions.GetReason(vmImport,
+				vmopv1.VirtualMachineImageImportRequestConditionUploaded)).ToNot(Equal(vmopv1.UploadingReason))
+		}).Should(Succeed())
+	}
+
+	Context("ReconcileNormal", func() {
+		When("the source is a URL", func() {
+			It("imports the image from the URL", func() {
+				var importURL string
+				fakeVMProvider.Lock()
+				fakeVMProvider.ImportVirtualMachineImageFn = func(_ context.Context,
+					_ *vmopv1.VirtualMachineImageImportRequest, _ *imgregv1a1.ContentLibrary, url string) (string, error) {
+					importURL = url
+					return "dummy-item-id", nil
+				}
+				fakeVMProvider.Unlock()
+
+				_, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vmImport.Status.ItemName).To(Equal(vmImport.Name))
+				Expect(vmImport.Status.StartTime.IsZero()).To(BeFalse())
+				Expect(vmImport.Status.Attempts).To(BeEquivalentTo(1))
+				Expect(conditions.IsTrue(vmImport,
+					vmopv1.VirtualMachineImageImportRequestConditionSourceValid)).To(BeTrue())
+				Expect(conditions.IsTrue(vmImport,
+					vmopv1.VirtualMachineImageImportRequestConditionTargetValid)).To(BeTrue())
+
+				Eventually(func(g Gomega) {
+					_, err := reconciler.ReconcileNormal(vmImportCtx)
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(conditions.IsTrue(vmImport,
+						vmopv1.VirtualMachineImageImportRequestConditionUploaded)).To(BeTrue())
+				}).Should(Succeed())
+
+				fakeVMProvider.Lock()
+				Expect(importURL).To(Equal(sourceURL))
+				fakeVMProvider.Unlock()
+
+				Expect(vmImport.Status.ItemID).To(Equal("dummy-item-id"))
+				Expect(vmImport.Status.Attempts).To(BeEquivalentTo(1))
+				Expect(conditions.GetReason(vmImport,
+					vmopv1.VirtualMachineImageImportRequestConditionImageAvailable)).To(Equal(vmopv1.TargetVirtualMachineImageNotFoundReason))
+				Expect(conditions.GetReason(vmImport,
+					vmopv1.VirtualMachineImageImportRequestConditionComplete)).To(Equal(vmopv1.ImageUnavailableReason))
+				Expect(vmImport.Status.Ready).To(BeFalse())
+			})
+
+			When("the target item name is specified", func() {
+				BeforeEach(func() {
+					vmImport.Spec.Target.Item.Name = "dummy-item"
+				})
+
+				It("imports the image as an item with that name", func() {
+					_, err := reconciler.ReconcileNormal(vmImportCtx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(vmImport.Status.ItemName).To(Equal("dummy-item"))
+				})
+			})
+
+			When("the VirtualMachineImage is available", func() {
+				BeforeEach(func() {
+					vmImport.Status.ItemID = "dummy-item-id"
+					conditions.MarkTrue(vmImport, vmopv1.VirtualMachineImageImportRequestConditionUploaded)
+
+					vmi := builder.DummyVirtualMachineImage("vmi-dummy")
+					vmi.Namespace = vmImport.Namespace
+					vmi.Status.ProviderItemID = "dummy-item-id"
+					initObjects = append(initObjects, vmi)
+				})
+
+				It("completes the request", func() {
+					_, err := reconciler.ReconcileNormal(vmImportCtx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(vmImport.Status.ImageName).To(Equal("vmi-dummy"))
+					Expect(conditions.IsTrue(vmImport,
+						vmopv1.VirtualMachineImageImportRequestConditionImageAvailable)).To(BeTrue())
+					Expect(conditions.IsTrue(vmImport,
+						vmopv1.VirtualMachineImageImportRequestConditionComplete)).To(BeTrue())
+					Expect(vmImport.Status.Ready).To(BeTrue())
+					Expect(vmImport.Status.CompletionTime.IsZero()).To(BeFalse())
+				})
+			})
+		})
+
+		When("the OVF is not valid", func() {
+			JustBeforeEach(func() {
+				fakeVMProvider.Lock()
+				fakeVMProvider.ImportVirtualMachineImageFn = func(_ context.Context,
+					_ *vmopv1.VirtualMachineImageImportRequest, _ *imgregv1a1.ContentLibrary, _ string) (string, error) {
+					return "", fmt.Errorf("%w: the OVF does not have any disks", contentlibrary.ErrInvalidOVF)
+				}
+				fakeVMProvider.Unlock()
+			})
+
+			It("marks Uploaded false and does not import the image again", func() {
+				reconcileUntilUploaded()
+				Expect(conditions.GetReason(vmImport,
+					vmopv1.VirtualMachineImageImportRequestConditionUploaded)).To(Equal(vmopv1.InvalidOVFReason))
+
+				_, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conditions.GetReason(vmImport,
+					vmopv1.VirtualMachineImageImportRequestConditionUploaded)).To(Equal(vmopv1.InvalidOVFReason))
+				Expect(vmImport.Status.Attempts).To(BeEquivalentTo(1))
+				Expect(vmImport.Status.Ready).To(BeFalse())
+			})
+		})
+
+		When("the import fails", func() {
+			JustBeforeEach(func() {
+				fakeVMProvider.Lock()
+				fakeVMProvider.ImportVirtualMachineImageFn = func(_ context.Context,
+					_ *vmopv1.VirtualMachineImageImportRequest, _ *imgregv1a1.ContentLibrary, _ string) (string, error) {
+					return "", errors.New("import failed")
+				}
+				fakeVMProvider.Unlock()
+			})
+
+			It("marks Uploaded false and waits before importing the image again", func() {
+				reconcileUntilUploaded()
+				Expect(conditions.GetReason(vmImport,
+					vmopv1.VirtualMachineImageImportRequestConditionUploaded)).To(Equal(vmopv1.UploadFailureReason))
+
+				result, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+				Expect(vmImport.Status.Attempts).To(BeEquivalentTo(1))
+			})
+		})
+
+		When("the maximum number of imports are in progress", func() {
+			var release chan struct{}
+
+			JustBeforeEach(func() {
+				release = make(chan struct{})
+				fakeVMProvider.Lock()
+				fakeVMProvider.ImportVirtualMachineImageFn = func(_ context.Context,
+					_ *vmopv1.VirtualMachineImageImportRequest, _ *imgregv1a1.ContentLibrary, _ string) (string, error) {
+					<-release
+					return "dummy-item-id", nil
+				}
+				fakeVMProvider.Unlock()
+
+				for i := 0; i < virtualmachineimageimportrequest.MaxConcurrentImports; i++ {
+					other := vmImport.DeepCopy()
+					other.Name = fmt.Sprintf("%s-%d", vmImport.Name, i)
+					other.UID = types.UID(fmt.Sprintf("%s-%d", vmImport.UID, i))
+					_, err := reconciler.ReconcileNormal(&pkgctx.VirtualMachineImageImportRequestContext{
+						Context:  ctx,
+						Logger:   ctx.Logger.WithName(other.Name),
+						VMImport: other,
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(other.Status.Attempts).To(BeEquivalentTo(1))
+				}
+			})
+
+			It("waits for an import to complete before importing the image", func() {
+				result, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+				Expect(vmImport.Status.Attempts).To(BeZero())
+				Expect(conditions.GetMessage(vmImport,
+					vmopv1.VirtualMachineImageImportRequestConditionUploaded)).To(Equal("Waiting for other imports to complete."))
+
+				close(release)
+
+				Eventually(func(g Gomega) {
+					_, err := reconciler.ReconcileNormal(vmImportCtx)
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(vmImport.Status.Attempts).To(BeEquivalentTo(1))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the target item already exists", func() {
+			JustBeforeEach(func() {
+				fakeVMProvider.Lock()
+				fakeVMProvider.GetItemFromLibraryByNameFn = func(_ context.Context,
+					_, _ string) (*library.Item, error) {
+					return &library.Item{ID: "dummy-item-id"}, nil
+				}
+				fakeVMProvider.Unlock()
+			})
+
+			It("marks TargetValid false and does not import the image", func() {
+				_, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conditions.GetReason(vmImport,
+					vmopv1.VirtualMachineImageImportRequestConditionTargetValid)).To(Equal(vmopv1.TargetItemAlreadyExistsReason))
+				Expect(vmImport.Status.Attempts).To(BeZero())
+			})
+		})
+
+		When("the target content library does not exist", func() {
+			BeforeEach(func() {
+				initObjects = []client.Object{vmImport}
+			})
+
+			It("returns an error", func() {
+				_, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).To(HaveOccurred())
+				Expect(conditions.GetReason(vmImport,
+					vmopv1.VirtualMachineImageImportRequestConditionTargetValid)).To(Equal(vmopv1.TargetContentLibraryNotExistReason))
+			})
+		})
+
+		When("the target content library is not writable", func() {
+			BeforeEach(func() {
+				cl.Spec.Writable = false
+			})
+
+			It("returns an error", func() {
+				_, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).To(HaveOccurred())
+				Expect(conditions.GetReason(vmImport,
+					vmopv1.VirtualMachineImageImportRequestConditionTargetValid)).To(Equal(vmopv1.TargetContentLibraryNotWritableReason))
+			})
+		})
+
+		When("the source is a PersistentVolumeClaim", func() {
+			var (
+				pvc *corev1.PersistentVolumeClaim
+			)
+
+			BeforeEach(func() {
+				vmImport.Spec.Source.URL = ""
+				vmImport.Spec.Source.PersistentVolumeClaim = &vmopv1.VirtualMachineImageImportRequestSourcePersistentVolumeClaim{
+					ClaimName: "dummy-pvc",
+					Path:      "images/dummy.ova",
+				}
+				pvc = &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "dummy-pvc",
+						Namespace: vmImport.Namespace,
+					},
+				}
+				initObjects = append(initObjects, pvc)
+			})
+
+			When("the source server image is not configured", func() {
+				It("marks SourceValid false", func() {
+					_, err := reconciler.ReconcileNormal(vmImportCtx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(conditions.GetReason(vmImport,
+						vmopv1.VirtualMachineImageImportRequestConditionSourceValid)).To(Equal(vmopv1.SourceNotSupportedReason))
+				})
+			})
+
+			When("the source server image is configured", func() {
+				JustBeforeEach(func() {
+					pkgcfg.SetContext(vmImportCtx, func(config *pkgcfg.Config) {
+						config.ImageImportServerImage = "dummy-server-image"
+					})
+				})
+
+				getPod := func() (*corev1.Pod, error) {
+					pod := &corev1.Pod{}
+					key := client.ObjectKey{
+						Namespace: vmImport.Namespace,
+						Name:      virtualmachineimageimportrequest.SourceServerName(vmImport),
+					}
+					return pod, ctx.Client.Get(ctx, key, pod)
+				}
+
+				It("creates the source server Pod", func() {
+					_, err := reconciler.ReconcileNormal(vmImportCtx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(conditions.GetReason(vmImport,
+						vmopv1.VirtualMachineImageImportRequestConditionSourceValid)).To(Equal(vmopv1.SourceServerNotReadyReason))
+
+					pod, err := getPod()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(pod.OwnerReferences).To(HaveLen(1))
+					Expect(pod.OwnerReferences[0].UID).To(Equal(vmImport.UID))
+					Expect(pod.Spec.Containers).To(HaveLen(1))
+					Expect(pod.Spec.Containers[0].Image).To(Equal("dummy-server-image"))
+					Expect(pod.Spec.Volumes).To(HaveLen(1))
+					Expect(pod.Spec.Volumes[0].PersistentVolumeClaim).ToNot(BeNil())
+					Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal(pvc.Name))
+					Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ReadOnly).To(BeTrue())
+					Expect(vmImport.Status.Attempts).To(BeZero())
+				})
+
+				When("the PersistentVolumeClaim does not exist", func() {
+					BeforeEach(func() {
+						initObjects = []client.Object{cl, vmImport}
+					})
+
+					It("marks SourceValid false", func() {
+						_, err := reconciler.ReconcileNormal(vmImportCtx)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(conditions.GetReason(vmImport,
+							vmopv1.VirtualMachineImageImportRequestConditionSourceValid)).To(Equal(vmopv1.SourcePersistentVolumeClaimNotExistReason))
+
+						_, err = getPod()
+						Expect(apierrors.IsNotFound(err)).To(BeTrue())
+					})
+				})
+
+				When("the source server Pod is running", func() {
+					BeforeEach(func() {
+						initObjects = append(initObjects, &corev1.Pod{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      virtualmachineimageimportrequest.SourceServerName(vmImport),
+								Namespace: vmImport.Namespace,
+							},
+							Status: corev1.PodStatus{
+								Phase: corev1.PodRunning,
+								PodIP: "192.168.1.10",
+							},
+						})
+					})
+
+					It("imports the image from the Pod and deletes the Pod once the request completes", func() {
+						var importURL string
+						fakeVMProvider.Lock()
+						fakeVMProvider.ImportVirtualMachineImageFn = func(_ context.Context,
+							_ *vmopv1.VirtualMachineImageImportRequest, _ *imgregv1a1.ContentLibrary, url string) (string, error) {
+							importURL = url
+							return "dummy-item-id", nil
+						}
+						fakeVMProvider.Unlock()
+
+						reconcileUntilUploaded()
+						Expect(conditions.IsTrue(vmImport,
+							vmopv1.VirtualMachineImageImportRequestConditionUploaded)).To(BeTrue())
+
+						fakeVMProvider.Lock()
+						Expect(importURL).To(Equal("http://192.168.1.10:8080/images/dummy.ova"))
+						fakeVMProvider.Unlock()
+
+						vmi := builder.DummyVirtualMachineImage("vmi-dummy")
+						vmi.Namespace = vmImport.Namespace
+						Expect(ctx.Client.Create(ctx, vmi)).To(Succeed())
+						vmi.Status.ProviderItemID = "dummy-item-id"
+						Expect(ctx.Client.Status().Update(ctx, vmi)).To(Succeed())
+
+						_, err := reconciler.ReconcileNormal(vmImportCtx)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(vmImport.Status.Ready).To(BeTrue())
+
+						_, err = getPod()
+						Expect(apierrors.IsNotFound(err)).To(BeTrue())
+					})
+				})
+			})
+		})
+
+		When("the request is complete and the TTL has expired", func() {
+			BeforeEach(func() {
+				vmImport.Spec.TTLSecondsAfterFinished = ptr.To[int64](0)
+				vmImport.Status.Ready = true
+				vmImport.Status.CompletionTime = metav1.Now()
+			})
+
+			It("deletes the request", func() {
+				_, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ctx.Client.Get(ctx, client.ObjectKeyFromObject(vmImport), &vmopv1.VirtualMachineImageImportRequest{})
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+}
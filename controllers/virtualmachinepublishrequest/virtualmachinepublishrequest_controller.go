@@ -10,6 +10,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -37,6 +38,8 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/patch"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
 )
 
 const (
@@ -157,6 +160,37 @@ type Reconciler struct {
 	Recorder   record.Recorder
 	VMProvider providers.VirtualMachineProviderInterface
 	Metrics    *metrics.VMPublishMetrics
+
+	// ociPushes tracks the pushes to OCI registries by activation ID. Unlike
+	// publishing to a content library, there is no vCenter task to query for
+	// the result of a push.
+	ociPushes sync.Map
+}
+
+// ociPushResult is the result of publishing a VM to an OCI registry.
+type ociPushResult struct {
+	done        bool
+	finishedAt  time.Time
+	artifactRef string
+	err         error
+}
+
+const (
+	// ociPushRetryBaseDelay is the delay before the VM is pushed again after
+	// the first failed push to an OCI registry. The delay is doubled for each
+	// subsequent failed push, up to ociPushRetryMaxDelay.
+	ociPushRetryBaseDelay = 30 * time.Second
+	ociPushRetryMaxDelay  = 10 * time.Minute
+)
+
+// ociPushRetryDelay returns the delay before the next push after the given
+// number of push attempts have failed.
+func ociPushRetryDelay(attempts int64) time.Duration {
+	delay := ociPushRetryBaseDelay
+	for i := int64(1); i < attempts && delay < ociPushRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, ociPushRetryMaxDelay)
 }
 
 func requeueResult(ctx *pkgctx.VirtualMachinePublishRequestContext) ctrl.Result {
@@ -195,6 +229,7 @@ func requeueResult(ctx *pkgctx.VirtualMachinePublishRequestContext) ctrl.Result
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list
 // +kubebuilder:rbac:groups=imageregistry.vmware.com,resources=contentlibraries,verbs=get;list;watch
 // +kubebuilder:rbac:groups=imageregistry.vmware.com,resources=contentlibraries/status,verbs=get;
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	ctx = pkgcfg.JoinContext(ctx, r.Context)
@@ -268,11 +303,18 @@ func (r *Reconciler) updateSourceAndTargetRef(ctx *pkgctx.VirtualMachinePublishR
 				Name:        targetItemName,
 				Description: vmPubReq.Spec.Target.Item.Description,
 			},
-			Location: vmPubReq.Spec.Target.Location,
+			Location:    vmPubReq.Spec.Target.Location,
+			OCIRegistry: vmPubReq.Spec.Target.OCIRegistry.DeepCopy(),
 		}
 	}
 }
 
+// isOCIRegistryTarget returns true if the VM is published to an OCI registry
+// instead of a content library.
+func isOCIRegistryTarget(vmPubReq *vmopv1.VirtualMachinePublishRequest) bool {
+	return vmPubReq.Status.TargetRef != nil && vmPubReq.Status.TargetRef.OCIRegistry != nil
+}
+
 // publishVirtualMachine checks if source VM and target is valid. Publish a VM if all requirements are met.
 func (r *Reconciler) publishVirtualMachine(ctx *pkgctx.VirtualMachinePublishRequestContext) error {
 	vmPublishReq := ctx.VMPublishRequest
@@ -328,6 +370,119 @@ func (r *Reconciler) publishVirtualMachine(ctx *pkgctx.VirtualMachinePublishRequ
 	return nil
 }
 
+// publishVirtualMachineToOCIRegistry checks the result of a prior push to the
+// target OCI registry, and pushes the VM if there is no push in progress and
+// no prior push succeeded. After a failed push, the VM is pushed again once
+// the backoff for the number of attempts in the status has elapsed, and the
+// remaining backoff is returned so the request is requeued.
+//
+// A push is tracked in memory, so if VM Operator restarts while a push is in
+// progress, the VM is pushed again. This is safe since pushing the same VM to
+// the same tag is idempotent.
+func (r *Reconciler) publishVirtualMachineToOCIRegistry(
+	ctx *pkgctx.VirtualMachinePublishRequestContext) (time.Duration, error) {
+
+	vmPublishReq := ctx.VMPublishRequest
+
+	if conditions.IsTrue(vmPublishReq, vmopv1.VirtualMachinePublishRequestConditionUploaded) {
+		return 0, nil
+	}
+
+	if vmPublishReq.Status.Attempts > 0 {
+		actID := getPublishRequestActID(vmPublishReq)
+		if obj, ok := r.ociPushes.Load(actID); ok {
+			res := obj.(ociPushResult)
+			if !res.done {
+				conditions.MarkFalse(vmPublishReq,
+					vmopv1.VirtualMachinePublishRequestConditionUploaded,
+					vmopv1.UploadingReason,
+					"Pushing artifact to OCI registry.")
+				return 0, nil
+			}
+
+			if res.err == nil {
+				r.ociPushes.Delete(actID)
+				vmPublishReq.Status.ArtifactRef = res.artifactRef
+				conditions.MarkTrue(vmPublishReq, vmopv1.VirtualMachinePublishRequestConditionUploaded)
+				return 0, nil
+			}
+
+			conditions.MarkFalse(vmPublishReq,
+				vmopv1.VirtualMachinePublishRequestConditionUploaded,
+				vmopv1.UploadFailureReason,
+				fmt.Sprintf("Push attempt %d failed: %v", vmPublishReq.Status.Attempts, res.err))
+
+			// Keep the failed result until the backoff has elapsed.
+			delay := ociPushRetryDelay(vmPublishReq.Status.Attempts)
+			if wait := delay - time.Since(res.finishedAt); wait > 0 {
+				ctx.Logger.Error(res.err, "VM Publish to OCI registry failed, will retry this operation",
+					"actID", actID, "retryAfter", wait)
+				return wait, nil
+			}
+
+			r.ociPushes.Delete(actID)
+		}
+	}
+
+	if err := r.checkIsSourceValid(ctx); err != nil {
+		ctx.Logger.Error(err, "failed to check if source is valid")
+		return 0, err
+	}
+
+	// Exporting the VM's disks requires the VM to be powered off.
+	if ctx.VM.Status.PowerState != vmopv1.VirtualMachinePowerStateOff {
+		err := fmt.Errorf("VM %s must be powered off to be published to an OCI registry", ctx.VM.Name)
+		conditions.MarkFalse(vmPublishReq,
+			vmopv1.VirtualMachinePublishRequestConditionSourceValid,
+			vmopv1.SourceVirtualMachineNotPoweredOffReason,
+			err.Error())
+		return 0, err
+	}
+
+	opts, err := r.checkIsOCIRegistryTargetValid(ctx)
+	if err != nil {
+		ctx.Logger.Error(err, "failed to check if target is valid")
+		return 0, err
+	}
+
+	vmPublishReq.Status.Attempts++
+	vmPublishReq.Status.LastAttemptTime = metav1.Now()
+
+	// Please refer to publishVirtualMachine for why Update is used instead
+	// of Patch.
+	ctx.SkipPatch = true
+	if err := r.Client.Status().Update(ctx, vmPublishReq); err != nil {
+		ctx.Logger.Error(err, "update VirtualMachinePublishRequest status failed")
+		return 0, err
+	}
+
+	actID := getPublishRequestActID(vmPublishReq)
+	r.ociPushes.Store(actID, ociPushResult{})
+
+	// The goroutine outlives this reconcile, so it must not share the objects
+	// in the context that are modified by later reconciles.
+	vmPublishReqCopy := vmPublishReq.DeepCopy()
+	vmCopy := ctx.VM.DeepCopy()
+	pushCtx, logger := ctx.Context, ctx.Logger
+	go func() {
+		artifactRef, pubErr := r.VMProvider.PublishVirtualMachineToOCIRegistry(pushCtx, vmCopy, vmPublishReqCopy, opts)
+		if pubErr != nil {
+			logger.Error(pubErr, "failed to publish VM to OCI registry")
+		} else {
+			logger.Info("pushed VM to OCI registry", "artifactRef", artifactRef)
+		}
+		r.ociPushes.Store(actID, ociPushResult{
+			done:        true,
+			finishedAt:  time.Now(),
+			artifactRef: artifactRef,
+			err:         pubErr,
+		})
+		r.Recorder.EmitEvent(vmPublishReqCopy, "Publish", pubErr, false)
+	}()
+
+	return 0, nil
+}
+
 func (r *Reconciler) removeVMPubResourceFromCluster(ctx *pkgctx.VirtualMachinePublishRequestContext) (requeueAfter time.Duration,
 	deleted bool, err error) {
 
@@ -474,6 +629,45 @@ func (r *Reconciler) checkIsTargetValid(ctx *pkgctx.VirtualMachinePublishRequest
 	return nil
 }
 
+// checkIsOCIRegistryTargetValid checks if the target OCI registry is valid and
+// returns the options used to connect to it. It is invalid if the Secret with
+// the registry credentials doesn't exist or doesn't have a username.
+func (r *Reconciler) checkIsOCIRegistryTargetValid(ctx *pkgctx.VirtualMachinePublishRequestContext) (oci.Options, error) {
+	vmPubReq := ctx.VMPublishRequest
+	target := vmPubReq.Status.TargetRef.OCIRegistry
+	opts := oci.Options{
+		Insecure: target.Insecure,
+	}
+
+	if secretName := target.CredentialsSecretName; secretName != "" {
+		secret, err := pkgutil.GetSecretResource(ctx, r.Client, vmPubReq.Namespace, secretName)
+		if err != nil {
+			ctx.Logger.Error(err, "failed to get OCI registry credentials Secret", "secretName", secretName)
+			if apierrors.IsNotFound(err) {
+				conditions.MarkFalse(vmPubReq,
+					vmopv1.VirtualMachinePublishRequestConditionTargetValid,
+					vmopv1.TargetOCIRegistryCredentialsInvalidReason,
+					err.Error())
+			}
+			return opts, err
+		}
+
+		opts.Username = string(secret.Data[corev1.BasicAuthUsernameKey])
+		opts.Password = string(secret.Data[corev1.BasicAuthPasswordKey])
+		if opts.Username == "" {
+			err := fmt.Errorf("secret %s does not have the key %q", secretName, corev1.BasicAuthUsernameKey)
+			conditions.MarkFalse(vmPubReq,
+				vmopv1.VirtualMachinePublishRequestConditionTargetValid,
+				vmopv1.TargetOCIRegistryCredentialsInvalidReason,
+				err.Error())
+			return opts, err
+		}
+	}
+
+	conditions.MarkTrue(vmPubReq, vmopv1.VirtualMachinePublishRequestConditionTargetValid)
+	return opts, nil
+}
+
 // checkIsImageAvailable checks if the published VirtualMachineImage resource is available in the cluster.
 func (r *Reconciler) checkIsImageAvailable(ctx *pkgctx.VirtualMachinePublishRequestContext) error {
 	if !conditions.IsTrue(ctx.VMPublishRequest, vmopv1.VirtualMachinePublishRequestConditionUploaded) {
@@ -535,7 +729,10 @@ func (r *Reconciler) checkIsComplete(ctx *pkgctx.VirtualMachinePublishRequestCon
 		return false
 	}
 
-	if !conditions.IsTrue(ctx.VMPublishRequest, vmopv1.VirtualMachinePublishRequestConditionImageAvailable) {
+	// A VirtualMachineImage resource is not realized for a VM published to
+	// an OCI registry.
+	if !isOCIRegistryTarget(ctx.VMPublishRequest) &&
+		!conditions.IsTrue(ctx.VMPublishRequest, vmopv1.VirtualMachinePublishRequestConditionImageAvailable) {
 		conditions.MarkFalse(ctx.VMPublishRequest,
 			vmopv1.VirtualMachinePublishRequestConditionComplete,
 			vmopv1.ImageUnavailableReason,
@@ -881,6 +1078,25 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachinePublishRequestCon
 
 	r.updateSourceAndTargetRef(ctx)
 
+	if isOCIRegistryTarget(vmPublishReq) {
+		retryAfter, err := r.publishVirtualMachineToOCIRegistry(ctx)
+		if err != nil {
+			ctx.Logger.Error(err, "failed to publish VirtualMachine to OCI registry")
+			return ctrl.Result{}, fmt.Errorf("failed to publish VirtualMachine: %w", err)
+		}
+		if retryAfter > 0 {
+			return ctrl.Result{RequeueAfter: retryAfter}, nil
+		}
+
+		if isComplete = r.checkIsComplete(ctx); isComplete {
+			requeueAfter, deleted, err := r.removeVMPubResourceFromCluster(ctx)
+			isDeleted = deleted
+			return ctrl.Result{RequeueAfter: requeueAfter}, err
+		}
+
+		return requeueResult(ctx), nil
+	}
+
 	shouldPublish, err := r.checkPubReqStatusAndShouldRepublish(ctx)
 	if err != nil {
 		return ctrl.Result{}, err
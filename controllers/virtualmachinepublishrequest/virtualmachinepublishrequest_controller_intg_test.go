@@ -241,6 +241,76 @@ func intgTestsReconcile() {
 			})
 		})
 
+		Context("Successfully reconcile a VirtualMachinePublishRequest with an OCI registry target", func() {
+			BeforeEach(func() {
+				vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+				Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+				vm.Status.UniqueID = "dummy-vm-unique-id"
+				vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+				Expect(ctx.Client.Status().Update(ctx, vm)).To(Succeed())
+
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "dummy-creds",
+						Namespace: ctx.Namespace,
+					},
+					Type: corev1.SecretTypeBasicAuth,
+					StringData: map[string]string{
+						corev1.BasicAuthUsernameKey: "user",
+						corev1.BasicAuthPasswordKey: "pass",
+					},
+				}
+				Expect(ctx.Client.Create(ctx, secret)).To(Succeed())
+
+				vmPubReq.Spec.Target.Location = vmopv1.VirtualMachinePublishRequestTargetLocation{}
+				vmPubReq.Spec.Target.OCIRegistry = &vmopv1.VirtualMachinePublishRequestTargetOCIRegistry{
+					Repository:            "registry.example.com/images/dummy-vm",
+					CredentialsSecretName: secret.Name,
+				}
+				Expect(ctx.Client.Create(ctx, vmPubReq)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				err := ctx.Client.Delete(ctx, vmPubReq)
+				Expect(client.IgnoreNotFound(err)).ToNot(HaveOccurred())
+				err = ctx.Client.Delete(ctx, vm)
+				Expect(client.IgnoreNotFound(err)).ToNot(HaveOccurred())
+
+				intgFakeVMProvider.Reset()
+			})
+
+			It("VirtualMachinePublishRequest completed", func() {
+				// Wait for initial reconcile.
+				waitForVirtualMachinePublishRequestFinalizer(ctx, client.ObjectKeyFromObject(vmPubReq))
+
+				By("PublishVM should be called", func() {
+					Eventually(intgFakeVMProvider.IsPublishVMCalled).Should(BeTrue())
+				})
+
+				attempt := 0
+				Eventually(func(g Gomega) {
+					// Force trigger a reconcile since the result of the push
+					// is not observed until the next reconcile.
+					attempt++
+					_, err := controllerutil.CreateOrPatch(ctx, ctx.Client, vmPubReq, func() error {
+						vmPubReq.Annotations = map[string]string{"dummy": fmt.Sprintf("dummy-%d", attempt)}
+						return nil
+					})
+					g.Expect(err).ToNot(HaveOccurred())
+
+					req := getVirtualMachinePublishRequest(ctx, client.ObjectKeyFromObject(vmPubReq))
+					g.Expect(req).ToNot(BeNil())
+
+					g.Expect(conditions.IsTrue(req, vmopv1.VirtualMachinePublishRequestConditionUploaded)).To(BeTrue())
+					g.Expect(conditions.IsTrue(req, vmopv1.VirtualMachinePublishRequestConditionComplete)).To(BeTrue())
+					g.Expect(req.Status.Ready).To(BeTrue())
+					g.Expect(req.Status.Attempts).To(BeEquivalentTo(1))
+					g.Expect(req.Status.ArtifactRef).To(Equal("registry.example.com/images/dummy-vm@sha256:dummy-digest"))
+					g.Expect(req.Status.CompletionTime).NotTo(BeZero())
+				}).Should(Succeed())
+			})
+		})
+
 		It("Reconciles after VirtualMachinePublishRequest deletion", func() {
 			Expect(ctx.Client.Create(ctx, vmPubReq)).To(Succeed())
 
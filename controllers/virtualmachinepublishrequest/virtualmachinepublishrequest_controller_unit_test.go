@@ -5,6 +5,7 @@ package virtualmachinepublishrequest_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/google/uuid"
@@ -28,6 +30,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
@@ -677,5 +680,156 @@ func unitTestsReconcile() {
 				})
 			})
 		})
+
+		Context("Publish to an OCI registry", func() {
+			var (
+				secret *corev1.Secret
+			)
+
+			BeforeEach(func() {
+				vmpub.Spec.Target.Location = vmopv1.VirtualMachinePublishRequestTargetLocation{}
+				vmpub.Spec.Target.OCIRegistry = &vmopv1.VirtualMachinePublishRequestTargetOCIRegistry{
+					Repository:            "registry.example.com/images/dummy-vm",
+					Tag:                   "v1",
+					CredentialsSecretName: "dummy-creds",
+				}
+				secret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "dummy-creds",
+						Namespace: vmpub.Namespace,
+					},
+					Type: corev1.SecretTypeBasicAuth,
+					Data: map[string][]byte{
+						corev1.BasicAuthUsernameKey: []byte("user"),
+						corev1.BasicAuthPasswordKey: []byte("pass"),
+					},
+				}
+				vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+				initObjects = []client.Object{vm, vmpub, secret}
+			})
+
+			It("pushes the VM with the credentials from the Secret and completes", func() {
+				var opts oci.Options
+				fakeVMProvider.Lock()
+				fakeVMProvider.PublishVirtualMachineToOCIRegistryFn = func(_ context.Context,
+					_ *vmopv1.VirtualMachine, vmPub *vmopv1.VirtualMachinePublishRequest, o oci.Options) (string, error) {
+					opts = o
+					return vmPub.Status.TargetRef.OCIRegistry.Repository + "@sha256:dummy-digest", nil
+				}
+				fakeVMProvider.Unlock()
+
+				_, err := reconciler.ReconcileNormal(vmpubCtx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conditions.IsTrue(vmpub,
+					vmopv1.VirtualMachinePublishRequestConditionSourceValid)).To(BeTrue())
+				Expect(conditions.IsTrue(vmpub,
+					vmopv1.VirtualMachinePublishRequestConditionTargetValid)).To(BeTrue())
+				Expect(vmpub.Status.Attempts).To(BeEquivalentTo(1))
+
+				Eventually(func() bool {
+					return fakeVMProvider.IsPublishVMCalled()
+				}).Should(BeTrue())
+
+				Eventually(func(g Gomega) {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(conditions.IsTrue(vmpub,
+						vmopv1.VirtualMachinePublishRequestConditionUploaded)).To(BeTrue())
+				}).Should(Succeed())
+
+				fakeVMProvider.Lock()
+				Expect(opts.Username).To(Equal("user"))
+				Expect(opts.Password).To(Equal("pass"))
+				fakeVMProvider.Unlock()
+
+				Expect(vmpub.Status.ArtifactRef).To(Equal("registry.example.com/images/dummy-vm@sha256:dummy-digest"))
+				Expect(conditions.Get(vmpub, vmopv1.VirtualMachinePublishRequestConditionImageAvailable)).To(BeNil())
+				Expect(conditions.IsTrue(vmpub,
+					vmopv1.VirtualMachinePublishRequestConditionComplete)).To(BeTrue())
+				Expect(vmpub.Status.Ready).To(BeTrue())
+				Expect(vmpub.Status.Attempts).To(BeEquivalentTo(1))
+			})
+
+			When("the push fails", func() {
+				JustBeforeEach(func() {
+					fakeVMProvider.Lock()
+					fakeVMProvider.PublishVirtualMachineToOCIRegistryFn = func(_ context.Context,
+						_ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachinePublishRequest, _ oci.Options) (string, error) {
+						return "", errors.New("push failed")
+					}
+					fakeVMProvider.Unlock()
+				})
+
+				It("marks Uploaded false and requeues with a backoff before pushing the VM again", func() {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					Expect(err).NotTo(HaveOccurred())
+
+					var result ctrl.Result
+					Eventually(func(g Gomega) {
+						var err error
+						result, err = reconciler.ReconcileNormal(vmpubCtx)
+						g.Expect(err).NotTo(HaveOccurred())
+						g.Expect(conditions.GetReason(vmpub,
+							vmopv1.VirtualMachinePublishRequestConditionUploaded)).To(Equal(vmopv1.UploadFailureReason))
+					}).Should(Succeed())
+
+					Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+					Expect(result.RequeueAfter).To(BeNumerically("<=", 30*time.Second))
+					Expect(conditions.Get(vmpub,
+						vmopv1.VirtualMachinePublishRequestConditionUploaded).Message).To(Equal("Push attempt 1 failed: push failed"))
+					Expect(vmpub.Status.Attempts).To(BeEquivalentTo(1))
+					Expect(vmpub.Status.Ready).To(BeFalse())
+				})
+			})
+
+			When("the VM is powered on", func() {
+				BeforeEach(func() {
+					vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+				})
+
+				It("returns an error and does not push the VM", func() {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					Expect(err).To(MatchError(ContainSubstring("must be powered off")))
+					Expect(conditions.GetReason(vmpub,
+						vmopv1.VirtualMachinePublishRequestConditionSourceValid)).To(Equal(vmopv1.SourceVirtualMachineNotPoweredOffReason))
+					Expect(vmpub.Status.Attempts).To(BeZero())
+
+					Consistently(func() bool {
+						return fakeVMProvider.IsPublishVMCalled()
+					}).Should(BeFalse())
+				})
+			})
+
+			When("the credentials Secret does not exist", func() {
+				BeforeEach(func() {
+					initObjects = []client.Object{vm, vmpub}
+				})
+
+				It("returns an error and does not push the VM", func() {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					Expect(err).To(HaveOccurred())
+					Expect(conditions.GetReason(vmpub,
+						vmopv1.VirtualMachinePublishRequestConditionTargetValid)).To(Equal(vmopv1.TargetOCIRegistryCredentialsInvalidReason))
+
+					Consistently(func() bool {
+						return fakeVMProvider.IsPublishVMCalled()
+					}).Should(BeFalse())
+				})
+			})
+
+			When("the credentials Secret does not have a username", func() {
+				BeforeEach(func() {
+					delete(secret.Data, corev1.BasicAuthUsernameKey)
+				})
+
+				It("returns an error and does not push the VM", func() {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					Expect(err).To(HaveOccurred())
+					Expect(conditions.GetReason(vmpub,
+						vmopv1.VirtualMachinePublishRequestConditionTargetValid)).To(Equal(vmopv1.TargetOCIRegistryCredentialsInvalidReason))
+					Expect(vmpub.Status.Attempts).To(BeZero())
+				})
+			})
+		})
 	})
 }
@@ -0,0 +1,487 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinepublishschedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/cron"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+// defaultActiveDeadline is how long a publication may be in progress when the
+// schedule does not specify an active deadline.
+const defaultActiveDeadline = 24 * time.Hour
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachinePublishSchedule{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProvider,
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		Owns(&vmopv1.VirtualMachinePublishRequest{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider providers.VirtualMachineProviderInterface) *Reconciler {
+
+	return &Reconciler{
+		Context:    ctx,
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+	}
+}
+
+// Reconciler reconciles a VirtualMachinePublishSchedule object.
+type Reconciler struct {
+	client.Client
+	Context    context.Context
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider providers.VirtualMachineProviderInterface
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinepublishschedules,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinepublishschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinepublishrequests,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimages,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	vmPubSched := &vmopv1.VirtualMachinePublishSchedule{}
+	if err := r.Get(ctx, req.NamespacedName, vmPubSched); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// The publication requests created by the schedule are garbage collected
+	// via their owner references. The published items are retained.
+	if !vmPubSched.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	schedCtx := &pkgctx.VirtualMachinePublishScheduleContext{
+		Context:           ctx,
+		Logger:            ctrl.Log.WithName("VirtualMachinePublishSchedule").WithValues("namespace", vmPubSched.Namespace, "name", vmPubSched.Name),
+		VMPublishSchedule: vmPubSched,
+	}
+
+	patchHelper, err := patch.NewHelper(vmPubSched, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", schedCtx.String(), err)
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, vmPubSched); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			schedCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	result, err := r.ReconcileNormal(schedCtx)
+	if err != nil {
+		schedCtx.Logger.Error(err, "Failed to reconcile VirtualMachinePublishSchedule")
+		return ctrl.Result{}, err
+	}
+
+	return result, nil
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachinePublishScheduleContext) (ctrl.Result, error) {
+	ctx.Logger.V(4).Info("Reconciling VirtualMachinePublishSchedule")
+
+	vmPubSched := ctx.VMPublishSchedule
+	now := time.Now()
+
+	schedule, err := cron.Parse(vmPubSched.Spec.Schedule)
+	if err != nil {
+		conditions.MarkFalse(
+			vmPubSched,
+			vmopv1.VirtualMachinePublishScheduleConditionScheduleValid,
+			vmopv1.InvalidScheduleReason,
+			err.Error())
+		vmPubSched.Status.NextScheduleTime = nil
+		return ctrl.Result{}, nil
+	}
+
+	// Render the templates up front so an invalid template is reported
+	// before the schedule is due.
+	data := vmopv1util.NewPublishScheduleTemplateData(vmPubSched, vmPubSched.Status.Revision+1, now)
+	if _, err := vmopv1util.RenderPublishScheduleTarget(vmPubSched, data); err != nil {
+		conditions.MarkFalse(
+			vmPubSched,
+			vmopv1.VirtualMachinePublishScheduleConditionScheduleValid,
+			vmopv1.InvalidTargetTemplateReason,
+			err.Error())
+		vmPubSched.Status.NextScheduleTime = nil
+		return ctrl.Result{}, nil
+	}
+
+	conditions.MarkTrue(vmPubSched, vmopv1.VirtualMachinePublishScheduleConditionScheduleValid)
+
+	activeRequeueAfter, err := r.reconcileActive(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.reconcileRetention(ctx); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if scheduledTime := mostRecentScheduleTime(vmPubSched, schedule, now); !scheduledTime.IsZero() {
+		if err := r.reconcileScheduled(ctx, scheduledTime); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	next := schedule.Next(now)
+	if next.IsZero() {
+		vmPubSched.Status.NextScheduleTime = nil
+		return ctrl.Result{}, nil
+	}
+	vmPubSched.Status.NextScheduleTime = &metav1.Time{Time: next}
+
+	requeueAfter := next.Sub(now)
+	if activeRequeueAfter > 0 && activeRequeueAfter < requeueAfter {
+		requeueAfter = activeRequeueAfter
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcileActive records the item published by the active publication
+// request once the request completes, and then deletes the request. A request
+// that failed or exceeded the active deadline is recorded as a failure and
+// deleted. The returned duration is greater than zero when the request is
+// still in progress, and is the time until its deadline.
+func (r *Reconciler) reconcileActive(ctx *pkgctx.VirtualMachinePublishScheduleContext) (time.Duration, error) {
+	vmPubSched := ctx.VMPublishSchedule
+	if vmPubSched.Status.Active == "" {
+		return 0, nil
+	}
+
+	vmPub := &vmopv1.VirtualMachinePublishRequest{}
+	key := client.ObjectKey{Namespace: vmPubSched.Namespace, Name: vmPubSched.Status.Active}
+	if err := r.Get(ctx, key, vmPub); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return 0, err
+		}
+		ctx.Logger.Info("Active VirtualMachinePublishRequest no longer exists", "vmPub", key.Name)
+		vmPubSched.Status.Active = ""
+		return 0, nil
+	}
+
+	if !vmPub.Status.Ready {
+		if msg, failed := publishRequestFailure(vmPub); failed {
+			return 0, r.failActive(ctx, vmPub, vmopv1.PublishFailedReason, msg)
+		}
+
+		deadline := defaultActiveDeadline
+		if s := vmPubSched.Spec.ActiveDeadlineSeconds; s != nil {
+			deadline = time.Duration(*s) * time.Second
+		}
+		if d := time.Until(vmPub.CreationTimestamp.Add(deadline)); d > 0 {
+			return d, nil
+		}
+
+		return 0, r.failActive(ctx, vmPub, vmopv1.PublishDeadlineExceededReason,
+			fmt.Sprintf("VirtualMachinePublishRequest %s did not complete within %s", vmPub.Name, deadline))
+	}
+
+	item := vmopv1.VirtualMachinePublishScheduleItem{
+		PublishRequestName: vmPub.Name,
+		ImageName:          vmPub.Status.ImageName,
+		ArtifactRef:        vmPub.Status.ArtifactRef,
+		CompletionTime:     vmPub.Status.CompletionTime,
+	}
+	if vmPub.Status.TargetRef != nil {
+		item.ItemName = vmPub.Status.TargetRef.Item.Name
+	}
+	vmPubSched.Status.Published = append(vmPubSched.Status.Published, item)
+	vmPubSched.Status.Active = ""
+	r.Recorder.EmitEvent(vmPubSched, "Publish", nil, false)
+
+	if err := r.Delete(ctx, vmPub); client.IgnoreNotFound(err) != nil {
+		return 0, err
+	}
+
+	return 0, nil
+}
+
+// failActive records the failure of the active publication request and
+// deletes the request so it does not publish an item after the schedule has
+// given up on it.
+func (r *Reconciler) failActive(
+	ctx *pkgctx.VirtualMachinePublishScheduleContext,
+	vmPub *vmopv1.VirtualMachinePublishRequest,
+	reason, message string) error {
+
+	vmPubSched := ctx.VMPublishSchedule
+
+	ctx.Logger.Info("Active VirtualMachinePublishRequest failed",
+		"vmPub", vmPub.Name, "reason", reason, "message", message)
+	vmPubSched.Status.LastFailure = &vmopv1.VirtualMachinePublishScheduleFailure{
+		PublishRequestName: vmPub.Name,
+		Reason:             reason,
+		Message:            message,
+		FailureTime:        metav1.Now(),
+	}
+	vmPubSched.Status.Failed++
+	vmPubSched.Status.Active = ""
+	r.Recorder.EmitEvent(vmPubSched, "Publish", errors.New(message), false)
+
+	if err := r.Delete(ctx, vmPub); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	return nil
+}
+
+// publishRequestFailure returns the message of the publication request's
+// condition that indicates it failed, and whether it failed. A request is
+// retried indefinitely by its own controller, so a failed upload, or a target
+// item that already exists, is treated as the failure of the scheduled
+// publication.
+func publishRequestFailure(vmPub *vmopv1.VirtualMachinePublishRequest) (string, bool) {
+	if c := conditions.Get(vmPub, vmopv1.VirtualMachinePublishRequestConditionUploaded); c != nil &&
+		c.Status == metav1.ConditionFalse && c.Reason == vmopv1.UploadFailureReason {
+		return c.Message, true
+	}
+	if c := conditions.Get(vmPub, vmopv1.VirtualMachinePublishRequestConditionTargetValid); c != nil &&
+		c.Status == metav1.ConditionFalse && c.Reason == vmopv1.TargetItemAlreadyExistsReason {
+		return c.Message, true
+	}
+	return "", false
+}
+
+// reconcileRetention deletes the oldest published items until no more than
+// the retention count remain.
+func (r *Reconciler) reconcileRetention(ctx *pkgctx.VirtualMachinePublishScheduleContext) error {
+	vmPubSched := ctx.VMPublishSchedule
+	if vmPubSched.Spec.RetentionCount == nil {
+		return nil
+	}
+
+	retentionCount := int(*vmPubSched.Spec.RetentionCount)
+	for len(vmPubSched.Status.Published) > retentionCount {
+		item := vmPubSched.Status.Published[0]
+
+		err := r.deletePublishedItem(ctx, item)
+		r.Recorder.EmitEvent(vmPubSched, "Prune", err, false)
+		if err != nil {
+			return fmt.Errorf("failed to delete published item %q: %w", item.ItemName, err)
+		}
+
+		ctx.Logger.Info("Deleted published item",
+			"itemName", item.ItemName, "imageName", item.ImageName, "artifactRef", item.ArtifactRef)
+		vmPubSched.Status.Published = vmPubSched.Status.Published[1:]
+	}
+
+	return nil
+}
+
+func (r *Reconciler) deletePublishedItem(
+	ctx *pkgctx.VirtualMachinePublishScheduleContext,
+	item vmopv1.VirtualMachinePublishScheduleItem) error {
+
+	vmPubSched := ctx.VMPublishSchedule
+
+	if item.ArtifactRef != "" {
+		repo, digest, ok := strings.Cut(item.ArtifactRef, "@")
+		if !ok {
+			return fmt.Errorf("invalid artifact reference %q", item.ArtifactRef)
+		}
+		opts, err := r.ociOptions(ctx)
+		if err != nil {
+			return err
+		}
+		c, err := oci.NewClient(repo, opts)
+		if err != nil {
+			return err
+		}
+		return c.DeleteManifest(ctx, digest)
+	}
+
+	if item.ImageName == "" {
+		return nil
+	}
+
+	vmi := &vmopv1.VirtualMachineImage{}
+	key := client.ObjectKey{Namespace: vmPubSched.Namespace, Name: item.ImageName}
+	if err := r.Get(ctx, key, vmi); err != nil {
+		if apierrors.IsNotFound(err) {
+			ctx.Logger.Info("VirtualMachineImage for published item no longer exists", "imageName", item.ImageName)
+			return nil
+		}
+		return err
+	}
+	if vmi.Status.ProviderItemID == "" {
+		return fmt.Errorf("VirtualMachineImage %s does not have a provider item ID", vmi.Name)
+	}
+
+	return r.VMProvider.DeleteContentLibraryItem(ctx, vmi.Status.ProviderItemID)
+}
+
+// ociOptions returns the options used to connect to the schedule's target
+// OCI registry.
+func (r *Reconciler) ociOptions(ctx *pkgctx.VirtualMachinePublishScheduleContext) (oci.Options, error) {
+	vmPubSched := ctx.VMPublishSchedule
+	target := vmPubSched.Spec.Target.OCIRegistry
+	if target == nil {
+		return oci.Options{}, nil
+	}
+
+	opts := oci.Options{
+		Insecure: target.Insecure,
+	}
+	if secretName := target.CredentialsSecretName; secretName != "" {
+		secret, err := pkgutil.GetSecretResource(ctx, r.Client, vmPubSched.Namespace, secretName)
+		if err != nil {
+			return opts, err
+		}
+		opts.Username = string(secret.Data[corev1.BasicAuthUsernameKey])
+		opts.Password = string(secret.Data[corev1.BasicAuthPasswordKey])
+	}
+
+	return opts, nil
+}
+
+// reconcileScheduled creates the publication request for the provided
+// scheduled time. The scheduled publication is skipped if the schedule is
+// suspended or a publication is already in progress.
+func (r *Reconciler) reconcileScheduled(
+	ctx *pkgctx.VirtualMachinePublishScheduleContext,
+	scheduledTime time.Time) error {
+
+	vmPubSched := ctx.VMPublishSchedule
+
+	switch {
+	case vmPubSched.Spec.Suspend:
+		ctx.Logger.V(4).Info("Skipping scheduled publication because the schedule is suspended",
+			"scheduledTime", scheduledTime)
+	case vmPubSched.Status.Active != "":
+		ctx.Logger.Info("Skipping scheduled publication because a publication is in progress",
+			"scheduledTime", scheduledTime, "active", vmPubSched.Status.Active)
+	default:
+		vmPub, err := r.newPublishRequest(vmPubSched, scheduledTime)
+		if err != nil {
+			return err
+		}
+		if err := r.Create(ctx, vmPub); err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		ctx.Logger.Info("Created VirtualMachinePublishRequest", "vmPub", vmPub.Name)
+
+		vmPubSched.Status.Revision++
+		vmPubSched.Status.Active = vmPub.Name
+	}
+
+	vmPubSched.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+	return nil
+}
+
+func (r *Reconciler) newPublishRequest(
+	vmPubSched *vmopv1.VirtualMachinePublishSchedule,
+	scheduledTime time.Time) (*vmopv1.VirtualMachinePublishRequest, error) {
+
+	revision := vmPubSched.Status.Revision + 1
+	data := vmopv1util.NewPublishScheduleTemplateData(vmPubSched, revision, scheduledTime)
+	target, err := vmopv1util.RenderPublishScheduleTarget(vmPubSched, data)
+	if err != nil {
+		return nil, err
+	}
+
+	source := vmPubSched.Spec.Source
+	source.Name = data.SourceName
+
+	vmPub := &vmopv1.VirtualMachinePublishRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%d", vmPubSched.Name, revision),
+			Namespace: vmPubSched.Namespace,
+			Labels: map[string]string{
+				vmopv1.VirtualMachinePublishScheduleLabel: vmPubSched.Name,
+			},
+		},
+		Spec: vmopv1.VirtualMachinePublishRequestSpec{
+			Source: source,
+			Target: target,
+		},
+	}
+	if err := controllerutil.SetControllerReference(vmPubSched, vmPub, r.Scheme()); err != nil {
+		return nil, err
+	}
+
+	return vmPub, nil
+}
+
+// mostRecentScheduleTime returns the most recent time at or before now when
+// the schedule was due that is after the schedule's last scheduled time. The
+// zero time is returned if the schedule is not due.
+func mostRecentScheduleTime(
+	vmPubSched *vmopv1.VirtualMachinePublishSchedule,
+	schedule *cron.Schedule,
+	now time.Time) time.Time {
+
+	last := vmPubSched.CreationTimestamp.Time
+	if t := vmPubSched.Status.LastScheduleTime; t != nil {
+		last = t.Time
+	}
+
+	var mostRecent time.Time
+	for t := schedule.Next(last); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		mostRecent = t
+	}
+
+	return mostRecent
+}
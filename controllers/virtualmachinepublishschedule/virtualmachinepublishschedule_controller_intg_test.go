@@ -0,0 +1,115 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinepublishschedule_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.V1Alpha3,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx *builder.IntegrationTestContext
+
+		vmPubSched *vmopv1.VirtualMachinePublishSchedule
+		schedKey   types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		vmPubSched = builder.DummyVirtualMachinePublishSchedule("dummy-sched", ctx.Namespace, "dummy-vm", "dummy-cl")
+		vmPubSched.Spec.Schedule = "@hourly"
+		schedKey = types.NamespacedName{Name: vmPubSched.Name, Namespace: vmPubSched.Namespace}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	getSchedule := func(g Gomega) *vmopv1.VirtualMachinePublishSchedule {
+		s := &vmopv1.VirtualMachinePublishSchedule{}
+		g.Expect(ctx.Client.Get(ctx, schedKey, s)).To(Succeed())
+		return s
+	}
+
+	Context("Reconcile", func() {
+		BeforeEach(func() {
+			Expect(ctx.Client.Create(ctx, vmPubSched)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(client.IgnoreNotFound(ctx.Client.Delete(ctx, vmPubSched))).To(Succeed())
+		})
+
+		It("Creates publish requests on schedule", func() {
+			Eventually(func(g Gomega) {
+				s := getSchedule(g)
+				g.Expect(conditions.IsTrue(s, vmopv1.VirtualMachinePublishScheduleConditionScheduleValid)).To(BeTrue())
+				g.Expect(s.Status.NextScheduleTime).ToNot(BeNil())
+				g.Expect(s.Status.Active).To(BeEmpty())
+			}).Should(Succeed())
+
+			By("The schedule being due", func() {
+				Eventually(func(g Gomega) {
+					s := getSchedule(g)
+					s.Status.LastScheduleTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+					g.Expect(ctx.Client.Status().Update(ctx, s)).To(Succeed())
+				}).Should(Succeed())
+			})
+
+			Eventually(func(g Gomega) {
+				s := getSchedule(g)
+				g.Expect(s.Status.Revision).To(BeEquivalentTo(1))
+				g.Expect(s.Status.Active).To(Equal("dummy-sched-1"))
+			}).Should(Succeed())
+
+			vmPub := &vmopv1.VirtualMachinePublishRequest{}
+			vmPubKey := types.NamespacedName{Name: "dummy-sched-1", Namespace: ctx.Namespace}
+			Expect(ctx.Client.Get(ctx, vmPubKey, vmPub)).To(Succeed())
+			Expect(vmPub.Labels).To(HaveKeyWithValue(vmopv1.VirtualMachinePublishScheduleLabel, vmPubSched.Name))
+			Expect(vmPub.Spec.Target.Item.Name).To(HavePrefix("dummy-vm-image-"))
+
+			By("The publish request completing", func() {
+				vmPub.Status.Ready = true
+				vmPub.Status.ImageName = "vmi-1"
+				vmPub.Status.TargetRef = vmPub.Spec.Target.DeepCopy()
+				vmPub.Status.CompletionTime = metav1.Now()
+				Expect(ctx.Client.Status().Update(ctx, vmPub)).To(Succeed())
+			})
+
+			Eventually(func(g Gomega) {
+				s := getSchedule(g)
+				g.Expect(s.Status.Active).To(BeEmpty())
+				g.Expect(s.Status.Published).To(HaveLen(1))
+				g.Expect(s.Status.Published[0].ImageName).To(Equal("vmi-1"))
+				g.Expect(s.Status.Published[0].ItemName).To(Equal(vmPub.Spec.Target.Item.Name))
+			}).Should(Succeed())
+		})
+	})
+}
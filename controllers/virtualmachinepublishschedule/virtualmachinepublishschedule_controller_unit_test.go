@@ -0,0 +1,422 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinepublishschedule_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinepublishschedule"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	ocifake "github.com/vmware-tanzu/vm-operator/pkg/util/oci/fake"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.V1Alpha3,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+
+		reconciler     *virtualmachinepublishschedule.Reconciler
+		fakeVMProvider *providerfake.VMProvider
+		vmPubSched     *vmopv1.VirtualMachinePublishSchedule
+		schedCtx       *pkgctx.VirtualMachinePublishScheduleContext
+	)
+
+	getPublishRequest := func(name string) (*vmopv1.VirtualMachinePublishRequest, error) {
+		vmPub := &vmopv1.VirtualMachinePublishRequest{}
+		key := client.ObjectKey{Namespace: vmPubSched.Namespace, Name: name}
+		return vmPub, ctx.Client.Get(ctx, key, vmPub)
+	}
+
+	BeforeEach(func() {
+		vmPubSched = builder.DummyVirtualMachinePublishSchedule("dummy-sched", "dummy-ns", "dummy-vm", "dummy-cl")
+		vmPubSched.Spec.Schedule = "@hourly"
+		vmPubSched.CreationTimestamp = metav1.NewTime(time.Now().Add(-90 * time.Minute))
+
+		initObjects = nil
+	})
+
+	JustBeforeEach(func() {
+		initObjects = append(initObjects, vmPubSched)
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = virtualmachinepublishschedule.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProvider,
+		)
+		fakeVMProvider = ctx.VMProvider.(*providerfake.VMProvider)
+		fakeVMProvider.Reset()
+
+		schedCtx = &pkgctx.VirtualMachinePublishScheduleContext{
+			Context:           ctx,
+			Logger:            ctx.Logger.WithName(vmPubSched.Name),
+			VMPublishSchedule: vmPubSched,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+	})
+
+	Context("ReconcileNormal", func() {
+
+		When("the schedule is invalid", func() {
+			BeforeEach(func() {
+				vmPubSched.Spec.Schedule = "* * *"
+			})
+			It("marks the schedule as invalid", func() {
+				result, err := reconciler.ReconcileNormal(schedCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+
+				c := conditions.Get(vmPubSched, vmopv1.VirtualMachinePublishScheduleConditionScheduleValid)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.InvalidScheduleReason))
+				Expect(vmPubSched.Status.NextScheduleTime).To(BeNil())
+				Expect(vmPubSched.Status.Revision).To(BeZero())
+			})
+		})
+
+		When("the item name template is invalid", func() {
+			BeforeEach(func() {
+				vmPubSched.Spec.Target.Item.Name = "{{ .Unknown }}"
+			})
+			It("marks the schedule as invalid", func() {
+				_, err := reconciler.ReconcileNormal(schedCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(conditions.GetReason(vmPubSched, vmopv1.VirtualMachinePublishScheduleConditionScheduleValid)).To(
+					Equal(vmopv1.InvalidTargetTemplateReason))
+				Expect(vmPubSched.Status.Revision).To(BeZero())
+			})
+		})
+
+		When("the schedule is not due", func() {
+			BeforeEach(func() {
+				vmPubSched.Status.LastScheduleTime = &metav1.Time{Time: time.Now()}
+			})
+			It("requeues until the next scheduled time", func() {
+				result, err := reconciler.ReconcileNormal(schedCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+				Expect(result.RequeueAfter).To(BeNumerically("<=", time.Hour))
+
+				Expect(conditions.IsTrue(vmPubSched, vmopv1.VirtualMachinePublishScheduleConditionScheduleValid)).To(BeTrue())
+				Expect(vmPubSched.Status.NextScheduleTime).ToNot(BeNil())
+				Expect(vmPubSched.Status.NextScheduleTime.UTC().Minute()).To(BeZero())
+				Expect(vmPubSched.Status.Revision).To(BeZero())
+				Expect(vmPubSched.Status.Active).To(BeEmpty())
+			})
+		})
+
+		When("the schedule is due", func() {
+			It("creates a publish request", func() {
+				_, err := reconciler.ReconcileNormal(schedCtx)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(vmPubSched.Status.Revision).To(BeEquivalentTo(1))
+				Expect(vmPubSched.Status.Active).To(Equal("dummy-sched-1"))
+				Expect(vmPubSched.Status.LastScheduleTime).ToNot(BeNil())
+				lastScheduleTime := vmPubSched.Status.LastScheduleTime.UTC()
+				Expect(lastScheduleTime.Minute()).To(BeZero())
+
+				vmPub, err := getPublishRequest("dummy-sched-1")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vmPub.Labels).To(HaveKeyWithValue(vmopv1.VirtualMachinePublishScheduleLabel, vmPubSched.Name))
+				Expect(metav1.IsControlledBy(vmPub, vmPubSched)).To(BeTrue())
+				Expect(vmPub.Spec.Source.Name).To(Equal("dummy-vm"))
+				Expect(vmPub.Spec.Target.Location.Name).To(Equal("dummy-cl"))
+				Expect(vmPub.Spec.Target.Item.Name).To(Equal(
+					"dummy-vm-image-" + lastScheduleTime.Format("20060102150405")))
+			})
+
+			When("the schedule is suspended", func() {
+				BeforeEach(func() {
+					vmPubSched.Spec.Suspend = true
+				})
+				It("does not create a publish request", func() {
+					_, err := reconciler.ReconcileNormal(schedCtx)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(vmPubSched.Status.Revision).To(BeZero())
+					Expect(vmPubSched.Status.Active).To(BeEmpty())
+					Expect(vmPubSched.Status.LastScheduleTime).ToNot(BeNil())
+					Expect(vmPubSched.Status.NextScheduleTime).ToNot(BeNil())
+				})
+			})
+
+			When("a publication is in progress", func() {
+				BeforeEach(func() {
+					vmPub := builder.DummyVirtualMachinePublishRequest("dummy-sched-1", vmPubSched.Namespace, "dummy-vm", "dummy-item", "dummy-cl")
+					vmPub.CreationTimestamp = metav1.NewTime(time.Now().Add(-30 * time.Minute))
+					initObjects = append(initObjects, vmPub)
+					vmPubSched.Status.Revision = 1
+					vmPubSched.Status.Active = vmPub.Name
+				})
+				It("skips the scheduled publication", func() {
+					_, err := reconciler.ReconcileNormal(schedCtx)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(vmPubSched.Status.Revision).To(BeEquivalentTo(1))
+					Expect(vmPubSched.Status.Active).To(Equal("dummy-sched-1"))
+					Expect(vmPubSched.Status.LastScheduleTime).ToNot(BeNil())
+
+					_, err = getPublishRequest("dummy-sched-2")
+					Expect(err).To(HaveOccurred())
+				})
+			})
+		})
+
+		When("the active publication is complete", func() {
+			var vmPub *vmopv1.VirtualMachinePublishRequest
+
+			BeforeEach(func() {
+				vmPubSched.Status.LastScheduleTime = &metav1.Time{Time: time.Now()}
+				vmPubSched.Status.Revision = 2
+				vmPubSched.Status.Active = "dummy-sched-2"
+				vmPubSched.Status.Published = []vmopv1.VirtualMachinePublishScheduleItem{
+					{
+						PublishRequestName: "dummy-sched-1",
+						ItemName:           "dummy-item-1",
+						ImageName:          "vmi-1",
+					},
+				}
+
+				vmPub = builder.DummyVirtualMachinePublishRequest("dummy-sched-2", vmPubSched.Namespace, "dummy-vm", "dummy-item-2", "dummy-cl")
+				vmPub.Finalizers = nil
+				vmPub.Status.Ready = true
+				vmPub.Status.ImageName = "vmi-2"
+				vmPub.Status.TargetRef = vmPub.Spec.Target.DeepCopy()
+				vmPub.Status.CompletionTime = metav1.Now()
+				initObjects = append(initObjects, vmPub)
+			})
+
+			It("records the published item and deletes the publish request", func() {
+				_, err := reconciler.ReconcileNormal(schedCtx)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(vmPubSched.Status.Active).To(BeEmpty())
+				Expect(vmPubSched.Status.Published).To(HaveLen(2))
+				item := vmPubSched.Status.Published[1]
+				Expect(item.PublishRequestName).To(Equal("dummy-sched-2"))
+				Expect(item.ItemName).To(Equal("dummy-item-2"))
+				Expect(item.ImageName).To(Equal("vmi-2"))
+				Expect(item.CompletionTime.IsZero()).To(BeFalse())
+
+				_, err = getPublishRequest(vmPub.Name)
+				Expect(err).To(HaveOccurred())
+			})
+
+			When("the retention count is exceeded", func() {
+				var deletedItemIDs []string
+
+				BeforeEach(func() {
+					deletedItemIDs = nil
+					vmPubSched.Spec.RetentionCount = ptr.To(int32(1))
+
+					vmi := builder.DummyVirtualMachineImage("vmi-1")
+					vmi.Namespace = vmPubSched.Namespace
+					vmi.Status.ProviderItemID = "item-id-1"
+					initObjects = append(initObjects, vmi)
+				})
+
+				JustBeforeEach(func() {
+					fakeVMProvider.DeleteContentLibraryItemFn = func(_ context.Context, itemID string) error {
+						deletedItemIDs = append(deletedItemIDs, itemID)
+						return nil
+					}
+				})
+
+				It("deletes the oldest published item", func() {
+					_, err := reconciler.ReconcileNormal(schedCtx)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(deletedItemIDs).To(Equal([]string{"item-id-1"}))
+					Expect(vmPubSched.Status.Published).To(HaveLen(1))
+					Expect(vmPubSched.Status.Published[0].PublishRequestName).To(Equal("dummy-sched-2"))
+				})
+
+				When("deleting the item fails", func() {
+					JustBeforeEach(func() {
+						fakeVMProvider.DeleteContentLibraryItemFn = func(_ context.Context, _ string) error {
+							return errors.New("delete error")
+						}
+					})
+
+					It("returns an error and retains the item", func() {
+						_, err := reconciler.ReconcileNormal(schedCtx)
+						Expect(err).To(MatchError(ContainSubstring("delete error")))
+						Expect(vmPubSched.Status.Published).To(HaveLen(2))
+					})
+				})
+			})
+		})
+
+		When("the active publication is in progress", func() {
+			var vmPub *vmopv1.VirtualMachinePublishRequest
+
+			BeforeEach(func() {
+				vmPubSched.Status.LastScheduleTime = &metav1.Time{Time: time.Now()}
+				vmPubSched.Status.Revision = 1
+				vmPubSched.Status.Active = "dummy-sched-1"
+				vmPubSched.Spec.ActiveDeadlineSeconds = ptr.To[int64](600)
+
+				vmPub = builder.DummyVirtualMachinePublishRequest("dummy-sched-1", vmPubSched.Namespace, "dummy-vm", "dummy-item-1", "dummy-cl")
+				vmPub.Finalizers = nil
+				vmPub.CreationTimestamp = metav1.NewTime(time.Now().Add(-5 * time.Minute))
+				initObjects = append(initObjects, vmPub)
+			})
+
+			It("requeues until the active deadline", func() {
+				result, err := reconciler.ReconcileNormal(schedCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically("~", 5*time.Minute, time.Minute))
+
+				Expect(vmPubSched.Status.Active).To(Equal("dummy-sched-1"))
+				Expect(vmPubSched.Status.LastFailure).To(BeNil())
+				Expect(vmPubSched.Status.Failed).To(BeZero())
+			})
+
+			When("the active deadline is exceeded", func() {
+				BeforeEach(func() {
+					vmPub.CreationTimestamp = metav1.NewTime(time.Now().Add(-15 * time.Minute))
+				})
+
+				It("records the failure and deletes the publish request", func() {
+					_, err := reconciler.ReconcileNormal(schedCtx)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(vmPubSched.Status.Active).To(BeEmpty())
+					Expect(vmPubSched.Status.Published).To(BeEmpty())
+					Expect(vmPubSched.Status.Failed).To(BeEquivalentTo(1))
+					Expect(vmPubSched.Status.LastFailure).ToNot(BeNil())
+					Expect(vmPubSched.Status.LastFailure.PublishRequestName).To(Equal("dummy-sched-1"))
+					Expect(vmPubSched.Status.LastFailure.Reason).To(Equal(vmopv1.PublishDeadlineExceededReason))
+					Expect(vmPubSched.Status.LastFailure.FailureTime.IsZero()).To(BeFalse())
+
+					_, err = getPublishRequest(vmPub.Name)
+					Expect(err).To(HaveOccurred())
+				})
+			})
+
+			When("the upload failed", func() {
+				BeforeEach(func() {
+					conditions.MarkFalse(vmPub,
+						vmopv1.VirtualMachinePublishRequestConditionUploaded,
+						vmopv1.UploadFailureReason,
+						"upload error")
+				})
+
+				It("records the failure and deletes the publish request", func() {
+					_, err := reconciler.ReconcileNormal(schedCtx)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(vmPubSched.Status.Active).To(BeEmpty())
+					Expect(vmPubSched.Status.Failed).To(BeEquivalentTo(1))
+					Expect(vmPubSched.Status.LastFailure).ToNot(BeNil())
+					Expect(vmPubSched.Status.LastFailure.Reason).To(Equal(vmopv1.PublishFailedReason))
+					Expect(vmPubSched.Status.LastFailure.Message).To(Equal("upload error"))
+
+					_, err = getPublishRequest(vmPub.Name)
+					Expect(err).To(HaveOccurred())
+				})
+			})
+
+			When("the target item already exists", func() {
+				BeforeEach(func() {
+					conditions.MarkFalse(vmPub,
+						vmopv1.VirtualMachinePublishRequestConditionTargetValid,
+						vmopv1.TargetItemAlreadyExistsReason,
+						"item already exists")
+				})
+
+				It("records the failure", func() {
+					_, err := reconciler.ReconcileNormal(schedCtx)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(vmPubSched.Status.Active).To(BeEmpty())
+					Expect(vmPubSched.Status.LastFailure).ToNot(BeNil())
+					Expect(vmPubSched.Status.LastFailure.Reason).To(Equal(vmopv1.PublishFailedReason))
+				})
+			})
+		})
+
+		When("the active publish request no longer exists", func() {
+			BeforeEach(func() {
+				vmPubSched.Status.LastScheduleTime = &metav1.Time{Time: time.Now()}
+				vmPubSched.Status.Revision = 1
+				vmPubSched.Status.Active = "dummy-sched-1"
+			})
+			It("clears the active publication", func() {
+				_, err := reconciler.ReconcileNormal(schedCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vmPubSched.Status.Active).To(BeEmpty())
+				Expect(vmPubSched.Status.Published).To(BeEmpty())
+			})
+		})
+
+		When("published to an OCI registry and the retention count is exceeded", func() {
+			var registry *ocifake.Registry
+
+			BeforeEach(func() {
+				registry = ocifake.NewRegistry()
+				DeferCleanup(registry.Close)
+
+				vmPubSched.Spec.Target.Location.Name = ""
+				vmPubSched.Spec.Target.OCIRegistry = &vmopv1.VirtualMachinePublishRequestTargetOCIRegistry{
+					Repository: registry.Host() + "/dummy-vm",
+					Insecure:   true,
+				}
+				vmPubSched.Spec.RetentionCount = ptr.To(int32(1))
+				vmPubSched.Status.LastScheduleTime = &metav1.Time{Time: time.Now()}
+				vmPubSched.Status.Published = []vmopv1.VirtualMachinePublishScheduleItem{
+					{
+						PublishRequestName: "dummy-sched-1",
+						ArtifactRef:        registry.Host() + "/dummy-vm@sha256:1234",
+					},
+					{
+						PublishRequestName: "dummy-sched-2",
+						ArtifactRef:        registry.Host() + "/dummy-vm@sha256:5678",
+					},
+				}
+			})
+
+			It("deletes the oldest artifact", func() {
+				_, err := reconciler.ReconcileNormal(schedCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vmPubSched.Status.Published).To(HaveLen(1))
+				Expect(vmPubSched.Status.Published[0].PublishRequestName).To(Equal("dummy-sched-2"))
+			})
+		})
+	})
+}
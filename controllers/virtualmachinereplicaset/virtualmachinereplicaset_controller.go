@@ -49,7 +49,9 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/patch"
 	"github.com/vmware-tanzu/vm-operator/pkg/prober"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 var (
@@ -429,8 +431,6 @@ func (r *Reconciler) getNewVirtualMachine(rs *vmopv1.VirtualMachineReplicaSet) *
 
 	vm.Annotations = getAnnotationsFromReplicaSet(rs)
 
-	// TODO: Propagate the VirtualMachineDeploymentNameLabel from VirtualMachineReplicaSet to VirtualMachines if it exists.
-
 	return vm
 }
 
@@ -443,6 +443,11 @@ func getLabelsFromVMReplicaSet(rs *vmopv1.VirtualMachineReplicaSet) map[string]s
 	// Ensure that the ReplicaSetLabel is always present.
 	labels[vmopv1.VirtualMachineReplicaSetNameLabel] = util.MustFormatValue(rs.Name)
 
+	// Propagate the DeploymentLabel if the replica set is owned by a deployment.
+	if name, ok := rs.Labels[vmopv1.VirtualMachineDeploymentNameLabel]; ok {
+		labels[vmopv1.VirtualMachineDeploymentNameLabel] = name
+	}
+
 	return labels
 }
 
@@ -474,6 +479,12 @@ func (r *Reconciler) syncReplicas(
 			"vmsToBeCreated", diff,
 		)
 
+		zones, err := r.getSpreadZones(ctx, rs)
+		if err != nil {
+			return err
+		}
+		zoneCounts := getZoneCounts(vms, zones)
+
 		var (
 			vmList []*vmopv1.VirtualMachine
 			errs   []error
@@ -481,6 +492,13 @@ func (r *Reconciler) syncReplicas(
 
 		for i := 0; i < diff; i++ {
 			vm := r.getNewVirtualMachine(rs)
+
+			var zone string
+			if len(zones) > 0 {
+				zone = getZoneForNewReplica(zones, zoneCounts, getMaxSkew(rs))
+				vm.Labels[topology.KubernetesTopologyZoneLabelKey] = zone
+			}
+
 			log := ctx.Logger.WithValues("vm", vm.Name)
 			log.Info("Creating VM", "index", i+1, "totalVMsToBeCreated", diff)
 
@@ -497,6 +515,10 @@ func (r *Reconciler) syncReplicas(
 				continue
 			}
 
+			if zone != "" {
+				zoneCounts[zone]++
+			}
+
 			log.V(5).Info("Created VM", "index", i+1, "totalVMsToBeCreated", diff)
 			r.Recorder.Eventf(rs, "SuccessfulCreate", "Created vm %q", vm.Name)
 			vmList = append(vmList, vm)
@@ -511,8 +533,8 @@ func (r *Reconciler) syncReplicas(
 		ctx.Logger.Info("ReplicaSet is scaling down",
 			"currentReplicas", len(vms),
 			"desiredReplicas", *(rs.Spec.Replicas),
-			"vmsToBeCreated", diff,
-			"deletePolicy", "oldestFirst",
+			"vmsToBeDeleted", diff,
+			"deletePolicy", rs.Spec.DeletePolicy,
 		)
 
 		deletePriorityFunc, err := getDeletePriorityFunc(rs)
@@ -520,11 +542,35 @@ func (r *Reconciler) syncReplicas(
 			return err
 		}
 
-		var errs []error
-		vmsToDelete := getMachinesToDeletePrioritized(vms, diff, deletePriorityFunc)
+		zones, err := r.getSpreadZones(ctx, rs)
+		if err != nil {
+			return err
+		}
+
+		var vmsToDelete []*vmopv1.VirtualMachine
+		if len(zones) > 0 {
+			vmsToDelete = getMachinesToDeleteWithZoneSpread(vms, diff, deletePriorityFunc, zones, getMaxSkew(rs))
+		} else {
+			vmsToDelete = getMachinesToDeletePrioritized(vms, diff, deletePriorityFunc)
+		}
+
+		var (
+			errs      []error
+			disrupted []string
+		)
 		for i, vm := range vmsToDelete {
 			log := ctx.Logger.WithValues("vm", vm.Name)
 			if vm.GetDeletionTimestamp().IsZero() {
+				// Honor the disruption budgets that select the VM. The VMs that
+				// were deleted in this loop are treated as disrupted since they
+				// may not yet be observed as being deleted.
+				if err := vmopv1util.CheckDisruptionAllowed(ctx, r.Client, vm, disrupted...); err != nil {
+					log.Info("Not deleting VM to scale down replicaset", "reason", err.Error())
+					r.Recorder.Warnf(rs, "DisruptionNotAllowed", "Not deleting VM %q: %v", vm.Name, err)
+					errs = append(errs, err)
+					continue
+				}
+
 				log.Info("Deleting VM to scale down replicaset", "index", i+1, "totalVMsToBeDeleted", diff)
 
 				if err := r.Client.Delete(ctx, vm); err != nil {
@@ -533,6 +579,7 @@ func (r *Reconciler) syncReplicas(
 					errs = append(errs, err)
 					continue
 				}
+				disrupted = append(disrupted, vm.Name)
 				log.V(5).Info("Deleted VM", "index", i+1, "totalVMsToBeDeleted", diff)
 				r.Recorder.Eventf(rs, "SuccessfulDelete", "Deleted VM %q", vm.Name)
 			} else {
@@ -615,11 +662,9 @@ func (r *Reconciler) updateStatus(
 			fullyLabeledReplicasCount++
 		}
 
-		// TODO: Figure out an equivalent of Ready condition on the VirtualMachine
-		// resource so we can populate the ready and available replicas in the Status.
-		// For now, we count all replicas as ready and available.
-		readyReplicasCount++
-
+		if vmopv1util.IsVirtualMachineReady(vm) {
+			readyReplicasCount++
+		}
 	}
 
 	newStatus.Replicas = int32(len(filteredVMs))
@@ -15,10 +15,13 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
@@ -210,6 +213,105 @@ func intgTestsReconcile() {
 			})
 		})
 
+		It("Spreads the replicas across zones", func() {
+			rs.Spec.Replicas = ptrTo(int32(3))
+			rs.Spec.TopologySpread = &vmopv1.VirtualMachineReplicaSetTopologySpread{
+				MaxSkew: 1,
+				Zones:   []string{"zone-a", "zone-b"},
+			}
+			Expect(ctx.Client.Create(ctx, rs)).To(Succeed())
+
+			By("VirtualMachineReplicaSet should have finalizer added", func() {
+				waitForReplicaSetFinalizer(ctx, rsKey)
+			})
+
+			getZoneCounts := func(g Gomega) map[string]int {
+				vmList := &vmopv1.VirtualMachineList{}
+				g.Expect(ctx.Client.List(ctx, vmList, client.InNamespace(ctx.Namespace),
+					client.MatchingLabels(rs.Spec.Selector.MatchLabels))).To(Succeed())
+
+				counts := map[string]int{}
+				for _, vm := range vmList.Items {
+					if vm.DeletionTimestamp.IsZero() {
+						counts[vm.Labels[topology.KubernetesTopologyZoneLabelKey]]++
+					}
+				}
+				return counts
+			}
+
+			By("Replicas must be placed in the preferred zone first", func() {
+				Eventually(getZoneCounts).Should(Equal(map[string]int{"zone-a": 2, "zone-b": 1}))
+			})
+
+			By("Replicas must be removed from the least preferred zone on scale down", func() {
+				_, err := controllerutil.CreateOrPatch(ctx, ctx.Client, rs, func() error {
+					rs.Spec.Replicas = ptrTo(int32(2))
+					return nil
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Removing the replica from zone-b would exceed the max skew.
+				Eventually(getZoneCounts).Should(Equal(map[string]int{"zone-a": 1, "zone-b": 1}))
+			})
+		})
+
+		It("Honors a VirtualMachineDisruptionBudget on scale down", func() {
+			budget := &vmopv1.VirtualMachineDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dummy-budget",
+					Namespace: ctx.Namespace,
+				},
+				Spec: vmopv1.VirtualMachineDisruptionBudgetSpec{
+					Selector:     rs.Spec.Selector.DeepCopy(),
+					MinAvailable: ptrTo(intstr.FromInt32(2)),
+				},
+			}
+			Expect(ctx.Client.Create(ctx, budget)).To(Succeed())
+
+			Expect(ctx.Client.Create(ctx, rs)).To(Succeed())
+
+			By("Sufficient replicas must be created", func() {
+				ensureReplicas(ctx, rs.Spec.Selector.MatchLabels, int(*rs.Spec.Replicas))
+			})
+
+			By("Marking the replicas ready", func() {
+				vmList := &vmopv1.VirtualMachineList{}
+				Expect(ctx.Client.List(ctx, vmList, client.InNamespace(ctx.Namespace),
+					client.MatchingLabels(rs.Spec.Selector.MatchLabels))).To(Succeed())
+				for i := range vmList.Items {
+					vm := &vmList.Items[i]
+					conditions.MarkTrue(vm, vmopv1.VirtualMachineConditionCreated)
+					vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+					Expect(ctx.Client.Status().Update(ctx, vm)).To(Succeed())
+				}
+			})
+
+			By("Replicas must not be deleted in violation of the budget", func() {
+				_, err := controllerutil.CreateOrPatch(ctx, ctx.Client, rs, func() error {
+					rs.Spec.Replicas = ptrTo(int32(1))
+					return nil
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				Consistently(func(g Gomega) int {
+					vmList := &vmopv1.VirtualMachineList{}
+					g.Expect(ctx.Client.List(ctx, vmList, client.InNamespace(ctx.Namespace),
+						client.MatchingLabels(rs.Spec.Selector.MatchLabels))).To(Succeed())
+					return len(vmList.Items)
+				}, 5*time.Second, 1*time.Second).Should(Equal(2))
+			})
+
+			By("Replicas must be deleted once the budget allows it", func() {
+				_, err := controllerutil.CreateOrPatch(ctx, ctx.Client, budget, func() error {
+					budget.Spec.MinAvailable = ptrTo(intstr.FromInt32(1))
+					return nil
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				ensureReplicas(ctx, rs.Spec.Selector.MatchLabels, 1)
+			})
+		})
+
 		It("Reconciles after VirtualMachineReplicaSet deletion", func() {
 			Expect(ctx.Client.Create(ctx, rs)).To(Succeed())
 			// Wait for initial reconcile.
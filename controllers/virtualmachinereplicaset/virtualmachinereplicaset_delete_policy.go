@@ -20,9 +20,14 @@ limitations under the License.
 package virtualmachinereplicaset
 
 import (
+	"fmt"
+	"math"
 	"sort"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 type (
@@ -32,18 +37,61 @@ type (
 
 const (
 	mustDelete    deletePriority = 100.0
-	couldDelete   deletePriority = 50.0
+	shouldDelete  deletePriority = 75.0
+	betterDelete  deletePriority = 50.0
+	couldDelete   deletePriority = 20.0
 	mustNotDelete deletePriority = 0.0
+
+	secondsPerTenDays float64 = 864000
 )
 
-func randomDeletePolicy(vm *vmopv1.VirtualMachine) deletePriority {
+// basePriority returns the priority shared by all delete policies and true,
+// or false if the VM is ready and not otherwise marked for deletion, in which
+// case the policy decides.
+func basePriority(vm *vmopv1.VirtualMachine) (deletePriority, bool) {
 	if !vm.DeletionTimestamp.IsZero() {
-		return mustDelete
+		return mustDelete, true
+	}
+	if _, ok := vm.Annotations[vmopv1.VirtualMachineReplicaSetDeletePriorityAnnotation]; ok {
+		return shouldDelete, true
+	}
+	if !vmopv1util.IsVirtualMachineReady(vm) {
+		return betterDelete, true
 	}
+	return 0, false
+}
+
+// agePriority maps the age of the VM onto [mustNotDelete, couldDelete), with
+// older VMs getting a higher value.
+func agePriority(vm *vmopv1.VirtualMachine) deletePriority {
+	if vm.CreationTimestamp.Time.IsZero() {
+		return mustNotDelete
+	}
+	d := metav1.Now().Sub(vm.CreationTimestamp.Time)
+	if d.Seconds() < 0 {
+		return mustNotDelete
+	}
+	return deletePriority(float64(couldDelete) * (1.0 - math.Exp(-d.Seconds()/secondsPerTenDays)))
+}
 
-	// TODO: when we support a Ready/healthy condition, that should have a
-	// higher priority for deletion.  For now, all VMs that are not marked
-	// for deletion get the same priority.
+func oldestDeletePolicy(vm *vmopv1.VirtualMachine) deletePriority {
+	if p, ok := basePriority(vm); ok {
+		return p
+	}
+	return agePriority(vm)
+}
+
+func newestDeletePolicy(vm *vmopv1.VirtualMachine) deletePriority {
+	if p, ok := basePriority(vm); ok {
+		return p
+	}
+	return couldDelete - agePriority(vm)
+}
+
+func randomDeletePolicy(vm *vmopv1.VirtualMachine) deletePriority {
+	if p, ok := basePriority(vm); ok {
+		return p
+	}
 	return couldDelete
 }
 
@@ -81,8 +129,15 @@ func getMachinesToDeletePrioritized(filteredMachines []*vmopv1.VirtualMachine, d
 	return sortable.machines[:diff]
 }
 
-// TODO(muchhals): Needed when we add delete priority field.
-func getDeletePriorityFunc(_ *vmopv1.VirtualMachineReplicaSet) (deletePriorityFunc, error) {
-	// TODO: Expand when we start supporting other delete policies.
-	return randomDeletePolicy, nil
+func getDeletePriorityFunc(rs *vmopv1.VirtualMachineReplicaSet) (deletePriorityFunc, error) {
+	switch rs.Spec.DeletePolicy {
+	case vmopv1.RandomVirtualMachineReplicaSetDeletePolicy, "":
+		return randomDeletePolicy, nil
+	case vmopv1.NewestVirtualMachineReplicaSetDeletePolicy:
+		return newestDeletePolicy, nil
+	case vmopv1.OldestVirtualMachineReplicaSetDeletePolicy:
+		return oldestDeletePolicy, nil
+	default:
+		return nil, fmt.Errorf("unsupported delete policy %q", rs.Spec.DeletePolicy)
+	}
 }
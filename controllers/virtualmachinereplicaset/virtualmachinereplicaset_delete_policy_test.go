@@ -0,0 +1,132 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinereplicaset
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+)
+
+var _ = Describe(
+	"Delete policy",
+	Label(testlabels.Controller, testlabels.V1Alpha3),
+	func() {
+		var (
+			now = time.Now()
+
+			newVM = func(name string, age time.Duration, ready bool) *vmopv1.VirtualMachine {
+				vm := &vmopv1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              name,
+						CreationTimestamp: metav1.NewTime(now.Add(-age)),
+					},
+				}
+				if ready {
+					conditions.MarkTrue(vm, vmopv1.VirtualMachineConditionCreated)
+					vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+				}
+				return vm
+			}
+
+			names = func(vms []*vmopv1.VirtualMachine) []string {
+				var n []string
+				for _, vm := range vms {
+					n = append(n, vm.Name)
+				}
+				return n
+			}
+
+			vmMiddle, vmNew *vmopv1.VirtualMachine
+			vms             []*vmopv1.VirtualMachine
+		)
+
+		BeforeEach(func() {
+			vmMiddle = newVM("vm-middle", 24*time.Hour, true)
+			vmNew = newVM("vm-new", time.Hour, true)
+			vms = []*vmopv1.VirtualMachine{
+				vmMiddle,
+				newVM("vm-old", 30*24*time.Hour, true),
+				vmNew,
+			}
+		})
+
+		getToDelete := func(policy string, diff int) []string {
+			fn, err := getDeletePriorityFunc(&vmopv1.VirtualMachineReplicaSet{
+				Spec: vmopv1.VirtualMachineReplicaSetSpec{DeletePolicy: policy},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			return names(getMachinesToDeletePrioritized(vms, diff, fn))
+		}
+
+		Context("getDeletePriorityFunc", func() {
+			It("returns an error for an unsupported policy", func() {
+				_, err := getDeletePriorityFunc(&vmopv1.VirtualMachineReplicaSet{
+					Spec: vmopv1.VirtualMachineReplicaSetSpec{DeletePolicy: "Unknown"},
+				})
+				Expect(err).To(MatchError(`unsupported delete policy "Unknown"`))
+			})
+		})
+
+		Context("Oldest", func() {
+			It("deletes the oldest VMs first", func() {
+				Expect(getToDelete(vmopv1.OldestVirtualMachineReplicaSetDeletePolicy, 2)).To(
+					Equal([]string{"vm-old", "vm-middle"}))
+			})
+		})
+
+		Context("Newest", func() {
+			It("deletes the newest VMs first", func() {
+				Expect(getToDelete(vmopv1.NewestVirtualMachineReplicaSetDeletePolicy, 2)).To(
+					Equal([]string{"vm-new", "vm-middle"}))
+			})
+		})
+
+		Context("Random", func() {
+			It("deletes VMs in a stable order", func() {
+				Expect(getToDelete("", 2)).To(Equal([]string{"vm-middle", "vm-new"}))
+			})
+		})
+
+		DescribeTable("prioritized VMs",
+			func(policy string) {
+				By("deleting a VM that is not ready first", func() {
+					vms = append(vms, newVM("vm-not-ready", 48*time.Hour, false))
+					Expect(getToDelete(policy, 1)).To(Equal([]string{"vm-not-ready"}))
+				})
+
+				By("deleting a VM with the delete-priority annotation before a VM that is not ready", func() {
+					vmMiddle.Annotations = map[string]string{
+						vmopv1.VirtualMachineReplicaSetDeletePriorityAnnotation: "",
+					}
+					Expect(getToDelete(policy, 2)).To(Equal([]string{"vm-middle", "vm-not-ready"}))
+				})
+
+				By("deleting a VM that is already being deleted before all others", func() {
+					vmNew.DeletionTimestamp = &metav1.Time{Time: now}
+					Expect(getToDelete(policy, 1)).To(Equal([]string{"vm-new"}))
+				})
+			},
+			Entry("Random", vmopv1.RandomVirtualMachineReplicaSetDeletePolicy),
+			Entry("Newest", vmopv1.NewestVirtualMachineReplicaSetDeletePolicy),
+			Entry("Oldest", vmopv1.OldestVirtualMachineReplicaSetDeletePolicy),
+		)
+
+		Context("getMachinesToDeletePrioritized", func() {
+			It("returns all VMs when diff is at least the number of VMs", func() {
+				Expect(getToDelete("", 5)).To(HaveLen(3))
+			})
+
+			It("returns no VMs when diff is not positive", func() {
+				Expect(getToDelete("", 0)).To(BeEmpty())
+			})
+		})
+	})
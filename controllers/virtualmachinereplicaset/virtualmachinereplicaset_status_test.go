@@ -0,0 +1,150 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinereplicaset
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+var _ = Describe(
+	"updateStatus",
+	Label(testlabels.Controller, testlabels.V1Alpha3),
+	func() {
+		var (
+			reconciler *Reconciler
+			rs         *vmopv1.VirtualMachineReplicaSet
+			rsCtx      *pkgctx.VirtualMachineReplicaSetContext
+			vms        []*vmopv1.VirtualMachine
+		)
+
+		newVM := func(name string) *vmopv1.VirtualMachine {
+			return &vmopv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: map[string]string{"app": "dummy"},
+				},
+			}
+		}
+
+		// A VM without a readiness probe is ready once it is created and
+		// powered on.
+		readyVM := func(name string) *vmopv1.VirtualMachine {
+			vm := newVM(name)
+			conditions.MarkTrue(vm, vmopv1.VirtualMachineConditionCreated)
+			vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+			return vm
+		}
+
+		BeforeEach(func() {
+			reconciler = &Reconciler{}
+			rs = &vmopv1.VirtualMachineReplicaSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "dummy-rs",
+					Generation: 2,
+				},
+				Spec: vmopv1.VirtualMachineReplicaSetSpec{
+					Replicas: ptr.To(int32(4)),
+					Template: vmopv1.VirtualMachineTemplateSpec{
+						ObjectMeta: vmopv1common.ObjectMeta{
+							Labels: map[string]string{"app": "dummy"},
+						},
+					},
+				},
+			}
+			rsCtx = &pkgctx.VirtualMachineReplicaSetContext{
+				Context:    context.Background(),
+				Logger:     logr.Discard(),
+				ReplicaSet: rs,
+			}
+		})
+
+		JustBeforeEach(func() {
+			reconciler.updateStatus(rsCtx, rs, vms)
+		})
+
+		When("some of the replicas are not ready", func() {
+			BeforeEach(func() {
+				poweredOffVM := readyVM("vm-powered-off")
+				poweredOffVM.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+
+				probeReadyVM := readyVM("vm-probe-ready")
+				probeReadyVM.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{}
+				conditions.MarkTrue(probeReadyVM, vmopv1.ReadyConditionType)
+
+				probeNotReadyVM := readyVM("vm-probe-not-ready")
+				probeNotReadyVM.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{}
+				conditions.MarkFalse(probeNotReadyVM, vmopv1.ReadyConditionType, "NotReady", "")
+
+				vms = []*vmopv1.VirtualMachine{
+					readyVM("vm-ready"),
+					poweredOffVM,
+					probeReadyVM,
+					probeNotReadyVM,
+				}
+			})
+
+			It("only counts the ready replicas", func() {
+				Expect(rs.Status.Replicas).To(Equal(int32(4)))
+				Expect(rs.Status.FullyLabeledReplicas).To(Equal(int32(4)))
+				Expect(rs.Status.ReadyReplicas).To(Equal(int32(2)))
+				Expect(rs.Status.ObservedGeneration).To(Equal(int64(2)))
+			})
+
+			It("does not mark the replica set resized", func() {
+				Expect(conditions.IsTrue(rs, vmopv1.ResizedCondition)).To(BeFalse())
+				Expect(conditions.IsTrue(rs, vmopv1.VirtualMachinesCreatedCondition)).To(BeTrue())
+			})
+		})
+
+		When("a ready replica is being deleted", func() {
+			BeforeEach(func() {
+				deletingVM := readyVM("vm-deleting")
+				deletingVM.DeletionTimestamp = ptr.To(metav1.Now())
+
+				vms = []*vmopv1.VirtualMachine{
+					readyVM("vm-ready-1"),
+					readyVM("vm-ready-2"),
+					readyVM("vm-ready-3"),
+					deletingVM,
+				}
+			})
+
+			It("does not count the replica as ready", func() {
+				Expect(rs.Status.Replicas).To(Equal(int32(4)))
+				Expect(rs.Status.ReadyReplicas).To(Equal(int32(3)))
+				Expect(conditions.IsTrue(rs, vmopv1.ResizedCondition)).To(BeFalse())
+			})
+		})
+
+		When("all of the replicas are ready", func() {
+			BeforeEach(func() {
+				vms = []*vmopv1.VirtualMachine{
+					readyVM("vm-ready-1"),
+					readyVM("vm-ready-2"),
+					readyVM("vm-ready-3"),
+					readyVM("vm-ready-4"),
+				}
+			})
+
+			It("marks the replica set resized", func() {
+				Expect(rs.Status.Replicas).To(Equal(int32(4)))
+				Expect(rs.Status.ReadyReplicas).To(Equal(int32(4)))
+				Expect(conditions.IsTrue(rs, vmopv1.ResizedCondition)).To(BeTrue())
+				Expect(conditions.IsTrue(rs, vmopv1.VirtualMachinesCreatedCondition)).To(BeTrue())
+			})
+		})
+	})
@@ -0,0 +1,243 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinereplicaset
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+)
+
+// getSpreadZones returns the zones, in order of preference, across which the
+// replicas of the replica set are spread. Nil is returned if the replica set
+// does not specify a topology spread.
+func (r *Reconciler) getSpreadZones(
+	ctx *pkgctx.VirtualMachineReplicaSetContext,
+	rs *vmopv1.VirtualMachineReplicaSet) ([]string, error) {
+
+	if rs.Spec.TopologySpread == nil {
+		return nil, nil
+	}
+
+	if len(rs.Spec.TopologySpread.Zones) > 0 {
+		return rs.Spec.TopologySpread.Zones, nil
+	}
+
+	var zoneNames []string
+
+	if pkgcfg.FromContext(ctx).Features.WorkloadDomainIsolation {
+		zones, err := topology.GetZones(ctx, r.Client, rs.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get zones for topology spread: %w", err)
+		}
+		for _, zone := range zones {
+			// Do not place new replicas in a zone that is being deleted.
+			if zone.DeletionTimestamp.IsZero() {
+				zoneNames = append(zoneNames, zone.Name)
+			}
+		}
+	} else {
+		azs, err := topology.GetAvailabilityZones(ctx, r.Client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get availability zones for topology spread: %w", err)
+		}
+		for _, az := range azs {
+			if _, ok := az.Spec.Namespaces[rs.Namespace]; ok {
+				zoneNames = append(zoneNames, az.Name)
+			}
+		}
+	}
+
+	if len(zoneNames) == 0 {
+		return nil, fmt.Errorf("no zones available for topology spread in namespace %s", rs.Namespace)
+	}
+
+	sort.Strings(zoneNames)
+
+	return zoneNames, nil
+}
+
+// getMaxSkew returns the maximum permitted skew of the topology spread.
+func getMaxSkew(rs *vmopv1.VirtualMachineReplicaSet) int {
+	if rs.Spec.TopologySpread == nil || rs.Spec.TopologySpread.MaxSkew < 1 {
+		return 1
+	}
+	return int(rs.Spec.TopologySpread.MaxSkew)
+}
+
+// getZoneCounts returns the number of replicas, that are not being deleted, in
+// each of the zones.
+func getZoneCounts(vms []*vmopv1.VirtualMachine, zones []string) map[string]int {
+	counts := make(map[string]int, len(zones))
+	for _, zone := range zones {
+		counts[zone] = 0
+	}
+
+	for _, vm := range vms {
+		if !vm.DeletionTimestamp.IsZero() {
+			continue
+		}
+		zone := vm.Labels[topology.KubernetesTopologyZoneLabelKey]
+		if _, ok := counts[zone]; ok {
+			counts[zone]++
+		}
+	}
+
+	return counts
+}
+
+// getSkew returns the difference between the number of replicas in the most
+// and least populated zones.
+func getSkew(counts map[string]int) int {
+	first := true
+	minCount, maxCount := 0, 0
+	for _, c := range counts {
+		if first || c < minCount {
+			minCount = c
+		}
+		if first || c > maxCount {
+			maxCount = c
+		}
+		first = false
+	}
+	return maxCount - minCount
+}
+
+// getZoneForNewReplica returns the most preferred zone in which a new replica
+// can be placed without exceeding maxSkew. If there is no such zone, which is
+// possible when the replicas are already skewed beyond maxSkew, the least
+// populated zone is returned.
+func getZoneForNewReplica(zones []string, counts map[string]int, maxSkew int) string {
+	for _, zone := range zones {
+		counts[zone]++
+		skew := getSkew(counts)
+		counts[zone]--
+
+		if skew <= maxSkew {
+			return zone
+		}
+	}
+
+	var result string
+	for _, zone := range zones {
+		if result == "" || counts[zone] < counts[result] {
+			result = zone
+		}
+	}
+	return result
+}
+
+// getZoneForDeletion returns the least preferred of the zones from which a
+// replica can be removed without exceeding maxSkew across all the zones in
+// counts. If there is no such zone, the most populated of the zones is
+// returned.
+func getZoneForDeletion(zones []string, counts map[string]int, maxSkew int) string {
+	for i := len(zones) - 1; i >= 0; i-- {
+		zone := zones[i]
+		if counts[zone] == 0 {
+			continue
+		}
+
+		counts[zone]--
+		skew := getSkew(counts)
+		counts[zone]++
+
+		if skew <= maxSkew {
+			return zone
+		}
+	}
+
+	var result string
+	for i := len(zones) - 1; i >= 0; i-- {
+		zone := zones[i]
+		if result == "" || counts[zone] > counts[result] {
+			result = zone
+		}
+	}
+	return result
+}
+
+// getMachinesToDeleteWithZoneSpread returns the diff VMs to delete. The VMs
+// are chosen in order of their base delete priority, i.e. VMs that are being
+// deleted, then VMs annotated for deletion, then VMs that are not ready, and
+// finally all other VMs. Among the VMs with the same base priority, VMs that
+// are not in one of the zones are chosen first, and then VMs are chosen so the
+// spread across the zones does not exceed maxSkew, using the delete priority
+// within a zone.
+func getMachinesToDeleteWithZoneSpread(
+	vms []*vmopv1.VirtualMachine,
+	diff int,
+	fun deletePriorityFunc,
+	zones []string,
+	maxSkew int) []*vmopv1.VirtualMachine {
+
+	if diff >= len(vms) {
+		return vms
+	} else if diff <= 0 {
+		return []*vmopv1.VirtualMachine{}
+	}
+
+	remaining := slices.Clone(vms)
+	sort.Sort(sortableMachines{
+		machines: remaining,
+		priority: fun,
+	})
+
+	counts := getZoneCounts(remaining, zones)
+	getZone := func(vm *vmopv1.VirtualMachine) string {
+		return vm.Labels[topology.KubernetesTopologyZoneLabelKey]
+	}
+	inZones := func(vm *vmopv1.VirtualMachine) bool {
+		_, ok := counts[getZone(vm)]
+		return ok
+	}
+
+	toDelete := make([]*vmopv1.VirtualMachine, 0, diff)
+	for len(toDelete) < diff {
+		idx := 0
+
+		// The remaining VMs are sorted by delete priority, so the first VM has
+		// the highest base priority.
+		if top, _ := basePriority(remaining[0]); top != mustDelete {
+			isCandidate := func(vm *vmopv1.VirtualMachine) bool {
+				p, _ := basePriority(vm)
+				return p == top
+			}
+
+			idx = slices.IndexFunc(remaining, func(vm *vmopv1.VirtualMachine) bool {
+				return isCandidate(vm) && !inZones(vm)
+			})
+			if idx < 0 {
+				candidateZones := make([]string, 0, len(zones))
+				for _, zone := range zones {
+					if slices.ContainsFunc(remaining, func(vm *vmopv1.VirtualMachine) bool {
+						return isCandidate(vm) && getZone(vm) == zone
+					}) {
+						candidateZones = append(candidateZones, zone)
+					}
+				}
+
+				zone := getZoneForDeletion(candidateZones, counts, maxSkew)
+				idx = slices.IndexFunc(remaining, func(vm *vmopv1.VirtualMachine) bool {
+					return isCandidate(vm) && getZone(vm) == zone
+				})
+			}
+		}
+
+		vm := remaining[idx]
+		if vm.DeletionTimestamp.IsZero() && inZones(vm) {
+			counts[getZone(vm)]--
+		}
+
+		toDelete = append(toDelete, vm)
+		remaining = slices.Delete(remaining, idx, idx+1)
+	}
+
+	return toDelete
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinereplicaset
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+)
+
+var _ = Describe(
+	"Zone spread",
+	Label(testlabels.Controller, testlabels.V1Alpha3),
+	func() {
+		zones := []string{"zone-a", "zone-b", "zone-c"}
+
+		newVM := func(name, zone string, ready bool) *vmopv1.VirtualMachine {
+			vm := &vmopv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					Labels: map[string]string{
+						topology.KubernetesTopologyZoneLabelKey: zone,
+					},
+				},
+			}
+			if ready {
+				conditions.MarkTrue(vm, vmopv1.VirtualMachineConditionCreated)
+				vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+			}
+			return vm
+		}
+
+		Context("getZoneForNewReplica", func() {
+			DescribeTable("chooses a zone",
+				func(counts map[string]int, maxSkew int, expected string) {
+					Expect(getZoneForNewReplica(zones, counts, maxSkew)).To(Equal(expected))
+				},
+				Entry("most preferred zone when balanced",
+					map[string]int{"zone-a": 0, "zone-b": 0, "zone-c": 0}, 1, "zone-a"),
+				Entry("next preferred zone when max skew would be exceeded",
+					map[string]int{"zone-a": 1, "zone-b": 0, "zone-c": 0}, 1, "zone-b"),
+				Entry("most preferred zone when max skew allows it",
+					map[string]int{"zone-a": 1, "zone-b": 0, "zone-c": 0}, 2, "zone-a"),
+				Entry("least populated zone when already skewed",
+					map[string]int{"zone-a": 5, "zone-b": 2, "zone-c": 0}, 1, "zone-c"),
+			)
+		})
+
+		Context("getZoneForDeletion", func() {
+			DescribeTable("chooses a zone",
+				func(counts map[string]int, maxSkew int, expected string) {
+					Expect(getZoneForDeletion(zones, counts, maxSkew)).To(Equal(expected))
+				},
+				Entry("least preferred zone when balanced",
+					map[string]int{"zone-a": 1, "zone-b": 1, "zone-c": 1}, 1, "zone-c"),
+				Entry("next least preferred zone when max skew would be exceeded",
+					map[string]int{"zone-a": 2, "zone-b": 2, "zone-c": 1}, 1, "zone-b"),
+				Entry("most populated zone when already skewed",
+					map[string]int{"zone-a": 5, "zone-b": 1, "zone-c": 1}, 1, "zone-a"),
+			)
+		})
+
+		Context("getMachinesToDeleteWithZoneSpread", func() {
+			var vms []*vmopv1.VirtualMachine
+
+			BeforeEach(func() {
+				vms = []*vmopv1.VirtualMachine{
+					newVM("vm-a1", "zone-a", true),
+					newVM("vm-a2", "zone-a", true),
+					newVM("vm-b1", "zone-b", true),
+					newVM("vm-b2", "zone-b", true),
+					newVM("vm-c1", "zone-c", true),
+				}
+			})
+
+			getToDelete := func(diff int) []string {
+				var n []string
+				for _, vm := range getMachinesToDeleteWithZoneSpread(vms, diff, randomDeletePolicy, zones, 1) {
+					n = append(n, vm.Name)
+				}
+				return n
+			}
+
+			It("keeps the replicas spread across the zones", func() {
+				Expect(getToDelete(2)).To(Equal([]string{"vm-b1", "vm-a1"}))
+			})
+
+			It("deletes replicas that are not ready first", func() {
+				vms[4] = newVM("vm-c1", "zone-c", false)
+				Expect(getToDelete(1)).To(Equal([]string{"vm-c1"}))
+			})
+
+			It("deletes replicas outside of the zones first", func() {
+				vms = append(vms, newVM("vm-d1", "zone-d", true))
+				Expect(getToDelete(1)).To(Equal([]string{"vm-d1"}))
+			})
+
+			It("deletes replicas that are being deleted first", func() {
+				vms[0].DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+				Expect(getToDelete(2)).To(Equal([]string{"vm-a1", "vm-b1"}))
+			})
+		})
+	})
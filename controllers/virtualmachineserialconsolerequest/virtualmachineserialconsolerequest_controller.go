@@ -0,0 +1,248 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineserialconsolerequest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+)
+
+const (
+	DefaultExpiryTime = time.Second * 120
+	UUIDLabelKey      = "vmoperator.vmware.com/serialconsolerequest-uuid"
+
+	// SerialPortRequeueDelay is how often a request is reconciled while the
+	// VM does not yet have a serial port connected to the serial port
+	// concentrator.
+	SerialPortRequeueDelay = time.Second * 30
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineSerialConsoleRequest{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProvider,
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{MaxConcurrentReconciles: 1}).
+		Complete(r)
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider providers.VirtualMachineProviderInterface) *Reconciler {
+	return &Reconciler{
+		Context:    ctx,
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineSerialConsoleRequest object.
+type Reconciler struct {
+	client.Client
+	Context    context.Context
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider providers.VirtualMachineProviderInterface
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineserialconsolerequests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineserialconsolerequests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;patch
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	scr := &vmopv1.VirtualMachineSerialConsoleRequest{}
+	if err := r.Get(ctx, req.NamespacedName, scr); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	scrCtx := &pkgctx.VirtualMachineSerialConsoleRequestContext{
+		Context:              ctx,
+		Logger:               ctrl.Log.WithName("VirtualMachineSerialConsoleRequest").WithValues("name", req.NamespacedName),
+		SerialConsoleRequest: scr,
+		VM:                   &vmopv1.VirtualMachine{},
+	}
+
+	done, err := r.ReconcileEarlyNormal(scrCtx)
+	if err != nil {
+		scrCtx.Logger.Error(err, "failed to expire VirtualMachineSerialConsoleRequest")
+		return ctrl.Result{}, err
+	}
+	if done {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(scr, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", scrCtx, err)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, scr); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			scrCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	err = r.Get(ctx, client.ObjectKey{Name: scr.Spec.Name, Namespace: scr.Namespace}, scrCtx.VM)
+	if err != nil {
+		r.Recorder.Warn(scr, "VirtualMachine Not Found", "")
+		return ctrl.Result{}, fmt.Errorf("failed to get subject vm %s: %w", scr.Spec.Name, err)
+	}
+
+	if err := r.ReconcileNormal(scrCtx); err != nil {
+		scrCtx.Logger.Error(err, "failed to reconcile VirtualMachineSerialConsoleRequest")
+		return ctrl.Result{}, err
+	}
+
+	if c := conditions.Get(scr, vmopv1.ReadyConditionType); c != nil && c.Reason == vmopv1.SerialPortNotReadyReason {
+		return ctrl.Result{RequeueAfter: SerialPortRequeueDelay}, nil
+	}
+
+	if expiryTime := scr.Status.ExpiryTime; !expiryTime.IsZero() {
+		return ctrl.Result{RequeueAfter: time.Until(expiryTime.Time)}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) ReconcileEarlyNormal(ctx *pkgctx.VirtualMachineSerialConsoleRequestContext) (bool, error) {
+	expiryTime := ctx.SerialConsoleRequest.Status.ExpiryTime
+	nowTime := metav1.Now()
+	if !expiryTime.IsZero() && !nowTime.Before(&expiryTime) {
+		err := r.Delete(ctx, ctx.SerialConsoleRequest)
+		if client.IgnoreNotFound(err) != nil {
+			return false, fmt.Errorf("failed to delete serialconsolerequest: %w", err)
+		}
+		r.Recorder.Eventf(ctx.SerialConsoleRequest, "Expired",
+			"Serial console request for VirtualMachine %s expired", ctx.SerialConsoleRequest.Spec.Name)
+		ctx.Logger.Info("Deleted expired VirtualMachineSerialConsoleRequest")
+		return true, nil
+	}
+
+	if ctx.SerialConsoleRequest.Status.Response != "" {
+		// If the response is already set, no need to reconcile anymore.
+		ctx.Logger.V(4).Info("Response already set, skip reconciling")
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineSerialConsoleRequestContext) error {
+	ctx.Logger.Info("Reconciling VirtualMachineSerialConsoleRequest")
+	defer func() {
+		ctx.Logger.Info("Finished reconciling VirtualMachineSerialConsoleRequest")
+	}()
+
+	scr := ctx.SerialConsoleRequest
+
+	if pkgcfg.FromContext(ctx).SerialConsoleProxyURI == "" {
+		conditions.MarkFalse(
+			scr,
+			vmopv1.ReadyConditionType,
+			vmopv1.SerialConsoleNotSupportedReason,
+			"The serial port concentrator is not configured")
+		return nil
+	}
+
+	r.ReconcileOwnerReferences(ctx)
+
+	// Add UUID as a Label to the request. This is used when validating the
+	// connection request from the serial port concentrator.
+	if scr.Labels == nil {
+		scr.Labels = make(map[string]string)
+	}
+	scr.Labels[UUIDLabelKey] = string(scr.UID)
+
+	// Serial ports cannot be hot-added, so the annotation results in the VM's
+	// serial port being added the next time the VM is powered on.
+	if _, ok := ctx.VM.Annotations[vmopv1.SerialConsoleAnnotation]; !ok {
+		vmPatch := client.MergeFrom(ctx.VM.DeepCopy())
+		if ctx.VM.Annotations == nil {
+			ctx.VM.Annotations = make(map[string]string)
+		}
+		ctx.VM.Annotations[vmopv1.SerialConsoleAnnotation] = ""
+		if err := r.Patch(ctx, ctx.VM, vmPatch); err != nil {
+			return fmt.Errorf("failed to annotate vm %s: %w", ctx.VM.Name, err)
+		}
+	}
+
+	ticket, err := r.VMProvider.GetVirtualMachineSerialConsoleTicket(ctx, ctx.VM, scr.Spec.PublicKey, string(scr.UID))
+	if err != nil {
+		return fmt.Errorf("failed to get serial console ticket: %w", err)
+	}
+
+	if ticket == "" {
+		conditions.MarkFalse(
+			scr,
+			vmopv1.ReadyConditionType,
+			vmopv1.SerialPortNotReadyReason,
+			"The serial port is added the next time the VM is powered on")
+		return nil
+	}
+
+	r.Recorder.EmitEvent(scr, "Acquired Ticket", nil, false)
+
+	scr.Status.Response = ticket
+	scr.Status.ExpiryTime = metav1.NewTime(metav1.Now().Add(DefaultExpiryTime))
+	conditions.MarkTrue(scr, vmopv1.ReadyConditionType)
+
+	ctx.Logger.Info("Acquired serial console ticket", "expiryTime", scr.Status.ExpiryTime)
+
+	return nil
+}
+
+func (r *Reconciler) ReconcileOwnerReferences(ctx *pkgctx.VirtualMachineSerialConsoleRequestContext) {
+	isController := true
+	ownerRef := metav1.OwnerReference{
+		APIVersion: vmopv1.GroupVersion.String(),
+		Kind:       "VirtualMachine",
+		Name:       ctx.VM.Name,
+		UID:        ctx.VM.UID,
+		Controller: &isController,
+	}
+
+	ctx.SerialConsoleRequest.SetOwnerReferences([]metav1.OwnerReference{ownerRef})
+}
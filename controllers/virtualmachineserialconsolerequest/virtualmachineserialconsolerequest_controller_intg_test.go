@@ -0,0 +1,139 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineserialconsolerequest_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineserialconsolerequest"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.V1Alpha3,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	const ticket = "some-fake-serialconsoleticket"
+
+	var (
+		ctx       *builder.IntegrationTestContext
+		scr       *vmopv1.VirtualMachineSerialConsoleRequest
+		vm        *vmopv1.VirtualMachine
+		portReady bool
+	)
+
+	getSerialConsoleRequest := func() *vmopv1.VirtualMachineSerialConsoleRequest {
+		obj := &vmopv1.VirtualMachineSerialConsoleRequest{}
+		if err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(scr), obj); err != nil {
+			return nil
+		}
+		return obj
+	}
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: ctx.Namespace,
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ImageName:  "dummy-image",
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+			},
+		}
+
+		_, publicKeyPem := builder.WebConsoleRequestKeyPair()
+		scr = builder.DummyVirtualMachineSerialConsoleRequest("dummy-scr", ctx.Namespace, vm.Name, publicKeyPem)
+
+		intgFakeVMProvider.Lock()
+		defer intgFakeVMProvider.Unlock()
+		portReady = false
+		intgFakeVMProvider.GetVirtualMachineSerialConsoleTicketFn = func(
+			_ context.Context, vm *vmopv1.VirtualMachine, _, _ string) (string, error) {
+			// The serial port is added once the VM has the annotation and
+			// is powered on again.
+			if _, ok := vm.Annotations[vmopv1.SerialConsoleAnnotation]; !ok || !portReady {
+				return "", nil
+			}
+			return ticket, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+		Expect(ctx.Client.Create(ctx, scr)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		err := ctx.Client.Delete(ctx, scr)
+		Expect(err == nil || apierrors.IsNotFound(err)).To(BeTrue())
+		err = ctx.Client.Delete(ctx, vm)
+		Expect(err == nil || apierrors.IsNotFound(err)).To(BeTrue())
+
+		ctx.AfterEach()
+		ctx = nil
+		intgFakeVMProvider.Reset()
+	})
+
+	It("annotates the VM and waits for the serial port", func() {
+		Eventually(func(g Gomega) {
+			obj := getSerialConsoleRequest()
+			g.Expect(obj).ToNot(BeNil())
+			c := conditions.Get(obj, vmopv1.ReadyConditionType)
+			g.Expect(c).ToNot(BeNil())
+			g.Expect(c.Status).To(Equal(metav1.ConditionFalse))
+			g.Expect(c.Reason).To(Equal(vmopv1.SerialPortNotReadyReason))
+		}).Should(Succeed(), "waiting for request to be not ready")
+
+		Eventually(func(g Gomega) {
+			obj := &vmopv1.VirtualMachine{}
+			g.Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), obj)).To(Succeed())
+			g.Expect(obj.Annotations).To(HaveKey(vmopv1.SerialConsoleAnnotation))
+		}).Should(Succeed(), "waiting for VM to be annotated")
+	})
+
+	When("the VM has the serial port", func() {
+		BeforeEach(func() {
+			intgFakeVMProvider.Lock()
+			defer intgFakeVMProvider.Unlock()
+			portReady = true
+		})
+
+		It("sets the response", func() {
+			var obj *vmopv1.VirtualMachineSerialConsoleRequest
+			Eventually(func(g Gomega) {
+				obj = getSerialConsoleRequest()
+				g.Expect(obj).ToNot(BeNil())
+				g.Expect(obj.Status.Response).ToNot(BeEmpty())
+			}).Should(Succeed(), "waiting for response to be set")
+
+			Expect(obj.Status.Response).To(Equal(ticket))
+			Expect(obj.Status.ExpiryTime.Time).To(BeTemporally("~", time.Now(), virtualmachineserialconsolerequest.DefaultExpiryTime))
+			Expect(obj.Labels).To(HaveKeyWithValue(virtualmachineserialconsolerequest.UUIDLabelKey, string(obj.UID)))
+			Expect(conditions.IsTrue(obj, vmopv1.ReadyConditionType)).To(BeTrue())
+		})
+	})
+}
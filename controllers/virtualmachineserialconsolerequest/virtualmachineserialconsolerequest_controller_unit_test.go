@@ -0,0 +1,228 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineserialconsolerequest_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineserialconsolerequest"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.V1Alpha3,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	const ticket = "my-fake-serialconsoleticket"
+
+	var (
+		initObjects    []client.Object
+		ctx            *builder.UnitTestContextForController
+		fakeVMProvider *providerfake.VMProvider
+		proxyURI       string
+
+		reconciler *virtualmachineserialconsolerequest.Reconciler
+		scrCtx     *pkgctx.VirtualMachineSerialConsoleRequestContext
+		scr        *vmopv1.VirtualMachineSerialConsoleRequest
+		vm         *vmopv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		proxyURI = serialConsoleProxyURI
+
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+				UID:       types.UID("dummy-vm-uid"),
+			},
+		}
+
+		scr = builder.DummyVirtualMachineSerialConsoleRequest("dummy-scr", vm.Namespace, vm.Name, "dummy-public-key")
+		scr.UID = types.UID("dummy-scr-uid")
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+			config.SerialConsoleProxyURI = proxyURI
+		})
+
+		reconciler = virtualmachineserialconsolerequest.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProvider,
+		)
+		fakeVMProvider = ctx.VMProvider.(*providerfake.VMProvider)
+
+		scrCtx = &pkgctx.VirtualMachineSerialConsoleRequestContext{
+			Context:              ctx,
+			Logger:               ctx.Logger.WithName(scr.Name),
+			SerialConsoleRequest: scr,
+			VM:                   vm,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+		fakeVMProvider.Reset()
+	})
+
+	Context("ReconcileNormal", func() {
+		BeforeEach(func() {
+			initObjects = append(initObjects, scr, vm)
+		})
+
+		When("the VM has a serial port connected to the concentrator", func() {
+			var uuid string
+
+			JustBeforeEach(func() {
+				fakeVMProvider.GetVirtualMachineSerialConsoleTicketFn = func(
+					_ context.Context, _ *vmopv1.VirtualMachine, _, u string) (string, error) {
+					uuid = u
+					return ticket, nil
+				}
+			})
+
+			It("sets the response and marks the request ready", func() {
+				Expect(reconciler.ReconcileNormal(scrCtx)).To(Succeed())
+
+				Expect(uuid).To(Equal(string(scr.UID)))
+				Expect(scr.Status.Response).To(Equal(ticket))
+				Expect(scr.Status.ExpiryTime.Time).To(BeTemporally("~", time.Now(), virtualmachineserialconsolerequest.DefaultExpiryTime))
+				Expect(scr.Labels).To(HaveKeyWithValue(virtualmachineserialconsolerequest.UUIDLabelKey, string(scr.UID)))
+				Expect(scr.OwnerReferences).To(HaveLen(1))
+				Expect(scr.OwnerReferences[0].UID).To(Equal(vm.UID))
+				Expect(conditions.IsTrue(scr, vmopv1.ReadyConditionType)).To(BeTrue())
+
+				updatedVM := &vmopv1.VirtualMachine{}
+				Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), updatedVM)).To(Succeed())
+				Expect(updatedVM.Annotations).To(HaveKey(vmopv1.SerialConsoleAnnotation))
+			})
+		})
+
+		When("the VM does not have a serial port connected to the concentrator", func() {
+			It("marks the serial port not ready", func() {
+				Expect(reconciler.ReconcileNormal(scrCtx)).To(Succeed())
+
+				Expect(scr.Status.Response).To(BeEmpty())
+				Expect(scr.Status.ExpiryTime.IsZero()).To(BeTrue())
+				c := conditions.Get(scr, vmopv1.ReadyConditionType)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.SerialPortNotReadyReason))
+
+				updatedVM := &vmopv1.VirtualMachine{}
+				Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), updatedVM)).To(Succeed())
+				Expect(updatedVM.Annotations).To(HaveKey(vmopv1.SerialConsoleAnnotation))
+			})
+		})
+
+		When("getting the ticket fails", func() {
+			JustBeforeEach(func() {
+				fakeVMProvider.GetVirtualMachineSerialConsoleTicketFn = func(
+					_ context.Context, _ *vmopv1.VirtualMachine, _, _ string) (string, error) {
+					return "", errors.New("fake error")
+				}
+			})
+
+			It("returns an error", func() {
+				err := reconciler.ReconcileNormal(scrCtx)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake error"))
+				Expect(scr.Status.Response).To(BeEmpty())
+			})
+		})
+
+		When("the serial port concentrator is not configured", func() {
+			BeforeEach(func() {
+				proxyURI = ""
+			})
+
+			It("marks the serial console not supported", func() {
+				Expect(reconciler.ReconcileNormal(scrCtx)).To(Succeed())
+
+				c := conditions.Get(scr, vmopv1.ReadyConditionType)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.SerialConsoleNotSupportedReason))
+
+				updatedVM := &vmopv1.VirtualMachine{}
+				Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), updatedVM)).To(Succeed())
+				Expect(updatedVM.Annotations).ToNot(HaveKey(vmopv1.SerialConsoleAnnotation))
+			})
+		})
+	})
+
+	Context("ReconcileEarlyNormal", func() {
+		BeforeEach(func() {
+			initObjects = append(initObjects, scr)
+		})
+
+		When("the request has expired", func() {
+			BeforeEach(func() {
+				scr.Status.ExpiryTime = metav1.NewTime(time.Now().Add(-time.Minute))
+			})
+
+			It("deletes the request", func() {
+				done, err := reconciler.ReconcileEarlyNormal(scrCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(done).To(BeTrue())
+
+				err = ctx.Client.Get(ctx, client.ObjectKeyFromObject(scr), &vmopv1.VirtualMachineSerialConsoleRequest{})
+				Expect(err).To(HaveOccurred())
+				Expect(ctx.Events).To(Receive(ContainSubstring("Expired")))
+			})
+		})
+
+		When("the request has a response", func() {
+			BeforeEach(func() {
+				scr.Status.Response = ticket
+				scr.Status.ExpiryTime = metav1.NewTime(time.Now().Add(time.Minute))
+			})
+
+			It("is done", func() {
+				done, err := reconciler.ReconcileEarlyNormal(scrCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(done).To(BeTrue())
+			})
+		})
+
+		When("the request does not have a response", func() {
+			It("is not done", func() {
+				done, err := reconciler.ReconcileEarlyNormal(scrCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(done).To(BeFalse())
+			})
+		})
+	})
+}
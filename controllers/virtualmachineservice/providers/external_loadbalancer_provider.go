@@ -0,0 +1,270 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package providers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/yaml"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+)
+
+const (
+	// ExternalLoadBalancer is the provider type of a load balancer that is
+	// implemented by a controller outside of VM Operator, ex. AKO, that
+	// reconciles the LoadBalancer Services created for VirtualMachineServices
+	// and reports the ingress addresses in the Services' status.
+	ExternalLoadBalancer = "external"
+
+	// ExternalLoadBalancerConfigMapName is the name of the ConfigMap in the
+	// VM Operator namespace that configures the external load balancer
+	// provider.
+	ExternalLoadBalancerConfigMapName = "vmoperator-loadbalancer-config"
+
+	// ExternalLoadBalancerClassKey is the key in the ConfigMap whose value is
+	// the load balancer class set on the Services when they are created. The
+	// load balancer class of a Service cannot be changed, so when the value
+	// changes, the existing LoadBalancer Services keep their class and a
+	// warning event is emitted for their VirtualMachineServices.
+	ExternalLoadBalancerClassKey = "loadBalancerClass"
+
+	// ExternalLoadBalancerAnnotationsKey is the key in the ConfigMap whose
+	// value is a YAML map of the annotations placed on the Services.
+	ExternalLoadBalancerAnnotationsKey = "annotations"
+
+	// ExternalLoadBalancerLabelsKey is the key in the ConfigMap whose value is
+	// a YAML map of the labels placed on the Services.
+	ExternalLoadBalancerLabelsKey = "labels"
+
+	// ExternalLoadBalancerAppliedAnnotationsAnnotation is the annotation on a
+	// VirtualMachineService, and on its Service, whose value is the
+	// comma-separated keys of the annotations last placed on them from the
+	// ConfigMap. It is used to remove the annotations that are removed from
+	// the ConfigMap.
+	ExternalLoadBalancerAppliedAnnotationsAnnotation = vmopv1.GroupName + "/external-lb-annotations"
+
+	// ExternalLoadBalancerAppliedLabelsAnnotation is the annotation on a
+	// VirtualMachineService, and on its Service, whose value is the
+	// comma-separated keys of the labels last placed on them from the
+	// ConfigMap. It is used to remove the labels that are removed from the
+	// ConfigMap.
+	ExternalLoadBalancerAppliedLabelsAnnotation = vmopv1.GroupName + "/external-lb-labels"
+)
+
+func init() {
+	RegisterLoadbalancerProvider(ExternalLoadBalancer, func(mgr manager.Manager) (LoadbalancerProvider, error) {
+		return NewExternalLoadbalancerProvider(mgr.GetClient(), mgr.GetAPIReader()), nil
+	})
+}
+
+// ExternalLoadbalancerProvider annotates and labels Services for a load
+// balancer controller outside of VM Operator as configured by the
+// ExternalLoadBalancerConfigMapName ConfigMap.
+type ExternalLoadbalancerProvider struct {
+	client ctrlclient.Client
+
+	// apiReader reads the ConfigMap from the API server, since ConfigMaps
+	// are not cached by the manager.
+	apiReader ctrlclient.Reader
+}
+
+var _ LoadBalancerClassProvider = &ExternalLoadbalancerProvider{}
+
+// NewExternalLoadbalancerProvider returns an ExternalLoadbalancerProvider
+// instance.
+func NewExternalLoadbalancerProvider(
+	client ctrlclient.Client,
+	apiReader ctrlclient.Reader) *ExternalLoadbalancerProvider {
+
+	return &ExternalLoadbalancerProvider{
+		client:    client,
+		apiReader: apiReader,
+	}
+}
+
+type externalLoadBalancerConfig struct {
+	loadBalancerClass string
+	annotations       map[string]string
+	labels            map[string]string
+}
+
+type externalLoadBalancerConfigCacheKey struct{}
+
+// getConfig returns the provider's config. The ConfigMap is read once per
+// reconcile when the context has a reconcile cache.
+func (el *ExternalLoadbalancerProvider) getConfig(ctx context.Context) (externalLoadBalancerConfig, error) {
+	cache := reconcileCacheFrom(ctx)
+	if cache != nil {
+		if v, ok := cache.Load(externalLoadBalancerConfigCacheKey{}); ok {
+			return v.(externalLoadBalancerConfig), nil
+		}
+	}
+
+	config, err := el.readConfig(ctx)
+	if err != nil {
+		return config, err
+	}
+
+	if cache != nil {
+		cache.Store(externalLoadBalancerConfigCacheKey{}, config)
+	}
+	return config, nil
+}
+
+// readConfig reads the provider's config from the ConfigMap. An empty config
+// is returned if the ConfigMap does not exist.
+func (el *ExternalLoadbalancerProvider) readConfig(ctx context.Context) (externalLoadBalancerConfig, error) {
+	var config externalLoadBalancerConfig
+
+	configMap := &corev1.ConfigMap{}
+	configMapKey := ctrlclient.ObjectKey{
+		Name:      ExternalLoadBalancerConfigMapName,
+		Namespace: pkgcfg.FromContext(ctx).PodNamespace,
+	}
+	if err := el.apiReader.Get(ctx, configMapKey, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return config, nil
+		}
+		return config, fmt.Errorf("error retrieving the load balancer ConfigMap %s: %w", configMapKey, err)
+	}
+
+	config.loadBalancerClass = configMap.Data[ExternalLoadBalancerClassKey]
+
+	if v := configMap.Data[ExternalLoadBalancerAnnotationsKey]; v != "" {
+		if err := yaml.Unmarshal([]byte(v), &config.annotations); err != nil {
+			return config, fmt.Errorf("invalid %s in load balancer ConfigMap %s: %w",
+				ExternalLoadBalancerAnnotationsKey, configMapKey, err)
+		}
+	}
+
+	if v := configMap.Data[ExternalLoadBalancerLabelsKey]; v != "" {
+		if err := yaml.Unmarshal([]byte(v), &config.labels); err != nil {
+			return config, fmt.Errorf("invalid %s in load balancer ConfigMap %s: %w",
+				ExternalLoadBalancerLabelsKey, configMapKey, err)
+		}
+	}
+
+	return config, nil
+}
+
+// EnsureLoadBalancer validates the provider's config, and removes the
+// annotations and labels that were removed from the ConfigMap from the
+// VirtualMachineService, from which the Service inherits them. The load
+// balancer is created by the external controller when it reconciles the
+// Service.
+func (el *ExternalLoadbalancerProvider) EnsureLoadBalancer(ctx context.Context, vmService *vmopv1.VirtualMachineService) error {
+	config, err := el.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	for k := range staleKeys(vmService.Annotations[ExternalLoadBalancerAppliedAnnotationsAnnotation], config.annotations) {
+		delete(vmService.Annotations, k)
+	}
+	for k := range staleKeys(vmService.Annotations[ExternalLoadBalancerAppliedLabelsAnnotation], config.labels) {
+		delete(vmService.Labels, k)
+	}
+
+	return nil
+}
+
+// GetLoadBalancerClass provides the configured load balancer class for the
+// Service.
+func (el *ExternalLoadbalancerProvider) GetLoadBalancerClass(ctx context.Context, _ *vmopv1.VirtualMachineService) (string, error) {
+	config, err := el.getConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	return config.loadBalancerClass, nil
+}
+
+// GetServiceLabels provides the configured labels on Service. The
+// responsibility is left to the caller to actually set them.
+func (el *ExternalLoadbalancerProvider) GetServiceLabels(ctx context.Context, _ *vmopv1.VirtualMachineService) (map[string]string, error) {
+	config, err := el.getConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return config.labels, nil
+}
+
+// GetToBeRemovedServiceLabels returns the labels that were placed on the
+// Service from the ConfigMap and have since been removed from the ConfigMap.
+func (el *ExternalLoadbalancerProvider) GetToBeRemovedServiceLabels(ctx context.Context, vmService *vmopv1.VirtualMachineService) (map[string]string, error) {
+	return el.getToBeRemoved(ctx, vmService, ExternalLoadBalancerAppliedLabelsAnnotation,
+		func(config externalLoadBalancerConfig) map[string]string { return config.labels })
+}
+
+// GetServiceAnnotations provides the configured annotations on Service, and
+// the annotations that record the keys of the configured annotations and
+// labels. The responsibility is left to the caller to actually set them.
+func (el *ExternalLoadbalancerProvider) GetServiceAnnotations(ctx context.Context, _ *vmopv1.VirtualMachineService) (map[string]string, error) {
+	config, err := el.getConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := make(map[string]string, len(config.annotations)+2)
+	maps.Copy(annotations, config.annotations)
+	annotations[ExternalLoadBalancerAppliedAnnotationsAnnotation] = joinKeys(config.annotations)
+	annotations[ExternalLoadBalancerAppliedLabelsAnnotation] = joinKeys(config.labels)
+
+	return annotations, nil
+}
+
+// GetToBeRemovedServiceAnnotations returns the annotations that were placed
+// on the Service from the ConfigMap and have since been removed from the
+// ConfigMap.
+func (el *ExternalLoadbalancerProvider) GetToBeRemovedServiceAnnotations(ctx context.Context, vmService *vmopv1.VirtualMachineService) (map[string]string, error) {
+	return el.getToBeRemoved(ctx, vmService, ExternalLoadBalancerAppliedAnnotationsAnnotation,
+		func(config externalLoadBalancerConfig) map[string]string { return config.annotations })
+}
+
+// getToBeRemoved returns the keys recorded in the applied annotation of the
+// Service that are not in the configured keys.
+func (el *ExternalLoadbalancerProvider) getToBeRemoved(
+	ctx context.Context,
+	vmService *vmopv1.VirtualMachineService,
+	appliedAnnotation string,
+	configured func(externalLoadBalancerConfig) map[string]string) (map[string]string, error) {
+
+	config, err := el.getConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	service := &corev1.Service{}
+	if err := el.client.Get(ctx, ctrlclient.ObjectKeyFromObject(vmService), service); err != nil {
+		return nil, ctrlclient.IgnoreNotFound(err)
+	}
+
+	return staleKeys(service.Annotations[appliedAnnotation], configured(config)), nil
+}
+
+// staleKeys returns the keys in the comma-separated applied keys that are not
+// in the configured map.
+func staleKeys(applied string, configured map[string]string) map[string]string {
+	stale := map[string]string{}
+	for _, k := range strings.Split(applied, ",") {
+		if _, ok := configured[k]; k != "" && !ok {
+			stale[k] = ""
+		}
+	}
+	return stale
+}
+
+// joinKeys returns the sorted, comma-separated keys of the map.
+func joinKeys(m map[string]string) string {
+	return strings.Join(slices.Sorted(maps.Keys(m)), ",")
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package providers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe(
+	"External Loadbalancer Provider",
+	Label(testlabels.Controller, testlabels.V1Alpha3),
+	func() {
+		const podNamespace = "vmop-system"
+
+		var (
+			ctx        context.Context
+			initObjs   []ctrlclient.Object
+			configMap  *corev1.ConfigMap
+			vmService  *vmopv1.VirtualMachineService
+			lbProvider *ExternalLoadbalancerProvider
+		)
+
+		BeforeEach(func() {
+			ctx = pkgcfg.UpdateContext(pkgcfg.NewContextWithDefaultConfig(), func(config *pkgcfg.Config) {
+				config.PodNamespace = podNamespace
+			})
+
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ExternalLoadBalancerConfigMapName,
+					Namespace: podNamespace,
+				},
+				Data: map[string]string{
+					ExternalLoadBalancerClassKey:       "ako.vmware.com/avi-lb",
+					ExternalLoadBalancerAnnotationsKey: "ako.vmware.com/enable-shared-vip: my-vip\n",
+					ExternalLoadBalancerLabelsKey:      "my-label: my-value\n",
+				},
+			}
+			initObjs = []ctrlclient.Object{configMap}
+
+			vmService = &vmopv1.VirtualMachineService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dummy-vmservice",
+					Namespace: dummyNamespace,
+				},
+				Spec: vmopv1.VirtualMachineServiceSpec{
+					Type: vmopv1.VirtualMachineServiceTypeLoadBalancer,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			client := builder.NewFakeClient(initObjs...)
+			lbProvider = NewExternalLoadbalancerProvider(client, client)
+		})
+
+		It("should return the configured load balancer class, annotations, and labels", func() {
+			Expect(lbProvider.EnsureLoadBalancer(ctx, vmService)).To(Succeed())
+
+			class, err := lbProvider.GetLoadBalancerClass(ctx, vmService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(class).To(Equal("ako.vmware.com/avi-lb"))
+
+			annotations, err := lbProvider.GetServiceAnnotations(ctx, vmService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(annotations).To(Equal(map[string]string{
+				"ako.vmware.com/enable-shared-vip":               "my-vip",
+				ExternalLoadBalancerAppliedAnnotationsAnnotation: "ako.vmware.com/enable-shared-vip",
+				ExternalLoadBalancerAppliedLabelsAnnotation:      "my-label",
+			}))
+
+			labels, err := lbProvider.GetServiceLabels(ctx, vmService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(labels).To(Equal(map[string]string{"my-label": "my-value"}))
+
+			annotations, err = lbProvider.GetToBeRemovedServiceAnnotations(ctx, vmService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(annotations).To(BeEmpty())
+
+			labels, err = lbProvider.GetToBeRemovedServiceLabels(ctx, vmService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(labels).To(BeEmpty())
+		})
+
+		When("the ConfigMap does not exist", func() {
+			BeforeEach(func() {
+				initObjs = nil
+			})
+
+			It("should return an empty config", func() {
+				Expect(lbProvider.EnsureLoadBalancer(ctx, vmService)).To(Succeed())
+
+				class, err := lbProvider.GetLoadBalancerClass(ctx, vmService)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(class).To(BeEmpty())
+
+				annotations, err := lbProvider.GetServiceAnnotations(ctx, vmService)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(annotations).To(Equal(map[string]string{
+					ExternalLoadBalancerAppliedAnnotationsAnnotation: "",
+					ExternalLoadBalancerAppliedLabelsAnnotation:      "",
+				}))
+			})
+		})
+
+		When("annotations and labels were removed from the ConfigMap", func() {
+			var service *corev1.Service
+
+			BeforeEach(func() {
+				vmService.Annotations = map[string]string{
+					"ako.vmware.com/enable-shared-vip":               "my-vip",
+					"old-annotation":                                 "old-value",
+					"user-annotation":                                "user-value",
+					ExternalLoadBalancerAppliedAnnotationsAnnotation: "ako.vmware.com/enable-shared-vip,old-annotation",
+					ExternalLoadBalancerAppliedLabelsAnnotation:      "my-label,old-label",
+				}
+				vmService.Labels = map[string]string{
+					"my-label":   "my-value",
+					"old-label":  "old-value",
+					"user-label": "user-value",
+				}
+
+				service = &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        vmService.Name,
+						Namespace:   vmService.Namespace,
+						Annotations: vmService.Annotations,
+						Labels:      vmService.Labels,
+					},
+				}
+				initObjs = append(initObjs, service)
+			})
+
+			It("should remove them from the VirtualMachineService", func() {
+				Expect(lbProvider.EnsureLoadBalancer(ctx, vmService)).To(Succeed())
+				Expect(vmService.Annotations).ToNot(HaveKey("old-annotation"))
+				Expect(vmService.Annotations).To(HaveKey("user-annotation"))
+				Expect(vmService.Labels).ToNot(HaveKey("old-label"))
+				Expect(vmService.Labels).To(HaveKey("user-label"))
+			})
+
+			It("should return them to be removed from the Service", func() {
+				annotations, err := lbProvider.GetToBeRemovedServiceAnnotations(ctx, vmService)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(annotations).To(HaveLen(1))
+				Expect(annotations).To(HaveKey("old-annotation"))
+
+				labels, err := lbProvider.GetToBeRemovedServiceLabels(ctx, vmService)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(labels).To(HaveLen(1))
+				Expect(labels).To(HaveKey("old-label"))
+			})
+		})
+
+		When("the context has a reconcile cache", func() {
+			BeforeEach(func() {
+				ctx = WithReconcileCache(ctx)
+			})
+
+			It("should read the ConfigMap once", func() {
+				class, err := lbProvider.GetLoadBalancerClass(ctx, vmService)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(class).To(Equal("ako.vmware.com/avi-lb"))
+
+				Expect(lbProvider.client.Delete(ctx, configMap)).To(Succeed())
+
+				class, err = lbProvider.GetLoadBalancerClass(ctx, vmService)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(class).To(Equal("ako.vmware.com/avi-lb"))
+			})
+		})
+
+		When("the ConfigMap has invalid annotations", func() {
+			BeforeEach(func() {
+				configMap.Data[ExternalLoadBalancerAnnotationsKey] = "not a map"
+			})
+
+			It("should return an error", func() {
+				Expect(lbProvider.EnsureLoadBalancer(ctx, vmService)).ToNot(Succeed())
+
+				_, err := lbProvider.GetServiceAnnotations(ctx, vmService)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
@@ -5,17 +5,20 @@ package providers
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 
-	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice/utils"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 const (
-	NSXTLoadBalancer = "nsx-t-lb"
+	NSXTLoadBalancer = vmopv1util.NSXTLoadBalancerProviderType
 
 	ServiceLoadBalancerHealthCheckNodePortTagKey = "ncp/healthCheckNodePort"
 	NSXTServiceProxy                             = "nsx-t"
@@ -64,11 +67,77 @@ type LoadbalancerProvider interface {
 	GetToBeRemovedServiceAnnotations(ctx context.Context, vmService *vmopv1.VirtualMachineService) (map[string]string, error)
 }
 
-func GetLoadbalancerProviderByType(mgr manager.Manager, providerType string) (LoadbalancerProvider, error) {
-	if providerType == NSXTLoadBalancer {
+var log = logf.Log.WithName("loadbalancer-provider")
+
+// LoadBalancerClassProvider is an optional interface implemented by a
+// LoadbalancerProvider that hands off the load balancing of a Service to a
+// load balancer controller identified by a load balancer class.
+type LoadBalancerClassProvider interface {
+	// GetLoadBalancerClass returns the load balancer class, if any, to set on
+	// a LoadBalancer Service when it is created.
+	GetLoadBalancerClass(ctx context.Context, vmService *vmopv1.VirtualMachineService) (string, error)
+}
+
+// LoadbalancerProviderFactory returns a new LoadbalancerProvider.
+type LoadbalancerProviderFactory func(mgr manager.Manager) (LoadbalancerProvider, error)
+
+var (
+	lbProviderFactoriesMu sync.RWMutex
+	lbProviderFactories   = map[string]LoadbalancerProviderFactory{}
+)
+
+// RegisterLoadbalancerProvider registers the factory for the provider type.
+// It panics if a factory is already registered for the provider type.
+func RegisterLoadbalancerProvider(providerType string, factory LoadbalancerProviderFactory) {
+	lbProviderFactoriesMu.Lock()
+	defer lbProviderFactoriesMu.Unlock()
+
+	if _, ok := lbProviderFactories[providerType]; ok {
+		panic(fmt.Sprintf("load balancer provider %q already registered", providerType))
+	}
+	lbProviderFactories[providerType] = factory
+}
+
+func init() {
+	RegisterLoadbalancerProvider(NSXTLoadBalancer, func(manager.Manager) (LoadbalancerProvider, error) {
 		return NsxtLoadBalancerProvider(), nil
+	})
+}
+
+// GetLoadbalancerProviderByType returns the registered provider for the
+// provider type. A noop provider is returned when the type is empty or is not
+// registered.
+func GetLoadbalancerProviderByType(mgr manager.Manager, providerType string) (LoadbalancerProvider, error) {
+	if providerType == "" {
+		return NoopLoadbalancerProvider{}, nil
+	}
+
+	lbProviderFactoriesMu.RLock()
+	factory, ok := lbProviderFactories[providerType]
+	lbProviderFactoriesMu.RUnlock()
+
+	if !ok {
+		log.Info("Unknown load balancer provider, falling back to the noop provider",
+			"providerType", providerType)
+		return NoopLoadbalancerProvider{}, nil
 	}
-	return NoopLoadbalancerProvider{}, nil
+	return factory(mgr)
+}
+
+type reconcileCacheKey struct{}
+
+// WithReconcileCache returns a context in which a LoadbalancerProvider may
+// cache the state it reads, ex. its config, for the duration of a single
+// reconcile of a VirtualMachineService.
+func WithReconcileCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, reconcileCacheKey{}, &sync.Map{})
+}
+
+// reconcileCacheFrom returns the cache in the context, or nil if the context
+// does not have one.
+func reconcileCacheFrom(ctx context.Context) *sync.Map {
+	cache, _ := ctx.Value(reconcileCacheKey{}).(*sync.Map)
+	return cache
 }
 
 type NoopLoadbalancerProvider struct{}
@@ -112,7 +181,7 @@ func (nl *NsxtLoadbalancerProvider) GetServiceLabels(ctx context.Context, vmServ
 
 	// When externalTrafficPolicy is set to Local, skip kube-proxy for the
 	// target Service
-	if etp := vmService.Annotations[utils.AnnotationServiceExternalTrafficPolicyKey]; corev1.ServiceExternalTrafficPolicyType(etp) == corev1.ServiceExternalTrafficPolicyTypeLocal {
+	if vmopv1util.ExternalTrafficPolicy(vmService) == corev1.ServiceExternalTrafficPolicyTypeLocal {
 		res[LabelServiceProxyName] = NSXTServiceProxy
 	}
 
@@ -126,7 +195,7 @@ func (nl *NsxtLoadbalancerProvider) GetToBeRemovedServiceLabels(ctx context.Cont
 
 	// When there is no externalTrafficPolicy configured or it's not Local,
 	// remove the service-proxy label
-	if etp := vmService.Annotations[utils.AnnotationServiceExternalTrafficPolicyKey]; corev1.ServiceExternalTrafficPolicyType(etp) != corev1.ServiceExternalTrafficPolicyTypeLocal {
+	if vmopv1util.ExternalTrafficPolicy(vmService) != corev1.ServiceExternalTrafficPolicyTypeLocal {
 		res[LabelServiceProxyName] = NSXTServiceProxy
 	}
 
@@ -138,7 +207,7 @@ func (nl *NsxtLoadbalancerProvider) GetToBeRemovedServiceLabels(ctx context.Cont
 func (nl *NsxtLoadbalancerProvider) GetServiceAnnotations(ctx context.Context, vmService *vmopv1.VirtualMachineService) (map[string]string, error) {
 	res := make(map[string]string)
 
-	if healthCheckNodePortString, ok := vmService.Annotations[utils.AnnotationServiceHealthCheckNodePortKey]; ok {
+	if healthCheckNodePortString, ok := vmopv1util.HealthCheckNodePort(vmService); ok {
 		res[ServiceLoadBalancerHealthCheckNodePortTagKey] = healthCheckNodePortString
 	}
 
@@ -152,7 +221,7 @@ func (nl *NsxtLoadbalancerProvider) GetToBeRemovedServiceAnnotations(ctx context
 
 	// When healthCheckNodePort is NOT present, the corresponding NSX-T
 	// annotation should be cleared as well
-	if _, ok := vmService.Annotations[utils.AnnotationServiceHealthCheckNodePortKey]; !ok {
+	if _, ok := vmopv1util.HealthCheckNodePort(vmService); !ok {
 		res[ServiceLoadBalancerHealthCheckNodePortTagKey] = ""
 	}
 
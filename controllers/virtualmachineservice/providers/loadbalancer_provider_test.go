@@ -11,6 +11,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice/utils"
@@ -47,6 +48,31 @@ var _ = Describe(
 				Expect(err).NotTo(HaveOccurred())
 				Expect(lbProvider).To(Equal(NoopLoadbalancerProvider{}))
 			})
+
+			It("should successfully get a registered loadbalancer provider", func() {
+				RegisterLoadbalancerProvider("dummy-lb", func(manager.Manager) (LoadbalancerProvider, error) {
+					return NsxtLoadBalancerProvider(), nil
+				})
+				DeferCleanup(func() {
+					delete(lbProviderFactories, "dummy-lb")
+				})
+
+				lbProvider, err := GetLoadbalancerProviderByType(nil, "dummy-lb")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lbProvider).To(Equal(NsxtLoadBalancerProvider()))
+			})
+
+			It("should panic when a loadbalancer provider is registered twice", func() {
+				Expect(func() {
+					RegisterLoadbalancerProvider(NSXTLoadBalancer, nil)
+				}).To(Panic())
+			})
+
+			It("should fall back to the noop loadbalancer provider for an unknown loadbalancer provider", func() {
+				lbProvider, err := GetLoadbalancerProviderByType(nil, "unknown-lb")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lbProvider).To(Equal(NoopLoadbalancerProvider{}))
+			})
 		})
 
 		Context("noop loadbalancer provider", func() {
@@ -103,6 +129,25 @@ var _ = Describe(
 				port := vmServiceAnnotations[ServiceLoadBalancerHealthCheckNodePortTagKey]
 				Expect(port).To(Equal("30012"))
 			})
+
+			When("healthCheckNodePort is also specified in the spec", func() {
+				BeforeEach(func() {
+					vmService.Spec.HealthCheckNodePort = 30013
+				})
+
+				It("should get health check node port from the spec", func() {
+					vmServiceAnnotations, err := lbProvider.GetServiceAnnotations(ctx, vmService)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vmServiceAnnotations).To(HaveKeyWithValue(ServiceLoadBalancerHealthCheckNodePortTagKey, "30013"))
+				})
+
+				It("should not get health check node port in the to be removed annotation", func() {
+					delete(vmService.Annotations, utils.AnnotationServiceHealthCheckNodePortKey)
+					vmServiceAnnotations, err := lbProvider.GetToBeRemovedServiceAnnotations(ctx, vmService)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vmServiceAnnotations).ToNot(HaveKey(ServiceLoadBalancerHealthCheckNodePortTagKey))
+				})
+			})
 		})
 
 		Context("GetToBeRemovedServiceAnnotations when VMService does not have healthCheckNodePort defined", func() {
@@ -175,6 +220,19 @@ var _ = Describe(
 					Expect(labels[LabelServiceProxyName]).To(Equal(NSXTServiceProxy))
 				})
 			})
+
+			Context("etp is Local in the spec", func() {
+				BeforeEach(func() {
+					vmService.Spec.ExternalTrafficPolicy = vmopv1.ServiceExternalTrafficPolicyLocal
+				})
+
+				It("should create one label for ServiceProxyName", func() {
+					labels, err := lbProvider.GetServiceLabels(ctx, vmService)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(labels).To(HaveLen(1))
+					Expect(labels[LabelServiceProxyName]).To(Equal(NSXTServiceProxy))
+				})
+			})
 		})
 
 		Context("GetToBeRemovedServiceLabels", func() {
@@ -234,5 +292,18 @@ var _ = Describe(
 					Expect(exists).To(BeTrue())
 				})
 			})
+
+			Context("etp is Cluster in the spec and Local in the annotation", func() {
+				BeforeEach(func() {
+					vmService.Spec.ExternalTrafficPolicy = vmopv1.ServiceExternalTrafficPolicyCluster
+					vmService.Annotations[utils.AnnotationServiceExternalTrafficPolicyKey] = string(corev1.ServiceExternalTrafficPolicyTypeLocal)
+				})
+
+				It("should remove ServiceProxyName label", func() {
+					Expect(err).ToNot(HaveOccurred())
+					_, exists := labels[LabelServiceProxyName]
+					Expect(exists).To(BeTrue())
+				})
+			})
 		})
 	})
@@ -3,7 +3,11 @@
 
 package utils
 
+import (
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
 const (
-	AnnotationServiceExternalTrafficPolicyKey = "virtualmachineservice.vmoperator.vmware.com/service.externalTrafficPolicy"
-	AnnotationServiceHealthCheckNodePortKey   = "virtualmachineservice.vmoperator.vmware.com/service.healthCheckNodePort"
+	AnnotationServiceExternalTrafficPolicyKey = vmopv1util.AnnotationServiceExternalTrafficPolicyKey
+	AnnotationServiceHealthCheckNodePortKey   = vmopv1util.AnnotationServiceHealthCheckNodePortKey
 )
@@ -7,14 +7,16 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -31,7 +33,9 @@ import (
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/patch"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 const (
@@ -41,6 +45,20 @@ const (
 	OpCreate = "CreateK8sService"
 	OpDelete = "DeleteK8sService"
 	OpUpdate = "UpdateK8sService"
+
+	// LoadBalancerClassMismatchReason is the reason of the warning event
+	// emitted when the load balancer class of a Service is not the class
+	// configured by the load balancer provider.
+	LoadBalancerClassMismatchReason = "LoadBalancerClassMismatch"
+
+	// EndpointSliceManagedBy is the value of the managed-by label on the
+	// EndpointSlices created for a VirtualMachineService.
+	EndpointSliceManagedBy = "virtualmachineservice-controller.vmoperator.vmware.com"
+
+	// MaxEndpointsPerSlice is the maximum number of endpoints in each
+	// EndpointSlice. This is the same default as the Kubernetes EndpointSlice
+	// controller.
+	MaxEndpointsPerSlice = 100
 )
 
 func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
@@ -52,14 +70,7 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
 	)
 
-	lbProviderType := pkgcfg.FromContext(ctx).LoadBalancerProvider
-	if lbProviderType == "" {
-		if pkgcfg.FromContext(ctx).NetworkProviderType == pkgcfg.NetworkProviderTypeNSXT {
-			lbProviderType = providers.NSXTLoadBalancer
-		}
-	}
-
-	lbProvider, err := providers.GetLoadbalancerProviderByType(mgr, lbProviderType)
+	lbProvider, err := providers.GetLoadbalancerProviderByType(mgr, vmopv1util.LoadBalancerProviderType(ctx))
 	if err != nil {
 		return err
 	}
@@ -72,7 +83,7 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		lbProvider,
 	)
 
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(controlledType).
 		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
 		Watches(&corev1.Service{},
@@ -80,8 +91,14 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		Watches(&corev1.Endpoints{},
 			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &vmopv1.VirtualMachineService{})).
 		Watches(&vmopv1.VirtualMachine{},
-			handler.EnqueueRequestsFromMapFunc(r.virtualMachineToVirtualMachineServiceMapper())).
-		Complete(r)
+			handler.EnqueueRequestsFromMapFunc(r.virtualMachineToVirtualMachineServiceMapper()))
+
+	if pkgcfg.FromContext(ctx).Features.VMServiceEndpointSlices {
+		builder = builder.Watches(&discoveryv1.EndpointSlice{},
+			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &vmopv1.VirtualMachineService{}))
+	}
+
+	return builder.Complete(r)
 }
 
 func NewReconciler(
@@ -118,9 +135,11 @@ type ReconcileVirtualMachineService struct {
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch;create;update;patch;delete
 
 func (r *ReconcileVirtualMachineService) Reconcile(ctx context.Context, request reconcile.Request) (_ reconcile.Result, reterr error) {
 	ctx = pkgcfg.JoinContext(ctx, r.Context)
+	ctx = providers.WithReconcileCache(ctx)
 
 	vmService := &vmopv1.VirtualMachineService{}
 	if err := r.Get(ctx, request.NamespacedName, vmService); err != nil {
@@ -167,6 +186,16 @@ func (r *ReconcileVirtualMachineService) ReconcileDelete(ctx *pkgctx.VirtualMach
 			return err
 		}
 
+		if pkgcfg.FromContext(ctx).Features.VMServiceEndpointSlices {
+			err := r.Client.DeleteAllOf(ctx, &discoveryv1.EndpointSlice{},
+				client.InNamespace(ctx.VMService.Namespace),
+				client.MatchingLabels(endpointSliceSelector(ctx.VMService.Name)))
+			if client.IgnoreNotFound(err) != nil {
+				ctx.Logger.Error(err, "Failed to delete EndpointSlices")
+				return err
+			}
+		}
+
 		service := &corev1.Service{ObjectMeta: objectMeta}
 		if err := r.Client.Delete(ctx, service); client.IgnoreNotFound(err) != nil {
 			ctx.Logger.Error(err, "Failed to delete Service")
@@ -272,6 +301,14 @@ func (r *ReconcileVirtualMachineService) reconcileVMService(ctx *pkgctx.VirtualM
 		return err
 	}
 
+	if pkgcfg.FromContext(ctx).Features.VMServiceEndpointSlices {
+		err = r.createOrUpdateEndpointSlices(ctx, service)
+		if err != nil {
+			ctx.Logger.Error(err, "Failed to update VirtualMachineService EndpointSlices")
+			return err
+		}
+	}
+
 	err = r.updateVMService(ctx, service)
 	if err != nil {
 		ctx.Logger.Error(err, "Failed to update VirtualMachineService Status")
@@ -391,6 +428,16 @@ func (r *ReconcileVirtualMachineService) createOrUpdateService(ctx *pkgctx.Virtu
 		},
 	}
 
+	var loadBalancerClass string
+	if vmService.Spec.Type == vmopv1.VirtualMachineServiceTypeLoadBalancer {
+		if classProvider, ok := r.loadbalancerProvider.(providers.LoadBalancerClassProvider); ok {
+			var err error
+			if loadBalancerClass, err = classProvider.GetLoadBalancerClass(ctx, vmService); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	result, err := controllerutil.CreateOrPatch(ctx, r.Client, service, func() error {
 		if err := controllerutil.SetControllerReference(vmService, service, r.Client.Scheme()); err != nil {
 			return err
@@ -415,6 +462,24 @@ func (r *ReconcileVirtualMachineService) createOrUpdateService(ctx *pkgctx.Virtu
 		if service.ResourceVersion == "" {
 			// ClusterIP cannot be changed through update.
 			service.Spec.ClusterIP = vmService.Spec.ClusterIP
+
+			// LoadBalancerClass cannot be changed through update.
+			if loadBalancerClass != "" {
+				service.Spec.LoadBalancerClass = ptr.To(loadBalancerClass)
+			}
+		}
+
+		// The IPFamilies and IPFamilyPolicy are assigned by k8s when not specified, so only
+		// set them when the VirtualMachineService does so the assigned values are preserved.
+		if len(vmService.Spec.IPFamilies) > 0 {
+			ipFamilies := make([]corev1.IPFamily, 0, len(vmService.Spec.IPFamilies))
+			for _, f := range vmService.Spec.IPFamilies {
+				ipFamilies = append(ipFamilies, corev1.IPFamily(f))
+			}
+			service.Spec.IPFamilies = ipFamilies
+		}
+		if vmService.Spec.IPFamilyPolicy != nil {
+			service.Spec.IPFamilyPolicy = ptr.To(corev1.IPFamilyPolicy(*vmService.Spec.IPFamilyPolicy))
 		}
 
 		// Maintain the existing mapping of ServicePort -> NodePort as un-setting it will cause
@@ -430,7 +495,7 @@ func (r *ReconcileVirtualMachineService) createOrUpdateService(ctx *pkgctx.Virtu
 				Name:       vmPort.Name,
 				Protocol:   corev1.Protocol(vmPort.Protocol),
 				Port:       vmPort.Port,
-				TargetPort: intstr.FromInt(int(vmPort.TargetPort)),
+				TargetPort: vmPort.TargetPort,
 				NodePort:   nodePortMap[vmPort.Name],
 			}
 			servicePorts = append(servicePorts, servicePort)
@@ -438,24 +503,63 @@ func (r *ReconcileVirtualMachineService) createOrUpdateService(ctx *pkgctx.Virtu
 		service.Spec.Ports = servicePorts
 
 		// This is the default that k8s would otherwise set (note that we don't really support NodePort).
-		// The only real purpose of this is if the AnnotationServiceExternalTrafficPolicyKey annotation
-		// below is removed, so that we switch the Service back to the default.
+		// The only real purpose of this is if the ExternalTrafficPolicy field or the
+		// AnnotationServiceExternalTrafficPolicyKey annotation below is removed, so that we switch the
+		// Service back to the default.
 		if service.Spec.Type == corev1.ServiceTypeNodePort || service.Spec.Type == corev1.ServiceTypeLoadBalancer {
 			service.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
 		}
 
-		if externalTrafficPolicy, ok := service.Annotations[utils.AnnotationServiceExternalTrafficPolicyKey]; ok {
-			// Note that this annotation is only set (and makes sense) from the GC cloud provider.
-			trafficPolicy := corev1.ServiceExternalTrafficPolicyType(externalTrafficPolicy)
-			switch trafficPolicy {
+		// The ExternalTrafficPolicy field takes precedence over the annotation. Note that the
+		// annotation is only set (and makes sense) from the GC cloud provider.
+		if externalTrafficPolicy := vmopv1util.ExternalTrafficPolicy(vmService); externalTrafficPolicy != "" {
+			switch externalTrafficPolicy {
 			case corev1.ServiceExternalTrafficPolicyTypeLocal, corev1.ServiceExternalTrafficPolicyTypeCluster:
-				service.Spec.ExternalTrafficPolicy = trafficPolicy
+				service.Spec.ExternalTrafficPolicy = externalTrafficPolicy
 			default:
 				ctx.Logger.V(5).Info("Unknown externalTrafficPolicy VirtualMachineService annotation",
 					"externalTrafficPolicy", externalTrafficPolicy)
 			}
 		}
 
+		// The HealthCheckNodePort is allocated by k8s when not specified, so preserve the assigned
+		// value unless the Service no longer needs one.
+		switch {
+		case vmService.Spec.HealthCheckNodePort != 0:
+			service.Spec.HealthCheckNodePort = vmService.Spec.HealthCheckNodePort
+		case service.Spec.Type != corev1.ServiceTypeLoadBalancer ||
+			service.Spec.ExternalTrafficPolicy != corev1.ServiceExternalTrafficPolicyTypeLocal:
+			service.Spec.HealthCheckNodePort = 0
+		}
+
+		// This is the default that k8s would otherwise set.
+		if service.Spec.Type != corev1.ServiceTypeExternalName {
+			service.Spec.InternalTrafficPolicy = ptr.To(corev1.ServiceInternalTrafficPolicyCluster)
+		} else {
+			service.Spec.InternalTrafficPolicy = nil
+		}
+		if vmService.Spec.InternalTrafficPolicy != nil {
+			service.Spec.InternalTrafficPolicy = ptr.To(corev1.ServiceInternalTrafficPolicy(*vmService.Spec.InternalTrafficPolicy))
+		}
+
+		service.Spec.SessionAffinity = corev1.ServiceAffinityNone
+		if vmService.Spec.SessionAffinity != "" {
+			service.Spec.SessionAffinity = corev1.ServiceAffinity(vmService.Spec.SessionAffinity)
+		}
+		// k8s defaults the SessionAffinityConfig for ClientIP affinity, so preserve the assigned
+		// value when the VirtualMachineService does not specify it.
+		if service.Spec.SessionAffinity == corev1.ServiceAffinityClientIP {
+			if cfg := vmService.Spec.SessionAffinityConfig; cfg != nil && cfg.ClientIP != nil && cfg.ClientIP.TimeoutSeconds != nil {
+				service.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+					ClientIP: &corev1.ClientIPConfig{
+						TimeoutSeconds: ptr.To(*cfg.ClientIP.TimeoutSeconds),
+					},
+				}
+			}
+		} else {
+			service.Spec.SessionAffinityConfig = nil
+		}
+
 		return nil
 	})
 
@@ -470,6 +574,19 @@ func (r *ReconcileVirtualMachineService) createOrUpdateService(ctx *pkgctx.Virtu
 		r.recorder.EmitEvent(ctx.VMService, OpUpdate, nil, false)
 	}
 
+	// The load balancer class of a Service cannot be changed, so a Service
+	// created with another class is left as is until it is recreated.
+	if loadBalancerClass != "" && service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		if existing := ptr.Deref(service.Spec.LoadBalancerClass); existing != loadBalancerClass {
+			ctx.Logger.Info("Service has a different load balancer class than the configured class",
+				"loadBalancerClass", existing, "configuredLoadBalancerClass", loadBalancerClass)
+			r.recorder.Warnf(ctx.VMService, LoadBalancerClassMismatchReason,
+				"Service has the load balancer class %q instead of the configured class %q. "+
+					"The load balancer class of a Service cannot be changed, so delete the Service to recreate it with the configured class.",
+				existing, loadBalancerClass)
+		}
+	}
+
 	return service, nil
 }
 
@@ -569,6 +686,16 @@ func (r *ReconcileVirtualMachineService) createOrUpdateEndpoints(ctx *pkgctx.Vir
 		// of anything else setting Labels, so just sync the Labels (and Annotations) with the Service.
 		endpoints.Labels = service.Labels
 		endpoints.Annotations = service.Annotations
+
+		if pkgcfg.FromContext(ctx).Features.VMServiceEndpointSlices {
+			// We create the EndpointSlices ourselves so the Kubernetes EndpointSlice mirroring
+			// controller must not also mirror these Endpoints.
+			endpoints.Labels = make(map[string]string, len(service.Labels)+1)
+			for k, v := range service.Labels {
+				endpoints.Labels[k] = v
+			}
+			endpoints.Labels[discoveryv1.LabelSkipMirror] = "true"
+		}
 		endpoints.Subsets = subsets
 		return nil
 	})
@@ -587,17 +714,6 @@ func (r *ReconcileVirtualMachineService) createOrUpdateEndpoints(ctx *pkgctx.Vir
 	return nil
 }
 
-func findVMPortNum(_ *vmopv1.VirtualMachine, port intstr.IntOrString, _ corev1.Protocol) (int, error) {
-	switch port.Type {
-	case intstr.String:
-		// Not supported.
-	case intstr.Int:
-		return port.IntValue(), nil
-	}
-
-	return 0, fmt.Errorf("no matching port on VM")
-}
-
 // generateSubsetsForService generates Endpoints subsets for a given Service.
 func (r *ReconcileVirtualMachineService) generateSubsetsForService(
 	ctx *pkgctx.VirtualMachineServiceContext,
@@ -620,13 +736,7 @@ func (r *ReconcileVirtualMachineService) generateSubsetsForService(
 			continue
 		}
 
-		var vmIP string
-		if vm.Status.Network != nil {
-			vmIP = vm.Status.Network.PrimaryIP4
-			if vmIP == "" {
-				vmIP = vm.Status.Network.PrimaryIP6
-			}
-		}
+		vmIP := getVMPrimaryIP(&vm, service)
 
 		if vmIP == "" {
 			// The EndpointAddress must have a valid IP so we cannot include this VM in the
@@ -636,41 +746,17 @@ func (r *ReconcileVirtualMachineService) generateSubsetsForService(
 			continue
 		}
 
-		// If the VM has a ReadinessProbe and Ready condition, ready is a reflection of the condition
-		// status. If the VM has a ReadinessProbe but no condition, we assume that the prober just
-		// hasn't run against the VM yet, so infer the VM's readiness if it was previously in the EP;
-		// this is to handle upgrade scenarios.
-		// Otherwise, a VM that does not have a ReadinessProbe is implicitly ready.
-		ready := true
-
-		if probe := vm.Spec.ReadinessProbe; probe != nil && (probe.TCPSocket != nil || probe.GuestHeartbeat != nil || len(probe.GuestInfo) != 0) {
-			if condition := conditions.Get(&vm, vmopv1.ReadyConditionType); condition == nil {
-				if vmInSubsetsMap == nil {
-					vmInSubsetsMap = r.getVMsReferencedByServiceEndpoints(ctx, service)
-				}
-
-				// If this VM was previously in the EP subset, preserve its readiness until prober
-				// updates the condition (the probe used to be done inline here before we had a
-				// Ready condition).
-				_, ready = vmInSubsetsMap[vm.UID]
-			} else {
-				ready = condition.Status == metav1.ConditionTrue
+		ready := isVMReady(&vm, func() bool {
+			if vmInSubsetsMap == nil {
+				vmInSubsetsMap = r.getVMsReferencedByServiceEndpoints(ctx, service)
 			}
-		}
+			_, ok := vmInSubsetsMap[vm.UID]
+			return ok
+		})
 
 		epa := corev1.EndpointAddress{
-			IP: vmIP,
-			TargetRef: &corev1.ObjectReference{
-				APIVersion: vm.APIVersion,
-				Kind:       vm.Kind,
-				Namespace:  vm.Namespace,
-				Name:       vm.Name,
-				UID:        vm.UID,
-				// NOTE: This currently isn't set to limit downstream reconcile churn in things
-				// watching these Endpoints but isn't ideal. We should be smarter and only update
-				// this when something relevant to the service, e.g. the VM's IP, changes.
-				// ResourceVersion: vm.ResourceVersion,
-			},
+			IP:        vmIP,
+			TargetRef: vmObjectReference(&vm),
 		}
 
 		// Populate the EP subset for this VM. We create one subset for each VM, and then our
@@ -690,7 +776,7 @@ func (r *ReconcileVirtualMachineService) generateSubsetsForService(
 			logger.V(5).Info("ServicePort for VirtualMachine",
 				"port name", portName, "port proto", portProto)
 
-			portNum, err := findVMPortNum(&vm, servicePort.TargetPort, portProto)
+			portNum, err := vmopv1util.FindPort(vm, servicePort.TargetPort, portProto)
 			if err != nil {
 				logger.Info("Failed to find port for service",
 					"name", portName, "protocol", portProto, "error", err)
@@ -707,6 +793,327 @@ func (r *ReconcileVirtualMachineService) generateSubsetsForService(
 	return subsets, nil
 }
 
+// getVMPrimaryIP returns the VM's primary IP of the Service's primary IP family. Endpoints
+// only contain addresses of a single family, so a VM without an IP of the primary family is
+// not included. If the Service does not have any IP families assigned yet, the IPv4 address
+// is preferred.
+func getVMPrimaryIP(vm *vmopv1.VirtualMachine, service *corev1.Service) string {
+	if vm.Status.Network == nil {
+		return ""
+	}
+
+	if len(service.Spec.IPFamilies) > 0 {
+		if service.Spec.IPFamilies[0] == corev1.IPv6Protocol {
+			return vm.Status.Network.PrimaryIP6
+		}
+		return vm.Status.Network.PrimaryIP4
+	}
+
+	if ip := vm.Status.Network.PrimaryIP4; ip != "" {
+		return ip
+	}
+	return vm.Status.Network.PrimaryIP6
+}
+
+// serviceHasIPFamily returns true if the Service has the IP family assigned, or if the
+// Service does not have any IP families assigned yet.
+func serviceHasIPFamily(service *corev1.Service, family corev1.IPFamily) bool {
+	return len(service.Spec.IPFamilies) == 0 || slices.Contains(service.Spec.IPFamilies, family)
+}
+
+// serviceUsesTopologyHints returns true if the Service opted into topology aware routing,
+// either with the topology mode annotation set to Auto or with a traffic distribution. Like
+// the EndpointSlice controller, zone hints are only set for such a Service.
+func serviceUsesTopologyHints(service *corev1.Service) bool {
+	if service.Spec.TrafficDistribution != nil {
+		return true
+	}
+	mode, ok := service.Annotations[corev1.AnnotationTopologyMode]
+	if !ok {
+		mode = service.Annotations[corev1.DeprecatedAnnotationTopologyAwareHints]
+	}
+	return strings.EqualFold(mode, "Auto")
+}
+
+func vmObjectReference(vm *vmopv1.VirtualMachine) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: vm.APIVersion,
+		Kind:       vm.Kind,
+		Namespace:  vm.Namespace,
+		Name:       vm.Name,
+		UID:        vm.UID,
+		// NOTE: This currently isn't set to limit downstream reconcile churn in things
+		// watching these Endpoints but isn't ideal. We should be smarter and only update
+		// this when something relevant to the service, e.g. the VM's IP, changes.
+		// ResourceVersion: vm.ResourceVersion,
+	}
+}
+
+// isVMReady returns true if the VM is ready to receive traffic.
+//
+// If the VM has a ReadinessProbe and Ready condition, ready is a reflection of the condition
+// status. If the VM has a ReadinessProbe but no condition, we assume that the prober just
+// hasn't run against the VM yet, so infer the VM's readiness if it was previously in the EP;
+// this is to handle upgrade scenarios.
+// Otherwise, a VM that does not have a ReadinessProbe is implicitly ready.
+func isVMReady(vm *vmopv1.VirtualMachine, wasInEndpoints func() bool) bool {
+	probe := vm.Spec.ReadinessProbe
+	if probe == nil || (probe.TCPSocket == nil && probe.HTTPGet == nil &&
+		probe.GuestHeartbeat == nil && len(probe.GuestInfo) == 0) {
+		return true
+	}
+
+	if condition := conditions.Get(vm, vmopv1.ReadyConditionType); condition != nil {
+		return condition.Status == metav1.ConditionTrue
+	}
+
+	// If this VM was previously in the EP subset, preserve its readiness until prober
+	// updates the condition (the probe used to be done inline here before we had a
+	// Ready condition).
+	return wasInEndpoints()
+}
+
+// endpointSliceSelector returns the labels that select the EndpointSlices
+// created for the VirtualMachineService.
+func endpointSliceSelector(serviceName string) map[string]string {
+	return map[string]string{
+		discoveryv1.LabelServiceName: serviceName,
+		discoveryv1.LabelManagedBy:   EndpointSliceManagedBy,
+	}
+}
+
+// createOrUpdateEndpointSlices updates the EndpointSlices for VirtualMachineService, and deletes
+// the EndpointSlices that are no longer needed.
+func (r *ReconcileVirtualMachineService) createOrUpdateEndpointSlices(
+	ctx *pkgctx.VirtualMachineServiceContext,
+	service *corev1.Service) error {
+
+	ctx.Logger.V(5).Info("Updating VirtualMachineService EndpointSlices")
+	defer ctx.Logger.V(5).Info("Finished updating VirtualMachineService EndpointSlices")
+
+	if len(ctx.VMService.Spec.Selector) == 0 {
+		ctx.Logger.V(5).Info("Selectorless VirtualMachineService so skipping EndpointSlices reconciliation")
+		return nil
+	}
+
+	desiredSlices, err := r.generateEndpointSlicesForService(ctx, service)
+	if err != nil {
+		return err
+	}
+
+	desiredNames := make(map[string]struct{}, len(desiredSlices))
+	for i := range desiredSlices {
+		desired := desiredSlices[i]
+		desiredNames[desired.Name] = struct{}{}
+
+		slice := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      desired.Name,
+				Namespace: desired.Namespace,
+			},
+			AddressType: desired.AddressType,
+		}
+
+		result, err := controllerutil.CreateOrPatch(ctx, r.Client, slice, func() error {
+			if err := controllerutil.SetControllerReference(ctx.VMService, slice, r.Client.Scheme()); err != nil {
+				return err
+			}
+
+			slice.Labels = make(map[string]string, len(service.Labels)+2)
+			for k, v := range service.Labels {
+				slice.Labels[k] = v
+			}
+			for k, v := range endpointSliceSelector(service.Name) {
+				slice.Labels[k] = v
+			}
+			slice.Endpoints = desired.Endpoints
+			slice.Ports = desired.Ports
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		switch result {
+		case controllerutil.OperationResultCreated:
+			ctx.Logger.Info("Creating Service EndpointSlice", "endpointSlice", slice.Name)
+		case controllerutil.OperationResultUpdated:
+			ctx.Logger.Info("Updating Service EndpointSlice", "endpointSlice", slice.Name)
+		}
+	}
+
+	sliceList := &discoveryv1.EndpointSliceList{}
+	if err := r.List(ctx, sliceList,
+		client.InNamespace(service.Namespace),
+		client.MatchingLabels(endpointSliceSelector(service.Name))); err != nil {
+		return err
+	}
+
+	for i := range sliceList.Items {
+		slice := &sliceList.Items[i]
+		if _, ok := desiredNames[slice.Name]; ok {
+			continue
+		}
+		if err := r.Delete(ctx, slice); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		ctx.Logger.Info("Deleted stale Service EndpointSlice", "endpointSlice", slice.Name)
+	}
+
+	return nil
+}
+
+// generateEndpointSlicesForService generates the EndpointSlices for a given Service.
+//
+// Each VM is an endpoint in an IPv4 EndpointSlice and an IPv6 EndpointSlice when it has a
+// primary IP of that family and the Service has that IP family. Endpoints with the same
+// ports are grouped into the same EndpointSlices, with at most MaxEndpointsPerSlice
+// endpoints in each. Unlike Endpoints, a VM that is being deleted is included as a
+// terminating endpoint.
+func (r *ReconcileVirtualMachineService) generateEndpointSlicesForService(
+	ctx *pkgctx.VirtualMachineServiceContext,
+	service *corev1.Service) ([]discoveryv1.EndpointSlice, error) {
+
+	vmList, err := r.getVirtualMachinesSelectedByVMService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type sliceKey struct {
+		addressType discoveryv1.AddressType
+		ports       string
+	}
+
+	var (
+		vmInSubsetsMap map[types.UID]struct{}
+		keys           []sliceKey
+		keyToPorts     = map[sliceKey][]discoveryv1.EndpointPort{}
+		keyToEndpoints = map[sliceKey][]discoveryv1.Endpoint{}
+		zoneHints      = serviceUsesTopologyHints(service)
+	)
+
+	for i := range vmList.Items {
+		vm := vmList.Items[i]
+		logger := ctx.Logger.WithValues("virtualMachine", vm.NamespacedName())
+
+		if vm.Status.Network == nil {
+			logger.V(5).Info("Skipping VM without network status")
+			continue
+		}
+
+		terminating := !vm.DeletionTimestamp.IsZero()
+		serving := isVMReady(&vm, func() bool {
+			if vmInSubsetsMap == nil {
+				vmInSubsetsMap = r.getVMsReferencedByServiceEndpoints(ctx, service)
+			}
+			_, ok := vmInSubsetsMap[vm.UID]
+			return ok
+		})
+
+		var ports []discoveryv1.EndpointPort
+		for _, servicePort := range service.Spec.Ports {
+			portNum, err := vmopv1util.FindPort(vm, servicePort.TargetPort, servicePort.Protocol)
+			if err != nil {
+				logger.Info("Failed to find port for service",
+					"name", servicePort.Name, "protocol", servicePort.Protocol, "error", err)
+				continue
+			}
+
+			ports = append(ports, discoveryv1.EndpointPort{
+				Name:     ptr.To(servicePort.Name),
+				Protocol: ptr.To(servicePort.Protocol),
+				Port:     ptr.To(int32(portNum)),
+			})
+		}
+		portsKey := endpointPortsKey(ports)
+
+		for _, family := range []struct {
+			ipFamily    corev1.IPFamily
+			addressType discoveryv1.AddressType
+			ip          string
+		}{
+			{corev1.IPv4Protocol, discoveryv1.AddressTypeIPv4, vm.Status.Network.PrimaryIP4},
+			{corev1.IPv6Protocol, discoveryv1.AddressTypeIPv6, vm.Status.Network.PrimaryIP6},
+		} {
+			if family.ip == "" || !serviceHasIPFamily(service, family.ipFamily) {
+				continue
+			}
+
+			ep := discoveryv1.Endpoint{
+				Addresses: []string{family.ip},
+				Conditions: discoveryv1.EndpointConditions{
+					Ready:       ptr.To(serving && !terminating),
+					Serving:     ptr.To(serving),
+					Terminating: ptr.To(terminating),
+				},
+				TargetRef: vmObjectReference(&vm),
+			}
+			if zone := vm.Labels[topology.KubernetesTopologyZoneLabelKey]; zone != "" {
+				ep.Zone = ptr.To(zone)
+				if zoneHints {
+					ep.Hints = &discoveryv1.EndpointHints{
+						ForZones: []discoveryv1.ForZone{{Name: zone}},
+					}
+				}
+			}
+
+			key := sliceKey{addressType: family.addressType, ports: portsKey}
+			if _, ok := keyToPorts[key]; !ok {
+				keys = append(keys, key)
+				keyToPorts[key] = ports
+			}
+			keyToEndpoints[key] = append(keyToEndpoints[key], ep)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].addressType != keys[j].addressType {
+			return keys[i].addressType < keys[j].addressType
+		}
+		return keys[i].ports < keys[j].ports
+	})
+
+	var endpointSlices []discoveryv1.EndpointSlice
+	sliceIndex := map[discoveryv1.AddressType]int{}
+
+	for _, key := range keys {
+		endpoints := keyToEndpoints[key]
+		sort.Slice(endpoints, func(i, j int) bool {
+			if endpoints[i].Addresses[0] != endpoints[j].Addresses[0] {
+				return endpoints[i].Addresses[0] < endpoints[j].Addresses[0]
+			}
+			return endpoints[i].TargetRef.UID < endpoints[j].TargetRef.UID
+		})
+
+		for start := 0; start < len(endpoints); start += MaxEndpointsPerSlice {
+			end := min(start+MaxEndpointsPerSlice, len(endpoints))
+			endpointSlices = append(endpointSlices, discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("%s-%s-%d",
+						service.Name, strings.ToLower(string(key.addressType)), sliceIndex[key.addressType]),
+					Namespace: service.Namespace,
+				},
+				AddressType: key.addressType,
+				Endpoints:   endpoints[start:end],
+				Ports:       keyToPorts[key],
+			})
+			sliceIndex[key.addressType]++
+		}
+	}
+
+	return endpointSlices, nil
+}
+
+// endpointPortsKey returns a string that uniquely identifies the ports.
+func endpointPortsKey(ports []discoveryv1.EndpointPort) string {
+	keys := make([]string, 0, len(ports))
+	for _, p := range ports {
+		keys = append(keys, fmt.Sprintf("%s/%s/%d", *p.Name, *p.Protocol, *p.Port))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
 // updateVMService syncs the VirtualMachineService Status from the Service status.
 //
 //nolint:unparam
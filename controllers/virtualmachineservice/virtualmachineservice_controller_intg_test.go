@@ -8,7 +8,9 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
@@ -56,7 +58,7 @@ func intgTestsReconcile() {
 			Name:       "port1",
 			Protocol:   "TCP",
 			Port:       42,
-			TargetPort: 142,
+			TargetPort: intstr.FromInt32(142),
 		}
 	})
 
@@ -241,10 +243,33 @@ func intgTestsReconcile() {
 					Expect(subset.Ports).To(HaveLen(1))
 					port := subset.Ports[0]
 					Expect(port.Name).To(Equal(port.Name))
-					Expect(port.Port).To(BeEquivalentTo(vmServicePort.TargetPort))
+					Expect(port.Port).To(Equal(vmServicePort.TargetPort.IntVal))
 					Expect(port.Protocol).To(BeEquivalentTo(corev1.ProtocolTCP))
 				})
 
+				By("EndpointSlice should be created", func() {
+					endpointSlice := &discoveryv1.EndpointSlice{}
+					sliceKey := client.ObjectKey{Namespace: vmService.Namespace, Name: vmService.Name + "-ipv4-0"}
+					Eventually(func(g Gomega) {
+						g.Expect(ctx.Client.Get(ctx, sliceKey, endpointSlice)).To(Succeed())
+						g.Expect(endpointSlice.Endpoints).To(HaveLen(2))
+					}).Should(Succeed())
+
+					Expect(endpointSlice.AddressType).To(Equal(discoveryv1.AddressTypeIPv4))
+					Expect(endpointSlice.Labels).To(HaveKeyWithValue(discoveryv1.LabelServiceName, vmService.Name))
+					Expect(endpointSlice.Ports).To(HaveLen(1))
+					Expect(endpointSlice.Ports[0].Port).To(HaveValue(Equal(vmServicePort.TargetPort.IntVal)))
+
+					for _, ep := range endpointSlice.Endpoints {
+						Expect(ep.TargetRef).ToNot(BeNil())
+						Expect(ep.Conditions.Ready).To(HaveValue(Equal(ep.TargetRef.Name == readyVM.Name)))
+					}
+
+					endpoints := &corev1.Endpoints{}
+					Expect(ctx.Client.Get(ctx, objKey, endpoints)).To(Succeed())
+					Expect(endpoints.Labels).To(HaveKeyWithValue(discoveryv1.LabelSkipMirror, "true"))
+				})
+
 				By("Deleted VM should be removed from Endpoints", func() {
 					// Must add finalizer here so that the VM does not get deleted immediately, as our
 					// VM mapping function assumes that the VM exists. This is a bug, and should have
@@ -364,7 +389,7 @@ func intgTestsReconcile() {
 					Expect(subset.Ports).To(HaveLen(1))
 					port := subset.Ports[0]
 					Expect(port.Name).To(Equal(port.Name))
-					Expect(port.Port).To(BeEquivalentTo(vmServicePort.TargetPort))
+					Expect(port.Port).To(Equal(vmServicePort.TargetPort.IntVal))
 					Expect(port.Protocol).To(BeEquivalentTo(corev1.ProtocolTCP))
 				})
 
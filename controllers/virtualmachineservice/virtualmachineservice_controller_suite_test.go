@@ -15,7 +15,12 @@ import (
 )
 
 var suite = builder.NewTestSuiteForControllerWithContext(
-	pkgcfg.NewContextWithDefaultConfig(),
+	pkgcfg.UpdateContext(
+		pkgcfg.NewContextWithDefaultConfig(),
+		func(config *pkgcfg.Config) {
+			config.Features.VMServiceEndpointSlices = true
+		},
+	),
 	virtualmachineservice.AddToManager,
 	manager.InitializeProvidersNoopFn)
 
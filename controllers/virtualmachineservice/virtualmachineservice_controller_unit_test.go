@@ -4,15 +4,19 @@
 package virtualmachineservice_test
 
 import (
+	"fmt"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/onsi/gomega/types"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	apiEquality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
@@ -20,8 +24,10 @@ import (
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice/providers"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice/utils"
 	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
@@ -50,8 +56,9 @@ const LabelServiceProxyName = "service.kubernetes.io/service-proxy-name"
 
 func unitTestsReconcile() {
 	var (
-		initObjects []client.Object
-		ctx         *builder.UnitTestContextForController
+		initObjects   []client.Object
+		useExternalLB bool
+		ctx           *builder.UnitTestContextForController
 
 		reconciler   *virtualmachineservice.ReconcileVirtualMachineService
 		vmServiceCtx *pkgctx.VirtualMachineServiceContext
@@ -100,14 +107,14 @@ func unitTestsReconcile() {
 			Name:       "port1",
 			Protocol:   "TCP",
 			Port:       42,
-			TargetPort: 142,
+			TargetPort: intstr.FromInt32(142),
 		}
 
 		vmServicePort2 = vmopv1.VirtualMachineServicePort{
 			Name:       "port2",
 			Protocol:   "UDP",
 			Port:       1042,
-			TargetPort: 1142,
+			TargetPort: intstr.FromInt32(1142),
 		}
 
 		lbSourceRanges = []string{"1.1.1.0/24", "2.2.0.0/16"}
@@ -117,12 +124,18 @@ func unitTestsReconcile() {
 
 	JustBeforeEach(func() {
 		ctx = suite.NewUnitTestContextForController(initObjects...)
+
+		var lbProvider providers.LoadbalancerProvider = providers.NoopLoadbalancerProvider{}
+		if useExternalLB {
+			lbProvider = providers.NewExternalLoadbalancerProvider(ctx.Client, ctx.Client)
+		}
+
 		reconciler = virtualmachineservice.NewReconciler(
 			ctx,
 			ctx.Client,
 			ctx.Logger,
 			ctx.Recorder,
-			providers.NoopLoadbalancerProvider{},
+			lbProvider,
 		)
 
 		vmServiceCtx = &pkgctx.VirtualMachineServiceContext{
@@ -136,6 +149,7 @@ func unitTestsReconcile() {
 		ctx.AfterEach()
 		ctx = nil
 		initObjects = nil
+		useExternalLB = false
 		vmServiceCtx = nil
 		reconciler = nil
 	})
@@ -191,6 +205,79 @@ func unitTestsReconcile() {
 				Expect(service.Spec.LoadBalancerSourceRanges).To(ContainElements(lbSourceRanges))
 				Expect(service.Spec.AllocateLoadBalancerNodePorts).ToNot(BeNil())
 				Expect(*service.Spec.AllocateLoadBalancerNodePorts).To(BeFalse())
+				Expect(service.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityNone))
+				Expect(service.Spec.SessionAffinityConfig).To(BeNil())
+				Expect(service.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyTypeCluster))
+				Expect(service.Spec.InternalTrafficPolicy).To(HaveValue(Equal(corev1.ServiceInternalTrafficPolicyCluster)))
+				Expect(service.Spec.HealthCheckNodePort).To(BeZero())
+			})
+
+			Context("With an external load balancer provider", func() {
+				BeforeEach(func() {
+					useExternalLB = true
+					initObjects = append(initObjects, &corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      providers.ExternalLoadBalancerConfigMapName,
+							Namespace: pkgcfg.Default().PodNamespace,
+						},
+						Data: map[string]string{
+							providers.ExternalLoadBalancerClassKey:       "my-lb-class",
+							providers.ExternalLoadBalancerAnnotationsKey: "my-lb-annotation: my-value",
+						},
+					})
+				})
+
+				It("Service LoadBalancerClass and Annotations", func() {
+					Expect(service.Spec.LoadBalancerClass).To(HaveValue(Equal("my-lb-class")))
+					Expect(service.Annotations).To(HaveKeyWithValue("my-lb-annotation", "my-value"))
+				})
+
+				When("the load balancer class is changed", func() {
+					It("Service keeps its load balancer class and a warning is emitted", func() {
+						configMap := &corev1.ConfigMap{}
+						Expect(ctx.Client.Get(ctx, client.ObjectKey{
+							Namespace: pkgcfg.Default().PodNamespace,
+							Name:      providers.ExternalLoadBalancerConfigMapName,
+						}, configMap)).To(Succeed())
+						configMap.Data[providers.ExternalLoadBalancerClassKey] = "new-lb-class"
+						Expect(ctx.Client.Update(ctx, configMap)).To(Succeed())
+
+						Expect(reconciler.ReconcileNormal(vmServiceCtx)).To(Succeed())
+						Expect(ctx.Client.Get(ctx, objKey, service)).To(Succeed())
+						Expect(service.Spec.LoadBalancerClass).To(HaveValue(Equal("my-lb-class")))
+						Eventually(ctx.Events).Should(Receive(ContainSubstring(virtualmachineservice.LoadBalancerClassMismatchReason)))
+					})
+				})
+
+				When("an annotation is removed from the ConfigMap", func() {
+					It("annotation is removed from the Service", func() {
+						configMap := &corev1.ConfigMap{}
+						Expect(ctx.Client.Get(ctx, client.ObjectKey{
+							Namespace: pkgcfg.Default().PodNamespace,
+							Name:      providers.ExternalLoadBalancerConfigMapName,
+						}, configMap)).To(Succeed())
+						configMap.Data[providers.ExternalLoadBalancerAnnotationsKey] = "new-lb-annotation: new-value"
+						Expect(ctx.Client.Update(ctx, configMap)).To(Succeed())
+
+						Expect(reconciler.ReconcileNormal(vmServiceCtx)).To(Succeed())
+						Expect(ctx.Client.Get(ctx, objKey, service)).To(Succeed())
+						Expect(service.Annotations).ToNot(HaveKey("my-lb-annotation"))
+						Expect(service.Annotations).To(HaveKeyWithValue("new-lb-annotation", "new-value"))
+						Expect(vmService.Annotations).ToNot(HaveKey("my-lb-annotation"))
+					})
+				})
+			})
+
+			Context("With IPFamilies and IPFamilyPolicy", func() {
+				BeforeEach(func() {
+					vmService.Spec.IPFamilies = []vmopv1.IPFamily{vmopv1.IPv6Protocol, vmopv1.IPv4Protocol}
+					vmService.Spec.IPFamilyPolicy = ptr.To(vmopv1.IPFamilyPolicyRequireDualStack)
+				})
+
+				It("Service IPFamilies and IPFamilyPolicy", func() {
+					Expect(service.Spec.IPFamilies).To(Equal([]corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}))
+					Expect(service.Spec.IPFamilyPolicy).To(HaveValue(Equal(corev1.IPFamilyPolicyRequireDualStack)))
+				})
 			})
 
 			Context("With Expected Spec.Ports", func() {
@@ -209,13 +296,13 @@ func unitTestsReconcile() {
 					Expect(port.Name).To(Equal(vmServicePort1.Name))
 					Expect(port.Protocol).To(BeEquivalentTo(vmServicePort1.Protocol))
 					Expect(port.Port).To(Equal(vmServicePort1.Port))
-					Expect(port.TargetPort.IntValue()).To(Equal(int(vmServicePort1.TargetPort)))
+					Expect(port.TargetPort).To(Equal(vmServicePort1.TargetPort))
 
 					port = ports[1]
 					Expect(port.Name).To(Equal(vmServicePort2.Name))
 					Expect(port.Protocol).To(BeEquivalentTo(vmServicePort2.Protocol))
 					Expect(port.Port).To(Equal(vmServicePort2.Port))
-					Expect(port.TargetPort.IntValue()).To(Equal(int(vmServicePort2.TargetPort)))
+					Expect(port.TargetPort).To(Equal(vmServicePort2.TargetPort))
 				})
 			})
 
@@ -254,6 +341,43 @@ func unitTestsReconcile() {
 					Expect(service.Annotations).To(HaveKeyWithValue(utils.AnnotationServiceHealthCheckNodePortKey, "99"))
 				})
 			})
+
+			Context("With SessionAffinity and traffic policies", func() {
+				BeforeEach(func() {
+					vmService.Spec.SessionAffinity = vmopv1.ServiceAffinityClientIP
+					vmService.Spec.SessionAffinityConfig = &vmopv1.SessionAffinityConfig{
+						ClientIP: &vmopv1.ClientIPConfig{
+							TimeoutSeconds: ptr.To[int32](600),
+						},
+					}
+					vmService.Spec.ExternalTrafficPolicy = vmopv1.ServiceExternalTrafficPolicyLocal
+					vmService.Spec.InternalTrafficPolicy = ptr.To(vmopv1.ServiceInternalTrafficPolicyLocal)
+					vmService.Spec.HealthCheckNodePort = 30012
+				})
+
+				It("Expected values", func() {
+					Expect(service.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityClientIP))
+					Expect(service.Spec.SessionAffinityConfig).ToNot(BeNil())
+					Expect(service.Spec.SessionAffinityConfig.ClientIP).ToNot(BeNil())
+					Expect(service.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds).To(HaveValue(BeEquivalentTo(600)))
+					Expect(service.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyTypeLocal))
+					Expect(service.Spec.InternalTrafficPolicy).To(HaveValue(Equal(corev1.ServiceInternalTrafficPolicyLocal)))
+					Expect(service.Spec.HealthCheckNodePort).To(BeEquivalentTo(30012))
+				})
+
+				When("the ExternalTrafficPolicy annotation is also specified", func() {
+					BeforeEach(func() {
+						vmService.Spec.ExternalTrafficPolicy = vmopv1.ServiceExternalTrafficPolicyCluster
+						vmService.Spec.HealthCheckNodePort = 0
+						vmService.Annotations[utils.AnnotationServiceExternalTrafficPolicyKey] = string(corev1.ServiceExternalTrafficPolicyTypeLocal)
+					})
+
+					It("ExternalTrafficPolicy field takes precedence", func() {
+						Expect(service.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyTypeCluster))
+						Expect(service.Spec.HealthCheckNodePort).To(BeZero())
+					})
+				})
+			})
 		})
 
 		Context("Service Exists", func() {
@@ -390,7 +514,7 @@ func unitTestsReconcile() {
 					Expect(port.Name).To(Equal(vmServicePort1.Name))
 					Expect(port.Protocol).To(BeEquivalentTo(vmServicePort1.Protocol))
 					Expect(port.Port).To(Equal(vmServicePort1.Port))
-					Expect(port.TargetPort.IntValue()).To(Equal(int(vmServicePort1.TargetPort)))
+					Expect(port.TargetPort).To(Equal(vmServicePort1.TargetPort))
 					Expect(port.NodePort).To(BeNumerically("==", 10000))
 				})
 			})
@@ -532,6 +656,52 @@ func unitTestsReconcile() {
 						Expect(endpoints.Subsets).To(BeEmpty())
 					})
 				})
+
+				Context("When the target port is a named port", func() {
+					BeforeEach(func() {
+						vmService.Spec.Ports[0].TargetPort = intstr.FromString("my-port")
+						vm1.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Ports: []vmopv1.VirtualMachineNetworkPort{
+								{
+									Name: "my-port",
+									Port: 8080,
+								},
+							},
+						}
+					})
+
+					It("With the port number from the VM", func() {
+						Expect(endpoints.Subsets).To(HaveLen(1))
+						subset := endpoints.Subsets[0]
+						Expect(subset.Ports).To(HaveLen(1))
+						Expect(subset.Ports[0].Name).To(Equal(vmServicePort1.Name))
+						Expect(subset.Ports[0].Port).To(BeEquivalentTo(8080))
+					})
+				})
+
+				Context("When the Service's primary IP family is IPv6", func() {
+					BeforeEach(func() {
+						vmService.Spec.IPFamilies = []vmopv1.IPFamily{vmopv1.IPv6Protocol, vmopv1.IPv4Protocol}
+						vm1.Status.Network.PrimaryIP6 = "fd00::1"
+					})
+
+					It("With the VM's IPv6 address", func() {
+						Expect(endpoints.Subsets).To(HaveLen(1))
+						subset := endpoints.Subsets[0]
+						Expect(subset.Addresses).To(HaveLen(1))
+						Expect(subset.Addresses[0].IP).To(Equal("fd00::1"))
+					})
+
+					When("VM does not have an IPv6 address", func() {
+						BeforeEach(func() {
+							vm1.Status.Network.PrimaryIP6 = ""
+						})
+
+						It("Not included in Subsets", func() {
+							Expect(endpoints.Subsets).To(BeEmpty())
+						})
+					})
+				})
 			})
 
 			Context("When multiple VMs match label selector", func() {
@@ -641,6 +811,30 @@ func unitTestsReconcile() {
 				})
 			})
 
+			Context("When VMs have HTTP GET Readiness Probe", func() {
+				BeforeEach(func() {
+					vm1.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+						HTTPGet: &vmopv1.HTTPGetAction{},
+					}
+					vm2.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+						HTTPGet: &vmopv1.HTTPGetAction{},
+					}
+					conditions.MarkTrue(vm1, vmopv1.ReadyConditionType)
+
+					initObjects = append(initObjects, vm1, vm2)
+				})
+
+				It("Only VMs with true Ready condition are included in Addresses", func() {
+					Expect(endpoints.Subsets).To(HaveLen(1))
+					subset := endpoints.Subsets[0]
+
+					Expect(subset.Addresses).To(HaveLen(1))
+					assertEPAddrFromVM(subset.Addresses[0], vm1)
+					Expect(subset.NotReadyAddresses).To(HaveLen(1))
+					assertEPAddrFromVM(subset.NotReadyAddresses[0], vm2)
+				})
+			})
+
 			Context("Preserve VMs in Endpoints that have Probe but hasn't run yet", func() {
 				BeforeEach(func() {
 					vm1.UID = "abc"
@@ -687,6 +881,234 @@ func unitTestsReconcile() {
 			})
 		})
 
+		Context("Creates expected EndpointSlices", func() {
+			var (
+				endpointSlices *discoveryv1.EndpointSliceList
+				vm1, vm2       *vmopv1.VirtualMachine
+			)
+
+			getSlice := func(name string) *discoveryv1.EndpointSlice {
+				for i := range endpointSlices.Items {
+					if endpointSlices.Items[i].Name == name {
+						return &endpointSlices.Items[i]
+					}
+				}
+				return nil
+			}
+
+			BeforeEach(func() {
+				vmLabels := map[string]string{"my-app": "dummy-label"}
+
+				vmService.Labels[labelName1] = "bar2"
+				vmService.Spec.Selector = vmLabels
+				vmService.Spec.Ports = []vmopv1.VirtualMachineServicePort{
+					vmServicePort1,
+				}
+
+				vm1 = &vmopv1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "dummy-vm1",
+						Namespace: vmService.Namespace,
+						UID:       "vm1-uid",
+						Labels: map[string]string{
+							"my-app":                                "dummy-label",
+							topology.KubernetesTopologyZoneLabelKey: "zone-a",
+						},
+					},
+					Status: vmopv1.VirtualMachineStatus{
+						Network: &vmopv1.VirtualMachineNetworkStatus{
+							PrimaryIP4: "1.1.1.1",
+							PrimaryIP6: "fd00::1",
+						},
+					},
+				}
+
+				vm2 = &vmopv1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "dummy-vm2",
+						Namespace: vmService.Namespace,
+						UID:       "vm2-uid",
+						Labels:    vmLabels,
+					},
+					Spec: vmopv1.VirtualMachineSpec{
+						ReadinessProbe: &vmopv1.VirtualMachineReadinessProbeSpec{
+							TCPSocket: &vmopv1.TCPSocketAction{},
+						},
+					},
+					Status: vmopv1.VirtualMachineStatus{
+						Network: &vmopv1.VirtualMachineNetworkStatus{
+							PrimaryIP4: "2.2.2.2",
+						},
+					},
+				}
+				conditions.MarkFalse(vm2, vmopv1.ReadyConditionType, "reason", "")
+
+				initObjects = append(initObjects, vm1, vm2)
+			})
+
+			JustBeforeEach(func() {
+				pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+					config.Features.VMServiceEndpointSlices = true
+				})
+
+				Expect(reconciler.ReconcileNormal(vmServiceCtx)).To(Succeed())
+
+				endpointSlices = &discoveryv1.EndpointSliceList{}
+				Expect(ctx.Client.List(ctx, endpointSlices, client.InNamespace(vmService.Namespace))).To(Succeed())
+			})
+
+			It("Creates an EndpointSlice for each address family", func() {
+				Expect(endpointSlices.Items).To(HaveLen(2))
+
+				ipv4Slice := getSlice(vmService.Name + "-ipv4-0")
+				Expect(ipv4Slice).ToNot(BeNil())
+				Expect(ipv4Slice.AddressType).To(Equal(discoveryv1.AddressTypeIPv4))
+				Expect(ipv4Slice.OwnerReferences).To(HaveLen(1))
+				Expect(ipv4Slice.OwnerReferences[0].Name).To(Equal(vmService.Name))
+				Expect(ipv4Slice.Labels).To(HaveKeyWithValue(discoveryv1.LabelServiceName, vmService.Name))
+				Expect(ipv4Slice.Labels).To(HaveKeyWithValue(discoveryv1.LabelManagedBy, virtualmachineservice.EndpointSliceManagedBy))
+				Expect(ipv4Slice.Labels).To(HaveKeyWithValue(labelName1, "bar2"))
+
+				Expect(ipv4Slice.Ports).To(HaveLen(1))
+				Expect(ipv4Slice.Ports[0].Name).To(HaveValue(Equal(vmServicePort1.Name)))
+				Expect(ipv4Slice.Ports[0].Protocol).To(HaveValue(BeEquivalentTo(vmServicePort1.Protocol)))
+				Expect(ipv4Slice.Ports[0].Port).To(HaveValue(Equal(vmServicePort1.TargetPort.IntVal)))
+
+				Expect(ipv4Slice.Endpoints).To(HaveLen(2))
+				ep1, ep2 := ipv4Slice.Endpoints[0], ipv4Slice.Endpoints[1]
+				Expect(ep1.Addresses).To(ConsistOf("1.1.1.1"))
+				Expect(ep1.TargetRef).ToNot(BeNil())
+				Expect(ep1.TargetRef.Name).To(Equal(vm1.Name))
+				Expect(ep1.Conditions.Ready).To(HaveValue(BeTrue()))
+				Expect(ep1.Conditions.Serving).To(HaveValue(BeTrue()))
+				Expect(ep1.Conditions.Terminating).To(HaveValue(BeFalse()))
+				Expect(ep1.Zone).To(HaveValue(Equal("zone-a")))
+				Expect(ep1.Hints).To(BeNil())
+
+				Expect(ep2.Addresses).To(ConsistOf("2.2.2.2"))
+				Expect(ep2.TargetRef.Name).To(Equal(vm2.Name))
+				Expect(ep2.Conditions.Ready).To(HaveValue(BeFalse()))
+				Expect(ep2.Conditions.Serving).To(HaveValue(BeFalse()))
+				Expect(ep2.Zone).To(BeNil())
+				Expect(ep2.Hints).To(BeNil())
+
+				ipv6Slice := getSlice(vmService.Name + "-ipv6-0")
+				Expect(ipv6Slice).ToNot(BeNil())
+				Expect(ipv6Slice.AddressType).To(Equal(discoveryv1.AddressTypeIPv6))
+				Expect(ipv6Slice.Endpoints).To(HaveLen(1))
+				Expect(ipv6Slice.Endpoints[0].Addresses).To(ConsistOf("fd00::1"))
+			})
+
+			It("Skips mirroring the Endpoints", func() {
+				endpoints := &corev1.Endpoints{}
+				Expect(ctx.Client.Get(ctx, objKey, endpoints)).To(Succeed())
+				Expect(endpoints.Labels).To(HaveKeyWithValue(discoveryv1.LabelSkipMirror, "true"))
+				Expect(endpoints.Labels).To(HaveKeyWithValue(labelName1, "bar2"))
+
+				service := &corev1.Service{}
+				Expect(ctx.Client.Get(ctx, objKey, service)).To(Succeed())
+				Expect(service.Labels).ToNot(HaveKey(discoveryv1.LabelSkipMirror))
+			})
+
+			When("a VM is being deleted", func() {
+				BeforeEach(func() {
+					vm1.DeletionTimestamp = ptr.To(metav1.Now())
+					vm1.Finalizers = []string{"dummy-finalizer"}
+				})
+
+				It("Includes the VM as a terminating endpoint", func() {
+					ipv4Slice := getSlice(vmService.Name + "-ipv4-0")
+					Expect(ipv4Slice).ToNot(BeNil())
+					Expect(ipv4Slice.Endpoints).To(HaveLen(2))
+					ep1 := ipv4Slice.Endpoints[0]
+					Expect(ep1.TargetRef.Name).To(Equal(vm1.Name))
+					Expect(ep1.Conditions.Ready).To(HaveValue(BeFalse()))
+					Expect(ep1.Conditions.Serving).To(HaveValue(BeTrue()))
+					Expect(ep1.Conditions.Terminating).To(HaveValue(BeTrue()))
+
+					endpoints := &corev1.Endpoints{}
+					Expect(ctx.Client.Get(ctx, objKey, endpoints)).To(Succeed())
+					Expect(endpoints.Subsets).To(HaveLen(1))
+					Expect(endpoints.Subsets[0].Addresses).To(BeEmpty())
+					Expect(endpoints.Subsets[0].NotReadyAddresses).To(HaveLen(1))
+					assertEPAddrFromVM(endpoints.Subsets[0].NotReadyAddresses[0], vm2)
+				})
+			})
+
+			When("there are more VMs than fit in one EndpointSlice", func() {
+				BeforeEach(func() {
+					for i := 0; i < virtualmachineservice.MaxEndpointsPerSlice; i++ {
+						vm := vm2.DeepCopy()
+						vm.Name = fmt.Sprintf("dummy-vm-%d", i)
+						vm.Status.Network.PrimaryIP4 = fmt.Sprintf("10.0.%d.%d", i/256, i%256)
+						initObjects = append(initObjects, vm)
+					}
+				})
+
+				It("Splits the endpoints across EndpointSlices", func() {
+					Expect(endpointSlices.Items).To(HaveLen(3))
+					Expect(getSlice(vmService.Name + "-ipv4-0").Endpoints).To(HaveLen(virtualmachineservice.MaxEndpointsPerSlice))
+					Expect(getSlice(vmService.Name + "-ipv4-1").Endpoints).To(HaveLen(2))
+				})
+			})
+
+			When("the Service has a single IP family", func() {
+				BeforeEach(func() {
+					vmService.Spec.IPFamilies = []vmopv1.IPFamily{vmopv1.IPv6Protocol}
+					vmService.Spec.IPFamilyPolicy = ptr.To(vmopv1.IPFamilyPolicySingleStack)
+				})
+
+				It("Only creates an EndpointSlice for that address family", func() {
+					Expect(endpointSlices.Items).To(HaveLen(1))
+					ipv6Slice := getSlice(vmService.Name + "-ipv6-0")
+					Expect(ipv6Slice).ToNot(BeNil())
+					Expect(ipv6Slice.Endpoints).To(HaveLen(1))
+					Expect(ipv6Slice.Endpoints[0].Addresses).To(ConsistOf("fd00::1"))
+				})
+			})
+
+			When("the Service uses topology aware routing", func() {
+				BeforeEach(func() {
+					if vmService.Annotations == nil {
+						vmService.Annotations = map[string]string{}
+					}
+					vmService.Annotations[corev1.AnnotationTopologyMode] = "Auto"
+				})
+
+				It("Sets the zone hints of the endpoints", func() {
+					ipv4Slice := getSlice(vmService.Name + "-ipv4-0")
+					Expect(ipv4Slice).ToNot(BeNil())
+					Expect(ipv4Slice.Endpoints).To(HaveLen(2))
+					Expect(ipv4Slice.Endpoints[0].Hints).ToNot(BeNil())
+					Expect(ipv4Slice.Endpoints[0].Hints.ForZones).To(ConsistOf(discoveryv1.ForZone{Name: "zone-a"}))
+					Expect(ipv4Slice.Endpoints[1].Hints).To(BeNil())
+				})
+			})
+
+			When("an EndpointSlice is no longer needed", func() {
+				BeforeEach(func() {
+					vm1.Status.Network.PrimaryIP6 = ""
+
+					initObjects = append(initObjects, &discoveryv1.EndpointSlice{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      vmService.Name + "-ipv6-0",
+							Namespace: vmService.Namespace,
+							Labels: map[string]string{
+								discoveryv1.LabelServiceName: vmService.Name,
+								discoveryv1.LabelManagedBy:   virtualmachineservice.EndpointSliceManagedBy,
+							},
+						},
+						AddressType: discoveryv1.AddressTypeIPv6,
+					})
+				})
+
+				It("Deletes the EndpointSlice", func() {
+					Expect(endpointSlices.Items).To(HaveLen(1))
+					Expect(getSlice(vmService.Name + "-ipv4-0")).ToNot(BeNil())
+				})
+			})
+		})
+
 		Context("Selectorless VirtualMachineService", func() {
 			var vm1 *vmopv1.VirtualMachine
 			var labelSelector, vmLabels map[string]string
@@ -788,6 +1210,36 @@ func unitTestsReconcile() {
 				err = ctx.Client.Get(ctx, objKey, service)
 				Expect(errors.IsNotFound(err)).To(BeTrue())
 			})
+
+			When("EndpointSlices are enabled", func() {
+				BeforeEach(func() {
+					initObjects = append(initObjects, &discoveryv1.EndpointSlice{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      vmService.Name + "-ipv4-0",
+							Namespace: vmService.Namespace,
+							Labels: map[string]string{
+								discoveryv1.LabelServiceName: vmService.Name,
+								discoveryv1.LabelManagedBy:   virtualmachineservice.EndpointSliceManagedBy,
+							},
+						},
+						AddressType: discoveryv1.AddressTypeIPv4,
+					})
+				})
+
+				JustBeforeEach(func() {
+					pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+						config.Features.VMServiceEndpointSlices = true
+					})
+				})
+
+				It("Deletes the EndpointSlices", func() {
+					Expect(reconciler.ReconcileDelete(vmServiceCtx)).To(Succeed())
+
+					endpointSlices := &discoveryv1.EndpointSliceList{}
+					Expect(ctx.Client.List(ctx, endpointSlices, client.InNamespace(vmService.Namespace))).To(Succeed())
+					Expect(endpointSlices.Items).To(BeEmpty())
+				})
+			})
 		})
 	})
 }
@@ -823,7 +1275,7 @@ func nsxtLBProviderTestsReconcile() {
 			Name:       "port1",
 			Protocol:   "TCP",
 			Port:       42,
-			TargetPort: 142,
+			TargetPort: intstr.FromInt32(142),
 		}
 
 		vmService = &vmopv1.VirtualMachineService{
@@ -929,6 +1381,26 @@ func nsxtLBProviderTestsReconcile() {
 				Expect(newService.Spec.LoadBalancerSourceRanges).To(BeEmpty())
 			})
 
+			It("Should update the k8s Service to match with the VirtualMachineService when sessionAffinity is cleared", func() {
+				service.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+				service.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+					ClientIP: &corev1.ClientIPConfig{
+						TimeoutSeconds: ptr.To[int32](600),
+					},
+				}
+				Expect(ctx.Client.Update(ctx, service)).To(Succeed())
+
+				err := reconciler.ReconcileNormal(vmServiceCtx)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				expectEvent(ctx, ContainSubstring(virtualmachineservice.OpUpdate))
+
+				newService := &corev1.Service{}
+				Expect(ctx.Client.Get(ctx, objKey, newService)).To(Succeed())
+				Expect(newService.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityNone))
+				Expect(newService.Spec.SessionAffinityConfig).To(BeNil())
+			})
+
 			It("Should update the k8s Service to match with the VirtualMachineService when externalTrafficPolicy is cleared", func() {
 				if service.Annotations == nil {
 					service.Annotations = make(map[string]string)
@@ -1005,7 +1477,7 @@ func assertEPPortFromVMServicePort(
 
 	ExpectWithOffset(1, port.Name).To(Equal(vmServicePort.Name))
 	ExpectWithOffset(1, port.Protocol).To(BeEquivalentTo(vmServicePort.Protocol))
-	ExpectWithOffset(1, port.Port).To(Equal(vmServicePort.TargetPort))
+	ExpectWithOffset(1, port.Port).To(Equal(vmServicePort.TargetPort.IntVal))
 }
 
 func assertEPAddrFromVM(
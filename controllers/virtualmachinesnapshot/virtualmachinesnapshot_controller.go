@@ -0,0 +1,292 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinesnapshot
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	vspherevm "github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+)
+
+const (
+	finalizerName = "vmoperator.vmware.com/virtualmachinesnapshot"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineSnapshot{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProvider,
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Watches(&vmopv1.VirtualMachine{},
+			handler.EnqueueRequestsFromMapFunc(vmToVMSnapshotMapperFn(ctx, r.Client))).
+		Complete(r)
+}
+
+// vmToVMSnapshotMapperFn returns a mapper function that can be used to queue
+// reconcile requests for the VirtualMachineSnapshots in response to an event
+// on the VirtualMachine resource they reference.
+func vmToVMSnapshotMapperFn(ctx *pkgctx.ControllerManagerContext, c client.Client) func(_ context.Context, o client.Object) []reconcile.Request {
+	return func(_ context.Context, o client.Object) []reconcile.Request {
+		vm := o.(*vmopv1.VirtualMachine)
+		logger := ctx.Logger.WithValues("name", vm.Name, "namespace", vm.Namespace)
+
+		vmSnapshotList := &vmopv1.VirtualMachineSnapshotList{}
+		if err := c.List(ctx, vmSnapshotList, client.InNamespace(vm.Namespace)); err != nil {
+			logger.Error(err, "Failed to list VirtualMachineSnapshots for reconciliation due to VirtualMachine watch")
+			return nil
+		}
+
+		var reconcileRequests []reconcile.Request
+		for _, vmSnapshot := range vmSnapshotList.Items {
+			if vmSnapshot.Spec.VMRef != nil && vmSnapshot.Spec.VMRef.Name == vm.Name {
+				key := client.ObjectKey{Namespace: vmSnapshot.Namespace, Name: vmSnapshot.Name}
+				reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: key})
+			}
+		}
+
+		logger.V(4).Info("Returning VirtualMachineSnapshot reconcile requests due to VirtualMachine watch",
+			"requests", reconcileRequests)
+		return reconcileRequests
+	}
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider providers.VirtualMachineProviderInterface) *Reconciler {
+
+	return &Reconciler{
+		Context:    ctx,
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineSnapshot object.
+type Reconciler struct {
+	client.Client
+	Context    context.Context
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider providers.VirtualMachineProviderInterface
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesnapshots,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesnapshots/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	vmSnapshot := &vmopv1.VirtualMachineSnapshot{}
+	if err := r.Get(ctx, req.NamespacedName, vmSnapshot); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	vmSnapshotCtx := &pkgctx.VirtualMachineSnapshotContext{
+		Context:                ctx,
+		Logger:                 ctrl.Log.WithName("VirtualMachineSnapshot").WithValues("name", req.NamespacedName),
+		VirtualMachineSnapshot: vmSnapshot,
+	}
+
+	patchHelper, err := patch.NewHelper(vmSnapshot, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", vmSnapshotCtx, err)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, vmSnapshot); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			vmSnapshotCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !vmSnapshot.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.ReconcileDelete(vmSnapshotCtx)
+	}
+
+	return ctrl.Result{}, r.ReconcileNormal(vmSnapshotCtx)
+}
+
+func (r *Reconciler) ReconcileDelete(ctx *pkgctx.VirtualMachineSnapshotContext) error {
+	if !controllerutil.ContainsFinalizer(ctx.VirtualMachineSnapshot, finalizerName) {
+		return nil
+	}
+
+	ctx.Logger.Info("Deleting VirtualMachineSnapshot")
+
+	vm, err := r.getVirtualMachine(ctx)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	// When the VirtualMachine no longer exists its snapshots went with it.
+	if vm != nil {
+		if err := r.VMProvider.DeleteVirtualMachineSnapshot(ctx, vm, ctx.VirtualMachineSnapshot); err != nil {
+			r.Recorder.EmitEvent(ctx.VirtualMachineSnapshot, "Delete", err, false)
+			return fmt.Errorf("failed to delete snapshot: %w", err)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(ctx.VirtualMachineSnapshot, finalizerName)
+	ctx.Logger.Info("Deleted VirtualMachineSnapshot")
+
+	return nil
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineSnapshotContext) error {
+	vmSnapshot := ctx.VirtualMachineSnapshot
+
+	if !controllerutil.ContainsFinalizer(vmSnapshot, finalizerName) {
+		// The finalizer must be present before proceeding in order to ensure
+		// the snapshot is removed from the VM when this object is deleted.
+		// Return immediately after here to let the patcher helper update the
+		// object, and then we'll proceed on the next reconciliation.
+		controllerutil.AddFinalizer(vmSnapshot, finalizerName)
+		return nil
+	}
+
+	ctx.Logger.Info("Reconciling VirtualMachineSnapshot")
+	defer func() {
+		ctx.Logger.Info("Finished reconciling VirtualMachineSnapshot")
+	}()
+
+	vm, err := r.getVirtualMachine(ctx)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			conditions.MarkFalse(
+				vmSnapshot,
+				vmopv1.VirtualMachineSnapshotReadyCondition,
+				vmopv1.VirtualMachineSnapshotVMNotFoundReason,
+				"VirtualMachine %s not found", vmSnapshot.Spec.VMRef.Name)
+			return nil
+		}
+		return err
+	}
+	ctx.VM = vm
+
+	if err := controllerutil.SetOwnerReference(vm, vmSnapshot, r.Client.Scheme()); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+
+	if vm.Status.UniqueID == "" {
+		conditions.MarkFalse(
+			vmSnapshot,
+			vmopv1.VirtualMachineSnapshotReadyCondition,
+			vmopv1.VirtualMachineSnapshotVMNotCreatedReason,
+			"VirtualMachine %s has not been created", vm.Name)
+		return nil
+	}
+
+	if vmSnapshot.Status.UniqueID == "" {
+		snapshotID, err := r.VMProvider.CreateVirtualMachineSnapshot(ctx, vm, vmSnapshot)
+		r.Recorder.EmitEvent(vmSnapshot, "Create", err, false)
+		if err != nil {
+			conditions.MarkFalse(
+				vmSnapshot,
+				vmopv1.VirtualMachineSnapshotReadyCondition,
+				vmopv1.VirtualMachineSnapshotCreateFailedReason,
+				"%v", err)
+			return fmt.Errorf("failed to create snapshot: %w", err)
+		}
+
+		vmSnapshot.Status.UniqueID = snapshotID
+		vmSnapshot.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+		if vmSnapshot.Spec.Memory || vm.Status.PowerState == vmopv1.VirtualMachinePowerStateSuspended {
+			vmSnapshot.Status.PowerState = vm.Status.PowerState
+		}
+	}
+
+	conditions.MarkTrue(vmSnapshot, vmopv1.VirtualMachineSnapshotReadyCondition)
+
+	return r.reconcileSnapshotTree(ctx)
+}
+
+// reconcileSnapshotTree updates the status of the VirtualMachineSnapshot with
+// the snapshot's information from the VM's snapshot tree.
+func (r *Reconciler) reconcileSnapshotTree(ctx *pkgctx.VirtualMachineSnapshotContext) error {
+	vmSnapshot := ctx.VirtualMachineSnapshot
+
+	info, err := r.VMProvider.GetVirtualMachineSnapshotInfo(ctx, ctx.VM)
+	if err != nil {
+		return fmt.Errorf("failed to get snapshot info: %w", err)
+	}
+	if info == nil {
+		return nil
+	}
+
+	tree := vspherevm.FindSnapshotTree(info.RootSnapshotList, vmSnapshot.Status.UniqueID)
+	if tree == nil {
+		return nil
+	}
+
+	vmSnapshot.Status.Quiesced = tree.Quiesced
+	vmSnapshot.Status.Children = nil
+	for _, child := range tree.ChildSnapshotList {
+		vmSnapshot.Status.Children = append(vmSnapshot.Status.Children, vmopv1common.LocalObjectRef{
+			APIVersion: vmopv1.GroupVersion.String(),
+			Kind:       "VirtualMachineSnapshot",
+			Name:       child.Name,
+		})
+	}
+
+	return nil
+}
+
+func (r *Reconciler) getVirtualMachine(ctx *pkgctx.VirtualMachineSnapshotContext) (*vmopv1.VirtualMachine, error) {
+	vmSnapshot := ctx.VirtualMachineSnapshot
+	if vmSnapshot.Spec.VMRef == nil {
+		return nil, fmt.Errorf("spec.vmRef is not set")
+	}
+
+	vm := &vmopv1.VirtualMachine{}
+	key := client.ObjectKey{Namespace: vmSnapshot.Namespace, Name: vmSnapshot.Spec.VMRef.Name}
+	if err := r.Get(ctx, key, vm); err != nil {
+		return nil, err
+	}
+
+	return vm, nil
+}
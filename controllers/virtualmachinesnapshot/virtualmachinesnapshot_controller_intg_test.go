@@ -0,0 +1,125 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinesnapshot_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.V1Alpha3,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx        *builder.IntegrationTestContext
+		vm         *vmopv1.VirtualMachine
+		vmSnapshot *vmopv1.VirtualMachineSnapshot
+	)
+
+	getVirtualMachineSnapshot := func(ctx *builder.IntegrationTestContext, objKey client.ObjectKey) *vmopv1.VirtualMachineSnapshot {
+		obj := &vmopv1.VirtualMachineSnapshot{}
+		if err := ctx.Client.Get(ctx, objKey, obj); err != nil {
+			return nil
+		}
+		return obj
+	}
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: ctx.Namespace,
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ImageName:  "dummy-image",
+				ClassName:  "dummy-class",
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+			},
+		}
+
+		vmSnapshot = builder.DummyVirtualMachineSnapshot(ctx.Namespace, "dummy-snapshot", vm.Name)
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		intgFakeVMProvider.Reset()
+	})
+
+	Context("Reconcile", func() {
+		BeforeEach(func() {
+			intgFakeVMProvider.Lock()
+			intgFakeVMProvider.CreateVirtualMachineSnapshotFn = func(
+				_ context.Context,
+				_ *vmopv1.VirtualMachine,
+				_ *vmopv1.VirtualMachineSnapshot) (string, error) {
+				return "snapshot-1", nil
+			}
+			intgFakeVMProvider.Unlock()
+
+			Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+			vm.Status.UniqueID = "dummy-vm-unique-id"
+			Expect(ctx.Client.Status().Update(ctx, vm)).To(Succeed())
+
+			Expect(ctx.Client.Create(ctx, vmSnapshot)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			err := ctx.Client.Delete(ctx, vmSnapshot)
+			Expect(client.IgnoreNotFound(err)).ToNot(HaveOccurred())
+			err = ctx.Client.Delete(ctx, vm)
+			Expect(client.IgnoreNotFound(err)).ToNot(HaveOccurred())
+		})
+
+		It("Reconciles after VirtualMachineSnapshot creation and deletion", func() {
+			objKey := client.ObjectKeyFromObject(vmSnapshot)
+
+			By("Finalizer should be added", func() {
+				Eventually(func(g Gomega) {
+					obj := getVirtualMachineSnapshot(ctx, objKey)
+					g.Expect(obj).ToNot(BeNil())
+					g.Expect(obj.GetFinalizers()).To(ContainElement(finalizerName))
+				}).Should(Succeed())
+			})
+
+			By("Snapshot should be ready", func() {
+				Eventually(func(g Gomega) {
+					obj := getVirtualMachineSnapshot(ctx, objKey)
+					g.Expect(obj).ToNot(BeNil())
+					g.Expect(obj.Status.UniqueID).To(Equal("snapshot-1"))
+					g.Expect(conditions.IsTrue(obj, vmopv1.VirtualMachineSnapshotReadyCondition)).To(BeTrue())
+				}).Should(Succeed())
+			})
+
+			By("Deleting the VirtualMachineSnapshot", func() {
+				Expect(ctx.Client.Delete(ctx, vmSnapshot)).To(Succeed())
+				Eventually(func() *vmopv1.VirtualMachineSnapshot {
+					return getVirtualMachineSnapshot(ctx, objKey)
+				}).Should(BeNil())
+			})
+		})
+	})
+}
@@ -0,0 +1,279 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinesnapshot_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinesnapshot"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+const finalizerName = "vmoperator.vmware.com/virtualmachinesnapshot"
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.V1Alpha3,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+
+		reconciler     *virtualmachinesnapshot.Reconciler
+		fakeVMProvider *providerfake.VMProvider
+
+		vm            *vmopv1.VirtualMachine
+		vmSnapshot    *vmopv1.VirtualMachineSnapshot
+		vmSnapshotCtx *pkgctx.VirtualMachineSnapshotContext
+	)
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				UniqueID:   "dummy-id",
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+			},
+		}
+
+		vmSnapshot = builder.DummyVirtualMachineSnapshot(vm.Namespace, "dummy-snapshot", vm.Name)
+		vmSnapshot.Finalizers = []string{finalizerName}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = virtualmachinesnapshot.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProvider,
+		)
+		fakeVMProvider = ctx.VMProvider.(*providerfake.VMProvider)
+
+		vmSnapshotCtx = &pkgctx.VirtualMachineSnapshotContext{
+			Context:                ctx,
+			Logger:                 ctx.Logger.WithName(vmSnapshot.Name),
+			VirtualMachineSnapshot: vmSnapshot,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+	})
+
+	Context("ReconcileNormal", func() {
+		BeforeEach(func() {
+			initObjects = append(initObjects, vm, vmSnapshot)
+		})
+
+		When("object does not have finalizer set", func() {
+			BeforeEach(func() {
+				vmSnapshot.Finalizers = nil
+			})
+
+			It("will set finalizer", func() {
+				Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).To(Succeed())
+				Expect(vmSnapshot.GetFinalizers()).To(ContainElement(finalizerName))
+				Expect(vmSnapshot.Status.UniqueID).To(BeEmpty())
+			})
+		})
+
+		When("the VM does not exist", func() {
+			BeforeEach(func() {
+				initObjects = []client.Object{vmSnapshot}
+			})
+
+			It("marks the snapshot as not ready", func() {
+				Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).To(Succeed())
+				Expect(conditions.IsFalse(vmSnapshot, vmopv1.VirtualMachineSnapshotReadyCondition)).To(BeTrue())
+				Expect(conditions.GetReason(vmSnapshot, vmopv1.VirtualMachineSnapshotReadyCondition)).To(
+					Equal(vmopv1.VirtualMachineSnapshotVMNotFoundReason))
+			})
+		})
+
+		When("the VM has not been created", func() {
+			BeforeEach(func() {
+				vm.Status.UniqueID = ""
+			})
+
+			It("marks the snapshot as not ready", func() {
+				Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).To(Succeed())
+				Expect(conditions.IsFalse(vmSnapshot, vmopv1.VirtualMachineSnapshotReadyCondition)).To(BeTrue())
+				Expect(conditions.GetReason(vmSnapshot, vmopv1.VirtualMachineSnapshotReadyCondition)).To(
+					Equal(vmopv1.VirtualMachineSnapshotVMNotCreatedReason))
+			})
+		})
+
+		When("the snapshot is created", func() {
+			JustBeforeEach(func() {
+				fakeVMProvider.CreateVirtualMachineSnapshotFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachine,
+					_ *vmopv1.VirtualMachineSnapshot) (string, error) {
+					return "snapshot-1", nil
+				}
+				fakeVMProvider.GetVirtualMachineSnapshotInfoFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachine) (*vimtypes.VirtualMachineSnapshotInfo, error) {
+					return &vimtypes.VirtualMachineSnapshotInfo{
+						RootSnapshotList: []vimtypes.VirtualMachineSnapshotTree{
+							{
+								Name:     vmSnapshot.Name,
+								Quiesced: true,
+								Snapshot: vimtypes.ManagedObjectReference{
+									Type:  "VirtualMachineSnapshot",
+									Value: "snapshot-1",
+								},
+								ChildSnapshotList: []vimtypes.VirtualMachineSnapshotTree{
+									{
+										Name: "child-snapshot",
+										Snapshot: vimtypes.ManagedObjectReference{
+											Type:  "VirtualMachineSnapshot",
+											Value: "snapshot-2",
+										},
+									},
+								},
+							},
+						},
+					}, nil
+				}
+			})
+
+			It("sets the status", func() {
+				Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).To(Succeed())
+				Expect(vmSnapshot.Status.UniqueID).To(Equal("snapshot-1"))
+				Expect(vmSnapshot.Status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+				Expect(vmSnapshot.Status.Quiesced).To(BeTrue())
+				Expect(vmSnapshot.Status.Children).To(HaveLen(1))
+				Expect(vmSnapshot.Status.Children[0].Name).To(Equal("child-snapshot"))
+				Expect(conditions.IsTrue(vmSnapshot, vmopv1.VirtualMachineSnapshotReadyCondition)).To(BeTrue())
+				Expect(vmSnapshot.OwnerReferences).To(HaveLen(1))
+				Expect(vmSnapshot.OwnerReferences[0].Name).To(Equal(vm.Name))
+			})
+
+			When("the snapshot includes memory", func() {
+				BeforeEach(func() {
+					vmSnapshot.Spec.Memory = true
+					vmSnapshot.Spec.Quiesce = &vmopv1.QuiesceSpec{
+						Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+					}
+				})
+
+				It("sets the power state from the VM", func() {
+					Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).To(Succeed())
+					Expect(vmSnapshot.Status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+				})
+			})
+		})
+
+		When("creating the snapshot fails", func() {
+			JustBeforeEach(func() {
+				fakeVMProvider.CreateVirtualMachineSnapshotFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachine,
+					_ *vmopv1.VirtualMachineSnapshot) (string, error) {
+					return "", errors.New("fake")
+				}
+			})
+
+			It("returns an error and marks the snapshot as not ready", func() {
+				Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).ToNot(Succeed())
+				Expect(vmSnapshot.Status.UniqueID).To(BeEmpty())
+				Expect(conditions.GetReason(vmSnapshot, vmopv1.VirtualMachineSnapshotReadyCondition)).To(
+					Equal(vmopv1.VirtualMachineSnapshotCreateFailedReason))
+			})
+		})
+
+		When("the snapshot already exists", func() {
+			BeforeEach(func() {
+				vmSnapshot.Status.UniqueID = "snapshot-1"
+			})
+
+			It("does not create the snapshot again", func() {
+				fakeVMProvider.CreateVirtualMachineSnapshotFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachine,
+					_ *vmopv1.VirtualMachineSnapshot) (string, error) {
+					return "", errors.New("should not be called")
+				}
+				Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).To(Succeed())
+				Expect(vmSnapshot.Status.UniqueID).To(Equal("snapshot-1"))
+			})
+		})
+	})
+
+	Context("ReconcileDelete", func() {
+		BeforeEach(func() {
+			vmSnapshot.Status.UniqueID = "snapshot-1"
+			initObjects = append(initObjects, vm, vmSnapshot)
+		})
+
+		It("deletes the snapshot and removes the finalizer", func() {
+			var deleteCalled bool
+			fakeVMProvider.DeleteVirtualMachineSnapshotFn = func(
+				_ context.Context,
+				_ *vmopv1.VirtualMachine,
+				_ *vmopv1.VirtualMachineSnapshot) error {
+				deleteCalled = true
+				return nil
+			}
+
+			Expect(reconciler.ReconcileDelete(vmSnapshotCtx)).To(Succeed())
+			Expect(deleteCalled).To(BeTrue())
+			Expect(vmSnapshot.GetFinalizers()).ToNot(ContainElement(finalizerName))
+		})
+
+		It("returns an error and keeps the finalizer when the delete fails", func() {
+			fakeVMProvider.DeleteVirtualMachineSnapshotFn = func(
+				_ context.Context,
+				_ *vmopv1.VirtualMachine,
+				_ *vmopv1.VirtualMachineSnapshot) error {
+				return errors.New("fake")
+			}
+
+			Expect(reconciler.ReconcileDelete(vmSnapshotCtx)).ToNot(Succeed())
+			Expect(vmSnapshot.GetFinalizers()).To(ContainElement(finalizerName))
+		})
+
+		When("the VM does not exist", func() {
+			BeforeEach(func() {
+				initObjects = []client.Object{vmSnapshot}
+			})
+
+			It("removes the finalizer", func() {
+				Expect(reconciler.ReconcileDelete(vmSnapshotCtx)).To(Succeed())
+				Expect(vmSnapshot.GetFinalizers()).ToNot(ContainElement(finalizerName))
+			})
+		})
+	})
+}
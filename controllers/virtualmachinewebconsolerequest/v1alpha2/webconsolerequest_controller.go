@@ -1,4 +1,4 @@
-// Copyright (c) 2022-2023 VMware, Inc. All Rights Reserved.
+// Copyright (c) 2022-2024 VMware, Inc. All Rights Reserved.
 // SPDX-License-Identifier: Apache-2.0
 
 package v1alpha2
@@ -20,6 +20,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/patch"
@@ -31,6 +32,10 @@ const (
 	DefaultExpiryTime = time.Second * 120
 	UUIDLabelKey      = "vmoperator.vmware.com/webconsolerequest-uuid"
 
+	// RequesterAnnotationKey is the annotation set by the mutation webhook
+	// to the name of the user that created the web console request.
+	RequesterAnnotationKey = "vmoperator.vmware.com/webconsolerequest-requester"
+
 	ProxyAddrServiceName      = "kube-apiserver-lb-svc"
 	ProxyAddrServiceNamespace = "kube-system"
 )
@@ -114,13 +119,6 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, nil
 	}
 
-	err = r.Get(ctx, client.ObjectKey{Name: webconsolerequest.Spec.Name, Namespace: webconsolerequest.Namespace}, webConsoleRequestCtx.VM)
-	if err != nil {
-		r.Recorder.Warn(webConsoleRequestCtx.WebConsoleRequest, "VirtualMachine Not Found", "")
-		webConsoleRequestCtx.Logger.Error(err, "failed to get subject vm %s", webconsolerequest.Spec.Name)
-		return ctrl.Result{}, fmt.Errorf("failed to get subject vm %s: %w", webconsolerequest.Spec.Name, err)
-	}
-
 	patchHelper, err := patch.NewHelper(webconsolerequest, r.Client)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", webConsoleRequestCtx, err)
@@ -134,6 +132,23 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		}
 	}()
 
+	if webconsolerequest.Spec.Revoked {
+		r.ReconcileRevoked(webConsoleRequestCtx)
+
+		// Requeue so the request is deleted once its ticket expires.
+		if expiryTime := webconsolerequest.Status.ExpiryTime; !expiryTime.IsZero() {
+			return ctrl.Result{RequeueAfter: time.Until(expiryTime.Time)}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+
+	err = r.Get(ctx, client.ObjectKey{Name: webconsolerequest.Spec.Name, Namespace: webconsolerequest.Namespace}, webConsoleRequestCtx.VM)
+	if err != nil {
+		r.Recorder.Warn(webConsoleRequestCtx.WebConsoleRequest, "VirtualMachine Not Found", "")
+		webConsoleRequestCtx.Logger.Error(err, "failed to get subject vm %s", webconsolerequest.Spec.Name)
+		return ctrl.Result{}, fmt.Errorf("failed to get subject vm %s: %w", webconsolerequest.Spec.Name, err)
+	}
+
 	if err := r.ReconcileNormal(webConsoleRequestCtx); err != nil {
 		webConsoleRequestCtx.Logger.Error(err, "failed to reconcile WebConsoleRequest")
 		return ctrl.Result{}, err
@@ -150,10 +165,17 @@ func (r *Reconciler) ReconcileEarlyNormal(ctx *pkgctx.WebConsoleRequestContextV1
 		if client.IgnoreNotFound(err) != nil {
 			return false, fmt.Errorf("failed to delete webconsolerequest: %w", err)
 		}
+		r.Recorder.Eventf(ctx.WebConsoleRequest, "Expired",
+			"Web console request for VirtualMachine %s expired", ctx.WebConsoleRequest.Spec.Name)
 		ctx.Logger.Info("Deleted expired WebConsoleRequest")
 		return true, nil
 	}
 
+	if ctx.WebConsoleRequest.Spec.Revoked {
+		// A revoked request still needs its status updated.
+		return false, nil
+	}
+
 	if ctx.WebConsoleRequest.Status.Response != "" &&
 		ctx.WebConsoleRequest.Status.ProxyAddr != "" {
 		// If the response and proxy address are already set, no need to reconcile anymore
@@ -178,6 +200,12 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.WebConsoleRequestContextV1) err
 
 	ctx.WebConsoleRequest.Status.Response = ticket
 	ctx.WebConsoleRequest.Status.ExpiryTime = metav1.NewTime(metav1.Now().Add(DefaultExpiryTime))
+	ctx.WebConsoleRequest.Status.Requester = ctx.WebConsoleRequest.Annotations[RequesterAnnotationKey]
+	conditions.MarkTrue(ctx.WebConsoleRequest, vmopv1.VirtualMachineWebConsoleRequestConditionActive)
+
+	ctx.Logger.Info("Acquired web console ticket",
+		"requester", ctx.WebConsoleRequest.Status.Requester,
+		"expiryTime", ctx.WebConsoleRequest.Status.ExpiryTime)
 
 	// Retrieve the proxy address from the load balancer service ingress IP.
 	proxySvc := &corev1.Service{}
@@ -207,6 +235,27 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.WebConsoleRequestContextV1) err
 	return nil
 }
 
+// ReconcileRevoked marks the web console request as no longer active and
+// removes its ticket from the status. The web console validation server
+// refuses connections for revoked requests.
+func (r *Reconciler) ReconcileRevoked(ctx *pkgctx.WebConsoleRequestContextV1) {
+	if c := conditions.Get(ctx.WebConsoleRequest, vmopv1.VirtualMachineWebConsoleRequestConditionActive); c != nil &&
+		c.Status == metav1.ConditionFalse && c.Reason == vmopv1.WebConsoleRequestRevokedReason {
+		return
+	}
+
+	ctx.WebConsoleRequest.Status.Response = ""
+	conditions.MarkFalse(
+		ctx.WebConsoleRequest,
+		vmopv1.VirtualMachineWebConsoleRequestConditionActive,
+		vmopv1.WebConsoleRequestRevokedReason,
+		"")
+
+	r.Recorder.Eventf(ctx.WebConsoleRequest, "Revoked",
+		"Web console request for VirtualMachine %s was revoked", ctx.WebConsoleRequest.Spec.Name)
+	ctx.Logger.Info("Revoked WebConsoleRequest", "requester", ctx.WebConsoleRequest.Status.Requester)
+}
+
 func (r *Reconciler) ReconcileOwnerReferences(ctx *pkgctx.WebConsoleRequestContextV1) error {
 	isController := true
 	ownerRef := metav1.OwnerReference{
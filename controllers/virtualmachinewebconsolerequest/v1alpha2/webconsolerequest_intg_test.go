@@ -17,6 +17,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	webconsolerequest "github.com/vmware-tanzu/vm-operator/controllers/virtualmachinewebconsolerequest/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
@@ -144,6 +145,31 @@ func intgTestsReconcile() {
 			Expect(wcr.Status.Response).To(Equal(ticket))
 			Expect(wcr.Status.ExpiryTime.Time).To(BeTemporally("~", time.Now(), webconsolerequest.DefaultExpiryTime))
 			Expect(wcr.Labels).To(HaveKeyWithValue(webconsolerequest.UUIDLabelKey, string(wcr.UID)))
+			Expect(conditions.IsTrue(wcr, vmopv1.VirtualMachineWebConsoleRequestConditionActive)).To(BeTrue())
+		})
+
+		It("resource is revoked", func() {
+			objKey := types.NamespacedName{Name: wcr.Name, Namespace: wcr.Namespace}
+
+			Eventually(func(g Gomega) {
+				wcr = getWebConsoleRequest(ctx, objKey)
+				g.Expect(wcr).ToNot(BeNil())
+				g.Expect(wcr.Status.Response).ToNot(BeEmpty())
+			}).Should(Succeed(), "waiting response to be set")
+
+			wcr.Spec.Revoked = true
+			Expect(ctx.Client.Update(ctx, wcr)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				wcr = getWebConsoleRequest(ctx, objKey)
+				g.Expect(wcr).ToNot(BeNil())
+				c := conditions.Get(wcr, vmopv1.VirtualMachineWebConsoleRequestConditionActive)
+				g.Expect(c).ToNot(BeNil())
+				g.Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(c.Reason).To(Equal(vmopv1.WebConsoleRequestRevokedReason))
+			}).Should(Succeed(), "waiting request to be revoked")
+
+			Expect(wcr.Status.Response).To(BeEmpty())
 		})
 	})
 }
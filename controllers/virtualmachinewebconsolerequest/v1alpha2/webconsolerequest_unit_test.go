@@ -16,6 +16,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	webconsolerequest "github.com/vmware-tanzu/vm-operator/controllers/virtualmachinewebconsolerequest/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
@@ -54,6 +55,9 @@ func unitTestsReconcile() {
 		wcr = &vmopv1.VirtualMachineWebConsoleRequest{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: "dummy-wcr",
+				Annotations: map[string]string{
+					webconsolerequest.RequesterAnnotationKey: "dummy-user",
+				},
 			},
 			Spec: vmopv1.VirtualMachineWebConsoleRequestSpec{
 				Name:      vm.Name,
@@ -129,7 +133,75 @@ func unitTestsReconcile() {
 				Expect(wcrCtx.WebConsoleRequest.Status.ExpiryTime.Time).To(BeTemporally("~", time.Now(), webconsolerequest.DefaultExpiryTime))
 				// Checking the label key only because UID will not be set to a resource during unit test.
 				Expect(wcrCtx.WebConsoleRequest.Labels).To(HaveKey(webconsolerequest.UUIDLabelKey))
+				Expect(wcrCtx.WebConsoleRequest.Status.Requester).To(Equal("dummy-user"))
+				Expect(conditions.IsTrue(wcrCtx.WebConsoleRequest, vmopv1.VirtualMachineWebConsoleRequestConditionActive)).To(BeTrue())
+			})
+		})
+	})
+
+	Context("ReconcileEarlyNormal", func() {
+		BeforeEach(func() {
+			initObjects = append(initObjects, wcr)
+		})
+
+		When("the request has expired", func() {
+			BeforeEach(func() {
+				wcr.Status.ExpiryTime = metav1.NewTime(time.Now().Add(-time.Minute))
+			})
+
+			It("deletes the request", func() {
+				done, err := reconciler.ReconcileEarlyNormal(wcrCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(done).To(BeTrue())
+
+				err = ctx.Client.Get(ctx, client.ObjectKeyFromObject(wcr), &vmopv1.VirtualMachineWebConsoleRequest{})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("the request has a ticket", func() {
+			BeforeEach(func() {
+				wcr.Status.Response = "my-fake-webmksticket"
+				wcr.Status.ProxyAddr = "dummy-proxy-ip"
+				wcr.Status.ExpiryTime = metav1.NewTime(time.Now().Add(time.Minute))
+			})
+
+			It("is done", func() {
+				done, err := reconciler.ReconcileEarlyNormal(wcrCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(done).To(BeTrue())
+			})
+
+			When("the request is revoked", func() {
+				BeforeEach(func() {
+					wcr.Spec.Revoked = true
+				})
+
+				It("is not done", func() {
+					done, err := reconciler.ReconcileEarlyNormal(wcrCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(done).To(BeFalse())
+				})
 			})
 		})
 	})
+
+	Context("ReconcileRevoked", func() {
+		BeforeEach(func() {
+			wcr.Spec.Revoked = true
+			wcr.Status.Response = "my-fake-webmksticket"
+			conditions.MarkTrue(wcr, vmopv1.VirtualMachineWebConsoleRequestConditionActive)
+		})
+
+		It("marks the request as not active and removes the ticket", func() {
+			reconciler.ReconcileRevoked(wcrCtx)
+
+			Expect(wcrCtx.WebConsoleRequest.Status.Response).To(BeEmpty())
+			c := conditions.Get(wcrCtx.WebConsoleRequest, vmopv1.VirtualMachineWebConsoleRequestConditionActive)
+			Expect(c).ToNot(BeNil())
+			Expect(c.Status).To(Equal(metav1.ConditionFalse))
+			Expect(c.Reason).To(Equal(vmopv1.WebConsoleRequestRevokedReason))
+			Expect(ctx.Events).To(Receive(ContainSubstring("Revoked")))
+		})
+	})
 }
@@ -104,6 +104,37 @@ type Config struct {
 	// used to reconcile VirtualMachine objects if their backend state has
 	// changed.
 	AsyncSignalDisabled bool
+
+	// ImageImportServerImage is the container image used to serve the OVF or
+	// OVA in a PersistentVolumeClaim that is the source of a
+	// VirtualMachineImageImportRequest. The image must serve the files in the
+	// directory /data over HTTP on port 8080.
+	//
+	// When empty, importing an image from a PersistentVolumeClaim is not
+	// supported.
+	ImageImportServerImage string
+
+	// VMExportServerImage is the container image used to receive the files of
+	// a VM exported by a VirtualMachineExport. The image must accept HTTP PUT
+	// requests that write files to, and HTTP GET requests that read files
+	// from, the directory /data on port 8080. When the directory
+	// /etc/export-server/tls contains a tls.crt and tls.key, the image must
+	// also serve HTTPS GET requests for the files on port 8443. Every request
+	// must be authenticated with the bearer token in the EXPORT_TOKEN
+	// environment variable.
+	//
+	// When empty, the export-server binary from VM Operator's own image is
+	// used.
+	VMExportServerImage string
+
+	// SerialConsoleProxyURI is the URI of the virtual serial port
+	// concentrator (vSPC) to which the network-backed serial ports of VMs
+	// with a serial console are connected, ex. telnets://vspc.local:13370.
+	// Connections to a VM's serial console through the concentrator must be
+	// authenticated with the web console validation server.
+	//
+	// When empty, serial consoles are not supported.
+	SerialConsoleProxyURI string
 }
 
 // GetMaxDeployThreadsOnProvider returns MaxDeployThreadsOnProvider if it is >0
@@ -135,6 +166,19 @@ type FeatureStates struct {
 	BringYourOwnEncryptionKey bool // FSS_WCP_VMSERVICE_BYOK
 	SVAsyncUpgrade            bool // FSS_WCP_SUPERVISOR_ASYNC_UPGRADE
 	SimplifiedEnablement      bool // FSS_WCP_SIMPLIFIED_ENABLEMENT
+	VMSnapshots               bool // FSS_WCP_VMSERVICE_VM_SNAPSHOTS
+	VMRelocate                bool // FSS_WCP_VMSERVICE_VM_RELOCATE
+	VMStorageClassChange      bool // FSS_WCP_VMSERVICE_VM_STORAGE_CLASS_CHANGE
+	VMNetworkHotPlug          bool // FSS_WCP_VMSERVICE_VM_NETWORK_HOT_PLUG
+	VMRebuild                 bool // FSS_WCP_VMSERVICE_VM_REBUILD
+	VMClone                   bool // FSS_WCP_VMSERVICE_VM_CLONE
+	VMPublishOCI              bool // FSS_WCP_VMSERVICE_VM_PUBLISH_OCI
+	VMPublishSchedule         bool // FSS_WCP_VMSERVICE_VM_PUBLISH_SCHEDULE
+	VMImageImport             bool // FSS_WCP_VMSERVICE_VM_IMAGE_IMPORT
+	VMExport                  bool // FSS_WCP_VMSERVICE_VM_EXPORT
+	VMSerialConsole           bool // FSS_WCP_VMSERVICE_VM_SERIAL_CONSOLE
+	VMGuestOperations         bool // FSS_WCP_VMSERVICE_VM_GUEST_OPERATIONS
+	VMServiceEndpointSlices   bool // FSS_WCP_VMSERVICE_ENDPOINTSLICES
 }
 
 type InstanceStorage struct {
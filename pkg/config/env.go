@@ -28,6 +28,9 @@ func FromEnv() Config {
 	setStringSlice(env.PrivilegedUsers, &config.PrivilegedUsers)
 	setBool(env.LogSensitiveData, &config.LogSensitiveData)
 	setBool(env.AsyncSignalDisabled, &config.AsyncSignalDisabled)
+	setString(env.ImageImportServerImage, &config.ImageImportServerImage)
+	setString(env.VMExportServerImage, &config.VMExportServerImage)
+	setString(env.SerialConsoleProxyURI, &config.SerialConsoleProxyURI)
 
 	setDuration(env.InstanceStoragePVPlacementFailedTTL, &config.InstanceStorage.PVPlacementFailedTTL)
 	setFloat64(env.InstanceStorageJitterMaxFactor, &config.InstanceStorage.JitterMaxFactor)
@@ -63,6 +66,19 @@ func FromEnv() Config {
 	setBool(env.FSSVMIncrementalRestore, &config.Features.VMIncrementalRestore)
 	setBool(env.FSSBringYourOwnEncryptionKey, &config.Features.BringYourOwnEncryptionKey)
 	setBool(env.FSSSimplifiedEnablement, &config.Features.SimplifiedEnablement)
+	setBool(env.FSSVMSnapshots, &config.Features.VMSnapshots)
+	setBool(env.FSSVMRelocate, &config.Features.VMRelocate)
+	setBool(env.FSSVMStorageClassChange, &config.Features.VMStorageClassChange)
+	setBool(env.FSSVMNetworkHotPlug, &config.Features.VMNetworkHotPlug)
+	setBool(env.FSSVMRebuild, &config.Features.VMRebuild)
+	setBool(env.FSSVMClone, &config.Features.VMClone)
+	setBool(env.FSSVMPublishOCI, &config.Features.VMPublishOCI)
+	setBool(env.FSSVMPublishSchedule, &config.Features.VMPublishSchedule)
+	setBool(env.FSSVMImageImport, &config.Features.VMImageImport)
+	setBool(env.FSSVMExport, &config.Features.VMExport)
+	setBool(env.FSSVMSerialConsole, &config.Features.VMSerialConsole)
+	setBool(env.FSSVMGuestOperations, &config.Features.VMGuestOperations)
+	setBool(env.FSSVMServiceEndpointSlices, &config.Features.VMServiceEndpointSlices)
 
 	setBool(env.FSSSVAsyncUpgrade, &config.Features.SVAsyncUpgrade)
 	if !config.Features.SVAsyncUpgrade {
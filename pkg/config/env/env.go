@@ -44,6 +44,9 @@ const (
 	WebhookServiceNamespace
 	WebhookSecretName
 	WebhookSecretNamespace
+	ImageImportServerImage
+	VMExportServerImage
+	SerialConsoleProxyURI
 	FSSInstanceStorage
 	FSSIsoSupport
 	FSSK8sWorkloadMgmtAPI
@@ -58,6 +61,19 @@ const (
 	FSSBringYourOwnEncryptionKey
 	FSSSVAsyncUpgrade
 	FSSSimplifiedEnablement
+	FSSVMSnapshots
+	FSSVMRelocate
+	FSSVMStorageClassChange
+	FSSVMNetworkHotPlug
+	FSSVMRebuild
+	FSSVMClone
+	FSSVMPublishOCI
+	FSSVMPublishSchedule
+	FSSVMImageImport
+	FSSVMExport
+	FSSVMSerialConsole
+	FSSVMGuestOperations
+	FSSVMServiceEndpointSlices
 
 	_varNameEnd
 )
@@ -148,6 +164,12 @@ func (n VarName) String() string {
 		return "WEBHOOK_SECRET_NAME"
 	case WebhookSecretNamespace:
 		return "WEBHOOK_SECRET_NAMESPACE"
+	case ImageImportServerImage:
+		return "IMAGE_IMPORT_SERVER_IMAGE"
+	case VMExportServerImage:
+		return "VM_EXPORT_SERVER_IMAGE"
+	case SerialConsoleProxyURI:
+		return "SERIAL_CONSOLE_PROXY_URI"
 	case FSSInstanceStorage:
 		return "FSS_WCP_INSTANCE_STORAGE"
 	case FSSIsoSupport:
@@ -176,6 +198,32 @@ func (n VarName) String() string {
 		return "FSS_WCP_SUPERVISOR_ASYNC_UPGRADE"
 	case FSSSimplifiedEnablement:
 		return "FSS_WCP_SIMPLIFIED_ENABLEMENT"
+	case FSSVMSnapshots:
+		return "FSS_WCP_VMSERVICE_VM_SNAPSHOTS"
+	case FSSVMRelocate:
+		return "FSS_WCP_VMSERVICE_VM_RELOCATE"
+	case FSSVMStorageClassChange:
+		return "FSS_WCP_VMSERVICE_VM_STORAGE_CLASS_CHANGE"
+	case FSSVMNetworkHotPlug:
+		return "FSS_WCP_VMSERVICE_VM_NETWORK_HOT_PLUG"
+	case FSSVMRebuild:
+		return "FSS_WCP_VMSERVICE_VM_REBUILD"
+	case FSSVMClone:
+		return "FSS_WCP_VMSERVICE_VM_CLONE"
+	case FSSVMPublishOCI:
+		return "FSS_WCP_VMSERVICE_VM_PUBLISH_OCI"
+	case FSSVMPublishSchedule:
+		return "FSS_WCP_VMSERVICE_VM_PUBLISH_SCHEDULE"
+	case FSSVMImageImport:
+		return "FSS_WCP_VMSERVICE_VM_IMAGE_IMPORT"
+	case FSSVMExport:
+		return "FSS_WCP_VMSERVICE_VM_EXPORT"
+	case FSSVMSerialConsole:
+		return "FSS_WCP_VMSERVICE_VM_SERIAL_CONSOLE"
+	case FSSVMGuestOperations:
+		return "FSS_WCP_VMSERVICE_VM_GUEST_OPERATIONS"
+	case FSSVMServiceEndpointSlices:
+		return "FSS_WCP_VMSERVICE_ENDPOINTSLICES"
 	}
 	panic("unknown environment variable")
 }
@@ -101,8 +101,24 @@ var _ = Describe(
 					Expect(os.Setenv("FSS_WCP_VMSERVICE_BYOK", "true")).To(Succeed())
 					Expect(os.Setenv("FSS_WCP_SUPERVISOR_ASYNC_UPGRADE", "false")).To(Succeed())
 					Expect(os.Setenv("FSS_WCP_SIMPLIFIED_ENABLEMENT", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VM_SNAPSHOTS", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VM_RELOCATE", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VM_STORAGE_CLASS_CHANGE", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VM_NETWORK_HOT_PLUG", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VM_REBUILD", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VM_CLONE", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VM_PUBLISH_OCI", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VM_PUBLISH_SCHEDULE", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VM_IMAGE_IMPORT", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VM_EXPORT", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VM_SERIAL_CONSOLE", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VM_GUEST_OPERATIONS", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_ENDPOINTSLICES", "true")).To(Succeed())
 					Expect(os.Setenv("CREATE_VM_REQUEUE_DELAY", "125h")).To(Succeed())
 					Expect(os.Setenv("POWERED_ON_VM_HAS_IP_REQUEUE_DELAY", "126h")).To(Succeed())
+					Expect(os.Setenv("IMAGE_IMPORT_SERVER_IMAGE", "127")).To(Succeed())
+					Expect(os.Setenv("VM_EXPORT_SERVER_IMAGE", "128")).To(Succeed())
+					Expect(os.Setenv("SERIAL_CONSOLE_PROXY_URI", "129")).To(Succeed())
 				})
 				It("Should return a default config overridden by the environment", func() {
 					Expect(config).To(BeComparableTo(pkgcfg.Config{
@@ -150,9 +166,25 @@ var _ = Describe(
 							SVAsyncUpgrade:            false, // Capability gate so tested below
 							WorkloadDomainIsolation:   true,
 							SimplifiedEnablement:      true,
+							VMSnapshots:               true,
+							VMRelocate:                true,
+							VMStorageClassChange:      true,
+							VMNetworkHotPlug:          true,
+							VMRebuild:                 true,
+							VMClone:                   true,
+							VMPublishOCI:              true,
+							VMPublishSchedule:         true,
+							VMImageImport:             true,
+							VMExport:                  true,
+							VMSerialConsole:           true,
+							VMGuestOperations:         true,
+							VMServiceEndpointSlices:   true,
 						},
 						CreateVMRequeueDelay:         125 * time.Hour,
 						PoweredOnVMHasIPRequeueDelay: 126 * time.Hour,
+						ImageImportServerImage:       "127",
+						VMExportServerImage:          "128",
+						SerialConsoleProxyURI:        "129",
 					}))
 				})
 			})
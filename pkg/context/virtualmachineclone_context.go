@@ -0,0 +1,25 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+)
+
+// VirtualMachineCloneContext is the context used for VirtualMachineClone reconciliation.
+type VirtualMachineCloneContext struct {
+	context.Context
+	Logger   logr.Logger
+	VMClone  *vmopv1.VirtualMachineClone
+	SourceVM *vmopv1.VirtualMachine
+}
+
+func (v *VirtualMachineCloneContext) String() string {
+	return fmt.Sprintf("%s %s/%s", v.VMClone.GroupVersionKind(), v.VMClone.Namespace, v.VMClone.Name)
+}
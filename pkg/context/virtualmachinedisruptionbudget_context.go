@@ -0,0 +1,24 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+)
+
+// VirtualMachineDisruptionBudgetContext is the context used for VirtualMachineDisruptionBudget reconciliation.
+type VirtualMachineDisruptionBudgetContext struct {
+	context.Context
+	Logger logr.Logger
+	Budget *vmopv1.VirtualMachineDisruptionBudget
+}
+
+func (v *VirtualMachineDisruptionBudgetContext) String() string {
+	return fmt.Sprintf("%s %s/%s", v.Budget.GroupVersionKind(), v.Budget.Namespace, v.Budget.Name)
+}
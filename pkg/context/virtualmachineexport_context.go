@@ -0,0 +1,28 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+)
+
+// VirtualMachineExportContext is the context used for VirtualMachineExport
+// reconciliation.
+type VirtualMachineExportContext struct {
+	context.Context
+	Logger    logr.Logger
+	VMExport  *vmopv1.VirtualMachineExport
+	VM        *vmopv1.VirtualMachine
+	TargetURL string
+	Token     string
+}
+
+func (v *VirtualMachineExportContext) String() string {
+	return fmt.Sprintf("%s %s/%s", v.VMExport.GroupVersionKind(), v.VMExport.Namespace, v.VMExport.Name)
+}
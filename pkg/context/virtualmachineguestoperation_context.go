@@ -0,0 +1,26 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+)
+
+// VirtualMachineGuestOperationContext is the context used for
+// VirtualMachineGuestOperation reconciliation.
+type VirtualMachineGuestOperationContext struct {
+	context.Context
+	Logger         logr.Logger
+	GuestOperation *vmopv1.VirtualMachineGuestOperation
+	VM             *vmopv1.VirtualMachine
+}
+
+func (v *VirtualMachineGuestOperationContext) String() string {
+	return fmt.Sprintf("%s %s/%s", v.GuestOperation.GroupVersionKind(), v.GuestOperation.Namespace, v.GuestOperation.Name)
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+)
+
+// VirtualMachineImageImportRequestContext is the context used for
+// VirtualMachineImageImportRequest reconciliation.
+type VirtualMachineImageImportRequestContext struct {
+	context.Context
+	Logger         logr.Logger
+	VMImport       *vmopv1.VirtualMachineImageImportRequest
+	ContentLibrary *imgregv1a1.ContentLibrary
+	SourceURL      string
+}
+
+func (v *VirtualMachineImageImportRequestContext) String() string {
+	return fmt.Sprintf("%s %s/%s", v.VMImport.GroupVersionKind(), v.VMImport.Namespace, v.VMImport.Name)
+}
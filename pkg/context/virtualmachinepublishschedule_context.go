@@ -0,0 +1,25 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+)
+
+// VirtualMachinePublishScheduleContext is the context used for
+// VirtualMachinePublishSchedule reconciliation.
+type VirtualMachinePublishScheduleContext struct {
+	context.Context
+	Logger            logr.Logger
+	VMPublishSchedule *vmopv1.VirtualMachinePublishSchedule
+}
+
+func (v *VirtualMachinePublishScheduleContext) String() string {
+	return fmt.Sprintf("%s %s/%s", v.VMPublishSchedule.GroupVersionKind(), v.VMPublishSchedule.Namespace, v.VMPublishSchedule.Name)
+}
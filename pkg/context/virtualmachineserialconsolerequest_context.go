@@ -0,0 +1,26 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+)
+
+// VirtualMachineSerialConsoleRequestContext is the context used for
+// VirtualMachineSerialConsoleRequest reconciliation.
+type VirtualMachineSerialConsoleRequestContext struct {
+	context.Context
+	Logger               logr.Logger
+	SerialConsoleRequest *vmopv1.VirtualMachineSerialConsoleRequest
+	VM                   *vmopv1.VirtualMachine
+}
+
+func (v *VirtualMachineSerialConsoleRequestContext) String() string {
+	return fmt.Sprintf("%s %s/%s", v.SerialConsoleRequest.GroupVersionKind(), v.SerialConsoleRequest.Namespace, v.SerialConsoleRequest.Name)
+}
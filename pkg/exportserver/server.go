@@ -0,0 +1,158 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package exportserver
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Server receives the files of a VM exported by a VirtualMachineExport and
+// serves them for download.
+//
+// The files are uploaded with HTTP PUT requests to the plain HTTP address,
+// which is only reachable from within the cluster. When a certificate is
+// configured, the files may also be downloaded with HTTP GET requests from the
+// HTTPS address. Every request must be authenticated with the bearer token.
+type Server struct {
+	Addr, TLSAddr     string
+	Dir, Token        string
+	CertFile, KeyFile string
+}
+
+// NewServer creates a new export server.
+func NewServer(addr, tlsAddr, dir, token, certFile, keyFile string) (*Server, error) {
+	if addr == "" || dir == "" {
+		return nil, errors.New("server addr and dir cannot be empty")
+	}
+	if token == "" {
+		return nil, errors.New("server token cannot be empty")
+	}
+	if tlsAddr != "" && (certFile == "" || keyFile == "") {
+		return nil, errors.New("server cert and key cannot be empty when the TLS addr is set")
+	}
+
+	return &Server{
+		Addr:     addr,
+		TLSAddr:  tlsAddr,
+		Dir:      dir,
+		Token:    token,
+		CertFile: certFile,
+		KeyFile:  keyFile,
+	}, nil
+}
+
+// Run starts the export server and returns when either address stops
+// serving.
+func (s *Server) Run() error {
+	errCh := make(chan error, 2)
+
+	go func() {
+		server := &http.Server{
+			Addr:              s.Addr,
+			Handler:           s.Handler(false),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		errCh <- server.ListenAndServe()
+	}()
+
+	if s.TLSAddr != "" {
+		go func() {
+			server := &http.Server{
+				Addr:              s.TLSAddr,
+				Handler:           s.Handler(true),
+				ReadHeaderTimeout: 10 * time.Second,
+				TLSConfig: &tls.Config{
+					MinVersion: tls.VersionTLS12,
+				},
+			}
+			errCh <- server.ListenAndServeTLS(s.CertFile, s.KeyFile)
+		}()
+	}
+
+	return <-errCh
+}
+
+// Handler returns the handler of the export server's requests. A read-only
+// handler refuses uploads.
+func (s *Server) Handler(readOnly bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := ctrllog.Log.WithName(r.URL.Path).WithValues("method", r.Method)
+
+		if !s.isAuthorized(r) {
+			logger.Info("Refusing unauthorized request")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		name, ok := fileName(r.URL.Path)
+		if !ok {
+			http.Error(w, "invalid file name", http.StatusBadRequest)
+			return
+		}
+		filePath := filepath.Join(s.Dir, name)
+
+		switch {
+		case r.Method == http.MethodGet || r.Method == http.MethodHead:
+			http.ServeFile(w, r, filePath)
+		case r.Method == http.MethodPut && !readOnly:
+			if err := writeFile(filePath, r.Body); err != nil {
+				logger.Error(err, "Failed to write file")
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			logger.Info("Wrote file")
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// isAuthorized returns true if the request has the server's bearer token.
+func (s *Server) isAuthorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) == 1
+}
+
+// fileName returns the name of the file from the path of a request. The
+// exported files are all in the same directory, so the name may not contain a
+// path separator.
+func fileName(urlPath string) (string, bool) {
+	name := strings.TrimPrefix(path.Clean("/"+urlPath), "/")
+	if name == "" || name == "." || strings.ContainsAny(name, `/\`) {
+		return "", false
+	}
+	return name, true
+}
+
+// writeFile writes the file atomically so a partially uploaded file is never
+// downloaded.
+func writeFile(filePath string, r io.Reader) error {
+	f, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+"-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), filePath)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package exportserver_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/exportserver"
+)
+
+func serverUnitTests() {
+
+	const (
+		serverAddr = "localhost:8080"
+		token      = "dummy-token"
+	)
+
+	Context("NewServer", func() {
+
+		It("should return an error when the addr or dir is empty", func() {
+			_, err := exportserver.NewServer("", "", "/data", token, "", "")
+			Expect(err).To(MatchError("server addr and dir cannot be empty"))
+
+			_, err = exportserver.NewServer(serverAddr, "", "", token, "", "")
+			Expect(err).To(MatchError("server addr and dir cannot be empty"))
+		})
+
+		It("should return an error when the token is empty", func() {
+			_, err := exportserver.NewServer(serverAddr, "", "/data", "", "", "")
+			Expect(err).To(MatchError("server token cannot be empty"))
+		})
+
+		It("should return an error when the TLS addr is set without a cert", func() {
+			_, err := exportserver.NewServer(serverAddr, "localhost:8443", "/data", token, "", "")
+			Expect(err).To(MatchError("server cert and key cannot be empty when the TLS addr is set"))
+		})
+	})
+
+	Context("Handler", func() {
+		var (
+			dir      string
+			readOnly bool
+			server   *exportserver.Server
+		)
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			readOnly = false
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			server, err = exportserver.NewServer(serverAddr, "", dir, token, "", "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		do := func(method, path, authorization, body string) *httptest.ResponseRecorder {
+			r := httptest.NewRequest(method, path, strings.NewReader(body))
+			if authorization != "" {
+				r.Header.Set("Authorization", authorization)
+			}
+			w := httptest.NewRecorder()
+			server.Handler(readOnly).ServeHTTP(w, r)
+			return w
+		}
+
+		It("should write and read a file", func() {
+			w := do(http.MethodPut, "/vm.ovf", "Bearer "+token, "dummy-ovf")
+			Expect(w.Code).To(Equal(http.StatusCreated))
+
+			data, err := os.ReadFile(filepath.Join(dir, "vm.ovf"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("dummy-ovf"))
+
+			w = do(http.MethodGet, "/vm.ovf", "Bearer "+token, "")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			body, err := io.ReadAll(w.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(Equal("dummy-ovf"))
+		})
+
+		It("should refuse a request without the token", func() {
+			Expect(do(http.MethodGet, "/vm.ovf", "", "").Code).To(Equal(http.StatusUnauthorized))
+			Expect(do(http.MethodGet, "/vm.ovf", "Bearer wrong-token", "").Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should refuse a file outside of the directory", func() {
+			Expect(do(http.MethodPut, "/a/vm.ovf", "Bearer "+token, "dummy-ovf").Code).To(Equal(http.StatusBadRequest))
+			Expect(do(http.MethodGet, "/", "Bearer "+token, "").Code).To(Equal(http.StatusBadRequest))
+		})
+
+		When("the handler is read-only", func() {
+			BeforeEach(func() {
+				readOnly = true
+			})
+
+			It("should refuse an upload", func() {
+				w := do(http.MethodPut, "/vm.ovf", "Bearer "+token, "dummy-ovf")
+				Expect(w.Code).To(Equal(http.StatusMethodNotAllowed))
+				Expect(filepath.Join(dir, "vm.ovf")).ToNot(BeAnExistingFile())
+			})
+		})
+	})
+}
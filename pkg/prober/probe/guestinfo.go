@@ -24,7 +24,9 @@ func NewGuestInfoProber(prober vmProviderGuestInfoProber) Probe {
 
 func (gip guestInfoProber) Probe(ctx *context.ProbeContext) (Result, error) {
 
-	numProbes := len(ctx.VM.Spec.ReadinessProbe.GuestInfo)
+	guestInfo := ctx.GetProbeSpec().GuestInfo
+
+	numProbes := len(guestInfo)
 	if numProbes == 0 {
 		return Unknown, nil
 	}
@@ -34,8 +36,8 @@ func (gip guestInfoProber) Probe(ctx *context.ProbeContext) (Result, error) {
 		propertyPaths   = make([]string, numProbes)
 		propertyKeyVals = make(map[string]string, numProbes)
 	)
-	for i := range ctx.VM.Spec.ReadinessProbe.GuestInfo {
-		gi := ctx.VM.Spec.ReadinessProbe.GuestInfo[i]
+	for i := range guestInfo {
+		gi := guestInfo[i]
 		pp := fmt.Sprintf(`config.extraConfig["guestinfo.%s"]`, gi.Key)
 		propertyPaths[i] = pp
 		propertyKeyVals[pp] = gi.Value
@@ -0,0 +1,135 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober/context"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+const (
+	// defaultHTTPStatusMin and defaultHTTPStatusMax are the inclusive bounds
+	// of the status codes that indicate success when the probe does not
+	// specify an expected status range.
+	defaultHTTPStatusMin = http.StatusOK
+	defaultHTTPStatusMax = http.StatusBadRequest - 1
+
+	// maxHTTPBodyDrain is the maximum number of bytes read from a response
+	// body before the connection is closed.
+	maxHTTPBodyDrain = 10 * 1024
+)
+
+// httpGetProber implements the Probe interface.
+type httpGetProber struct{}
+
+// NewHTTPGetProber creates a new HTTP GET prober which implements the Probe
+// interface to execute HTTP GET probes.
+func NewHTTPGetProber() Probe {
+	return &httpGetProber{}
+}
+
+func (pr httpGetProber) Probe(ctx *context.ProbeContext) (Result, error) {
+	vm := ctx.VM
+	p := ctx.GetProbeSpec()
+	action := p.HTTPGet
+
+	portNum, err := vmopv1util.FindPort(*vm, action.Port, corev1.ProtocolTCP)
+	if err != nil {
+		return Failure, err
+	}
+
+	host := action.Host
+	if host == "" {
+		ctx.Logger.V(4).Info("HTTPGet Host not specified, using VM IP", "probe", ctx.String())
+		if host, err = getVMIP(vm); err != nil {
+			return Failure, err
+		}
+	}
+
+	reqURL := buildHTTPGetURL(action, host, portNum)
+
+	//nolint:noctx // The request is bounded by the client's timeout.
+	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return Failure, err
+	}
+	for _, h := range action.HTTPHeaders {
+		if strings.EqualFold(h.Name, "Host") {
+			req.Host = h.Value
+			continue
+		}
+		req.Header.Add(h.Name, h.Value)
+	}
+
+	client := &http.Client{
+		Timeout: getTimeout(p),
+		Transport: &http.Transport{
+			Proxy: nil,
+			TLSClientConfig: &tls.Config{
+				//nolint:gosec // The certificate of the guest is not verified.
+				InsecureSkipVerify: true,
+			},
+			DisableKeepAlives: true,
+		},
+		// Do not follow redirects. A redirect status is evaluated against
+		// the expected status range like any other status.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return Failure, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxHTTPBodyDrain))
+
+	minStatus, maxStatus := defaultHTTPStatusMin, defaultHTTPStatusMax
+	if r := action.ExpectedStatus; r != nil {
+		minStatus, maxStatus = int(r.Min), int(r.Max)
+	}
+
+	if res.StatusCode < minStatus || res.StatusCode > maxStatus {
+		return Failure, fmt.Errorf("HTTP probe of %s returned status %d, expected %d-%d",
+			reqURL.Redacted(), res.StatusCode, minStatus, maxStatus)
+	}
+
+	return Success, nil
+}
+
+func buildHTTPGetURL(action *vmopv1.HTTPGetAction, host string, port int) *url.URL {
+	scheme := strings.ToLower(string(action.Scheme))
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	path := action.Path
+	if path == "" {
+		path = "/"
+	} else if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		u = &url.URL{Path: path}
+	}
+	u.Scheme = scheme
+	u.Host = net.JoinHostPort(host, strconv.Itoa(port))
+
+	return u
+}
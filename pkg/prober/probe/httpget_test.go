@@ -0,0 +1,188 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober/context"
+)
+
+var _ = Describe("HTTPGet probe", func() {
+	var (
+		vm               *vmopv1.VirtualMachine
+		testHTTPGetProbe Probe
+		probeCtx         *context.ProbeContext
+
+		testServer *httptest.Server
+		testHost   string
+		testPort   int
+
+		gotPath   string
+		gotHeader http.Header
+		status    int
+	)
+
+	startServer := func(newServer func(http.Handler) *httptest.Server) {
+		testServer = newServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			gotHeader = r.Header
+			w.WriteHeader(status)
+		}))
+		host, port, err := net.SplitHostPort(testServer.Listener.Addr().String())
+		Expect(err).NotTo(HaveOccurred())
+		testHost = host
+		testPort, err = strconv.Atoi(port)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ClassName: "dummy-vmclass",
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				Network: &vmopv1.VirtualMachineNetworkStatus{},
+			},
+		}
+
+		status = http.StatusOK
+		gotPath = ""
+		gotHeader = nil
+
+		startServer(httptest.NewServer)
+		testHTTPGetProbe = NewHTTPGetProber()
+		probeCtx = &context.ProbeContext{
+			VM:     vm,
+			Logger: ctrl.Log.WithName("Probe").WithValues("name", vm.NamespacedName()),
+		}
+	})
+
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It("HTTPGet probe succeeds, with host set in VM spec", func() {
+		vm.Spec.ReadinessProbe = getVirtualMachineReadinessHTTPGetProbe(testHost, testPort)
+
+		res, err := testHTTPGetProbe.Probe(probeCtx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res).To(Equal(Success))
+		Expect(gotPath).To(Equal("/"))
+	})
+
+	It("HTTPGet probe succeeds, with empty host", func() {
+		vm.Status.Network.PrimaryIP4 = testHost
+		vm.Spec.ReadinessProbe = getVirtualMachineReadinessHTTPGetProbe("", testPort)
+
+		res, err := testHTTPGetProbe.Probe(probeCtx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res).To(Equal(Success))
+	})
+
+	It("HTTPGet probe fails, with empty host and no VM IP", func() {
+		vm.Spec.ReadinessProbe = getVirtualMachineReadinessHTTPGetProbe("", testPort)
+
+		res, err := testHTTPGetProbe.Probe(probeCtx)
+		Expect(err).Should(HaveOccurred())
+		Expect(res).To(Equal(Failure))
+	})
+
+	It("HTTPGet probe sends the path and headers", func() {
+		vm.Spec.ReadinessProbe = getVirtualMachineReadinessHTTPGetProbe(testHost, testPort)
+		vm.Spec.ReadinessProbe.HTTPGet.Path = "/healthz"
+		vm.Spec.ReadinessProbe.HTTPGet.HTTPHeaders = []vmopv1.HTTPHeader{
+			{Name: "X-Probe", Value: "vm-operator"},
+		}
+
+		res, err := testHTTPGetProbe.Probe(probeCtx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res).To(Equal(Success))
+		Expect(gotPath).To(Equal("/healthz"))
+		Expect(gotHeader.Get("X-Probe")).To(Equal("vm-operator"))
+	})
+
+	It("HTTPGet probe fails when the status is outside the default range", func() {
+		status = http.StatusServiceUnavailable
+		vm.Spec.ReadinessProbe = getVirtualMachineReadinessHTTPGetProbe(testHost, testPort)
+
+		res, err := testHTTPGetProbe.Probe(probeCtx)
+		Expect(err).Should(HaveOccurred())
+		Expect(res).To(Equal(Failure))
+	})
+
+	It("HTTPGet probe succeeds when the status is within the expected range", func() {
+		status = http.StatusServiceUnavailable
+		vm.Spec.ReadinessProbe = getVirtualMachineReadinessHTTPGetProbe(testHost, testPort)
+		vm.Spec.ReadinessProbe.HTTPGet.ExpectedStatus = &vmopv1.HTTPStatusRange{
+			Min: http.StatusServiceUnavailable,
+			Max: http.StatusServiceUnavailable,
+		}
+
+		res, err := testHTTPGetProbe.Probe(probeCtx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res).To(Equal(Success))
+	})
+
+	It("HTTPGet probe fails when the status is outside the expected range", func() {
+		vm.Spec.ReadinessProbe = getVirtualMachineReadinessHTTPGetProbe(testHost, testPort)
+		vm.Spec.ReadinessProbe.HTTPGet.ExpectedStatus = &vmopv1.HTTPStatusRange{
+			Min: http.StatusNoContent,
+			Max: http.StatusNoContent,
+		}
+
+		res, err := testHTTPGetProbe.Probe(probeCtx)
+		Expect(err).Should(HaveOccurred())
+		Expect(res).To(Equal(Failure))
+	})
+
+	It("HTTPGet probe fails when the server is not listening", func() {
+		vm.Spec.ReadinessProbe = getVirtualMachineReadinessHTTPGetProbe(testHost, 10001)
+
+		res, err := testHTTPGetProbe.Probe(probeCtx)
+		Expect(err).Should(HaveOccurred())
+		Expect(res).To(Equal(Failure))
+	})
+
+	When("the scheme is HTTPS", func() {
+		BeforeEach(func() {
+			testServer.Close()
+			startServer(httptest.NewTLSServer)
+		})
+
+		It("HTTPGet probe succeeds without verifying the certificate", func() {
+			vm.Spec.ReadinessProbe = getVirtualMachineReadinessHTTPGetProbe(testHost, testPort)
+			vm.Spec.ReadinessProbe.HTTPGet.Scheme = vmopv1.URISchemeHTTPS
+
+			res, err := testHTTPGetProbe.Probe(probeCtx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res).To(Equal(Success))
+		})
+	})
+})
+
+func getVirtualMachineReadinessHTTPGetProbe(host string, port int) *vmopv1.VirtualMachineReadinessProbeSpec {
+	return &vmopv1.VirtualMachineReadinessProbeSpec{
+		HTTPGet: &vmopv1.HTTPGetAction{
+			Host: host,
+			Port: intstr.FromInt(port),
+		},
+		PeriodSeconds: 1,
+	}
+}
@@ -44,6 +44,7 @@ type vmProviderProber interface {
 // Prober contains the different type of probes.
 type Prober struct {
 	TCPProbe       Probe
+	HTTPGetProbe   Probe
 	GuestHeartbeat Probe
 	GuestInfo      Probe
 }
@@ -52,6 +53,7 @@ type Prober struct {
 func NewProber(vmProvider vmProviderProber) *Prober {
 	return &Prober{
 		TCPProbe:       NewTCPProber(),
+		HTTPGetProbe:   NewHTTPGetProber(),
 		GuestHeartbeat: NewGuestHeartbeatProber(vmProvider),
 		GuestInfo:      NewGuestInfoProber(vmProvider),
 	}
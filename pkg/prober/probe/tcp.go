@@ -10,10 +10,10 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	"github.com/vmware-tanzu/vm-operator/pkg/prober/context"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 // tcpProber implements the Probe interface.
@@ -26,10 +26,10 @@ func NewTCPProber() Probe {
 
 func (pr tcpProber) Probe(ctx *context.ProbeContext) (Result, error) {
 	vm := ctx.VM
-	p := ctx.VM.Spec.ReadinessProbe
+	p := ctx.GetProbeSpec()
 
 	portProto := corev1.ProtocolTCP
-	portNum, err := findPort(vm, p.TCPSocket.Port, portProto)
+	portNum, err := vmopv1util.FindPort(*vm, p.TCPSocket.Port, portProto)
 	if err != nil {
 		return Failure, err
 	}
@@ -37,40 +37,39 @@ func (pr tcpProber) Probe(ctx *context.ProbeContext) (Result, error) {
 	ip := p.TCPSocket.Host
 	if ip == "" {
 		ctx.Logger.V(4).Info("TCPSocket Host not specified, using VM IP", "probe", ctx.String())
-		if vm.Status.Network != nil {
-			ip = vm.Status.Network.PrimaryIP4
-			if ip == "" {
-				ip = vm.Status.Network.PrimaryIP6
-			}
+		if ip, err = getVMIP(vm); err != nil {
+			return Failure, err
 		}
-		if ip == "" {
-			return Failure, fmt.Errorf("VM %s doesn't have an IP assigned", vm.NamespacedName())
-		}
-	}
-
-	var timeout time.Duration
-	if p.TimeoutSeconds <= 0 {
-		timeout = defaultConnectTimeout
-	} else {
-		timeout = time.Duration(p.TimeoutSeconds) * time.Second
 	}
 
-	if err := checkConnection("tcp", ip, strconv.Itoa(portNum), timeout); err != nil {
+	if err := checkConnection("tcp", ip, strconv.Itoa(portNum), getTimeout(p)); err != nil {
 		return Failure, err
 	}
 
 	return Success, nil
 }
 
-func findPort(vm *vmopv1.VirtualMachine, portName intstr.IntOrString, _ corev1.Protocol) (int, error) {
-	switch portName.Type {
-	case intstr.String:
-		// Not supported.
-	case intstr.Int:
-		return portName.IntValue(), nil
+// getVMIP returns the VM's primary IP, preferring IPv4 over IPv6.
+func getVMIP(vm *vmopv1.VirtualMachine) (string, error) {
+	var ip string
+	if vm.Status.Network != nil {
+		ip = vm.Status.Network.PrimaryIP4
+		if ip == "" {
+			ip = vm.Status.Network.PrimaryIP6
+		}
+	}
+	if ip == "" {
+		return "", fmt.Errorf("VM %s doesn't have an IP assigned", vm.NamespacedName())
 	}
+	return ip, nil
+}
 
-	return 0, fmt.Errorf("no suitable port for manifest: %s", vm.UID)
+// getTimeout returns the probe's timeout, or the default if one is not set.
+func getTimeout(p *vmopv1.VirtualMachineReadinessProbeSpec) time.Duration {
+	if p.TimeoutSeconds <= 0 {
+		return defaultConnectTimeout
+	}
+	return time.Duration(p.TimeoutSeconds) * time.Second
 }
 
 func checkConnection(proto, host, port string, timeout time.Duration) error {
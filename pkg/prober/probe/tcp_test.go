@@ -79,6 +79,39 @@ var _ = Describe("TCP probe", func() {
 		Expect(res).To(Equal(Success))
 	})
 
+	It("TCP probe succeeds, with named port", func() {
+		vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+			Ports: []vmopv1.VirtualMachineNetworkPort{
+				{
+					Name: "my-port",
+					Port: int32(testPort),
+				},
+			},
+		}
+		vm.Spec.ReadinessProbe = getVirtualMachineReadinessTCPProbe(testHost, testPort)
+		vm.Spec.ReadinessProbe.TCPSocket.Port = intstr.FromString("my-port")
+		probeCtx := &context.ProbeContext{
+			VM:     vm,
+			Logger: ctrl.Log.WithName("Probe").WithValues("name", vm.NamespacedName()),
+		}
+
+		res, err := testTCPProbe.Probe(probeCtx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res).To(Equal(Success))
+	})
+
+	It("TCP probe fails, with unknown named port", func() {
+		vm.Spec.ReadinessProbe = getVirtualMachineReadinessTCPProbe(testHost, testPort)
+		vm.Spec.ReadinessProbe.TCPSocket.Port = intstr.FromString("my-port")
+		probeCtx := &context.ProbeContext{
+			VM: vm,
+		}
+
+		res, err := testTCPProbe.Probe(probeCtx)
+		Expect(err).Should(HaveOccurred())
+		Expect(res).To(Equal(Failure))
+	})
+
 	It("TCP probe fails", func() {
 		vm.Spec.ReadinessProbe = getVirtualMachineReadinessTCPProbe(testHost, 10001)
 		probeCtx := &context.ProbeContext{
@@ -29,6 +29,7 @@ import (
 const (
 	proberManagerName       = "virtualmachine-prober-manager"
 	readinessProbeQueueName = "readinessProbeQueue"
+	livenessProbeQueueName  = "livenessProbeQueue"
 
 	// defaultPeriodSeconds represents the default value for the frequency (in seconds) to perform the probe.
 	// We use the same default value as the kubernetes container probe.
@@ -37,6 +38,9 @@ const (
 	// the number of readiness workers.
 	// TODO: find a way to calibrate it.
 	numberOfReadinessWorkers = 5
+
+	// the number of liveness workers.
+	numberOfLivenessWorkers = 5
 )
 
 // Manager represents a prober manager interface.
@@ -61,6 +65,15 @@ type manager struct {
 	// adding VMs to the readiness queue when this VM is already in the heap but not in the queue.
 	readinessMutex       sync.Mutex
 	vmReadinessProbeList map[string]vmopv1.VirtualMachineReadinessProbeSpec
+	readinessResults     *worker.ProbeResults
+
+	// livenessQueue and vmLivenessProbeList are the liveness equivalents of
+	// readinessQueue and vmReadinessProbeList. livenessResults tracks the
+	// consecutive failures of the VMs' liveness probes.
+	livenessQueue       worker.DelayingInterface
+	livenessMutex       sync.Mutex
+	vmLivenessProbeList map[string]vmopv1.VirtualMachineLivenessProbeSpec
+	livenessResults     *worker.ProbeResults
 }
 
 // NewManager initializes a prober manager.
@@ -76,6 +89,10 @@ func NewManager(
 		log:                  ctrl.Log.WithName(proberManagerName),
 		recorder:             record,
 		vmReadinessProbeList: make(map[string]vmopv1.VirtualMachineReadinessProbeSpec),
+		readinessResults:     worker.NewProbeResults(),
+		livenessQueue:        workqueue.NewNamedDelayingQueue(livenessProbeQueueName),
+		vmLivenessProbeList:  make(map[string]vmopv1.VirtualMachineLivenessProbeSpec),
+		livenessResults:      worker.NewProbeResults(),
 	}
 	return probeManager
 }
@@ -98,11 +115,19 @@ func (m *manager) AddToProberManager(vm *vmopv1.VirtualMachine) {
 	vmName := vm.NamespacedName()
 	m.log.V(4).Info("Add to prober manager", "vm", vmName)
 
+	m.addToReadinessProbeList(vm)
+	m.addToLivenessProbeList(vm)
+}
+
+func (m *manager) addToReadinessProbeList(vm *vmopv1.VirtualMachine) {
+	vmName := vm.NamespacedName()
+
 	m.readinessMutex.Lock()
 	defer m.readinessMutex.Unlock()
 
 	if vm.Spec.ReadinessProbe != nil &&
-		(vm.Spec.ReadinessProbe.TCPSocket != nil || vm.Spec.ReadinessProbe.GuestHeartbeat != nil || len(vm.Spec.ReadinessProbe.GuestInfo) != 0) {
+		(vm.Spec.ReadinessProbe.TCPSocket != nil || vm.Spec.ReadinessProbe.HTTPGet != nil ||
+			vm.Spec.ReadinessProbe.GuestHeartbeat != nil || len(vm.Spec.ReadinessProbe.GuestInfo) != 0) {
 		// if the VM is not in the list, or its readiness probe spec has been updated, immediately add it to the queue
 		// otherwise, ignore it.
 		if oldProbe, ok := m.vmReadinessProbeList[vmName]; ok && reflect.DeepEqual(oldProbe, vm.Spec.ReadinessProbe) {
@@ -114,6 +139,32 @@ func (m *manager) AddToProberManager(vm *vmopv1.VirtualMachine) {
 		m.vmReadinessProbeList[vmName] = *vm.Spec.ReadinessProbe
 	} else {
 		delete(m.vmReadinessProbeList, vmName)
+		m.readinessResults.Delete(vmName)
+	}
+}
+
+func (m *manager) addToLivenessProbeList(vm *vmopv1.VirtualMachine) {
+	vmName := vm.NamespacedName()
+
+	m.livenessMutex.Lock()
+	defer m.livenessMutex.Unlock()
+
+	if vm.Spec.LivenessProbe != nil &&
+		(vm.Spec.LivenessProbe.TCPSocket != nil || vm.Spec.LivenessProbe.HTTPGet != nil ||
+			vm.Spec.LivenessProbe.GuestHeartbeat != nil || len(vm.Spec.LivenessProbe.GuestInfo) != 0) {
+		// if the VM is not in the list, or its liveness probe spec has been updated, immediately add it to the queue
+		// otherwise, ignore it.
+		if oldProbe, ok := m.vmLivenessProbeList[vmName]; ok && reflect.DeepEqual(oldProbe, *vm.Spec.LivenessProbe) {
+			m.log.V(4).Info("VM is already in the liveness probe list and its probe spec is not updated, skip it", "vm", vmName)
+			return
+		}
+
+		m.livenessQueue.Add(client.ObjectKey{Name: vm.Name, Namespace: vm.Namespace})
+		m.vmLivenessProbeList[vmName] = *vm.Spec.LivenessProbe
+		m.livenessResults.Delete(vmName)
+	} else {
+		delete(m.vmLivenessProbeList, vmName)
+		m.livenessResults.Delete(vmName)
 	}
 }
 
@@ -123,8 +174,14 @@ func (m *manager) RemoveFromProberManager(vm *vmopv1.VirtualMachine) {
 	m.log.V(4).Info("Remove from prober manager", "vm", vmName)
 
 	m.readinessMutex.Lock()
-	defer m.readinessMutex.Unlock()
 	delete(m.vmReadinessProbeList, vmName)
+	m.readinessMutex.Unlock()
+	m.readinessResults.Delete(vmName)
+
+	m.livenessMutex.Lock()
+	delete(m.vmLivenessProbeList, vmName)
+	m.livenessMutex.Unlock()
+	m.livenessResults.Delete(vmName)
 }
 
 // Start starts the probe manager.
@@ -135,13 +192,21 @@ func (m *manager) Start(ctx context.Context) error {
 	m.log.Info("Starting readiness workers", "count", numberOfReadinessWorkers)
 	m.workersWG.Add(numberOfReadinessWorkers)
 	for i := 0; i < numberOfReadinessWorkers; i++ {
-		readinessWorker := worker.NewReadinessWorker(m.readinessQueue, m.prober, m.client, m.recorder)
+		readinessWorker := worker.NewReadinessWorker(m.readinessQueue, m.prober, m.client, m.recorder, m.readinessResults)
 		m.worker(readinessWorker)
 	}
 
+	m.log.Info("Starting liveness workers", "count", numberOfLivenessWorkers)
+	m.workersWG.Add(numberOfLivenessWorkers)
+	for i := 0; i < numberOfLivenessWorkers; i++ {
+		livenessWorker := worker.NewLivenessWorker(m.livenessQueue, m.prober, m.client, m.recorder, m.livenessResults)
+		m.worker(livenessWorker)
+	}
+
 	<-ctx.Done()
 
 	m.readinessQueue.ShutDown()
+	m.livenessQueue.ShutDown()
 	m.workersWG.Wait()
 	return nil
 }
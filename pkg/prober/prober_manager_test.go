@@ -295,6 +295,54 @@ var _ = Describe("VirtualMachine probes", func() {
 				testManager.readinessMutex.Unlock()
 			})
 		})
+
+		When("VM specifies a liveness probe", func() {
+			BeforeEach(func() {
+				vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+					GuestHeartbeat: &vmopv1.GuestHeartbeatAction{},
+					PeriodSeconds:  periodSeconds,
+				}
+			})
+
+			It("Should add to the liveness queue and list", func() {
+				testManager.AddToProberManager(vm)
+
+				Expect(testManager.livenessQueue.Len()).To(Equal(1))
+				testManager.livenessMutex.Lock()
+				Expect(testManager.vmLivenessProbeList).Should(HaveKey(vm.NamespacedName()))
+				testManager.livenessMutex.Unlock()
+			})
+
+			It("Should not add to the liveness queue again if the probe spec is not updated", func() {
+				testManager.AddToProberManager(vm)
+				Expect(testManager.livenessQueue.Len()).To(Equal(1))
+				item, _ := testManager.livenessQueue.Get()
+				testManager.livenessQueue.Done(item)
+
+				testManager.AddToProberManager(vm)
+				Expect(testManager.livenessQueue.Len()).To(Equal(0))
+			})
+
+			It("Should remove from the liveness list if the probe spec is changed to nil", func() {
+				testManager.AddToProberManager(vm)
+
+				vm.Spec.LivenessProbe = nil
+				testManager.AddToProberManager(vm)
+
+				testManager.livenessMutex.Lock()
+				Expect(testManager.vmLivenessProbeList).ShouldNot(HaveKey(vm.NamespacedName()))
+				testManager.livenessMutex.Unlock()
+			})
+
+			It("Should remove from the liveness list when the VM is removed from the prober manager", func() {
+				testManager.AddToProberManager(vm)
+				testManager.RemoveFromProberManager(vm)
+
+				testManager.livenessMutex.Lock()
+				Expect(testManager.vmLivenessProbeList).ShouldNot(HaveKey(vm.NamespacedName()))
+				testManager.livenessMutex.Unlock()
+			})
+		})
 	})
 })
 
@@ -0,0 +1,191 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	proberctx "github.com/vmware-tanzu/vm-operator/pkg/prober/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober/probe"
+	vmoprecord "github.com/vmware-tanzu/vm-operator/pkg/record"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+const (
+	// livenessProbeFailedReason represents the reason for the event emitted
+	// when a VM is restarted because its liveness probe failed.
+	livenessProbeFailedReason string = "LivenessProbeFailed"
+
+	// defaultFailureThreshold is the default number of consecutive times a
+	// liveness probe must fail before the VM is restarted. We use the same
+	// default value as the kubernetes container probe.
+	defaultFailureThreshold = 3
+
+	// nextRestartTimeNow is the value of spec.nextRestartTime that requests
+	// the VM be restarted.
+	nextRestartTimeNow = "now"
+)
+
+// livenessWorker implements Worker interface.
+type livenessWorker struct {
+	queue    DelayingInterface
+	prober   *probe.Prober
+	client   client.Client
+	recorder vmoprecord.Recorder
+	results  *ProbeResults
+}
+
+// NewLivenessWorker creates a new liveness worker to run liveness probes. The
+// results are shared by all of the liveness workers so the consecutive
+// failures of a VM's probe are counted regardless of the worker that ran it.
+func NewLivenessWorker(
+	queue DelayingInterface,
+	prober *probe.Prober,
+	client client.Client,
+	recorder vmoprecord.Recorder,
+	results *ProbeResults,
+) Worker {
+	return &livenessWorker{
+		queue:    queue,
+		prober:   prober,
+		client:   client,
+		recorder: recorder,
+		results:  results,
+	}
+}
+
+func (w *livenessWorker) GetQueue() DelayingInterface {
+	return w.queue
+}
+
+// CreateProbeContext creates a probe context for liveness probe.
+func (w *livenessWorker) CreateProbeContext(vm *vmopv1.VirtualMachine) (*proberctx.ProbeContext, error) {
+	p := vm.Spec.LivenessProbe
+
+	if p == nil || (p.TCPSocket == nil && p.HTTPGet == nil && p.GuestHeartbeat == nil && len(p.GuestInfo) == 0) {
+		return nil, nil
+	}
+
+	patchHelper, err := patch.NewHelper(vm, w.client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proberctx.ProbeContext{
+		Context:       context.Background(),
+		Logger:        ctrl.Log.WithName("liveness-probe").WithValues("vmName", vm.NamespacedName()),
+		PatchHelper:   patchHelper,
+		VM:            vm,
+		ProbeType:     "liveness",
+		PeriodSeconds: p.PeriodSeconds,
+		ProbeSpec:     vmopv1util.LivenessProbeToReadinessProbe(p),
+	}, nil
+}
+
+// ProcessProbeResult processes probe results and restarts the VM when the
+// probe has failed FailureThreshold consecutive times.
+func (w *livenessWorker) ProcessProbeResult(ctx *proberctx.ProbeContext, res probe.Result, resErr error) error {
+	vm := ctx.VM
+	vmName := vm.NamespacedName()
+
+	if !canRestart(vm) {
+		w.results.Delete(vmName)
+		return nil
+	}
+
+	count := w.results.Record(vmName, res)
+	if res != probe.Failure {
+		return nil
+	}
+
+	failureThreshold := vm.Spec.LivenessProbe.FailureThreshold
+	if failureThreshold <= 0 {
+		failureThreshold = defaultFailureThreshold
+	}
+
+	if count < failureThreshold {
+		ctx.Logger.V(4).Info("VM resource LIVENESS probe failed",
+			"failures", count, "failureThreshold", failureThreshold)
+		return nil
+	}
+
+	ctx.Logger.Info("VM resource LIVENESS probe failed, restarting VM",
+		"failures", count, "failureThreshold", failureThreshold, "restartMode", vm.Spec.RestartMode)
+
+	msg := fmt.Sprintf("Liveness probe failed %d times, restarting VM", count)
+	if resErr != nil {
+		msg = fmt.Sprintf("%s: %v", msg, resErr)
+	}
+	w.recorder.Warn(vm, livenessProbeFailedReason, msg)
+
+	// The mutation webhook changes "now" to the current time, which the VM
+	// controller uses to restart the VM in accordance with spec.restartMode.
+	vm.Spec.NextRestartTime = nextRestartTimeNow
+	vm.Status.RestartCount++
+
+	if err := ctx.PatchHelper.Patch(ctx, vm); err != nil {
+		return fmt.Errorf("patched failed: %w", err)
+	}
+
+	w.results.Delete(vmName)
+
+	return nil
+}
+
+func (w *livenessWorker) DoProbe(ctx *proberctx.ProbeContext) error {
+	vm := ctx.VM
+
+	// The initial delay starts when the VM is first probed after it is
+	// powered on or restarted, since the results are deleted while the VM
+	// cannot be restarted.
+	if delay := vm.Spec.LivenessProbe.InitialDelaySeconds; delay > 0 && canRestart(vm) {
+		startTime := w.results.StartTime(vm.NamespacedName())
+		if t := vm.Status.LastRestartTime; t != nil && t.Time.After(startTime) {
+			startTime = t.Time
+		}
+		if remaining := time.Until(startTime.Add(time.Duration(delay) * time.Second)); remaining > 0 {
+			ctx.Logger.V(4).Info("Skipping liveness probe during initial delay", "remaining", remaining)
+			return nil
+		}
+	}
+
+	res, err := runProbe(w.prober, ctx)
+	if err != nil {
+		ctx.Logger.V(4).Info("liveness probe fails", "result", res, "error", err.Error())
+	}
+	return w.ProcessProbeResult(ctx, res, err)
+}
+
+// canRestart returns true if the VM may be restarted. Only a powered on VM may
+// be restarted. Failures are not counted while the VM is not powered on or a
+// restart is still in progress so the VM is not restarted again before the
+// guest has a chance to come up.
+func canRestart(vm *vmopv1.VirtualMachine) bool {
+	return vm.Status.PowerState == vmopv1.VirtualMachinePowerStateOn && !isRestartPending(vm)
+}
+
+// isRestartPending returns true if the VM has been asked to restart but has
+// not been restarted yet.
+func isRestartPending(vm *vmopv1.VirtualMachine) bool {
+	if vm.Spec.NextRestartTime == "" {
+		return false
+	}
+
+	nextRestartTime, err := time.Parse(time.RFC3339Nano, vm.Spec.NextRestartTime)
+	if err != nil {
+		// The value has not been mutated into a timestamp yet.
+		return true
+	}
+
+	// The last restart time is stored with a precision of seconds.
+	lastRestartTime := vm.Status.LastRestartTime
+	return lastRestartTime == nil || lastRestartTime.Time.Before(nextRestartTime.Truncate(time.Second))
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgorecord "k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+
+	proberctx "github.com/vmware-tanzu/vm-operator/pkg/prober/context"
+	fakeprobe "github.com/vmware-tanzu/vm-operator/pkg/prober/fake/probe"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober/probe"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("VirtualMachine liveness probes", func() {
+	var (
+		testWorker Worker
+
+		vm    *vmopv1.VirtualMachine
+		vmKey client.ObjectKey
+
+		fakeClient         client.Client
+		fakeEvents         chan string
+		fakeTCPProbe       *fakeprobe.FakeProbe
+		fakeHeartbeatProbe *fakeprobe.FakeProbe
+		results            *ProbeResults
+	)
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ClassName: "dummy-vmclass",
+				LivenessProbe: &vmopv1.VirtualMachineLivenessProbeSpec{
+					TCPSocket: &vmopv1.TCPSocketAction{
+						Port: intstr.FromInt(10001),
+					},
+					PeriodSeconds:    1,
+					FailureThreshold: 2,
+				},
+			},
+		}
+		vmKey = client.ObjectKey{Name: vm.Name, Namespace: vm.Namespace}
+
+		fakeClient = builder.NewFakeClient()
+		eventRecorder := clientgorecord.NewFakeRecorder(1024)
+		fakeEvents = eventRecorder.Events
+
+		queue := workqueue.NewNamedDelayingQueue("test")
+		fakeTCPProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
+		fakeHeartbeatProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
+		prober := &probe.Prober{
+			TCPProbe:       fakeTCPProbe,
+			GuestHeartbeat: fakeHeartbeatProbe,
+		}
+		results = NewProbeResults()
+		testWorker = NewLivenessWorker(queue, prober, fakeClient, record.New(eventRecorder), results)
+	})
+
+	JustBeforeEach(func() {
+		Expect(fakeClient.Create(context.Background(), vm)).To(Succeed())
+		vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+		Expect(fakeClient.Status().Update(context.Background(), vm)).To(Succeed())
+	})
+
+	doProbe := func() {
+		Expect(fakeClient.Get(context.Background(), vmKey, vm)).To(Succeed())
+		ctx, err := testWorker.CreateProbeContext(vm)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctx).ToNot(BeNil())
+		Expect(testWorker.DoProbe(ctx)).To(Succeed())
+		Expect(fakeClient.Get(context.Background(), vmKey, vm)).To(Succeed())
+	}
+
+	When("the VM does not specify a liveness probe action", func() {
+		BeforeEach(func() {
+			vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{}
+		})
+
+		It("Should not create a probe context", func() {
+			ctx, err := testWorker.CreateProbeContext(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ctx).To(BeNil())
+		})
+	})
+
+	It("Should run the probe for the liveness probe's action", func() {
+		vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+			GuestHeartbeat: &vmopv1.GuestHeartbeatAction{
+				ThresholdStatus: vmopv1.YellowHeartbeatStatus,
+			},
+		}
+		ctx, err := testWorker.CreateProbeContext(vm)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctx.GetProbeSpec().GuestHeartbeat).To(Equal(vm.Spec.LivenessProbe.GuestHeartbeat))
+
+		var called bool
+		fakeHeartbeatProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+			called = true
+			return probe.Success, nil
+		}
+		Expect(testWorker.DoProbe(ctx)).To(Succeed())
+		Expect(called).To(BeTrue())
+	})
+
+	When("the probe succeeds", func() {
+		BeforeEach(func() {
+			fakeTCPProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+				return probe.Success, nil
+			}
+		})
+
+		It("Should not restart the VM", func() {
+			for i := 0; i < 3; i++ {
+				doProbe()
+			}
+			Expect(vm.Spec.NextRestartTime).To(BeEmpty())
+			Expect(vm.Status.RestartCount).To(BeZero())
+			Expect(fakeEvents).ToNot(Receive())
+		})
+	})
+
+	When("the probe fails", func() {
+		BeforeEach(func() {
+			fakeTCPProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+				return probe.Failure, fmt.Errorf("connection refused")
+			}
+		})
+
+		It("Should not restart the VM before the failure threshold is reached", func() {
+			doProbe()
+			Expect(vm.Spec.NextRestartTime).To(BeEmpty())
+			Expect(vm.Status.RestartCount).To(BeZero())
+		})
+
+		It("Should restart the VM when the failure threshold is reached", func() {
+			doProbe()
+			doProbe()
+			Expect(vm.Spec.NextRestartTime).To(Equal("now"))
+			Expect(vm.Status.RestartCount).To(Equal(int32(1)))
+			Expect(fakeEvents).To(Receive(And(
+				ContainSubstring(livenessProbeFailedReason),
+				ContainSubstring("connection refused"))))
+		})
+
+		It("Should not restart the VM if the failures are not consecutive", func() {
+			doProbe()
+			fakeTCPProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+				return probe.Success, nil
+			}
+			doProbe()
+			fakeTCPProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+				return probe.Failure, nil
+			}
+			doProbe()
+			Expect(vm.Spec.NextRestartTime).To(BeEmpty())
+			Expect(vm.Status.RestartCount).To(BeZero())
+		})
+
+		When("the VM is not powered on", func() {
+			JustBeforeEach(func() {
+				vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+				Expect(fakeClient.Status().Update(context.Background(), vm)).To(Succeed())
+			})
+
+			It("Should not restart the VM", func() {
+				doProbe()
+				doProbe()
+				Expect(vm.Spec.NextRestartTime).To(BeEmpty())
+				Expect(vm.Status.RestartCount).To(BeZero())
+			})
+		})
+
+		When("a restart is pending", func() {
+			BeforeEach(func() {
+				vm.Spec.NextRestartTime = time.Now().UTC().Format(time.RFC3339Nano)
+			})
+
+			It("Should not restart the VM again", func() {
+				doProbe()
+				doProbe()
+				Expect(vm.Status.RestartCount).To(BeZero())
+			})
+		})
+
+		When("the probe has an initial delay", func() {
+			BeforeEach(func() {
+				vm.Spec.LivenessProbe.InitialDelaySeconds = 60
+			})
+
+			It("Should not run the probe during the initial delay", func() {
+				var called bool
+				fakeTCPProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+					called = true
+					return probe.Failure, nil
+				}
+
+				doProbe()
+				doProbe()
+				Expect(called).To(BeFalse())
+				Expect(vm.Spec.NextRestartTime).To(BeEmpty())
+				Expect(vm.Status.RestartCount).To(BeZero())
+			})
+
+			It("Should restart the VM once the initial delay has elapsed", func() {
+				results.Lock()
+				results.results[vm.NamespacedName()] = probeResult{startTime: time.Now().Add(-time.Minute)}
+				results.Unlock()
+
+				doProbe()
+				doProbe()
+				Expect(vm.Spec.NextRestartTime).To(Equal("now"))
+				Expect(vm.Status.RestartCount).To(Equal(int32(1)))
+			})
+
+			When("the VM was restarted during the initial delay", func() {
+				JustBeforeEach(func() {
+					results.Lock()
+					results.results[vm.NamespacedName()] = probeResult{startTime: time.Now().Add(-time.Minute)}
+					results.Unlock()
+
+					vm.Status.LastRestartTime = &metav1.Time{Time: time.Now().UTC()}
+					Expect(fakeClient.Status().Update(context.Background(), vm)).To(Succeed())
+				})
+
+				It("Should not restart the VM until the delay after the restart has elapsed", func() {
+					doProbe()
+					doProbe()
+					Expect(vm.Spec.NextRestartTime).To(BeEmpty())
+					Expect(vm.Status.RestartCount).To(BeZero())
+				})
+			})
+		})
+
+		When("the VM was restarted", func() {
+			BeforeEach(func() {
+				vm.Spec.NextRestartTime = time.Now().UTC().Format(time.RFC3339Nano)
+			})
+
+			JustBeforeEach(func() {
+				vm.Status.LastRestartTime = &metav1.Time{Time: time.Now().UTC()}
+				Expect(fakeClient.Status().Update(context.Background(), vm)).To(Succeed())
+			})
+
+			It("Should restart the VM when the failure threshold is reached", func() {
+				doProbe()
+				doProbe()
+				Expect(vm.Spec.NextRestartTime).To(Equal("now"))
+				Expect(vm.Status.RestartCount).To(Equal(int32(1)))
+			})
+		})
+	})
+})
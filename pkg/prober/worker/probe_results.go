@@ -0,0 +1,75 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/prober/probe"
+)
+
+// probeResult is the last result of a VM's probe and the number of times in a
+// row the probe has returned that result.
+type probeResult struct {
+	result probe.Result
+	count  int32
+
+	// startTime is when the VM was first probed.
+	startTime time.Time
+}
+
+// ProbeResults tracks the consecutive results of the probes run against VMs.
+// It is safe for concurrent use by multiple workers.
+type ProbeResults struct {
+	sync.Mutex
+	results map[string]probeResult
+}
+
+// NewProbeResults returns a new ProbeResults.
+func NewProbeResults() *ProbeResults {
+	return &ProbeResults{
+		results: map[string]probeResult{},
+	}
+}
+
+// Record records the result of a VM's probe and returns the number of times in
+// a row the probe has returned that result.
+func (r *ProbeResults) Record(vmName string, res probe.Result) int32 {
+	r.Lock()
+	defer r.Unlock()
+
+	pr := r.results[vmName]
+	if pr.count > 0 && pr.result == res {
+		pr.count++
+	} else {
+		pr.result, pr.count = res, 1
+	}
+	r.results[vmName] = pr
+
+	return pr.count
+}
+
+// StartTime returns the time the VM was first probed, recording the current
+// time if the VM has not been probed before.
+func (r *ProbeResults) StartTime(vmName string) time.Time {
+	r.Lock()
+	defer r.Unlock()
+
+	pr := r.results[vmName]
+	if pr.startTime.IsZero() {
+		pr.startTime = time.Now()
+		r.results[vmName] = pr
+	}
+
+	return pr.startTime
+}
+
+// Delete removes the recorded results of a VM's probe.
+func (r *ProbeResults) Delete(vmName string) {
+	r.Lock()
+	defer r.Unlock()
+
+	delete(r.results, vmName)
+}
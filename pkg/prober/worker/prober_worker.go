@@ -4,6 +4,8 @@
 package worker
 
 import (
+	"fmt"
+
 	"k8s.io/client-go/util/workqueue"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
@@ -21,3 +23,34 @@ type Worker interface {
 	DoProbe(ctx *context.ProbeContext) error
 	ProcessProbeResult(ctx *context.ProbeContext, res probe.Result, resErr error) error
 }
+
+// getProbe returns a specific type of probe method.
+func getProbe(prober *probe.Prober, probeSpec *vmopv1.VirtualMachineReadinessProbeSpec) probe.Probe {
+	if probeSpec == nil {
+		return nil
+	}
+
+	if probeSpec.TCPSocket != nil {
+		return prober.TCPProbe
+	}
+	if probeSpec.HTTPGet != nil {
+		return prober.HTTPGetProbe
+	}
+	if probeSpec.GuestHeartbeat != nil {
+		return prober.GuestHeartbeat
+	}
+	if len(probeSpec.GuestInfo) != 0 {
+		return prober.GuestInfo
+	}
+
+	return nil
+}
+
+// runProbe runs a specific type of probe based on the probe spec.
+func runProbe(prober *probe.Prober, ctx *context.ProbeContext) (probe.Result, error) {
+	if p := getProbe(prober, ctx.GetProbeSpec()); p != nil {
+		return p.Probe(ctx)
+	}
+
+	return probe.Unknown, fmt.Errorf("unknown action specified for VM %s %s probe", ctx.VM.NamespacedName(), ctx.ProbeType)
+}
@@ -6,6 +6,7 @@ package worker
 import (
 	"context"
 	"fmt"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -24,6 +25,18 @@ const (
 	readyReason    string = "Ready"
 	notReadyReason string = "NotReady"
 	unknownReason  string = "Unknown"
+
+	// defaultSuccessThreshold is the default number of consecutive times a
+	// readiness probe must succeed for a VM that is not ready to become ready.
+	// We use the same default value as the kubernetes container probe.
+	defaultSuccessThreshold = 1
+
+	// defaultReadinessFailureThreshold is the default number of consecutive
+	// times a readiness probe must fail for a ready VM to become not ready.
+	// Unlike the kubernetes container probe, this is one so a VM whose probe
+	// does not specify a threshold becomes not ready after a single failure,
+	// as it did before the threshold could be specified.
+	defaultReadinessFailureThreshold = 1
 )
 
 // readinessWorker implements Worker interface.
@@ -32,20 +45,25 @@ type readinessWorker struct {
 	prober   *probe.Prober
 	client   client.Client
 	recorder vmoprecord.Recorder
+	results  *ProbeResults
 }
 
 // NewReadinessWorker creates a new readiness worker to run readiness probes.
+// The results are shared by all of the readiness workers so the consecutive
+// results of a VM's probe are counted regardless of the worker that ran it.
 func NewReadinessWorker(
 	queue DelayingInterface,
 	prober *probe.Prober,
 	client client.Client,
 	recorder vmoprecord.Recorder,
+	results *ProbeResults,
 ) Worker {
 	return &readinessWorker{
 		queue:    queue,
 		prober:   prober,
 		client:   client,
 		recorder: recorder,
+		results:  results,
 	}
 }
 
@@ -57,7 +75,7 @@ func (w *readinessWorker) GetQueue() DelayingInterface {
 func (w *readinessWorker) CreateProbeContext(vm *vmopv1.VirtualMachine) (*proberctx.ProbeContext, error) {
 	p := vm.Spec.ReadinessProbe
 
-	if p.TCPSocket == nil && p.GuestHeartbeat == nil && len(p.GuestInfo) == 0 {
+	if p.TCPSocket == nil && p.HTTPGet == nil && p.GuestHeartbeat == nil && len(p.GuestInfo) == 0 {
 		return nil, nil
 	}
 
@@ -82,6 +100,25 @@ func (w *readinessWorker) ProcessProbeResult(ctx *proberctx.ProbeContext, res pr
 	vm := ctx.VM
 	condition := w.getCondition(res, resErr)
 
+	if vm.Status.PowerState == vmopv1.VirtualMachinePowerStateOn {
+		count := w.results.Record(vm.NamespacedName(), res)
+		if threshold := w.getThreshold(vm.Spec.ReadinessProbe, res); count < threshold {
+			// Do not change the status of the ReadyCondition until the probe
+			// has returned the same result threshold times in a row. A VM
+			// without a ReadyCondition is not ready, so a failure is
+			// reported immediately.
+			c := conditions.Get(vm, condition.Type)
+			if (c != nil && c.Status != condition.Status) || (c == nil && condition.Status == metav1.ConditionTrue) {
+				ctx.Logger.V(4).Info("VM resource READINESS probe threshold not reached",
+					"result", res, "count", count, "threshold", threshold)
+				return nil
+			}
+		}
+	} else {
+		// Start over once the VM is powered on again.
+		w.results.Delete(vm.NamespacedName())
+	}
+
 	// We only send event when either the condition type is added or its status changes, not
 	// if either its reason, severity, or message changes.
 	if c := conditions.Get(vm, condition.Type); c == nil || c.Status != condition.Status {
@@ -109,39 +146,38 @@ func (w *readinessWorker) ProcessProbeResult(ctx *proberctx.ProbeContext, res pr
 }
 
 func (w *readinessWorker) DoProbe(ctx *proberctx.ProbeContext) error {
-	res, err := w.runProbe(ctx)
+	if delay := ctx.VM.Spec.ReadinessProbe.InitialDelaySeconds; delay > 0 {
+		startTime := w.results.StartTime(ctx.VM.NamespacedName())
+		if t := ctx.VM.Status.LastRestartTime; t != nil && t.Time.After(startTime) {
+			startTime = t.Time
+		}
+		if remaining := time.Until(startTime.Add(time.Duration(delay) * time.Second)); remaining > 0 {
+			ctx.Logger.V(4).Info("Skipping readiness probe during initial delay", "remaining", remaining)
+			return nil
+		}
+	}
+
+	res, err := runProbe(w.prober, ctx)
 	if err != nil {
 		ctx.Logger.Error(err, "readiness probe fails", "result", res)
 	}
 	return w.ProcessProbeResult(ctx, res, err)
 }
 
-// getProbe returns a specific type of probe method.
-func (w *readinessWorker) getProbe(probeSpec *vmopv1.VirtualMachineReadinessProbeSpec) probe.Probe {
-	if probeSpec == nil {
-		return nil
-	}
-
-	if probeSpec.TCPSocket != nil {
-		return w.prober.TCPProbe
-	}
-	if probeSpec.GuestHeartbeat != nil {
-		return w.prober.GuestHeartbeat
-	}
-	if len(probeSpec.GuestInfo) != 0 {
-		return w.prober.GuestInfo
+// getThreshold returns the number of times in a row the probe must return the
+// provided result before the ReadyCondition's status is changed.
+func (w *readinessWorker) getThreshold(p *vmopv1.VirtualMachineReadinessProbeSpec, res probe.Result) int32 {
+	if res == probe.Success {
+		if p.SuccessThreshold > 0 {
+			return p.SuccessThreshold
+		}
+		return defaultSuccessThreshold
 	}
 
-	return nil
-}
-
-// runProbe runs a specific type of probe based on the VM probe spec.
-func (w *readinessWorker) runProbe(ctx *proberctx.ProbeContext) (probe.Result, error) {
-	if p := w.getProbe(ctx.VM.Spec.ReadinessProbe); p != nil {
-		return p.Probe(ctx)
+	if p.FailureThreshold > 0 {
+		return p.FailureThreshold
 	}
-
-	return probe.Unknown, fmt.Errorf("unknown action specified for VM %s readiness probe", ctx.VM.NamespacedName())
+	return defaultReadinessFailureThreshold
 }
 
 // getCondition returns condition based on VM probe results.
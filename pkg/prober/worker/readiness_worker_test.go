@@ -39,6 +39,7 @@ var _ = Describe("VirtualMachine readiness probes", func() {
 		fakeRecorder       record.Recorder
 		fakeEvents         chan string
 		fakeTCPProbe       *fakeprobe.FakeProbe
+		fakeHTTPGetProbe   *fakeprobe.FakeProbe
 		fakeHeartbeatProbe *fakeprobe.FakeProbe
 	)
 
@@ -62,12 +63,14 @@ var _ = Describe("VirtualMachine readiness probes", func() {
 
 		queue := workqueue.NewNamedDelayingQueue("test")
 		fakeTCPProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
+		fakeHTTPGetProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
 		fakeHeartbeatProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
 		prober := &probe.Prober{
 			TCPProbe:       fakeTCPProbe,
+			HTTPGetProbe:   fakeHTTPGetProbe,
 			GuestHeartbeat: fakeHeartbeatProbe,
 		}
-		testWorker = NewReadinessWorker(queue, prober, fakeClient, fakeRecorder)
+		testWorker = NewReadinessWorker(queue, prober, fakeClient, fakeRecorder, NewProbeResults())
 	})
 
 	checkReadyCondition := func(c client.Client, objKey client.ObjectKey, expectedCondition metav1.ConditionStatus) {
@@ -160,6 +163,140 @@ var _ = Describe("VirtualMachine readiness probes", func() {
 		})
 	})
 
+	Context("VM has readiness probe thresholds", func() {
+		var (
+			result probe.Result
+		)
+
+		doProbe := func() {
+			Expect(fakeClient.Get(context.Background(), vmKey, vm)).Should(Succeed())
+			var err error
+			ctx, err = testWorker.CreateProbeContext(vm)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+		}
+
+		BeforeEach(func() {
+			result = probe.Success
+			fakeTCPProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+				return result, nil
+			}
+
+			vm.Spec.ReadinessProbe = getVirtualMachineReadinessTCPProbe(10001)
+			vm.Spec.ReadinessProbe.SuccessThreshold = 2
+			vm.Spec.ReadinessProbe.FailureThreshold = 2
+		})
+
+		JustBeforeEach(func() {
+			Expect(fakeClient.Create(context.Background(), vm)).Should(Succeed())
+			vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+			Expect(fakeClient.Status().Update(context.Background(), vm)).Should(Succeed())
+		})
+
+		It("Should not be ready until the success threshold is reached", func() {
+			doProbe()
+			Expect(fakeClient.Get(ctx, vmKey, vm)).Should(Succeed())
+			Expect(conditions.Get(vm, vmopv1.ReadyConditionType)).To(BeNil())
+
+			doProbe()
+			checkReadyCondition(fakeClient, vmKey, metav1.ConditionTrue)
+		})
+
+		It("Should be not ready immediately when there is no ReadyCondition", func() {
+			result = probe.Failure
+			doProbe()
+			checkReadyCondition(fakeClient, vmKey, metav1.ConditionFalse)
+		})
+
+		It("Should not be not ready until the failure threshold is reached", func() {
+			doProbe()
+			doProbe()
+			checkReadyCondition(fakeClient, vmKey, metav1.ConditionTrue)
+
+			result = probe.Failure
+			doProbe()
+			checkReadyCondition(fakeClient, vmKey, metav1.ConditionTrue)
+
+			doProbe()
+			checkReadyCondition(fakeClient, vmKey, metav1.ConditionFalse)
+		})
+
+		It("Should reset the count when the result changes", func() {
+			doProbe()
+			doProbe()
+			checkReadyCondition(fakeClient, vmKey, metav1.ConditionTrue)
+
+			result = probe.Failure
+			doProbe()
+			result = probe.Success
+			doProbe()
+			result = probe.Failure
+			doProbe()
+			checkReadyCondition(fakeClient, vmKey, metav1.ConditionTrue)
+		})
+
+		When("the probe does not specify a failure threshold", func() {
+			BeforeEach(func() {
+				vm.Spec.ReadinessProbe.SuccessThreshold = 1
+				vm.Spec.ReadinessProbe.FailureThreshold = 0
+			})
+
+			It("Should be not ready after a single failure", func() {
+				doProbe()
+				checkReadyCondition(fakeClient, vmKey, metav1.ConditionTrue)
+
+				result = probe.Failure
+				doProbe()
+				checkReadyCondition(fakeClient, vmKey, metav1.ConditionFalse)
+			})
+		})
+
+		When("the probe has an initial delay", func() {
+			BeforeEach(func() {
+				vm.Spec.ReadinessProbe.SuccessThreshold = 1
+				vm.Spec.ReadinessProbe.InitialDelaySeconds = 60
+			})
+
+			It("Should not run the probe during the initial delay", func() {
+				var called bool
+				fakeTCPProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+					called = true
+					return probe.Success, nil
+				}
+
+				doProbe()
+				Expect(called).To(BeFalse())
+				Expect(fakeClient.Get(ctx, vmKey, vm)).Should(Succeed())
+				Expect(conditions.Get(vm, vmopv1.ReadyConditionType)).To(BeNil())
+			})
+		})
+	})
+
+	Context("HTTPGet Probe", func() {
+
+		BeforeEach(func() {
+			vm.Spec.ReadinessProbe = getVirtualMachineReadinessHTTPGetProbe(10001)
+			Expect(fakeClient.Create(context.Background(), vm)).Should(Succeed())
+			Expect(fakeClient.Get(context.Background(), vmKey, vm)).Should(Succeed())
+			var err error
+			ctx, err = testWorker.CreateProbeContext(vm)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		// Just need to test for probe selection.
+		It("Should update ReadyCondition when probe fails", func() {
+			fakeHTTPGetProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+				return probe.Failure, fmt.Errorf("http get error")
+			}
+
+			Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+			Expect(fakeClient.Get(ctx, vmKey, vm)).Should(Succeed())
+			condition := conditions.Get(vm, vmopv1.ReadyConditionType)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Message).To(ContainSubstring("http get error"))
+		})
+	})
+
 	Context("Guest heartbeat Probe", func() {
 
 		BeforeEach(func() {
@@ -206,3 +343,12 @@ func getVirtualMachineHeartbeatProbe() *vmopv1.VirtualMachineReadinessProbeSpec
 		PeriodSeconds:  1,
 	}
 }
+
+func getVirtualMachineReadinessHTTPGetProbe(port int) *vmopv1.VirtualMachineReadinessProbeSpec {
+	return &vmopv1.VirtualMachineReadinessProbeSpec{
+		HTTPGet: &vmopv1.HTTPGetAction{
+			Port: intstr.FromInt(port),
+		},
+		PeriodSeconds: 1,
+	}
+}
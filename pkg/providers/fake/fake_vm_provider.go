@@ -17,6 +17,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
 	vsclient "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/client"
 )
 
@@ -32,10 +33,23 @@ type funcs struct {
 	DeleteVirtualMachineFn         func(ctx context.Context, vm *vmopv1.VirtualMachine) error
 	PublishVirtualMachineFn        func(ctx context.Context, vm *vmopv1.VirtualMachine,
 		vmPub *vmopv1.VirtualMachinePublishRequest, cl *imgregv1a1.ContentLibrary, actID string) (string, error)
-	GetVirtualMachineGuestHeartbeatFn  func(ctx context.Context, vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error)
-	GetVirtualMachinePropertiesFn      func(ctx context.Context, vm *vmopv1.VirtualMachine, propertyPaths []string) (map[string]any, error)
-	GetVirtualMachineWebMKSTicketFn    func(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey string) (string, error)
-	GetVirtualMachineHardwareVersionFn func(ctx context.Context, vm *vmopv1.VirtualMachine) (vimtypes.HardwareVersion, error)
+	PublishVirtualMachineToOCIRegistryFn func(ctx context.Context, vm *vmopv1.VirtualMachine,
+		vmPub *vmopv1.VirtualMachinePublishRequest, opts oci.Options) (string, error)
+	ImportVirtualMachineImageFn func(ctx context.Context, vmImport *vmopv1.VirtualMachineImageImportRequest,
+		cl *imgregv1a1.ContentLibrary, sourceURL string) (string, error)
+	ExportVirtualMachineFn func(ctx context.Context, vm *vmopv1.VirtualMachine,
+		vmExport *vmopv1.VirtualMachineExport, targetURL, token string) ([]string, error)
+	GetVirtualMachineGuestHeartbeatFn      func(ctx context.Context, vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error)
+	GetVirtualMachinePropertiesFn          func(ctx context.Context, vm *vmopv1.VirtualMachine, propertyPaths []string) (map[string]any, error)
+	GetVirtualMachineWebMKSTicketFn        func(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey string) (string, error)
+	GetVirtualMachineSerialConsoleTicketFn func(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey, uuid string) (string, error)
+	RunVirtualMachineGuestOperationFn      func(ctx context.Context, vm *vmopv1.VirtualMachine, op *vmopv1.VirtualMachineGuestOperation, username, password string, content []byte) error
+	GetVirtualMachineHardwareVersionFn     func(ctx context.Context, vm *vmopv1.VirtualMachine) (vimtypes.HardwareVersion, error)
+
+	CreateVirtualMachineSnapshotFn   func(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) (string, error)
+	GetVirtualMachineSnapshotInfoFn  func(ctx context.Context, vm *vmopv1.VirtualMachine) (*vimtypes.VirtualMachineSnapshotInfo, error)
+	RevertVirtualMachineToSnapshotFn func(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error
+	DeleteVirtualMachineSnapshotFn   func(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error
 
 	// ListItemsFromContentLibraryFn              func(ctx context.Context, contentLibrary *vmopv1.ContentLibraryProvider) ([]string, error)
 	// GetVirtualMachineImageFromContentLibraryFn func(ctx context.Context, contentLibrary *vmopv1.ContentLibraryProvider, itemID string,
@@ -43,6 +57,7 @@ type funcs struct {
 
 	GetItemFromLibraryByNameFn func(ctx context.Context, contentLibrary, itemName string) (*library.Item, error)
 	UpdateContentLibraryItemFn func(ctx context.Context, itemID, newName string, newDescription *string) error
+	DeleteContentLibraryItemFn func(ctx context.Context, itemID string) error
 	SyncVirtualMachineImageFn  func(ctx context.Context, cli, vmi client.Object) error
 
 	UpdateVcPNIDFn  func(ctx context.Context, vcPNID, vcPort string) error
@@ -117,6 +132,44 @@ func (s *VMProvider) PublishVirtualMachine(ctx context.Context, vm *vmopv1.Virtu
 	return "dummy-id", nil
 }
 
+func (s *VMProvider) PublishVirtualMachineToOCIRegistry(ctx context.Context, vm *vmopv1.VirtualMachine,
+	vmPub *vmopv1.VirtualMachinePublishRequest, opts oci.Options) (string, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.isPublishVMCalled = true
+
+	if s.PublishVirtualMachineToOCIRegistryFn != nil {
+		return s.PublishVirtualMachineToOCIRegistryFn(ctx, vm, vmPub, opts)
+	}
+
+	return vmPub.Status.TargetRef.OCIRegistry.Repository + "@sha256:dummy-digest", nil
+}
+
+func (s *VMProvider) ImportVirtualMachineImage(ctx context.Context, vmImport *vmopv1.VirtualMachineImageImportRequest,
+	cl *imgregv1a1.ContentLibrary, sourceURL string) (string, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.ImportVirtualMachineImageFn != nil {
+		return s.ImportVirtualMachineImageFn(ctx, vmImport, cl, sourceURL)
+	}
+
+	return "dummy-id", nil
+}
+
+func (s *VMProvider) ExportVirtualMachine(ctx context.Context, vm *vmopv1.VirtualMachine,
+	vmExport *vmopv1.VirtualMachineExport, targetURL, token string) ([]string, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.ExportVirtualMachineFn != nil {
+		return s.ExportVirtualMachineFn(ctx, vm, vmExport, targetURL, token)
+	}
+
+	return []string{vm.Name + ".ovf", vm.Name + "-disk-0.vmdk"}, nil
+}
+
 func (s *VMProvider) GetVirtualMachineGuestHeartbeat(ctx context.Context, vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error) {
 	s.Lock()
 	defer s.Unlock()
@@ -148,6 +201,25 @@ func (s *VMProvider) GetVirtualMachineWebMKSTicket(ctx context.Context, vm *vmop
 	return "", nil
 }
 
+func (s *VMProvider) GetVirtualMachineSerialConsoleTicket(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey, uuid string) (string, error) {
+	s.Lock()
+	defer s.Unlock()
+	if s.GetVirtualMachineSerialConsoleTicketFn != nil {
+		return s.GetVirtualMachineSerialConsoleTicketFn(ctx, vm, pubKey, uuid)
+	}
+	return "", nil
+}
+
+func (s *VMProvider) RunVirtualMachineGuestOperation(ctx context.Context, vm *vmopv1.VirtualMachine,
+	op *vmopv1.VirtualMachineGuestOperation, username, password string, content []byte) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.RunVirtualMachineGuestOperationFn != nil {
+		return s.RunVirtualMachineGuestOperationFn(ctx, vm, op, username, password, content)
+	}
+	return nil
+}
+
 func (s *VMProvider) GetVirtualMachineHardwareVersion(ctx context.Context, vm *vmopv1.VirtualMachine) (vimtypes.HardwareVersion, error) {
 	s.Lock()
 	defer s.Unlock()
@@ -157,6 +229,57 @@ func (s *VMProvider) GetVirtualMachineHardwareVersion(ctx context.Context, vm *v
 	return vimtypes.VMX15, nil
 }
 
+func (s *VMProvider) CreateVirtualMachineSnapshot(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) (string, error) {
+
+	s.Lock()
+	defer s.Unlock()
+	if s.CreateVirtualMachineSnapshotFn != nil {
+		return s.CreateVirtualMachineSnapshotFn(ctx, vm, vmSnapshot)
+	}
+	return "snapshot-" + vmSnapshot.Name, nil
+}
+
+func (s *VMProvider) GetVirtualMachineSnapshotInfo(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine) (*vimtypes.VirtualMachineSnapshotInfo, error) {
+
+	s.Lock()
+	defer s.Unlock()
+	if s.GetVirtualMachineSnapshotInfoFn != nil {
+		return s.GetVirtualMachineSnapshotInfoFn(ctx, vm)
+	}
+	return nil, nil
+}
+
+func (s *VMProvider) RevertVirtualMachineToSnapshot(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+
+	s.Lock()
+	defer s.Unlock()
+	if s.RevertVirtualMachineToSnapshotFn != nil {
+		return s.RevertVirtualMachineToSnapshotFn(ctx, vm, vmSnapshot)
+	}
+	return nil
+}
+
+func (s *VMProvider) DeleteVirtualMachineSnapshot(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+
+	s.Lock()
+	defer s.Unlock()
+	if s.DeleteVirtualMachineSnapshotFn != nil {
+		return s.DeleteVirtualMachineSnapshotFn(ctx, vm, vmSnapshot)
+	}
+	return nil
+}
+
 func (s *VMProvider) CreateOrUpdateVirtualMachineSetResourcePolicy(ctx context.Context, resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) error {
 	s.Lock()
 	defer s.Unlock()
@@ -285,6 +408,16 @@ func (s *VMProvider) UpdateContentLibraryItem(ctx context.Context, itemID, newNa
 	return nil
 }
 
+func (s *VMProvider) DeleteContentLibraryItem(ctx context.Context, itemID string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.DeleteContentLibraryItemFn != nil {
+		return s.DeleteContentLibraryItemFn(ctx, itemID)
+	}
+	return nil
+}
+
 func (s *VMProvider) GetTasksByActID(ctx context.Context, actID string) (tasksInfo []vimtypes.TaskInfo, retErr error) {
 	s.Lock()
 	defer s.Unlock()
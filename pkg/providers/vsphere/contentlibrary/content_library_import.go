@@ -0,0 +1,510 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contentlibrary
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/vmware/govmomi/ovf"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+)
+
+// ErrInvalidOVF is returned when an OVF or OVA is not valid. Downloading the
+// same OVF or OVA again does not resolve this error.
+var ErrInvalidOVF = errors.New("invalid OVF")
+
+const (
+	// maxOVFDescriptorSize is the maximum size of an OVF descriptor.
+	maxOVFDescriptorSize = 10 * 1024 * 1024
+
+	// maxImportSize is the maximum size of all of the files of an OVF or
+	// OVA, or of the OVA itself.
+	maxImportSize = 512 * 1024 * 1024 * 1024
+
+	importDialTimeout           = 30 * time.Second
+	importResponseHeaderTimeout = time.Minute
+	importTimeout               = 4 * time.Hour
+	importMaxRedirects          = 10
+)
+
+// NewImportHTTPClient returns the HTTP client used to download an OVF or OVA
+// that is imported into a content library. The client times out after
+// importTimeout and only follows redirects to HTTP or HTTPS URLs.
+//
+// When restricted is true, the client does not use a proxy and refuses to
+// connect to loopback, private, shared, link-local, multicast and unspecified
+// addresses, so a user-provided URL cannot be used to reach services that
+// are internal to the cluster or to the infrastructure.
+func NewImportHTTPClient(restricted, insecureSkipTLSVerify bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   importDialTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = importResponseHeaderTimeout
+	if restricted {
+		// The address is checked after it is resolved, so a host name that
+		// resolves to a disallowed address is refused as well.
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			return checkImportAddress(address)
+		}
+		transport.Proxy = nil
+	}
+	transport.DialContext = dialer.DialContext
+	if insecureSkipTLSVerify {
+		transport.TLSClientConfig = &tls.Config{
+			//nolint:gosec // The user opted out of verifying the certificate.
+			InsecureSkipVerify: true,
+		}
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   importTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= importMaxRedirects {
+				return fmt.Errorf("stopped after %d redirects", importMaxRedirects)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("unsupported redirect URL scheme %q", req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+}
+
+// sharedAddressSpace is the address block shared by service providers and
+// their subscribers for carrier-grade NAT, as defined in RFC 6598. IP.IsPrivate
+// does not include it, but it is commonly used for pod and service networks.
+var sharedAddressSpace = &net.IPNet{
+	IP:   net.IPv4(100, 64, 0, 0),
+	Mask: net.CIDRMask(10, 32),
+}
+
+func checkImportAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid address %q", host)
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip) {
+
+		return fmt.Errorf("connecting to the address %s is not allowed", ip)
+	}
+	return nil
+}
+
+// ImportFile is a file of an OVF or OVA that is uploaded to a content library
+// item. Size is zero if the size of the file is not known in advance.
+type ImportFile struct {
+	Name   string
+	Size   int64
+	Reader io.Reader
+}
+
+// ImportSource returns the files of an OVF or OVA one at a time so they may be
+// streamed to a content library item without being stored locally. The OVF
+// descriptor is always the first file, and Next returns io.EOF after the last
+// file. The Reader of a file may only be read until Next is called again.
+type ImportSource interface {
+	Next() (*ImportFile, error)
+	Close() error
+}
+
+// OpenOVF opens the OVF or OVA at the provided HTTP or HTTPS URL. The files
+// referenced by an OVF descriptor are downloaded relative to the descriptor's
+// URL, while the files of an OVA are read from the OVA as it is downloaded.
+//
+// The OVF envelope is returned along with the source of the files to upload,
+// which must be closed by the caller. An error that wraps ErrInvalidOVF is
+// returned by OpenOVF or by the source if the OVF or OVA is not valid or
+// exceeds the maximum supported size.
+func OpenOVF(
+	ctx context.Context,
+	client *http.Client,
+	rawURL string) (*ovf.Envelope, ImportSource, error) {
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, nil, fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+	}
+
+	remaining := int64(maxImportSize)
+	if strings.EqualFold(path.Ext(u.Path), ".ova") {
+		return openOVA(ctx, client, u, &remaining)
+	}
+	return openOVFDescriptor(ctx, client, u, &remaining)
+}
+
+// ValidateOvfEnvelope returns an error that wraps ErrInvalidOVF if the OVF
+// envelope cannot be realized as a VirtualMachineImage.
+func ValidateOvfEnvelope(ovfEnvelope *ovf.Envelope) error {
+	if ovfEnvelope.VirtualSystem == nil {
+		return fmt.Errorf("%w: the OVF does not have a virtual system", ErrInvalidOVF)
+	}
+	if len(ovfEnvelope.VirtualSystem.VirtualHardware) == 0 {
+		return fmt.Errorf("%w: the OVF does not have a virtual hardware section", ErrInvalidOVF)
+	}
+	if ovfEnvelope.Disk == nil || len(ovfEnvelope.Disk.Disks) == 0 {
+		return fmt.Errorf("%w: the OVF does not have any disks", ErrInvalidOVF)
+	}
+
+	files := map[string]struct{}{}
+	for _, f := range ovfEnvelope.References {
+		files[f.ID] = struct{}{}
+	}
+	for _, d := range ovfEnvelope.Disk.Disks {
+		if d.FileRef == nil {
+			continue
+		}
+		if _, ok := files[*d.FileRef]; !ok {
+			return fmt.Errorf("%w: disk %q references the file %q that does not exist",
+				ErrInvalidOVF, d.DiskID, *d.FileRef)
+		}
+	}
+
+	// Realize the image from the OVF in the same way the image is realized
+	// once the OVF is in a content library.
+	vmi := &vmopv1.VirtualMachineImage{}
+	UpdateVmiWithOvfEnvelope(vmi, *ovfEnvelope)
+
+	for i, d := range vmi.Status.Disks {
+		if d.Capacity == nil || d.Capacity.IsZero() {
+			return fmt.Errorf("%w: disk %q does not have a capacity",
+				ErrInvalidOVF, ovfEnvelope.Disk.Disks[i].DiskID)
+		}
+	}
+
+	return nil
+}
+
+// openOVA starts to download the OVA at the provided URL and reads the OVF
+// descriptor, which the OVF specification requires to be the first file of an
+// OVA.
+func openOVA(
+	ctx context.Context,
+	client *http.Client,
+	u *url.URL,
+	remaining *int64) (*ovf.Envelope, ImportSource, error) {
+
+	resp, err := get(ctx, client, u)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	src := &ovaSource{
+		body: resp.Body,
+		tr:   tar.NewReader(newLimitedReader(resp.Body, remaining)),
+	}
+
+	ovfEnvelope, err := src.readDescriptor()
+	if err != nil {
+		_ = src.Close()
+		return nil, nil, err
+	}
+
+	return ovfEnvelope, src, nil
+}
+
+// ovaSource returns the files of an OVA in the order in which they are stored
+// in the OVA.
+type ovaSource struct {
+	body       io.ReadCloser
+	tr         *tar.Reader
+	descriptor *ImportFile
+
+	// missing are the names of the files referenced by the OVF descriptor
+	// that have not yet been read from the OVA.
+	missing []string
+}
+
+func (s *ovaSource) readDescriptor() (*ovf.Envelope, error) {
+	hdr, err := s.nextEntry()
+	if errors.Is(err, io.EOF) || (err == nil && !strings.EqualFold(path.Ext(hdr.Name), ".ovf")) {
+		return nil, fmt.Errorf("%w: the OVA does not contain an OVF descriptor as its first file", ErrInvalidOVF)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := fileName(hdr.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := readDescriptor(s.tr)
+	if err != nil {
+		return nil, err
+	}
+
+	ovfEnvelope, err := parseOvfEnvelope(b)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range ovfEnvelope.References {
+		name, err := fileName(f.Href)
+		if err != nil {
+			return nil, err
+		}
+		s.missing = append(s.missing, name)
+	}
+
+	s.descriptor = &ImportFile{Name: name, Size: int64(len(b)), Reader: bytes.NewReader(b)}
+	return ovfEnvelope, nil
+}
+
+func (s *ovaSource) Next() (*ImportFile, error) {
+	if f := s.descriptor; f != nil {
+		s.descriptor = nil
+		return f, nil
+	}
+
+	hdr, err := s.nextEntry()
+	if errors.Is(err, io.EOF) {
+		if len(s.missing) > 0 {
+			return nil, fmt.Errorf("%w: the OVA does not contain the file %q", ErrInvalidOVF, s.missing[0])
+		}
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := fileName(hdr.Name)
+	if err != nil {
+		return nil, err
+	}
+	s.missing = slices.DeleteFunc(s.missing, func(m string) bool { return m == name })
+
+	return &ImportFile{Name: name, Size: hdr.Size, Reader: s.tr}, nil
+}
+
+func (s *ovaSource) Close() error {
+	return s.body.Close()
+}
+
+// nextEntry returns the header of the next regular file in the OVA.
+func (s *ovaSource) nextEntry() (*tar.Header, error) {
+	for {
+		hdr, err := s.tr.Next()
+		if errors.Is(err, io.EOF) || errors.Is(err, errImportTooLarge) {
+			return nil, err
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to read OVA: %w", ErrInvalidOVF, err)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			return hdr, nil
+		}
+	}
+}
+
+// openOVFDescriptor downloads the OVF descriptor at the provided URL. The
+// files it references are downloaded as they are returned by the source.
+func openOVFDescriptor(
+	ctx context.Context,
+	client *http.Client,
+	u *url.URL,
+	remaining *int64) (*ovf.Envelope, ImportSource, error) {
+
+	name, err := fileName(u.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := get(ctx, client, u)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := readDescriptor(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ovfEnvelope, err := parseOvfEnvelope(b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	src := &ovfSource{
+		ctx:        ctx,
+		client:     client,
+		remaining:  remaining,
+		descriptor: &ImportFile{Name: name, Size: int64(len(b)), Reader: bytes.NewReader(b)},
+	}
+
+	for _, f := range ovfEnvelope.References {
+		ref, err := url.Parse(f.Href)
+		if err != nil || ref.IsAbs() || ref.Host != "" || path.IsAbs(ref.Path) {
+			return nil, nil, fmt.Errorf("%w: the file %q is not relative to the OVF descriptor",
+				ErrInvalidOVF, f.Href)
+		}
+
+		if _, err := fileName(ref.Path); err != nil {
+			return nil, nil, err
+		}
+		src.files = append(src.files, u.ResolveReference(ref))
+	}
+
+	return ovfEnvelope, src, nil
+}
+
+// ovfSource returns the OVF descriptor followed by the files it references in
+// the order in which they are referenced.
+type ovfSource struct {
+	ctx        context.Context
+	client     *http.Client
+	remaining  *int64
+	descriptor *ImportFile
+	files      []*url.URL
+	body       io.ReadCloser
+}
+
+func (s *ovfSource) Next() (*ImportFile, error) {
+	if f := s.descriptor; f != nil {
+		s.descriptor = nil
+		return f, nil
+	}
+
+	if err := s.Close(); err != nil {
+		return nil, err
+	}
+	if len(s.files) == 0 {
+		return nil, io.EOF
+	}
+
+	u := s.files[0]
+	s.files = s.files[1:]
+
+	name, err := fileName(u.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := get(s.ctx, s.client, u)
+	if err != nil {
+		return nil, err
+	}
+	s.body = resp.Body
+
+	return &ImportFile{
+		Name:   name,
+		Size:   max(resp.ContentLength, 0),
+		Reader: newLimitedReader(resp.Body, s.remaining),
+	}, nil
+}
+
+func (s *ovfSource) Close() error {
+	if s.body == nil {
+		return nil
+	}
+	err := s.body.Close()
+	s.body = nil
+	return err
+}
+
+// fileName returns the base name of the provided path. Content library items
+// do not have directories, so the files of an OVF are uploaded by their base
+// name.
+func fileName(p string) (string, error) {
+	name := path.Base(p)
+	if name == "." || name == ".." || name == "/" {
+		return "", fmt.Errorf("%w: invalid file name %q", ErrInvalidOVF, p)
+	}
+	return name, nil
+}
+
+// readDescriptor reads an OVF descriptor into memory. An error that wraps
+// ErrInvalidOVF is returned if the descriptor exceeds the maximum supported
+// size.
+func readDescriptor(r io.Reader) ([]byte, error) {
+	b, err := io.ReadAll(io.LimitReader(r, maxOVFDescriptorSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > maxOVFDescriptorSize {
+		return nil, fmt.Errorf("%w: the OVF descriptor exceeds the maximum supported size", ErrInvalidOVF)
+	}
+	return b, nil
+}
+
+func parseOvfEnvelope(b []byte) (*ovf.Envelope, error) {
+	ovfEnvelope, err := ovf.Unmarshal(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse OVF descriptor: %w", ErrInvalidOVF, err)
+	}
+	return ovfEnvelope, nil
+}
+
+func get(ctx context.Context, client *http.Client, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("failed to download %s: %s", u.Redacted(), resp.Status)
+	}
+
+	return resp, nil
+}
+
+var errImportTooLarge = fmt.Errorf("%w: the image exceeds the maximum supported size", ErrInvalidOVF)
+
+// limitedReader returns errImportTooLarge once more than the remaining number
+// of bytes are read. The remaining number of bytes may be shared by several
+// readers to limit the total size of a download.
+type limitedReader struct {
+	r         io.Reader
+	remaining *int64
+}
+
+func newLimitedReader(r io.Reader, remaining *int64) io.Reader {
+	return &limitedReader{r: r, remaining: remaining}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if limit := *l.remaining + 1; int64(len(p)) > limit {
+		p = p[:limit]
+	}
+	n, err := l.r.Read(p)
+	*l.remaining -= int64(n)
+	if *l.remaining < 0 {
+		return n, errImportTooLarge
+	}
+	return n, err
+}
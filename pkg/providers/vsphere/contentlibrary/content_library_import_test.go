@@ -0,0 +1,341 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contentlibrary_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/ovf"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/contentlibrary"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/testutil"
+)
+
+var _ = Describe("OpenOVF", func() {
+	const (
+		diskName    = "ttylinux-pc_i486-16.1-disk1.vmdk"
+		diskContent = "dummy-disk"
+	)
+
+	var (
+		ctx      context.Context
+		ovfBytes []byte
+		files    map[string][]byte
+		server   *httptest.Server
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		var err error
+		ovfBytes, err = os.ReadFile(filepath.Join(
+			testutil.GetRootDirOrDie(),
+			"test", "builder", "testdata",
+			"images", "ttylinux-pc_i486-16.1.ovf"))
+		Expect(err).ToNot(HaveOccurred())
+
+		files = map[string][]byte{
+			"/images/ttylinux.ovf":        ovfBytes,
+			"/images/" + diskName:         []byte(diskContent),
+			"/images/ttylinux-no-ovf.ova": newOVA(map[string][]byte{diskName: []byte(diskContent)}),
+		}
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, ok := files[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(b)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("the URL is an OVF descriptor", func() {
+		It("returns the descriptor and the files it references", func() {
+			ovfEnvelope, src, err := contentlibrary.OpenOVF(ctx, server.Client(), server.URL+"/images/ttylinux.ovf")
+			Expect(err).ToNot(HaveOccurred())
+			defer func() {
+				Expect(src.Close()).To(Succeed())
+			}()
+			Expect(ovfEnvelope).ToNot(BeNil())
+			Expect(ovfEnvelope.VirtualSystem).ToNot(BeNil())
+
+			names, contents, err := readImportSource(src)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names).To(Equal([]string{"ttylinux.ovf", diskName}))
+			Expect(contents[0]).To(Equal(ovfBytes))
+			Expect(string(contents[1])).To(Equal(diskContent))
+		})
+
+		When("a referenced file does not exist", func() {
+			BeforeEach(func() {
+				delete(files, "/images/"+diskName)
+			})
+
+			It("returns an error from the source", func() {
+				_, src, err := contentlibrary.OpenOVF(ctx, server.Client(), server.URL+"/images/ttylinux.ovf")
+				Expect(err).ToNot(HaveOccurred())
+				defer func() {
+					Expect(src.Close()).To(Succeed())
+				}()
+
+				_, _, err = readImportSource(src)
+				Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+				Expect(err).ToNot(MatchError(contentlibrary.ErrInvalidOVF))
+			})
+		})
+
+		When("the descriptor is not valid XML", func() {
+			BeforeEach(func() {
+				files["/images/ttylinux.ovf"] = []byte("not-xml")
+			})
+
+			It("returns an invalid OVF error", func() {
+				_, _, err := contentlibrary.OpenOVF(ctx, server.Client(), server.URL+"/images/ttylinux.ovf")
+				Expect(err).To(MatchError(contentlibrary.ErrInvalidOVF))
+			})
+		})
+
+		When("the descriptor is too large", func() {
+			BeforeEach(func() {
+				files["/images/ttylinux.ovf"] = bytes.Repeat([]byte("a"), 10*1024*1024+1)
+			})
+
+			It("returns an invalid OVF error", func() {
+				_, _, err := contentlibrary.OpenOVF(ctx, server.Client(), server.URL+"/images/ttylinux.ovf")
+				Expect(err).To(MatchError(contentlibrary.ErrInvalidOVF))
+				Expect(err).To(MatchError(ContainSubstring("exceeds the maximum supported size")))
+			})
+		})
+	})
+
+	When("the URL is an OVA", func() {
+		BeforeEach(func() {
+			files["/images/ttylinux.ova"] = newOVA(map[string][]byte{
+				"ttylinux.ovf": ovfBytes,
+				diskName:       []byte(diskContent),
+			})
+		})
+
+		It("returns the files of the OVA", func() {
+			ovfEnvelope, src, err := contentlibrary.OpenOVF(ctx, server.Client(), server.URL+"/images/ttylinux.ova")
+			Expect(err).ToNot(HaveOccurred())
+			defer func() {
+				Expect(src.Close()).To(Succeed())
+			}()
+			Expect(ovfEnvelope).ToNot(BeNil())
+
+			names, contents, err := readImportSource(src)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names).To(Equal([]string{"ttylinux.ovf", diskName}))
+			Expect(contents[0]).To(Equal(ovfBytes))
+			Expect(string(contents[1])).To(Equal(diskContent))
+		})
+
+		When("the OVA does not start with an OVF descriptor", func() {
+			It("returns an invalid OVF error", func() {
+				_, _, err := contentlibrary.OpenOVF(ctx, server.Client(), server.URL+"/images/ttylinux-no-ovf.ova")
+				Expect(err).To(MatchError(contentlibrary.ErrInvalidOVF))
+				Expect(err).To(MatchError(ContainSubstring("does not contain an OVF descriptor")))
+			})
+		})
+
+		When("the OVA does not contain a referenced file", func() {
+			BeforeEach(func() {
+				files["/images/ttylinux.ova"] = newOVA(map[string][]byte{
+					"ttylinux.ovf": ovfBytes,
+				})
+			})
+
+			It("returns an invalid OVF error from the source", func() {
+				_, src, err := contentlibrary.OpenOVF(ctx, server.Client(), server.URL+"/images/ttylinux.ova")
+				Expect(err).ToNot(HaveOccurred())
+				defer func() {
+					Expect(src.Close()).To(Succeed())
+				}()
+
+				_, _, err = readImportSource(src)
+				Expect(err).To(MatchError(contentlibrary.ErrInvalidOVF))
+				Expect(err).To(MatchError(ContainSubstring(diskName)))
+			})
+		})
+	})
+
+	When("the URL scheme is not supported", func() {
+		It("returns an error", func() {
+			_, _, err := contentlibrary.OpenOVF(ctx, server.Client(), "ftp://example.com/ttylinux.ova")
+			Expect(err).To(MatchError(`unsupported URL scheme "ftp"`))
+		})
+	})
+})
+
+var _ = Describe("NewImportHTTPClient", func() {
+	var (
+		ctx    context.Context
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("the client is restricted", func() {
+		It("refuses to connect to a loopback address", func() {
+			client := contentlibrary.NewImportHTTPClient(true, false)
+			_, _, err := contentlibrary.OpenOVF(ctx, client, server.URL+"/images/ttylinux.ovf")
+			Expect(err).To(MatchError(ContainSubstring("connecting to the address 127.0.0.1 is not allowed")))
+		})
+
+		It("refuses to connect to a link-local address", func() {
+			client := contentlibrary.NewImportHTTPClient(true, false)
+			_, _, err := contentlibrary.OpenOVF(ctx, client, "http://169.254.169.254/images/ttylinux.ovf")
+			Expect(err).To(MatchError(ContainSubstring("connecting to the address 169.254.169.254 is not allowed")))
+		})
+
+		It("refuses to connect to a shared address", func() {
+			client := contentlibrary.NewImportHTTPClient(true, false)
+			_, _, err := contentlibrary.OpenOVF(ctx, client, "http://100.64.0.10/images/ttylinux.ovf")
+			Expect(err).To(MatchError(ContainSubstring("connecting to the address 100.64.0.10 is not allowed")))
+		})
+	})
+
+	When("the client is not restricted", func() {
+		It("connects to a loopback address", func() {
+			client := contentlibrary.NewImportHTTPClient(false, false)
+			_, _, err := contentlibrary.OpenOVF(ctx, client, server.URL+"/images/ttylinux.ovf")
+			Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+		})
+	})
+})
+
+var _ = Describe("ValidateOvfEnvelope", func() {
+	var (
+		ovfEnvelope *ovf.Envelope
+	)
+
+	BeforeEach(func() {
+		ovfEnvelope = &ovf.Envelope{
+			References: []ovf.File{
+				{ID: "file1", Href: "disk1.vmdk"},
+			},
+			Disk: &ovf.DiskSection{
+				Disks: []ovf.VirtualDiskDesc{
+					{
+						DiskID:                  "vmdisk1",
+						FileRef:                 ptr.To("file1"),
+						Capacity:                "30",
+						CapacityAllocationUnits: ptr.To("byte * 2^20"),
+					},
+				},
+			},
+			VirtualSystem: &ovf.VirtualSystem{
+				VirtualHardware: []ovf.VirtualHardwareSection{{}},
+			},
+		}
+	})
+
+	It("returns nil for a valid OVF", func() {
+		Expect(contentlibrary.ValidateOvfEnvelope(ovfEnvelope)).To(Succeed())
+	})
+
+	DescribeTable("invalid OVFs",
+		func(mutateFn func(*ovf.Envelope), expectedErr string) {
+			mutateFn(ovfEnvelope)
+			err := contentlibrary.ValidateOvfEnvelope(ovfEnvelope)
+			Expect(err).To(MatchError(contentlibrary.ErrInvalidOVF))
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		},
+		Entry("no virtual system",
+			func(e *ovf.Envelope) { e.VirtualSystem = nil },
+			"does not have a virtual system"),
+		Entry("no virtual hardware",
+			func(e *ovf.Envelope) { e.VirtualSystem.VirtualHardware = nil },
+			"does not have a virtual hardware section"),
+		Entry("no disks",
+			func(e *ovf.Envelope) { e.Disk = nil },
+			"does not have any disks"),
+		Entry("disk references missing file",
+			func(e *ovf.Envelope) { e.References = nil },
+			`disk "vmdisk1" references the file "file1" that does not exist`),
+		Entry("disk without capacity",
+			func(e *ovf.Envelope) { e.Disk.Disks[0].Capacity = "" },
+			`disk "vmdisk1" does not have a capacity`),
+	)
+})
+
+func newOVA(files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	// The OVF descriptor must be the first file in an OVA.
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if filepath.Ext(name) == ".ovf" {
+			names = append([]string{name}, names...)
+		} else {
+			names = append(names, name)
+		}
+	}
+
+	for _, name := range names {
+		Expect(tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0600,
+			Size:     int64(len(files[name])),
+			Typeflag: tar.TypeReg,
+		})).To(Succeed())
+		_, err := tw.Write(files[name])
+		Expect(err).ToNot(HaveOccurred())
+	}
+	Expect(tw.Close()).To(Succeed())
+
+	return buf.Bytes()
+}
+
+// readImportSource reads all of the files of the source, and returns their
+// names and contents in the order in which they were returned.
+func readImportSource(src contentlibrary.ImportSource) ([]string, [][]byte, error) {
+	var (
+		names    []string
+		contents [][]byte
+	)
+	for {
+		f, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			return names, contents, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		b, err := io.ReadAll(f.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		names = append(names, f.Name)
+		contents = append(contents, b)
+	}
+}
@@ -5,6 +5,7 @@ package contentlibrary
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/url"
@@ -32,11 +33,11 @@ type Provider interface {
 	GetLibraryItemID(ctx context.Context, itemUUID string) (*library.Item, error)
 	ListLibraryItems(ctx context.Context, libraryUUID string) ([]string, error)
 	UpdateLibraryItem(ctx context.Context, itemID, newName string, newDescription *string) error
+	DeleteLibraryItem(ctx context.Context, itemID string) error
 	RetrieveOvfEnvelopeFromLibraryItem(ctx context.Context, item *library.Item) (*ovf.Envelope, error)
 	RetrieveOvfEnvelopeByLibraryItemID(ctx context.Context, itemID string) (*ovf.Envelope, error)
-
-	// TODO: Testing only. Remove these from this file.
-	CreateLibraryItem(ctx context.Context, libraryItem library.Item, path string) error
+	CreateLibraryItem(ctx context.Context, libraryItem library.Item, paths ...string) (string, error)
+	ImportLibraryItem(ctx context.Context, libraryItem library.Item, src ImportSource) (string, error)
 }
 
 type provider struct {
@@ -244,39 +245,114 @@ func (cs *provider) UpdateLibraryItem(ctx context.Context, itemID, newName strin
 	return cs.libMgr.UpdateLibraryItem(ctx, item)
 }
 
-// Only used in testing.
-func (cs *provider) CreateLibraryItem(ctx context.Context, libraryItem library.Item, path string) error {
-	log.Info("Creating Library Item", "item", libraryItem, "path", path)
+// DeleteLibraryItem deletes the library item with the given ID. No error is
+// returned if the item does not exist.
+func (cs *provider) DeleteLibraryItem(ctx context.Context, itemID string) error {
+	log.Info("Deleting Library Item", "itemID", itemID)
 
-	itemID, err := cs.libMgr.CreateLibraryItem(ctx, libraryItem)
+	item, err := cs.libMgr.GetLibraryItem(ctx, itemID)
 	if err != nil {
+		if util.IsNotFoundError(err) {
+			return nil
+		}
+		log.Error(err, "error getting library item")
 		return err
 	}
 
-	sessionID, err := cs.libMgr.CreateLibraryItemUpdateSession(ctx, library.Session{LibraryItemID: itemID})
-	if err != nil {
+	if err := cs.libMgr.DeleteLibraryItem(ctx, item); err != nil && !util.IsNotFoundError(err) {
 		return err
 	}
 
-	// Update Library item with library file "ovf"
-	uploadFunc := func(c *rest.Client, path string) error {
-		f, err := os.Open(filepath.Clean(path))
-		if err != nil {
-			return err
+	return nil
+}
+
+// CreateLibraryItem creates a library item and uploads the files at the
+// provided paths to it. The ID of the new library item is returned. If the
+// files cannot be uploaded, the library item is deleted.
+func (cs *provider) CreateLibraryItem(ctx context.Context, libraryItem library.Item, paths ...string) (string, error) {
+	log.Info("Creating Library Item", "item", libraryItem, "paths", paths)
+
+	var f *os.File
+	defer func() {
+		if f != nil {
+			_ = f.Close()
 		}
-		defer func() {
+	}()
+
+	next := func() (*ImportFile, error) {
+		if f != nil {
 			_ = f.Close()
-		}()
+			f = nil
+		}
+		if len(paths) == 0 {
+			return nil, io.EOF
+		}
+
+		p := paths[0]
+		paths = paths[1:]
+
+		var err error
+		if f, err = os.Open(filepath.Clean(p)); err != nil {
+			return nil, err
+		}
 
 		fi, err := f.Stat()
 		if err != nil {
-			return err
+			return nil, err
 		}
 
+		return &ImportFile{Name: filepath.Base(p), Size: fi.Size(), Reader: f}, nil
+	}
+
+	return cs.createLibraryItem(ctx, libraryItem, next)
+}
+
+// ImportLibraryItem creates a library item and streams the files returned by
+// the provided source to it. The ID of the new library item is returned. If
+// the files cannot be uploaded, the library item is deleted.
+func (cs *provider) ImportLibraryItem(ctx context.Context, libraryItem library.Item, src ImportSource) (string, error) {
+	log.Info("Importing Library Item", "item", libraryItem)
+	return cs.createLibraryItem(ctx, libraryItem, src.Next)
+}
+
+func (cs *provider) createLibraryItem(
+	ctx context.Context,
+	libraryItem library.Item,
+	next func() (*ImportFile, error)) (string, error) {
+
+	itemID, err := cs.libMgr.CreateLibraryItem(ctx, libraryItem)
+	if err != nil {
+		return "", err
+	}
+
+	if err := cs.uploadLibraryItemFiles(ctx, itemID, next); err != nil {
+		if delErr := cs.libMgr.DeleteLibraryItem(ctx, &library.Item{ID: itemID}); delErr != nil {
+			log.Error(delErr, "error deleting library item after failed upload", "itemID", itemID)
+		}
+		return "", err
+	}
+
+	return itemID, nil
+}
+
+// uploadLibraryItemFiles uploads the files returned by next, until it returns
+// io.EOF, to the library item in a single update session.
+func (cs *provider) uploadLibraryItemFiles(
+	ctx context.Context,
+	itemID string,
+	next func() (*ImportFile, error)) error {
+
+	sessionID, err := cs.libMgr.CreateLibraryItemUpdateSession(ctx, library.Session{LibraryItemID: itemID})
+	if err != nil {
+		return err
+	}
+
+	// Update Library item with the library files.
+	uploadFunc := func(c *rest.Client, f *ImportFile) error {
 		info := library.UpdateFile{
-			Name:       filepath.Base(path),
+			Name:       f.Name,
 			SourceType: "PUSH",
-			Size:       fi.Size(),
+			Size:       f.Size,
 		}
 
 		update, err := cs.libMgr.AddLibraryItemFile(ctx, sessionID, info)
@@ -292,11 +368,20 @@ func (cs *provider) CreateLibraryItem(ctx context.Context, libraryItem library.I
 		p := soap.DefaultUpload
 		p.ContentLength = info.Size
 
-		return c.Upload(ctx, f, u, &p)
+		return c.Upload(ctx, f.Reader, u, &p)
 	}
 
-	if err = uploadFunc(cs.libMgr.Client, path); err != nil {
-		return err
+	for {
+		f, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := uploadFunc(cs.libMgr.Client, f); err != nil {
+			return err
+		}
 	}
 
 	return cs.libMgr.CompleteLibraryItemUpdateSession(ctx, sessionID)
@@ -76,6 +76,22 @@ func clTests() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(ovfEnvelope).ToNot(BeNil())
 			})
+
+			It("Deletes item", func() {
+				item, err := clProvider.GetLibraryItem(ctx, ctx.ContentLibraryID, ctx.ContentLibraryImageName, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(item).ToNot(BeNil())
+
+				Expect(clProvider.DeleteLibraryItem(ctx, item.ID)).To(Succeed())
+
+				item, err = clProvider.GetLibraryItem(ctx, ctx.ContentLibraryID, ctx.ContentLibraryImageName, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(item).To(BeNil())
+			})
+
+			It("Does not return error when deleting item that does not exist", func() {
+				Expect(clProvider.DeleteLibraryItem(ctx, "dummy-item-id")).To(Succeed())
+			})
 		})
 
 		Context("when items are not present in library", func() {
@@ -121,8 +137,9 @@ func clTests() {
 					LibraryID: ctx.ContentLibraryID,
 				}
 
-				err = clProvider.CreateLibraryItem(ctx, libItem, ovfPath)
+				itemID, err := clProvider.CreateLibraryItem(ctx, libItem, ovfPath)
 				Expect(err).NotTo(HaveOccurred())
+				Expect(itemID).ToNot(BeEmpty())
 
 				libItem2, err := clProvider.GetLibraryItem(ctx, ctx.ContentLibraryID, libItemName, true)
 				Expect(err).ToNot(HaveOccurred())
@@ -150,15 +150,99 @@ func CreateAndWaitForNetworkInterfaces(
 		results = append(results, *result)
 	}
 
-	// TODO: Once we really support network changing on the fly, we need to keep track of now
-	// unused network interface CRDs so they can be deleted after they're removed from the VM
-	// via Reconfigure, instead of delaying that until the VM is deleted via GC.
-
 	return NetworkInterfaceResults{
 		Results: results,
 	}, nil
 }
 
+// DeleteUnusedNetworkInterfaces deletes the VM's network interface CRs that no longer
+// correspond to an interface in the provided InterfaceSpecs. This should be called after
+// the removed interfaces' devices have been removed from the VM via Reconfigure, so that
+// the CRs are not left around until the VM is deleted and they are GC'd.
+func DeleteUnusedNetworkInterfaces(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client,
+	interfaces []vmopv1.VirtualMachineNetworkInterfaceSpec) error {
+
+	var list ctrlclient.ObjectList
+	inUse := map[string]struct{}{}
+
+	for i := range interfaces {
+		interfaceSpec := &interfaces[i]
+
+		var networkRefName string
+		if netRef := interfaceSpec.Network; netRef != nil {
+			networkRefName = netRef.Name
+		}
+
+		for _, isV1A1 := range []bool{true, false} {
+			inUse[NetOPCRName(vmCtx.VM.Name, networkRefName, interfaceSpec.Name, isV1A1)] = struct{}{}
+			inUse[NCPCRName(vmCtx.VM.Name, networkRefName, interfaceSpec.Name, isV1A1)] = struct{}{}
+		}
+		inUse[VPCCRName(vmCtx.VM.Name, networkRefName, interfaceSpec.Name)] = struct{}{}
+	}
+
+	switch networkType := pkgcfg.FromContext(vmCtx).NetworkProviderType; networkType {
+	case pkgcfg.NetworkProviderTypeVDS:
+		list = &netopv1alpha1.NetworkInterfaceList{}
+	case pkgcfg.NetworkProviderTypeNSXT:
+		list = &ncpv1alpha1.VirtualNetworkInterfaceList{}
+	case pkgcfg.NetworkProviderTypeVPC:
+		list = &vpcv1alpha1.SubnetPortList{}
+	case pkgcfg.NetworkProviderTypeNamed:
+		// There are no CRs for the named network provider.
+		return nil
+	default:
+		return fmt.Errorf("unsupported network provider envvar value: %q", networkType)
+	}
+
+	if err := client.List(
+		vmCtx,
+		list,
+		ctrlclient.InNamespace(vmCtx.VM.Namespace),
+		ctrlclient.MatchingLabels{VMNameLabel: vmCtx.VM.Name}); err != nil {
+		return err
+	}
+
+	var objs []ctrlclient.Object
+	switch l := list.(type) {
+	case *netopv1alpha1.NetworkInterfaceList:
+		for i := range l.Items {
+			objs = append(objs, &l.Items[i])
+		}
+	case *ncpv1alpha1.VirtualNetworkInterfaceList:
+		for i := range l.Items {
+			objs = append(objs, &l.Items[i])
+		}
+	case *vpcv1alpha1.SubnetPortList:
+		for i := range l.Items {
+			objs = append(objs, &l.Items[i])
+		}
+	}
+
+	for _, obj := range objs {
+		if _, ok := inUse[obj.GetName()]; ok || !isOwnedByVM(vmCtx.VM, obj) {
+			continue
+		}
+
+		vmCtx.Logger.Info("Deleting unused network interface CR", "name", obj.GetName())
+		if err := client.Delete(vmCtx, obj); ctrlclient.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete network interface CR %q: %w", obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+func isOwnedByVM(vm *vmopv1.VirtualMachine, obj ctrlclient.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == "VirtualMachine" && ref.Name == vm.Name && ref.UID == vm.UID {
+			return true
+		}
+	}
+	return false
+}
+
 // applyInterfaceSpecToResult applies the InterfaceSpec to results. Much of the InterfaceSpec - like DHCP -
 // cannot be specified to the underlying network provider so apply those overrides to the results.
 func applyInterfaceSpecToResult(
@@ -731,3 +731,169 @@ var _ = Describe("CreateAndWaitForNetworkInterfaces", Label(testlabels.VCSim), f
 		})
 	})
 })
+
+var _ = Describe("DeleteUnusedNetworkInterfaces", Label(testlabels.VCSim), func() {
+
+	var (
+		testConfig builder.VCSimTestConfig
+		ctx        *builder.TestContextForVCSim
+
+		vmCtx          pkgctx.VirtualMachineContext
+		vm             *vmopv1.VirtualMachine
+		interfaceSpecs []vmopv1.VirtualMachineNetworkInterfaceSpec
+
+		err         error
+		initObjects []client.Object
+	)
+
+	ownerRefs := func() []metav1.OwnerReference {
+		return []metav1.OwnerReference{
+			{
+				APIVersion: vmopv1.GroupVersion.String(),
+				Kind:       "VirtualMachine",
+				Name:       vm.Name,
+				UID:        vm.UID,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		testConfig = builder.VCSimTestConfig{}
+
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "network-test-vm",
+				Namespace: "network-test-ns",
+				UID:       "network-test-vm-uid",
+			},
+		}
+
+		interfaceSpecs = []vmopv1.VirtualMachineNetworkInterfaceSpec{
+			{
+				Name:    "eth0",
+				Network: &common.PartialObjectRef{Name: "my-network"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(testConfig, initObjects...)
+
+		vmCtx = pkgctx.VirtualMachineContext{
+			Context: ctx,
+			Logger:  suite.GetLogger().WithName("network_test"),
+			VM:      vm,
+		}
+
+		err = network.DeleteUnusedNetworkInterfaces(vmCtx, ctx.Client, interfaceSpecs)
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+	})
+
+	Context("VDS", func() {
+		BeforeEach(func() {
+			testConfig.WithNetworkEnv = builder.NetworkEnvVDS
+
+			newNetIf := func(name string, owned bool) *netopv1alpha1.NetworkInterface {
+				netIf := &netopv1alpha1.NetworkInterface{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: vm.Namespace,
+						Labels:    map[string]string{network.VMNameLabel: vm.Name},
+					},
+				}
+				if owned {
+					netIf.OwnerReferences = ownerRefs()
+				}
+				return netIf
+			}
+
+			initObjects = append(initObjects,
+				newNetIf(network.NetOPCRName(vm.Name, "my-network", "eth0", false), true),
+				newNetIf(network.NetOPCRName(vm.Name, "my-network", "eth1", false), true),
+				newNetIf(network.NetOPCRName(vm.Name, "other-network", "eth2", false), false),
+			)
+		})
+
+		It("deletes only the unused network interfaces owned by the VM", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			list := &netopv1alpha1.NetworkInterfaceList{}
+			Expect(ctx.Client.List(ctx, list, client.InNamespace(vm.Namespace))).To(Succeed())
+
+			var names []string
+			for _, item := range list.Items {
+				names = append(names, item.Name)
+			}
+			Expect(names).To(ConsistOf(
+				network.NetOPCRName(vm.Name, "my-network", "eth0", false),
+				network.NetOPCRName(vm.Name, "other-network", "eth2", false)))
+		})
+	})
+
+	Context("NCP", func() {
+		BeforeEach(func() {
+			testConfig.WithNetworkEnv = builder.NetworkEnvNSXT
+
+			newVNetIf := func(name string) *ncpv1alpha1.VirtualNetworkInterface {
+				return &ncpv1alpha1.VirtualNetworkInterface{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            name,
+						Namespace:       vm.Namespace,
+						Labels:          map[string]string{network.VMNameLabel: vm.Name},
+						OwnerReferences: ownerRefs(),
+					},
+				}
+			}
+
+			initObjects = append(initObjects,
+				newVNetIf(network.NCPCRName(vm.Name, "my-network", "eth0", true)),
+				newVNetIf(network.NCPCRName(vm.Name, "my-network", "eth1", false)),
+			)
+		})
+
+		It("deletes the unused network interfaces", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			list := &ncpv1alpha1.VirtualNetworkInterfaceList{}
+			Expect(ctx.Client.List(ctx, list, client.InNamespace(vm.Namespace))).To(Succeed())
+			Expect(list.Items).To(HaveLen(1))
+			Expect(list.Items[0].Name).To(Equal(network.NCPCRName(vm.Name, "my-network", "eth0", true)))
+		})
+	})
+
+	Context("VPC", func() {
+		BeforeEach(func() {
+			testConfig.WithNetworkEnv = builder.NetworkEnvVPC
+
+			newSubnetPort := func(name string) *vpcv1alpha1.SubnetPort {
+				return &vpcv1alpha1.SubnetPort{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            name,
+						Namespace:       vm.Namespace,
+						Labels:          map[string]string{network.VMNameLabel: vm.Name},
+						OwnerReferences: ownerRefs(),
+					},
+				}
+			}
+
+			initObjects = append(initObjects,
+				newSubnetPort(network.VPCCRName(vm.Name, "my-network", "eth0")),
+				newSubnetPort(network.VPCCRName(vm.Name, "my-network", "eth1")),
+			)
+		})
+
+		It("deletes the unused network interfaces", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			list := &vpcv1alpha1.SubnetPortList{}
+			Expect(ctx.Client.List(ctx, list, client.InNamespace(vm.Namespace))).To(Succeed())
+			Expect(list.Items).To(HaveLen(1))
+			Expect(list.Items[0].Name).To(Equal(network.VPCCRName(vm.Name, "my-network", "eth0")))
+		})
+	})
+})
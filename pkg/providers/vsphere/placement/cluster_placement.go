@@ -19,7 +19,9 @@ import (
 type Recommendation struct {
 	PoolMoRef vimtypes.ManagedObjectReference
 	HostMoRef *vimtypes.ManagedObjectReference
-	// TODO: Datastore, whatever else as we need it.
+	// Datastore and Disks are only set for relocate recommendations.
+	Datastore *vimtypes.ManagedObjectReference
+	Disks     []vimtypes.VirtualMachineRelocateSpecDiskLocator
 }
 
 func relocateSpecToRecommendation(relocateSpec *vimtypes.VirtualMachineRelocateSpec) *Recommendation {
@@ -14,6 +14,7 @@ import (
 
 func vcSimTests() {
 	Describe("Placement", Label(testlabels.VCSim), vcSimPlacement)
+	Describe("RelocatePlacement", Label(testlabels.VCSim), vcSimRelocatePlacement)
 }
 
 var suite = builder.NewTestSuite()
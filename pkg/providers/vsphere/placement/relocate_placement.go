@@ -0,0 +1,138 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package placement
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/vmware/govmomi/vim25"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+// IsResourcePoolInZone returns true if the ResourcePool is one of the
+// candidate ResourcePools of the zone assigned to the VM.
+func IsResourcePoolInZone(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client,
+	vcClient *vim25.Client,
+	childRPName string,
+	rpMoID string) (bool, error) {
+
+	candidates, err := getPlacementCandidates(vmCtx, client, vcClient, false, childRPName)
+	if err != nil {
+		return false, err
+	}
+
+	for _, rpMoIDs := range candidates {
+		if slices.Contains(rpMoIDs, rpMoID) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// PlaceVMForRelocate determines where to relocate the existing VM within the
+// zone assigned to the VM. Recommendations that place the VM on the host it is
+// currently running on are ignored.
+func PlaceVMForRelocate(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client,
+	vcClient *vim25.Client,
+	constraints Constraints,
+	currentHostMoRef *vimtypes.ManagedObjectReference) (*Result, error) {
+
+	candidates, err := getPlacementCandidates(vmCtx, client, vcClient, false, constraints.ChildRPName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no placement candidates available")
+	}
+
+	vmMoRef := vmCtx.MoVM.Self
+	recommendations := map[string][]Recommendation{}
+
+	for zoneName, rpMoIDs := range candidates {
+		for _, rpMoID := range rpMoIDs {
+			rpMoRef := vimtypes.ManagedObjectReference{Type: "ResourcePool", Value: rpMoID}
+
+			cluster, err := rpMoIDToCluster(vmCtx, vcClient, rpMoRef)
+			if err != nil {
+				vmCtx.Logger.Error(err, "failed to get CCR from RP", "zone", zoneName, "rpMoID", rpMoID)
+				continue
+			}
+
+			placementSpec := vimtypes.PlacementSpec{
+				PlacementType: string(vimtypes.PlacementSpecPlacementTypeRelocate),
+				Vm:            &vmMoRef,
+				RelocateSpec: &vimtypes.VirtualMachineRelocateSpec{
+					Pool: &rpMoRef,
+				},
+			}
+
+			resp, err := cluster.PlaceVm(vmCtx, placementSpec)
+			if err != nil {
+				vmCtx.Logger.Error(err, "PlaceVM failed", "zone", zoneName,
+					"clusterMoID", cluster.Reference().Value, "rpMoID", rpMoID)
+				continue
+			}
+
+			for _, r := range resp.Recommendations {
+				if r.Reason != string(vimtypes.RecommendationReasonCodeXvmotionPlacement) {
+					continue
+				}
+
+				for _, a := range r.Action {
+					pa, ok := a.(*vimtypes.PlacementAction)
+					if !ok {
+						continue
+					}
+
+					rec := relocateSpecToRecommendation(pa.RelocateSpec)
+					if rec == nil {
+						continue
+					}
+
+					if currentHostMoRef != nil && rec.HostMoRef.Value == currentHostMoRef.Value {
+						continue
+					}
+
+					// Like for create, the VM must be placed under the
+					// namespace or namespace child RP instead of the
+					// cluster's root RP returned by PlaceVM.
+					rec.PoolMoRef = rpMoRef
+					// The VM's storage may not be accessible from the
+					// recommended host, so also use the recommended
+					// datastore for the VM and its disks.
+					rec.Datastore = pa.RelocateSpec.Datastore
+					rec.Disks = pa.RelocateSpec.Disk
+					recommendations[zoneName] = append(recommendations[zoneName], *rec)
+				}
+			}
+		}
+	}
+
+	vmCtx.Logger.V(5).Info("Relocate placement recommendations", "recommendations", recommendations)
+
+	if len(recommendations) == 0 {
+		return nil, fmt.Errorf("no placement recommendations available")
+	}
+
+	zoneName, rec := MakePlacementDecision(recommendations)
+	vmCtx.Logger.V(5).Info("Relocate placement decision result", "zone", zoneName, "recommendation", rec)
+
+	return &Result{
+		ZoneName:       zoneName,
+		PoolMoRef:      rec.PoolMoRef,
+		HostMoRef:      rec.HostMoRef,
+		DatastoreMoRef: rec.Datastore,
+		Disks:          rec.Disks,
+	}, nil
+}
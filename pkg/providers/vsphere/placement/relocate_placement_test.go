@@ -0,0 +1,126 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package placement_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/placement"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func vcSimRelocatePlacement() {
+
+	var (
+		ctx    *builder.TestContextForVCSim
+		nsInfo builder.WorkloadNamespaceInfo
+
+		vm       *vmopv1.VirtualMachine
+		vmCtx    pkgctx.VirtualMachineContext
+		zoneName string
+	)
+
+	BeforeEach(func() {
+		vm = builder.DummyVirtualMachine()
+		vm.Name = "relocate-placement-test"
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{WithWorkloadIsolation: true})
+		nsInfo = ctx.CreateWorkloadNamespace()
+
+		zoneName = ctx.GetFirstZoneName()
+		vm.Namespace = nsInfo.Namespace
+		vm.Labels[topology.KubernetesTopologyZoneLabelKey] = zoneName
+
+		vcVM, err := ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+		Expect(err).ToNot(HaveOccurred())
+
+		vmCtx = pkgctx.VirtualMachineContext{
+			Context: ctx,
+			Logger:  suite.GetLogger().WithValues("vmName", vm.Name),
+			VM:      vm,
+		}
+		vmCtx.MoVM.Self = vcVM.Reference()
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	Describe("IsResourcePoolInZone", func() {
+
+		It("returns true for the namespace ResourcePool in the VM's zone", func() {
+			nsRP := ctx.GetResourcePoolForNamespace(vm.Namespace, zoneName, "")
+			Expect(nsRP).ToNot(BeNil())
+
+			inZone, err := placement.IsResourcePoolInZone(vmCtx, ctx.Client, ctx.VCClient.Client, "", nsRP.Reference().Value)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inZone).To(BeTrue())
+		})
+
+		It("returns false for a ResourcePool that is not in the VM's zone", func() {
+			inZone, err := placement.IsResourcePoolInZone(vmCtx, ctx.Client, ctx.VCClient.Client, "", "resgroup-bogus")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inZone).To(BeFalse())
+		})
+
+		It("returns an error when the VM's zone does not exist", func() {
+			vm.Labels[topology.KubernetesTopologyZoneLabelKey] = "bogus-zone"
+			_, err := placement.IsResourcePoolInZone(vmCtx, ctx.Client, ctx.VCClient.Client, "", "resgroup-bogus")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("PlaceVMForRelocate", func() {
+
+		It("returns the namespace ResourcePool and a host in the VM's zone", func() {
+			result, err := placement.PlaceVMForRelocate(vmCtx, ctx.Client, ctx.VCClient.Client, placement.Constraints{}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).ToNot(BeNil())
+			Expect(result.ZoneName).To(Equal(zoneName))
+			Expect(result.HostMoRef).ToNot(BeNil())
+
+			nsRP := ctx.GetResourcePoolForNamespace(vm.Namespace, zoneName, "")
+			Expect(nsRP).ToNot(BeNil())
+			Expect(result.PoolMoRef.Value).To(Equal(nsRP.Reference().Value))
+		})
+
+		It("does not return the host the VM is currently on", func() {
+			result, err := placement.PlaceVMForRelocate(vmCtx, ctx.Client, ctx.VCClient.Client, placement.Constraints{}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.HostMoRef).ToNot(BeNil())
+
+			currentHostMoRef := *result.HostMoRef
+			result, err = placement.PlaceVMForRelocate(vmCtx, ctx.Client, ctx.VCClient.Client, placement.Constraints{}, &currentHostMoRef)
+			if err == nil {
+				Expect(result.HostMoRef).ToNot(BeNil())
+				Expect(result.HostMoRef.Value).ToNot(Equal(currentHostMoRef.Value))
+			} else {
+				Expect(err).To(MatchError("no placement recommendations available"))
+			}
+		})
+
+		Context("VM is in child RP via ResourcePolicy", func() {
+			It("returns the child ResourcePool", func() {
+				resourcePolicy, _ := ctx.CreateVirtualMachineSetResourcePolicyA2("my-child-rp", nsInfo)
+				Expect(resourcePolicy).ToNot(BeNil())
+				childRPName := resourcePolicy.Spec.ResourcePool.Name
+
+				constraints := placement.Constraints{ChildRPName: childRPName}
+				result, err := placement.PlaceVMForRelocate(vmCtx, ctx.Client, ctx.VCClient.Client, constraints, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				childRP := ctx.GetResourcePoolForNamespace(vm.Namespace, zoneName, childRPName)
+				Expect(childRP).ToNot(BeNil())
+				Expect(result.PoolMoRef.Value).To(Equal(childRP.Reference().Value))
+			})
+		})
+	})
+}
@@ -42,7 +42,9 @@ type Result struct {
 	ZoneName                 string
 	HostMoRef                *vimtypes.ManagedObjectReference
 	PoolMoRef                vimtypes.ManagedObjectReference
-	// TODO: Datastore, whatever else as we need it.
+	// DatastoreMoRef and Disks are only set when placing a VM for relocate.
+	DatastoreMoRef *vimtypes.ManagedObjectReference
+	Disks          []vimtypes.VirtualMachineRelocateSpecDiskLocator
 }
 
 func doesVMNeedPlacement(vmCtx pkgctx.VirtualMachineContext) (res Result, needZonePlacement, needInstanceStoragePlacement bool) {
@@ -563,6 +563,14 @@ func (s *Session) prePowerOnVMReconfigure(
 		return err
 	}
 
+	if features.VMSerialConsole {
+		// Serial ports cannot be hot-added, so the serial console's port is
+		// only added while the VM is powered off.
+		configSpec.DeviceChange = append(configSpec.DeviceChange,
+			virtualmachine.UpdateSerialConsoleDeviceChanges(
+				vmCtx, object.VirtualDeviceList(config.Hardware.Device))...)
+	}
+
 	if _, err := doReconfigure(
 		logr.NewContext(
 			vmCtx,
@@ -750,6 +758,16 @@ func (s *Session) prepareVMForPowerOn(
 		return err
 	}
 
+	// The devices of any removed interfaces were removed by the reconfigure,
+	// so their network interface CRs are no longer needed.
+	if pkgcfg.FromContext(vmCtx).Features.VMNetworkHotPlug {
+		if networkSpec := vmCtx.VM.Spec.Network; networkSpec != nil && !networkSpec.Disabled {
+			if err := network2.DeleteUnusedNetworkInterfaces(vmCtx, s.K8sClient, networkSpec.Interfaces); err != nil {
+				return err
+			}
+		}
+	}
+
 	if err := s.fixupMacAddresses(vmCtx, resVM, updateArgs); err != nil {
 		return err
 	}
@@ -834,6 +852,153 @@ func (s *Session) poweredOnVMReconfigure(
 	return refetchProps, nil
 }
 
+// reconcileNetworkInterfaceDevices adds and removes the network interfaces of
+// a VM so they match the VM's spec.network.interfaces, and then deletes the
+// network interface CRs of the removed interfaces. The ethernet cards in the
+// class's ConfigSpec are used for the interfaces, like when the VM is created.
+func (s *Session) reconcileNetworkInterfaceDevices(
+	vmCtx pkgctx.VirtualMachineContext,
+	resVM *res.VirtualMachine,
+	config *vimtypes.VirtualMachineConfigInfo,
+	classConfigSpec *vimtypes.VirtualMachineConfigSpec,
+	loggerName string) (network2.NetworkInterfaceResults, bool, error) {
+
+	results, err := s.ensureNetworkInterfaces(vmCtx, classConfigSpec)
+	if err != nil {
+		return network2.NetworkInterfaceResults{}, false, err
+	}
+
+	var expectedEthCards object.VirtualDeviceList
+	for idx := range results.Results {
+		expectedEthCards = append(expectedEthCards, results.Results[idx].Device)
+	}
+
+	currentEthCards := object.VirtualDeviceList(config.Hardware.Device).SelectByType((*vimtypes.VirtualEthernetCard)(nil))
+
+	ethCardDeviceChanges, err := UpdateEthCardDeviceChanges(vmCtx, expectedEthCards, currentEthCards)
+	if err != nil {
+		return network2.NetworkInterfaceResults{}, false, err
+	}
+
+	var refetchProps bool
+	if len(ethCardDeviceChanges) > 0 {
+		refetchProps, err = doReconfigure(
+			logr.NewContext(
+				vmCtx,
+				vmCtx.Logger.WithName(loggerName),
+			),
+			s.K8sClient,
+			vmCtx.VM,
+			resVM.VcVM(),
+			vmCtx.MoVM,
+			vimtypes.VirtualMachineConfigSpec{DeviceChange: ethCardDeviceChanges})
+		if err != nil {
+			return network2.NetworkInterfaceResults{}, false, err
+		}
+	}
+
+	// Only delete the CRs of the removed interfaces once their devices have
+	// been removed from the VM.
+	if err := network2.DeleteUnusedNetworkInterfaces(vmCtx, s.K8sClient, vmCtx.VM.Spec.Network.Interfaces); err != nil {
+		return network2.NetworkInterfaceResults{}, refetchProps, err
+	}
+
+	return results, refetchProps, nil
+}
+
+// poweredOffVMNetworkReconfigure adds and removes the network interfaces of a
+// powered off VM so they match the VM's spec.network.interfaces. The network
+// interface CRs of removed interfaces are deleted. The guest's network
+// configuration is updated when the VM is next powered on.
+func (s *Session) poweredOffVMNetworkReconfigure(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	getResizeArgsFn func() (*VMResizeArgs, error)) (bool, error) {
+
+	networkSpec := vmCtx.VM.Spec.Network
+	if networkSpec == nil || networkSpec.Disabled {
+		return false, nil
+	}
+
+	// See GoVmomi's VirtualMachine::Device() explanation for this check.
+	if vmCtx.MoVM.Config == nil {
+		return false, fmt.Errorf(
+			"VM config is not available, connectionState=%s",
+			vmCtx.MoVM.Summary.Runtime.ConnectionState)
+	}
+
+	resizeArgs, err := getResizeArgsFn()
+	if err != nil {
+		return false, err
+	}
+
+	_, refetchProps, err := s.reconcileNetworkInterfaceDevices(
+		vmCtx,
+		res.NewVMFromObject(vcVM),
+		vmCtx.MoVM.Config,
+		&resizeArgs.ConfigSpec,
+		"poweredOffVMNetworkReconfigure")
+	return refetchProps, err
+}
+
+// poweredOnVMNetworkHotPlug adds and removes the network interfaces of a
+// powered on VM so they match the VM's spec.network.interfaces. The network
+// interface CRs of removed interfaces are deleted, and the guest's network
+// configuration is updated when the VM's bootstrap provider allows it.
+func (s *Session) poweredOnVMNetworkHotPlug(
+	vmCtx pkgctx.VirtualMachineContext,
+	resVM *res.VirtualMachine,
+	config *vimtypes.VirtualMachineConfigInfo,
+	getUpdateArgsFn func() (*VMUpdateArgs, error)) (bool, error) {
+
+	networkSpec := vmCtx.VM.Spec.Network
+	if networkSpec == nil || networkSpec.Disabled {
+		return false, nil
+	}
+
+	updateArgs, err := getUpdateArgsFn()
+	if err != nil {
+		return false, err
+	}
+
+	results, refetchProps, err := s.reconcileNetworkInterfaceDevices(
+		vmCtx,
+		resVM,
+		config,
+		&updateArgs.ConfigSpec,
+		"poweredOnVMNetworkHotPlug")
+	if err != nil {
+		return refetchProps, err
+	}
+
+	if !refetchProps {
+		return false, nil
+	}
+
+	updateArgs.NetworkResults = results
+
+	if err := s.fixupMacAddresses(vmCtx, resVM, updateArgs); err != nil {
+		return refetchProps, err
+	}
+
+	bootstrapArgs, err := vmlifecycle.GetBootstrapArgs(
+		vmCtx,
+		s.K8sClient,
+		updateArgs.NetworkResults,
+		updateArgs.BootstrapData)
+	if err != nil {
+		return refetchProps, err
+	}
+
+	vmlifecycle.UpdateNetworkStatusConfig(vmCtx.VM, bootstrapArgs)
+
+	if err := vmlifecycle.UpdateGuestNetworkConfig(vmCtx, resVM.VcVM(), config, bootstrapArgs); err != nil {
+		return refetchProps, err
+	}
+
+	return refetchProps, nil
+}
+
 func (s *Session) attachClusterModule(
 	vmCtx pkgctx.VirtualMachineContext,
 	resVM *res.VirtualMachine,
@@ -999,6 +1164,16 @@ func (s *Session) updateVMDesiredPowerStateOff(
 		refetchProps = true
 	}
 
+	if pkgcfg.FromContext(vmCtx).Features.VMNetworkHotPlug {
+		refetch, err := s.poweredOffVMNetworkReconfigure(vmCtx, vcVM, getResizeArgsFn)
+		if err != nil {
+			return refetchProps, err
+		}
+		if refetch {
+			refetchProps = true
+		}
+	}
+
 	if f := pkgcfg.FromContext(vmCtx).Features; f.VMResize || f.VMResizeCPUMemory {
 		refetch, err := s.resizeVMWhenPoweredStateOff(
 			vmCtx,
@@ -1113,6 +1288,14 @@ func (s *Session) updateVMDesiredPowerStateOn(
 		}
 		refetchProps = refetchProps || reconfigured
 
+		if pkgcfg.FromContext(vmCtx).Features.VMNetworkHotPlug {
+			reconfigured, err = s.poweredOnVMNetworkHotPlug(vmCtx, resVM, config, getUpdateArgsFn)
+			if err != nil {
+				return refetchProps, err
+			}
+			refetchProps = refetchProps || reconfigured
+		}
+
 		return refetchProps, err
 	}
 
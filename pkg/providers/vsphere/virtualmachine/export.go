@@ -0,0 +1,173 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/vmware/govmomi/nfc"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/ovf"
+	"github.com/vmware/govmomi/vim25/soap"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+// ExportOVFToURL exports the VM as an OVF and uploads the OVF descriptor and
+// each exported file with an HTTP PUT request to the provided base URL joined
+// with the file's name. Each request is authenticated with the provided
+// bearer token. The names of the uploaded files are returned, with the OVF
+// descriptor first.
+func ExportOVFToURL(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	name, targetURL, token string) ([]string, error) {
+
+	baseURL, err := url.Parse(targetURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target URL: %w", err)
+	}
+
+	vmCtx.Logger.Info("Exporting VM", "targetURL", baseURL.Redacted())
+
+	lease, err := vcVM.Export(vmCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export VM: %w", err)
+	}
+
+	info, err := lease.Wait(vmCtx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for export lease: %w", err)
+	}
+
+	files, ovfFiles, err := uploadExportedFiles(vmCtx, lease, info, baseURL, token)
+	if err != nil {
+		_ = lease.Abort(vmCtx, &vimtypes.LocalizedMethodFault{LocalizedMessage: err.Error()})
+		return nil, err
+	}
+
+	if err := lease.Complete(vmCtx); err != nil {
+		return nil, fmt.Errorf("failed to complete export lease: %w", err)
+	}
+
+	desc, err := ovf.NewManager(vcVM.Client()).CreateDescriptor(vmCtx, vcVM, vimtypes.OvfCreateDescriptorParams{
+		Name:     name,
+		OvfFiles: ovfFiles,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OVF descriptor: %w", err)
+	}
+	if len(desc.Error) > 0 {
+		return nil, fmt.Errorf("failed to create OVF descriptor: %s", desc.Error[0].LocalizedMessage)
+	}
+
+	descName := name + ".ovf"
+	descriptor := desc.OvfDescriptor
+	if err := uploadFile(vmCtx, baseURL, descName, token, strings.NewReader(descriptor), int64(len(descriptor))); err != nil {
+		return nil, fmt.Errorf("failed to upload OVF descriptor: %w", err)
+	}
+
+	return append([]string{descName}, files...), nil
+}
+
+// uploadExportedFiles streams each of the files exported by the lease to the
+// target URL. The names of the files and the files to include in the OVF
+// descriptor are returned.
+func uploadExportedFiles(
+	vmCtx pkgctx.VirtualMachineContext,
+	lease *nfc.Lease,
+	info *nfc.LeaseInfo,
+	baseURL *url.URL,
+	token string) ([]string, []vimtypes.OvfFile, error) {
+
+	updater := lease.StartUpdater(vmCtx, info)
+	defer updater.Done()
+
+	files := make([]string, 0, len(info.Items))
+	ovfFiles := make([]vimtypes.OvfFile, 0, len(info.Items))
+
+	for _, item := range info.Items {
+		size, err := uploadExportedFile(vmCtx, lease, item, baseURL, token)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to upload %s: %w", item.Path, err)
+		}
+
+		file := item.File()
+		file.Size = size
+
+		files = append(files, item.Path)
+		ovfFiles = append(ovfFiles, file)
+	}
+
+	return files, ovfFiles, nil
+}
+
+func uploadExportedFile(
+	vmCtx pkgctx.VirtualMachineContext,
+	lease *nfc.Lease,
+	item nfc.FileItem,
+	baseURL *url.URL,
+	token string) (int64, error) {
+
+	r, size, err := lease.Download(vmCtx, item, soap.DefaultDownload)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	// The size of a streamed disk is not known until it is read.
+	cr := &countingReader{r: r}
+	if err := uploadFile(vmCtx, baseURL, item.Path, token, cr, size); err != nil {
+		return 0, err
+	}
+
+	return cr.n, nil
+}
+
+func uploadFile(
+	vmCtx pkgctx.VirtualMachineContext,
+	baseURL *url.URL,
+	name, token string,
+	body io.Reader,
+	size int64) error {
+
+	u := baseURL.JoinPath(name)
+	req, err := http.NewRequestWithContext(vmCtx, http.MethodPut, u.String(), body)
+	if err != nil {
+		return err
+	}
+	if size > 0 {
+		req.ContentLength = size
+	}
+	req.Header.Set("Content-Type", OVFFileMediaType)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to upload %s: %s", u.Redacted(), resp.Status)
+	}
+
+	return nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func exportTests() {
+	const token = "dummy-token"
+
+	var (
+		ctx    *builder.TestContextForVCSim
+		vcVM   *object.VirtualMachine
+		vm     *vmopv1.VirtualMachine
+		vmCtx  pkgctx.VirtualMachineContext
+		server *httptest.Server
+
+		mu    sync.Mutex
+		files map[string][]byte
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{})
+
+		var err error
+		vcVM, err = ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+		Expect(err).ToNot(HaveOccurred())
+
+		t, err := vcVM.PowerOff(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(t.Wait(ctx)).To(Succeed())
+
+		vm = builder.DummyVirtualMachine()
+		vm.Status.UniqueID = vcVM.Reference().Value
+		vmCtx = pkgctx.VirtualMachineContext{
+			Context: ctx,
+			Logger:  suite.GetLogger().WithValues("vmName", vcVM.Name()),
+			VM:      vm,
+		}
+
+		files = map[string][]byte{}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPut {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			if r.Header.Get("Authorization") != "Bearer "+token {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			b, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			mu.Lock()
+			files[r.URL.Path] = b
+			mu.Unlock()
+			w.WriteHeader(http.StatusCreated)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	It("Exports the VM to the URL", func() {
+		names, err := virtualmachine.ExportOVFToURL(vmCtx, vcVM, "dummy-vm", server.URL+"/export", token)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(len(names)).To(BeNumerically(">", 1))
+		Expect(names[0]).To(Equal("dummy-vm.ovf"))
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		descriptor, ok := files["/export/dummy-vm.ovf"]
+		Expect(ok).To(BeTrue())
+		for _, name := range names[1:] {
+			Expect(files).To(HaveKey("/export/" + name))
+			Expect(strings.Contains(string(descriptor), name)).To(BeTrue())
+		}
+	})
+
+	It("Returns an error when the token is invalid", func() {
+		_, err := virtualmachine.ExportOVFToURL(vmCtx, vcVM, "dummy-vm", server.URL+"/export", "wrong")
+		Expect(err).To(MatchError(ContainSubstring("401 Unauthorized")))
+
+		mu.Lock()
+		defer mu.Unlock()
+		Expect(files).To(BeEmpty())
+	})
+}
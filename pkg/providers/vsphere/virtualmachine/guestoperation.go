@@ -0,0 +1,372 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/vmware/govmomi/guest"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+const (
+	// guestOperationStdoutMaxBytes is the maximum number of bytes of a
+	// command's output that is recorded in the status.
+	guestOperationStdoutMaxBytes = 16 * 1024
+
+	// defaultGuestOperationTimeout is the timeout of a command when the
+	// operation does not specify one.
+	defaultGuestOperationTimeout = 300 * time.Second
+
+	windowsCmdPath   = `C:\Windows\System32\cmd.exe`
+	windowsTempDir   = `C:\Windows\Temp\`
+	posixTempDir     = "/tmp/"
+	stdoutFilePrefix = "vmop-guestop-"
+	stdoutFileSuffix = ".out"
+)
+
+// RunGuestOperation runs the guest operation in the VM's guest and updates
+// the operation's status.
+//
+// A file is copied into the guest synchronously. A command is started the
+// first time this function is called unless the guest already has a process
+// running it, and each subsequent call checks if the command has completed.
+// Once it has, the command's exit code and output are recorded in the status.
+// A command that does not complete before the operation's timeout is
+// terminated, and a command whose process is no longer found in the guest is
+// failed.
+func RunGuestOperation(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	op *vmopv1.VirtualMachineGuestOperation,
+	username, password string,
+	content []byte) error {
+
+	auth := &vimtypes.NamePasswordAuthentication{
+		Username: username,
+		Password: password,
+	}
+	opsMgr := guest.NewOperationsManager(vcVM.Client(), vcVM.Reference())
+
+	switch {
+	case op.Spec.CopyFile != nil:
+		return copyFileToGuest(vmCtx, vcVM, opsMgr, auth, op, content)
+	case op.Spec.Command != nil && op.Status.ProcessID == 0:
+		return startGuestCommand(vmCtx, vcVM, opsMgr, auth, op)
+	case op.Spec.Command != nil:
+		return waitForGuestCommand(vmCtx, vcVM, opsMgr, auth, op)
+	}
+
+	return errors.New("guest operation has neither a command nor a file to copy")
+}
+
+func copyFileToGuest(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	opsMgr *guest.OperationsManager,
+	auth vimtypes.BaseGuestAuthentication,
+	op *vmopv1.VirtualMachineGuestOperation,
+	content []byte) error {
+
+	fileMgr, err := opsMgr.FileManager(vmCtx)
+	if err != nil {
+		return err
+	}
+
+	op.Status.StartTime = metav1.Now()
+
+	path := op.Spec.CopyFile.Path
+	transferURL, err := fileMgr.InitiateFileTransferToGuest(
+		vmCtx,
+		auth,
+		path,
+		&vimtypes.GuestFileAttributes{},
+		int64(len(content)),
+		op.Spec.CopyFile.Overwrite)
+	if err != nil {
+		return fmt.Errorf("failed to initiate file transfer to %s: %w", path, err)
+	}
+
+	u, err := fileMgr.TransferURL(vmCtx, transferURL)
+	if err != nil {
+		return err
+	}
+
+	param := soap.DefaultUpload
+	param.ContentLength = int64(len(content))
+	if err := vcVM.Client().Upload(vmCtx, bytes.NewReader(content), u, &param); err != nil {
+		return fmt.Errorf("failed to copy file to %s: %w", path, err)
+	}
+
+	vmCtx.Logger.Info("Copied file to guest", "path", path, "size", len(content))
+
+	op.Status.CompletionTime = metav1.Now()
+	conditions.MarkTrue(op, vmopv1.VirtualMachineGuestOperationConditionCompleted)
+
+	return nil
+}
+
+func startGuestCommand(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	opsMgr *guest.OperationsManager,
+	auth vimtypes.BaseGuestAuthentication,
+	op *vmopv1.VirtualMachineGuestOperation) error {
+
+	windows, err := isWindowsGuest(vmCtx, vcVM)
+	if err != nil {
+		return err
+	}
+
+	procMgr, err := opsMgr.ProcessManager(vmCtx)
+	if err != nil {
+		return err
+	}
+
+	// The command may have already been started by an earlier reconcile whose
+	// status update failed, so adopt that process instead of running the
+	// command again.
+	stdoutPath := guestStdoutPath(op, windows)
+	procs, err := procMgr.ListProcesses(vmCtx, auth, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list processes: %w", err)
+	}
+	for _, proc := range procs {
+		if strings.Contains(proc.CmdLine, stdoutPath) {
+			vmCtx.Logger.Info("Found command already started in guest", "path", op.Spec.Command.Path, "pid", proc.Pid)
+
+			op.Status.StartTime = metav1.NewTime(proc.StartTime)
+			op.Status.ProcessID = proc.Pid
+			conditions.MarkFalse(
+				op,
+				vmopv1.VirtualMachineGuestOperationConditionCompleted,
+				vmopv1.GuestOperationInProgressReason,
+				"")
+
+			return nil
+		}
+	}
+
+	spec := guestProgramSpec(op, windows)
+	pid, err := procMgr.StartProgram(vmCtx, auth, spec)
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", op.Spec.Command.Path, err)
+	}
+
+	vmCtx.Logger.Info("Started command in guest", "path", op.Spec.Command.Path, "pid", pid)
+
+	op.Status.StartTime = metav1.Now()
+	op.Status.ProcessID = pid
+	conditions.MarkFalse(
+		op,
+		vmopv1.VirtualMachineGuestOperationConditionCompleted,
+		vmopv1.GuestOperationInProgressReason,
+		"")
+
+	return nil
+}
+
+func waitForGuestCommand(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	opsMgr *guest.OperationsManager,
+	auth vimtypes.BaseGuestAuthentication,
+	op *vmopv1.VirtualMachineGuestOperation) error {
+
+	procMgr, err := opsMgr.ProcessManager(vmCtx)
+	if err != nil {
+		return err
+	}
+
+	pid := op.Status.ProcessID
+	procs, err := procMgr.ListProcesses(vmCtx, auth, []int64{pid})
+	if err != nil {
+		return fmt.Errorf("failed to get process %d: %w", pid, err)
+	}
+	if len(procs) == 0 {
+		// The guest no longer knows about the process, ex. because the guest
+		// was rebooted, so the command's result will never be available.
+		vmCtx.Logger.Info("Command's process not found in guest", "pid", pid)
+
+		op.Status.CompletionTime = metav1.Now()
+		conditions.MarkFalse(
+			op,
+			vmopv1.VirtualMachineGuestOperationConditionCompleted,
+			vmopv1.GuestOperationProcessNotFoundReason,
+			"Process %d was not found in the guest",
+			pid)
+
+		return nil
+	}
+
+	proc := procs[0]
+	if proc.EndTime == nil {
+		timeout := time.Duration(op.Spec.TimeoutSeconds) * time.Second
+		if timeout == 0 {
+			timeout = defaultGuestOperationTimeout
+		}
+		if time.Since(op.Status.StartTime.Time) < timeout {
+			return nil
+		}
+
+		if err := procMgr.TerminateProcess(vmCtx, auth, pid); err != nil {
+			return fmt.Errorf("failed to terminate process %d: %w", pid, err)
+		}
+
+		vmCtx.Logger.Info("Terminated command in guest after timeout", "pid", pid, "timeout", timeout)
+
+		op.Status.CompletionTime = metav1.Now()
+		conditions.MarkFalse(
+			op,
+			vmopv1.VirtualMachineGuestOperationConditionCompleted,
+			vmopv1.GuestOperationTimedOutReason,
+			"Command did not complete within %s",
+			timeout)
+	} else {
+		vmCtx.Logger.Info("Command completed in guest", "pid", pid, "exitCode", proc.ExitCode)
+
+		op.Status.CompletionTime = metav1.NewTime(*proc.EndTime)
+		op.Status.ExitCode = &proc.ExitCode
+		conditions.MarkTrue(op, vmopv1.VirtualMachineGuestOperationConditionCompleted)
+	}
+
+	// The command has finished, so collecting its output is best effort.
+	windows, err := isWindowsGuest(vmCtx, vcVM)
+	if err != nil {
+		vmCtx.Logger.Error(err, "Failed to get guest family")
+		return nil
+	}
+	stdout, err := readAndDeleteGuestFile(vmCtx, vcVM, opsMgr, auth, guestStdoutPath(op, windows))
+	if err != nil {
+		vmCtx.Logger.Error(err, "Failed to get command output")
+		return nil
+	}
+	op.Status.Stdout = stdout
+
+	return nil
+}
+
+func readAndDeleteGuestFile(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	opsMgr *guest.OperationsManager,
+	auth vimtypes.BaseGuestAuthentication,
+	path string) (string, error) {
+
+	fileMgr, err := opsMgr.FileManager(vmCtx)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := fileMgr.InitiateFileTransferFromGuest(vmCtx, auth, path)
+	if err != nil {
+		return "", fmt.Errorf("failed to initiate file transfer from %s: %w", path, err)
+	}
+
+	u, err := fileMgr.TransferURL(vmCtx, info.Url)
+	if err != nil {
+		return "", err
+	}
+
+	rc, _, err := vcVM.Client().Download(vmCtx, u, &soap.DefaultDownload)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, guestOperationStdoutMaxBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if err := fileMgr.DeleteFile(vmCtx, auth, path); err != nil {
+		vmCtx.Logger.Error(err, "Failed to delete file in guest", "path", path)
+	}
+
+	return string(data), nil
+}
+
+func isWindowsGuest(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine) (bool, error) {
+
+	var moVM mo.VirtualMachine
+	if err := vcVM.Properties(vmCtx, vcVM.Reference(), []string{"guest.guestFamily"}, &moVM); err != nil {
+		return false, fmt.Errorf("failed to get guest family: %w", err)
+	}
+
+	return moVM.Guest != nil &&
+		moVM.Guest.GuestFamily == string(vimtypes.VirtualMachineGuestOsFamilyWindowsGuest), nil
+}
+
+// guestStdoutPath returns the path of the file in the guest to which the
+// command's output is redirected.
+func guestStdoutPath(op *vmopv1.VirtualMachineGuestOperation, windows bool) string {
+	name := stdoutFilePrefix + string(op.UID) + stdoutFileSuffix
+	if windows {
+		return windowsTempDir + name
+	}
+	return posixTempDir + name
+}
+
+// guestProgramSpec returns the spec of the program that runs the operation's
+// command with its output redirected to a file in the guest. On Linux, VMware
+// Tools runs the program with a shell that handles the redirection. On
+// Windows, the command is run by cmd.exe to redirect its output.
+func guestProgramSpec(
+	op *vmopv1.VirtualMachineGuestOperation,
+	windows bool) *vimtypes.GuestProgramSpec {
+
+	cmd := op.Spec.Command
+	spec := &vimtypes.GuestProgramSpec{
+		WorkingDirectory: cmd.WorkingDirectory,
+	}
+	for _, e := range cmd.Env {
+		spec.EnvVariables = append(spec.EnvVariables, e.Name+"="+e.Value)
+	}
+
+	stdoutPath := guestStdoutPath(op, windows)
+
+	if windows {
+		args := make([]string, 0, len(cmd.Args)+1)
+		args = append(args, windowsQuote(cmd.Path))
+		for _, a := range cmd.Args {
+			args = append(args, windowsQuote(a))
+		}
+		spec.ProgramPath = windowsCmdPath
+		spec.Arguments = fmt.Sprintf(`/c "%s > %s 2>&1"`, strings.Join(args, " "), windowsQuote(stdoutPath))
+		return spec
+	}
+
+	args := make([]string, 0, len(cmd.Args))
+	for _, a := range cmd.Args {
+		args = append(args, posixQuote(a))
+	}
+	args = append(args, "> "+posixQuote(stdoutPath), "2>&1")
+	spec.ProgramPath = cmd.Path
+	spec.Arguments = strings.Join(args, " ")
+
+	return spec
+}
+
+func posixQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func windowsQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
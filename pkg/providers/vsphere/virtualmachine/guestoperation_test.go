@@ -0,0 +1,97 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func guestOperationTests() {
+	var (
+		ctx   *builder.TestContextForVCSim
+		vcVM  *object.VirtualMachine
+		vmCtx pkgctx.VirtualMachineContext
+		op    *vmopv1.VirtualMachineGuestOperation
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{})
+
+		var err error
+		vcVM, err = ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+		Expect(err).ToNot(HaveOccurred())
+
+		vmCtx = pkgctx.VirtualMachineContext{
+			Context: ctx,
+			Logger:  suite.GetLogger().WithValues("vmName", vcVM.Name()),
+			VM:      builder.DummyVirtualMachine(),
+		}
+
+		op = builder.DummyVirtualMachineGuestOperation("dummy-op", vmCtx.VM.Namespace, vmCtx.VM.Name)
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	// Guest operations are only supported by vC Sim for container-backed VMs,
+	// so these tests cover the case where VMware Tools cannot run the
+	// operation.
+
+	Context("Command", func() {
+		It("returns an error and does not start the command", func() {
+			err := virtualmachine.RunGuestOperation(vmCtx, vcVM, op, "user", "password", nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to list processes"))
+
+			Expect(op.Status.ProcessID).To(BeZero())
+			Expect(op.Status.StartTime.IsZero()).To(BeTrue())
+			Expect(op.Status.Conditions).To(BeEmpty())
+		})
+	})
+
+	Context("CopyFile", func() {
+		BeforeEach(func() {
+			op.Spec.Command = nil
+			op.Spec.CopyFile = &vmopv1.VirtualMachineGuestOperationCopyFile{
+				Path: "/tmp/hello.txt",
+				Content: vmopv1common.ValueOrSecretKeySelector{
+					Value: ptr.To("hello"),
+				},
+			}
+		})
+
+		It("returns an error and does not complete", func() {
+			err := virtualmachine.RunGuestOperation(vmCtx, vcVM, op, "user", "password", []byte("hello"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to initiate file transfer to /tmp/hello.txt"))
+
+			Expect(op.Status.CompletionTime.IsZero()).To(BeTrue())
+			Expect(op.Status.Conditions).To(BeEmpty())
+		})
+	})
+
+	Context("No operation", func() {
+		BeforeEach(func() {
+			op.Spec.Command = nil
+		})
+
+		It("returns an error", func() {
+			err := virtualmachine.RunGuestOperation(vmCtx, vcVM, op, "user", "password", nil)
+			Expect(err).To(MatchError("guest operation has neither a command nor a file to copy"))
+		})
+	})
+
+}
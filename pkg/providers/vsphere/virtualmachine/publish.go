@@ -1,19 +1,28 @@
-// Copyright (c) 2022 VMware, Inc. All Rights Reserved.
+// Copyright (c) 2022-2024 VMware, Inc. All Rights Reserved.
 // SPDX-License-Identifier: Apache-2.0
 
 package virtualmachine
 
 import (
+	"bytes"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/vmware/govmomi/nfc"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/ovf"
 	"github.com/vmware/govmomi/vapi/rest"
 	"github.com/vmware/govmomi/vapi/vcenter"
+	"github.com/vmware/govmomi/vim25/soap"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
 
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
 )
 
 const (
@@ -23,6 +32,20 @@ const (
 	vAPICtxActIDHttpHeader = "vapi-ctx-actid"
 
 	itemDescriptionFormat = "virtualmachinepublishrequest.vmoperator.vmware.com: %s\n"
+
+	// OVFArtifactType is the artifact type of the OCI artifact pushed when a
+	// VM is published to an OCI registry.
+	OVFArtifactType = "application/vnd.vmware.vm-operator.ovf.v1"
+
+	// OVFDescriptorMediaType is the media type of the layer that contains the
+	// OVF descriptor of a VM published to an OCI registry.
+	OVFDescriptorMediaType = "application/vnd.vmware.ovf.descriptor.v1+xml"
+
+	// OVFFileMediaType is the media type of the layers that contain the files,
+	// ex. disks, referenced by the OVF descriptor.
+	OVFFileMediaType = "application/octet-stream"
+
+	defaultOCITag = "latest"
 )
 
 func CreateOVF(
@@ -62,3 +85,150 @@ func CreateOVF(
 	ctxHeader := client.WithHeader(vmCtx, http.Header{vAPICtxActIDHttpHeader: []string{actID}})
 	return vcenter.NewManager(client).CreateOVF(ctxHeader, ovf)
 }
+
+// PushOVFToOCIRegistry exports the VM as an OVF and pushes it as an OCI
+// artifact to the repository described by the publish request's target. The
+// OVF descriptor and each exported file are pushed as separate layers. The
+// digest reference of the pushed artifact is returned. The VM must be powered
+// off.
+func PushOVFToOCIRegistry(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	vmPubReq *vmopv1.VirtualMachinePublishRequest,
+	opts oci.Options) (string, error) {
+
+	target := vmPubReq.Status.TargetRef.OCIRegistry
+	if target == nil {
+		return "", fmt.Errorf("publish request %s does not have an OCI registry target", vmPubReq.Name)
+	}
+
+	ociClient, err := oci.NewClient(target.Repository, opts)
+	if err != nil {
+		return "", fmt.Errorf("invalid OCI registry repository: %w", err)
+	}
+
+	tag := target.Tag
+	if tag == "" {
+		tag = defaultOCITag
+	}
+
+	// A VM cannot be exported while it is powered on.
+	powerState, err := vcVM.PowerState(vmCtx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get VM power state: %w", err)
+	}
+	if powerState != vimtypes.VirtualMachinePowerStatePoweredOff {
+		return "", fmt.Errorf("VM must be powered off to be exported, power state is %s", powerState)
+	}
+
+	vmCtx.Logger.Info("Exporting VM to OCI registry",
+		"repository", target.Repository, "tag", tag)
+
+	lease, err := vcVM.Export(vmCtx)
+	if err != nil {
+		return "", fmt.Errorf("failed to export VM: %w", err)
+	}
+
+	info, err := lease.Wait(vmCtx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to wait for export lease: %w", err)
+	}
+
+	layers, ovfFiles, err := pushExportedFiles(vmCtx, lease, info, ociClient)
+	if err != nil {
+		_ = lease.Abort(vmCtx, &vimtypes.LocalizedMethodFault{LocalizedMessage: err.Error()})
+		return "", err
+	}
+
+	if err := lease.Complete(vmCtx); err != nil {
+		return "", fmt.Errorf("failed to complete export lease: %w", err)
+	}
+
+	name := vmPubReq.Status.TargetRef.Item.Name
+	desc, err := ovf.NewManager(vcVM.Client()).CreateDescriptor(vmCtx, vcVM, vimtypes.OvfCreateDescriptorParams{
+		Name:        name,
+		Description: fmt.Sprintf(itemDescriptionFormat, string(vmPubReq.UID)) + vmPubReq.Status.TargetRef.Item.Description,
+		OvfFiles:    ovfFiles,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create OVF descriptor: %w", err)
+	}
+	if len(desc.Error) > 0 {
+		return "", fmt.Errorf("failed to create OVF descriptor: %s", desc.Error[0].LocalizedMessage)
+	}
+
+	descLayer, err := ociClient.PushBlob(
+		vmCtx,
+		OVFDescriptorMediaType,
+		strings.NewReader(desc.OvfDescriptor),
+		map[string]string{oci.AnnotationTitle: name + ".ovf"})
+	if err != nil {
+		return "", fmt.Errorf("failed to push OVF descriptor: %w", err)
+	}
+
+	if _, err := ociClient.PushBlob(vmCtx, oci.MediaTypeEmptyJSON, bytes.NewReader(oci.EmptyJSON), nil); err != nil {
+		return "", fmt.Errorf("failed to push artifact config: %w", err)
+	}
+
+	manifest := oci.NewArtifactManifest(
+		OVFArtifactType,
+		append([]oci.Descriptor{descLayer}, layers...),
+		map[string]string{oci.AnnotationCreated: time.Now().UTC().Format(time.RFC3339)})
+
+	manifestDesc, err := ociClient.PushManifest(vmCtx, tag, manifest)
+	if err != nil {
+		return "", fmt.Errorf("failed to push artifact manifest: %w", err)
+	}
+
+	return ociClient.Repository().String() + "@" + manifestDesc.Digest, nil
+}
+
+// pushExportedFiles streams each of the files exported by the lease to the
+// registry as a blob. The blobs' descriptors and the files to include in the
+// OVF descriptor are returned.
+func pushExportedFiles(
+	vmCtx pkgctx.VirtualMachineContext,
+	lease *nfc.Lease,
+	info *nfc.LeaseInfo,
+	ociClient *oci.Client) ([]oci.Descriptor, []vimtypes.OvfFile, error) {
+
+	updater := lease.StartUpdater(vmCtx, info)
+	defer updater.Done()
+
+	layers := make([]oci.Descriptor, 0, len(info.Items))
+	ovfFiles := make([]vimtypes.OvfFile, 0, len(info.Items))
+
+	for _, item := range info.Items {
+		layer, err := pushExportedFile(vmCtx, lease, item, ociClient)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to push %s: %w", item.Path, err)
+		}
+
+		file := item.File()
+		file.Size = layer.Size
+
+		layers = append(layers, layer)
+		ovfFiles = append(ovfFiles, file)
+	}
+
+	return layers, ovfFiles, nil
+}
+
+func pushExportedFile(
+	vmCtx pkgctx.VirtualMachineContext,
+	lease *nfc.Lease,
+	item nfc.FileItem,
+	ociClient *oci.Client) (oci.Descriptor, error) {
+
+	r, _, err := lease.Download(vmCtx, item, soap.DefaultDownload)
+	if err != nil {
+		return oci.Descriptor{}, err
+	}
+	defer r.Close()
+
+	return ociClient.PushBlob(
+		vmCtx,
+		OVFFileMediaType,
+		r,
+		map[string]string{oci.AnnotationTitle: item.Path})
+}
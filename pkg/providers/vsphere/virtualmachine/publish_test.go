@@ -1,9 +1,12 @@
-// Copyright (c) 2022 VMware, Inc. All Rights Reserved.
+// Copyright (c) 2022-2024 VMware, Inc. All Rights Reserved.
 // SPDX-License-Identifier: Apache-2.0
 
 package virtualmachine_test
 
 import (
+	"encoding/json"
+	"strings"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -15,6 +18,8 @@ import (
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+	ocifake "github.com/vmware-tanzu/vm-operator/pkg/util/oci/fake"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
@@ -95,4 +100,85 @@ func publishTests() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(itemID).NotTo(BeNil())
 	})
+
+	Context("PushOVFToOCIRegistry", func() {
+		var (
+			registry *ocifake.Registry
+		)
+
+		BeforeEach(func() {
+			registry = ocifake.NewRegistry()
+			registry.Username = "user"
+			registry.Password = "pass"
+
+			vmPub.Spec.Target.Location = vmopv1.VirtualMachinePublishRequestTargetLocation{}
+			vmPub.Spec.Target.OCIRegistry = &vmopv1.VirtualMachinePublishRequestTargetOCIRegistry{
+				Repository: registry.Host() + "/images/dummy-vm",
+				Tag:        "v1",
+			}
+		})
+
+		AfterEach(func() {
+			registry.Close()
+		})
+
+		It("Pushes the VM to the registry", func() {
+			t, err := vcVM.PowerOff(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(t.Wait(ctx)).To(Succeed())
+
+			ref, err := virtualmachine.PushOVFToOCIRegistry(vmCtx, vcVM, vmPub, oci.Options{
+				Username: "user",
+				Password: "pass",
+				Insecure: true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ref).To(HavePrefix(registry.Host() + "/images/dummy-vm@sha256:"))
+
+			data, ok := registry.Manifest("images/dummy-vm", "v1")
+			Expect(ok).To(BeTrue())
+
+			var manifest oci.Manifest
+			Expect(json.Unmarshal(data, &manifest)).To(Succeed())
+			Expect(manifest.ArtifactType).To(Equal(virtualmachine.OVFArtifactType))
+			Expect(len(manifest.Layers)).To(BeNumerically(">", 1))
+			Expect(manifest.Layers[0].MediaType).To(Equal(virtualmachine.OVFDescriptorMediaType))
+			Expect(manifest.Layers[0].Annotations).To(HaveKeyWithValue(oci.AnnotationTitle, "dummy-item-name.ovf"))
+
+			descriptor, ok := registry.Blob(manifest.Layers[0].Digest)
+			Expect(ok).To(BeTrue())
+			for _, l := range manifest.Layers[1:] {
+				Expect(l.MediaType).To(Equal(virtualmachine.OVFFileMediaType))
+				Expect(strings.Contains(string(descriptor), l.Annotations[oci.AnnotationTitle])).To(BeTrue())
+				_, ok := registry.Blob(l.Digest)
+				Expect(ok).To(BeTrue())
+			}
+		})
+
+		It("Returns an error when the VM is powered on", func() {
+			_, err := virtualmachine.PushOVFToOCIRegistry(vmCtx, vcVM, vmPub, oci.Options{
+				Username: "user",
+				Password: "pass",
+				Insecure: true,
+			})
+			Expect(err).To(MatchError(ContainSubstring("VM must be powered off to be exported")))
+			_, ok := registry.Manifest("images/dummy-vm", "v1")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("Returns an error when the credentials are invalid", func() {
+			t, err := vcVM.PowerOff(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(t.Wait(ctx)).To(Succeed())
+
+			_, err = virtualmachine.PushOVFToOCIRegistry(vmCtx, vcVM, vmPub, oci.Options{
+				Username: "user",
+				Password: "wrong",
+				Insecure: true,
+			})
+			Expect(err).To(HaveOccurred())
+			_, ok := registry.Manifest("images/dummy-vm", "v1")
+			Expect(ok).To(BeFalse())
+		})
+	})
 }
@@ -0,0 +1,125 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"errors"
+	"net/url"
+	"path"
+
+	"github.com/vmware/govmomi/object"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+// SerialConsoleServiceURI returns the service URI of the network-backed
+// serial port used for the VM's serial console. The serial port concentrator
+// uses the service URI to identify the VM.
+func SerialConsoleServiceURI(vm *vmopv1.VirtualMachine) string {
+	return path.Join("vmoperator", vm.Namespace, vm.Name)
+}
+
+// UpdateSerialConsoleDeviceChanges returns the device change to add a serial
+// port connected to the serial port concentrator when the VM has the serial
+// console annotation and does not already have such a port.
+func UpdateSerialConsoleDeviceChanges(
+	vmCtx pkgctx.VirtualMachineContext,
+	curDevices object.VirtualDeviceList) []vimtypes.BaseVirtualDeviceConfigSpec {
+
+	proxyURI := pkgcfg.FromContext(vmCtx).SerialConsoleProxyURI
+	if proxyURI == "" {
+		return nil
+	}
+
+	if _, ok := vmCtx.VM.Annotations[vmopv1.SerialConsoleAnnotation]; !ok {
+		return nil
+	}
+
+	if getSerialConsolePort(vmCtx.VM, curDevices) != nil {
+		return nil
+	}
+
+	port, err := curDevices.CreateSerialPort()
+	if err != nil {
+		vmCtx.Logger.Error(err, "Cannot add serial console port")
+		return nil
+	}
+	port = curDevices.ConnectSerialPort(port, SerialConsoleServiceURI(vmCtx.VM), false, proxyURI)
+	port.Connectable = &vimtypes.VirtualDeviceConnectInfo{
+		StartConnected: true,
+		Connected:      true,
+	}
+
+	vmCtx.Logger.Info("Adding serial console port",
+		"serviceURI", SerialConsoleServiceURI(vmCtx.VM), "proxyURI", proxyURI)
+
+	return []vimtypes.BaseVirtualDeviceConfigSpec{
+		&vimtypes.VirtualDeviceConfigSpec{
+			Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+			Device:    port,
+		},
+	}
+}
+
+// GetSerialConsoleTicket returns the URI of the VM's serial console encrypted
+// with pubKey. The URI's query includes the uuid and namespace that the serial
+// port concentrator uses to authenticate the connection with the web console
+// validation server, along with the service URI from the ticket's path that
+// ties the connection to this VM. An empty string is returned if the VM does not have a
+// serial port connected to the serial port concentrator.
+func GetSerialConsoleTicket(
+	vmCtx pkgctx.VirtualMachineContext,
+	vm *object.VirtualMachine,
+	pubKey, uuid string) (string, error) {
+
+	vmCtx.Logger.V(5).Info("GetSerialConsoleTicket")
+
+	proxyURI := pkgcfg.FromContext(vmCtx).SerialConsoleProxyURI
+	if proxyURI == "" {
+		return "", errors.New("serial console proxy URI is not configured")
+	}
+
+	devices, err := vm.Device(vmCtx)
+	if err != nil {
+		return "", err
+	}
+
+	if getSerialConsolePort(vmCtx.VM, devices) == nil {
+		return "", nil
+	}
+
+	u, err := url.Parse(proxyURI)
+	if err != nil {
+		return "", err
+	}
+	u = u.JoinPath(SerialConsoleServiceURI(vmCtx.VM))
+
+	query := url.Values{}
+	query.Set("uuid", uuid)
+	query.Set("namespace", vmCtx.VM.Namespace)
+	u.RawQuery = query.Encode()
+
+	return EncryptWebMKS(pubKey, u.String())
+}
+
+func getSerialConsolePort(
+	vm *vmopv1.VirtualMachine,
+	devices object.VirtualDeviceList) *vimtypes.VirtualSerialPort {
+
+	serviceURI := SerialConsoleServiceURI(vm)
+
+	for _, d := range devices.SelectByType((*vimtypes.VirtualSerialPort)(nil)) {
+		port := d.(*vimtypes.VirtualSerialPort)
+		if backing, ok := port.Backing.(*vimtypes.VirtualSerialPortURIBackingInfo); ok {
+			if backing.ServiceURI == serviceURI {
+				return port
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	"crypto/rsa"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func serialConsoleTests() {
+	const proxyURI = "telnets://vspc.local:13370"
+
+	var (
+		ctx          *builder.TestContextForVCSim
+		vcVM         *object.VirtualMachine
+		vmCtx        pkgctx.VirtualMachineContext
+		privateKey   *rsa.PrivateKey
+		publicKeyPem string
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{})
+
+		var err error
+		vcVM, err = ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+		Expect(err).ToNot(HaveOccurred())
+
+		pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+			config.SerialConsoleProxyURI = proxyURI
+		})
+
+		vmCtx = pkgctx.VirtualMachineContext{
+			Context: ctx,
+			Logger:  suite.GetLogger().WithValues("vmName", vcVM.Name()),
+			VM:      builder.DummyVirtualMachine(),
+		}
+		vmCtx.VM.Annotations[vmopv1.SerialConsoleAnnotation] = ""
+
+		privateKey, publicKeyPem = builder.WebConsoleRequestKeyPair()
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	getDevices := func() object.VirtualDeviceList {
+		devices, err := vcVM.Device(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		return devices
+	}
+
+	addSerialConsolePort := func() {
+		deviceChanges := virtualmachine.UpdateSerialConsoleDeviceChanges(vmCtx, getDevices())
+		Expect(deviceChanges).To(HaveLen(1))
+		Expect(vcVM.AddDevice(ctx, deviceChanges[0].GetVirtualDeviceConfigSpec().Device)).To(Succeed())
+	}
+
+	Context("UpdateSerialConsoleDeviceChanges", func() {
+		It("returns a serial port connected to the serial port concentrator", func() {
+			deviceChanges := virtualmachine.UpdateSerialConsoleDeviceChanges(vmCtx, getDevices())
+			Expect(deviceChanges).To(HaveLen(1))
+
+			spec := deviceChanges[0].GetVirtualDeviceConfigSpec()
+			Expect(spec.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationAdd))
+			port, ok := spec.Device.(*vimtypes.VirtualSerialPort)
+			Expect(ok).To(BeTrue())
+			backing, ok := port.Backing.(*vimtypes.VirtualSerialPortURIBackingInfo)
+			Expect(ok).To(BeTrue())
+			Expect(backing.ServiceURI).To(Equal(virtualmachine.SerialConsoleServiceURI(vmCtx.VM)))
+			Expect(backing.ProxyURI).To(Equal(proxyURI))
+			Expect(backing.Direction).To(Equal(string(vimtypes.VirtualDeviceURIBackingOptionDirectionServer)))
+		})
+
+		When("the VM does not have the serial console annotation", func() {
+			BeforeEach(func() {
+				delete(vmCtx.VM.Annotations, vmopv1.SerialConsoleAnnotation)
+			})
+
+			It("returns no device changes", func() {
+				Expect(virtualmachine.UpdateSerialConsoleDeviceChanges(vmCtx, getDevices())).To(BeEmpty())
+			})
+		})
+
+		When("the serial console proxy URI is not configured", func() {
+			BeforeEach(func() {
+				pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+					config.SerialConsoleProxyURI = ""
+				})
+			})
+
+			It("returns no device changes", func() {
+				Expect(virtualmachine.UpdateSerialConsoleDeviceChanges(vmCtx, getDevices())).To(BeEmpty())
+			})
+		})
+
+		When("the VM already has the serial port", func() {
+			BeforeEach(func() {
+				addSerialConsolePort()
+			})
+
+			It("returns no device changes", func() {
+				Expect(virtualmachine.UpdateSerialConsoleDeviceChanges(vmCtx, getDevices())).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("GetSerialConsoleTicket", func() {
+		It("returns empty ticket when the VM does not have the serial port", func() {
+			ticket, err := virtualmachine.GetSerialConsoleTicket(vmCtx, vcVM, publicKeyPem, "my-uuid")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ticket).To(BeEmpty())
+		})
+
+		When("the VM has the serial port", func() {
+			BeforeEach(func() {
+				addSerialConsolePort()
+			})
+
+			It("returns the encrypted serial console URI", func() {
+				ticket, err := virtualmachine.GetSerialConsoleTicket(vmCtx, vcVM, publicKeyPem, "my-uuid")
+				Expect(err).ToNot(HaveOccurred())
+
+				uri, err := virtualmachine.DecryptWebMKS(privateKey, ticket)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(uri).To(Equal(proxyURI + "/" + virtualmachine.SerialConsoleServiceURI(vmCtx.VM) +
+					"?namespace=" + vmCtx.VM.Namespace + "&uuid=my-uuid"))
+			})
+		})
+	})
+}
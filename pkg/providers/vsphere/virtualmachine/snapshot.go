@@ -0,0 +1,199 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"fmt"
+
+	"github.com/vmware/govmomi/fault"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+const (
+	// minQuiesceTimeoutMinutes and maxQuiesceTimeoutMinutes are the bounds
+	// vSphere places on the time allowed to quiesce a guest.
+	minQuiesceTimeoutMinutes = 5
+	maxQuiesceTimeoutMinutes = 240
+)
+
+// CreateSnapshot takes a snapshot of the VM as described by the provided
+// VirtualMachineSnapshot. The name of the snapshot on vSphere is the name of
+// the VirtualMachineSnapshot resource. The reference to the newly created
+// snapshot is returned.
+func CreateSnapshot(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) (*vimtypes.ManagedObjectReference, error) {
+
+	vmCtx.Logger.V(4).Info("Creating snapshot", "snapshotName", vmSnapshot.Name)
+
+	var (
+		t   *object.Task
+		err error
+
+		name        = vmSnapshot.Name
+		description = vmSnapshot.Spec.Description
+		memory      = vmSnapshot.Spec.Memory
+		quiesce     = vmSnapshot.Spec.Quiesce
+	)
+
+	if quiesce != nil && quiesce.Timeout != nil {
+		// The timeout can only be specified with the CreateSnapshotEx API.
+		var res *vimtypes.CreateSnapshotEx_TaskResponse
+		res, err = methods.CreateSnapshotEx_Task(vmCtx, vcVM.Client(), &vimtypes.CreateSnapshotEx_Task{
+			This:        vcVM.Reference(),
+			Name:        name,
+			Description: description,
+			Memory:      memory,
+			QuiesceSpec: &vimtypes.VirtualMachineGuestQuiesceSpec{
+				Timeout: QuiesceTimeoutMinutes(quiesce),
+			},
+		})
+		if err == nil {
+			t = object.NewTask(vcVM.Client(), res.Returnval)
+		}
+	} else {
+		t, err = vcVM.CreateSnapshot(vmCtx, name, description, memory, quiesce != nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	taskInfo, err := t.WaitForResult(vmCtx)
+	if err != nil {
+		if taskInfo != nil {
+			vmCtx.Logger.V(5).Error(err, "create snapshot task failed", "taskInfo", taskInfo)
+		}
+		return nil, fmt.Errorf("create snapshot task failed: %w", err)
+	}
+
+	snapRef, ok := taskInfo.Result.(vimtypes.ManagedObjectReference)
+	if !ok {
+		return nil, fmt.Errorf("create snapshot task returned unexpected result %T", taskInfo.Result)
+	}
+
+	return &snapRef, nil
+}
+
+// QuiesceTimeoutMinutes returns the quiesce timeout in whole minutes, clamped
+// to the range supported by vSphere.
+func QuiesceTimeoutMinutes(quiesce *vmopv1.QuiesceSpec) int32 {
+	if quiesce == nil || quiesce.Timeout == nil {
+		return 0
+	}
+
+	minutes := int32(quiesce.Timeout.Duration.Minutes())
+	switch {
+	case minutes < minQuiesceTimeoutMinutes:
+		return minQuiesceTimeoutMinutes
+	case minutes > maxQuiesceTimeoutMinutes:
+		return maxQuiesceTimeoutMinutes
+	}
+	return minutes
+}
+
+// GetSnapshotInfo returns the VM's snapshot information. Nil is returned if
+// the VM does not have any snapshots.
+func GetSnapshotInfo(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine) (*vimtypes.VirtualMachineSnapshotInfo, error) {
+
+	var o mo.VirtualMachine
+	if err := vcVM.Properties(vmCtx, vcVM.Reference(), []string{"snapshot"}, &o); err != nil {
+		return nil, err
+	}
+
+	return o.Snapshot, nil
+}
+
+// RevertToSnapshot reverts the VM to the snapshot identified by snapshotID,
+// which is the value of the snapshot's managed object reference.
+func RevertToSnapshot(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	snapshotID string) error {
+
+	vmCtx.Logger.V(4).Info("Reverting to snapshot", "snapshotID", snapshotID)
+
+	// Do not suppress the power on so the VM's power state matches the power
+	// state of the snapshot.
+	t, err := vcVM.RevertToSnapshot(vmCtx, snapshotID, false)
+	if err != nil {
+		return err
+	}
+
+	if taskInfo, err := t.WaitForResult(vmCtx); err != nil {
+		if taskInfo != nil {
+			vmCtx.Logger.V(5).Error(err, "revert to snapshot task failed", "taskInfo", taskInfo)
+		}
+		return fmt.Errorf("revert to snapshot task failed: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSnapshot removes the snapshot identified by snapshotID, which is the
+// value of the snapshot's managed object reference. The snapshot's children
+// are not removed. No error is returned if the snapshot does not exist.
+func DeleteSnapshot(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	snapshotID string) error {
+
+	vmCtx.Logger.V(4).Info("Deleting snapshot", "snapshotID", snapshotID)
+
+	ref := vimtypes.ManagedObjectReference{
+		Type:  "VirtualMachineSnapshot",
+		Value: snapshotID,
+	}
+
+	res, err := methods.RemoveSnapshot_Task(vmCtx, vcVM.Client(), &vimtypes.RemoveSnapshot_Task{
+		This:           ref,
+		RemoveChildren: false,
+		Consolidate:    ptr.To(true),
+	})
+	if err != nil {
+		if fault.Is(err, &vimtypes.ManagedObjectNotFound{}) {
+			return nil
+		}
+		return err
+	}
+
+	t := object.NewTask(vcVM.Client(), res.Returnval)
+	if taskInfo, err := t.WaitForResult(vmCtx); err != nil {
+		if fault.Is(err, &vimtypes.ManagedObjectNotFound{}) {
+			return nil
+		}
+		if taskInfo != nil {
+			vmCtx.Logger.V(5).Error(err, "remove snapshot task failed", "taskInfo", taskInfo)
+		}
+		return fmt.Errorf("remove snapshot task failed: %w", err)
+	}
+
+	return nil
+}
+
+// FindSnapshotTree returns the node in the provided snapshot trees for the
+// snapshot with the given ID. Nil is returned if no such snapshot exists.
+func FindSnapshotTree(
+	trees []vimtypes.VirtualMachineSnapshotTree,
+	snapshotID string) *vimtypes.VirtualMachineSnapshotTree {
+
+	for i := range trees {
+		if trees[i].Snapshot.Value == snapshotID {
+			return &trees[i]
+		}
+		if t := FindSnapshotTree(trees[i].ChildSnapshotList, snapshotID); t != nil {
+			return t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/object"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func snapshotTests() {
+
+	var (
+		ctx        *builder.TestContextForVCSim
+		vcVM       *object.VirtualMachine
+		vmCtx      pkgctx.VirtualMachineContext
+		vmSnapshot *vmopv1.VirtualMachineSnapshot
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{})
+
+		var err error
+		vcVM, err = ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+		Expect(err).ToNot(HaveOccurred())
+
+		vmCtx = pkgctx.VirtualMachineContext{
+			Context: ctx,
+			Logger:  suite.GetLogger().WithValues("vmName", vcVM.Name()),
+			VM:      builder.DummyVirtualMachine(),
+		}
+
+		vmSnapshot = builder.DummyVirtualMachineSnapshot("my-namespace", "snap-1", vmCtx.VM.Name)
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	Context("CreateSnapshot", func() {
+		It("creates a snapshot named after the resource", func() {
+			snapRef, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, vmSnapshot)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapRef).ToNot(BeNil())
+
+			info, err := virtualmachine.GetSnapshotInfo(vmCtx, vcVM)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info).ToNot(BeNil())
+			Expect(info.CurrentSnapshot).ToNot(BeNil())
+			Expect(info.CurrentSnapshot.Value).To(Equal(snapRef.Value))
+
+			tree := virtualmachine.FindSnapshotTree(info.RootSnapshotList, snapRef.Value)
+			Expect(tree).ToNot(BeNil())
+			Expect(tree.Name).To(Equal(vmSnapshot.Name))
+		})
+
+		When("a quiesce timeout is specified", func() {
+			BeforeEach(func() {
+				vmSnapshot.Spec.Quiesce = &vmopv1.QuiesceSpec{
+					Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+				}
+			})
+			It("creates the snapshot", func() {
+				snapRef, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, vmSnapshot)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(snapRef).ToNot(BeNil())
+			})
+		})
+	})
+
+	Context("GetSnapshotInfo", func() {
+		It("returns nil when the VM has no snapshots", func() {
+			info, err := virtualmachine.GetSnapshotInfo(vmCtx, vcVM)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info).To(BeNil())
+		})
+	})
+
+	Context("RevertToSnapshot", func() {
+		It("reverts to the snapshot", func() {
+			snap1Ref, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, vmSnapshot)
+			Expect(err).ToNot(HaveOccurred())
+
+			vmSnapshot.Name = "snap-2"
+			_, err = virtualmachine.CreateSnapshot(vmCtx, vcVM, vmSnapshot)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(virtualmachine.RevertToSnapshot(vmCtx, vcVM, snap1Ref.Value)).To(Succeed())
+
+			info, err := virtualmachine.GetSnapshotInfo(vmCtx, vcVM)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.CurrentSnapshot).ToNot(BeNil())
+			Expect(info.CurrentSnapshot.Value).To(Equal(snap1Ref.Value))
+		})
+	})
+
+	Context("DeleteSnapshot", func() {
+		It("deletes the snapshot", func() {
+			snapRef, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, vmSnapshot)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(virtualmachine.DeleteSnapshot(vmCtx, vcVM, snapRef.Value)).To(Succeed())
+
+			info, err := virtualmachine.GetSnapshotInfo(vmCtx, vcVM)
+			Expect(err).ToNot(HaveOccurred())
+			if info != nil {
+				Expect(virtualmachine.FindSnapshotTree(info.RootSnapshotList, snapRef.Value)).To(BeNil())
+			}
+		})
+
+		It("does not return an error when the snapshot does not exist", func() {
+			Expect(virtualmachine.DeleteSnapshot(vmCtx, vcVM, "snapshot-does-not-exist")).To(Succeed())
+		})
+	})
+
+	Context("QuiesceTimeoutMinutes", func() {
+		DescribeTable("clamps the timeout",
+			func(timeout *metav1.Duration, expected int32) {
+				var spec *vmopv1.QuiesceSpec
+				if timeout != nil {
+					spec = &vmopv1.QuiesceSpec{Timeout: timeout}
+				}
+				Expect(virtualmachine.QuiesceTimeoutMinutes(spec)).To(Equal(expected))
+			},
+			Entry("nil", nil, int32(0)),
+			Entry("below minimum", &metav1.Duration{Duration: time.Minute}, int32(5)),
+			Entry("within range", &metav1.Duration{Duration: 30*time.Minute + 30*time.Second}, int32(30)),
+			Entry("above maximum", &metav1.Duration{Duration: 10 * time.Hour}, int32(240)),
+		)
+	})
+}
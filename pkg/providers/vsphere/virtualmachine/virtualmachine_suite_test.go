@@ -16,9 +16,13 @@ func vcSimTests() {
 	Describe("ClusterComputeResource", Label(testlabels.VCSim), ccrTests)
 	Describe("Delete", Label(testlabels.VCSim), deleteTests)
 	Describe("Publish", Label(testlabels.VCSim), publishTests)
+	Describe("Export", Label(testlabels.VCSim), exportTests)
 	Describe("Backup", Label(testlabels.VCSim), backupTests)
 	Describe("GuestInfo", Label(testlabels.VCSim), guestInfoTests)
 	Describe("CD-ROM", Label(testlabels.VCSim), cdromTests)
+	Describe("Snapshot", Label(testlabels.VCSim), snapshotTests)
+	Describe("SerialConsole", Label(testlabels.VCSim), serialConsoleTests)
+	Describe("GuestOperation", Label(testlabels.VCSim), guestOperationTests)
 }
 
 var suite = builder.NewTestSuite()
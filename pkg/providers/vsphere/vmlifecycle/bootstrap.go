@@ -127,6 +127,44 @@ func DoBootstrap(
 	return nil
 }
 
+// UpdateGuestNetworkConfig updates the network configuration of a powered on
+// VM's guest after its network interfaces have been changed. This is only
+// possible with the Cloud-Init bootstrap provider using the GuestInfo
+// datasource since the other bootstrap providers customize the guest via
+// GOSC, which requires the VM to be powered off. The guest's network is
+// reconfigured when Cloud-Init next applies the network configuration, for
+// example on a hotplug event or reboot.
+func UpdateGuestNetworkConfig(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	config *vimtypes.VirtualMachineConfigInfo,
+	bootstrapArgs BootstrapArgs) error {
+
+	bootstrap := vmCtx.VM.Spec.Bootstrap
+	if bootstrap == nil || bootstrap.CloudInit == nil {
+		vmCtx.Logger.V(4).Info("Skipping guest network config update since bootstrap provider is not Cloud-Init")
+		return nil
+	}
+
+	if vmCtx.VM.Annotations[constants.CloudInitTypeAnnotation] == constants.CloudInitTypeValueCloudInitPrep {
+		vmCtx.Logger.V(4).Info("Skipping guest network config update since Cloud-Init uses GOSC")
+		return nil
+	}
+
+	configSpec, _, err := BootStrapCloudInit(vmCtx, config, bootstrap.CloudInit, &bootstrapArgs)
+	if err != nil {
+		return fmt.Errorf("failed to create bootstrap data: %w", err)
+	}
+
+	if configSpec != nil {
+		if err := doReconfigure(vmCtx, vcVM, configSpec); err != nil {
+			return fmt.Errorf("bootstrap reconfigure failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // GetBootstrapArgs returns the information used to bootstrap the VM via
 // one of the many, possible bootstrap engines.
 func GetBootstrapArgs(
@@ -18,6 +18,12 @@ type CreateArgs struct {
 	UseContentLibrary bool
 	ProviderItemID    string
 
+	// CloneSourceMoID is the managed object ID of an existing VM to clone
+	// instead of deploying the VM from its image. LinkedClone creates the
+	// clone's disks as child disks of the source VM's current snapshot.
+	CloneSourceMoID string
+	LinkedClone     bool
+
 	ConfigSpec          vimtypes.VirtualMachineConfigSpec
 	StorageProvisioning string
 	FolderMoID          string
@@ -36,6 +42,10 @@ func CreateVirtualMachine(
 
 	ctxop.MarkCreate(vmCtx)
 
+	if createArgs.CloneSourceMoID != "" {
+		return cloneVMFromSourceVM(vmCtx, vimClient, createArgs)
+	}
+
 	if createArgs.UseContentLibrary {
 		return deployFromContentLibrary(vmCtx, restClient, vimClient, createArgs)
 	}
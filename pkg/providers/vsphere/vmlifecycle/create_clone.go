@@ -4,10 +4,13 @@
 package vmlifecycle
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
@@ -30,6 +33,30 @@ func cloneVMFromInventory(
 		return nil, fmt.Errorf("failed to find clone source VM: %s: %w", srcVMName, err)
 	}
 
+	return cloneVM(vmCtx, createArgs, srcVM)
+}
+
+// cloneVMFromSourceVM creates a new VM by cloning the existing VM identified by
+// createArgs.CloneSourceMoID, i.e. the VM backing a VirtualMachineClone's
+// source VirtualMachine.
+func cloneVMFromSourceVM(
+	vmCtx pkgctx.VirtualMachineContext,
+	vimClient *vim25.Client,
+	createArgs *CreateArgs) (*vimtypes.ManagedObjectReference, error) {
+
+	srcVM := object.NewVirtualMachine(vimClient, vimtypes.ManagedObjectReference{
+		Type:  "VirtualMachine",
+		Value: createArgs.CloneSourceMoID,
+	})
+
+	return cloneVM(vmCtx, createArgs, srcVM)
+}
+
+func cloneVM(
+	vmCtx pkgctx.VirtualMachineContext,
+	createArgs *CreateArgs,
+	srcVM *object.VirtualMachine) (*vimtypes.ManagedObjectReference, error) {
+
 	cloneSpec, err := createCloneSpec(vmCtx, createArgs, srcVM)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create CloneSpec: %w", err)
@@ -69,6 +96,41 @@ func createCloneSpec(
 
 	virtualDisks := virtualDevices.SelectByType((*vimtypes.VirtualDisk)(nil))
 
+	if createArgs.CloneSourceMoID != "" {
+		// The source VM's NICs and PVC backed disks belong to the source VM.
+		// The clone's NICs are added by the ConfigSpec.
+		var removeDevices object.VirtualDeviceList
+		removeDevices = append(removeDevices, virtualDevices.SelectByType((*vimtypes.VirtualEthernetCard)(nil))...)
+
+		var classicDisks object.VirtualDeviceList
+		for _, d := range virtualDisks {
+			if d.(*vimtypes.VirtualDisk).VDiskId != nil {
+				removeDevices = append(removeDevices, d)
+			} else {
+				classicDisks = append(classicDisks, d)
+			}
+		}
+		virtualDisks = classicDisks
+
+		for _, d := range removeDevices {
+			cloneSpec.Config.DeviceChange = append(cloneSpec.Config.DeviceChange, &vimtypes.VirtualDeviceConfigSpec{
+				Operation: vimtypes.VirtualDeviceConfigSpecOperationRemove,
+				Device:    d,
+			})
+		}
+
+		if createArgs.LinkedClone {
+			var moVM mo.VirtualMachine
+			if err := srcVM.Properties(vmCtx, srcVM.Reference(), []string{"snapshot"}, &moVM); err != nil {
+				return nil, fmt.Errorf("failed to get clone source VM snapshot: %w", err)
+			}
+			if moVM.Snapshot == nil || moVM.Snapshot.CurrentSnapshot == nil {
+				return nil, errors.New("clone source VM does not have a snapshot required for a linked clone")
+			}
+			cloneSpec.Snapshot = moVM.Snapshot.CurrentSnapshot
+		}
+	}
+
 	for _, deviceChange := range resizeBootDiskDeviceChange(vmCtx, virtualDisks) {
 		if deviceChange.GetVirtualDeviceConfigSpec().Operation == vimtypes.VirtualDeviceConfigSpecOperationEdit {
 			cloneSpec.Location.DeviceChange = append(cloneSpec.Location.DeviceChange, deviceChange)
@@ -136,6 +198,14 @@ func cloneVMDiskLocators(
 			DiskMoveType: string(vimtypes.VirtualMachineRelocateDiskMoveOptionsMoveChildMostDiskBacking),
 		}
 
+		if createArgs.LinkedClone {
+			// The child disks are backed by the source VM's disks so their
+			// provisioning cannot be changed.
+			locator.DiskMoveType = string(vimtypes.VirtualMachineRelocateDiskMoveOptionsCreateNewChildDiskBacking)
+			diskLocators = append(diskLocators, locator)
+			continue
+		}
+
 		if backing, ok := disk.(*vimtypes.VirtualDisk).Backing.(*vimtypes.VirtualDiskFlatVer2BackingInfo); ok {
 			switch createArgs.StorageProvisioning {
 			case string(vimtypes.OvfCreateImportSpecParamsDiskProvisioningTypeThin):
@@ -29,6 +29,7 @@ import (
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/vcenter"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
 	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
@@ -48,6 +49,7 @@ var (
 		"guest",
 		"resourcePool",
 		"runtime",
+		"snapshot",
 		"summary",
 	}
 )
@@ -94,6 +96,7 @@ func UpdateStatus(
 	vm.Status.HardwareVersion = int32(hardwareVersion)
 	updateGuestNetworkStatus(vmCtx.VM, vmCtx.MoVM.Guest)
 	updateStorageStatus(vmCtx.VM, vmCtx.MoVM)
+	updateSnapshotStatus(vmCtx.VM, vmCtx.MoVM)
 
 	vm.Status.Host, err = getRuntimeHostHostname(vmCtx, vcVM, summary.Runtime.Host)
 	if err != nil {
@@ -875,6 +878,41 @@ func updateVolumeStatus(vm *vmopv1.VirtualMachine, moVM mo.VirtualMachine) {
 
 const byteToGiB = 1 /* B */ * 1024 /* KiB */ * 1024 /* MiB */ * 1024 /* GiB */
 
+// updateSnapshotStatus sets the VM's current and root snapshots from the
+// vSphere snapshot tree.
+func updateSnapshotStatus(vm *vmopv1.VirtualMachine, moVM mo.VirtualMachine) {
+	vm.Status.CurrentSnapshot = nil
+	vm.Status.RootSnapshots = nil
+
+	info := moVM.Snapshot
+	if info == nil {
+		return
+	}
+
+	// The name of the vSphere snapshot is the name of the
+	// VirtualMachineSnapshot resource from which it was created.
+	for i := range info.RootSnapshotList {
+		vm.Status.RootSnapshots = append(
+			vm.Status.RootSnapshots,
+			snapshotLocalObjectRef(info.RootSnapshotList[i].Name))
+	}
+
+	if info.CurrentSnapshot != nil {
+		if t := virtualmachine.FindSnapshotTree(info.RootSnapshotList, info.CurrentSnapshot.Value); t != nil {
+			ref := snapshotLocalObjectRef(t.Name)
+			vm.Status.CurrentSnapshot = &ref
+		}
+	}
+}
+
+func snapshotLocalObjectRef(name string) common.LocalObjectRef {
+	return common.LocalObjectRef{
+		APIVersion: vmopv1.GroupVersion.String(),
+		Kind:       "VirtualMachineSnapshot",
+		Name:       name,
+	}
+}
+
 // BytesToResourceGiB returns the resource.Quantity GiB value for the specified
 // number of bytes.
 func BytesToResourceGiB(b int64) *resource.Quantity {
@@ -1028,6 +1028,71 @@ var _ = Describe("UpdateStatus", func() {
 		})
 	})
 
+	Context("Snapshots", func() {
+		snapshotRef := func(name string) vmopv1common.LocalObjectRef {
+			return vmopv1common.LocalObjectRef{
+				APIVersion: vmopv1.GroupVersion.String(),
+				Kind:       "VirtualMachineSnapshot",
+				Name:       name,
+			}
+		}
+
+		When("the VM has no snapshots", func() {
+			BeforeEach(func() {
+				vmCtx.MoVM.Snapshot = nil
+				vmCtx.VM.Status.CurrentSnapshot = ptr.To(snapshotRef("stale"))
+				vmCtx.VM.Status.RootSnapshots = []vmopv1common.LocalObjectRef{snapshotRef("stale")}
+			})
+			It("clears the snapshot status", func() {
+				Expect(vmCtx.VM.Status.CurrentSnapshot).To(BeNil())
+				Expect(vmCtx.VM.Status.RootSnapshots).To(BeNil())
+			})
+		})
+
+		When("the VM has snapshots", func() {
+			BeforeEach(func() {
+				vmCtx.MoVM.Snapshot = &vimtypes.VirtualMachineSnapshotInfo{
+					CurrentSnapshot: &vimtypes.ManagedObjectReference{
+						Type:  "VirtualMachineSnapshot",
+						Value: "snapshot-2",
+					},
+					RootSnapshotList: []vimtypes.VirtualMachineSnapshotTree{
+						{
+							Name: "snap-1",
+							Snapshot: vimtypes.ManagedObjectReference{
+								Type:  "VirtualMachineSnapshot",
+								Value: "snapshot-1",
+							},
+							ChildSnapshotList: []vimtypes.VirtualMachineSnapshotTree{
+								{
+									Name: "snap-2",
+									Snapshot: vimtypes.ManagedObjectReference{
+										Type:  "VirtualMachineSnapshot",
+										Value: "snapshot-2",
+									},
+								},
+							},
+						},
+						{
+							Name: "snap-3",
+							Snapshot: vimtypes.ManagedObjectReference{
+								Type:  "VirtualMachineSnapshot",
+								Value: "snapshot-3",
+							},
+						},
+					},
+				}
+			})
+			It("sets the current and root snapshots", func() {
+				Expect(vmCtx.VM.Status.CurrentSnapshot).To(Equal(ptr.To(snapshotRef("snap-2"))))
+				Expect(vmCtx.VM.Status.RootSnapshots).To(Equal([]vmopv1common.LocalObjectRef{
+					snapshotRef("snap-1"),
+					snapshotRef("snap-3"),
+				}))
+			})
+		})
+	})
+
 	Context("Copies values to the VM status", func() {
 		biosUUID, instanceUUID := "f7c371d6-2003-5a48-9859-3bc9a8b0890", "6132d223-1566-5921-bc3b-df91ece09a4d"
 		BeforeEach(func() {
@@ -291,6 +291,76 @@ func (vs *vSphereVMProvider) UpdateContentLibraryItem(ctx context.Context, itemI
 	return contentLibraryProvider.UpdateLibraryItem(ctx, itemID, newName, newDescription)
 }
 
+func (vs *vSphereVMProvider) DeleteContentLibraryItem(ctx context.Context, itemID string) error {
+	log.V(4).Info("Delete Content Library Item", "itemID", itemID)
+
+	client, err := vs.getVcClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	contentLibraryProvider := contentlibrary.NewProvider(ctx, client.RestClient())
+	return contentLibraryProvider.DeleteLibraryItem(ctx, itemID)
+}
+
+// ImportVirtualMachineImage downloads the OVF or OVA at the source URL,
+// validates it, and streams it to the content library as a new library item.
+// The files are not stored locally. The ID of the new library item is
+// returned.
+//
+// An error that wraps contentlibrary.ErrInvalidOVF is returned if the OVF or
+// OVA is not valid.
+func (vs *vSphereVMProvider) ImportVirtualMachineImage(
+	ctx context.Context,
+	vmImport *vmopv1.VirtualMachineImageImportRequest,
+	cl *imgregv1a1.ContentLibrary,
+	sourceURL string) (string, error) {
+
+	logger := log.WithValues(
+		"vmImportName", fmt.Sprintf("%s/%s", vmImport.Namespace, vmImport.Name),
+		"clName", fmt.Sprintf("%s/%s", cl.Namespace, cl.Name))
+
+	// The URL of a PersistentVolumeClaim source is the source server Pod
+	// created by VM Operator, while any other URL is provided by the user and
+	// must not refer to an internal address. The files are pushed by VM
+	// Operator rather than pulled by vCenter, since vCenter can neither reach
+	// the source server Pod nor apply these address restrictions.
+	httpClient := contentlibrary.NewImportHTTPClient(
+		vmImport.Spec.Source.PersistentVolumeClaim == nil,
+		vmImport.Spec.Source.InsecureSkipTLSVerify)
+
+	logger.Info("Downloading OVF descriptor")
+	ovfEnvelope, src, err := contentlibrary.OpenOVF(ctx, httpClient, sourceURL)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	if err := contentlibrary.ValidateOvfEnvelope(ovfEnvelope); err != nil {
+		return "", err
+	}
+
+	client, err := vs.getVcClient(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get vCenter client: %w", err)
+	}
+
+	libraryItem := library.Item{
+		Name:      vmImport.Status.ItemName,
+		Type:      library.ItemTypeOVF,
+		LibraryID: string(cl.Spec.UUID),
+	}
+	if description := vmImport.Spec.Target.Item.Description; description != "" {
+		libraryItem.Description = &description
+	}
+
+	logger.Info("Uploading OVF", "itemName", libraryItem.Name)
+	contentLibraryProvider := contentlibrary.NewProvider(ctx, client.RestClient())
+	return contentLibraryProvider.ImportLibraryItem(ctx, libraryItem, src)
+}
+
 func (vs *vSphereVMProvider) getOpID(vm *vmopv1.VirtualMachine, operation string) string {
 	const charset = "0123456789abcdef"
 
@@ -5,6 +5,10 @@ package vsphere_test
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -19,8 +23,10 @@ import (
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers"
 	vsphere "github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/contentlibrary"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
+	"github.com/vmware-tanzu/vm-operator/test/testutil"
 )
 
 func cpuFreqTests() {
@@ -202,3 +208,124 @@ func syncVirtualMachineImageTests() {
 		})
 	})
 }
+
+func importVirtualMachineImageTests() {
+	var (
+		ctx        *builder.TestContextForVCSim
+		testConfig builder.VCSimTestConfig
+		vmProvider providers.VirtualMachineProviderInterface
+
+		files    map[string][]byte
+		server   *httptest.Server
+		cl       *imgregv1a1.ContentLibrary
+		vmImport *vmopv1.VirtualMachineImageImportRequest
+	)
+
+	BeforeEach(func() {
+		testConfig.WithContentLibrary = true
+		ctx = suite.NewTestContextForVCSim(testConfig)
+		vmProvider = vsphere.NewVSphereVMProviderFromClient(ctx, ctx.Client, ctx.Recorder)
+
+		ovfBytes, err := os.ReadFile(filepath.Join(
+			testutil.GetRootDirOrDie(),
+			"test", "builder", "testdata",
+			"images", "ttylinux-pc_i486-16.1.ovf"))
+		Expect(err).ToNot(HaveOccurred())
+
+		files = map[string][]byte{
+			"/ttylinux.ovf":                     ovfBytes,
+			"/ttylinux-pc_i486-16.1-disk1.vmdk": []byte("dummy-disk"),
+		}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, ok := files[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(b)
+		}))
+
+		cl = &imgregv1a1.ContentLibrary{
+			Spec: imgregv1a1.ContentLibrarySpec{
+				UUID: types.UID(ctx.ContentLibraryID),
+			},
+		}
+		vmImport = builder.DummyVirtualMachineImageImportRequest(
+			"dummy-import", "dummy-ns", server.URL+"/ttylinux.ovf", "dummy-cl")
+		vmImport.Status.ItemName = "imported-item"
+	})
+
+	AfterEach(func() {
+		server.Close()
+		ctx.AfterEach()
+	})
+
+	// The test server listens on a loopback address, which may only be used
+	// by the source server of a PersistentVolumeClaim.
+	usePVCSource := func() {
+		vmImport.Spec.Source.URL = ""
+		vmImport.Spec.Source.PersistentVolumeClaim = &vmopv1.VirtualMachineImageImportRequestSourcePersistentVolumeClaim{
+			ClaimName: "dummy-pvc",
+			Path:      "ttylinux.ovf",
+		}
+	}
+
+	It("should refuse to download a user-provided URL from an internal address", func() {
+		_, err := vmProvider.ImportVirtualMachineImage(ctx, vmImport, cl, vmImport.Spec.Source.URL)
+		Expect(err).To(MatchError(ContainSubstring("connecting to the address 127.0.0.1 is not allowed")))
+	})
+
+	It("should import the OVF into the content library", func() {
+		usePVCSource()
+		itemID, err := vmProvider.ImportVirtualMachineImage(ctx, vmImport, cl, server.URL+"/ttylinux.ovf")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(itemID).ToNot(BeEmpty())
+
+		item, err := vmProvider.GetItemFromLibraryByName(ctx, ctx.ContentLibraryID, "imported-item")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(item).ToNot(BeNil())
+		Expect(item.ID).To(Equal(itemID))
+		Expect(item.Type).To(Equal(library.ItemTypeOVF))
+	})
+
+	When("the OVF is not valid", func() {
+		BeforeEach(func() {
+			files["/ttylinux.ovf"] = []byte("not-xml")
+		})
+
+		It("should return an invalid OVF error", func() {
+			usePVCSource()
+			_, err := vmProvider.ImportVirtualMachineImage(ctx, vmImport, cl, server.URL+"/ttylinux.ovf")
+			Expect(err).To(MatchError(contentlibrary.ErrInvalidOVF))
+
+			item, err := vmProvider.GetItemFromLibraryByName(ctx, ctx.ContentLibraryID, "imported-item")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(item).To(BeNil())
+		})
+	})
+
+	When("the OVF cannot be downloaded", func() {
+		It("should return an error", func() {
+			usePVCSource()
+			_, err := vmProvider.ImportVirtualMachineImage(ctx, vmImport, cl, server.URL+"/does-not-exist.ovf")
+			Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+			Expect(err).ToNot(MatchError(contentlibrary.ErrInvalidOVF))
+		})
+	})
+
+	When("a file referenced by the OVF cannot be downloaded", func() {
+		BeforeEach(func() {
+			delete(files, "/ttylinux-pc_i486-16.1-disk1.vmdk")
+		})
+
+		It("should return an error and delete the library item", func() {
+			usePVCSource()
+			_, err := vmProvider.ImportVirtualMachineImage(ctx, vmImport, cl, server.URL+"/ttylinux.ovf")
+			Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+
+			item, err := vmProvider.GetItemFromLibraryByName(ctx, ctx.ContentLibraryID, "imported-item")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(item).To(BeNil())
+		})
+	})
+}
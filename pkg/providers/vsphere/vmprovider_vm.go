@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"maps"
 	"math/rand"
+	"strconv"
 	"strings"
 	"sync"
 	"text/template"
@@ -44,6 +45,7 @@ import (
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/annotations"
 	kubeutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
 	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmconfig"
 )
@@ -185,6 +187,60 @@ func (vs *vSphereVMProvider) PublishVirtualMachine(
 	return itemID, nil
 }
 
+func (vs *vSphereVMProvider) PublishVirtualMachineToOCIRegistry(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	vmPub *vmopv1.VirtualMachinePublishRequest,
+	opts oci.Options) (string, error) {
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(vm, "publishOCI")),
+		// Update logger info
+		Logger: log.WithValues("vmName", vm.NamespacedName()).
+			WithValues("vmPubName", fmt.Sprintf("%s/%s", vmPub.Namespace, vmPub.Name)),
+		VM: vm,
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get vCenter client: %w", err)
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return "", err
+	}
+
+	return virtualmachine.PushOVFToOCIRegistry(vmCtx, vcVM, vmPub, opts)
+}
+
+func (vs *vSphereVMProvider) ExportVirtualMachine(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	vmExport *vmopv1.VirtualMachineExport,
+	targetURL, token string) ([]string, error) {
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(vm, "export")),
+		// Update logger info
+		Logger: log.WithValues("vmName", vm.NamespacedName()).
+			WithValues("vmExportName", fmt.Sprintf("%s/%s", vmExport.Namespace, vmExport.Name)),
+		VM: vm,
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vCenter client: %w", err)
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return virtualmachine.ExportOVFToURL(vmCtx, vcVM, vm.Name, targetURL, token)
+}
+
 func (vs *vSphereVMProvider) GetVirtualMachineGuestHeartbeat(
 	ctx context.Context,
 	vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error) {
@@ -302,6 +358,56 @@ func (vs *vSphereVMProvider) GetVirtualMachineWebMKSTicket(
 	return ticket, nil
 }
 
+func (vs *vSphereVMProvider) GetVirtualMachineSerialConsoleTicket(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	pubKey, uuid string) (string, error) {
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(vm, "serialconsole")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName()),
+		VM:      vm,
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return "", err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return "", err
+	}
+
+	return virtualmachine.GetSerialConsoleTicket(vmCtx, vcVM, pubKey, uuid)
+}
+
+func (vs *vSphereVMProvider) RunVirtualMachineGuestOperation(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	op *vmopv1.VirtualMachineGuestOperation,
+	username, password string,
+	content []byte) error {
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(vm, "guestoperation")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName(), "guestOperation", op.Name),
+		VM:      vm,
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return err
+	}
+
+	return virtualmachine.RunGuestOperation(vmCtx, vcVM, op, username, password, content)
+}
+
 func (vs *vSphereVMProvider) GetVirtualMachineHardwareVersion(
 	ctx context.Context,
 	vm *vmopv1.VirtualMachine) (vimtypes.HardwareVersion, error) {
@@ -331,6 +437,119 @@ func (vs *vSphereVMProvider) GetVirtualMachineHardwareVersion(
 	return vimtypes.ParseHardwareVersion(o.Config.Version)
 }
 
+func (vs *vSphereVMProvider) CreateVirtualMachineSnapshot(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) (string, error) {
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(vm, "createSnapshot")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName(), "snapshotName", vmSnapshot.Name),
+		VM:      vm,
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return "", err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return "", err
+	}
+
+	snapRef, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, vmSnapshot)
+	if err != nil {
+		return "", err
+	}
+
+	return snapRef.Value, nil
+}
+
+func (vs *vSphereVMProvider) GetVirtualMachineSnapshotInfo(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine) (*vimtypes.VirtualMachineSnapshotInfo, error) {
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(vm, "getSnapshotInfo")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName()),
+		VM:      vm,
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return virtualmachine.GetSnapshotInfo(vmCtx, vcVM)
+}
+
+func (vs *vSphereVMProvider) RevertVirtualMachineToSnapshot(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(vm, "revertSnapshot")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName(), "snapshotName", vmSnapshot.Name),
+		VM:      vm,
+	}
+
+	if vmSnapshot.Status.UniqueID == "" {
+		return fmt.Errorf("snapshot %s has not been created", vmSnapshot.NamespacedName())
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return err
+	}
+
+	return virtualmachine.RevertToSnapshot(vmCtx, vcVM, vmSnapshot.Status.UniqueID)
+}
+
+func (vs *vSphereVMProvider) DeleteVirtualMachineSnapshot(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(vm, "deleteSnapshot")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName(), "snapshotName", vmSnapshot.Name),
+		VM:      vm,
+	}
+
+	if vmSnapshot.Status.UniqueID == "" {
+		// The snapshot was never created so there is nothing to delete.
+		return nil
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, false)
+	if err != nil {
+		return err
+	}
+	if vcVM == nil {
+		// The VM is gone and so are its snapshots.
+		return nil
+	}
+
+	return virtualmachine.DeleteSnapshot(vmCtx, vcVM, vmSnapshot.Status.UniqueID)
+}
+
 func (vs *vSphereVMProvider) vmCreatePathName(
 	vmCtx pkgctx.VirtualMachineContext,
 	vcClient *vcclient.Client,
@@ -447,6 +666,14 @@ func (vs *vSphereVMProvider) createVirtualMachine(
 	vmCtx.VM.Status.UniqueID = moRef.Reference().Value
 	conditions.MarkTrue(vmCtx.VM, vmopv1.VirtualMachineConditionCreated)
 
+	if pkgcfg.FromContext(vmCtx).Features.VMStorageClassChange {
+		// The VM's storage was placed with the storage class's policy.
+		if vmCtx.VM.Status.Storage == nil {
+			vmCtx.VM.Status.Storage = &vmopv1.VirtualMachineStorageStatus{}
+		}
+		vmCtx.VM.Status.Storage.StorageClass = vmCtx.VM.Spec.StorageClass
+	}
+
 	return object.NewVirtualMachine(vcClient.VimClient(), *moRef), createArgs, nil
 }
 
@@ -473,6 +700,7 @@ var VMUpdatePropertiesSelector = []string{
 	"layoutEx",
 	"resourcePool",
 	"runtime",
+	"snapshot",
 	"summary",
 }
 
@@ -501,6 +729,25 @@ func (vs *vSphereVMProvider) updateVirtualMachine(
 			return fmt.Errorf("VM doesn't have a resourcePool")
 		}
 
+		if pkgcfg.FromContext(vmCtx).Features.VMRelocate {
+			relocated, err := vs.vmRelocateIfNeeded(vmCtx, vcVM, vcClient)
+			if err != nil {
+				return err
+			}
+			if relocated {
+				// The VM's resourcePool and host have changed.
+				vmCtx.MoVM = mo.VirtualMachine{}
+				if err := vcVM.Properties(
+					vmCtx,
+					vcVM.Reference(),
+					VMUpdatePropertiesSelector,
+					&vmCtx.MoVM); err != nil {
+
+					return err
+				}
+			}
+		}
+
 		clusterMoRef, err := vcenter.GetResourcePoolOwnerMoRef(
 			vmCtx,
 			vcVM.Client(),
@@ -509,6 +756,44 @@ func (vs *vSphereVMProvider) updateVirtualMachine(
 			return err
 		}
 
+		if pkgcfg.FromContext(vmCtx).Features.VMStorageClassChange {
+			relocated, err := vs.vmStorageRelocateIfNeeded(vmCtx, vcVM, vcClient, clusterMoRef)
+			if err != nil {
+				return err
+			}
+			if relocated {
+				// The VM's files and disks have moved.
+				vmCtx.MoVM = mo.VirtualMachine{}
+				if err := vcVM.Properties(
+					vmCtx,
+					vcVM.Reference(),
+					VMUpdatePropertiesSelector,
+					&vmCtx.MoVM); err != nil {
+
+					return err
+				}
+			}
+		}
+
+		if pkgcfg.FromContext(vmCtx).Features.VMRebuild {
+			rebuilt, err := vs.vmRebuildIfNeeded(vmCtx, vcVM, vcClient)
+			if rebuilt {
+				// The VM's disks and power state have changed.
+				vmCtx.MoVM = mo.VirtualMachine{}
+				if err := vcVM.Properties(
+					vmCtx,
+					vcVM.Reference(),
+					VMUpdatePropertiesSelector,
+					&vmCtx.MoVM); err != nil {
+
+					return err
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+
 		ses := &session.Session{
 			K8sClient:    vs.k8sClient,
 			Client:       vcClient.Client,
@@ -832,6 +1117,12 @@ func (vs *vSphereVMProvider) vmCreateGetPrereqs(
 		prereqErrs = append(prereqErrs, err)
 	}
 
+	if pkgcfg.FromContext(vmCtx).Features.VMClone {
+		if err := vs.vmCreateGetCloneSource(vmCtx, createArgs); err != nil {
+			prereqErrs = append(prereqErrs, err)
+		}
+	}
+
 	if err := vs.vmCreateGetSetResourcePolicy(vmCtx, createArgs); err != nil {
 		prereqErrs = append(prereqErrs, err)
 	}
@@ -919,6 +1210,35 @@ func (vs *vSphereVMProvider) vmCreateGetVirtualMachineImage(
 	return nil
 }
 
+// vmCreateGetCloneSource sets the VM to clone when the VM was created by a
+// VirtualMachineClone. This must be called after the image has been resolved
+// since the clone source takes precedence over the image.
+func (vs *vSphereVMProvider) vmCreateGetCloneSource(
+	vmCtx pkgctx.VirtualMachineContext,
+	createArgs *VMCreateArgs) error {
+
+	sourceName := vmCtx.VM.Annotations[vmopv1.CloneSourceAnnotation]
+	if sourceName == "" {
+		return nil
+	}
+
+	sourceVM := &vmopv1.VirtualMachine{}
+	key := ctrlclient.ObjectKey{Namespace: vmCtx.VM.Namespace, Name: sourceName}
+	if err := vs.k8sClient.Get(vmCtx, key, sourceVM); err != nil {
+		return fmt.Errorf("failed to get clone source VM %s: %w", sourceName, err)
+	}
+
+	if sourceVM.Status.UniqueID == "" {
+		return fmt.Errorf("clone source VM %s has not been created", sourceName)
+	}
+
+	createArgs.UseContentLibrary = false
+	createArgs.CloneSourceMoID = sourceVM.Status.UniqueID
+	createArgs.LinkedClone = vmCtx.VM.Annotations[vmopv1.CloneTypeAnnotation] == string(vmopv1.VirtualMachineCloneTypeLinked)
+
+	return nil
+}
+
 func (vs *vSphereVMProvider) vmCreateGetSetResourcePolicy(
 	vmCtx pkgctx.VirtualMachineContext,
 	createArgs *VMCreateArgs) error {
@@ -1156,6 +1476,16 @@ func (vs *vSphereVMProvider) vmCreateGenConfigSpecExtraConfig(
 		}
 	}
 
+	// Record the image a VM deployed from a library item was deployed from,
+	// and how many of its classic disks came from the image, so the VM can
+	// later be rebuilt from a new image.
+	if image := vmCtx.VM.Spec.Image; image != nil && createArgs.UseContentLibrary {
+		ecMap[ExtraConfigKeyImage] = image.Kind + "/" + image.Name
+		if n := len(createArgs.ImageStatus.Disks); n > 0 {
+			ecMap[ExtraConfigKeyImageDisks] = strconv.Itoa(n)
+		}
+	}
+
 	// The ConfigSpec's current ExtraConfig values (that came from the class)
 	// take precedence over what was set here.
 	createArgs.ConfigSpec.ExtraConfig = pkgutil.OptionValues(
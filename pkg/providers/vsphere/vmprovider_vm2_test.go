@@ -12,6 +12,7 @@ import (
 	"github.com/vmware/govmomi/vim25/mo"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -254,6 +255,92 @@ func vmE2ETests() {
 				})
 			})
 		})
+
+		Context("Removed interfaces", func() {
+
+			const removedInterfaceName = "eth1"
+
+			var removedNetInterface *netopv1alpha1.NetworkInterface
+
+			simulateNetOPReconcile := func() {
+				netInterface := &netopv1alpha1.NetworkInterface{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      network.NetOPCRName(vm.Name, networkName, interfaceName, false),
+						Namespace: vm.Namespace,
+					},
+				}
+				ExpectWithOffset(1, ctx.Client.Get(ctx, client.ObjectKeyFromObject(netInterface), netInterface)).To(Succeed())
+
+				netInterface.Status.NetworkID = ctx.NetworkRef.Reference().Value
+				netInterface.Status.Conditions = []netopv1alpha1.NetworkInterfaceCondition{
+					{
+						Type:   netopv1alpha1.NetworkInterfaceReady,
+						Status: corev1.ConditionTrue,
+					},
+				}
+				ExpectWithOffset(1, ctx.Client.Status().Update(ctx, netInterface)).To(Succeed())
+			}
+
+			JustBeforeEach(func() {
+				vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+
+				err := vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("network interface is not ready yet"))
+
+				simulateNetOPReconcile()
+
+				Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+				Expect(vm.Status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+
+				// The CR of an interface that was removed from the spec.
+				removedNetInterface = &netopv1alpha1.NetworkInterface{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      network.NetOPCRName(vm.Name, networkName, removedInterfaceName, false),
+						Namespace: vm.Namespace,
+						Labels:    map[string]string{network.VMNameLabel: vm.Name},
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: vmopv1.GroupVersion.String(),
+								Kind:       "VirtualMachine",
+								Name:       vm.Name,
+								UID:        vm.UID,
+							},
+						},
+					},
+				}
+				Expect(ctx.Client.Create(ctx, removedNetInterface)).To(Succeed())
+			})
+
+			It("Deletes the CR when a powered off VM is updated", func() {
+				pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+					config.Features.VMNetworkHotPlug = true
+				})
+
+				Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+				Expect(vm.Status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+
+				err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(removedNetInterface), removedNetInterface)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("Keeps the CR when a powered off VM is updated without network hot plug", func() {
+				Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+				Expect(vm.Status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+
+				Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(removedNetInterface), removedNetInterface)).To(Succeed())
+			})
+
+			It("Deletes the CR when the VM is powered on", func() {
+				vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+
+				Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+				Expect(vm.Status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+
+				err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(removedNetInterface), removedNetInterface)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
 	})
 
 	Context("NSX-T", func() {
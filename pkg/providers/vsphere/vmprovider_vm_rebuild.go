@@ -0,0 +1,508 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	vcclient "github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/client"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/instancestorage"
+	res "github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/resources"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/vmlifecycle"
+	spqutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube/spq"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+const (
+	// ExtraConfigKeyImage is the name of the key in a VM's ExtraConfig that
+	// records the kind and name, as "<kind>/<name>", of the image the VM was
+	// deployed or last rebuilt from.
+	ExtraConfigKeyImage = "vmservice.image"
+
+	// ExtraConfigKeyImageDisks is the name of the key in a VM's ExtraConfig
+	// that records the number of the VM's classic disks that came from the
+	// image the VM was deployed or last rebuilt from.
+	ExtraConfigKeyImageDisks = "vmservice.imageDisks"
+
+	// rebuildVMNameSuffix is the suffix of the name of the temporary VM that
+	// is deployed from the new image when a VM is rebuilt.
+	rebuildVMNameSuffix = "-rebuild"
+
+	// rebuildRetryDelay is how long to wait before retrying a failed rebuild.
+	rebuildRetryDelay = 5 * time.Minute
+)
+
+// vmRebuildIfNeeded rebuilds the VM from the image in spec.image when it
+// differs from the image in status.image. The VM's classic disks are replaced
+// with the disks of a temporary VM deployed from the new image, so the VM's
+// UUIDs, network interfaces and PVC volumes are preserved, and the bootstrap
+// provider is run again when the VM is next powered on. A VM with classic
+// disks that did not come from its image is not rebuilt. The result of the
+// rebuild is reflected in the VM's VirtualMachineRebuilt condition, and a
+// failed rebuild is not retried until rebuildRetryDelay has passed. True is
+// returned if the VM was rebuilt.
+func (vs *vSphereVMProvider) vmRebuildIfNeeded(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	vcClient *vcclient.Client) (bool, error) {
+
+	image := vmCtx.VM.Spec.Image
+	if image == nil {
+		return false, nil
+	}
+
+	if vmCtx.VM.Status.Image == nil {
+		vmCtx.VM.Status.Image = getExtraConfigImage(vmCtx.MoVM)
+		if vmCtx.VM.Status.Image == nil {
+			// The VM predates recording the image in its ExtraConfig. The
+			// webhook does not allow spec.image to change until status.image
+			// is set, so the VM was deployed from the image in spec.image.
+			vmCtx.VM.Status.Image = imageToStatusRef(*image)
+			return false, nil
+		}
+	}
+
+	deployedImage := vmCtx.VM.Status.Image
+	if deployedImage.Kind == image.Kind && deployedImage.Name == image.Name {
+		return false, nil
+	}
+
+	if c := conditions.Get(vmCtx.VM, vmopv1.VirtualMachineRebuiltCondition); c != nil &&
+		c.Status == metav1.ConditionFalse && c.Reason == vmopv1.VirtualMachineRebuildFailedReason {
+
+		// Leave the VM as it is, likely powered off, instead of cycling its
+		// power state on every reconcile until the failure is resolved.
+		if d := rebuildRetryDelay - time.Since(c.LastTransitionTime.Time); d > 0 {
+			return false, fmt.Errorf("waiting %s to retry failed rebuild: %s", d.Round(time.Second), c.Message)
+		}
+	}
+
+	// Each attempt transitions the condition so its last transition time is
+	// the time of the most recent attempt.
+	conditions.MarkFalse(
+		vmCtx.VM,
+		vmopv1.VirtualMachineRebuiltCondition,
+		vmopv1.VirtualMachineRebuildInProgressReason,
+		"Rebuilding VM from image %s", image.Name)
+
+	markFailed := func(reason string, err error) error {
+		conditions.MarkFalse(vmCtx.VM, vmopv1.VirtualMachineRebuiltCondition, reason, err.Error())
+		return err
+	}
+
+	if instancestorage.IsPresent(vmCtx.VM) {
+		return false, markFailed(
+			vmopv1.VirtualMachineRebuildFailedReason,
+			fmt.Errorf("cannot rebuild VM with instance storage"))
+	}
+
+	if vmCtx.MoVM.Snapshot != nil {
+		return false, markFailed(
+			vmopv1.VirtualMachineRebuildFailedReason,
+			fmt.Errorf("cannot rebuild VM with snapshots"))
+	}
+
+	if vmCtx.MoVM.Config == nil {
+		return false, fmt.Errorf("VM config is not available")
+	}
+
+	imageDisks, err := vs.vmRebuildGetImageDisks(vmCtx)
+	if err != nil {
+		return false, markFailed(vmopv1.VirtualMachineRebuildFailedReason, err)
+	}
+	if disks := selectClassicDisks(object.VirtualDeviceList(vmCtx.MoVM.Config.Hardware.Device)); len(disks) > imageDisks {
+		// Replacing the disks would destroy the data on the disks that were
+		// added to the VM after it was deployed.
+		return false, markFailed(
+			vmopv1.VirtualMachineRebuildFailedReason,
+			fmt.Errorf("cannot rebuild VM with %d classic disks that are not part of image %s",
+				len(disks)-imageDisks, deployedImage.Name))
+	}
+
+	createArgs := &VMCreateArgs{}
+	if err := vs.vmCreateGetVirtualMachineImage(vmCtx, createArgs); err != nil {
+		return false, markFailed(vmopv1.VirtualMachineRebuildImageNotSupportedReason, err)
+	}
+
+	if err := vs.vmRebuildGetCreateArgs(vmCtx, vcVM, vcClient, createArgs); err != nil {
+		return false, markFailed(vmopv1.VirtualMachineRebuildFailedReason, err)
+	}
+
+	if vmCtx.MoVM.Runtime.PowerState == vimtypes.VirtualMachinePowerStatePoweredOn {
+		// Powering off the VM disrupts it just like a user requested power
+		// off, so wait until its disruption budgets allow it. This is not
+		// a failed rebuild, so it is retried without the backoff.
+		if err := vmopv1util.CheckDisruptionAllowed(vmCtx, vs.k8sClient, vmCtx.VM); err != nil {
+			conditions.MarkFalse(
+				vmCtx.VM,
+				vmopv1.VirtualMachineRebuiltCondition,
+				vmopv1.VirtualMachineRebuildInProgressReason,
+				"Waiting to power off VM: %v", err)
+			return false, err
+		}
+
+		vmCtx.Logger.Info("Powering off VM to rebuild it")
+		if err := res.NewVMFromObject(vcVM).SetPowerState(
+			vmCtx,
+			vmopv1.VirtualMachinePowerStateOn,
+			vmopv1.VirtualMachinePowerStateOff,
+			vmCtx.VM.Spec.PowerOffMode); err != nil {
+
+			return false, markFailed(vmopv1.VirtualMachineRebuildFailedReason, err)
+		}
+	}
+
+	if err := vs.vmRebuild(vmCtx, vcVM, vcClient, createArgs); err != nil {
+		return true, markFailed(vmopv1.VirtualMachineRebuildFailedReason, err)
+	}
+
+	vmCtx.VM.Status.Image = imageToStatusRef(*image)
+	conditions.MarkTrue(vmCtx.VM, vmopv1.VirtualMachineRebuiltCondition)
+
+	// The guest has not yet been booted from the new boot disk.
+	delete(vmCtx.VM.Annotations, vmopv1.FirstBootDoneAnnotation)
+
+	return true, nil
+}
+
+// vmRebuildGetImageDisks returns the number of the VM's classic disks that
+// came from the image the VM was deployed or last rebuilt from.
+func (vs *vSphereVMProvider) vmRebuildGetImageDisks(vmCtx pkgctx.VirtualMachineContext) (int, error) {
+	if v, ok := object.OptionValueList(vmCtx.MoVM.Config.ExtraConfig).GetString(ExtraConfigKeyImageDisks); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			return n, nil
+		}
+	}
+
+	// Fall back to the disks of the image in status.image.
+	var (
+		deployedImage = vmCtx.VM.Status.Image
+		key           = ctrlclient.ObjectKey{Name: deployedImage.Name}
+		imageStatus   vmopv1.VirtualMachineImageStatus
+	)
+
+	switch deployedImage.Kind {
+	case "VirtualMachineImage":
+		var img vmopv1.VirtualMachineImage
+		key.Namespace = vmCtx.VM.Namespace
+		if err := vs.k8sClient.Get(vmCtx, key, &img); err != nil {
+			return 0, fmt.Errorf("failed to get deployed image %s: %w", deployedImage.Name, err)
+		}
+		imageStatus = img.Status
+	case "ClusterVirtualMachineImage":
+		var img vmopv1.ClusterVirtualMachineImage
+		if err := vs.k8sClient.Get(vmCtx, key, &img); err != nil {
+			return 0, fmt.Errorf("failed to get deployed image %s: %w", deployedImage.Name, err)
+		}
+		imageStatus = img.Status
+	default:
+		return 0, fmt.Errorf("unsupported deployed image kind %q", deployedImage.Kind)
+	}
+
+	if len(imageStatus.Disks) == 0 {
+		return 0, fmt.Errorf("cannot determine the disks of deployed image %s", deployedImage.Name)
+	}
+
+	return len(imageStatus.Disks), nil
+}
+
+func (vs *vSphereVMProvider) vmRebuildGetCreateArgs(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	vcClient *vcclient.Client,
+	createArgs *VMCreateArgs) error {
+
+	var o mo.VirtualMachine
+	if err := vcVM.Properties(vmCtx, vcVM.Reference(), []string{"parent"}, &o); err != nil {
+		return err
+	}
+	if o.Parent == nil {
+		return fmt.Errorf("VM doesn't have a parent folder")
+	}
+
+	createArgs.ConfigSpec = vimtypes.VirtualMachineConfigSpec{
+		Name: vmCtx.VM.Name + rebuildVMNameSuffix,
+	}
+	createArgs.FolderMoID = o.Parent.Value
+	createArgs.ResourcePoolMoID = vmCtx.MoVM.ResourcePool.Value
+	if host := vmCtx.MoVM.Runtime.Host; host != nil {
+		createArgs.HostMoID = host.Value
+	}
+
+	if storageClass := vmCtx.VM.Spec.StorageClass; storageClass != "" {
+		profileID, err := spqutil.GetStoragePolicyIDFromClass(vmCtx, vs.k8sClient, storageClass)
+		if err != nil {
+			return fmt.Errorf("failed to get storage policy ID for storage class %s: %w", storageClass, err)
+		}
+		createArgs.StorageProfileID = profileID
+	} else if datastore := vmCtx.MoVM.Config.DatastoreUrl; len(datastore) > 0 {
+		ds, err := vcClient.Finder().Datastore(vmCtx, datastore[0].Name)
+		if err != nil {
+			return err
+		}
+		createArgs.DatastoreMoID = ds.Reference().Value
+	}
+
+	return nil
+}
+
+// vmRebuild replaces the VM's classic disks with the disks of a temporary VM
+// deployed from the new image. The temporary VM's disks are moved into the
+// VM's directory so they are not deleted along with the temporary VM. If the
+// disks cannot be replaced, the moved disks are deleted and the VM is left
+// with its old disks.
+func (vs *vSphereVMProvider) vmRebuild(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	vcClient *vcclient.Client,
+	createArgs *VMCreateArgs) (retErr error) {
+
+	tmpVMName := createArgs.ConfigSpec.Name
+
+	// Remove the temporary VM left behind by a previous, failed rebuild.
+	if err := destroyVMInFolder(vmCtx, vcClient, createArgs.FolderMoID, tmpVMName); err != nil {
+		return err
+	}
+
+	tmpVMCtx := vmCtx
+	tmpVMCtx.VM = vmCtx.VM.DeepCopy()
+	tmpVMCtx.VM.Name = tmpVMName
+	tmpVMCtx.Logger = vmCtx.Logger.WithValues("rebuildVMName", tmpVMName)
+
+	moRef, err := vmlifecycle.CreateVirtualMachine(
+		tmpVMCtx,
+		vcClient.RestClient(),
+		vcClient.VimClient(),
+		vcClient.Finder(),
+		&createArgs.CreateArgs)
+	if err != nil {
+		return fmt.Errorf("failed to deploy image %s: %w", vmCtx.VM.Spec.Image.Name, err)
+	}
+
+	tmpVM := object.NewVirtualMachine(vcClient.VimClient(), *moRef)
+	defer func() {
+		if err := destroyVM(vmCtx, tmpVM); err != nil {
+			vmCtx.Logger.Error(err, "Failed to destroy temporary rebuild VM", "rebuildVMName", tmpVMName)
+		}
+	}()
+
+	tmpDevices, err := tmpVM.Device(vmCtx)
+	if err != nil {
+		return err
+	}
+	newDisks := selectClassicDisks(tmpDevices)
+	if len(newDisks) == 0 {
+		return fmt.Errorf("image %s does not have any disks", vmCtx.VM.Spec.Image.Name)
+	}
+
+	// Detach the disks from the temporary VM without deleting them.
+	var detachChanges []vimtypes.BaseVirtualDeviceConfigSpec
+	for _, disk := range newDisks {
+		detachChanges = append(detachChanges, &vimtypes.VirtualDeviceConfigSpec{
+			Operation: vimtypes.VirtualDeviceConfigSpecOperationRemove,
+			Device:    disk,
+		})
+	}
+	if _, err := res.NewVMFromObject(tmpVM).Reconfigure(
+		vmCtx,
+		&vimtypes.VirtualMachineConfigSpec{DeviceChange: detachChanges}); err != nil {
+
+		return fmt.Errorf("failed to detach disks from temporary rebuild VM: %w", err)
+	}
+
+	var vmPath object.DatastorePath
+	if !vmPath.FromString(vmCtx.MoVM.Config.Files.VmPathName) {
+		return fmt.Errorf("failed to parse VM path %q", vmCtx.MoVM.Config.Files.VmPathName)
+	}
+
+	diskManager := object.NewVirtualDiskManager(vcClient.VimClient())
+	oldDisks := selectClassicDisks(object.VirtualDeviceList(vmCtx.MoVM.Config.Hardware.Device))
+
+	// The moved disks are not attached to any VM until the VM is
+	// reconfigured, so they would be leaked if the rebuild fails.
+	var movedDisks []string
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		for _, name := range movedDisks {
+			task, err := diskManager.DeleteVirtualDisk(vmCtx, name, vcClient.Datacenter())
+			if err == nil {
+				err = task.Wait(vmCtx)
+			}
+			if err != nil {
+				vmCtx.Logger.Error(err, "Failed to delete moved rebuild disk", "disk", name)
+			}
+		}
+	}()
+
+	var deviceChanges []vimtypes.BaseVirtualDeviceConfigSpec
+	for _, disk := range oldDisks {
+		deviceChanges = append(deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
+			Operation:     vimtypes.VirtualDeviceConfigSpecOperationRemove,
+			FileOperation: vimtypes.VirtualDeviceConfigSpecFileOperationDestroy,
+			Device:        disk,
+		})
+	}
+
+	for i, disk := range newDisks {
+		backing, ok := disk.Backing.(*vimtypes.VirtualDiskFlatVer2BackingInfo)
+		if !ok {
+			return fmt.Errorf("unsupported disk backing %T", disk.Backing)
+		}
+
+		// The generation is part of the name so the moved disk does not
+		// collide with a disk moved by a previous rebuild.
+		dstPath := object.DatastorePath{
+			Datastore: vmPath.Datastore,
+			Path:      path.Join(path.Dir(vmPath.Path), fmt.Sprintf("%s-%d-%d.vmdk", vmCtx.VM.Name, vmCtx.VM.Generation, i)),
+		}
+
+		task, err := diskManager.MoveVirtualDisk(
+			vmCtx,
+			backing.FileName, vcClient.Datacenter(),
+			dstPath.String(), vcClient.Datacenter(),
+			true)
+		if err == nil {
+			err = task.Wait(vmCtx)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to move disk %s: %w", backing.FileName, err)
+		}
+		movedDisks = append(movedDisks, dstPath.String())
+
+		newBacking := *backing
+		newBacking.FileName = dstPath.String()
+		newBacking.Datastore = nil
+		newBacking.Uuid = ""
+
+		newDisk := &vimtypes.VirtualDisk{
+			VirtualDevice: vimtypes.VirtualDevice{
+				Key:     int32(-200 - i),
+				Backing: &newBacking,
+			},
+			CapacityInKB:    disk.CapacityInKB,
+			CapacityInBytes: disk.CapacityInBytes,
+		}
+
+		// Attach the new disks where the old disks were attached so the new
+		// boot disk is still the VM's boot device.
+		if i < len(oldDisks) {
+			newDisk.ControllerKey = oldDisks[i].ControllerKey
+			newDisk.UnitNumber = oldDisks[i].UnitNumber
+		} else if len(oldDisks) > 0 {
+			newDisk.ControllerKey = oldDisks[0].ControllerKey
+		}
+
+		deviceChange := &vimtypes.VirtualDeviceConfigSpec{
+			Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+			Device:    newDisk,
+		}
+		if createArgs.StorageProfileID != "" {
+			deviceChange.Profile = []vimtypes.BaseVirtualMachineProfileSpec{
+				&vimtypes.VirtualMachineDefinedProfileSpec{ProfileId: createArgs.StorageProfileID},
+			}
+		}
+		deviceChanges = append(deviceChanges, deviceChange)
+	}
+
+	vmCtx.Logger.Info("Replacing VM disks with disks from image",
+		"image", vmCtx.VM.Spec.Image.Name,
+		"oldDisks", len(oldDisks),
+		"newDisks", len(newDisks))
+
+	image := vmCtx.VM.Spec.Image
+	configSpec := &vimtypes.VirtualMachineConfigSpec{
+		DeviceChange: deviceChanges,
+		ExtraConfig: []vimtypes.BaseOptionValue{
+			&vimtypes.OptionValue{
+				Key:   ExtraConfigKeyImage,
+				Value: image.Kind + "/" + image.Name,
+			},
+			&vimtypes.OptionValue{
+				Key:   ExtraConfigKeyImageDisks,
+				Value: strconv.Itoa(len(newDisks)),
+			},
+		},
+	}
+
+	if _, err := res.NewVMFromObject(vcVM).Reconfigure(vmCtx, configSpec); err != nil {
+		return fmt.Errorf("failed to replace VM disks: %w", err)
+	}
+
+	return nil
+}
+
+func selectClassicDisks(devices object.VirtualDeviceList) []*vimtypes.VirtualDisk {
+	var disks []*vimtypes.VirtualDisk
+	for _, device := range devices {
+		// The FCDs that back PVCs are not part of the image.
+		if disk, ok := device.(*vimtypes.VirtualDisk); ok && disk.VDiskId == nil {
+			disks = append(disks, disk)
+		}
+	}
+	return disks
+}
+
+func destroyVMInFolder(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcClient *vcclient.Client,
+	folderMoID, name string) error {
+
+	folder := object.NewFolder(vcClient.VimClient(), vimtypes.ManagedObjectReference{Type: "Folder", Value: folderMoID})
+	ref, err := object.NewSearchIndex(vcClient.VimClient()).FindChild(vmCtx, folder, name)
+	if err != nil {
+		return err
+	}
+	vm, ok := ref.(*object.VirtualMachine)
+	if !ok {
+		return nil
+	}
+
+	return destroyVM(vmCtx, vm)
+}
+
+func destroyVM(vmCtx pkgctx.VirtualMachineContext, vm *object.VirtualMachine) error {
+	task, err := vm.Destroy(vmCtx)
+	if err != nil {
+		return err
+	}
+	return task.Wait(vmCtx)
+}
+
+// getExtraConfigImage returns the image recorded in the VM's ExtraConfig, or
+// nil if no image is recorded.
+func getExtraConfigImage(moVM mo.VirtualMachine) *vmopv1common.LocalObjectRef {
+	if moVM.Config == nil {
+		return nil
+	}
+	v, _ := object.OptionValueList(moVM.Config.ExtraConfig).GetString(ExtraConfigKeyImage)
+	kind, name, ok := strings.Cut(v, "/")
+	if !ok || kind == "" || name == "" {
+		return nil
+	}
+	return imageToStatusRef(vmopv1.VirtualMachineImageRef{Kind: kind, Name: name})
+}
+
+func imageToStatusRef(image vmopv1.VirtualMachineImageRef) *vmopv1common.LocalObjectRef {
+	return &vmopv1common.LocalObjectRef{
+		APIVersion: vmopv1.GroupVersion.String(),
+		Kind:       image.Kind,
+		Name:       image.Name,
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vmware/govmomi/object"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	vcclient "github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/client"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/instancestorage"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/placement"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/vcenter"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+)
+
+// ExtraConfigKeyLastRelocateTime is the name of the key in a VM's ExtraConfig
+// that records the value of spec.nextRelocateTime when the VM was last
+// relocated on request.
+const ExtraConfigKeyLastRelocateTime = "vmservice.lastRelocateTime"
+
+// vmRelocateIfNeeded relocates the VM when the VM's ResourcePool is not in the
+// zone assigned to the VM, or when a relocation has been requested with
+// spec.nextRelocateTime. The placement code selects the ResourcePool and host
+// the VM is relocated to, and the result of the relocation is reflected in the
+// VM's VirtualMachineRelocated condition. True is returned if the VM was
+// relocated.
+func (vs *vSphereVMProvider) vmRelocateIfNeeded(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	vcClient *vcclient.Client) (bool, error) {
+
+	zoneName := vmCtx.VM.Labels[topology.KubernetesTopologyZoneLabelKey]
+	if zoneName == "" || vmCtx.MoVM.ResourcePool == nil {
+		return false, nil
+	}
+
+	var childRPName string
+	resourcePolicy, err := GetVMSetResourcePolicy(vmCtx, vs.k8sClient)
+	if err != nil {
+		return false, err
+	}
+	if resourcePolicy != nil {
+		childRPName = resourcePolicy.Spec.ResourcePool.Name
+	}
+
+	inZone, err := placement.IsResourcePoolInZone(
+		vmCtx,
+		vs.k8sClient,
+		vcClient.VimClient(),
+		childRPName,
+		vmCtx.MoVM.ResourcePool.Value)
+	if err != nil {
+		return false, err
+	}
+
+	var lastRelocateTime string
+	if vmCtx.MoVM.Config != nil {
+		lastRelocateTime, _ = object.OptionValueList(vmCtx.MoVM.Config.ExtraConfig).
+			GetString(ExtraConfigKeyLastRelocateTime)
+	}
+	nextRelocateTime := vmCtx.VM.Spec.NextRelocateTime
+	relocateRequested := nextRelocateTime != "" && !strings.EqualFold(nextRelocateTime, "now") &&
+		nextRelocateTime != lastRelocateTime
+
+	if inZone && !relocateRequested {
+		return false, nil
+	}
+
+	if instancestorage.IsPresent(vmCtx.VM) {
+		err := fmt.Errorf("cannot relocate VM with instance storage")
+		conditions.MarkFalse(
+			vmCtx.VM,
+			vmopv1.VirtualMachineRelocatedCondition,
+			vmopv1.VirtualMachineRelocateFailedReason,
+			err.Error())
+		return false, err
+	}
+
+	var currentHostMoRef *vimtypes.ManagedObjectReference
+	if inZone {
+		// The VM is relocated within its zone, so do not select the host the
+		// VM is already on.
+		currentHostMoRef = vmCtx.MoVM.Runtime.Host
+	}
+
+	result, err := placement.PlaceVMForRelocate(
+		vmCtx,
+		vs.k8sClient,
+		vcClient.VimClient(),
+		placement.Constraints{ChildRPName: childRPName},
+		currentHostMoRef)
+	if err != nil {
+		conditions.MarkFalse(
+			vmCtx.VM,
+			vmopv1.VirtualMachineRelocatedCondition,
+			vmopv1.VirtualMachineRelocatePlacementFailedReason,
+			err.Error())
+		return false, fmt.Errorf("failed to place VM for relocate: %w", err)
+	}
+
+	ethCardDeviceChanges, err := vs.vmRelocateEthCardDeviceChanges(vmCtx, vcClient, result.PoolMoRef)
+	if err != nil {
+		conditions.MarkFalse(
+			vmCtx.VM,
+			vmopv1.VirtualMachineRelocatedCondition,
+			vmopv1.VirtualMachineRelocateFailedReason,
+			err.Error())
+		return false, err
+	}
+
+	vmCtx.Logger.Info("Relocating VM",
+		"zone", zoneName,
+		"resourcePool", result.PoolMoRef.Value,
+		"host", result.HostMoRef,
+		"datastore", result.DatastoreMoRef)
+
+	relocateSpec := vimtypes.VirtualMachineRelocateSpec{
+		Pool:         &result.PoolMoRef,
+		Host:         result.HostMoRef,
+		Datastore:    result.DatastoreMoRef,
+		Disk:         result.Disks,
+		DeviceChange: ethCardDeviceChanges,
+	}
+
+	task, err := vcVM.Relocate(vmCtx, relocateSpec, vimtypes.VirtualMachineMovePriorityDefaultPriority)
+	if err == nil {
+		err = task.Wait(vmCtx)
+	}
+	if err != nil {
+		conditions.MarkFalse(
+			vmCtx.VM,
+			vmopv1.VirtualMachineRelocatedCondition,
+			vmopv1.VirtualMachineRelocateFailedReason,
+			err.Error())
+		return false, fmt.Errorf("failed to relocate VM: %w", err)
+	}
+
+	if relocateRequested {
+		// Record that the requested relocation is complete so the VM is not
+		// relocated again.
+		configSpec := vimtypes.VirtualMachineConfigSpec{
+			ExtraConfig: []vimtypes.BaseOptionValue{
+				&vimtypes.OptionValue{
+					Key:   ExtraConfigKeyLastRelocateTime,
+					Value: nextRelocateTime,
+				},
+			},
+		}
+
+		task, err := vcVM.Reconfigure(vmCtx, configSpec)
+		if err == nil {
+			err = task.Wait(vmCtx)
+		}
+		if err != nil {
+			return true, fmt.Errorf("failed to record last relocate time: %w", err)
+		}
+	}
+
+	conditions.MarkTrue(vmCtx.VM, vmopv1.VirtualMachineRelocatedCondition)
+
+	return true, nil
+}
+
+// vmRelocateEthCardDeviceChanges returns the device changes that update the
+// backings of the VM's ethernet cards to the networks of the cluster that
+// owns the ResourcePool the VM is relocated to. No changes are returned when
+// the VM stays in its current cluster.
+func (vs *vSphereVMProvider) vmRelocateEthCardDeviceChanges(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcClient *vcclient.Client,
+	poolMoRef vimtypes.ManagedObjectReference) ([]vimtypes.BaseVirtualDeviceConfigSpec, error) {
+
+	networkSpec := vmCtx.VM.Spec.Network
+	if networkSpec == nil || networkSpec.Disabled || vmCtx.MoVM.Config == nil {
+		return nil, nil
+	}
+
+	clusterMoRef, err := vcenter.GetResourcePoolOwnerMoRef(vmCtx, vcClient.VimClient(), poolMoRef.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	currentClusterMoRef, err := vcenter.GetResourcePoolOwnerMoRef(vmCtx, vcClient.VimClient(), vmCtx.MoVM.ResourcePool.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	if clusterMoRef == currentClusterMoRef {
+		return nil, nil
+	}
+
+	results, err := network.CreateAndWaitForNetworkInterfaces(
+		vmCtx,
+		vs.k8sClient,
+		vcClient.VimClient(),
+		vcClient.Finder(),
+		&clusterMoRef,
+		networkSpec.Interfaces)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network interfaces for relocate: %w", err)
+	}
+
+	ethCards := object.VirtualDeviceList(vmCtx.MoVM.Config.Hardware.Device).
+		SelectByType((*vimtypes.VirtualEthernetCard)(nil))
+	if len(ethCards) != len(results.Results) {
+		return nil, fmt.Errorf("VM has %d ethernet cards but %d network interfaces",
+			len(ethCards), len(results.Results))
+	}
+
+	var deviceChanges []vimtypes.BaseVirtualDeviceConfigSpec
+
+	// Just zip these together until we can do interface identification.
+	for i := range results.Results {
+		backing := results.Results[i].Backing
+		if backing == nil {
+			continue
+		}
+
+		backingInfo, err := backing.EthernetCardBackingInfo(vmCtx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get ethernet card backing info for network %v: %w",
+				backing.Reference(), err)
+		}
+
+		ethCard := ethCards[i].(vimtypes.BaseVirtualEthernetCard).GetVirtualEthernetCard()
+		ethCard.Backing = backingInfo
+
+		deviceChanges = append(deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
+			Operation: vimtypes.VirtualDeviceConfigSpecOperationEdit,
+			Device:    ethCards[i],
+		})
+	}
+
+	return deviceChanges, nil
+}
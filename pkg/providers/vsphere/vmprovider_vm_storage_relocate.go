@@ -0,0 +1,219 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/pbm"
+	pbmtypes "github.com/vmware/govmomi/pbm/types"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	vcclient "github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/client"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/instancestorage"
+	spqutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube/spq"
+)
+
+// vmStorageRelocateIfNeeded relocates the VM's home and classic disks to a
+// datastore compatible with the storage policy of the VM's storage class when
+// the storage policy is not already applied to the VM. The FCDs that back PVCs
+// stay on their datastores since their storage is managed by CNS. The storage
+// class the
+// VM's storage uses is reflected in status.storage.storageClass, and the
+// result of the relocation is reflected in the VM's
+// VirtualMachineStorageRelocated condition. True is returned if the VM's
+// storage was relocated.
+func (vs *vSphereVMProvider) vmStorageRelocateIfNeeded(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	vcClient *vcclient.Client,
+	clusterMoRef vimtypes.ManagedObjectReference) (bool, error) {
+
+	storageClass := vmCtx.VM.Spec.StorageClass
+	if storageClass == "" {
+		return false, nil
+	}
+
+	if vmCtx.VM.Status.Storage == nil {
+		vmCtx.VM.Status.Storage = &vmopv1.VirtualMachineStorageStatus{}
+	}
+	if vmCtx.VM.Status.Storage.StorageClass == "" {
+		// The VM's storage class has not been recorded yet, so its storage
+		// is in the storage class it was created with.
+		vmCtx.VM.Status.Storage.StorageClass = storageClass
+		return false, nil
+	}
+	if vmCtx.VM.Status.Storage.StorageClass == storageClass {
+		return false, nil
+	}
+
+	profileID, err := spqutil.GetStoragePolicyIDFromClass(vmCtx, vs.k8sClient, storageClass)
+	if err != nil {
+		return false, fmt.Errorf("failed to get storage policy ID for storage class %s: %w", storageClass, err)
+	}
+
+	pc, err := pbm.NewClient(vmCtx, vcClient.VimClient())
+	if err != nil {
+		return false, err
+	}
+
+	profileIDs, err := pc.QueryAssociatedProfile(vmCtx, pbmtypes.PbmServerObjectRef{
+		ObjectType: string(pbmtypes.PbmObjectTypeVirtualMachine),
+		Key:        vcVM.Reference().Value,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get storage policy of VM: %w", err)
+	}
+
+	if slices.ContainsFunc(profileIDs, func(id pbmtypes.PbmProfileId) bool { return id.UniqueId == profileID }) {
+		// The VM already uses the storage class's storage policy.
+		vmCtx.VM.Status.Storage.StorageClass = storageClass
+		return false, nil
+	}
+
+	if instancestorage.IsPresent(vmCtx.VM) {
+		err := fmt.Errorf("cannot relocate storage of VM with instance storage")
+		conditions.MarkFalse(
+			vmCtx.VM,
+			vmopv1.VirtualMachineStorageRelocatedCondition,
+			vmopv1.VirtualMachineStorageRelocateFailedReason,
+			err.Error())
+		return false, err
+	}
+
+	datastoreMoRef, err := getCompatibleDatastore(vmCtx, pc, vcClient, clusterMoRef, profileID)
+	if err != nil {
+		conditions.MarkFalse(
+			vmCtx.VM,
+			vmopv1.VirtualMachineStorageRelocatedCondition,
+			vmopv1.VirtualMachineStorageRelocatePlacementFailedReason,
+			err.Error())
+		return false, err
+	}
+
+	profile := []vimtypes.BaseVirtualMachineProfileSpec{
+		&vimtypes.VirtualMachineDefinedProfileSpec{ProfileId: profileID},
+	}
+
+	relocateSpec := vimtypes.VirtualMachineRelocateSpec{
+		Datastore: datastoreMoRef,
+		Profile:   profile,
+	}
+
+	if config := vmCtx.MoVM.Config; config != nil {
+		for _, device := range config.Hardware.Device {
+			disk, ok := device.(*vimtypes.VirtualDisk)
+			if !ok {
+				continue
+			}
+			if disk.VDiskId != nil {
+				// Pin the FCDs that back PVCs to their current datastore
+				// since their storage is managed by CNS.
+				backing, ok := disk.Backing.(vimtypes.BaseVirtualDeviceFileBackingInfo)
+				if !ok || backing.GetVirtualDeviceFileBackingInfo().Datastore == nil {
+					continue
+				}
+				relocateSpec.Disk = append(relocateSpec.Disk, vimtypes.VirtualMachineRelocateSpecDiskLocator{
+					DiskId:    disk.Key,
+					Datastore: *backing.GetVirtualDeviceFileBackingInfo().Datastore,
+				})
+				continue
+			}
+			relocateSpec.Disk = append(relocateSpec.Disk, vimtypes.VirtualMachineRelocateSpecDiskLocator{
+				DiskId:    disk.Key,
+				Datastore: *datastoreMoRef,
+				Profile:   profile,
+			})
+		}
+	}
+
+	vmCtx.Logger.Info("Relocating VM storage",
+		"storageClass", storageClass,
+		"storagePolicyID", profileID,
+		"datastore", datastoreMoRef.Value)
+
+	task, err := vcVM.Relocate(vmCtx, relocateSpec, vimtypes.VirtualMachineMovePriorityDefaultPriority)
+	if err == nil {
+		err = task.Wait(vmCtx)
+	}
+	if err != nil {
+		conditions.MarkFalse(
+			vmCtx.VM,
+			vmopv1.VirtualMachineStorageRelocatedCondition,
+			vmopv1.VirtualMachineStorageRelocateFailedReason,
+			err.Error())
+		return false, fmt.Errorf("failed to relocate VM storage: %w", err)
+	}
+
+	vmCtx.VM.Status.Storage.StorageClass = storageClass
+	conditions.MarkTrue(vmCtx.VM, vmopv1.VirtualMachineStorageRelocatedCondition)
+
+	return true, nil
+}
+
+// getCompatibleDatastore returns the datastore in the cluster with the most free
+// space that is compatible with the provided storage policy.
+func getCompatibleDatastore(
+	vmCtx pkgctx.VirtualMachineContext,
+	pc *pbm.Client,
+	vcClient *vcclient.Client,
+	clusterMoRef vimtypes.ManagedObjectReference,
+	profileID string) (*vimtypes.ManagedObjectReference, error) {
+
+	ds, err := pc.DatastoreMap(vmCtx, vcClient.VimClient(), clusterMoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	req := []pbmtypes.BasePbmPlacementRequirement{
+		&pbmtypes.PbmPlacementCapabilityProfileRequirement{
+			ProfileId: pbmtypes.PbmProfileId{UniqueId: profileID},
+		},
+	}
+
+	res, err := pc.CheckRequirements(vmCtx, ds.PlacementHub, nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	hubs := res.CompatibleDatastores()
+	if len(hubs) == 0 {
+		return nil, fmt.Errorf("no datastores compatible with storage policy %s", profileID)
+	}
+
+	dsRefs := make([]vimtypes.ManagedObjectReference, 0, len(hubs))
+	for _, hub := range hubs {
+		dsRefs = append(dsRefs, vimtypes.ManagedObjectReference{Type: hub.HubType, Value: hub.HubId})
+	}
+
+	var datastores []mo.Datastore
+	if err := property.DefaultCollector(vcClient.VimClient()).Retrieve(
+		vmCtx,
+		dsRefs,
+		[]string{"summary.freeSpace"},
+		&datastores); err != nil {
+
+		return nil, fmt.Errorf("failed to get free space of datastores: %w", err)
+	}
+
+	var best *mo.Datastore
+	for i := range datastores {
+		if best == nil || datastores[i].Summary.FreeSpace > best.Summary.FreeSpace {
+			best = &datastores[i]
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no datastores compatible with storage policy %s", profileID)
+	}
+
+	dsRef := best.Reference()
+	return &dsRef, nil
+}
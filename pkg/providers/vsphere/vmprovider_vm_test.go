@@ -44,6 +44,7 @@ import (
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
 	kubeutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmconfig"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmconfig/crypto"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
@@ -1863,6 +1864,273 @@ func vmTests() {
 				})
 			})
 
+			Context("Storage Class Change", func() {
+				BeforeEach(func() {
+					pkgcfg.SetContext(parentCtx, func(config *pkgcfg.Config) {
+						config.Features.VMStorageClassChange = true
+					})
+				})
+
+				It("Reports the VM's storage class", func() {
+					_, err := createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					// The storage class is reported on update.
+					_, err = createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(vm.Status.Storage).ToNot(BeNil())
+					Expect(vm.Status.Storage.StorageClass).To(Equal(ctx.StorageClassName))
+				})
+
+				It("Records the storage class of a VM that does not report one", func() {
+					_, err := createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					vm.Status.Storage = nil
+					_, err = createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(vm.Status.Storage).ToNot(BeNil())
+					Expect(vm.Status.Storage.StorageClass).To(Equal(ctx.StorageClassName))
+					Expect(conditions.Has(vm, vmopv1.VirtualMachineStorageRelocatedCondition)).To(BeFalse())
+				})
+
+				It("Returns an error when the new storage class does not exist", func() {
+					_, err := createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					vm.Spec.StorageClass = "does-not-exist"
+					_, err = createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).To(HaveOccurred())
+				})
+			})
+
+			Context("Rebuild", func() {
+				BeforeEach(func() {
+					pkgcfg.SetContext(parentCtx, func(config *pkgcfg.Config) {
+						config.Features.VMRebuild = true
+					})
+				})
+
+				JustBeforeEach(func() {
+					// The ttylinux image has a single disk.
+					image := &vmopv1.ClusterVirtualMachineImage{}
+					Expect(ctx.Client.Get(ctx, client.ObjectKey{Name: vm.Spec.Image.Name}, image)).To(Succeed())
+					image.Status.Disks = []vmopv1.VirtualMachineImageDiskInfo{
+						{Capacity: ptr.To(resource.MustParse("30Mi"))},
+					}
+					Expect(ctx.Client.Status().Update(ctx, image)).To(Succeed())
+				})
+
+				changeImage := func() {
+					oldImage := &vmopv1.ClusterVirtualMachineImage{}
+					Expect(ctx.Client.Get(ctx, client.ObjectKey{Name: vm.Spec.Image.Name}, oldImage)).To(Succeed())
+
+					newImage := &vmopv1.ClusterVirtualMachineImage{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: oldImage.Name + "-new",
+						},
+						Spec: *oldImage.Spec.DeepCopy(),
+					}
+					Expect(ctx.Client.Create(ctx, newImage)).To(Succeed())
+					newImage.Status = *oldImage.Status.DeepCopy()
+					Expect(ctx.Client.Status().Update(ctx, newImage)).To(Succeed())
+
+					vm.Spec.Image.Name = newImage.Name
+					vm.Spec.ImageName = newImage.Name
+				}
+
+				It("Records the deployed image in the VM's ExtraConfig", func() {
+					vcVM, err := createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					var o mo.VirtualMachine
+					Expect(vcVM.Properties(ctx, vcVM.Reference(), []string{"config.extraConfig"}, &o)).To(Succeed())
+					ecList := object.OptionValueList(o.Config.ExtraConfig)
+					v, _ := ecList.GetString(vsphere.ExtraConfigKeyImage)
+					Expect(v).To(Equal(vm.Spec.Image.Kind + "/" + vm.Spec.Image.Name))
+					v, _ = ecList.GetString(vsphere.ExtraConfigKeyImageDisks)
+					Expect(v).To(Equal("1"))
+				})
+
+				It("Takes the deployed image from the VM's ExtraConfig when status.image is not set", func() {
+					_, err := createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+					deployedImageName := vm.Spec.Image.Name
+
+					vm.Status.Image = nil
+					changeImage()
+
+					_, err = createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vm.Status.Image).ToNot(BeNil())
+					Expect(vm.Status.Image.Name).ToNot(Equal(deployedImageName))
+					Expect(vm.Status.Image.Name).To(Equal(vm.Spec.Image.Name))
+					Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineRebuiltCondition)).To(BeTrue())
+				})
+
+				It("Does not rebuild a VM with classic disks that are not part of the image", func() {
+					vcVM, err := createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					By("adding a classic disk to the VM", func() {
+						devices, err := vcVM.Device(ctx)
+						Expect(err).ToNot(HaveOccurred())
+						controller, err := devices.FindDiskController("")
+						Expect(err).ToNot(HaveOccurred())
+						disk := devices.CreateDisk(controller, ctx.Datastore.Reference(), "")
+						disk.CapacityInKB = 1024
+						Expect(vcVM.AddDevice(ctx, disk)).To(Succeed())
+					})
+
+					deployedImageName := vm.Spec.Image.Name
+					changeImage()
+
+					err = vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)
+					Expect(err).To(MatchError(ContainSubstring("cannot rebuild VM with 1 classic disks that are not part of image")))
+					Expect(vm.Status.Image.Name).To(Equal(deployedImageName))
+					c := conditions.Get(vm, vmopv1.VirtualMachineRebuiltCondition)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Status).To(Equal(metav1.ConditionFalse))
+					Expect(c.Reason).To(Equal(vmopv1.VirtualMachineRebuildFailedReason))
+
+					By("not retrying the rebuild right away", func() {
+						err = vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)
+						Expect(err).To(MatchError(ContainSubstring("waiting")))
+						Expect(err).To(MatchError(ContainSubstring("to retry failed rebuild")))
+					})
+
+					var o mo.VirtualMachine
+					Expect(vcVM.Properties(ctx, vcVM.Reference(), nil, &o)).To(Succeed())
+					disks := object.VirtualDeviceList(o.Config.Hardware.Device).SelectByType(&vimtypes.VirtualDisk{})
+					Expect(disks).To(HaveLen(2))
+				})
+
+				It("Does not power off the VM when a disruption budget does not allow it", func() {
+					vm.Labels = map[string]string{"app": "dummy"}
+					vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+					vcVM, err := createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					budget := builder.DummyVirtualMachineDisruptionBudget()
+					budget.Namespace = vm.Namespace
+					Expect(ctx.Client.Create(ctx, budget)).To(Succeed())
+
+					deployedImageName := vm.Spec.Image.Name
+					changeImage()
+
+					err = vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)
+					Expect(err).To(MatchError(vmopv1util.ErrDisruptionNotAllowed))
+					Expect(vm.Status.Image.Name).To(Equal(deployedImageName))
+					c := conditions.Get(vm, vmopv1.VirtualMachineRebuiltCondition)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Reason).To(Equal(vmopv1.VirtualMachineRebuildInProgressReason))
+
+					state, err := vcVM.PowerState(ctx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(state).To(Equal(vimtypes.VirtualMachinePowerStatePoweredOn))
+				})
+
+				It("Rebuilds the VM from the new image", func() {
+					vcVM, err := createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(vm.Status.Image).ToNot(BeNil())
+					Expect(vm.Status.Image.Kind).To(Equal(vm.Spec.Image.Kind))
+					Expect(vm.Status.Image.Name).To(Equal(vm.Spec.Image.Name))
+					Expect(conditions.Has(vm, vmopv1.VirtualMachineRebuiltCondition)).To(BeFalse())
+					biosUUID := vm.Status.BiosUUID
+					instanceUUID := vm.Status.InstanceUUID
+
+					By("creating a new image from the same library item", changeImage)
+
+					_, err = createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(vm.Status.Image).ToNot(BeNil())
+					Expect(vm.Status.Image.Name).To(Equal(vm.Spec.Image.Name))
+					Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineRebuiltCondition)).To(BeTrue())
+					Expect(vm.Status.BiosUUID).To(Equal(biosUUID))
+					Expect(vm.Status.InstanceUUID).To(Equal(instanceUUID))
+					Expect(vm.Status.PowerState).To(Equal(vm.Spec.PowerState))
+
+					var o mo.VirtualMachine
+					Expect(vcVM.Properties(ctx, vcVM.Reference(), nil, &o)).To(Succeed())
+					disks := object.VirtualDeviceList(o.Config.Hardware.Device).SelectByType(&vimtypes.VirtualDisk{})
+					Expect(disks).ToNot(BeEmpty())
+					backing := disks[0].GetVirtualDevice().Backing.(*vimtypes.VirtualDiskFlatVer2BackingInfo)
+					Expect(backing.FileName).To(HaveSuffix(fmt.Sprintf("%s-%d-0.vmdk", vm.Name, vm.Generation)))
+
+					By("destroying the temporary VM", func() {
+						_, err := ctx.Finder.VirtualMachine(ctx, vm.Name+"-rebuild")
+						Expect(err).To(HaveOccurred())
+					})
+				})
+			})
+
+			Context("Clone", func() {
+				var cloneVM *vmopv1.VirtualMachine
+
+				BeforeEach(func() {
+					pkgcfg.SetContext(parentCtx, func(config *pkgcfg.Config) {
+						config.Features.VMClone = true
+					})
+				})
+
+				JustBeforeEach(func() {
+					_, err := createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					status := vm.Status.DeepCopy()
+					Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+					vm.Status = *status
+					Expect(ctx.Client.Status().Update(ctx, vm)).To(Succeed())
+
+					cloneVM = &vmopv1.VirtualMachine{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      vm.Name + "-clone",
+							Namespace: vm.Namespace,
+							Annotations: map[string]string{
+								vmopv1.CloneSourceAnnotation: vm.Name,
+								vmopv1.CloneTypeAnnotation:   string(vmopv1.VirtualMachineCloneTypeFull),
+							},
+						},
+						Spec: *vm.Spec.DeepCopy(),
+					}
+					cloneVM.Spec.BiosUUID = ""
+					cloneVM.Spec.InstanceUUID = ""
+				})
+
+				It("Clones the source VM", func() {
+					vcVM, err := createOrUpdateAndGetVcVM(ctx, cloneVM)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(cloneVM.Status.UniqueID).ToNot(Equal(vm.Status.UniqueID))
+					Expect(cloneVM.Status.InstanceUUID).ToNot(Equal(vm.Status.InstanceUUID))
+					Expect(conditions.IsTrue(cloneVM, vmopv1.VirtualMachineConditionCreated)).To(BeTrue())
+					Expect(cloneVM.Status.PowerState).To(Equal(cloneVM.Spec.PowerState))
+
+					var o mo.VirtualMachine
+					Expect(vcVM.Properties(ctx, vcVM.Reference(), nil, &o)).To(Succeed())
+					Expect(o.Config.Name).To(Equal(cloneVM.Name))
+					disks := object.VirtualDeviceList(o.Config.Hardware.Device).SelectByType(&vimtypes.VirtualDisk{})
+					Expect(disks).To(HaveLen(1))
+				})
+
+				When("a linked clone is requested and the source VM does not have a snapshot", func() {
+					JustBeforeEach(func() {
+						cloneVM.Annotations[vmopv1.CloneTypeAnnotation] = string(vmopv1.VirtualMachineCloneTypeLinked)
+					})
+
+					It("returns an error", func() {
+						err := vmProvider.CreateOrUpdateVirtualMachine(ctx, cloneVM)
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("does not have a snapshot required for a linked clone"))
+					})
+				})
+			})
+
 			Context("Without Content Library", func() {
 				BeforeEach(func() {
 					testConfig.WithContentLibrary = false
@@ -2310,6 +2578,54 @@ func vmTests() {
 						_, dvpg := getDVPG(ctx, dvpgName)
 						Expect(backing2.Port.PortgroupKey).To(Equal(dvpg.Reference().Value))
 					})
+
+					Context("VM Network Hot Plug", func() {
+						BeforeEach(func() {
+							pkgcfg.SetContext(parentCtx, func(config *pkgcfg.Config) {
+								config.Features.VMNetworkHotPlug = true
+							})
+							vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+						})
+
+						getEthCards := func(vcVM *object.VirtualMachine) object.VirtualDeviceList {
+							var o mo.VirtualMachine
+							ExpectWithOffset(1, vcVM.Properties(ctx, vcVM.Reference(), nil, &o)).To(Succeed())
+							return object.VirtualDeviceList(o.Config.Hardware.Device).SelectByType(&vimtypes.VirtualEthernetCard{})
+						}
+
+						It("Removes and adds NICs on a powered on VM", func() {
+							vcVM, err := createOrUpdateAndGetVcVM(ctx, vm)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(vm.Status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+							Expect(getEthCards(vcVM)).To(HaveLen(2))
+
+							By("removing a NIC", func() {
+								vm.Spec.Network.Interfaces = vm.Spec.Network.Interfaces[1:]
+								_, err := createOrUpdateAndGetVcVM(ctx, vm)
+								Expect(err).ToNot(HaveOccurred())
+
+								l := getEthCards(vcVM)
+								Expect(l).To(HaveLen(1))
+								backing, ok := l[0].GetVirtualDevice().Backing.(*vimtypes.VirtualEthernetCardDistributedVirtualPortBackingInfo)
+								Expect(ok).Should(BeTrue())
+								_, dvpg := getDVPG(ctx, dvpgName)
+								Expect(backing.Port.PortgroupKey).To(Equal(dvpg.Reference().Value))
+							})
+
+							By("adding a NIC", func() {
+								vm.Spec.Network.Interfaces = append(vm.Spec.Network.Interfaces,
+									vmopv1.VirtualMachineNetworkInterfaceSpec{
+										Name:    "eth2",
+										Network: &common.PartialObjectRef{Name: "VM Network"},
+									})
+								_, err := createOrUpdateAndGetVcVM(ctx, vm)
+								Expect(err).ToNot(HaveOccurred())
+
+								Expect(getEthCards(vcVM)).To(HaveLen(2))
+								Expect(vm.Status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+							})
+						})
+					})
 				})
 			})
 
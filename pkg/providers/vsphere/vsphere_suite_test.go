@@ -17,6 +17,7 @@ func vcSimTests() {
 	Describe("CPUFreq", cpuFreqTests)
 	Describe("InitOvfCacheAndLockPool", initOvfCacheAndLockPoolTests)
 	Describe("SyncVirtualMachineImage", syncVirtualMachineImageTests)
+	Describe("ImportVirtualMachineImage", importVirtualMachineImageTests)
 	Describe("ResourcePolicyTests", resourcePolicyTests)
 	Describe("VirtualMachine", vmTests)
 	Describe("VirtualMachineE2E", vmE2ETests)
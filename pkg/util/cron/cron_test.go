@@ -0,0 +1,76 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cron_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/cron"
+)
+
+func mustParseTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	Expect(err).ToNot(HaveOccurred())
+	return t
+}
+
+var _ = Describe("Parse", func() {
+	DescribeTable("invalid schedules",
+		func(spec, expectedErr string) {
+			_, err := cron.Parse(spec)
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		},
+		Entry("empty", "", "expected 5 fields, found 0"),
+		Entry("too few fields", "* * * *", "expected 5 fields, found 4"),
+		Entry("too many fields", "0 * * * * *", "expected 5 fields, found 6"),
+		Entry("unknown macro", "@every", "expected 5 fields, found 1"),
+		Entry("minute out of range", "60 * * * *", "invalid minute"),
+		Entry("hour out of range", "0 24 * * *", "invalid hour"),
+		Entry("day of month out of range", "0 0 0 * *", "invalid day of month"),
+		Entry("month out of range", "0 0 1 13 *", "invalid month"),
+		Entry("day of week out of range", "0 0 * * 8", "invalid day of week"),
+		Entry("unknown name", "0 0 * foo *", "invalid value \"foo\""),
+		Entry("reversed range", "0 5-1 * * *", "range start 5 is greater than end 1"),
+		Entry("zero step", "*/0 * * * *", "invalid step \"0\""),
+		Entry("multiple steps", "*/5/2 * * * *", "invalid range"),
+	)
+})
+
+var _ = Describe("Next", func() {
+	DescribeTable("schedules",
+		func(spec, from, expected string) {
+			s, err := cron.Parse(spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s.Next(mustParseTime(from))).To(Equal(mustParseTime(expected)))
+		},
+		Entry("every minute", "* * * * *", "2024-01-01T10:00:30Z", "2024-01-01T10:01:00Z"),
+		Entry("on the minute is exclusive", "* * * * *", "2024-01-01T10:00:00Z", "2024-01-01T10:01:00Z"),
+		Entry("step", "*/15 * * * *", "2024-01-01T10:16:00Z", "2024-01-01T10:30:00Z"),
+		Entry("value with step", "5/20 * * * *", "2024-01-01T10:26:00Z", "2024-01-01T10:45:00Z"),
+		Entry("list", "0 6,18 * * *", "2024-01-01T07:00:00Z", "2024-01-01T18:00:00Z"),
+		Entry("range", "0 9-17 * * *", "2024-01-01T17:30:00Z", "2024-01-02T09:00:00Z"),
+		Entry("hourly macro", "@hourly", "2024-01-01T10:10:00Z", "2024-01-01T11:00:00Z"),
+		Entry("daily macro", "@daily", "2024-01-01T10:10:00Z", "2024-01-02T00:00:00Z"),
+		Entry("weekly macro", "@weekly", "2024-01-01T10:10:00Z", "2024-01-07T00:00:00Z"),
+		Entry("monthly macro", "@monthly", "2024-01-31T10:10:00Z", "2024-02-01T00:00:00Z"),
+		Entry("yearly macro", "@yearly", "2024-01-01T10:10:00Z", "2025-01-01T00:00:00Z"),
+		Entry("month and day names", "30 2 * jan-mar MON-FRI", "2024-03-29T03:00:00Z", "2025-01-01T02:30:00Z"),
+		Entry("sunday as seven", "0 0 * * 7", "2024-01-01T00:00:00Z", "2024-01-07T00:00:00Z"),
+		Entry("day of month or day of week", "0 0 15 * FRI", "2024-01-06T00:00:00Z", "2024-01-12T00:00:00Z"),
+		Entry("day of month and any day of week", "0 0 15 * *", "2024-01-06T00:00:00Z", "2024-01-15T00:00:00Z"),
+		Entry("leap day", "0 0 29 2 *", "2024-03-01T00:00:00Z", "2028-02-29T00:00:00Z"),
+		Entry("non-UTC input", "0 12 * * *", "2024-01-01T08:00:00-05:00", "2024-01-02T12:00:00Z"),
+	)
+
+	When("no time matches the schedule", func() {
+		It("returns the zero time", func() {
+			s, err := cron.Parse("0 0 30 2 *")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s.Next(mustParseTime("2024-01-01T00:00:00Z"))).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,361 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Options describes how a Client connects to a registry.
+type Options struct {
+	// Username and Password are the credentials used to authenticate to the
+	// registry. When Username is empty the registry is accessed anonymously.
+	Username string
+	Password string
+
+	// Insecure indicates the registry is accessed over plain HTTP.
+	Insecure bool
+
+	// HTTPClient is the client used to send requests. When nil,
+	// http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// Client pushes content to a repository in an OCI registry using the OCI
+// distribution API.
+type Client struct {
+	repo       Repository
+	scheme     string
+	username   string
+	password   string
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	token string
+}
+
+// NewClient returns a Client for the provided repository.
+func NewClient(repository string, opts Options) (*Client, error) {
+	repo, err := ParseRepository(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		repo:       repo,
+		scheme:     "https",
+		username:   opts.Username,
+		password:   opts.Password,
+		httpClient: opts.HTTPClient,
+	}
+	if opts.Insecure {
+		c.scheme = "http"
+	}
+	if c.httpClient == nil {
+		c.httpClient = http.DefaultClient
+	}
+
+	return c, nil
+}
+
+// Repository returns the repository to which the client pushes content.
+func (c *Client) Repository() Repository {
+	return c.repo
+}
+
+// PushBlob streams the content from the provided reader to the repository as
+// a blob and returns its descriptor.
+func (c *Client) PushBlob(
+	ctx context.Context,
+	mediaType string,
+	r io.Reader,
+	annotations map[string]string) (Descriptor, error) {
+
+	resp, err := c.do(ctx, http.MethodPost, c.url("blobs", "uploads")+"/", nil, "")
+	if err != nil {
+		return Descriptor{}, err
+	}
+	location, err := c.location(resp, http.StatusAccepted)
+	if err != nil {
+		return Descriptor{}, err
+	}
+
+	// The content is streamed in a single chunk while it is hashed so it
+	// does not need to be buffered or read twice.
+	h := sha256.New()
+	cr := &countingReader{r: io.TeeReader(r, h)}
+	resp, err = c.do(ctx, http.MethodPatch, location, cr, "application/octet-stream")
+	if err != nil {
+		return Descriptor{}, err
+	}
+	if location, err = c.location(resp, http.StatusAccepted); err != nil {
+		return Descriptor{}, err
+	}
+
+	digest := "sha256:" + hex.EncodeToString(h.Sum(nil))
+	u, err := url.Parse(location)
+	if err != nil {
+		return Descriptor{}, err
+	}
+	q := u.Query()
+	q.Set("digest", digest)
+	u.RawQuery = q.Encode()
+
+	resp, err = c.do(ctx, http.MethodPut, u.String(), nil, "")
+	if err != nil {
+		return Descriptor{}, err
+	}
+	if err := checkStatus(resp, http.StatusCreated); err != nil {
+		return Descriptor{}, err
+	}
+
+	return Descriptor{
+		MediaType:   mediaType,
+		Digest:      digest,
+		Size:        cr.n,
+		Annotations: annotations,
+	}, nil
+}
+
+// PushManifest pushes the provided manifest to the repository with the
+// provided tag and returns its descriptor.
+func (c *Client) PushManifest(
+	ctx context.Context,
+	tag string,
+	manifest Manifest) (Descriptor, error) {
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return Descriptor{}, err
+	}
+
+	resp, err := c.do(
+		ctx,
+		http.MethodPut,
+		c.url("manifests", tag),
+		bytes.NewReader(data),
+		manifest.MediaType)
+	if err != nil {
+		return Descriptor{}, err
+	}
+	if err := checkStatus(resp, http.StatusCreated); err != nil {
+		return Descriptor{}, err
+	}
+
+	return Descriptor{
+		MediaType: manifest.MediaType,
+		Digest:    digestOf(data),
+		Size:      int64(len(data)),
+	}, nil
+}
+
+// DeleteManifest deletes the manifest with the provided digest from the
+// repository. No error is returned if the manifest does not exist.
+func (c *Client) DeleteManifest(ctx context.Context, digest string) error {
+	resp, err := c.do(ctx, http.MethodDelete, c.url("manifests", digest), nil, "")
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		drainAndClose(resp)
+		return nil
+	}
+	return checkStatus(resp, http.StatusAccepted)
+}
+
+func (c *Client) url(elem ...string) string {
+	return fmt.Sprintf("%s://%s/v2/%s/%s",
+		c.scheme, c.repo.Host, c.repo.Name, strings.Join(elem, "/"))
+}
+
+// location returns the absolute URL from the Location header of the provided
+// response.
+func (c *Client) location(resp *http.Response, expectedStatus int) (string, error) {
+	if err := checkStatus(resp, expectedStatus); err != nil {
+		return "", err
+	}
+	loc, err := resp.Location()
+	if err != nil {
+		return "", fmt.Errorf("invalid upload location: %w", err)
+	}
+	return loc.String(), nil
+}
+
+// do sends a request to the registry. If the registry responds with a bearer
+// token challenge, a token is obtained and the request is retried when its
+// body can be replayed.
+func (c *Client) do(
+	ctx context.Context,
+	method, url string,
+	body io.Reader,
+	contentType string) (*http.Response, error) {
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	c.authorize(req)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	drainAndClose(resp)
+
+	if req.Body != nil && req.GetBody == nil {
+		return nil, fmt.Errorf("%s %s: unauthorized", method, url)
+	}
+	if err := c.fetchToken(ctx, challenge); err != nil {
+		return nil, err
+	}
+
+	retry := req.Clone(ctx)
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	c.authorize(retry)
+	return c.httpClient.Do(retry)
+}
+
+func (c *Client) authorize(req *http.Request) {
+	c.mu.Lock()
+	token := c.token
+	c.mu.Unlock()
+
+	switch {
+	case token != "":
+		req.Header.Set("Authorization", "Bearer "+token)
+	case c.username != "":
+		req.SetBasicAuth(c.username, c.password)
+	}
+}
+
+var challengeParamRx = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// fetchToken obtains a bearer token from the authorization service described
+// by the provided WWW-Authenticate challenge.
+func (c *Client) fetchToken(ctx context.Context, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return errors.New("registry authentication failed")
+	}
+
+	p := map[string]string{}
+	for _, m := range challengeParamRx.FindAllStringSubmatch(params, -1) {
+		p[m[1]] = m[2]
+	}
+	if p["realm"] == "" {
+		return errors.New("registry authentication challenge is missing realm")
+	}
+
+	u, err := url.Parse(p["realm"])
+	if err != nil {
+		return fmt.Errorf("invalid authentication realm: %w", err)
+	}
+	q := u.Query()
+	if p["service"] != "" {
+		q.Set("service", p["service"])
+	}
+	scope := p["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull,push", c.repo.Name)
+	}
+	q.Set("scope", scope)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get registry token: %w", checkStatus(resp, http.StatusOK))
+	}
+	defer drainAndClose(resp)
+
+	var tokenResp struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return fmt.Errorf("failed to decode registry token: %w", err)
+	}
+
+	token := tokenResp.Token
+	if token == "" {
+		token = tokenResp.AccessToken
+	}
+	if token == "" {
+		return errors.New("registry token response is missing token")
+	}
+
+	c.mu.Lock()
+	c.token = token
+	c.mu.Unlock()
+
+	return nil
+}
+
+// checkStatus returns an error if the response does not have the expected
+// status code. The response body is always closed.
+func checkStatus(resp *http.Response, expectedStatus int) error {
+	defer drainAndClose(resp)
+	if resp.StatusCode == expectedStatus {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return fmt.Errorf("%s %s: unexpected status %d: %s",
+		resp.Request.Method, resp.Request.URL.Redacted(), resp.StatusCode,
+		strings.TrimSpace(string(body)))
+}
+
+func drainAndClose(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	_ = resp.Body.Close()
+}
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci/fake"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		ctx      context.Context
+		registry *fake.Registry
+		opts     oci.Options
+		client   *oci.Client
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		registry = fake.NewRegistry()
+		opts = oci.Options{Insecure: true}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		client, err = oci.NewClient(registry.Host()+"/images/my-vm", opts)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		registry.Close()
+	})
+
+	push := func() (oci.Descriptor, error) {
+		layer, err := client.PushBlob(
+			ctx,
+			"application/octet-stream",
+			// Wrap the reader so its length is unknown.
+			struct{ *strings.Reader }{strings.NewReader("hello world")},
+			map[string]string{oci.AnnotationTitle: "hello.txt"})
+		if err != nil {
+			return oci.Descriptor{}, err
+		}
+
+		_, err = client.PushBlob(ctx, oci.MediaTypeEmptyJSON, bytes.NewReader(oci.EmptyJSON), nil)
+		if err != nil {
+			return oci.Descriptor{}, err
+		}
+
+		m := oci.NewArtifactManifest("application/vnd.example", []oci.Descriptor{layer}, nil)
+		return client.PushManifest(ctx, "v1", m)
+	}
+
+	assertPushed := func(desc oci.Descriptor) {
+		data, ok := registry.Manifest("images/my-vm", "v1")
+		Expect(ok).To(BeTrue())
+		Expect(desc.Digest).To(HavePrefix("sha256:"))
+		Expect(desc.Size).To(BeEquivalentTo(len(data)))
+
+		var m oci.Manifest
+		Expect(json.Unmarshal(data, &m)).To(Succeed())
+		Expect(m.SchemaVersion).To(Equal(2))
+		Expect(m.MediaType).To(Equal(oci.MediaTypeImageManifest))
+		Expect(m.ArtifactType).To(Equal("application/vnd.example"))
+		Expect(m.Config.MediaType).To(Equal(oci.MediaTypeEmptyJSON))
+		Expect(m.Layers).To(HaveLen(1))
+		Expect(m.Layers[0].Size).To(BeEquivalentTo(len("hello world")))
+		Expect(m.Layers[0].Annotations).To(HaveKeyWithValue(oci.AnnotationTitle, "hello.txt"))
+
+		blob, ok := registry.Blob(m.Layers[0].Digest)
+		Expect(ok).To(BeTrue())
+		Expect(string(blob)).To(Equal("hello world"))
+
+		_, ok = registry.Blob(m.Config.Digest)
+		Expect(ok).To(BeTrue())
+
+		_, ok = registry.Manifest("images/my-vm", desc.Digest)
+		Expect(ok).To(BeTrue())
+	}
+
+	It("should reject an invalid repository", func() {
+		_, err := oci.NewClient(registry.Host()+"/my-vm:v1", opts)
+		Expect(err).To(HaveOccurred())
+	})
+
+	When("the registry allows anonymous access", func() {
+		It("should push the artifact", func() {
+			desc, err := push()
+			Expect(err).ToNot(HaveOccurred())
+			assertPushed(desc)
+		})
+	})
+
+	Describe("DeleteManifest", func() {
+		It("should delete the manifest and its tags", func() {
+			desc, err := push()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(client.DeleteManifest(ctx, desc.Digest)).To(Succeed())
+
+			_, ok := registry.Manifest("images/my-vm", desc.Digest)
+			Expect(ok).To(BeFalse())
+			_, ok = registry.Manifest("images/my-vm", "v1")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should not return an error if the manifest does not exist", func() {
+			Expect(client.DeleteManifest(ctx, "sha256:1234")).To(Succeed())
+		})
+	})
+
+	When("the registry requires basic auth", func() {
+		BeforeEach(func() {
+			registry.Username = "user"
+			registry.Password = "pass"
+		})
+
+		When("the credentials are valid", func() {
+			BeforeEach(func() {
+				opts.Username = "user"
+				opts.Password = "pass"
+			})
+			It("should push the artifact", func() {
+				desc, err := push()
+				Expect(err).ToNot(HaveOccurred())
+				assertPushed(desc)
+			})
+		})
+
+		When("the credentials are invalid", func() {
+			BeforeEach(func() {
+				opts.Username = "user"
+				opts.Password = "wrong"
+			})
+			It("should return an error", func() {
+				_, err := push()
+				Expect(err).To(MatchError(ContainSubstring("authentication failed")))
+			})
+		})
+	})
+
+	When("the registry requires token auth", func() {
+		BeforeEach(func() {
+			registry.TokenAuth = true
+			registry.Username = "user"
+			registry.Password = "pass"
+		})
+
+		When("the credentials are valid", func() {
+			BeforeEach(func() {
+				opts.Username = "user"
+				opts.Password = "pass"
+			})
+			It("should push the artifact", func() {
+				desc, err := push()
+				Expect(err).ToNot(HaveOccurred())
+				assertPushed(desc)
+			})
+		})
+
+		When("the credentials are invalid", func() {
+			It("should return an error", func() {
+				_, err := push()
+				Expect(err).To(MatchError(ContainSubstring("failed to get registry token")))
+			})
+		})
+	})
+})
@@ -0,0 +1,243 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+const fakeToken = "fake-registry-token"
+
+// Registry is an in-memory OCI registry that supports pushing blobs and
+// manifests. It is a stand-in for a real registry in tests.
+type Registry struct {
+	// Username and Password, when set, are the credentials required to
+	// access the registry.
+	Username string
+	Password string
+
+	// TokenAuth indicates the registry issues bearer token challenges
+	// instead of basic auth challenges.
+	TokenAuth bool
+
+	server *httptest.Server
+
+	mu        sync.Mutex
+	nextID    int
+	uploads   map[string]*bytes.Buffer
+	blobs     map[string][]byte
+	manifests map[string][]byte
+}
+
+// NewRegistry returns a new, started Registry. Callers should call Close when
+// finished.
+func NewRegistry() *Registry {
+	r := &Registry{
+		uploads:   map[string]*bytes.Buffer{},
+		blobs:     map[string][]byte{},
+		manifests: map[string][]byte{},
+	}
+	r.server = httptest.NewServer(http.HandlerFunc(r.serveHTTP))
+	return r
+}
+
+// Close shuts down the registry.
+func (r *Registry) Close() {
+	r.server.Close()
+}
+
+// Host returns the host and port of the registry.
+func (r *Registry) Host() string {
+	return strings.TrimPrefix(r.server.URL, "http://")
+}
+
+// Blob returns the content of the blob with the provided digest.
+func (r *Registry) Blob(digest string) ([]byte, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.blobs[digest]
+	return b, ok
+}
+
+// Manifest returns the manifest in the provided repository with the provided
+// tag or digest.
+func (r *Registry) Manifest(name, reference string) ([]byte, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m, ok := r.manifests[name+":"+reference]
+	return m, ok
+}
+
+func (r *Registry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		r.serveToken(w, req)
+		return
+	}
+	if !r.authorized(req) {
+		if r.TokenAuth {
+			w.Header().Set("WWW-Authenticate",
+				fmt.Sprintf(`Bearer realm="%s/token",service="fake"`, r.server.URL))
+		} else {
+			w.Header().Set("WWW-Authenticate", `Basic realm="fake"`)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	switch {
+	case strings.Contains(path, "/blobs/uploads/"):
+		name, id, _ := strings.Cut(path, "/blobs/uploads/")
+		r.serveUpload(w, req, name, id)
+	case strings.Contains(path, "/blobs/"):
+		_, digest, _ := strings.Cut(path, "/blobs/")
+		r.serveBlob(w, req, digest)
+	case strings.Contains(path, "/manifests/"):
+		name, ref, _ := strings.Cut(path, "/manifests/")
+		r.serveManifest(w, req, name, ref)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *Registry) authorized(req *http.Request) bool {
+	if r.Username == "" && !r.TokenAuth {
+		return true
+	}
+	if r.TokenAuth {
+		return req.Header.Get("Authorization") == "Bearer "+fakeToken
+	}
+	u, p, ok := req.BasicAuth()
+	return ok && u == r.Username && p == r.Password
+}
+
+func (r *Registry) serveToken(w http.ResponseWriter, req *http.Request) {
+	if r.Username != "" {
+		if u, p, ok := req.BasicAuth(); !ok || u != r.Username || p != r.Password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"token": fakeToken})
+}
+
+func (r *Registry) serveUpload(w http.ResponseWriter, req *http.Request, name, id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if req.Method == http.MethodPost {
+		r.nextID++
+		id = fmt.Sprintf("%d", r.nextID)
+		r.uploads[id] = &bytes.Buffer{}
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%s", name, id))
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	buf, ok := r.uploads[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if _, err := io.Copy(buf, req.Body); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodPatch:
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%s", name, id))
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodPut:
+		digest := req.URL.Query().Get("digest")
+		if digest != digestOf(buf.Bytes()) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.blobs[digest] = buf.Bytes()
+		delete(r.uploads, id)
+		w.Header().Set("Docker-Content-Digest", digest)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *Registry) serveBlob(w http.ResponseWriter, req *http.Request, digest string) {
+	b, ok := r.Blob(digest)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if req.Method == http.MethodGet {
+		_, _ = w.Write(b)
+	}
+}
+
+func (r *Registry) serveManifest(w http.ResponseWriter, req *http.Request, name, ref string) {
+	if req.Method == http.MethodDelete {
+		r.deleteManifest(w, name, ref)
+		return
+	}
+	if req.Method != http.MethodPut {
+		m, ok := r.Manifest(name, ref)
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(m)
+		}
+		return
+	}
+
+	b, err := io.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	digest := digestOf(b)
+
+	r.mu.Lock()
+	r.manifests[name+":"+ref] = b
+	r.manifests[name+":"+digest] = b
+	r.mu.Unlock()
+
+	w.Header().Set("Docker-Content-Digest", digest)
+	w.WriteHeader(http.StatusCreated)
+}
+
+// deleteManifest deletes the manifest with the provided digest along with any
+// tags that refer to it.
+func (r *Registry) deleteManifest(w http.ResponseWriter, name, digest string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.manifests[name+":"+digest]
+	if !ok || !strings.HasPrefix(digest, "sha256:") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	for k, v := range r.manifests {
+		if strings.HasPrefix(k, name+":") && bytes.Equal(v, m) {
+			delete(r.manifests, k)
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
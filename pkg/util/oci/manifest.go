@@ -0,0 +1,63 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+const (
+	// MediaTypeImageManifest is the media type of an OCI image manifest.
+	MediaTypeImageManifest = "application/vnd.oci.image.manifest.v1+json"
+
+	// MediaTypeEmptyJSON is the media type of the empty descriptor used as
+	// the config of an artifact that does not have a config.
+	MediaTypeEmptyJSON = "application/vnd.oci.empty.v1+json"
+
+	// AnnotationTitle is the annotation used to record the file name of a
+	// layer.
+	AnnotationTitle = "org.opencontainers.image.title"
+
+	// AnnotationCreated is the annotation used to record the time at which
+	// an artifact was created.
+	AnnotationCreated = "org.opencontainers.image.created"
+)
+
+// EmptyJSON is the content of the empty descriptor.
+var EmptyJSON = []byte("{}")
+
+// Descriptor describes content stored in a registry.
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest is an OCI image manifest.
+type Manifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        Descriptor        `json:"config"`
+	Layers        []Descriptor      `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// NewArtifactManifest returns a manifest for an artifact of the provided
+// type that is comprised of the provided layers and has an empty config.
+func NewArtifactManifest(
+	artifactType string,
+	layers []Descriptor,
+	annotations map[string]string) Manifest {
+
+	return Manifest{
+		SchemaVersion: 2,
+		MediaType:     MediaTypeImageManifest,
+		ArtifactType:  artifactType,
+		Config: Descriptor{
+			MediaType: MediaTypeEmptyJSON,
+			Digest:    digestOf(EmptyJSON),
+			Size:      int64(len(EmptyJSON)),
+		},
+		Layers:      layers,
+		Annotations: annotations,
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// repoNameRx matches a repository name as defined by the OCI
+	// distribution specification.
+	repoNameRx = regexp.MustCompile(`^[a-z0-9]+((\.|_|__|-+)[a-z0-9]+)*(/[a-z0-9]+((\.|_|__|-+)[a-z0-9]+)*)*$`)
+
+	// tagRx matches a tag as defined by the OCI distribution specification.
+	tagRx = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}$`)
+)
+
+// Repository is a reference to a repository in an OCI registry.
+type Repository struct {
+	// Host is the host, and optionally the port, of the registry.
+	Host string
+
+	// Name is the name of the repository in the registry.
+	Name string
+}
+
+// String returns the repository in the form host/name.
+func (r Repository) String() string {
+	return r.Host + "/" + r.Name
+}
+
+// ParseRepository parses a repository reference of the form host[:port]/name.
+// An error is returned if the reference includes a tag or digest.
+func ParseRepository(s string) (Repository, error) {
+	if strings.Contains(s, "@") {
+		return Repository{}, errors.New("repository must not include a digest")
+	}
+
+	host, name, ok := strings.Cut(s, "/")
+	if !ok || host == "" || name == "" {
+		return Repository{}, errors.New("repository must be of the form host/name")
+	}
+	if strings.Contains(name, ":") {
+		return Repository{}, errors.New("repository must not include a tag")
+	}
+	if !repoNameRx.MatchString(name) {
+		return Repository{}, fmt.Errorf("invalid repository name %q", name)
+	}
+
+	return Repository{Host: host, Name: name}, nil
+}
+
+// ValidateTag returns an error if the provided value is not a valid tag.
+func ValidateTag(tag string) error {
+	if !tagRx.MatchString(tag) {
+		return fmt.Errorf("invalid tag %q", tag)
+	}
+	return nil
+}
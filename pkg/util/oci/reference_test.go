@@ -0,0 +1,49 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+)
+
+var _ = Describe("ParseRepository", func() {
+	DescribeTable("valid repositories",
+		func(s, expectedHost, expectedName string) {
+			repo, err := oci.ParseRepository(s)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(repo.Host).To(Equal(expectedHost))
+			Expect(repo.Name).To(Equal(expectedName))
+			Expect(repo.String()).To(Equal(s))
+		},
+		Entry("host and name", "registry.example.com/my-vm", "registry.example.com", "my-vm"),
+		Entry("host with port", "registry.example.com:5000/my-vm", "registry.example.com:5000", "my-vm"),
+		Entry("nested name", "registry.example.com/images/my_vm.v1", "registry.example.com", "images/my_vm.v1"),
+	)
+
+	DescribeTable("invalid repositories",
+		func(s, expectedErr string) {
+			_, err := oci.ParseRepository(s)
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		},
+		Entry("no host", "my-vm", "must be of the form host/name"),
+		Entry("empty name", "registry.example.com/", "must be of the form host/name"),
+		Entry("tag", "registry.example.com/my-vm:v1", "must not include a tag"),
+		Entry("digest", "registry.example.com/my-vm@sha256:abcd", "must not include a digest"),
+		Entry("uppercase name", "registry.example.com/MyVM", "invalid repository name"),
+	)
+})
+
+var _ = Describe("ValidateTag", func() {
+	It("should allow a valid tag", func() {
+		Expect(oci.ValidateTag("v1.0_rc-1")).To(Succeed())
+	})
+	It("should not allow an invalid tag", func() {
+		Expect(oci.ValidateTag("")).ToNot(Succeed())
+		Expect(oci.ValidateTag("-v1")).ToNot(Succeed())
+		Expect(oci.ValidateTag("v1/2")).ToNot(Succeed())
+	})
+})
@@ -0,0 +1,184 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+)
+
+// ErrDisruptionNotAllowed is returned from CheckDisruptionAllowed when
+// disrupting a VM would violate a VirtualMachineDisruptionBudget.
+var ErrDisruptionNotAllowed = errors.New("disruption not allowed")
+
+// IsVirtualMachineReady returns true if the VM is ready. A VM with a
+// readiness probe is ready when its Ready condition is true. The Ready
+// condition is only set by the readiness probe, so a VM without a readiness
+// probe is ready once it has been created and is powered on.
+func IsVirtualMachineReady(vm *vmopv1.VirtualMachine) bool {
+	if !vm.DeletionTimestamp.IsZero() {
+		return false
+	}
+
+	if vm.Spec.ReadinessProbe != nil {
+		return conditions.IsTrue(vm, vmopv1.ReadyConditionType)
+	}
+
+	return conditions.IsTrue(vm, vmopv1.VirtualMachineConditionCreated) &&
+		vm.Status.PowerState == vmopv1.VirtualMachinePowerStateOn
+}
+
+// GetVirtualMachinesForDisruptionBudget returns the VMs selected by the
+// provided VirtualMachineDisruptionBudget.
+func GetVirtualMachinesForDisruptionBudget(
+	ctx context.Context,
+	k8sClient client.Client,
+	budget *vmopv1.VirtualMachineDisruptionBudget) ([]vmopv1.VirtualMachine, error) {
+
+	selector, err := metav1.LabelSelectorAsSelector(budget.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert VirtualMachineDisruptionBudget %q selector: %w", budget.Name, err)
+	}
+
+	vmList := &vmopv1.VirtualMachineList{}
+	if err := k8sClient.List(
+		ctx,
+		vmList,
+		client.InNamespace(budget.Namespace),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+
+		return nil, fmt.Errorf("failed to list VirtualMachines for VirtualMachineDisruptionBudget %q: %w", budget.Name, err)
+	}
+
+	return vmList.Items, nil
+}
+
+// GetDisruptionBudgetsForVirtualMachine returns the
+// VirtualMachineDisruptionBudgets that select the provided VM.
+func GetDisruptionBudgetsForVirtualMachine(
+	ctx context.Context,
+	k8sClient client.Client,
+	vm *vmopv1.VirtualMachine) ([]vmopv1.VirtualMachineDisruptionBudget, error) {
+
+	budgetList := &vmopv1.VirtualMachineDisruptionBudgetList{}
+	if err := k8sClient.List(ctx, budgetList, client.InNamespace(vm.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list VirtualMachineDisruptionBudgets: %w", err)
+	}
+
+	var budgets []vmopv1.VirtualMachineDisruptionBudget
+	for i := range budgetList.Items {
+		selector, err := metav1.LabelSelectorAsSelector(budgetList.Items[i].Spec.Selector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+		if selector.Matches(labels.Set(vm.Labels)) {
+			budgets = append(budgets, budgetList.Items[i])
+		}
+	}
+
+	return budgets, nil
+}
+
+// ComputeDisruptionBudgetStatus returns the expected, healthy, desired, and
+// allowed disruption counts of the provided budget over the VMs it selects.
+// The VMs named in disrupted are not considered healthy, which allows a
+// caller to account for disruptions not yet observed in vms.
+func ComputeDisruptionBudgetStatus(
+	budget *vmopv1.VirtualMachineDisruptionBudget,
+	vms []vmopv1.VirtualMachine,
+	disrupted ...string) (vmopv1.VirtualMachineDisruptionBudgetStatus, error) {
+
+	var status vmopv1.VirtualMachineDisruptionBudgetStatus
+
+	for i := range vms {
+		if !vms[i].DeletionTimestamp.IsZero() {
+			continue
+		}
+		status.ExpectedVMs++
+		if IsVirtualMachineReady(&vms[i]) && !slices.Contains(disrupted, vms[i].Name) {
+			status.CurrentHealthy++
+		}
+	}
+
+	switch {
+	case budget.Spec.MinAvailable != nil:
+		minAvailable, err := intstr.GetScaledValueFromIntOrPercent(
+			budget.Spec.MinAvailable, int(status.ExpectedVMs), true)
+		if err != nil {
+			return status, fmt.Errorf("invalid minAvailable: %w", err)
+		}
+		status.DesiredHealthy = int32(minAvailable)
+	case budget.Spec.MaxUnavailable != nil:
+		maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(
+			budget.Spec.MaxUnavailable, int(status.ExpectedVMs), true)
+		if err != nil {
+			return status, fmt.Errorf("invalid maxUnavailable: %w", err)
+		}
+		status.DesiredHealthy = max(status.ExpectedVMs-int32(maxUnavailable), 0)
+	default:
+		return status, errors.New("one of minAvailable or maxUnavailable must be specified")
+	}
+
+	status.DisruptionsAllowed = max(status.CurrentHealthy-status.DesiredHealthy, 0)
+
+	return status, nil
+}
+
+// CheckDisruptionAllowed returns an error that wraps ErrDisruptionNotAllowed
+// if deleting, powering off, or suspending the provided VM would violate one
+// of the VirtualMachineDisruptionBudgets that select it. Disrupting a VM that
+// is not ready is always allowed since it does not reduce the number of
+// healthy VMs. The VMs named in disrupted are treated as already disrupted.
+//
+// The check is not atomic with the disruption that follows it: the budgets and
+// VMs are read without reserving a disruption, so concurrent disruptions of
+// different VMs may each be allowed and together exceed the budget. The
+// budget's status catches up on the next reconcile of the budget.
+func CheckDisruptionAllowed(
+	ctx context.Context,
+	k8sClient client.Client,
+	vm *vmopv1.VirtualMachine,
+	disrupted ...string) error {
+
+	if !IsVirtualMachineReady(vm) || slices.Contains(disrupted, vm.Name) {
+		return nil
+	}
+
+	budgets, err := GetDisruptionBudgetsForVirtualMachine(ctx, k8sClient, vm)
+	if err != nil {
+		return err
+	}
+
+	for i := range budgets {
+		budget := &budgets[i]
+
+		vms, err := GetVirtualMachinesForDisruptionBudget(ctx, k8sClient, budget)
+		if err != nil {
+			return err
+		}
+
+		status, err := ComputeDisruptionBudgetStatus(budget, vms, disrupted...)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate VirtualMachineDisruptionBudget %q: %w", budget.Name, err)
+		}
+
+		if status.DisruptionsAllowed < 1 {
+			return fmt.Errorf(
+				"%w: VirtualMachineDisruptionBudget %q requires %d healthy VMs and %d are healthy",
+				ErrDisruptionNotAllowed, budget.Name, status.DesiredHealthy, status.CurrentHealthy)
+		}
+	}
+
+	return nil
+}
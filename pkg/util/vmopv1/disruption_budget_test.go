@@ -0,0 +1,190 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+// newDisruptionBudgetVM returns a VM selected by the dummy disruption budget
+// that is ready if ready is true.
+func newDisruptionBudgetVM(name string, ready bool) *vmopv1.VirtualMachine {
+	vm := builder.DummyVirtualMachine()
+	vm.Name = name
+	vm.Namespace = "my-namespace"
+	vm.Labels["app"] = "dummy"
+	conditions.MarkTrue(vm, vmopv1.VirtualMachineConditionCreated)
+	if ready {
+		vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+	} else {
+		vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+	}
+	return vm
+}
+
+var _ = Describe("IsVirtualMachineReady", func() {
+
+	var vm *vmopv1.VirtualMachine
+
+	BeforeEach(func() {
+		vm = newDisruptionBudgetVM("my-vm", true)
+	})
+
+	It("Returns true for a created and powered on VM", func() {
+		Expect(vmopv1util.IsVirtualMachineReady(vm)).To(BeTrue())
+	})
+
+	It("Returns false for a powered off VM", func() {
+		vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+		Expect(vmopv1util.IsVirtualMachineReady(vm)).To(BeFalse())
+	})
+
+	It("Uses the Ready condition for a VM with a readiness probe", func() {
+		vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{}
+		Expect(vmopv1util.IsVirtualMachineReady(vm)).To(BeFalse())
+
+		conditions.MarkTrue(vm, vmopv1.ReadyConditionType)
+		Expect(vmopv1util.IsVirtualMachineReady(vm)).To(BeTrue())
+	})
+})
+
+var _ = Describe("ComputeDisruptionBudgetStatus", func() {
+
+	var (
+		budget *vmopv1.VirtualMachineDisruptionBudget
+		vms    []vmopv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		budget = builder.DummyVirtualMachineDisruptionBudget()
+		vms = []vmopv1.VirtualMachine{
+			*newDisruptionBudgetVM("vm-1", true),
+			*newDisruptionBudgetVM("vm-2", true),
+			*newDisruptionBudgetVM("vm-3", true),
+			*newDisruptionBudgetVM("vm-4", false),
+		}
+	})
+
+	DescribeTable("Computes the status",
+		func(minAvailable, maxUnavailable *intstr.IntOrString, disrupted []string, expected vmopv1.VirtualMachineDisruptionBudgetStatus) {
+			budget.Spec.MinAvailable = minAvailable
+			budget.Spec.MaxUnavailable = maxUnavailable
+
+			status, err := vmopv1util.ComputeDisruptionBudgetStatus(budget, vms, disrupted...)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status).To(Equal(expected))
+		},
+		Entry("minAvailable",
+			ptr.To(intstr.FromInt32(2)), nil, nil,
+			vmopv1.VirtualMachineDisruptionBudgetStatus{
+				ExpectedVMs: 4, CurrentHealthy: 3, DesiredHealthy: 2, DisruptionsAllowed: 1,
+			}),
+		Entry("minAvailable percentage rounded up",
+			ptr.To(intstr.FromString("60%")), nil, nil,
+			vmopv1.VirtualMachineDisruptionBudgetStatus{
+				ExpectedVMs: 4, CurrentHealthy: 3, DesiredHealthy: 3, DisruptionsAllowed: 0,
+			}),
+		Entry("maxUnavailable",
+			nil, ptr.To(intstr.FromInt32(2)), nil,
+			vmopv1.VirtualMachineDisruptionBudgetStatus{
+				ExpectedVMs: 4, CurrentHealthy: 3, DesiredHealthy: 2, DisruptionsAllowed: 1,
+			}),
+		Entry("maxUnavailable greater than the expected VMs",
+			nil, ptr.To(intstr.FromInt32(10)), nil,
+			vmopv1.VirtualMachineDisruptionBudgetStatus{
+				ExpectedVMs: 4, CurrentHealthy: 3, DesiredHealthy: 0, DisruptionsAllowed: 3,
+			}),
+		Entry("disrupted VMs are not healthy",
+			ptr.To(intstr.FromInt32(1)), nil, []string{"vm-1", "vm-4"},
+			vmopv1.VirtualMachineDisruptionBudgetStatus{
+				ExpectedVMs: 4, CurrentHealthy: 2, DesiredHealthy: 1, DisruptionsAllowed: 1,
+			}),
+	)
+
+	It("Returns an error if neither minAvailable nor maxUnavailable is specified", func() {
+		budget.Spec.MinAvailable = nil
+		_, err := vmopv1util.ComputeDisruptionBudgetStatus(budget, vms)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("CheckDisruptionAllowed", func() {
+
+	var (
+		ctx       context.Context
+		k8sClient ctrlclient.Client
+		budget    *vmopv1.VirtualMachineDisruptionBudget
+		vm1, vm2  *vmopv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		budget = builder.DummyVirtualMachineDisruptionBudget()
+		budget.GenerateName = ""
+		budget.Name = "my-budget"
+		budget.Namespace = "my-namespace"
+
+		vm1 = newDisruptionBudgetVM("vm-1", true)
+		vm2 = newDisruptionBudgetVM("vm-2", true)
+	})
+
+	JustBeforeEach(func() {
+		k8sClient = builder.NewFakeClient(budget, vm1, vm2)
+	})
+
+	It("Allows disrupting one of the two healthy VMs", func() {
+		Expect(vmopv1util.CheckDisruptionAllowed(ctx, k8sClient, vm1)).To(Succeed())
+	})
+
+	It("Does not allow disrupting the second healthy VM", func() {
+		err := vmopv1util.CheckDisruptionAllowed(ctx, k8sClient, vm2, vm1.Name)
+		Expect(err).To(MatchError(vmopv1util.ErrDisruptionNotAllowed))
+		Expect(err.Error()).To(Equal(fmt.Sprintf(
+			"disruption not allowed: VirtualMachineDisruptionBudget %q requires 1 healthy VMs and 1 are healthy",
+			budget.Name)))
+	})
+
+	It("Allows disrupting a VM that was already disrupted", func() {
+		Expect(vmopv1util.CheckDisruptionAllowed(ctx, k8sClient, vm1, vm1.Name, vm2.Name)).To(Succeed())
+	})
+
+	When("the other VM is not ready", func() {
+		BeforeEach(func() {
+			vm2.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+		})
+
+		It("Does not allow disrupting the ready VM", func() {
+			Expect(vmopv1util.CheckDisruptionAllowed(ctx, k8sClient, vm1)).To(MatchError(vmopv1util.ErrDisruptionNotAllowed))
+		})
+
+		It("Allows disrupting the VM that is not ready", func() {
+			Expect(vmopv1util.CheckDisruptionAllowed(ctx, k8sClient, vm2)).To(Succeed())
+		})
+	})
+
+	When("the VM is not selected by the budget", func() {
+		BeforeEach(func() {
+			vm1.Labels["app"] = "other"
+			vm2.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+		})
+
+		It("Allows disrupting the VM", func() {
+			Expect(vmopv1util.CheckDisruptionAllowed(ctx, k8sClient, vm1)).To(Succeed())
+		})
+	})
+})
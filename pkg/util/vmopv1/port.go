@@ -0,0 +1,46 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+)
+
+// FindPort returns the number of the provided port on the VM. A port number
+// is returned as is, while a port name is resolved from the VM's
+// spec.network.ports with the same name and protocol. An empty protocol on
+// either side is treated as TCP.
+func FindPort(
+	vm vmopv1.VirtualMachine,
+	port intstr.IntOrString,
+	protocol corev1.Protocol) (int, error) {
+
+	switch port.Type {
+	case intstr.Int:
+		return port.IntValue(), nil
+	case intstr.String:
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		if vm.Spec.Network != nil {
+			for _, p := range vm.Spec.Network.Ports {
+				pProtocol := p.Protocol
+				if pProtocol == "" {
+					pProtocol = corev1.ProtocolTCP
+				}
+				if p.Name == port.StrVal && pProtocol == protocol {
+					return int(p.Port), nil
+				}
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("no port %q with protocol %s on VM %s",
+		port.String(), protocol, vm.NamespacedName())
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+var _ = Describe("FindPort", func() {
+	var vm vmopv1.VirtualMachine
+
+	BeforeEach(func() {
+		vm = vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-vm",
+				Namespace: "my-namespace",
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				Network: &vmopv1.VirtualMachineNetworkSpec{
+					Ports: []vmopv1.VirtualMachineNetworkPort{
+						{
+							Name: "http",
+							Port: 8080,
+						},
+						{
+							Name:     "dns",
+							Port:     5353,
+							Protocol: corev1.ProtocolUDP,
+						},
+						{
+							Name:     "dns",
+							Port:     5354,
+							Protocol: corev1.ProtocolTCP,
+						},
+					},
+				},
+			},
+		}
+	})
+
+	DescribeTable("Tests",
+		func(port intstr.IntOrString, protocol corev1.Protocol, expectedPort int, expectedErr bool) {
+			portNum, err := vmopv1util.FindPort(vm, port, protocol)
+			if expectedErr {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(portNum).To(Equal(expectedPort))
+			}
+		},
+		Entry("port number", intstr.FromInt32(80), corev1.ProtocolTCP, 80, false),
+		Entry("port name", intstr.FromString("http"), corev1.ProtocolTCP, 8080, false),
+		Entry("port name with empty protocol", intstr.FromString("http"), corev1.Protocol(""), 8080, false),
+		Entry("port name with UDP protocol", intstr.FromString("dns"), corev1.ProtocolUDP, 5353, false),
+		Entry("port name shared with TCP protocol", intstr.FromString("dns"), corev1.ProtocolTCP, 5354, false),
+		Entry("port name with mismatched protocol", intstr.FromString("http"), corev1.ProtocolUDP, 0, true),
+		Entry("unknown port name", intstr.FromString("metrics"), corev1.ProtocolTCP, 0, true),
+	)
+
+	When("the VM does not have a network spec", func() {
+		BeforeEach(func() {
+			vm.Spec.Network = nil
+		})
+
+		It("returns an error for a port name", func() {
+			_, err := vmopv1util.FindPort(vm, intstr.FromString("http"), corev1.ProtocolTCP)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
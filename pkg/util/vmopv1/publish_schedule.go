@@ -0,0 +1,110 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+)
+
+const (
+	// DefaultPublishScheduleItemNameTemplate is the template for the name of
+	// an item published by a VirtualMachinePublishSchedule when the schedule
+	// does not specify one.
+	DefaultPublishScheduleItemNameTemplate = "{{ .SourceName }}-image-{{ .Timestamp }}"
+
+	// PublishScheduleTimestampFormat is the format of the Timestamp value
+	// available to the templates of a VirtualMachinePublishSchedule.
+	PublishScheduleTimestampFormat = "20060102150405"
+)
+
+// PublishScheduleTemplateData is the data available to the templates of a
+// VirtualMachinePublishSchedule's target.
+type PublishScheduleTemplateData struct {
+	SourceName string
+	Revision   int64
+	Timestamp  string
+	Time       time.Time
+}
+
+// NewPublishScheduleTemplateData returns the template data for the
+// publication with the provided revision that was scheduled at the provided
+// time.
+func NewPublishScheduleTemplateData(
+	sched *vmopv1.VirtualMachinePublishSchedule,
+	revision int64,
+	t time.Time) PublishScheduleTemplateData {
+
+	t = t.UTC()
+	return PublishScheduleTemplateData{
+		SourceName: PublishScheduleSourceName(sched),
+		Revision:   revision,
+		Timestamp:  t.Format(PublishScheduleTimestampFormat),
+		Time:       t,
+	}
+}
+
+// PublishScheduleSourceName returns the name of the VM published by the
+// provided schedule.
+func PublishScheduleSourceName(sched *vmopv1.VirtualMachinePublishSchedule) string {
+	if name := sched.Spec.Source.Name; name != "" {
+		return name
+	}
+	return sched.Name
+}
+
+// RenderPublishScheduleTarget returns the target of a
+// VirtualMachinePublishRequest created by the provided schedule with the
+// templates in the schedule's target rendered using the provided data.
+func RenderPublishScheduleTarget(
+	sched *vmopv1.VirtualMachinePublishSchedule,
+	data PublishScheduleTemplateData) (vmopv1.VirtualMachinePublishRequestTarget, error) {
+
+	target := *sched.Spec.Target.DeepCopy()
+
+	itemNameTmpl := target.Item.Name
+	if itemNameTmpl == "" {
+		itemNameTmpl = DefaultPublishScheduleItemNameTemplate
+	}
+	itemName, err := renderPublishScheduleTemplate("item name", itemNameTmpl, data)
+	if err != nil {
+		return target, err
+	}
+	if itemName == "" {
+		return target, errors.New("item name template rendered an empty name")
+	}
+	target.Item.Name = itemName
+
+	if reg := target.OCIRegistry; reg != nil && reg.Tag != "" {
+		tag, err := renderPublishScheduleTemplate("tag", reg.Tag, data)
+		if err != nil {
+			return target, err
+		}
+		reg.Tag = tag
+	}
+
+	return target, nil
+}
+
+func renderPublishScheduleTemplate(
+	name, text string,
+	data PublishScheduleTemplateData) (string, error) {
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s template: %w", name, err)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+
+	return strings.TrimSpace(sb.String()), nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("RenderPublishScheduleTarget", func() {
+
+	var (
+		sched *vmopv1.VirtualMachinePublishSchedule
+		data  vmopv1util.PublishScheduleTemplateData
+	)
+
+	BeforeEach(func() {
+		sched = builder.DummyVirtualMachinePublishSchedule("my-schedule", "my-ns", "my-vm", "my-cl")
+	})
+
+	JustBeforeEach(func() {
+		data = vmopv1util.NewPublishScheduleTemplateData(
+			sched, 3, time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC))
+	})
+
+	It("Uses the default item name template", func() {
+		target, err := vmopv1util.RenderPublishScheduleTarget(sched, data)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(target.Item.Name).To(Equal("my-vm-image-20240506070809"))
+		Expect(target.Location.Name).To(Equal("my-cl"))
+		Expect(sched.Spec.Target.Item.Name).To(BeEmpty())
+	})
+
+	When("the source name is omitted", func() {
+		BeforeEach(func() {
+			sched.Spec.Source.Name = ""
+		})
+		It("Uses the name of the schedule", func() {
+			target, err := vmopv1util.RenderPublishScheduleTarget(sched, data)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(target.Item.Name).To(Equal("my-schedule-image-20240506070809"))
+		})
+	})
+
+	When("the item name is a template", func() {
+		BeforeEach(func() {
+			sched.Spec.Target.Item.Name = `golden-{{ .SourceName }}-v{{ .Revision }}-{{ .Time.Format "2006.01.02" }}`
+		})
+		It("Renders the template", func() {
+			target, err := vmopv1util.RenderPublishScheduleTarget(sched, data)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(target.Item.Name).To(Equal("golden-my-vm-v3-2024.05.06"))
+		})
+	})
+
+	When("the OCI registry tag is a template", func() {
+		BeforeEach(func() {
+			sched.Spec.Target.Location.Name = ""
+			sched.Spec.Target.OCIRegistry = &vmopv1.VirtualMachinePublishRequestTargetOCIRegistry{
+				Repository: "registry.example.com/my-vm",
+				Tag:        "v{{ .Revision }}",
+			}
+		})
+		It("Renders the template", func() {
+			target, err := vmopv1util.RenderPublishScheduleTarget(sched, data)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(target.OCIRegistry.Tag).To(Equal("v3"))
+			Expect(sched.Spec.Target.OCIRegistry.Tag).To(Equal("v{{ .Revision }}"))
+		})
+	})
+
+	DescribeTable("Invalid templates",
+		func(itemName, expectedErr string) {
+			sched.Spec.Target.Item.Name = itemName
+			_, err := vmopv1util.RenderPublishScheduleTarget(sched, data)
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		},
+		Entry("unparsable", "{{ .SourceName", "invalid item name template"),
+		Entry("unknown field", "{{ .Unknown }}", "failed to render item name template"),
+		Entry("empty result", "{{ if false }}x{{ end }}", "rendered an empty name"),
+	)
+})
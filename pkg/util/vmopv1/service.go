@@ -0,0 +1,73 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1
+
+import (
+	"context"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+)
+
+const (
+	// NSXTLoadBalancerProviderType is the type of the NSX-T load balancer
+	// provider.
+	NSXTLoadBalancerProviderType = "nsx-t-lb"
+
+	// AnnotationServiceExternalTrafficPolicyKey is the legacy annotation that
+	// requests the external traffic policy of a VirtualMachineService.
+	AnnotationServiceExternalTrafficPolicyKey = "virtualmachineservice.vmoperator.vmware.com/service.externalTrafficPolicy"
+
+	// AnnotationServiceHealthCheckNodePortKey is the legacy annotation that
+	// requests the health check node port of a VirtualMachineService.
+	AnnotationServiceHealthCheckNodePortKey = "virtualmachineservice.vmoperator.vmware.com/service.healthCheckNodePort"
+)
+
+// LoadBalancerProviderType returns the configured load balancer provider
+// type. When no provider is configured, the NSX-T provider is used with
+// NSX-T networking.
+func LoadBalancerProviderType(ctx context.Context) string {
+	lbProviderType := pkgcfg.FromContext(ctx).LoadBalancerProvider
+	if lbProviderType == "" {
+		if pkgcfg.FromContext(ctx).NetworkProviderType == pkgcfg.NetworkProviderTypeNSXT {
+			lbProviderType = NSXTLoadBalancerProviderType
+		}
+	}
+	return lbProviderType
+}
+
+// BypassesKubeProxy returns true if the Service for the VirtualMachineService
+// is implemented by a service proxy other than kube-proxy. The endpoints of a
+// VirtualMachineService do not have a node, so kube-proxy drops the traffic of
+// a Service with a Local traffic policy. Only the NSX-T provider bypasses
+// kube-proxy, and only when the external traffic policy is Local.
+func BypassesKubeProxy(ctx context.Context, vmService *vmopv1.VirtualMachineService) bool {
+	return LoadBalancerProviderType(ctx) == NSXTLoadBalancerProviderType &&
+		vmService.Spec.Type == vmopv1.VirtualMachineServiceTypeLoadBalancer &&
+		ExternalTrafficPolicy(vmService) == corev1.ServiceExternalTrafficPolicyTypeLocal
+}
+
+// ExternalTrafficPolicy returns the external traffic policy requested for the
+// VirtualMachineService. The spec field takes precedence over the legacy
+// annotation. An empty string is returned when neither is set.
+func ExternalTrafficPolicy(vmService *vmopv1.VirtualMachineService) corev1.ServiceExternalTrafficPolicyType {
+	if etp := vmService.Spec.ExternalTrafficPolicy; etp != "" {
+		return corev1.ServiceExternalTrafficPolicyType(etp)
+	}
+	return corev1.ServiceExternalTrafficPolicyType(vmService.Annotations[AnnotationServiceExternalTrafficPolicyKey])
+}
+
+// HealthCheckNodePort returns the health check node port requested for the
+// VirtualMachineService as a string, and whether one was requested. The spec
+// field takes precedence over the legacy annotation.
+func HealthCheckNodePort(vmService *vmopv1.VirtualMachineService) (string, bool) {
+	if port := vmService.Spec.HealthCheckNodePort; port != 0 {
+		return strconv.Itoa(int(port)), true
+	}
+	port, ok := vmService.Annotations[AnnotationServiceHealthCheckNodePortKey]
+	return port, ok
+}
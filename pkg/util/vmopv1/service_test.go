@@ -0,0 +1,85 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+var _ = Describe("ExternalTrafficPolicy", func() {
+	var vmService *vmopv1.VirtualMachineService
+
+	BeforeEach(func() {
+		vmService = &vmopv1.VirtualMachineService{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-vm-service",
+				Namespace: "my-namespace",
+			},
+		}
+	})
+
+	It("returns empty when neither the field nor the annotation is set", func() {
+		Expect(vmopv1util.ExternalTrafficPolicy(vmService)).To(BeEmpty())
+	})
+
+	It("returns the annotation", func() {
+		vmService.Annotations = map[string]string{
+			vmopv1util.AnnotationServiceExternalTrafficPolicyKey: string(corev1.ServiceExternalTrafficPolicyTypeLocal),
+		}
+		Expect(vmopv1util.ExternalTrafficPolicy(vmService)).To(Equal(corev1.ServiceExternalTrafficPolicyTypeLocal))
+	})
+
+	It("returns the field over the annotation", func() {
+		vmService.Annotations = map[string]string{
+			vmopv1util.AnnotationServiceExternalTrafficPolicyKey: string(corev1.ServiceExternalTrafficPolicyTypeLocal),
+		}
+		vmService.Spec.ExternalTrafficPolicy = vmopv1.ServiceExternalTrafficPolicyCluster
+		Expect(vmopv1util.ExternalTrafficPolicy(vmService)).To(Equal(corev1.ServiceExternalTrafficPolicyTypeCluster))
+	})
+})
+
+var _ = Describe("BypassesKubeProxy", func() {
+	var (
+		ctx       context.Context
+		vmService *vmopv1.VirtualMachineService
+	)
+
+	BeforeEach(func() {
+		ctx = pkgcfg.WithConfig(pkgcfg.Config{
+			NetworkProviderType: pkgcfg.NetworkProviderTypeNSXT,
+		})
+		vmService = &vmopv1.VirtualMachineService{
+			Spec: vmopv1.VirtualMachineServiceSpec{
+				Type:                  vmopv1.VirtualMachineServiceTypeLoadBalancer,
+				ExternalTrafficPolicy: vmopv1.ServiceExternalTrafficPolicyLocal,
+			},
+		}
+	})
+
+	It("returns true for a Local LoadBalancer with the NSX-T provider", func() {
+		Expect(vmopv1util.BypassesKubeProxy(ctx, vmService)).To(BeTrue())
+	})
+
+	It("returns false when the external traffic policy is Cluster", func() {
+		vmService.Spec.ExternalTrafficPolicy = vmopv1.ServiceExternalTrafficPolicyCluster
+		Expect(vmopv1util.BypassesKubeProxy(ctx, vmService)).To(BeFalse())
+	})
+
+	It("returns false with another load balancer provider", func() {
+		ctx = pkgcfg.WithConfig(pkgcfg.Config{
+			NetworkProviderType: pkgcfg.NetworkProviderTypeVDS,
+		})
+		Expect(vmopv1util.BypassesKubeProxy(ctx, vmService)).To(BeFalse())
+	})
+})
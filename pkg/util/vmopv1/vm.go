@@ -221,6 +221,28 @@ func IsImagelessVM(vm vmopv1.VirtualMachine) bool {
 	return vm.Spec.Image == nil && vm.Spec.ImageName == ""
 }
 
+// LivenessProbeToReadinessProbe returns the action and timing of the provided
+// liveness probe as a readiness probe spec. This allows the same probes and
+// validation to be used for both kinds of probe. Nil is returned if the
+// provided liveness probe is nil.
+func LivenessProbeToReadinessProbe(
+	p *vmopv1.VirtualMachineLivenessProbeSpec) *vmopv1.VirtualMachineReadinessProbeSpec {
+
+	if p == nil {
+		return nil
+	}
+
+	return &vmopv1.VirtualMachineReadinessProbeSpec{
+		TCPSocket:           p.TCPSocket,
+		HTTPGet:             p.HTTPGet,
+		GuestHeartbeat:      p.GuestHeartbeat,
+		GuestInfo:           p.GuestInfo,
+		TimeoutSeconds:      p.TimeoutSeconds,
+		PeriodSeconds:       p.PeriodSeconds,
+		InitialDelaySeconds: p.InitialDelaySeconds,
+	}
+}
+
 // SyncStorageUsageForNamespace updates the StoragePolicyUsage resource for
 // the given namespace and storage class with the reported usage information
 // for VMs in that namespace that use the specified storage class.
@@ -503,6 +503,40 @@ var _ = DescribeTable("IsImageLessVM",
 	),
 )
 
+var _ = DescribeTable("LivenessProbeToReadinessProbe",
+	func(
+		in *vmopv1.VirtualMachineLivenessProbeSpec,
+		expected *vmopv1.VirtualMachineReadinessProbeSpec,
+	) {
+		Ω(vmopv1util.LivenessProbeToReadinessProbe(in)).Should(Equal(expected))
+	},
+	Entry(
+		"nil",
+		nil,
+		nil,
+	),
+	Entry(
+		"guest heartbeat",
+		&vmopv1.VirtualMachineLivenessProbeSpec{
+			GuestHeartbeat: &vmopv1.GuestHeartbeatAction{
+				ThresholdStatus: vmopv1.GreenHeartbeatStatus,
+			},
+			TimeoutSeconds:      5,
+			PeriodSeconds:       30,
+			InitialDelaySeconds: 120,
+			FailureThreshold:    4,
+		},
+		&vmopv1.VirtualMachineReadinessProbeSpec{
+			GuestHeartbeat: &vmopv1.GuestHeartbeatAction{
+				ThresholdStatus: vmopv1.GreenHeartbeatStatus,
+			},
+			TimeoutSeconds:      5,
+			PeriodSeconds:       30,
+			InitialDelaySeconds: 120,
+		},
+	),
+)
+
 var _ = Describe("SyncStorageUsageForNamespace", func() {
 	var (
 		ctx          context.Context
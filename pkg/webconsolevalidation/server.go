@@ -7,14 +7,18 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 
 	vmopv1a1 "github.com/vmware-tanzu/vm-operator/api/v1alpha1"
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineserialconsolerequest"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinewebconsolerequest/v1alpha1"
 )
 
@@ -70,7 +74,12 @@ func (s *Server) Run() error {
 }
 
 // HandleWebConsoleValidation verifies a web console validation request by
-// checking if a WebConsoleRequest resource exists with the given UUID in query.
+// checking if a WebConsoleRequest or VirtualMachineWebConsoleRequest resource
+// exists with the given UUID in query and has neither expired nor been
+// revoked. Connections from the serial port concentrator are verified against
+// VirtualMachineSerialConsoleRequest resources in the same way, and must also
+// include the serviceURI of the VM's serial port in query so the request can
+// be tied to the VM being connected to.
 func (s *Server) HandleWebConsoleValidation(w http.ResponseWriter, r *http.Request) {
 	uuid := r.URL.Query().Get("uuid")
 	if uuid == "" {
@@ -84,9 +93,11 @@ func (s *Server) HandleWebConsoleValidation(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	serviceURI := r.URL.Query().Get("serviceURI")
+
 	logger := ctrllog.Log.WithName(r.URL.Path).WithValues("uuid", uuid).WithValues("namespace", namespace)
 
-	found, err := isResourceFound(r.Context(), uuid, namespace, s.KubeClient)
+	found, err := isResourceFound(ctrllog.IntoContext(r.Context(), logger), uuid, namespace, serviceURI, s.KubeClient)
 	if err != nil {
 		logger.Error(err, "Error occurred in finding a webconsolerequest resource with the given params.")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -97,20 +108,65 @@ func (s *Server) HandleWebConsoleValidation(w http.ResponseWriter, r *http.Reque
 		logger.Info("Found a webconsolerequest resource with the given params. Returning 200.")
 		w.WriteHeader(http.StatusOK)
 	} else {
-		logger.Info("Didn't find a webconsolerequest resource with the given params. Returning 403.")
+		logger.Info("Didn't find an active webconsolerequest resource with the given params. Returning 403.")
 		w.WriteHeader(http.StatusForbidden)
 	}
 }
 
+// isResourceFound returns true if an active request with the given UUID exists
+// in the namespace. A connection from the serial port concentrator includes
+// the serviceURI of the VM's serial port, and is only verified against
+// VirtualMachineSerialConsoleRequests for that VM, so a web console request
+// cannot be used to connect to a serial console.
 func isResourceFound(
+	ctx context.Context,
+	uuid, namespace, serviceURI string,
+	kubeClient ctrlclient.Client) (bool, error) {
+
+	// TODO: Use an Informer to avoid hitting the API server for every request.
+	// Not using an Informer also ensures that a request that is deleted or
+	// revoked is refused immediately.
+	if serviceURI != "" {
+		return isSerialConsoleRequestFound(ctx, uuid, namespace, serviceURI, kubeClient)
+	}
+	return isWebConsoleRequestFound(ctx, uuid, namespace, kubeClient)
+}
+
+func isWebConsoleRequestFound(
 	ctx context.Context,
 	uuid, namespace string,
 	kubeClient ctrlclient.Client) (bool, error) {
 	labelSelector := ctrlclient.MatchingLabels{
 		v1alpha1.UUIDLabelKey: uuid,
 	}
+	logger := ctrllog.FromContext(ctx)
+	now := time.Now()
+
+	vmWcrObjectList := &vmopv1.VirtualMachineWebConsoleRequestList{}
+	if err := kubeClient.List(
+		ctx,
+		vmWcrObjectList,
+		ctrlclient.InNamespace(namespace),
+		labelSelector,
+	); err != nil {
+		return false, err
+	}
+
+	for _, wcr := range vmWcrObjectList.Items {
+		switch {
+		case wcr.Spec.Revoked:
+			logger.Info("Refusing revoked webconsolerequest",
+				"name", wcr.Name, "requester", wcr.Status.Requester)
+		case isExpired(wcr.Status.ExpiryTime, now):
+			logger.Info("Refusing expired webconsolerequest",
+				"name", wcr.Name, "requester", wcr.Status.Requester)
+		default:
+			logger.Info("Found active webconsolerequest",
+				"name", wcr.Name, "requester", wcr.Status.Requester)
+			return true, nil
+		}
+	}
 
-	// TODO: Use an Informer to avoid hitting the API server for every request.
 	wcrObjectList := &vmopv1a1.WebConsoleRequestList{}
 	if err := kubeClient.List(
 		ctx,
@@ -121,5 +177,73 @@ func isResourceFound(
 		return false, err
 	}
 
-	return len(wcrObjectList.Items) > 0, nil
+	for _, wcr := range wcrObjectList.Items {
+		if !isExpired(wcr.Status.ExpiryTime, now) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// isSerialConsoleRequestFound returns true if an active serial console request
+// with the given UUID exists for the VM from the serial port's service URI.
+func isSerialConsoleRequestFound(
+	ctx context.Context,
+	uuid, namespace, serviceURI string,
+	kubeClient ctrlclient.Client) (bool, error) {
+	logger := ctrllog.FromContext(ctx)
+	now := time.Now()
+
+	vmNamespace, vmName, ok := parseSerialConsoleServiceURI(serviceURI)
+	if !ok || vmNamespace != namespace {
+		logger.Info("Refusing serial console connection with an invalid service URI",
+			"serviceURI", serviceURI)
+		return false, nil
+	}
+
+	scrObjectList := &vmopv1.VirtualMachineSerialConsoleRequestList{}
+	if err := kubeClient.List(
+		ctx,
+		scrObjectList,
+		ctrlclient.InNamespace(namespace),
+		ctrlclient.MatchingLabels{
+			virtualmachineserialconsolerequest.UUIDLabelKey: uuid,
+		},
+	); err != nil {
+		return false, err
+	}
+
+	for _, scr := range scrObjectList.Items {
+		switch {
+		case scr.Spec.Name != vmName:
+			logger.Info("Refusing serialconsolerequest for a different VM",
+				"name", scr.Name, "vmName", scr.Spec.Name, "serviceURI", serviceURI)
+		case scr.Status.Response == "" || scr.Status.ExpiryTime.IsZero():
+			logger.Info("Refusing serialconsolerequest that is not ready", "name", scr.Name)
+		case isExpired(scr.Status.ExpiryTime, now):
+			logger.Info("Refusing expired serialconsolerequest", "name", scr.Name)
+		default:
+			logger.Info("Found active serialconsolerequest", "name", scr.Name)
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// parseSerialConsoleServiceURI returns the namespace and name of the VM from
+// the service URI of its serial console port, which has the form
+// "vmoperator/<namespace>/<name>".
+func parseSerialConsoleServiceURI(serviceURI string) (string, string, bool) {
+	parts := strings.Split(strings.Trim(serviceURI, "/"), "/")
+	if len(parts) != 3 || parts[0] != "vmoperator" || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
+// isExpired returns true if the expiry time is set and has passed.
+func isExpired(expiryTime metav1.Time, now time.Time) bool {
+	return !expiryTime.IsZero() && !now.Before(expiryTime.Time)
 }
@@ -12,12 +12,15 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	vmopv1a1 "github.com/vmware-tanzu/vm-operator/api/v1alpha1"
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineserialconsolerequest"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinewebconsolerequest/v1alpha1"
 	"github.com/vmware-tanzu/vm-operator/pkg/webconsolevalidation"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
@@ -231,6 +234,200 @@ func serverUnitTests() {
 				})
 
 			})
+
+			When("the request includes the service URI of a serial console", func() {
+
+				It("should return http.StatusForbidden (403)", func() {
+					url := "/?uuid=test-uuid-1234&namespace=test-namespace&serviceURI=vmoperator/test-namespace/test-vm"
+					responseCode := fakeValidationRequest(url, server)
+					Expect(responseCode).To(Equal(http.StatusForbidden))
+				})
+
+			})
+
+			When("UUID matches an expired WebConsoleRequest resource", func() {
+
+				BeforeEach(func() {
+					wcr := initObjects[0].(*vmopv1a1.WebConsoleRequest)
+					wcr.Status.ExpiryTime = metav1.NewTime(time.Now().Add(-time.Minute))
+				})
+
+				It("should return http.StatusForbidden (403)", func() {
+					url := "/?uuid=test-uuid-1234&namespace=test-namespace"
+					responseCode := fakeValidationRequest(url, server)
+					Expect(responseCode).To(Equal(http.StatusForbidden))
+				})
+
+			})
+		})
+
+		Context("requests for a VirtualMachineWebConsoleRequest", func() {
+
+			var (
+				wcr *vmopv1.VirtualMachineWebConsoleRequest
+			)
+
+			BeforeEach(func() {
+				wcr = &vmopv1.VirtualMachineWebConsoleRequest{}
+				wcr.Name = "test-wcr"
+				wcr.Namespace = "test-namespace"
+				wcr.Labels = map[string]string{
+					v1alpha1.UUIDLabelKey: "test-uuid-5678",
+				}
+				wcr.Status.Requester = "test-user"
+				wcr.Status.ExpiryTime = metav1.NewTime(time.Now().Add(time.Minute))
+				initObjects = append(initObjects, wcr)
+			})
+
+			When("UUID matches an active VirtualMachineWebConsoleRequest resource", func() {
+
+				It("should return http.StatusOK (200)", func() {
+					url := "/?uuid=test-uuid-5678&namespace=test-namespace"
+					responseCode := fakeValidationRequest(url, server)
+					Expect(responseCode).To(Equal(http.StatusOK))
+				})
+
+			})
+
+			When("the request includes the service URI of a serial console", func() {
+
+				It("should return http.StatusForbidden (403)", func() {
+					url := "/?uuid=test-uuid-5678&namespace=test-namespace&serviceURI=vmoperator/test-namespace/test-vm"
+					responseCode := fakeValidationRequest(url, server)
+					Expect(responseCode).To(Equal(http.StatusForbidden))
+				})
+
+			})
+
+			When("UUID matches a revoked VirtualMachineWebConsoleRequest resource", func() {
+
+				BeforeEach(func() {
+					wcr.Spec.Revoked = true
+				})
+
+				It("should return http.StatusForbidden (403)", func() {
+					url := "/?uuid=test-uuid-5678&namespace=test-namespace"
+					responseCode := fakeValidationRequest(url, server)
+					Expect(responseCode).To(Equal(http.StatusForbidden))
+				})
+
+			})
+
+			When("UUID matches an expired VirtualMachineWebConsoleRequest resource", func() {
+
+				BeforeEach(func() {
+					wcr.Status.ExpiryTime = metav1.NewTime(time.Now().Add(-time.Minute))
+				})
+
+				It("should return http.StatusForbidden (403)", func() {
+					url := "/?uuid=test-uuid-5678&namespace=test-namespace"
+					responseCode := fakeValidationRequest(url, server)
+					Expect(responseCode).To(Equal(http.StatusForbidden))
+				})
+
+			})
+		})
+
+		Context("requests for a VirtualMachineSerialConsoleRequest", func() {
+
+			var (
+				scr *vmopv1.VirtualMachineSerialConsoleRequest
+			)
+
+			BeforeEach(func() {
+				scr = &vmopv1.VirtualMachineSerialConsoleRequest{}
+				scr.Name = "test-scr"
+				scr.Namespace = "test-namespace"
+				scr.Labels = map[string]string{
+					virtualmachineserialconsolerequest.UUIDLabelKey: "test-uuid-9012",
+				}
+				scr.Spec.Name = "test-vm"
+				scr.Status.Response = "test-response"
+				scr.Status.ExpiryTime = metav1.NewTime(time.Now().Add(time.Minute))
+				initObjects = append(initObjects, scr)
+			})
+
+			When("UUID matches an active VirtualMachineSerialConsoleRequest resource", func() {
+
+				It("should return http.StatusOK (200)", func() {
+					url := "/?uuid=test-uuid-9012&namespace=test-namespace&serviceURI=vmoperator/test-namespace/test-vm"
+					responseCode := fakeValidationRequest(url, server)
+					Expect(responseCode).To(Equal(http.StatusOK))
+				})
+
+			})
+
+			When("the service URI is for a different VM", func() {
+
+				It("should return http.StatusForbidden (403)", func() {
+					url := "/?uuid=test-uuid-9012&namespace=test-namespace&serviceURI=vmoperator/test-namespace/other-vm"
+					responseCode := fakeValidationRequest(url, server)
+					Expect(responseCode).To(Equal(http.StatusForbidden))
+				})
+
+			})
+
+			When("the service URI is for a VM in a different namespace", func() {
+
+				It("should return http.StatusForbidden (403)", func() {
+					url := "/?uuid=test-uuid-9012&namespace=test-namespace&serviceURI=vmoperator/other-namespace/test-vm"
+					responseCode := fakeValidationRequest(url, server)
+					Expect(responseCode).To(Equal(http.StatusForbidden))
+				})
+
+			})
+
+			When("the service URI is missing", func() {
+
+				It("should return http.StatusForbidden (403)", func() {
+					url := "/?uuid=test-uuid-9012&namespace=test-namespace"
+					responseCode := fakeValidationRequest(url, server)
+					Expect(responseCode).To(Equal(http.StatusForbidden))
+				})
+
+			})
+
+			When("UUID matches a VirtualMachineSerialConsoleRequest resource without an expiry time", func() {
+
+				BeforeEach(func() {
+					scr.Status.ExpiryTime = metav1.Time{}
+				})
+
+				It("should return http.StatusForbidden (403)", func() {
+					url := "/?uuid=test-uuid-9012&namespace=test-namespace&serviceURI=vmoperator/test-namespace/test-vm"
+					responseCode := fakeValidationRequest(url, server)
+					Expect(responseCode).To(Equal(http.StatusForbidden))
+				})
+
+			})
+
+			When("UUID matches a VirtualMachineSerialConsoleRequest resource that is not ready", func() {
+
+				BeforeEach(func() {
+					scr.Status = vmopv1.VirtualMachineSerialConsoleRequestStatus{}
+				})
+
+				It("should return http.StatusForbidden (403)", func() {
+					url := "/?uuid=test-uuid-9012&namespace=test-namespace&serviceURI=vmoperator/test-namespace/test-vm"
+					responseCode := fakeValidationRequest(url, server)
+					Expect(responseCode).To(Equal(http.StatusForbidden))
+				})
+
+			})
+
+			When("UUID matches an expired VirtualMachineSerialConsoleRequest resource", func() {
+
+				BeforeEach(func() {
+					scr.Status.ExpiryTime = metav1.NewTime(time.Now().Add(-time.Minute))
+				})
+
+				It("should return http.StatusForbidden (403)", func() {
+					url := "/?uuid=test-uuid-9012&namespace=test-namespace&serviceURI=vmoperator/test-namespace/test-vm"
+					responseCode := fakeValidationRequest(url, server)
+					Expect(responseCode).To(Equal(http.StatusForbidden))
+				})
+
+			})
 		})
 	})
 }
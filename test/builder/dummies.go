@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
@@ -421,6 +422,75 @@ func DummyVirtualMachineReplicaSet() *vmopv1.VirtualMachineReplicaSet {
 	}
 }
 
+func DummyVirtualMachineDeployment() *vmopv1.VirtualMachineDeployment {
+	return &vmopv1.VirtualMachineDeployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "VirtualMachineDeployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "test-",
+			Labels:       map[string]string{},
+			Annotations:  map[string]string{},
+		},
+		Spec: vmopv1.VirtualMachineDeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "dummy"},
+			},
+			Template: vmopv1.VirtualMachineTemplateSpec{
+				ObjectMeta: vmopv1common.ObjectMeta{
+					Labels:      map[string]string{"app": "dummy"},
+					Annotations: make(map[string]string),
+				},
+				Spec: vmopv1.VirtualMachineSpec{
+					Image: &vmopv1.VirtualMachineImageRef{
+						Kind: vmiKind,
+						Name: DummyVMIName,
+					},
+					ImageName:  DummyImageName,
+					ClassName:  DummyClassName,
+					PowerState: vmopv1.VirtualMachinePowerStateOn,
+				},
+			},
+			Strategy: vmopv1.VirtualMachineDeploymentStrategy{
+				Type: vmopv1.RollingUpdateVirtualMachineDeploymentStrategyType,
+			},
+		},
+	}
+}
+
+func DummyVirtualMachineDisruptionBudget() *vmopv1.VirtualMachineDisruptionBudget {
+	return &vmopv1.VirtualMachineDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "VirtualMachineDisruptionBudget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "test-",
+		},
+		Spec: vmopv1.VirtualMachineDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "dummy"},
+			},
+			MinAvailable: ptr.To(intstr.FromInt32(1)),
+		},
+	}
+}
+
+func DummyVirtualMachineClone(name, namespace, sourceName string) *vmopv1.VirtualMachineClone {
+	return &vmopv1.VirtualMachineClone{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineCloneSpec{
+			Source: vmopv1.VirtualMachineCloneSource{
+				Name: sourceName,
+			},
+			Type: vmopv1.VirtualMachineCloneTypeFull,
+		},
+	}
+}
+
 func AddDummyInstanceStorageVolume(vm *vmopv1.VirtualMachine) {
 	vm.Spec.Volumes = append(vm.Spec.Volumes, DummyInstanceStorageVirtualMachineVolumes()...)
 }
@@ -438,7 +508,7 @@ func DummyVirtualMachineService() *vmopv1.VirtualMachineService {
 					Name:       "dummy-port",
 					Protocol:   "TCP",
 					Port:       42,
-					TargetPort: 4242,
+					TargetPort: intstr.FromInt32(4242),
 				},
 			},
 			Selector: map[string]string{
@@ -513,6 +583,101 @@ func DummyVirtualMachinePublishRequest(name, namespace, sourceName, itemName, cl
 	}
 }
 
+func DummyVirtualMachinePublishSchedule(name, namespace, sourceName, clName string) *vmopv1.VirtualMachinePublishSchedule {
+	return &vmopv1.VirtualMachinePublishSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachinePublishScheduleSpec{
+			Schedule: "0 2 * * *",
+			Source: vmopv1.VirtualMachinePublishRequestSource{
+				Name:       sourceName,
+				APIVersion: "vmoperator.vmware.com/v1alpha2",
+				Kind:       "VirtualMachine",
+			},
+			Target: vmopv1.VirtualMachinePublishRequestTarget{
+				Location: vmopv1.VirtualMachinePublishRequestTargetLocation{
+					Name:       clName,
+					APIVersion: "imageregistry.vmware.com/v1alpha1",
+					Kind:       "ContentLibrary",
+				},
+			},
+		},
+	}
+}
+
+func DummyVirtualMachineImageImportRequest(name, namespace, sourceURL, clName string) *vmopv1.VirtualMachineImageImportRequest {
+	return &vmopv1.VirtualMachineImageImportRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineImageImportRequestSpec{
+			Source: vmopv1.VirtualMachineImageImportRequestSource{
+				URL: sourceURL,
+			},
+			Target: vmopv1.VirtualMachineImageImportRequestTarget{
+				Location: vmopv1.VirtualMachineImageImportRequestTargetLocation{
+					Name:       clName,
+					APIVersion: "imageregistry.vmware.com/v1alpha1",
+					Kind:       "ContentLibrary",
+				},
+			},
+		},
+	}
+}
+
+func DummyVirtualMachineExport(name, namespace, vmName string) *vmopv1.VirtualMachineExport {
+	return &vmopv1.VirtualMachineExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineExportSpec{
+			Source: vmopv1.VirtualMachineExportSource{
+				Name: vmName,
+			},
+			Target: vmopv1.VirtualMachineExportTarget{
+				Download: &vmopv1.VirtualMachineExportTargetDownload{
+					TTLSeconds: 3600,
+				},
+			},
+		},
+	}
+}
+
+func DummyVirtualMachineSerialConsoleRequest(name, namespace, vmName, publicKey string) *vmopv1.VirtualMachineSerialConsoleRequest {
+	return &vmopv1.VirtualMachineSerialConsoleRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineSerialConsoleRequestSpec{
+			Name:      vmName,
+			PublicKey: publicKey,
+		},
+	}
+}
+
+func DummyVirtualMachineGuestOperation(name, namespace, vmName string) *vmopv1.VirtualMachineGuestOperation {
+	return &vmopv1.VirtualMachineGuestOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineGuestOperationSpec{
+			VirtualMachineName:    vmName,
+			CredentialsSecretName: name + "-credentials",
+			Command: &vmopv1.VirtualMachineGuestOperationCommand{
+				Path: "/bin/echo",
+				Args: []string{"hello"},
+			},
+			TimeoutSeconds: 300,
+		},
+	}
+}
+
 func DummyVirtualMachineImage(imageName string) *vmopv1.VirtualMachineImage {
 	return &vmopv1.VirtualMachineImage{
 		ObjectMeta: metav1.ObjectMeta{
@@ -560,6 +725,22 @@ func DummyVirtualMachineWebConsoleRequest(namespace, wcrName, vmName, pubKey str
 	}
 }
 
+func DummyVirtualMachineSnapshot(namespace, name, vmName string) *vmopv1.VirtualMachineSnapshot {
+	return &vmopv1.VirtualMachineSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineSnapshotSpec{
+			VMRef: &vmopv1common.LocalObjectRef{
+				APIVersion: vmopv1.GroupVersion.String(),
+				Kind:       "VirtualMachine",
+				Name:       vmName,
+			},
+		},
+	}
+}
+
 func DummyImageAndItemObjectsForCdromBacking(
 	name, ns, kind, storageURI, libItemUUID string,
 	imgReady, imgHasProviderRef, itemObjExists bool,
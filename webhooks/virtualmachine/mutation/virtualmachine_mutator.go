@@ -154,6 +154,12 @@ func (m mutator) Mutate(ctx *pkgctx.WebhookRequestContext) admission.Response {
 			wasMutated = true
 		}
 
+		if ok, err := SetNextRelocateTime(ctx, modified, oldVM); err != nil {
+			return admission.Denied(err.Error())
+		} else if ok {
+			wasMutated = true
+		}
+
 		if ok := SetDefaultCdromImgKindOnUpdate(ctx, modified, oldVM); ok {
 			wasMutated = true
 		}
@@ -214,6 +220,30 @@ func SetNextRestartTime(
 		`may only be set to "now"`)
 }
 
+// SetNextRelocateTime sets spec.nextRelocateTime for a VM if the field's
+// current value is equal to "now" (case-insensitive).
+// Return true if set, otherwise false.
+func SetNextRelocateTime(
+	ctx *pkgctx.WebhookRequestContext,
+	newVM, oldVM *vmopv1.VirtualMachine) (bool, error) {
+
+	if newVM.Spec.NextRelocateTime == "" {
+		newVM.Spec.NextRelocateTime = oldVM.Spec.NextRelocateTime
+		return oldVM.Spec.NextRelocateTime != "", nil
+	}
+	if strings.EqualFold("now", newVM.Spec.NextRelocateTime) {
+		newVM.Spec.NextRelocateTime = time.Now().UTC().Format(time.RFC3339Nano)
+		return true, nil
+	}
+	if newVM.Spec.NextRelocateTime == oldVM.Spec.NextRelocateTime {
+		return false, nil
+	}
+	return false, field.Invalid(
+		field.NewPath("spec", "nextRelocateTime"),
+		newVM.Spec.NextRelocateTime,
+		`may only be set to "now"`)
+}
+
 // AddDefaultNetworkInterface adds default network interface to a VM if the NoNetwork annotation is not set
 // and no NetworkInterface is specified.
 // Return true if default NetworkInterface is added, otherwise return false.
@@ -1052,6 +1052,104 @@ func unitTestsMutating() {
 		})
 	})
 
+	Describe("SetNextRelocateTime", func() {
+
+		var (
+			oldVM *vmopv1.VirtualMachine
+		)
+
+		BeforeEach(func() {
+			oldVM = ctx.vm.DeepCopy()
+		})
+
+		When("oldVM has empty spec.nextRelocateTime", func() {
+			BeforeEach(func() {
+				oldVM.Spec.NextRelocateTime = ""
+			})
+			Context("newVM has spec.nextRelocateTime set to an empty value", func() {
+				It("should not mutate anything", func() {
+					ctx.vm.Spec.NextRelocateTime = ""
+					ok, err := mutation.SetNextRelocateTime(
+						&ctx.WebhookRequestContext,
+						ctx.vm,
+						oldVM)
+					Expect(ok).To(BeFalse())
+					Expect(err).ToNot(HaveOccurred())
+					Expect(ctx.vm.Spec.NextRelocateTime).To(BeEmpty())
+				})
+			})
+			Context("newVM has spec.nextRelocateTime set to 'now' (case-insensitive)", func() {
+				It("should mutate the field to a valid UTC timestamp", func() {
+					for _, s := range []string{"now", "Now", "NOW"} {
+						ctx.vm.Spec.NextRelocateTime = s
+						ok, err := mutation.SetNextRelocateTime(
+							&ctx.WebhookRequestContext,
+							ctx.vm,
+							oldVM)
+						Expect(ok).To(BeTrue())
+						Expect(err).ToNot(HaveOccurred())
+						_, err = time.Parse(time.RFC3339Nano, ctx.vm.Spec.NextRelocateTime)
+						Expect(err).ShouldNot(HaveOccurred())
+					}
+				})
+			})
+			DescribeTable(
+				`newVM has spec.nextRelocateTime set a non-empty value that is not "now"`,
+				append([]any{
+					func(nextRelocateTime string) {
+						ctx.vm.Spec.NextRelocateTime = nextRelocateTime
+						ok, err := mutation.SetNextRelocateTime(
+							&ctx.WebhookRequestContext,
+							ctx.vm,
+							oldVM)
+						Expect(ok).To(BeFalse())
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(Equal(field.Invalid(
+							field.NewPath("spec", "nextRelocateTime"),
+							nextRelocateTime,
+							`may only be set to "now"`).Error()))
+					}},
+					newInvalidNextRestartTimeTableEntries("should return an invalid field error"))...,
+			)
+		})
+
+		When("oldVM has non-empty spec.nextRelocateTime", func() {
+			var (
+				lastRelocateTime time.Time
+			)
+			BeforeEach(func() {
+				lastRelocateTime = time.Now().UTC()
+				oldVM.Spec.NextRelocateTime = lastRelocateTime.Format(time.RFC3339Nano)
+			})
+			Context("newVM has spec.nextRelocateTime set to an empty value", func() {
+				It("should mutate to match oldVM", func() {
+					ctx.vm.Spec.NextRelocateTime = ""
+					ok, err := mutation.SetNextRelocateTime(
+						&ctx.WebhookRequestContext,
+						ctx.vm,
+						oldVM)
+					Expect(ok).To(BeTrue())
+					Expect(err).ToNot(HaveOccurred())
+					Expect(ctx.vm.Spec.NextRelocateTime).To(Equal(oldVM.Spec.NextRelocateTime))
+				})
+			})
+			Context("newVM has spec.nextRelocateTime set to 'now'", func() {
+				It("should mutate the field to a later UTC timestamp", func() {
+					ctx.vm.Spec.NextRelocateTime = "now"
+					ok, err := mutation.SetNextRelocateTime(
+						&ctx.WebhookRequestContext,
+						ctx.vm,
+						oldVM)
+					Expect(ok).To(BeTrue())
+					Expect(err).ToNot(HaveOccurred())
+					nextRelocateTime, err := time.Parse(time.RFC3339Nano, ctx.vm.Spec.NextRelocateTime)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(lastRelocateTime.Before(nextRelocateTime)).To(BeTrue())
+				})
+			})
+		})
+	})
+
 	Describe("SetCreatedAtAnnotations", func() {
 		var (
 			vm *vmopv1.VirtualMachine
@@ -5,6 +5,7 @@ package validation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -23,6 +24,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
@@ -57,7 +60,10 @@ const (
 	cvmiKind = "ClusterVirtualMachineImage"
 
 	readinessProbeOnlyOneAction              = "only one action can be specified"
-	tcpReadinessProbeNotAllowedVPC           = "VPC networking doesn't allow TCP readiness probe to be specified"
+	tcpProbeNotAllowedVPCFmt                 = "VPC networking doesn't allow TCP %s probe to be specified"
+	httpGetProbeNotAllowedVPCFmt             = "VPC networking doesn't allow HTTPGet %s probe to be specified"
+	httpGetProbeInvalidPort                  = "must be a number in the range 1 to 65535"
+	httpGetProbeInvalidStatusRange           = "min must be less than or equal to max"
 	updatesNotAllowedWhenPowerOn             = "updates to this field is not allowed when VM power is on"
 	storageClassNotFoundFmt                  = "Storage policy %s does not exist"
 	storageClassNotAssignedFmt               = "Storage policy is not associated with the namespace %s"
@@ -70,6 +76,14 @@ const (
 	invalidNextRestartTimeOnCreate           = "cannot restart VM on create"
 	invalidNextRestartTimeOnUpdate           = "must be formatted as RFC3339Nano"
 	invalidNextRestartTimeOnUpdateNow        = "mutation webhooks are required to restart VM"
+	invalidNextRelocateTimeOnCreate          = "cannot relocate VM on create"
+	invalidNextRelocateTimeOnUpdate          = "must be formatted as RFC3339Nano"
+	invalidNextRelocateTimeOnUpdateNow       = "mutation webhooks are required to relocate VM"
+	invalidZoneChangeInstanceStorage         = "cannot change the zone of a VM with instance storage"
+	invalidStorageClassChangeInstanceStorage = "cannot change the storage class of a VM with instance storage"
+	invalidImageChangeInstanceStorage        = "cannot change the image of a VM with instance storage"
+	invalidImageNameOnImageChange            = "must be empty or equal to spec.image.name when the image is changed"
+	invalidImageChangeImageUnknown           = "cannot change the image until the VM's deployed image is reported in status.image"
 	modifyAnnotationNotAllowedForNonAdmin    = "modifying this annotation is not allowed for non-admin users"
 	modifyLabelNotAllowedForNonAdmin         = "modifying this label is not allowed for non-admin users"
 	invalidMinHardwareVersionNotSupported    = "should be less than or equal to %d"
@@ -81,9 +95,19 @@ const (
 	invalidPVCBYOKFmt                        = "cannot attach volume to vm with spec.crypto.encryptionClassName=%q"
 )
 
-// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachine,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachines,versions=v1alpha3,name=default.validating.virtualmachine.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// disruptionBudgetExemptUsers are the users whose VM deletes are not subject
+// to VirtualMachineDisruptionBudgets. The garbage collector deletes VMs whose
+// owner was deleted, and the namespace controller deletes the VMs in a
+// namespace that is being deleted.
+var disruptionBudgetExemptUsers = map[string]struct{}{
+	"system:serviceaccount:kube-system:generic-garbage-collector": {},
+	"system:serviceaccount:kube-system:namespace-controller":      {},
+}
+
+// +kubebuilder:webhook:verbs=create;update;delete,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachine,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachines,versions=v1alpha3,name=default.validating.virtualmachine.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines/status,verbs=get
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinedisruptionbudgets,verbs=get;list
 
 // AddToManager adds the webhook to the provided manager.
 func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
@@ -132,9 +156,11 @@ func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.R
 	fieldErrs = append(fieldErrs, v.validateVolumes(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateInstanceStorageVolumes(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateReadinessProbe(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateLivenessProbe(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateAdvanced(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validatePowerStateOnCreate(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateNextRestartTimeOnCreate(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateNextRelocateTimeOnCreate(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateAnnotation(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateLabel(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateNetworkHostAndDomainName(ctx, vm, nil)...)
@@ -149,7 +175,28 @@ func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.R
 	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
 }
 
-func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+// ValidateDelete denies the deletion of a VM that would violate one of the
+// VirtualMachineDisruptionBudgets that select it. Deletes issued by privileged
+// accounts, the garbage collector and the namespace controller are always
+// allowed since they are driven by the deletion of the VM's owner or
+// namespace.
+func (v validator) ValidateDelete(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	if _, ok := disruptionBudgetExemptUsers[ctx.UserInfo.Username]; ok {
+		return admission.Allowed("")
+	}
+
+	vm, err := v.vmFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := v.validateDisruptionBudget(ctx, vm); err != nil {
+		if errors.Is(err, vmopv1util.ErrDisruptionNotAllowed) {
+			return admission.Denied(err.Error())
+		}
+		return webhook.Errored(http.StatusInternalServerError, err)
+	}
+
 	return admission.Allowed("")
 }
 
@@ -184,6 +231,7 @@ func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.R
 	// First validate any updates to the desired state based on the current
 	// power state of the VM.
 	fieldErrs = append(fieldErrs, v.validatePowerStateOnUpdate(ctx, vm, oldVM)...)
+	fieldErrs = append(fieldErrs, v.validatePowerStateDisruption(ctx, vm, oldVM)...)
 
 	// Validations for allowed updates. Return validation responses here for conditional updates regardless
 	// of whether the update is allowed or not.
@@ -194,8 +242,10 @@ func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.R
 	fieldErrs = append(fieldErrs, v.validateVolumes(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateInstanceStorageVolumes(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateReadinessProbe(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateLivenessProbe(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateAdvanced(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateNextRestartTimeOnUpdate(ctx, vm, oldVM)...)
+	fieldErrs = append(fieldErrs, v.validateNextRelocateTimeOnUpdate(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateAnnotation(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateMinHardwareVersion(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateLabel(ctx, vm, oldVM)...)
@@ -915,19 +965,40 @@ func (v validator) validateInstanceStorageVolumes(
 }
 
 func (v validator) validateReadinessProbe(ctx *pkgctx.WebhookRequestContext, vm *vmopv1.VirtualMachine) field.ErrorList {
-	var allErrs field.ErrorList
-
 	probe := vm.Spec.ReadinessProbe
 	if probe == nil {
-		return allErrs
+		return nil
+	}
+
+	return v.validateProbe(ctx, probe, "readiness", field.NewPath("spec", "readinessProbe"))
+}
+
+func (v validator) validateLivenessProbe(ctx *pkgctx.WebhookRequestContext, vm *vmopv1.VirtualMachine) field.ErrorList {
+	probe := vm.Spec.LivenessProbe
+	if probe == nil {
+		return nil
 	}
 
-	readinessProbePath := field.NewPath("spec", "readinessProbe")
+	return v.validateProbe(ctx, vmopv1util.LivenessProbeToReadinessProbe(probe), "liveness", field.NewPath("spec", "livenessProbe"))
+}
+
+// validateProbe validates the actions of a readiness or liveness probe. The
+// probeType is used in error messages.
+func (v validator) validateProbe(
+	ctx *pkgctx.WebhookRequestContext,
+	probe *vmopv1.VirtualMachineReadinessProbeSpec,
+	probeType string,
+	probePath *field.Path) field.ErrorList {
+
+	var allErrs field.ErrorList
 
 	actionsCnt := 0
 	if probe.TCPSocket != nil {
 		actionsCnt++
 	}
+	if probe.HTTPGet != nil {
+		actionsCnt++
+	}
 	if probe.GuestHeartbeat != nil {
 		actionsCnt++
 	}
@@ -935,15 +1006,15 @@ func (v validator) validateReadinessProbe(ctx *pkgctx.WebhookRequestContext, vm
 		actionsCnt++
 	}
 	if actionsCnt > 1 {
-		allErrs = append(allErrs, field.Forbidden(readinessProbePath, readinessProbeOnlyOneAction))
+		allErrs = append(allErrs, field.Forbidden(probePath, readinessProbeOnlyOneAction))
 	}
 
 	if probe.TCPSocket != nil {
-		tcpSocketPath := readinessProbePath.Child("tcpSocket")
+		tcpSocketPath := probePath.Child("tcpSocket")
 
-		// TCP readiness probe is not allowed under VPC Networking
+		// TCP probe is not allowed under VPC Networking
 		if pkgcfg.FromContext(ctx).NetworkProviderType == pkgcfg.NetworkProviderTypeVPC {
-			allErrs = append(allErrs, field.Forbidden(tcpSocketPath, tcpReadinessProbeNotAllowedVPC))
+			allErrs = append(allErrs, field.Forbidden(tcpSocketPath, fmt.Sprintf(tcpProbeNotAllowedVPCFmt, probeType)))
 		} else if probe.TCPSocket.Port.IntValue() != allowedRestrictedNetworkTCPProbePort {
 			// Validate port if environment is a restricted network environment between SV CP VMs and Workload VMs e.g. VMC.
 			isRestrictedEnv, err := v.isNetworkRestrictedForReadinessProbe(ctx)
@@ -957,6 +1028,74 @@ func (v validator) validateReadinessProbe(ctx *pkgctx.WebhookRequestContext, vm
 		}
 	}
 
+	if probe.HTTPGet != nil {
+		allErrs = append(allErrs, v.validateProbeHTTPGet(ctx, probe.HTTPGet, probeType, probePath.Child("httpGet"))...)
+	}
+
+	return allErrs
+}
+
+func (v validator) validateProbeHTTPGet(
+	ctx *pkgctx.WebhookRequestContext,
+	httpGet *vmopv1.HTTPGetAction,
+	probeType string,
+	httpGetPath *field.Path) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	// Like TCP, the HTTPGet probe requires connectivity from the control plane
+	// to the VM.
+	if pkgcfg.FromContext(ctx).NetworkProviderType == pkgcfg.NetworkProviderTypeVPC {
+		return append(allErrs, field.Forbidden(httpGetPath, fmt.Sprintf(httpGetProbeNotAllowedVPCFmt, probeType)))
+	}
+
+	// A named port is resolved from the ports of the VM's network interfaces
+	// when the probe is run.
+	portPath := httpGetPath.Child("port")
+	var portErrs field.ErrorList
+	var portValue any
+	if httpGet.Port.Type == intstr.String {
+		portValue = httpGet.Port.StrVal
+		for _, msg := range validation.IsValidPortName(httpGet.Port.StrVal) {
+			portErrs = append(portErrs, field.Invalid(portPath, httpGet.Port.StrVal, msg))
+		}
+	} else {
+		portValue = httpGet.Port.IntValue()
+		if httpGet.Port.IntValue() < 1 || httpGet.Port.IntValue() > 65535 {
+			portErrs = append(portErrs, field.Invalid(portPath, httpGet.Port.String(), httpGetProbeInvalidPort))
+		}
+	}
+	allErrs = append(allErrs, portErrs...)
+
+	if len(portErrs) == 0 && portValue != allowedRestrictedNetworkTCPProbePort {
+		isRestrictedEnv, err := v.isNetworkRestrictedForReadinessProbe(ctx)
+		if err != nil {
+			allErrs = append(allErrs, field.Forbidden(httpGetPath, err.Error()))
+		} else if isRestrictedEnv {
+			allErrs = append(allErrs,
+				field.NotSupported(portPath, portValue,
+					[]string{strconv.Itoa(allowedRestrictedNetworkTCPProbePort)}))
+		}
+	}
+
+	switch httpGet.Scheme {
+	case "", vmopv1.URISchemeHTTP, vmopv1.URISchemeHTTPS:
+	default:
+		allErrs = append(allErrs, field.NotSupported(httpGetPath.Child("scheme"), httpGet.Scheme,
+			[]string{string(vmopv1.URISchemeHTTP), string(vmopv1.URISchemeHTTPS)}))
+	}
+
+	for i, h := range httpGet.HTTPHeaders {
+		if h.Name == "" {
+			allErrs = append(allErrs, field.Required(httpGetPath.Child("httpHeaders").Index(i).Child("name"), ""))
+		}
+	}
+
+	if r := httpGet.ExpectedStatus; r != nil && r.Min > r.Max {
+		allErrs = append(allErrs, field.Invalid(httpGetPath.Child("expectedStatus"),
+			fmt.Sprintf("%d-%d", r.Min, r.Max), httpGetProbeInvalidStatusRange))
+	}
+
 	return allErrs
 }
 
@@ -1031,6 +1170,59 @@ func (v validator) validateNextRestartTimeOnUpdate(
 	return allErrs
 }
 
+func (v validator) validateNextRelocateTimeOnCreate(
+	ctx *pkgctx.WebhookRequestContext,
+	vm *vmopv1.VirtualMachine) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if vm.Spec.NextRelocateTime != "" {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				field.NewPath("spec").Child("nextRelocateTime"),
+				vm.Spec.NextRelocateTime,
+				invalidNextRelocateTimeOnCreate))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateNextRelocateTimeOnUpdate(
+	ctx *pkgctx.WebhookRequestContext,
+	newVM, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+
+	if newVM.Spec.NextRelocateTime == oldVM.Spec.NextRelocateTime {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	nextRelocateTimePath := field.NewPath("spec").Child("nextRelocateTime")
+
+	if !pkgcfg.FromContext(ctx).Features.VMRelocate {
+		return append(allErrs, field.Forbidden(nextRelocateTimePath, fmt.Sprintf(featureNotEnabled, "VM Relocate")))
+	}
+
+	if strings.EqualFold(newVM.Spec.NextRelocateTime, "now") {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				nextRelocateTimePath,
+				newVM.Spec.NextRelocateTime,
+				invalidNextRelocateTimeOnUpdateNow))
+	} else if _, err := time.Parse(time.RFC3339Nano, newVM.Spec.NextRelocateTime); err != nil {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				nextRelocateTimePath,
+				newVM.Spec.NextRelocateTime,
+				invalidNextRelocateTimeOnUpdate))
+	}
+
+	return allErrs
+}
+
 func (v validator) validatePowerStateOnCreate(
 	ctx *pkgctx.WebhookRequestContext,
 	newVM *vmopv1.VirtualMachine) field.ErrorList {
@@ -1052,6 +1244,64 @@ func (v validator) validatePowerStateOnCreate(
 	return allErrs
 }
 
+// validatePowerStateDisruption returns an error if the update powers off or
+// suspends a VM in violation of one of the VirtualMachineDisruptionBudgets
+// that select it.
+func (v validator) validatePowerStateDisruption(
+	ctx *pkgctx.WebhookRequestContext,
+	newVM, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+
+	if oldVM.Spec.PowerState != vmopv1.VirtualMachinePowerStateOn {
+		return nil
+	}
+
+	switch newVM.Spec.PowerState {
+	case vmopv1.VirtualMachinePowerStateOff, vmopv1.VirtualMachinePowerStateSuspended:
+	default:
+		return nil
+	}
+
+	powerStatePath := field.NewPath("spec", "powerState")
+
+	if err := v.validateDisruptionBudget(ctx, oldVM); err != nil {
+		if errors.Is(err, vmopv1util.ErrDisruptionNotAllowed) {
+			return field.ErrorList{field.Forbidden(powerStatePath, err.Error())}
+		}
+		return field.ErrorList{field.InternalError(powerStatePath, err)}
+	}
+
+	return nil
+}
+
+// validateDisruptionBudget returns an error if disrupting the VM would violate
+// one of the VirtualMachineDisruptionBudgets that select it. Privileged users,
+// which includes VM Operator itself, are not subject to the budgets, and
+// neither are the VMs in a namespace that is being deleted.
+func (v validator) validateDisruptionBudget(
+	ctx *pkgctx.WebhookRequestContext,
+	vm *vmopv1.VirtualMachine) error {
+
+	if !pkgcfg.FromContext(ctx).Features.K8sWorkloadMgmtAPI || ctx.IsPrivilegedAccount {
+		return nil
+	}
+
+	if !vm.DeletionTimestamp.IsZero() {
+		// The VM is already being deleted.
+		return nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := v.client.Get(ctx, ctrlclient.ObjectKey{Name: vm.Namespace}, ns); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get namespace %s: %w", vm.Namespace, err)
+		}
+	} else if !ns.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	return vmopv1util.CheckDisruptionAllowed(ctx, v.client, vm)
+}
+
 func (v validator) validatePowerStateOnUpdate(
 	ctx *pkgctx.WebhookRequestContext,
 	newVM, oldVM *vmopv1.VirtualMachine) field.ErrorList {
@@ -1143,10 +1393,9 @@ func (v validator) validateImmutableFields(ctx *pkgctx.WebhookRequestContext, vm
 	var allErrs field.ErrorList
 	specPath := field.NewPath("spec")
 
-	allErrs = append(allErrs, validation.ValidateImmutableField(vm.Spec.Image, oldVM.Spec.Image, specPath.Child("image"))...)
-	allErrs = append(allErrs, validation.ValidateImmutableField(vm.Spec.ImageName, oldVM.Spec.ImageName, specPath.Child("imageName"))...)
+	allErrs = append(allErrs, v.validateImageOnUpdate(ctx, vm, oldVM)...)
 	allErrs = append(allErrs, v.validateClassOnUpdate(ctx, vm, oldVM)...)
-	allErrs = append(allErrs, validation.ValidateImmutableField(vm.Spec.StorageClass, oldVM.Spec.StorageClass, specPath.Child("storageClass"))...)
+	allErrs = append(allErrs, v.validateStorageClassOnUpdate(ctx, vm, oldVM)...)
 	// New VMs always have non-empty biosUUID. Existing VMs being upgraded may have an empty biosUUID.
 	if oldVM.Spec.BiosUUID != "" {
 		allErrs = append(allErrs, validation.ValidateImmutableField(vm.Spec.BiosUUID, oldVM.Spec.BiosUUID, specPath.Child("biosUUID"))...)
@@ -1161,6 +1410,71 @@ func (v validator) validateImmutableFields(ctx *pkgctx.WebhookRequestContext, vm
 	return allErrs
 }
 
+// validateStorageClassOnUpdate allows the storage class of an existing VM to
+// be changed when the VM storage class change feature is enabled, in which
+// case the VM's storage is relocated to the new storage class.
+func (v validator) validateStorageClassOnUpdate(ctx *pkgctx.WebhookRequestContext, vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if vm.Spec.StorageClass == oldVM.Spec.StorageClass {
+		return allErrs
+	}
+
+	scPath := field.NewPath("spec", "storageClass")
+
+	if vm.Spec.StorageClass == "" || !pkgcfg.FromContext(ctx).Features.VMStorageClassChange {
+		return append(allErrs, validation.ValidateImmutableField(vm.Spec.StorageClass, oldVM.Spec.StorageClass, scPath)...)
+	}
+
+	if instancestorage.IsPresent(vm) {
+		return append(allErrs, field.Forbidden(scPath, invalidStorageClassChangeInstanceStorage))
+	}
+
+	return append(allErrs, v.validateStorageClass(ctx, vm)...)
+}
+
+func (v validator) validateImageOnUpdate(ctx *pkgctx.WebhookRequestContext, vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if reflect.DeepEqual(vm.Spec.Image, oldVM.Spec.Image) && vm.Spec.ImageName == oldVM.Spec.ImageName {
+		return allErrs
+	}
+
+	specPath := field.NewPath("spec")
+	imagePath := specPath.Child("image")
+
+	if vm.Spec.Image == nil || reflect.DeepEqual(vm.Spec.Image, oldVM.Spec.Image) ||
+		!pkgcfg.FromContext(ctx).Features.VMRebuild {
+
+		allErrs = append(allErrs, validation.ValidateImmutableField(vm.Spec.Image, oldVM.Spec.Image, imagePath)...)
+		allErrs = append(allErrs, validation.ValidateImmutableField(vm.Spec.ImageName, oldVM.Spec.ImageName, specPath.Child("imageName"))...)
+		return allErrs
+	}
+
+	if instancestorage.IsPresent(vm) {
+		return append(allErrs, field.Forbidden(imagePath, invalidImageChangeInstanceStorage))
+	}
+
+	// The image in spec.image is taken as the deployed image of a VM that
+	// does not yet report one, so it may not change before then.
+	if oldVM.Status.Image == nil {
+		return append(allErrs, field.Forbidden(imagePath, invalidImageChangeImageUnknown))
+	}
+
+	switch {
+	case vm.Spec.Image.Kind == "":
+		allErrs = append(allErrs, field.Required(imagePath.Child("kind"), invalidImageKind))
+	case vm.Spec.Image.Kind != vmiKind && vm.Spec.Image.Kind != cvmiKind:
+		allErrs = append(allErrs, field.Invalid(imagePath.Child("kind"), vm.Spec.Image.Kind, invalidImageKind))
+	}
+
+	if vm.Spec.ImageName != "" && vm.Spec.ImageName != vm.Spec.Image.Name {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("imageName"), vm.Spec.ImageName, invalidImageNameOnImageChange))
+	}
+
+	return allErrs
+}
+
 func (v validator) validateImmutableReserved(_ *pkgctx.WebhookRequestContext, vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -1188,8 +1502,45 @@ func (v validator) validateImmutableNetwork(ctx *pkgctx.WebhookRequestContext, v
 
 	p := field.NewPath("spec", "network")
 
-	oldInterfaces := oldVM.Spec.Network.Interfaces
-	newInterfaces := vm.Spec.Network.Interfaces
+	var oldInterfaces, newInterfaces []vmopv1.VirtualMachineNetworkInterfaceSpec
+	if oldNetwork != nil {
+		oldInterfaces = oldNetwork.Interfaces
+	}
+	if newNetwork != nil {
+		newInterfaces = newNetwork.Interfaces
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMNetworkHotPlug {
+		// Interfaces may be added or removed, so an interface is matched to
+		// its previous spec by name instead of by index.
+		oldInterfaceIndexByName := make(map[string]int, len(oldInterfaces))
+		for i := range oldInterfaces {
+			oldInterfaceIndexByName[oldInterfaces[i].Name] = i
+		}
+
+		// The guest's network configuration of the interfaces is assigned to
+		// the VM's ethernet cards in order, so the remaining interfaces must
+		// keep their order.
+		lastOldIdx := -1
+		for i := range newInterfaces {
+			newInterface := &newInterfaces[i]
+			pi := p.Child("interfaces").Index(i)
+
+			oldIdx, ok := oldInterfaceIndexByName[newInterface.Name]
+			if !ok {
+				continue
+			}
+
+			if oldIdx < lastOldIdx {
+				allErrs = append(allErrs, field.Forbidden(pi, "existing network interfaces cannot be reordered"))
+			}
+			lastOldIdx = oldIdx
+
+			allErrs = append(allErrs, validateImmutableNetworkInterface(pi, newInterface, &oldInterfaces[oldIdx])...)
+		}
+
+		return allErrs
+	}
 
 	if len(oldInterfaces) != len(newInterfaces) {
 		return append(allErrs, field.Forbidden(p.Child("interfaces"), "network interfaces cannot be added or removed"))
@@ -1203,9 +1554,22 @@ func (v validator) validateImmutableNetwork(ctx *pkgctx.WebhookRequestContext, v
 		if newInterface.Name != oldInterface.Name {
 			allErrs = append(allErrs, field.Forbidden(pi.Child("name"), validation.FieldImmutableErrorMsg))
 		}
-		if !reflect.DeepEqual(newInterface.Network, oldInterface.Network) {
-			allErrs = append(allErrs, field.Forbidden(pi.Child("network"), validation.FieldImmutableErrorMsg))
-		}
+		allErrs = append(allErrs, validateImmutableNetworkInterface(pi, newInterface, oldInterface)...)
+	}
+
+	return allErrs
+}
+
+// validateImmutableNetworkInterface validates the fields of an existing
+// network interface that cannot be changed.
+func validateImmutableNetworkInterface(
+	interfacePath *field.Path,
+	newInterface, oldInterface *vmopv1.VirtualMachineNetworkInterfaceSpec) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if !reflect.DeepEqual(newInterface.Network, oldInterface.Network) {
+		allErrs = append(allErrs, field.Forbidden(interfacePath.Child("network"), validation.FieldImmutableErrorMsg))
 	}
 
 	return allErrs
@@ -1217,10 +1581,19 @@ func (v validator) validateAvailabilityZone(ctx *pkgctx.WebhookRequestContext, v
 	zoneLabelPath := field.NewPath("metadata", "labels").Key(topology.KubernetesTopologyZoneLabelKey)
 
 	if oldVM != nil {
-		// Once the zone has been set then make sure the field is immutable.
+		// Once the zone has been set then make sure the field is immutable,
+		// unless the VM may be relocated to another zone.
 		if oldVal := oldVM.Labels[topology.KubernetesTopologyZoneLabelKey]; oldVal != "" {
 			newVal := vm.Labels[topology.KubernetesTopologyZoneLabelKey]
-			return append(allErrs, validation.ValidateImmutableField(newVal, oldVal, zoneLabelPath)...)
+			if newVal == oldVal {
+				return allErrs
+			}
+			if newVal == "" || !pkgcfg.FromContext(ctx).Features.VMRelocate {
+				return append(allErrs, validation.ValidateImmutableField(newVal, oldVal, zoneLabelPath)...)
+			}
+			if instancestorage.IsPresent(vm) {
+				return append(allErrs, field.Forbidden(zoneLabelPath, invalidZoneChangeInstanceStorage))
+			}
 		}
 	}
 
@@ -1289,6 +1662,26 @@ func (v validator) validateAnnotation(ctx *pkgctx.WebhookRequestContext, vm, old
 		allErrs = append(allErrs, field.Forbidden(annotationPath.Child(vmopv1.FirstBootDoneAnnotation), modifyAnnotationNotAllowedForNonAdmin))
 	}
 
+	if vm.Annotations[vmopv1.CloneSourceAnnotation] != oldVM.Annotations[vmopv1.CloneSourceAnnotation] {
+		allErrs = append(allErrs, field.Forbidden(annotationPath.Child(vmopv1.CloneSourceAnnotation), modifyAnnotationNotAllowedForNonAdmin))
+	}
+
+	if vm.Annotations[vmopv1.CloneTypeAnnotation] != oldVM.Annotations[vmopv1.CloneTypeAnnotation] {
+		allErrs = append(allErrs, field.Forbidden(annotationPath.Child(vmopv1.CloneTypeAnnotation), modifyAnnotationNotAllowedForNonAdmin))
+	}
+
+	if vm.Annotations[vmopv1.LastRevertedSnapshotAnnotation] != oldVM.Annotations[vmopv1.LastRevertedSnapshotAnnotation] {
+		allErrs = append(allErrs, field.Forbidden(annotationPath.Child(vmopv1.LastRevertedSnapshotAnnotation), modifyAnnotationNotAllowedForNonAdmin))
+	}
+
+	// The serial console annotation has an empty value, so its presence must
+	// also be compared.
+	oldSerialConsole, oldHasSerialConsole := oldVM.Annotations[vmopv1.SerialConsoleAnnotation]
+	serialConsole, hasSerialConsole := vm.Annotations[vmopv1.SerialConsoleAnnotation]
+	if serialConsole != oldSerialConsole || hasSerialConsole != oldHasSerialConsole {
+		allErrs = append(allErrs, field.Forbidden(annotationPath.Child(vmopv1.SerialConsoleAnnotation), modifyAnnotationNotAllowedForNonAdmin))
+	}
+
 	// The following annotations will be added by the mutation webhook upon VM creation.
 	if !reflect.DeepEqual(oldVM, &vmopv1.VirtualMachine{}) {
 		if vm.Annotations[constants.CreatedAtBuildVersionAnnotationKey] != oldVM.Annotations[constants.CreatedAtBuildVersionAnnotationKey] {
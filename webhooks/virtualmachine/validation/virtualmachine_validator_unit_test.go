@@ -28,6 +28,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha3/sysprep"
 	topologyv1 "github.com/vmware-tanzu/vm-operator/external/tanzu-topology/api/v1alpha1"
 	pkgbuilder "github.com/vmware-tanzu/vm-operator/pkg/builder"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
@@ -154,6 +155,7 @@ func unitTestsValidateCreate() {
 		withInstanceStorageVolumes bool
 		powerState                 vmopv1.VirtualMachinePowerState
 		nextRestartTime            string
+		nextRelocateTime           string
 		instanceUUID               string
 		biosUUID                   string
 	}
@@ -187,6 +189,7 @@ func unitTestsValidateCreate() {
 
 		ctx.vm.Spec.PowerState = args.powerState
 		ctx.vm.Spec.NextRestartTime = args.nextRestartTime
+		ctx.vm.Spec.NextRelocateTime = args.nextRelocateTime
 		ctx.vm.Spec.InstanceUUID = args.instanceUUID
 		ctx.vm.Spec.BiosUUID = args.biosUUID
 
@@ -253,6 +256,9 @@ func unitTestsValidateCreate() {
 		Entry("should disallow creating VM with non-empty, invalid nextRestartTime value",
 			createArgs{nextRestartTime: "hello"}, false,
 			field.Invalid(nextRestartTimePath, "hello", "cannot restart VM on create").Error(), nil),
+		Entry("should disallow creating VM with non-empty nextRelocateTime value",
+			createArgs{nextRelocateTime: "now"}, false,
+			field.Invalid(specPath.Child("nextRelocateTime"), "now", "cannot relocate VM on create").Error(), nil),
 		Entry("should allow creating VM with instanceUUID set by admin user", createArgs{instanceUUID: "uuid", isServiceUser: true}, true, nil, nil),
 		Entry("should allow creating VM with biosUUID set by admin user", createArgs{biosUUID: "uuid", isServiceUser: true}, true, nil, nil),
 	)
@@ -923,6 +929,35 @@ func unitTestsValidateCreate() {
 						field.Forbidden(annotationPath.Child(vmopv1.FirstBootDoneAnnotation), "modifying this annotation is not allowed for non-admin users").Error()),
 				},
 			),
+			Entry("should disallow creating VM with clone annotations set by SSO user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Annotations[vmopv1.CloneSourceAnnotation] = "source-vm"
+						ctx.vm.Annotations[vmopv1.CloneTypeAnnotation] = string(vmopv1.VirtualMachineCloneTypeFull)
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(annotationPath.Child(vmopv1.CloneSourceAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
+						field.Forbidden(annotationPath.Child(vmopv1.CloneTypeAnnotation), "modifying this annotation is not allowed for non-admin users").Error()),
+				},
+			),
+			Entry("should disallow creating VM with serial console annotation set by SSO user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Annotations[vmopv1.SerialConsoleAnnotation] = ""
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(annotationPath.Child(vmopv1.SerialConsoleAnnotation), "modifying this annotation is not allowed for non-admin users").Error()),
+				},
+			),
+			Entry("should disallow creating VM with last reverted snapshot annotation set by SSO user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Annotations[vmopv1.LastRevertedSnapshotAnnotation] = "my-snapshot"
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(annotationPath.Child(vmopv1.LastRevertedSnapshotAnnotation), "modifying this annotation is not allowed for non-admin users").Error()),
+				},
+			),
 			Entry("should allow creating VM with admin-only annotations set by service user",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
@@ -930,6 +965,10 @@ func unitTestsValidateCreate() {
 
 						ctx.vm.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal
 						ctx.vm.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal
+						ctx.vm.Annotations[vmopv1.CloneSourceAnnotation] = "source-vm"
+						ctx.vm.Annotations[vmopv1.CloneTypeAnnotation] = string(vmopv1.VirtualMachineCloneTypeFull)
+						ctx.vm.Annotations[vmopv1.SerialConsoleAnnotation] = ""
+						ctx.vm.Annotations[vmopv1.LastRevertedSnapshotAnnotation] = "my-snapshot"
 					},
 					expectAllowed: true,
 				},
@@ -1115,6 +1154,237 @@ func unitTestsValidateCreate() {
 					expectAllowed: true,
 				},
 			),
+			Entry("should fail when Readiness probe has HTTPGet and TCPSocket actions",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							TCPSocket: &vmopv1.TCPSocketAction{Port: intstr.FromInt(6443)},
+							HTTPGet:   &vmopv1.HTTPGetAction{Port: intstr.FromInt(6443)},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe: Forbidden: only one action can be specified`),
+				},
+			),
+			Entry("should allow valid HTTPGet readiness probe",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{
+								Path:   "/healthz",
+								Port:   intstr.FromInt(6443),
+								Scheme: vmopv1.URISchemeHTTPS,
+								HTTPHeaders: []vmopv1.HTTPHeader{
+									{Name: "X-Probe", Value: "true"},
+								},
+								ExpectedStatus: &vmopv1.HTTPStatusRange{Min: 200, Max: 204},
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should deny when HTTPGet readiness probe is specified under VPC networking",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{Port: intstr.FromInt(6443)},
+						}
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeVPC
+						})
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe.httpGet: Forbidden: VPC networking doesn't allow HTTPGet readiness probe to be specified`),
+				},
+			),
+			Entry("should allow when HTTPGet readiness probe port is a name",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{Port: intstr.FromString("http")},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should deny when HTTPGet readiness probe port is an invalid name",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{Port: intstr.FromString("http_port")},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe.httpGet.port: Invalid value: "http_port": must contain only alpha-numeric characters (a-z, 0-9), and hyphens (-)`),
+				},
+			),
+			Entry("should allow when HTTPGet readiness probe port is a number in range",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{Port: intstr.FromInt(8080)},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should deny when HTTPGet readiness probe port is zero",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{Port: intstr.FromInt(0)},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe.httpGet.port: Invalid value: "0": must be a number in the range 1 to 65535`),
+				},
+			),
+			Entry("should deny when HTTPGet readiness probe port is out of range",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{Port: intstr.FromInt(70000)},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe.httpGet.port: Invalid value: "70000": must be a number in the range 1 to 65535`),
+				},
+			),
+			Entry("should deny when restricted network and HTTPGet port in readiness probe is not 6443",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						cm := &corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      config.ProviderConfigMapName,
+								Namespace: ctx.Namespace,
+							},
+							Data: map[string]string{
+								"IsRestrictedNetwork": "true",
+							},
+						}
+						Expect(ctx.Client.Create(ctx, cm)).To(Succeed())
+
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{Port: intstr.FromInt(443)},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe.httpGet.port: Unsupported value: 443: supported values: "6443"`),
+				},
+			),
+			Entry("should deny when HTTPGet readiness probe has an invalid scheme",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{
+								Port:   intstr.FromInt(6443),
+								Scheme: "FTP",
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe.httpGet.scheme: Unsupported value: "FTP": supported values: "HTTP", "HTTPS"`),
+				},
+			),
+			Entry("should deny when HTTPGet readiness probe header name is empty",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{
+								Port: intstr.FromInt(6443),
+								HTTPHeaders: []vmopv1.HTTPHeader{
+									{Value: "true"},
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe.httpGet.httpHeaders[0].name: Required value`),
+				},
+			),
+			Entry("should deny when HTTPGet readiness probe expected status min is greater than max",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{
+								Port:           intstr.FromInt(6443),
+								ExpectedStatus: &vmopv1.HTTPStatusRange{Min: 400, Max: 200},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe.httpGet.expectedStatus: Invalid value: "400-200": min must be less than or equal to max`),
+				},
+			),
+		)
+	})
+
+	Context("Liveness Probe", func() {
+
+		DescribeTable("create", doTest,
+			Entry("should allow valid liveness probe",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+							GuestHeartbeat:   &vmopv1.GuestHeartbeatAction{},
+							FailureThreshold: 3,
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should fail when liveness probe has multiple actions",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+							GuestInfo: []vmopv1.GuestInfoAction{
+								{
+									Key: "my-key",
+								},
+							},
+							GuestHeartbeat: &vmopv1.GuestHeartbeatAction{},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.livenessProbe: Forbidden: only one action can be specified`),
+				},
+			),
+			Entry("should deny when TCP liveness probe is specified under VPC networking",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+							TCPSocket: &vmopv1.TCPSocketAction{},
+						}
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeVPC
+						})
+					},
+					validate: doValidateWithMsg(
+						`spec.livenessProbe.tcpSocket: Forbidden: VPC networking doesn't allow TCP liveness probe to be specified`),
+				},
+			),
+			Entry("should allow when HTTPGet liveness probe port is a name",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{Port: intstr.FromString("http")},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should deny when HTTPGet liveness probe port is an invalid name",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{Port: intstr.FromString("-http")},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.livenessProbe.httpGet.port: Invalid value: "-http": must not begin or end with a hyphen`),
+				},
+			),
 		)
 	})
 
@@ -2698,91 +2968,384 @@ func unitTestsValidateUpdate() {
 		}
 	}
 
-	Context("Annotations", func() {
-		annotationPath := field.NewPath("metadata", "annotations")
+	Context("StorageClass", func() {
+		scPath := field.NewPath("spec", "storageClass")
+
+		setupStorageClassChange := func(ctx *unitValidatingWebhookContext, enableFeature bool) {
+			pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+				config.Features.VMStorageClassChange = enableFeature
+			})
+
+			storageClass := builder.DummyStorageClass()
+			Expect(ctx.Client.Create(ctx, storageClass)).To(Succeed())
+			rlName := storageClass.Name + ".storageclass.storage.k8s.io/persistentvolumeclaims"
+			Expect(ctx.Client.Create(ctx, builder.DummyResourceQuota(ctx.vm.Namespace, rlName))).To(Succeed())
+
+			ctx.oldVM.Spec.StorageClass = storageClass.Name + "-old"
+			ctx.vm.Spec.StorageClass = storageClass.Name
+		}
 
 		DescribeTable("update", doTest,
-			Entry("should disallow updating admin-only annotations by SSO user",
+			Entry("should deny storage class change when VM storage class change feature is disabled",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.oldVM.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal
-						ctx.oldVM.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal
-						ctx.oldVM.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal
-						ctx.oldVM.Annotations[constants.CreatedAtSchemaVersionAnnotationKey] = dummyCreatedAtSchemaVersionVal
-						ctx.vm.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal + updateSuffix
-						ctx.vm.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal + updateSuffix
-						ctx.vm.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal + updateSuffix
-						ctx.vm.Annotations[constants.CreatedAtSchemaVersionAnnotationKey] = dummyCreatedAtSchemaVersionVal + updateSuffix
+						setupStorageClassChange(ctx, false)
 					},
 					validate: doValidateWithMsg(
-						field.Forbidden(annotationPath.Child(vmopv1.InstanceIDAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
-						field.Forbidden(annotationPath.Child(vmopv1.FirstBootDoneAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
-						field.Forbidden(annotationPath.Child(constants.CreatedAtBuildVersionAnnotationKey), "modifying this annotation is not allowed for non-admin users").Error(),
-						field.Forbidden(annotationPath.Child(constants.CreatedAtSchemaVersionAnnotationKey), "modifying this annotation is not allowed for non-admin users").Error()),
+						field.Invalid(scPath, builder.DummyStorageClassName, "field is immutable").Error()),
 				},
 			),
-			Entry("should disallow removing admin-only annotations by SSO user",
+			Entry("should allow storage class change when VM storage class change feature is enabled",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.oldVM.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal
-						ctx.oldVM.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal
-						ctx.oldVM.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal
-						ctx.oldVM.Annotations[constants.CreatedAtSchemaVersionAnnotationKey] = dummyCreatedAtSchemaVersionVal
+						setupStorageClassChange(ctx, true)
 					},
-					validate: doValidateWithMsg(
-						field.Forbidden(annotationPath.Child(vmopv1.InstanceIDAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
-						field.Forbidden(annotationPath.Child(vmopv1.FirstBootDoneAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
-						field.Forbidden(annotationPath.Child(constants.CreatedAtBuildVersionAnnotationKey), "modifying this annotation is not allowed for non-admin users").Error(),
-						field.Forbidden(annotationPath.Child(constants.CreatedAtSchemaVersionAnnotationKey), "modifying this annotation is not allowed for non-admin users").Error()),
+					expectAllowed: true,
 				},
 			),
-			Entry("should allow updating admin-only annotations by service user",
+			Entry("should deny storage class change to a storage class that does not exist",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.IsPrivilegedAccount = true
-
-						ctx.oldVM.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal
-						ctx.oldVM.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal
-						ctx.oldVM.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal
-						ctx.oldVM.Annotations[constants.CreatedAtSchemaVersionAnnotationKey] = dummyCreatedAtSchemaVersionVal
-						ctx.vm.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal + updateSuffix
-						ctx.vm.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal + updateSuffix
-						ctx.vm.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal + updateSuffix
-						ctx.vm.Annotations[constants.CreatedAtSchemaVersionAnnotationKey] = dummyCreatedAtSchemaVersionVal + updateSuffix
+						setupStorageClassChange(ctx, true)
+						ctx.vm.Spec.StorageClass = "invalid"
 					},
-					expectAllowed: true,
+					validate: doValidateWithMsg(
+						field.Invalid(scPath, "invalid", "Storage policy invalid does not exist").Error()),
 				},
 			),
-			Entry("should allow removing admin-only annotations by service user",
+			Entry("should deny removing the storage class",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.IsPrivilegedAccount = true
-
-						ctx.oldVM.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal
-						ctx.oldVM.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal
-						ctx.oldVM.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal
-						ctx.oldVM.Annotations[constants.CreatedAtSchemaVersionAnnotationKey] = dummyCreatedAtSchemaVersionVal
+						setupStorageClassChange(ctx, true)
+						ctx.vm.Spec.StorageClass = ""
 					},
-					expectAllowed: true,
+					validate: doValidateWithMsg(
+						field.Invalid(scPath, "", "field is immutable").Error()),
 				},
 			),
-			Entry("should allow updating admin-only annotations by privileged user",
+			Entry("should deny storage class change of VM with instance storage",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						privilegedUsersEnvList := "  , foo ,bar , test,  "
-						privilegedUser := "bar"
+						setupStorageClassChange(ctx, true)
+						ctx.oldVM.Spec.Volumes = append(ctx.oldVM.Spec.Volumes, builder.DummyInstanceStorageVirtualMachineVolumes()...)
+						ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes, builder.DummyInstanceStorageVirtualMachineVolumes()...)
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(scPath, "cannot change the storage class of a VM with instance storage").Error()),
+				},
+			),
+		)
+	})
 
-						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
-							config.PrivilegedUsers = privilegedUsersEnvList
-						})
+	Context("Image", func() {
+		imagePath := field.NewPath("spec", "image")
+		const newImageName = builder.DummyVMIName + "-new"
 
-						ctx.UserInfo.Username = privilegedUser
-						ctx.IsPrivilegedAccount = pkgbuilder.IsPrivilegedAccount(ctx.WebhookContext, ctx.UserInfo)
+		setupImageChange := func(ctx *unitValidatingWebhookContext, enableFeature bool) {
+			pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+				config.Features.VMRebuild = enableFeature
+			})
 
-						ctx.oldVM.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal
-						ctx.oldVM.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal
-						ctx.oldVM.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal
-						ctx.oldVM.Annotations[constants.CreatedAtSchemaVersionAnnotationKey] = dummyCreatedAtSchemaVersionVal
+			ctx.oldVM.Status.Image = &common.LocalObjectRef{
+				APIVersion: vmopv1.GroupVersion.String(),
+				Kind:       ctx.oldVM.Spec.Image.Kind,
+				Name:       ctx.oldVM.Spec.Image.Name,
+			}
+			ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{
+				Kind: vmiKind,
+				Name: newImageName,
+			}
+			ctx.vm.Spec.ImageName = newImageName
+		}
+
+		validateImmutable := func(response admission.Response) {
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.image: Invalid value"))
+			Expect(string(response.Result.Reason)).To(ContainSubstring("field is immutable"))
+		}
+
+		DescribeTable("update", doTest,
+			Entry("should deny image change when VM rebuild feature is disabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupImageChange(ctx, false)
+					},
+					validate: validateImmutable,
+				},
+			),
+			Entry("should allow image change when VM rebuild feature is enabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupImageChange(ctx, true)
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should allow image change with empty image name when VM rebuild feature is enabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupImageChange(ctx, true)
+						ctx.vm.Spec.ImageName = ""
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should deny image change before the VM's deployed image is known",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupImageChange(ctx, true)
+						ctx.oldVM.Status.Image = nil
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(imagePath, "cannot change the image until the VM's deployed image is reported in status.image").Error()),
+				},
+			),
+			Entry("should deny removing the image",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupImageChange(ctx, true)
+						ctx.vm.Spec.Image = nil
+					},
+					validate: validateImmutable,
+				},
+			),
+			Entry("should deny image name change without image change",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupImageChange(ctx, true)
+						ctx.vm.Spec.Image = ctx.oldVM.Spec.Image.DeepCopy()
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(field.NewPath("spec", "imageName"), newImageName, "field is immutable").Error()),
+				},
+			),
+			Entry("should deny image name that does not match the new image",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupImageChange(ctx, true)
+						ctx.vm.Spec.ImageName = builder.DummyImageName
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(field.NewPath("spec", "imageName"), builder.DummyImageName,
+							"must be empty or equal to spec.image.name when the image is changed").Error()),
+				},
+			),
+			Entry("should deny image change with invalid kind",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupImageChange(ctx, true)
+						ctx.vm.Spec.Image.Kind = "bogus"
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(imagePath.Child("kind"), "bogus", "supported: VirtualMachineImage; ClusterVirtualMachineImage").Error()),
+				},
+			),
+			Entry("should deny image change of VM with instance storage",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupImageChange(ctx, true)
+						ctx.oldVM.Spec.Volumes = append(ctx.oldVM.Spec.Volumes, builder.DummyInstanceStorageVirtualMachineVolumes()...)
+						ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes, builder.DummyInstanceStorageVirtualMachineVolumes()...)
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(imagePath, "cannot change the image of a VM with instance storage").Error()),
+				},
+			),
+		)
+	})
+
+	Context("Relocate", func() {
+		zoneLabelPath := field.NewPath("metadata", "labels").Key(topology.KubernetesTopologyZoneLabelKey)
+		nextRelocateTimePath := field.NewPath("spec", "nextRelocateTime")
+		const newZoneName = builder.DummyZoneName + "-2"
+
+		setupZoneChange := func(ctx *unitValidatingWebhookContext, enableFeature bool) {
+			pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+				config.Features.VMRelocate = enableFeature
+			})
+			Expect(ctx.Client.Create(ctx, builder.DummyNamedAvailabilityZone(newZoneName))).To(Succeed())
+			ctx.oldVM.Labels[topology.KubernetesTopologyZoneLabelKey] = builder.DummyZoneName
+			ctx.vm.Labels[topology.KubernetesTopologyZoneLabelKey] = newZoneName
+		}
+
+		DescribeTable("update", doTest,
+			Entry("should deny zone change when VM Relocate feature is disabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupZoneChange(ctx, false)
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(zoneLabelPath, newZoneName, "field is immutable").Error()),
+				},
+			),
+			Entry("should allow zone change when VM Relocate feature is enabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupZoneChange(ctx, true)
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should deny zone change to a zone that does not exist",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupZoneChange(ctx, true)
+						ctx.vm.Labels[topology.KubernetesTopologyZoneLabelKey] = "invalid"
+					},
+				},
+			),
+			Entry("should deny removing the zone when VM Relocate feature is enabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupZoneChange(ctx, true)
+						delete(ctx.vm.Labels, topology.KubernetesTopologyZoneLabelKey)
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(zoneLabelPath, "", "field is immutable").Error()),
+				},
+			),
+			Entry("should deny zone change of VM with instance storage",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupZoneChange(ctx, true)
+						ctx.oldVM.Spec.Volumes = append(ctx.oldVM.Spec.Volumes, builder.DummyInstanceStorageVirtualMachineVolumes()...)
+						ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes, builder.DummyInstanceStorageVirtualMachineVolumes()...)
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(zoneLabelPath, "cannot change the zone of a VM with instance storage").Error()),
+				},
+			),
+			Entry("should deny nextRelocateTime when VM Relocate feature is disabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.NextRelocateTime = time.Now().UTC().Format(time.RFC3339Nano)
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(nextRelocateTimePath, "the VM Relocate feature is not enabled").Error()),
+				},
+			),
+			Entry("should allow valid nextRelocateTime when VM Relocate feature is enabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMRelocate = true
+						})
+						ctx.vm.Spec.NextRelocateTime = time.Now().UTC().Format(time.RFC3339Nano)
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should deny nextRelocateTime of now if mutation webhooks were not running",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMRelocate = true
+						})
+						ctx.vm.Spec.NextRelocateTime = "now"
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(nextRelocateTimePath, "now", "mutation webhooks are required to relocate VM").Error()),
+				},
+			),
+			Entry("should deny invalid nextRelocateTime",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMRelocate = true
+						})
+						ctx.vm.Spec.NextRelocateTime = "hello"
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(nextRelocateTimePath, "hello", "must be formatted as RFC3339Nano").Error()),
+				},
+			),
+		)
+	})
+
+	Context("Annotations", func() {
+		annotationPath := field.NewPath("metadata", "annotations")
+
+		DescribeTable("update", doTest,
+			Entry("should disallow updating admin-only annotations by SSO user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal
+						ctx.oldVM.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal
+						ctx.oldVM.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal
+						ctx.oldVM.Annotations[constants.CreatedAtSchemaVersionAnnotationKey] = dummyCreatedAtSchemaVersionVal
+						ctx.vm.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal + updateSuffix
+						ctx.vm.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal + updateSuffix
+						ctx.vm.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal + updateSuffix
+						ctx.vm.Annotations[constants.CreatedAtSchemaVersionAnnotationKey] = dummyCreatedAtSchemaVersionVal + updateSuffix
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(annotationPath.Child(vmopv1.InstanceIDAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
+						field.Forbidden(annotationPath.Child(vmopv1.FirstBootDoneAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
+						field.Forbidden(annotationPath.Child(constants.CreatedAtBuildVersionAnnotationKey), "modifying this annotation is not allowed for non-admin users").Error(),
+						field.Forbidden(annotationPath.Child(constants.CreatedAtSchemaVersionAnnotationKey), "modifying this annotation is not allowed for non-admin users").Error()),
+				},
+			),
+			Entry("should disallow removing admin-only annotations by SSO user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal
+						ctx.oldVM.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal
+						ctx.oldVM.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal
+						ctx.oldVM.Annotations[constants.CreatedAtSchemaVersionAnnotationKey] = dummyCreatedAtSchemaVersionVal
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(annotationPath.Child(vmopv1.InstanceIDAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
+						field.Forbidden(annotationPath.Child(vmopv1.FirstBootDoneAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
+						field.Forbidden(annotationPath.Child(constants.CreatedAtBuildVersionAnnotationKey), "modifying this annotation is not allowed for non-admin users").Error(),
+						field.Forbidden(annotationPath.Child(constants.CreatedAtSchemaVersionAnnotationKey), "modifying this annotation is not allowed for non-admin users").Error()),
+				},
+			),
+			Entry("should allow updating admin-only annotations by service user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.IsPrivilegedAccount = true
+
+						ctx.oldVM.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal
+						ctx.oldVM.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal
+						ctx.oldVM.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal
+						ctx.oldVM.Annotations[constants.CreatedAtSchemaVersionAnnotationKey] = dummyCreatedAtSchemaVersionVal
+						ctx.vm.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal + updateSuffix
+						ctx.vm.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal + updateSuffix
+						ctx.vm.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal + updateSuffix
+						ctx.vm.Annotations[constants.CreatedAtSchemaVersionAnnotationKey] = dummyCreatedAtSchemaVersionVal + updateSuffix
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should allow removing admin-only annotations by service user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.IsPrivilegedAccount = true
+
+						ctx.oldVM.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal
+						ctx.oldVM.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal
+						ctx.oldVM.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal
+						ctx.oldVM.Annotations[constants.CreatedAtSchemaVersionAnnotationKey] = dummyCreatedAtSchemaVersionVal
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should allow updating admin-only annotations by privileged user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						privilegedUsersEnvList := "  , foo ,bar , test,  "
+						privilegedUser := "bar"
+
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.PrivilegedUsers = privilegedUsersEnvList
+						})
+
+						ctx.UserInfo.Username = privilegedUser
+						ctx.IsPrivilegedAccount = pkgbuilder.IsPrivilegedAccount(ctx.WebhookContext, ctx.UserInfo)
+
+						ctx.oldVM.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal
+						ctx.oldVM.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal
+						ctx.oldVM.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal
+						ctx.oldVM.Annotations[constants.CreatedAtSchemaVersionAnnotationKey] = dummyCreatedAtSchemaVersionVal
 						ctx.vm.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal + updateSuffix
 						ctx.vm.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal + updateSuffix
 						ctx.vm.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal + updateSuffix
@@ -3015,6 +3578,119 @@ func unitTestsValidateUpdate() {
 						`spec.network.interfaces: Forbidden: network interfaces cannot be added or removed`),
 				},
 			),
+
+			Entry("allow adding network interface when FSS_WCP_VMSERVICE_VM_NETWORK_HOT_PLUG is enabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMNetworkHotPlug = true
+						})
+
+						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name: "eth0",
+								},
+							},
+						}
+
+						ctx.vm = ctx.oldVM.DeepCopy()
+						ctx.vm.Spec.Network.Interfaces = append(ctx.vm.Spec.Network.Interfaces,
+							vmopv1.VirtualMachineNetworkInterfaceSpec{Name: "eth1"})
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("allow removing network interface when FSS_WCP_VMSERVICE_VM_NETWORK_HOT_PLUG is enabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMNetworkHotPlug = true
+						})
+
+						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name: "eth0",
+								},
+								{
+									Name: "eth1",
+								},
+							},
+						}
+
+						ctx.vm = ctx.oldVM.DeepCopy()
+						ctx.vm.Spec.Network.Interfaces = ctx.vm.Spec.Network.Interfaces[1:]
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow changing network of existing interface when FSS_WCP_VMSERVICE_VM_NETWORK_HOT_PLUG is enabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMNetworkHotPlug = true
+						})
+
+						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name:    "eth0",
+									Network: &common.PartialObjectRef{Name: "my-network"},
+								},
+								{
+									Name:    "eth1",
+									Network: &common.PartialObjectRef{Name: "my-network"},
+								},
+							},
+						}
+
+						ctx.vm = ctx.oldVM.DeepCopy()
+						ctx.vm.Spec.Network.Interfaces = ctx.vm.Spec.Network.Interfaces[1:]
+						ctx.vm.Spec.Network.Interfaces[0].Network.Name = "my-other-network"
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].network: Forbidden: field is immutable`),
+				},
+			),
+
+			Entry("disallow reordering existing interfaces when FSS_WCP_VMSERVICE_VM_NETWORK_HOT_PLUG is enabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMNetworkHotPlug = true
+						})
+
+						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name: "eth0",
+								},
+								{
+									Name: "eth1",
+								},
+							},
+						}
+
+						ctx.vm = ctx.oldVM.DeepCopy()
+						ctx.vm.Spec.Network.Interfaces = []vmopv1.VirtualMachineNetworkInterfaceSpec{
+							{
+								Name: "eth1",
+							},
+							{
+								Name: "eth2",
+							},
+							{
+								Name: "eth0",
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[2]: Forbidden: existing network interfaces cannot be reordered`),
+				},
+			),
 		)
 
 		DescribeTable("update network - host and domain names", doTest,
@@ -3655,6 +4331,116 @@ func unitTestsValidateUpdate() {
 			),
 		)
 	})
+
+	Context("Disruption budget", func() {
+		DescribeTable("power state update", doTest,
+			Entry("disallow powering off a VM when the budget allows no disruptions",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupDisruptionBudget(ctx, ctx.oldVM, true)
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+					},
+					validate: doValidateWithMsg(
+						`spec.powerState: Forbidden: disruption not allowed: VirtualMachineDisruptionBudget "dummy-budget" requires 1 healthy VMs and 1 are healthy`),
+				},
+			),
+
+			Entry("disallow suspending a VM when the budget allows no disruptions",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupDisruptionBudget(ctx, ctx.oldVM, true)
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateSuspended
+					},
+					validate: doValidateWithMsg(
+						`spec.powerState: Forbidden: disruption not allowed: VirtualMachineDisruptionBudget "dummy-budget" requires 1 healthy VMs and 1 are healthy`),
+				},
+			),
+
+			Entry("allow powering off a VM when the budget allows a disruption",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupDisruptionBudget(ctx, ctx.oldVM, true)
+						addReadyVMForDisruptionBudget(ctx)
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("allow powering off a VM when the feature is disabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupDisruptionBudget(ctx, ctx.oldVM, false)
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("allow powering off a VM as a privileged user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupDisruptionBudget(ctx, ctx.oldVM, true)
+						ctx.IsPrivilegedAccount = true
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("allow powering on a VM when the budget allows no disruptions",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setupDisruptionBudget(ctx, ctx.oldVM, true)
+						ctx.oldVM.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+					},
+					expectAllowed: true,
+				},
+			),
+		)
+	})
+}
+
+// setupDisruptionBudget makes the VM ready, adds it to the client, and creates
+// a VirtualMachineDisruptionBudget that requires one of the VMs labeled like
+// the VM to be healthy.
+func setupDisruptionBudget(
+	ctx *unitValidatingWebhookContext,
+	vm *vmopv1.VirtualMachine,
+	enableFeature bool) {
+
+	pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+		config.Features.K8sWorkloadMgmtAPI = enableFeature
+	})
+
+	vm.Labels["app"] = "dummy"
+	vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+	vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+	conditions.MarkTrue(vm, vmopv1.VirtualMachineConditionCreated)
+	if ctx.vm != vm {
+		ctx.vm.Labels["app"] = "dummy"
+		ctx.vm.Status = *vm.Status.DeepCopy()
+	}
+	Expect(ctx.Client.Create(ctx, vm.DeepCopy())).To(Succeed())
+
+	budget := builder.DummyVirtualMachineDisruptionBudget()
+	budget.GenerateName = ""
+	budget.Name = "dummy-budget"
+	budget.Namespace = vm.Namespace
+	Expect(ctx.Client.Create(ctx, budget)).To(Succeed())
+}
+
+// addReadyVMForDisruptionBudget adds another ready VM selected by the
+// VirtualMachineDisruptionBudget created by setupDisruptionBudget.
+func addReadyVMForDisruptionBudget(ctx *unitValidatingWebhookContext) {
+	vm := builder.DummyVirtualMachine()
+	vm.Name = "dummy-vm-other"
+	vm.Namespace = dummyNamespaceName
+	vm.Labels["app"] = "dummy"
+	vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+	conditions.MarkTrue(vm, vmopv1.VirtualMachineConditionCreated)
+	Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
 }
 
 func unitTestsValidateDelete() {
@@ -3673,6 +4459,9 @@ func unitTestsValidateDelete() {
 
 	When("the delete is performed", func() {
 		JustBeforeEach(func() {
+			var err error
+			ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vm)
+			Expect(err).ToNot(HaveOccurred())
 			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
 		})
 
@@ -3680,5 +4469,96 @@ func unitTestsValidateDelete() {
 			Expect(response.Allowed).To(BeTrue())
 			Expect(response.Result).ToNot(BeNil())
 		})
+
+		When("the VM is selected by a disruption budget", func() {
+			BeforeEach(func() {
+				setupDisruptionBudget(ctx, ctx.vm, true)
+			})
+
+			It("should deny the request when the budget allows no disruptions", func() {
+				Expect(response.Allowed).To(BeFalse())
+				Expect(response.Result.Message).To(ContainSubstring(`VirtualMachineDisruptionBudget "dummy-budget" requires 1 healthy VMs and 1 are healthy`))
+			})
+
+			When("the budget allows a disruption", func() {
+				BeforeEach(func() {
+					addReadyVMForDisruptionBudget(ctx)
+				})
+
+				It("should allow the request", func() {
+					Expect(response.Allowed).To(BeTrue())
+				})
+			})
+
+			When("the VM is not ready", func() {
+				BeforeEach(func() {
+					conditions.MarkFalse(ctx.vm, vmopv1.VirtualMachineConditionCreated, "NotCreated", "")
+				})
+
+				It("should allow the request", func() {
+					Expect(response.Allowed).To(BeTrue())
+				})
+			})
+
+			When("the request is from a privileged user", func() {
+				BeforeEach(func() {
+					ctx.IsPrivilegedAccount = true
+				})
+
+				It("should allow the request", func() {
+					Expect(response.Allowed).To(BeTrue())
+				})
+			})
+
+			DescribeTable("the request is from a controller that deletes owned VMs",
+				func(username string) {
+					ctx.UserInfo.Username = username
+					response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+					Expect(response.Allowed).To(BeTrue())
+				},
+				Entry("garbage collector", "system:serviceaccount:kube-system:generic-garbage-collector"),
+				Entry("namespace controller", "system:serviceaccount:kube-system:namespace-controller"),
+			)
+
+			When("the VM is already being deleted", func() {
+				BeforeEach(func() {
+					ctx.vm.DeletionTimestamp = ptr.To(metav1.Now())
+					ctx.vm.Finalizers = []string{"test"}
+				})
+
+				It("should allow the request", func() {
+					Expect(response.Allowed).To(BeTrue())
+				})
+			})
+
+			When("the namespace is being deleted", func() {
+				BeforeEach(func() {
+					ns := &corev1.Namespace{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:       ctx.vm.Namespace,
+							Finalizers: []string{"test"},
+						},
+					}
+					Expect(ctx.Client.Create(ctx, ns)).To(Succeed())
+					Expect(ctx.Client.Delete(ctx, ns)).To(Succeed())
+				})
+
+				It("should allow the request", func() {
+					Expect(response.Allowed).To(BeTrue())
+				})
+			})
+
+			When("the feature is disabled", func() {
+				BeforeEach(func() {
+					pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+						config.Features.K8sWorkloadMgmtAPI = false
+					})
+				})
+
+				It("should allow the request", func() {
+					Expect(response.Allowed).To(BeTrue())
+				})
+			})
+		})
 	})
 }
@@ -0,0 +1,233 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	rollingUpdateNotAllowedWithRecreate = "may not be specified when strategy type is Recreate"
+	maxSurgeAndMaxUnavailableZero       = "may not be 0 when maxSurge is 0"
+	invalidIntOrPercent                 = "must be a non-negative integer or a percentage"
+	percentOver100                      = "must not be greater than 100%"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachinedeployment,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinedeployments,versions=v1alpha3,name=default.validating.virtualmachinedeployment.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create VirtualMachineDeployment validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineDeployment{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	d, err := v.deploymentFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+
+	fieldErrs = append(fieldErrs, v.validateLabelSelectorLabelMatch(d)...)
+	fieldErrs = append(fieldErrs, v.validateStrategy(d)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	d, err := v.deploymentFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	oldD, err := v.deploymentFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+
+	// The selector cannot change since it would orphan the replica sets of
+	// previous revisions.
+	fieldErrs = append(fieldErrs, validation.ValidateImmutableField(
+		d.Spec.Selector, oldD.Spec.Selector, field.NewPath("spec", "selector"))...)
+	fieldErrs = append(fieldErrs, v.validateLabelSelectorLabelMatch(d)...)
+	fieldErrs = append(fieldErrs, v.validateStrategy(d)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateLabelSelectorLabelMatch(d *vmopv1.VirtualMachineDeployment) field.ErrorList {
+	var allErrs field.ErrorList
+
+	specPath := field.NewPath("spec")
+
+	if d.Spec.Selector == nil {
+		allErrs = append(allErrs, field.Required(specPath.Child("selector"), ""))
+	} else if selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector); err != nil {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				specPath.Child("selector"),
+				d.Spec.Selector,
+				err.Error(),
+			),
+		)
+	} else if selector.Empty() {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				specPath.Child("selector"),
+				d.Spec.Selector,
+				"empty selector is invalid for deployment",
+			),
+		)
+	} else if !selector.Matches(labels.Set(d.Spec.Template.ObjectMeta.Labels)) {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				specPath.Child("template", "metadata", "labels"),
+				d.Spec.Template.ObjectMeta.Labels,
+				fmt.Sprintf("must match spec.selector %q", selector.String()),
+			),
+		)
+	}
+
+	allErrs = append(allErrs, d.Spec.Template.ObjectMeta.Validate(specPath.Child("template", "metadata"))...)
+
+	return allErrs
+}
+
+func (v validator) validateStrategy(d *vmopv1.VirtualMachineDeployment) field.ErrorList {
+	var allErrs field.ErrorList
+
+	strategy := d.Spec.Strategy
+	rollingUpdate := strategy.RollingUpdate
+	if rollingUpdate == nil {
+		return allErrs
+	}
+
+	rollingUpdatePath := field.NewPath("spec", "strategy", "rollingUpdate")
+
+	if strategy.Type == vmopv1.RecreateVirtualMachineDeploymentStrategyType {
+		return append(allErrs, field.Forbidden(rollingUpdatePath, rollingUpdateNotAllowedWithRecreate))
+	}
+
+	allErrs = append(allErrs, validateIntOrPercent(rollingUpdate.MaxSurge, rollingUpdatePath.Child("maxSurge"), false)...)
+	allErrs = append(allErrs, validateIntOrPercent(rollingUpdate.MaxUnavailable, rollingUpdatePath.Child("maxUnavailable"), true)...)
+
+	if len(allErrs) == 0 && isZero(rollingUpdate.MaxSurge) && isZero(rollingUpdate.MaxUnavailable) {
+		allErrs = append(allErrs, field.Invalid(rollingUpdatePath.Child("maxUnavailable"),
+			rollingUpdate.MaxUnavailable.String(), maxSurgeAndMaxUnavailableZero))
+	}
+
+	return allErrs
+}
+
+// validateIntOrPercent validates that the value is a non-negative integer or
+// percentage. A percentage greater than 100% is only allowed if
+// capAt100Percent is false.
+func validateIntOrPercent(v *intstr.IntOrString, fieldPath *field.Path, capAt100Percent bool) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if v == nil {
+		return allErrs
+	}
+
+	switch v.Type {
+	case intstr.Int:
+		if v.IntVal < 0 {
+			allErrs = append(allErrs, field.Invalid(fieldPath, v.IntVal, invalidIntOrPercent))
+		}
+	case intstr.String:
+		percent, err := strconv.Atoi(strings.TrimSuffix(v.StrVal, "%"))
+		switch {
+		case !strings.HasSuffix(v.StrVal, "%") || err != nil || percent < 0:
+			allErrs = append(allErrs, field.Invalid(fieldPath, v.StrVal, invalidIntOrPercent))
+		case capAt100Percent && percent > 100:
+			allErrs = append(allErrs, field.Invalid(fieldPath, v.StrVal, percentOver100))
+		}
+	}
+
+	return allErrs
+}
+
+// isZero returns true if the value is zero or 0%. A nil value is not zero
+// since it defaults to 25%.
+func isZero(v *intstr.IntOrString) bool {
+	if v == nil {
+		return false
+	}
+	if v.Type == intstr.Int {
+		return v.IntVal == 0
+	}
+	return v.StrVal == "0%"
+}
+
+// deploymentFromUnstructured returns the VirtualMachineDeployment from the unstructured object.
+func (v validator) deploymentFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineDeployment, error) {
+	d := &vmopv1.VirtualMachineDeployment{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	deployment, oldDeployment *vmopv1.VirtualMachineDeployment
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	deployment := builder.DummyVirtualMachineDeployment()
+	deployment.Name = "dummy-deployment-for-webhook-validation"
+	deployment.Namespace = "dummy-deployment-namespace-for-webhook-validation"
+	obj, err := builder.ToUnstructured(deployment)
+	Expect(err).ToNot(HaveOccurred())
+
+	var (
+		oldDeployment *vmopv1.VirtualMachineDeployment
+		oldObj        *unstructured.Unstructured
+	)
+
+	if isUpdate {
+		oldDeployment = deployment.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldDeployment)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		deployment:                          deployment,
+		oldDeployment:                       oldDeployment,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		noSelector            bool
+		emptySelector         bool
+		selectorMismatch      bool
+		recreateWithRolling   bool
+		maxSurge              *intstr.IntOrString
+		maxUnavailable        *intstr.IntOrString
+		invalidTemplateLabels bool
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string) {
+		if args.noSelector {
+			ctx.deployment.Spec.Selector = nil
+		}
+		if args.emptySelector {
+			ctx.deployment.Spec.Selector = &metav1.LabelSelector{}
+		}
+		if args.selectorMismatch {
+			ctx.deployment.Spec.Template.Labels = map[string]string{"app": "other"}
+		}
+		if args.recreateWithRolling {
+			ctx.deployment.Spec.Strategy.Type = vmopv1.RecreateVirtualMachineDeploymentStrategyType
+			ctx.deployment.Spec.Strategy.RollingUpdate = &vmopv1.RollingUpdateVirtualMachineDeployment{}
+		}
+		if args.maxSurge != nil || args.maxUnavailable != nil {
+			ctx.deployment.Spec.Strategy.RollingUpdate = &vmopv1.RollingUpdateVirtualMachineDeployment{
+				MaxSurge:       args.maxSurge,
+				MaxUnavailable: args.maxUnavailable,
+			}
+		}
+		if args.invalidTemplateLabels {
+			ctx.deployment.Spec.Template.Labels["/invalid-key"] = "foo"
+		}
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.deployment)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow valid", createArgs{}, true, ""),
+		Entry("should allow valid rolling update", createArgs{
+			maxSurge:       ptr.To(intstr.FromInt(0)),
+			maxUnavailable: ptr.To(intstr.FromString("50%")),
+		}, true, ""),
+		Entry("should allow maxSurge greater than 100%", createArgs{
+			maxSurge: ptr.To(intstr.FromString("200%")),
+		}, true, ""),
+		Entry("should deny missing selector", createArgs{noSelector: true}, false,
+			"spec.selector: Required value"),
+		Entry("should deny empty selector", createArgs{emptySelector: true}, false,
+			"spec.selector: Invalid value"),
+		Entry("should deny selector that does not match the template labels", createArgs{selectorMismatch: true}, false,
+			"spec.template.metadata.labels: Invalid value"),
+		Entry("should deny invalid template labels", createArgs{invalidTemplateLabels: true}, false,
+			"spec.template.metadata.labels: Invalid value"),
+		Entry("should deny rollingUpdate with Recreate", createArgs{recreateWithRolling: true}, false,
+			"spec.strategy.rollingUpdate: Forbidden: may not be specified when strategy type is Recreate"),
+		Entry("should deny negative maxSurge", createArgs{
+			maxSurge: ptr.To(intstr.FromInt(-1)),
+		}, false, "spec.strategy.rollingUpdate.maxSurge: Invalid value"),
+		Entry("should deny invalid maxSurge percentage", createArgs{
+			maxSurge: ptr.To(intstr.FromString("ten")),
+		}, false, "spec.strategy.rollingUpdate.maxSurge: Invalid value"),
+		Entry("should deny maxUnavailable greater than 100%", createArgs{
+			maxUnavailable: ptr.To(intstr.FromString("101%")),
+		}, false, "must not be greater than 100%"),
+		Entry("should deny maxSurge and maxUnavailable both zero", createArgs{
+			maxSurge:       ptr.To(intstr.FromString("0%")),
+			maxUnavailable: ptr.To(intstr.FromInt(0)),
+		}, false, "spec.strategy.rollingUpdate.maxUnavailable: Invalid value"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.deployment)
+		Expect(err).ToNot(HaveOccurred())
+
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the template is changed", func() {
+		BeforeEach(func() {
+			ctx.deployment.Spec.Template.Spec.ImageName = "new-image"
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("a rollback is requested", func() {
+		BeforeEach(func() {
+			ctx.deployment.Spec.RollbackTo = &vmopv1.VirtualMachineDeploymentRollbackConfig{Revision: 1}
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the selector is changed", func() {
+		BeforeEach(func() {
+			ctx.deployment.Spec.Selector.MatchLabels["tier"] = "web"
+			ctx.deployment.Spec.Template.Labels["tier"] = "web"
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.selector: Invalid value"))
+			Expect(string(response.Result.Reason)).To(ContainSubstring("field is immutable"))
+		})
+	})
+
+	When("the update is performed while object deletion", func() {
+		BeforeEach(func() {
+			ctx.deployment.Spec.Selector.MatchLabels["tier"] = "web"
+		})
+
+		JustBeforeEach(func() {
+			t := metav1.Now()
+			ctx.WebhookRequestContext.Obj.SetDeletionTimestamp(&t)
+			response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
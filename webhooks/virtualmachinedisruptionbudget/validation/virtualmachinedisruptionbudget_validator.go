@@ -0,0 +1,177 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	minAvailableAndMaxUnavailable = "minAvailable and maxUnavailable are mutually exclusive"
+	minAvailableOrMaxUnavailable  = "one of minAvailable or maxUnavailable must be specified"
+	invalidIntOrPercent           = "must be a non-negative integer or a percentage"
+	percentOver100                = "must not be greater than 100%"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachinedisruptionbudget,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinedisruptionbudgets,versions=v1alpha3,name=default.validating.virtualmachinedisruptionbudget.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create VirtualMachineDisruptionBudget validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineDisruptionBudget{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	budget, err := v.budgetFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return v.validate(ctx, budget)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	budget, err := v.budgetFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return v.validate(ctx, budget)
+}
+
+func (v validator) validate(
+	ctx *pkgctx.WebhookRequestContext,
+	budget *vmopv1.VirtualMachineDisruptionBudget) admission.Response {
+
+	var fieldErrs field.ErrorList
+
+	fieldErrs = append(fieldErrs, v.validateSelector(budget)...)
+	fieldErrs = append(fieldErrs, v.validateMinAvailableMaxUnavailable(budget)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateSelector(budget *vmopv1.VirtualMachineDisruptionBudget) field.ErrorList {
+	var allErrs field.ErrorList
+
+	selectorPath := field.NewPath("spec", "selector")
+
+	if budget.Spec.Selector == nil {
+		return append(allErrs, field.Required(selectorPath, ""))
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(budget.Spec.Selector)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(selectorPath, budget.Spec.Selector, err.Error()))
+	} else if selector.Empty() {
+		allErrs = append(allErrs, field.Invalid(selectorPath, budget.Spec.Selector,
+			"empty selector is invalid for disruption budget"))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateMinAvailableMaxUnavailable(budget *vmopv1.VirtualMachineDisruptionBudget) field.ErrorList {
+	var allErrs field.ErrorList
+
+	specPath := field.NewPath("spec")
+
+	switch {
+	case budget.Spec.MinAvailable != nil && budget.Spec.MaxUnavailable != nil:
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("maxUnavailable"), minAvailableAndMaxUnavailable))
+	case budget.Spec.MinAvailable == nil && budget.Spec.MaxUnavailable == nil:
+		allErrs = append(allErrs, field.Required(specPath.Child("minAvailable"), minAvailableOrMaxUnavailable))
+	}
+
+	allErrs = append(allErrs, validateIntOrPercent(budget.Spec.MinAvailable, specPath.Child("minAvailable"))...)
+	allErrs = append(allErrs, validateIntOrPercent(budget.Spec.MaxUnavailable, specPath.Child("maxUnavailable"))...)
+
+	return allErrs
+}
+
+// validateIntOrPercent validates that the value is a non-negative integer or a
+// percentage no greater than 100%.
+func validateIntOrPercent(v *intstr.IntOrString, fieldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if v == nil {
+		return allErrs
+	}
+
+	switch v.Type {
+	case intstr.Int:
+		if v.IntVal < 0 {
+			allErrs = append(allErrs, field.Invalid(fieldPath, v.IntVal, invalidIntOrPercent))
+		}
+	case intstr.String:
+		percent, err := strconv.Atoi(strings.TrimSuffix(v.StrVal, "%"))
+		switch {
+		case !strings.HasSuffix(v.StrVal, "%") || err != nil || percent < 0:
+			allErrs = append(allErrs, field.Invalid(fieldPath, v.StrVal, invalidIntOrPercent))
+		case percent > 100:
+			allErrs = append(allErrs, field.Invalid(fieldPath, v.StrVal, percentOver100))
+		}
+	}
+
+	return allErrs
+}
+
+// budgetFromUnstructured returns the VirtualMachineDisruptionBudget from the unstructured object.
+func (v validator) budgetFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineDisruptionBudget, error) {
+	b := &vmopv1.VirtualMachineDisruptionBudget{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
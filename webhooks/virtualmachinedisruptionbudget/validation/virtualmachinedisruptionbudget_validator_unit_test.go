@@ -0,0 +1,266 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	budget, oldBudget *vmopv1.VirtualMachineDisruptionBudget
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	budget := builder.DummyVirtualMachineDisruptionBudget()
+	budget.Name = "dummy-budget-for-webhook-validation"
+	budget.Namespace = "dummy-budget-namespace-for-webhook-validation"
+	obj, err := builder.ToUnstructured(budget)
+	Expect(err).ToNot(HaveOccurred())
+
+	var (
+		oldBudget *vmopv1.VirtualMachineDisruptionBudget
+		oldObj    *unstructured.Unstructured
+	)
+
+	if isUpdate {
+		oldBudget = budget.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldBudget)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		budget:                              budget,
+		oldBudget:                           oldBudget,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		noSelector      bool
+		emptySelector   bool
+		invalidSelector bool
+		minAvailable    *intstr.IntOrString
+		maxUnavailable  *intstr.IntOrString
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string) {
+		if args.noSelector {
+			ctx.budget.Spec.Selector = nil
+		}
+		if args.emptySelector {
+			ctx.budget.Spec.Selector = &metav1.LabelSelector{}
+		}
+		if args.invalidSelector {
+			ctx.budget.Spec.Selector.MatchLabels["/invalid-key"] = "foo"
+		}
+		ctx.budget.Spec.MinAvailable = args.minAvailable
+		ctx.budget.Spec.MaxUnavailable = args.maxUnavailable
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.budget)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow minAvailable", createArgs{
+			minAvailable: ptr.To(intstr.FromInt(1)),
+		}, true, ""),
+		Entry("should allow minAvailable percentage", createArgs{
+			minAvailable: ptr.To(intstr.FromString("50%")),
+		}, true, ""),
+		Entry("should allow maxUnavailable", createArgs{
+			maxUnavailable: ptr.To(intstr.FromInt(0)),
+		}, true, ""),
+		Entry("should allow maxUnavailable percentage", createArgs{
+			maxUnavailable: ptr.To(intstr.FromString("100%")),
+		}, true, ""),
+		Entry("should deny missing selector", createArgs{
+			noSelector:   true,
+			minAvailable: ptr.To(intstr.FromInt(1)),
+		}, false, "spec.selector: Required value"),
+		Entry("should deny empty selector", createArgs{
+			emptySelector: true,
+			minAvailable:  ptr.To(intstr.FromInt(1)),
+		}, false, "spec.selector: Invalid value"),
+		Entry("should deny invalid selector", createArgs{
+			invalidSelector: true,
+			minAvailable:    ptr.To(intstr.FromInt(1)),
+		}, false, "spec.selector: Invalid value"),
+		Entry("should deny neither minAvailable nor maxUnavailable", createArgs{},
+			false, "spec.minAvailable: Required value: one of minAvailable or maxUnavailable must be specified"),
+		Entry("should deny both minAvailable and maxUnavailable", createArgs{
+			minAvailable:   ptr.To(intstr.FromInt(1)),
+			maxUnavailable: ptr.To(intstr.FromInt(1)),
+		}, false, "spec.maxUnavailable: Forbidden: minAvailable and maxUnavailable are mutually exclusive"),
+		Entry("should deny negative minAvailable", createArgs{
+			minAvailable: ptr.To(intstr.FromInt(-1)),
+		}, false, "spec.minAvailable: Invalid value"),
+		Entry("should deny invalid maxUnavailable percentage", createArgs{
+			maxUnavailable: ptr.To(intstr.FromString("ten")),
+		}, false, "spec.maxUnavailable: Invalid value"),
+		Entry("should deny minAvailable greater than 100%", createArgs{
+			minAvailable: ptr.To(intstr.FromString("101%")),
+		}, false, "must not be greater than 100%"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.budget)
+		Expect(err).ToNot(HaveOccurred())
+
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("minAvailable is changed", func() {
+		BeforeEach(func() {
+			ctx.budget.Spec.MinAvailable = ptr.To(intstr.FromString("25%"))
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the selector is changed", func() {
+		BeforeEach(func() {
+			ctx.budget.Spec.Selector.MatchLabels["tier"] = "web"
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("maxUnavailable is also specified", func() {
+		BeforeEach(func() {
+			ctx.budget.Spec.MaxUnavailable = ptr.To(intstr.FromInt(1))
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.maxUnavailable: Forbidden"))
+		})
+	})
+
+	When("the update is performed while object deletion", func() {
+		BeforeEach(func() {
+			ctx.budget.Spec.MinAvailable = nil
+		})
+
+		JustBeforeEach(func() {
+			t := metav1.Now()
+			ctx.WebhookRequestContext.Obj.SetDeletionTimestamp(&t)
+			response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	pvcAndDownload  = "persistentVolumeClaim and download are mutually exclusive"
+	pvcOrDownload   = "one of persistentVolumeClaim or download must be specified"
+	pathNotRelative = "must be a relative path that does not contain '..'"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachineexport,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineexports,versions=v1alpha3,name=default.validating.virtualmachineexport.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create VirtualMachineExport validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineExport{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	vmExport, err := v.vmExportFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+
+	fieldErrs = append(fieldErrs, v.validateSource(vmExport)...)
+	fieldErrs = append(fieldErrs, v.validateTarget(vmExport)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	vmExport, err := v.vmExportFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	oldVMExport, err := v.vmExportFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+
+	// Check if an immutable field has been modified.
+	fieldErrs = append(fieldErrs, v.validateImmutableFields(vmExport, oldVMExport)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateSource(vmExport *vmopv1.VirtualMachineExport) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if vmExport.Spec.Source.Name == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "source", "name"), ""))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateTarget(vmExport *vmopv1.VirtualMachineExport) field.ErrorList {
+	var allErrs field.ErrorList
+
+	target := vmExport.Spec.Target
+	targetPath := field.NewPath("spec").Child("target")
+
+	switch {
+	case target.PersistentVolumeClaim != nil && target.Download != nil:
+		allErrs = append(allErrs, field.Forbidden(targetPath, pvcAndDownload))
+	case target.PersistentVolumeClaim != nil:
+		pvcPath := targetPath.Child("persistentVolumeClaim")
+		if target.PersistentVolumeClaim.ClaimName == "" {
+			allErrs = append(allErrs, field.Required(pvcPath.Child("claimName"), ""))
+		}
+		if p := target.PersistentVolumeClaim.Path; p != "" {
+			if c := path.Clean(p); path.IsAbs(c) || c == ".." || strings.HasPrefix(c, "../") {
+				allErrs = append(allErrs, field.Invalid(pvcPath.Child("path"), p, pathNotRelative))
+			}
+		}
+	case target.Download != nil:
+	default:
+		allErrs = append(allErrs, field.Required(targetPath, pvcOrDownload))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateImmutableFields(vmExport, oldVMExport *vmopv1.VirtualMachineExport) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	// All updates to source and target are not allowed. Otherwise, the files
+	// exported by the export may not match its spec.
+	allErrs = append(allErrs, validation.ValidateImmutableField(vmExport.Spec.Source, oldVMExport.Spec.Source, specPath.Child("source"))...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(vmExport.Spec.Target, oldVMExport.Spec.Target, specPath.Child("target"))...)
+
+	return allErrs
+}
+
+// vmExportFromUnstructured returns the VirtualMachineExport from the
+// unstructured object.
+func (v validator) vmExportFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineExport, error) {
+	vmExport := &vmopv1.VirtualMachineExport{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), vmExport); err != nil {
+		return nil, err
+	}
+	return vmExport, nil
+}
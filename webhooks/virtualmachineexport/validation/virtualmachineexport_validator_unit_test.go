@@ -0,0 +1,207 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	vmExport, oldVMExport *vmopv1.VirtualMachineExport
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	vmExport := builder.DummyVirtualMachineExport(
+		"dummy-export-for-webhook-validation",
+		"dummy-export-namespace-for-webhook-validation",
+		"dummy-vm")
+	obj, err := builder.ToUnstructured(vmExport)
+	Expect(err).ToNot(HaveOccurred())
+
+	var (
+		oldVMExport *vmopv1.VirtualMachineExport
+		oldObj      *unstructured.Unstructured
+	)
+
+	if isUpdate {
+		oldVMExport = vmExport.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldVMExport)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		vmExport:                            vmExport,
+		oldVMExport:                         oldVMExport,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		noSourceName bool
+		noDownload   bool
+		pvc          bool
+		pvcClaimName string
+		pvcPath      string
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string) {
+		if args.noSourceName {
+			ctx.vmExport.Spec.Source.Name = ""
+		}
+		if args.noDownload {
+			ctx.vmExport.Spec.Target.Download = nil
+		}
+		if args.pvc {
+			ctx.vmExport.Spec.Target.PersistentVolumeClaim = &vmopv1.VirtualMachineExportTargetPersistentVolumeClaim{
+				ClaimName: args.pvcClaimName,
+				Path:      args.pvcPath,
+			}
+		}
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmExport)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow valid download", createArgs{}, true, ""),
+		Entry("should allow valid PVC",
+			createArgs{noDownload: true, pvc: true, pvcClaimName: "my-pvc", pvcPath: "exports/my-vm"}, true, ""),
+		Entry("should deny missing source name", createArgs{noSourceName: true},
+			false, "spec.source.name: Required value"),
+		Entry("should deny PVC and download",
+			createArgs{pvc: true, pvcClaimName: "my-pvc"},
+			false, "spec.target: Forbidden: persistentVolumeClaim and download are mutually exclusive"),
+		Entry("should deny no target", createArgs{noDownload: true},
+			false, "spec.target: Required value: one of persistentVolumeClaim or download must be specified"),
+		Entry("should deny PVC with absolute path",
+			createArgs{noDownload: true, pvc: true, pvcClaimName: "my-pvc", pvcPath: "/exports"},
+			false, "spec.target.persistentVolumeClaim.path: Invalid value"),
+		Entry("should deny PVC with path outside of the volume",
+			createArgs{noDownload: true, pvc: true, pvcClaimName: "my-pvc", pvcPath: "exports/../.."},
+			false, "spec.target.persistentVolumeClaim.path: Invalid value"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmExport)
+		Expect(err).ToNot(HaveOccurred())
+
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the source is changed", func() {
+		BeforeEach(func() {
+			ctx.vmExport.Spec.Source.Name = "other-vm"
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.source: Invalid value"))
+		})
+	})
+
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	commandOrCopyFileRequired = "exactly one of command or copyFile must be specified"
+	fromOrValueRequired       = "exactly one of from or value must be specified"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachineguestoperation,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineguestoperations,versions=v1alpha3,name=default.validating.virtualmachineguestoperation.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineguestoperations,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineguestoperations/status,verbs=get
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create virtualmachineguestoperation validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineGuestOperation{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	op, err := v.guestOperationFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateSpec(op)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	op, err := v.guestOperationFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	oldOp, err := v.guestOperationFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	// The operation is run at most once, so its spec cannot be changed.
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, validation.ValidateImmutableField(op.Spec, oldOp.Spec, field.NewPath("spec"))...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateSpec(op *vmopv1.VirtualMachineGuestOperation) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if op.Spec.VirtualMachineName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("virtualMachineName"), ""))
+	}
+	if op.Spec.CredentialsSecretName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("credentialsSecretName"), ""))
+	}
+
+	switch {
+	case op.Spec.Command == nil && op.Spec.CopyFile == nil:
+		allErrs = append(allErrs, field.Required(specPath, commandOrCopyFileRequired))
+	case op.Spec.Command != nil && op.Spec.CopyFile != nil:
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("copyFile"), commandOrCopyFileRequired))
+	case op.Spec.Command != nil:
+		if op.Spec.Command.Path == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("command", "path"), ""))
+		}
+	case op.Spec.CopyFile != nil:
+		allErrs = append(allErrs, v.validateCopyFile(specPath.Child("copyFile"), op.Spec.CopyFile)...)
+	}
+
+	return allErrs
+}
+
+func (v validator) validateCopyFile(
+	path *field.Path,
+	copyFile *vmopv1.VirtualMachineGuestOperationCopyFile) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if copyFile.Path == "" {
+		allErrs = append(allErrs, field.Required(path.Child("path"), ""))
+	}
+
+	contentPath := path.Child("content")
+	content := copyFile.Content
+	switch {
+	case content.From == nil && content.Value == nil:
+		allErrs = append(allErrs, field.Required(contentPath, fromOrValueRequired))
+	case content.From != nil && content.Value != nil:
+		allErrs = append(allErrs, field.Forbidden(contentPath.Child("value"), fromOrValueRequired))
+	case content.From != nil:
+		if content.From.Name == "" {
+			allErrs = append(allErrs, field.Required(contentPath.Child("from", "name"), ""))
+		}
+		if content.From.Key == "" {
+			allErrs = append(allErrs, field.Required(contentPath.Child("from", "key"), ""))
+		}
+	}
+
+	return allErrs
+}
+
+// guestOperationFromUnstructured returns the operation from the unstructured
+// object.
+func (v validator) guestOperationFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineGuestOperation, error) {
+	op := &vmopv1.VirtualMachineGuestOperation{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), op); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	op, oldOp *vmopv1.VirtualMachineGuestOperation
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	op := builder.DummyVirtualMachineGuestOperation(
+		"dummy-op-for-webhook-validation",
+		"dummy-op-namespace-for-webhook-validation",
+		"dummy-vm")
+	obj, err := builder.ToUnstructured(op)
+	Expect(err).ToNot(HaveOccurred())
+
+	var (
+		oldOp  *vmopv1.VirtualMachineGuestOperation
+		oldObj *unstructured.Unstructured
+	)
+
+	if isUpdate {
+		oldOp = op.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldOp)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		op:                                  op,
+		oldOp:                               oldOp,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		noCommand         bool
+		emptyCommandPath  bool
+		copyFile          bool
+		emptyCopyFilePath bool
+		copyFileNoContent bool
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string) {
+		if args.noCommand {
+			ctx.op.Spec.Command = nil
+		}
+		if args.emptyCommandPath {
+			ctx.op.Spec.Command.Path = ""
+		}
+		if args.copyFile {
+			ctx.op.Spec.CopyFile = &vmopv1.VirtualMachineGuestOperationCopyFile{
+				Path: "/tmp/hello.txt",
+				Content: vmopv1common.ValueOrSecretKeySelector{
+					Value: ptr.To("hello"),
+				},
+			}
+		}
+		if args.emptyCopyFilePath {
+			ctx.op.Spec.CopyFile.Path = ""
+		}
+		if args.copyFileNoContent {
+			ctx.op.Spec.CopyFile.Content.Value = nil
+		}
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.op)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow valid command", createArgs{}, true, ""),
+		Entry("should allow valid copy file", createArgs{noCommand: true, copyFile: true}, true, ""),
+		Entry("should deny neither command nor copy file", createArgs{noCommand: true},
+			false, "spec: Required value: exactly one of command or copyFile must be specified"),
+		Entry("should deny both command and copy file", createArgs{copyFile: true},
+			false, "spec.copyFile: Forbidden: exactly one of command or copyFile must be specified"),
+		Entry("should deny empty command path", createArgs{emptyCommandPath: true},
+			false, "spec.command.path: Required value"),
+		Entry("should deny empty copy file path", createArgs{noCommand: true, copyFile: true, emptyCopyFilePath: true},
+			false, "spec.copyFile.path: Required value"),
+		Entry("should deny copy file without content", createArgs{noCommand: true, copyFile: true, copyFileNoContent: true},
+			false, "spec.copyFile.content: Required value: exactly one of from or value must be specified"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.op)
+		Expect(err).ToNot(HaveOccurred())
+		ctx.WebhookRequestContext.OldObj, err = builder.ToUnstructured(ctx.oldOp)
+		Expect(err).ToNot(HaveOccurred())
+
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the command is changed", func() {
+		BeforeEach(func() {
+			ctx.op.Spec.Command.Args = []string{"goodbye"}
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec: Invalid value"))
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
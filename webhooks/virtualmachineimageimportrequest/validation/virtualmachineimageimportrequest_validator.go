@@ -0,0 +1,194 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	urlAndPersistentVolumeClaim = "url and persistentVolumeClaim are mutually exclusive"
+	urlOrPersistentVolumeClaim  = "one of url or persistentVolumeClaim must be specified"
+	pathNotRelative             = "must be a relative path that does not contain '..'"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachineimageimportrequest,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineimageimportrequests,versions=v1alpha3,name=default.validating.virtualmachineimageimportrequest.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create VirtualMachineImageImportRequest validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineImageImportRequest{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	vmImport, err := v.vmImportFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+
+	fieldErrs = append(fieldErrs, v.validateSource(vmImport)...)
+	fieldErrs = append(fieldErrs, v.validateTargetLocation(vmImport)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	vmImport, err := v.vmImportFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	oldVMImport, err := v.vmImportFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+
+	// Check if an immutable field has been modified.
+	fieldErrs = append(fieldErrs, v.validateImmutableFields(vmImport, oldVMImport)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateSource(vmImport *vmopv1.VirtualMachineImageImportRequest) field.ErrorList {
+	var allErrs field.ErrorList
+
+	source := vmImport.Spec.Source
+	sourcePath := field.NewPath("spec").Child("source")
+
+	switch {
+	case source.URL != "" && source.PersistentVolumeClaim != nil:
+		allErrs = append(allErrs, field.Forbidden(sourcePath, urlAndPersistentVolumeClaim))
+	case source.URL != "":
+		urlPath := sourcePath.Child("url")
+		u, err := url.Parse(source.URL)
+		switch {
+		case err != nil:
+			allErrs = append(allErrs, field.Invalid(urlPath, source.URL, err.Error()))
+		case u.Scheme != "http" && u.Scheme != "https":
+			allErrs = append(allErrs, field.NotSupported(urlPath.Child("scheme"), u.Scheme, []string{"http", "https"}))
+		case u.Host == "":
+			allErrs = append(allErrs, field.Invalid(urlPath, source.URL, "must specify a host"))
+		}
+	case source.PersistentVolumeClaim != nil:
+		pvcPath := sourcePath.Child("persistentVolumeClaim")
+		if source.PersistentVolumeClaim.ClaimName == "" {
+			allErrs = append(allErrs, field.Required(pvcPath.Child("claimName"), ""))
+		}
+		if p := source.PersistentVolumeClaim.Path; p == "" {
+			allErrs = append(allErrs, field.Required(pvcPath.Child("path"), ""))
+		} else if c := path.Clean(p); path.IsAbs(c) || c == ".." || strings.HasPrefix(c, "../") {
+			allErrs = append(allErrs, field.Invalid(pvcPath.Child("path"), p, pathNotRelative))
+		}
+	default:
+		allErrs = append(allErrs, field.Required(sourcePath, urlOrPersistentVolumeClaim))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateTargetLocation(vmImport *vmopv1.VirtualMachineImageImportRequest) field.ErrorList {
+	var allErrs field.ErrorList
+
+	location := vmImport.Spec.Target.Location
+	targetLocationPath := field.NewPath("spec").Child("target").Child("location")
+
+	if location.Name == "" {
+		allErrs = append(allErrs, field.Required(targetLocationPath.Child("name"), ""))
+	}
+
+	if location.APIVersion != imgregv1a1.GroupVersion.String() {
+		allErrs = append(allErrs, field.NotSupported(targetLocationPath.Child("apiVersion"),
+			location.APIVersion, []string{imgregv1a1.GroupVersion.String(), ""}))
+	}
+
+	if location.Kind != reflect.TypeOf(imgregv1a1.ContentLibrary{}).Name() {
+		allErrs = append(allErrs, field.NotSupported(targetLocationPath.Child("kind"),
+			location.Kind, []string{reflect.TypeOf(imgregv1a1.ContentLibrary{}).Name(), ""}))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateImmutableFields(vmImport, oldVMImport *vmopv1.VirtualMachineImageImportRequest) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	// All updates to source and target are not allowed. Otherwise, the image
+	// imported by the request may not match its spec.
+	allErrs = append(allErrs, validation.ValidateImmutableField(vmImport.Spec.Source, oldVMImport.Spec.Source, specPath.Child("source"))...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(vmImport.Spec.Target, oldVMImport.Spec.Target, specPath.Child("target"))...)
+
+	return allErrs
+}
+
+// vmImportFromUnstructured returns the VirtualMachineImageImportRequest from
+// the unstructured object.
+func (v validator) vmImportFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineImageImportRequest, error) {
+	vmImport := &vmopv1.VirtualMachineImageImportRequest{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), vmImport); err != nil {
+		return nil, err
+	}
+	return vmImport, nil
+}
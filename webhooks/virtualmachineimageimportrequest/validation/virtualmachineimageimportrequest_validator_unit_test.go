@@ -0,0 +1,205 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	vmImport, oldVMImport *vmopv1.VirtualMachineImageImportRequest
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	vmImport := builder.DummyVirtualMachineImageImportRequest(
+		"dummy-import-for-webhook-validation",
+		"dummy-import-namespace-for-webhook-validation",
+		"https://example.com/images/dummy.ova",
+		"dummy-cl")
+	obj, err := builder.ToUnstructured(vmImport)
+	Expect(err).ToNot(HaveOccurred())
+
+	var (
+		oldVMImport *vmopv1.VirtualMachineImageImportRequest
+		oldObj      *unstructured.Unstructured
+	)
+
+	if isUpdate {
+		oldVMImport = vmImport.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldVMImport)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		vmImport:                            vmImport,
+		oldVMImport:                         oldVMImport,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		url          *string
+		pvcClaimName string
+		pvcPath      string
+		pvc          bool
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string) {
+		if args.url != nil {
+			ctx.vmImport.Spec.Source.URL = *args.url
+		}
+		if args.pvc {
+			ctx.vmImport.Spec.Source.PersistentVolumeClaim = &vmopv1.VirtualMachineImageImportRequestSourcePersistentVolumeClaim{
+				ClaimName: args.pvcClaimName,
+				Path:      args.pvcPath,
+			}
+		}
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmImport)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow valid URL", createArgs{}, true, ""),
+		Entry("should allow valid PVC",
+			createArgs{url: ptr.To(""), pvc: true, pvcClaimName: "my-pvc", pvcPath: "images/my-image.ova"}, true, ""),
+		Entry("should deny URL with unsupported scheme", createArgs{url: ptr.To("ftp://example.com/my-image.ova")},
+			false, `spec.source.url.scheme: Unsupported value: "ftp"`),
+		Entry("should deny URL and PVC",
+			createArgs{pvc: true, pvcClaimName: "my-pvc", pvcPath: "my-image.ova"},
+			false, "spec.source: Forbidden: url and persistentVolumeClaim are mutually exclusive"),
+		Entry("should deny no source", createArgs{url: ptr.To("")},
+			false, "spec.source: Required value: one of url or persistentVolumeClaim must be specified"),
+		Entry("should deny PVC with absolute path",
+			createArgs{url: ptr.To(""), pvc: true, pvcClaimName: "my-pvc", pvcPath: "/my-image.ova"},
+			false, "spec.source.persistentVolumeClaim.path: Invalid value"),
+		Entry("should deny PVC with path outside of the volume",
+			createArgs{url: ptr.To(""), pvc: true, pvcClaimName: "my-pvc", pvcPath: "images/../../my-image.ova"},
+			false, "spec.source.persistentVolumeClaim.path: Invalid value"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmImport)
+		Expect(err).ToNot(HaveOccurred())
+
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the source is changed", func() {
+		BeforeEach(func() {
+			ctx.vmImport.Spec.Source.URL = "https://example.com/images/other.ova"
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.source: Invalid value"))
+		})
+	})
+
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
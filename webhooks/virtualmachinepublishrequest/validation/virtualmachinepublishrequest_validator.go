@@ -1,4 +1,4 @@
-// Copyright (c) 2022-2023 VMware, Inc. All Rights Reserved.
+// Copyright (c) 2022-2024 VMware, Inc. All Rights Reserved.
 // SPDX-License-Identifier: Apache-2.0
 
 package validation
@@ -23,12 +23,17 @@ import (
 	vmopv1a2 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
 	"github.com/vmware-tanzu/vm-operator/webhooks/common"
 )
 
 const (
 	webHookName = "default"
+
+	featureNotEnabled      = "the %s feature is not enabled"
+	locationAndOCIRegistry = "location.name and ociRegistry are mutually exclusive"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachinepublishrequest,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinepublishrequests,versions=v1alpha3,name=default.validating.virtualmachinepublishrequest.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
@@ -73,7 +78,11 @@ func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.R
 	var fieldErrs field.ErrorList
 
 	fieldErrs = append(fieldErrs, v.validateSource(ctx, vmpub)...)
-	fieldErrs = append(fieldErrs, v.validateTargetLocation(ctx, vmpub)...)
+	if vmpub.Spec.Target.OCIRegistry != nil {
+		fieldErrs = append(fieldErrs, v.validateTargetOCIRegistry(ctx, vmpub)...)
+	} else {
+		fieldErrs = append(fieldErrs, v.validateTargetLocation(ctx, vmpub)...)
+	}
 
 	validationErrs := make([]string, 0, len(fieldErrs))
 	for _, fieldErr := range fieldErrs {
@@ -157,6 +166,37 @@ func (v validator) validateTargetLocation(ctx *pkgctx.WebhookRequestContext, vmp
 	return allErrs
 }
 
+func (v validator) validateTargetOCIRegistry(ctx *pkgctx.WebhookRequestContext, vmpub *vmopv1.VirtualMachinePublishRequest) field.ErrorList {
+	var allErrs field.ErrorList
+
+	targetPath := field.NewPath("spec").Child("target")
+	ociRegistryPath := targetPath.Child("ociRegistry")
+
+	if !pkgcfg.FromContext(ctx).Features.VMPublishOCI {
+		return append(allErrs, field.Forbidden(ociRegistryPath, fmt.Sprintf(featureNotEnabled, "VM Publish to OCI registry")))
+	}
+
+	if vmpub.Spec.Target.Location.Name != "" {
+		allErrs = append(allErrs, field.Forbidden(targetPath.Child("location", "name"), locationAndOCIRegistry))
+	}
+
+	ociRegistry := vmpub.Spec.Target.OCIRegistry
+	repositoryPath := ociRegistryPath.Child("repository")
+	if ociRegistry.Repository == "" {
+		allErrs = append(allErrs, field.Required(repositoryPath, ""))
+	} else if _, err := oci.ParseRepository(ociRegistry.Repository); err != nil {
+		allErrs = append(allErrs, field.Invalid(repositoryPath, ociRegistry.Repository, err.Error()))
+	}
+
+	if ociRegistry.Tag != "" {
+		if err := oci.ValidateTag(ociRegistry.Tag); err != nil {
+			allErrs = append(allErrs, field.Invalid(ociRegistryPath.Child("tag"), ociRegistry.Tag, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
 func (v validator) validateImmutableFields(vmpub, oldvmpub *vmopv1.VirtualMachinePublishRequest) field.ErrorList {
 	var allErrs field.ErrorList
 	specPath := field.NewPath("spec")
@@ -1,4 +1,4 @@
-// Copyright (c) 2022-2023 VMware, Inc. All Rights Reserved.
+// Copyright (c) 2022-2024 VMware, Inc. All Rights Reserved.
 // SPDX-License-Identifier: Apache-2.0
 
 package validation_test
@@ -15,6 +15,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	"github.com/vmware-tanzu/vm-operator/controllers/contentlibrary/utils"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
@@ -108,6 +109,11 @@ func unitTestsValidateCreate() {
 		targetLocationNameEmpty         bool
 		targetLocationNotFound          bool
 		targetItemAlreadyExists         bool
+		ociRegistry                     bool
+		ociRegistryFSSDisabled          bool
+		ociRegistryWithLocationName     bool
+		ociRegistryRepository           string
+		ociRegistryTag                  string
 	}
 
 	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
@@ -155,6 +161,25 @@ func unitTestsValidateCreate() {
 			Expect(ctx.Client.Status().Update(ctx, clItem)).To(Succeed())
 		}
 
+		if args.ociRegistry {
+			pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+				config.Features.VMPublishOCI = !args.ociRegistryFSSDisabled
+			})
+			if !args.ociRegistryWithLocationName {
+				ctx.vmPub.Spec.Target.Location.Name = ""
+			}
+			ctx.vmPub.Spec.Target.OCIRegistry = &vmopv1.VirtualMachinePublishRequestTargetOCIRegistry{
+				Repository: "registry.example.com/images/dummy-vm",
+				Tag:        "v1",
+			}
+			if args.ociRegistryRepository != "" {
+				ctx.vmPub.Spec.Target.OCIRegistry.Repository = args.ociRegistryRepository
+			}
+			if args.ociRegistryTag != "" {
+				ctx.vmPub.Spec.Target.OCIRegistry.Tag = args.ociRegistryTag
+			}
+		}
+
 		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmPub)
 		Expect(err).ToNot(HaveOccurred())
 
@@ -178,6 +203,7 @@ func unitTestsValidateCreate() {
 
 	sourcePath := field.NewPath("spec").Child("source")
 	targetLocationPath := field.NewPath("spec").Child("target", "location")
+	ociRegistryPath := field.NewPath("spec").Child("target", "ociRegistry")
 	DescribeTable("create table", validateCreate,
 		Entry("should allow valid", createArgs{}, true, nil, nil),
 		Entry("should deny invalid source API version", createArgs{invalidSourceAPIVersion: true}, false,
@@ -194,6 +220,19 @@ func unitTestsValidateCreate() {
 				[]string{"ContentLibrary", ""}).Error(), nil),
 		Entry("should deny if target location name is empty", createArgs{targetLocationNameEmpty: true}, false,
 			field.Required(targetLocationPath.Child("name"), "").Error(), nil),
+		Entry("should allow valid OCI registry target", createArgs{ociRegistry: true}, true, nil, nil),
+		Entry("should deny OCI registry target when feature is disabled", createArgs{ociRegistry: true, ociRegistryFSSDisabled: true}, false,
+			field.Forbidden(ociRegistryPath, "the VM Publish to OCI registry feature is not enabled").Error(), nil),
+		Entry("should deny OCI registry target with target location name", createArgs{ociRegistry: true, ociRegistryWithLocationName: true}, false,
+			field.Forbidden(targetLocationPath.Child("name"), "location.name and ociRegistry are mutually exclusive").Error(), nil),
+		Entry("should deny OCI registry repository with a tag", createArgs{ociRegistry: true, ociRegistryRepository: "registry.example.com/dummy-vm:v1"}, false,
+			field.Invalid(ociRegistryPath.Child("repository"), "registry.example.com/dummy-vm:v1", "repository must not include a tag").Error(), nil),
+		Entry("should deny OCI registry repository with a digest", createArgs{ociRegistry: true, ociRegistryRepository: "registry.example.com/dummy-vm@sha256:abcd"}, false,
+			field.Invalid(ociRegistryPath.Child("repository"), "registry.example.com/dummy-vm@sha256:abcd", "repository must not include a digest").Error(), nil),
+		Entry("should deny OCI registry repository without a host", createArgs{ociRegistry: true, ociRegistryRepository: "dummy-vm"}, false,
+			field.Invalid(ociRegistryPath.Child("repository"), "dummy-vm", "repository must be of the form host/name").Error(), nil),
+		Entry("should deny invalid OCI registry tag", createArgs{ociRegistry: true, ociRegistryTag: "-v1"}, false,
+			field.Invalid(ociRegistryPath.Child("tag"), "-v1", `invalid tag "-v1"`).Error(), nil),
 	)
 }
 
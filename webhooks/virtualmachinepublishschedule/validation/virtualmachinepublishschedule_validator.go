@@ -0,0 +1,246 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
+
+	vmopv1a1 "github.com/vmware-tanzu/vm-operator/api/v1alpha1"
+	vmopv1a2 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/cron"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	featureNotEnabled      = "the %s feature is not enabled"
+	locationAndOCIRegistry = "location.name and ociRegistry are mutually exclusive"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachinepublishschedule,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinepublishschedules,versions=v1alpha3,name=default.validating.virtualmachinepublishschedule.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create VirtualMachinePublishSchedule validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachinePublishSchedule{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	vmPubSched, err := v.vmPublishScheduleFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return v.validate(ctx, vmPubSched)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	vmPubSched, err := v.vmPublishScheduleFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	// Unlike a VirtualMachinePublishRequest, the spec of a schedule may be
+	// changed since it only affects the publications that have not yet been
+	// created.
+	return v.validate(ctx, vmPubSched)
+}
+
+func (v validator) validate(
+	ctx *pkgctx.WebhookRequestContext,
+	vmPubSched *vmopv1.VirtualMachinePublishSchedule) admission.Response {
+
+	var fieldErrs field.ErrorList
+
+	fieldErrs = append(fieldErrs, v.validateSchedule(vmPubSched)...)
+	fieldErrs = append(fieldErrs, v.validateSource(vmPubSched)...)
+	if vmPubSched.Spec.Target.OCIRegistry != nil {
+		fieldErrs = append(fieldErrs, v.validateTargetOCIRegistry(ctx, vmPubSched)...)
+	} else {
+		fieldErrs = append(fieldErrs, v.validateTargetLocation(vmPubSched)...)
+	}
+	fieldErrs = append(fieldErrs, v.validateTargetTemplates(vmPubSched)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateSchedule(vmPubSched *vmopv1.VirtualMachinePublishSchedule) field.ErrorList {
+	var allErrs field.ErrorList
+
+	schedulePath := field.NewPath("spec", "schedule")
+	if vmPubSched.Spec.Schedule == "" {
+		allErrs = append(allErrs, field.Required(schedulePath, ""))
+	} else if _, err := cron.Parse(vmPubSched.Spec.Schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(schedulePath, vmPubSched.Spec.Schedule, err.Error()))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateSource(vmPubSched *vmopv1.VirtualMachinePublishSchedule) field.ErrorList {
+	var allErrs field.ErrorList
+
+	sourcePath := field.NewPath("spec").Child("source")
+	if apiVersion := vmPubSched.Spec.Source.APIVersion; apiVersion != "" {
+		v1a1GV, v1a2GV, vmopv1 := vmopv1a1.GroupVersion.String(), vmopv1a2.GroupVersion.String(), vmopv1.GroupVersion.String()
+		switch apiVersion {
+		case v1a1GV, v1a2GV, vmopv1:
+		default:
+			allErrs = append(allErrs, field.NotSupported(sourcePath.Child("apiVersion"),
+				apiVersion, []string{v1a1GV, v1a2GV, vmopv1, ""}))
+		}
+	}
+
+	if kind := vmPubSched.Spec.Source.Kind; kind != reflect.TypeOf(vmopv1.VirtualMachine{}).Name() && kind != "" {
+		allErrs = append(allErrs, field.NotSupported(sourcePath.Child("kind"),
+			kind, []string{reflect.TypeOf(vmopv1.VirtualMachine{}).Name(), ""}))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateTargetLocation(vmPubSched *vmopv1.VirtualMachinePublishSchedule) field.ErrorList {
+	var allErrs field.ErrorList
+
+	location := vmPubSched.Spec.Target.Location
+	targetLocationPath := field.NewPath("spec").Child("target").Child("location")
+
+	if location.Name == "" {
+		allErrs = append(allErrs, field.Required(targetLocationPath.Child("name"), ""))
+	}
+
+	if location.APIVersion != imgregv1a1.GroupVersion.String() {
+		allErrs = append(allErrs, field.NotSupported(targetLocationPath.Child("apiVersion"),
+			location.APIVersion, []string{imgregv1a1.GroupVersion.String(), ""}))
+	}
+
+	if location.Kind != reflect.TypeOf(imgregv1a1.ContentLibrary{}).Name() {
+		allErrs = append(allErrs, field.NotSupported(targetLocationPath.Child("kind"),
+			location.Kind, []string{reflect.TypeOf(imgregv1a1.ContentLibrary{}).Name(), ""}))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateTargetOCIRegistry(
+	ctx *pkgctx.WebhookRequestContext,
+	vmPubSched *vmopv1.VirtualMachinePublishSchedule) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	targetPath := field.NewPath("spec").Child("target")
+	ociRegistryPath := targetPath.Child("ociRegistry")
+
+	if !pkgcfg.FromContext(ctx).Features.VMPublishOCI {
+		return append(allErrs, field.Forbidden(ociRegistryPath, fmt.Sprintf(featureNotEnabled, "VM Publish to OCI registry")))
+	}
+
+	if vmPubSched.Spec.Target.Location.Name != "" {
+		allErrs = append(allErrs, field.Forbidden(targetPath.Child("location", "name"), locationAndOCIRegistry))
+	}
+
+	ociRegistry := vmPubSched.Spec.Target.OCIRegistry
+	repositoryPath := ociRegistryPath.Child("repository")
+	if ociRegistry.Repository == "" {
+		allErrs = append(allErrs, field.Required(repositoryPath, ""))
+	} else if _, err := oci.ParseRepository(ociRegistry.Repository); err != nil {
+		allErrs = append(allErrs, field.Invalid(repositoryPath, ociRegistry.Repository, err.Error()))
+	}
+
+	return allErrs
+}
+
+// validateTargetTemplates renders the templates in the target with example
+// data to ensure they are valid and produce valid values.
+func (v validator) validateTargetTemplates(vmPubSched *vmopv1.VirtualMachinePublishSchedule) field.ErrorList {
+	var allErrs field.ErrorList
+
+	targetPath := field.NewPath("spec").Child("target")
+
+	data := vmopv1util.NewPublishScheduleTemplateData(vmPubSched, 1, time.Now())
+
+	// Render the item name separately from the OCI registry tag so an error
+	// is reported for the field with the invalid template.
+	itemOnly := vmPubSched.DeepCopy()
+	itemOnly.Spec.Target.OCIRegistry = nil
+	if _, err := vmopv1util.RenderPublishScheduleTarget(itemOnly, data); err != nil {
+		allErrs = append(allErrs, field.Invalid(targetPath.Child("item", "name"),
+			vmPubSched.Spec.Target.Item.Name, err.Error()))
+		return allErrs
+	}
+
+	ociRegistry := vmPubSched.Spec.Target.OCIRegistry
+	if ociRegistry == nil || ociRegistry.Tag == "" {
+		return allErrs
+	}
+
+	tagPath := targetPath.Child("ociRegistry", "tag")
+	target, err := vmopv1util.RenderPublishScheduleTarget(vmPubSched, data)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(tagPath, ociRegistry.Tag, err.Error()))
+	} else if err := oci.ValidateTag(target.OCIRegistry.Tag); err != nil {
+		allErrs = append(allErrs, field.Invalid(tagPath, ociRegistry.Tag, err.Error()))
+	}
+
+	return allErrs
+}
+
+// vmPublishScheduleFromUnstructured returns the VirtualMachinePublishSchedule
+// from the unstructured object.
+func (v validator) vmPublishScheduleFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachinePublishSchedule, error) {
+	vmPubSched := &vmopv1.VirtualMachinePublishSchedule{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), vmPubSched); err != nil {
+		return nil, err
+	}
+	return vmPubSched, nil
+}
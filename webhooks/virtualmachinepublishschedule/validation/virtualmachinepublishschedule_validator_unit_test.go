@@ -0,0 +1,218 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	vmPubSched, oldVMPubSched *vmopv1.VirtualMachinePublishSchedule
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	vmPubSched := builder.DummyVirtualMachinePublishSchedule(
+		"dummy-sched-for-webhook-validation",
+		"dummy-sched-namespace-for-webhook-validation",
+		"dummy-vm",
+		"dummy-cl")
+	obj, err := builder.ToUnstructured(vmPubSched)
+	Expect(err).ToNot(HaveOccurred())
+
+	var (
+		oldVMPubSched *vmopv1.VirtualMachinePublishSchedule
+		oldObj        *unstructured.Unstructured
+	)
+
+	if isUpdate {
+		oldVMPubSched = vmPubSched.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldVMPubSched)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		vmPubSched:                          vmPubSched,
+		oldVMPubSched:                       oldVMPubSched,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		schedule string
+		itemName string
+		ociTag   string
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string) {
+		if args.schedule != "" {
+			ctx.vmPubSched.Spec.Schedule = args.schedule
+		}
+		ctx.vmPubSched.Spec.Target.Item.Name = args.itemName
+		if args.ociTag != "" {
+			ctx.vmPubSched.Spec.Target.Location.Name = ""
+			ctx.vmPubSched.Spec.Target.OCIRegistry = &vmopv1.VirtualMachinePublishRequestTargetOCIRegistry{
+				Repository: "registry.example.com/my-vm",
+				Tag:        args.ociTag,
+			}
+			pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+				config.Features.VMPublishOCI = true
+			})
+		}
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmPubSched)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow valid", createArgs{}, true, ""),
+		Entry("should allow item name template",
+			createArgs{itemName: "golden-{{ .SourceName }}-v{{ .Revision }}"}, true, ""),
+		Entry("should allow OCI registry tag template",
+			createArgs{ociTag: "{{ .Timestamp }}"}, true, ""),
+		Entry("should deny invalid schedule", createArgs{schedule: "0 25 * * *"},
+			false, `spec.schedule: Invalid value: "0 25 * * *": invalid hour`),
+		Entry("should deny invalid item name template", createArgs{itemName: "{{ .SourceName"},
+			false, "spec.target.item.name: Invalid value"),
+		Entry("should deny invalid OCI tag template",
+			createArgs{ociTag: "{{ .Revision"},
+			false, "spec.target.ociRegistry.tag: Invalid value"),
+		Entry("should deny OCI tag template that renders an invalid tag",
+			createArgs{ociTag: "{{ .Time }}"},
+			false, "spec.target.ociRegistry.tag: Invalid value"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmPubSched)
+		Expect(err).ToNot(HaveOccurred())
+
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the schedule is changed", func() {
+		BeforeEach(func() {
+			ctx.vmPubSched.Spec.Schedule = "@daily"
+			ctx.vmPubSched.Spec.Suspend = true
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the schedule is changed to an invalid schedule", func() {
+		BeforeEach(func() {
+			ctx.vmPubSched.Spec.Schedule = "@every 1h"
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.schedule: Invalid value"))
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
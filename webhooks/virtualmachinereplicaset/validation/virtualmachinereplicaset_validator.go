@@ -23,11 +23,14 @@ import (
 
 	"github.com/vmware-tanzu/vm-operator/pkg/builder"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 	"github.com/vmware-tanzu/vm-operator/webhooks/common"
 )
 
 const (
 	webHookName = "default"
+
+	zoneLabelNotAllowedWithTopologySpread = "may not be specified when spec.topologySpread is set"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachinereplicaset,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinereplicasets,versions=v1alpha3,name=default.validating.virtualmachinereplicaset.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
@@ -67,6 +70,7 @@ func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.R
 	var fieldErrs field.ErrorList
 
 	fieldErrs = append(fieldErrs, v.validateLabelSelectorLabelMatch(ctx, rs, nil)...)
+	fieldErrs = append(fieldErrs, v.validateTopologySpread(rs)...)
 
 	validationErrs := make([]string, 0, len(fieldErrs))
 	for _, fieldErr := range fieldErrs {
@@ -88,6 +92,7 @@ func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.R
 
 	var fieldErrs field.ErrorList
 	fieldErrs = append(fieldErrs, v.validateLabelSelectorLabelMatch(ctx, rs, nil)...)
+	fieldErrs = append(fieldErrs, v.validateTopologySpread(rs)...)
 
 	validationErrs := make([]string, 0, len(fieldErrs))
 	for _, fieldErr := range fieldErrs {
@@ -132,6 +137,24 @@ func (v validator) validateLabelSelectorLabelMatch(
 	return allErrs
 }
 
+// validateTopologySpread validates that the template does not pin the replicas
+// to a zone when the replicas are spread across zones by the controller.
+func (v validator) validateTopologySpread(rs *vmopv1.VirtualMachineReplicaSet) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if rs.Spec.TopologySpread == nil {
+		return allErrs
+	}
+
+	if _, ok := rs.Spec.Template.Labels[topology.KubernetesTopologyZoneLabelKey]; ok {
+		allErrs = append(allErrs, field.Forbidden(
+			field.NewPath("spec", "template", "metadata", "labels").Key(topology.KubernetesTopologyZoneLabelKey),
+			zoneLabelNotAllowedWithTopologySpread))
+	}
+
+	return allErrs
+}
+
 // rsFromUnstructured returns the VirtualMachineClass from the unstructured object.
 func (v validator) rsFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineReplicaSet, error) {
 	rs := &vmopv1.VirtualMachineReplicaSet{}
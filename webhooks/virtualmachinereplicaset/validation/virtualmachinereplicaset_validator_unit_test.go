@@ -17,6 +17,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
@@ -56,6 +57,15 @@ func unitTests() {
 		),
 		unitTestVaildateTemplateObjectMetaAndSelectorMatching,
 	)
+	Describe(
+		"TopologySpread",
+		Label(
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateTopologySpread,
+	)
 }
 
 type unitValidatingWebhookContext struct {
@@ -248,6 +258,69 @@ func unitTestVaildateTemplateObjectMetaAndSelectorMatching() {
 	})
 }
 
+func unitTestsValidateTopologySpread() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	doTest := func(args testParams) {
+		args.setup(ctx)
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.rs)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(args.expectAllowed))
+
+		if args.validate != nil {
+			args.validate(ctx, response)
+		}
+	}
+
+	DescribeTable("topology spread", doTest,
+		Entry("should allow topology spread",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.rs.Spec.TopologySpread = &vmopv1.VirtualMachineReplicaSetTopologySpread{
+						MaxSkew: 1,
+						Zones:   []string{"zone-a", "zone-b"},
+					}
+				},
+				expectAllowed: true,
+			},
+		),
+		Entry("should allow zone label in template without topology spread",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.rs.Spec.Template.Labels[topology.KubernetesTopologyZoneLabelKey] = "zone-a"
+				},
+				expectAllowed: true,
+			},
+		),
+		Entry("should deny zone label in template with topology spread",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.rs.Spec.TopologySpread = &vmopv1.VirtualMachineReplicaSetTopologySpread{}
+					ctx.rs.Spec.Template.Labels[topology.KubernetesTopologyZoneLabelKey] = "zone-a"
+				},
+				validate: func(ctx *unitValidatingWebhookContext, response admission.Response) {
+					Expect(string(response.Result.Reason)).To(ContainSubstring(
+						"spec.template.metadata.labels[topology.kubernetes.io/zone]: Forbidden: may not be specified when spec.topologySpread is set"))
+				},
+				expectAllowed: false,
+			},
+		),
+	)
+}
+
 func unitTestsValidateUpdate() {
 	var (
 		ctx      *unitValidatingWebhookContext
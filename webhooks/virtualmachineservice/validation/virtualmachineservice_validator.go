@@ -12,9 +12,11 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	unversionedvalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -26,6 +28,7 @@ import (
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	"github.com/vmware-tanzu/vm-operator/pkg/builder"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 	"github.com/vmware-tanzu/vm-operator/webhooks/common"
 )
 
@@ -45,11 +48,43 @@ var (
 		string(corev1.ProtocolUDP),
 		string(corev1.ProtocolSCTP),
 	)
+
+	supportedIPFamilies = sets.NewString(
+		string(vmopv1.IPv4Protocol),
+		string(vmopv1.IPv6Protocol),
+	)
+
+	supportedIPFamilyPolicies = sets.NewString(
+		string(vmopv1.IPFamilyPolicySingleStack),
+		string(vmopv1.IPFamilyPolicyPreferDualStack),
+		string(vmopv1.IPFamilyPolicyRequireDualStack),
+	)
+
+	supportedSessionAffinities = sets.NewString(
+		string(vmopv1.ServiceAffinityClientIP),
+		string(vmopv1.ServiceAffinityNone),
+	)
+
+	supportedExternalTrafficPolicies = sets.NewString(
+		string(vmopv1.ServiceExternalTrafficPolicyCluster),
+		string(vmopv1.ServiceExternalTrafficPolicyLocal),
+	)
+
+	// The VirtualMachine endpoints are not on a node, so kube-proxy drops the
+	// ClusterIP traffic of a Service with a Local internal traffic policy.
+	supportedInternalTrafficPolicies = sets.NewString(
+		string(vmopv1.ServiceInternalTrafficPolicyCluster),
+	)
 )
 
+// maxClientIPTimeoutSeconds is the maximum session sticky time, in seconds, for
+// ClientIP session affinity.
+const maxClientIPTimeoutSeconds = 86400
+
 // +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachineservice,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineservices,versions=v1alpha3,name=default.validating.virtualmachineservice.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineservices,verbs=get;list
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineservices/status,verbs=get
+// +kubebuilder:rbac:groups="",resources=services,verbs=get
 
 // AddToManager adds the webhook to the provided manager.
 func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
@@ -63,8 +98,9 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) err
 }
 
 // NewValidator returns the package's Validator.
-func NewValidator(_ client.Client) builder.Validator {
+func NewValidator(client client.Client) builder.Validator {
 	return validator{
+		client:    client,
 		converter: runtime.DefaultUnstructuredConverter,
 	}
 }
@@ -74,6 +110,7 @@ func NewValidator(_ client.Client) builder.Validator {
 // be transformed into a valid Service.
 
 type validator struct {
+	client    client.Client
 	converter runtime.UnstructuredConverter
 }
 
@@ -163,6 +200,9 @@ func (v validator) validateSpec(ctx *pkgctx.WebhookRequestContext, vmService *vm
 	}
 
 	allErrs = append(allErrs, validatePorts(vmService, specPath)...)
+	allErrs = append(allErrs, validateIPFamilies(vmService, specPath)...)
+	allErrs = append(allErrs, validateSessionAffinity(vmService, specPath)...)
+	allErrs = append(allErrs, validateTrafficPolicies(ctx, vmService, specPath)...)
 
 	if vmService.Spec.Selector != nil {
 		allErrs = append(allErrs, unversionedvalidation.ValidateLabels(vmService.Spec.Selector, specPath.Child("selector"))...)
@@ -238,6 +278,116 @@ func validatePorts(vmService *vmopv1.VirtualMachineService, specPath *field.Path
 	return allErrs
 }
 
+func validateIPFamilies(vmService *vmopv1.VirtualMachineService, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	ipFamiliesPath := specPath.Child("ipFamilies")
+	ipFamilyPolicyPath := specPath.Child("ipFamilyPolicy")
+
+	if vmService.Spec.Type == vmopv1.VirtualMachineServiceTypeExternalName {
+		if len(vmService.Spec.IPFamilies) > 0 {
+			allErrs = append(allErrs, field.Forbidden(ipFamiliesPath, "may not be set for ExternalName services"))
+		}
+		if vmService.Spec.IPFamilyPolicy != nil {
+			allErrs = append(allErrs, field.Forbidden(ipFamilyPolicyPath, "may not be set for ExternalName services"))
+		}
+		return allErrs
+	}
+
+	if policy := vmService.Spec.IPFamilyPolicy; policy != nil {
+		if !supportedIPFamilyPolicies.Has(string(*policy)) {
+			allErrs = append(allErrs, field.NotSupported(ipFamilyPolicyPath, *policy, supportedIPFamilyPolicies.List()))
+		} else if *policy == vmopv1.IPFamilyPolicySingleStack && len(vmService.Spec.IPFamilies) > 1 {
+			allErrs = append(allErrs, field.Invalid(ipFamiliesPath, vmService.Spec.IPFamilies,
+				"may contain only one entry when `ipFamilyPolicy` is 'SingleStack'"))
+		}
+	}
+
+	seen := sets.Set[vmopv1.IPFamily]{}
+	for i, family := range vmService.Spec.IPFamilies {
+		if !supportedIPFamilies.Has(string(family)) {
+			allErrs = append(allErrs, field.NotSupported(ipFamiliesPath.Index(i), family, supportedIPFamilies.List()))
+		} else if seen.Has(family) {
+			allErrs = append(allErrs, field.Duplicate(ipFamiliesPath.Index(i), family))
+		}
+		seen.Insert(family)
+	}
+
+	return allErrs
+}
+
+func validateSessionAffinity(vmService *vmopv1.VirtualMachineService, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	sessionAffinityPath := specPath.Child("sessionAffinity")
+	sessionAffinityConfigPath := specPath.Child("sessionAffinityConfig")
+
+	affinity := vmService.Spec.SessionAffinity
+	if affinity != "" && !supportedSessionAffinities.Has(string(affinity)) {
+		allErrs = append(allErrs, field.NotSupported(sessionAffinityPath, affinity, supportedSessionAffinities.List()))
+	}
+
+	if affinity == vmopv1.ServiceAffinityClientIP && vmService.Spec.Type == vmopv1.VirtualMachineServiceTypeExternalName {
+		allErrs = append(allErrs, field.Forbidden(sessionAffinityPath, "may not be 'ClientIP' for ExternalName services"))
+	}
+
+	if cfg := vmService.Spec.SessionAffinityConfig; cfg != nil {
+		if affinity != vmopv1.ServiceAffinityClientIP {
+			allErrs = append(allErrs, field.Forbidden(sessionAffinityConfigPath,
+				"may only be set when `sessionAffinity` is 'ClientIP'"))
+		} else if cfg.ClientIP != nil {
+			if timeout := cfg.ClientIP.TimeoutSeconds; timeout != nil && (*timeout <= 0 || *timeout > maxClientIPTimeoutSeconds) {
+				allErrs = append(allErrs, field.Invalid(sessionAffinityConfigPath.Child("clientIP", "timeoutSeconds"), *timeout,
+					fmt.Sprintf("must be greater than 0 and less than or equal to %d", maxClientIPTimeoutSeconds)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateTrafficPolicies(ctx *pkgctx.WebhookRequestContext, vmService *vmopv1.VirtualMachineService, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	externalTrafficPolicyPath := specPath.Child("externalTrafficPolicy")
+	internalTrafficPolicyPath := specPath.Child("internalTrafficPolicy")
+	healthCheckNodePortPath := specPath.Child("healthCheckNodePort")
+	isLoadBalancer := vmService.Spec.Type == vmopv1.VirtualMachineServiceTypeLoadBalancer
+	isExternalTrafficPolicyLocal := vmopv1util.ExternalTrafficPolicy(vmService) == corev1.ServiceExternalTrafficPolicyTypeLocal
+
+	// The VirtualMachine endpoints are not on a node, so kube-proxy drops the
+	// traffic of a Service with a Local traffic policy. Only allow Local when
+	// the load balancer provider bypasses kube-proxy.
+	if etp := vmService.Spec.ExternalTrafficPolicy; etp != "" {
+		if !isLoadBalancer {
+			allErrs = append(allErrs, field.Forbidden(externalTrafficPolicyPath, "may only be used when `type` is 'LoadBalancer'"))
+		} else if !supportedExternalTrafficPolicies.Has(string(etp)) {
+			allErrs = append(allErrs, field.NotSupported(externalTrafficPolicyPath, etp, supportedExternalTrafficPolicies.List()))
+		} else if etp == vmopv1.ServiceExternalTrafficPolicyLocal && !vmopv1util.BypassesKubeProxy(ctx, vmService) {
+			allErrs = append(allErrs, field.Forbidden(externalTrafficPolicyPath,
+				"may only be 'Local' when the load balancer provider bypasses kube-proxy"))
+		}
+	}
+
+	if itp := vmService.Spec.InternalTrafficPolicy; itp != nil {
+		if vmService.Spec.Type == vmopv1.VirtualMachineServiceTypeExternalName {
+			allErrs = append(allErrs, field.Forbidden(internalTrafficPolicyPath, "may not be set for ExternalName services"))
+		} else if !supportedInternalTrafficPolicies.Has(string(*itp)) {
+			allErrs = append(allErrs, field.NotSupported(internalTrafficPolicyPath, *itp, supportedInternalTrafficPolicies.List()))
+		}
+	}
+
+	if port := vmService.Spec.HealthCheckNodePort; port != 0 {
+		if !isLoadBalancer || !isExternalTrafficPolicyLocal {
+			allErrs = append(allErrs, field.Forbidden(healthCheckNodePortPath,
+				"may only be used when `type` is 'LoadBalancer' and `externalTrafficPolicy` is 'Local'"))
+		} else {
+			for _, msg := range validation.IsValidPortNum(int(port)) {
+				allErrs = append(allErrs, field.Invalid(healthCheckNodePortPath, port, msg))
+			}
+		}
+	}
+
+	return allErrs
+}
+
 func validateServicePort(sp *vmopv1.VirtualMachineServicePort, requireName bool, allNames *sets.Set[string], fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -262,8 +412,14 @@ func validateServicePort(sp *vmopv1.VirtualMachineServicePort, requireName bool,
 		allErrs = append(allErrs, field.NotSupported(fldPath.Child("protocol"), sp.Protocol, supportedPortProtocols.List()))
 	}
 
-	for _, msg := range validation.IsValidPortNum(int(sp.TargetPort)) {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("targetPort"), sp.TargetPort, msg))
+	if sp.TargetPort.Type == intstr.String {
+		for _, msg := range validation.IsValidPortName(sp.TargetPort.StrVal) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("targetPort"), sp.TargetPort.StrVal, msg))
+		}
+	} else {
+		for _, msg := range validation.IsValidPortNum(sp.TargetPort.IntValue()) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("targetPort"), sp.TargetPort.IntVal, msg))
+		}
 	}
 
 	return allErrs
@@ -284,9 +440,58 @@ func (v validator) validateAllowedChanges(ctx *pkgctx.WebhookRequestContext, vmS
 		allErrs = append(allErrs, field.Forbidden(specPath.Child("clusterIP"), "field is immutable"))
 	}
 
+	allErrs = append(allErrs, v.validatePrimaryIPFamilyChange(ctx, vmService, oldVMService, specPath)...)
+
+	// Service's healthCheckNodePort cannot be changed once allocated.
+	if oldVMService.Spec.HealthCheckNodePort != 0 &&
+		vmService.Spec.HealthCheckNodePort != oldVMService.Spec.HealthCheckNodePort {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("healthCheckNodePort"), "field is immutable"))
+	}
+
 	return allErrs
 }
 
+// validatePrimaryIPFamilyChange validates that the primary IP family is not
+// changed. Service's primary IP family cannot be changed through updates, but a
+// secondary family may be added or removed. When the families were omitted,
+// the primary family was assigned to the Service by the cluster, so the
+// families may only be set if they keep the assigned primary family.
+func (v validator) validatePrimaryIPFamilyChange(
+	ctx *pkgctx.WebhookRequestContext,
+	vmService, oldVMService *vmopv1.VirtualMachineService,
+	specPath *field.Path) field.ErrorList {
+
+	if len(vmService.Spec.IPFamilies) == 0 {
+		return nil
+	}
+
+	primaryFamilyPath := specPath.Child("ipFamilies").Index(0)
+
+	if len(oldVMService.Spec.IPFamilies) > 0 {
+		if vmService.Spec.IPFamilies[0] != oldVMService.Spec.IPFamilies[0] {
+			return field.ErrorList{field.Forbidden(primaryFamilyPath, "primary IP family may not be changed")}
+		}
+		return nil
+	}
+
+	service := &corev1.Service{}
+	if err := v.client.Get(ctx, client.ObjectKeyFromObject(vmService), service); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The Service has not been created yet, so a family has not been assigned.
+			return nil
+		}
+		return field.ErrorList{field.InternalError(primaryFamilyPath, err)}
+	}
+
+	if len(service.Spec.IPFamilies) > 0 &&
+		string(vmService.Spec.IPFamilies[0]) != string(service.Spec.IPFamilies[0]) {
+		return field.ErrorList{field.Forbidden(primaryFamilyPath,
+			fmt.Sprintf("primary IP family may not be changed from the assigned family %s", service.Spec.IPFamilies[0]))}
+	}
+
+	return nil
+}
+
 // vmServiceFromUnstructured returns the VirtualMachineService from the unstructured object.
 func (v validator) vmServiceFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineService, error) {
 	vmService := &vmopv1.VirtualMachineService{}
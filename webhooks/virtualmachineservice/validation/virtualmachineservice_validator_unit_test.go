@@ -7,12 +7,17 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice/utils"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
@@ -82,14 +87,27 @@ func unitTestsValidateCreate() {
 	)
 
 	type createArgs struct {
-		invalidDNSName        bool
-		emptyType             bool
-		invalidType           bool
-		invalidPorts          bool
-		invalidSelector       bool
-		invalidClusterIP      bool
-		invalidLBSourceRanges bool
-		invalidExternalName   bool
+		invalidDNSName         bool
+		emptyType              bool
+		invalidType            bool
+		invalidPorts           bool
+		invalidSelector        bool
+		invalidClusterIP       bool
+		invalidLBSourceRanges  bool
+		invalidExternalName    bool
+		dualStack              bool
+		duplicateIPFamilies    bool
+		singleStackDualStack   bool
+		externalNameIPFamily   bool
+		clientIPAffinity       bool
+		invalidAffinityConfig  bool
+		invalidAffinityTimeout bool
+		localExternalTP        bool
+		localInternalTP        bool
+		annotationLocalETP     bool
+		clusterIPExternalTP    bool
+		invalidHealthCheck     bool
+		nsxtProvider           bool
 	}
 
 	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
@@ -122,6 +140,73 @@ func unitTestsValidateCreate() {
 			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeExternalName
 			ctx.vmService.Spec.ExternalName = "InValid!"
 		}
+		if args.dualStack {
+			ctx.vmService.Spec.IPFamilies = []vmopv1.IPFamily{vmopv1.IPv6Protocol, vmopv1.IPv4Protocol}
+			ctx.vmService.Spec.IPFamilyPolicy = ptr.To(vmopv1.IPFamilyPolicyRequireDualStack)
+		}
+		if args.duplicateIPFamilies {
+			ctx.vmService.Spec.IPFamilies = []vmopv1.IPFamily{vmopv1.IPv4Protocol, vmopv1.IPv4Protocol}
+		}
+		if args.singleStackDualStack {
+			ctx.vmService.Spec.IPFamilies = []vmopv1.IPFamily{vmopv1.IPv4Protocol, vmopv1.IPv6Protocol}
+			ctx.vmService.Spec.IPFamilyPolicy = ptr.To(vmopv1.IPFamilyPolicySingleStack)
+		}
+		if args.externalNameIPFamily {
+			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeExternalName
+			ctx.vmService.Spec.ExternalName = "my-external-name"
+			ctx.vmService.Spec.IPFamilies = []vmopv1.IPFamily{vmopv1.IPv4Protocol}
+		}
+		if args.clientIPAffinity {
+			ctx.vmService.Spec.SessionAffinity = vmopv1.ServiceAffinityClientIP
+			ctx.vmService.Spec.SessionAffinityConfig = &vmopv1.SessionAffinityConfig{
+				ClientIP: &vmopv1.ClientIPConfig{TimeoutSeconds: ptr.To[int32](600)},
+			}
+		}
+		if args.invalidAffinityConfig {
+			ctx.vmService.Spec.SessionAffinity = vmopv1.ServiceAffinityNone
+			ctx.vmService.Spec.SessionAffinityConfig = &vmopv1.SessionAffinityConfig{
+				ClientIP: &vmopv1.ClientIPConfig{TimeoutSeconds: ptr.To[int32](600)},
+			}
+		}
+		if args.invalidAffinityTimeout {
+			ctx.vmService.Spec.SessionAffinity = vmopv1.ServiceAffinityClientIP
+			ctx.vmService.Spec.SessionAffinityConfig = &vmopv1.SessionAffinityConfig{
+				ClientIP: &vmopv1.ClientIPConfig{TimeoutSeconds: ptr.To[int32](86401)},
+			}
+		}
+		if args.localExternalTP {
+			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeLoadBalancer
+			ctx.vmService.Spec.ExternalTrafficPolicy = vmopv1.ServiceExternalTrafficPolicyLocal
+			ctx.vmService.Spec.InternalTrafficPolicy = ptr.To(vmopv1.ServiceInternalTrafficPolicyCluster)
+			ctx.vmService.Spec.HealthCheckNodePort = 30012
+		}
+		if args.localInternalTP {
+			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeLoadBalancer
+			ctx.vmService.Spec.ExternalTrafficPolicy = vmopv1.ServiceExternalTrafficPolicyLocal
+			ctx.vmService.Spec.InternalTrafficPolicy = ptr.To(vmopv1.ServiceInternalTrafficPolicyLocal)
+		}
+		if args.annotationLocalETP {
+			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeLoadBalancer
+			if ctx.vmService.Annotations == nil {
+				ctx.vmService.Annotations = map[string]string{}
+			}
+			ctx.vmService.Annotations[utils.AnnotationServiceExternalTrafficPolicyKey] = string(vmopv1.ServiceExternalTrafficPolicyLocal)
+			ctx.vmService.Spec.HealthCheckNodePort = 30012
+		}
+		if args.clusterIPExternalTP {
+			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeClusterIP
+			ctx.vmService.Spec.ExternalTrafficPolicy = vmopv1.ServiceExternalTrafficPolicyLocal
+		}
+		if args.invalidHealthCheck {
+			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeLoadBalancer
+			ctx.vmService.Spec.ExternalTrafficPolicy = vmopv1.ServiceExternalTrafficPolicyCluster
+			ctx.vmService.Spec.HealthCheckNodePort = 30012
+		}
+		if args.nsxtProvider {
+			pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+				config.NetworkProviderType = pkgcfg.NetworkProviderTypeNSXT
+			})
+		}
 
 		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmService)
 		Expect(err).ToNot(HaveOccurred())
@@ -153,6 +238,19 @@ func unitTestsValidateCreate() {
 		Entry("should deny invalid ClusterIP", createArgs{invalidClusterIP: true}, false, "spec.clusterIP: Invalid value: \"100.1000.1.1\": must be a valid IP address", nil),
 		Entry("should deny invalid LoadBalancerSourceRanges", createArgs{invalidLBSourceRanges: true}, false, `spec.loadBalancerSourceRanges[0]: Invalid value: "10.1.1.1/42": must be compatible with https://pkg.go.dev/net#ParseCIDR`, nil),
 		Entry("should deny invalid ExternalName", createArgs{invalidExternalName: true}, false, "spec.externalName: Invalid value: \"InValid!\": a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters", nil),
+		Entry("should allow dual-stack", createArgs{dualStack: true}, true, nil, nil),
+		Entry("should deny duplicate IPFamilies", createArgs{duplicateIPFamilies: true}, false, `spec.ipFamilies[1]: Duplicate value: "IPv4"`, nil),
+		Entry("should deny multiple IPFamilies with SingleStack", createArgs{singleStackDualStack: true}, false, "may contain only one entry when `ipFamilyPolicy` is 'SingleStack'", nil),
+		Entry("should deny IPFamilies for ExternalName", createArgs{externalNameIPFamily: true}, false, "spec.ipFamilies: Forbidden: may not be set for ExternalName services", nil),
+		Entry("should allow ClientIP session affinity", createArgs{clientIPAffinity: true}, true, nil, nil),
+		Entry("should deny SessionAffinityConfig without ClientIP", createArgs{invalidAffinityConfig: true}, false, "spec.sessionAffinityConfig: Forbidden: may only be set when `sessionAffinity` is 'ClientIP'", nil),
+		Entry("should deny invalid ClientIP timeout", createArgs{invalidAffinityTimeout: true}, false, "spec.sessionAffinityConfig.clientIP.timeoutSeconds: Invalid value: 86401: must be greater than 0 and less than or equal to 86400", nil),
+		Entry("should allow Local ExternalTrafficPolicy when kube-proxy is bypassed", createArgs{localExternalTP: true, nsxtProvider: true}, true, nil, nil),
+		Entry("should deny Local ExternalTrafficPolicy when kube-proxy is not bypassed", createArgs{localExternalTP: true}, false, "spec.externalTrafficPolicy: Forbidden: may only be 'Local' when the load balancer provider bypasses kube-proxy", nil),
+		Entry("should deny Local InternalTrafficPolicy", createArgs{localInternalTP: true, nsxtProvider: true}, false, `spec.internalTrafficPolicy: Unsupported value: "Local": supported values: "Cluster"`, nil),
+		Entry("should allow HealthCheckNodePort with Local ExternalTrafficPolicy annotation", createArgs{annotationLocalETP: true}, true, nil, nil),
+		Entry("should deny ExternalTrafficPolicy for ClusterIP", createArgs{clusterIPExternalTP: true}, false, "spec.externalTrafficPolicy: Forbidden: may only be used when `type` is 'LoadBalancer'", nil),
+		Entry("should deny HealthCheckNodePort without Local ExternalTrafficPolicy", createArgs{invalidHealthCheck: true}, false, "spec.healthCheckNodePort: Forbidden: may only be used when `type` is 'LoadBalancer' and `externalTrafficPolicy` is 'Local'", nil),
 	)
 
 	validatePortCreate := func(expectedReason string, ports []vmopv1.VirtualMachineServicePort) {
@@ -179,7 +277,7 @@ func unitTestsValidateCreate() {
 					Name:       "http",
 					Protocol:   "TCP",
 					Port:       80,
-					TargetPort: 8080,
+					TargetPort: intstr.FromInt32(8080),
 				},
 			},
 		),
@@ -207,7 +305,24 @@ func unitTestsValidateCreate() {
 		Entry("should deny invalid target port", "spec.ports[0].targetPort: Invalid value: 200000:",
 			[]vmopv1.VirtualMachineServicePort{
 				{
-					TargetPort: 200000,
+					TargetPort: intstr.FromInt32(200000),
+				},
+			},
+		),
+		Entry("should allow named target port", "",
+			[]vmopv1.VirtualMachineServicePort{
+				{
+					Name:       "http",
+					Protocol:   "TCP",
+					Port:       80,
+					TargetPort: intstr.FromString("http"),
+				},
+			},
+		),
+		Entry("should deny invalid named target port", "spec.ports[0].targetPort: Invalid value: \"INVALID\"",
+			[]vmopv1.VirtualMachineServicePort{
+				{
+					TargetPort: intstr.FromString("INVALID"),
 				},
 			},
 		),
@@ -217,13 +332,13 @@ func unitTestsValidateCreate() {
 					Name:       "port1",
 					Protocol:   "TCP",
 					Port:       80,
-					TargetPort: 8080,
+					TargetPort: intstr.FromInt32(8080),
 				},
 				{
 					Name:       "port1",
 					Protocol:   "TCP",
 					Port:       433,
-					TargetPort: 6443,
+					TargetPort: intstr.FromInt32(6443),
 				},
 			},
 		),
@@ -233,13 +348,13 @@ func unitTestsValidateCreate() {
 					Name:       "port1",
 					Protocol:   "TCP",
 					Port:       80,
-					TargetPort: 8080,
+					TargetPort: intstr.FromInt32(8080),
 				},
 				{
 					Name:       "port2",
 					Protocol:   "TCP",
 					Port:       80,
-					TargetPort: 8080,
+					TargetPort: intstr.FromInt32(8080),
 				},
 			},
 		),
@@ -253,8 +368,13 @@ func unitTestsValidateUpdate() {
 	)
 
 	type updateArgs struct {
-		updateType      bool
-		updateClusterIP bool
+		updateType            bool
+		updateClusterIP       bool
+		addSecondaryIPFamily  bool
+		updatePrimaryIPFamily bool
+		updateHealthCheckPort bool
+		setIPFamilies         []vmopv1.IPFamily
+		assignedIPFamily      corev1.IPFamily
 	}
 
 	validateUpdate := func(args updateArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
@@ -266,6 +386,45 @@ func unitTestsValidateUpdate() {
 		if args.updateClusterIP {
 			ctx.vmService.Spec.ClusterIP = "9.9.9.9"
 		}
+		if args.addSecondaryIPFamily {
+			ctx.vmService.Spec.IPFamilies = []vmopv1.IPFamily{vmopv1.IPv4Protocol, vmopv1.IPv6Protocol}
+		}
+		if args.updatePrimaryIPFamily {
+			ctx.vmService.Spec.IPFamilies = []vmopv1.IPFamily{vmopv1.IPv6Protocol}
+		}
+		if args.setIPFamilies != nil {
+			ctx.oldVMService.Spec.IPFamilies = nil
+			ctx.vmService.Spec.IPFamilies = args.setIPFamilies
+
+			ctx.WebhookRequestContext.OldObj, err = builder.ToUnstructured(ctx.oldVMService)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		if args.assignedIPFamily != "" {
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ctx.vmService.Name,
+					Namespace: ctx.vmService.Namespace,
+				},
+				Spec: corev1.ServiceSpec{
+					IPFamilies: []corev1.IPFamily{args.assignedIPFamily},
+				},
+			}
+			Expect(ctx.Client.Create(ctx, service)).To(Succeed())
+		}
+		if args.updateHealthCheckPort {
+			pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+				config.NetworkProviderType = pkgcfg.NetworkProviderTypeNSXT
+			})
+			for _, vmService := range []*vmopv1.VirtualMachineService{ctx.oldVMService, ctx.vmService} {
+				vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeLoadBalancer
+				vmService.Spec.ExternalTrafficPolicy = vmopv1.ServiceExternalTrafficPolicyLocal
+				vmService.Spec.HealthCheckNodePort = 30012
+			}
+			ctx.vmService.Spec.HealthCheckNodePort = 30013
+
+			ctx.WebhookRequestContext.OldObj, err = builder.ToUnstructured(ctx.oldVMService)
+			Expect(err).ToNot(HaveOccurred())
+		}
 
 		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmService)
 		Expect(err).ToNot(HaveOccurred())
@@ -282,6 +441,12 @@ func unitTestsValidateUpdate() {
 
 	BeforeEach(func() {
 		ctx = newUnitTestContextForValidatingWebhook(true)
+		ctx.oldVMService.Spec.IPFamilies = []vmopv1.IPFamily{vmopv1.IPv4Protocol}
+		ctx.vmService.Spec.IPFamilies = []vmopv1.IPFamily{vmopv1.IPv4Protocol}
+
+		var err error
+		ctx.WebhookRequestContext.OldObj, err = builder.ToUnstructured(ctx.oldVMService)
+		Expect(err).ToNot(HaveOccurred())
 	})
 	AfterEach(func() {
 		ctx = nil
@@ -291,6 +456,16 @@ func unitTestsValidateUpdate() {
 		Entry("should allow", updateArgs{}, true, nil, nil),
 		Entry("should deny Type change", updateArgs{updateType: true}, false, "spec.type: Forbidden: field is immutable", nil),
 		Entry("should deny ClusterIP change", updateArgs{updateClusterIP: true}, false, "spec.clusterIP: Forbidden: field is immutable", nil),
+		Entry("should allow adding a secondary IPFamily", updateArgs{addSecondaryIPFamily: true}, true, nil, nil),
+		Entry("should deny primary IPFamily change", updateArgs{updatePrimaryIPFamily: true}, false, "spec.ipFamilies[0]: Forbidden: primary IP family may not be changed", nil),
+		Entry("should allow setting IPFamilies before the Service is created",
+			updateArgs{setIPFamilies: []vmopv1.IPFamily{vmopv1.IPv6Protocol}}, true, nil, nil),
+		Entry("should allow setting IPFamilies with the Service's assigned primary IPFamily",
+			updateArgs{setIPFamilies: []vmopv1.IPFamily{vmopv1.IPv4Protocol, vmopv1.IPv6Protocol}, assignedIPFamily: corev1.IPv4Protocol}, true, nil, nil),
+		Entry("should deny setting IPFamilies with a different primary IPFamily than the Service's assigned family",
+			updateArgs{setIPFamilies: []vmopv1.IPFamily{vmopv1.IPv6Protocol}, assignedIPFamily: corev1.IPv4Protocol}, false,
+			"spec.ipFamilies[0]: Forbidden: primary IP family may not be changed from the assigned family IPv4", nil),
+		Entry("should deny HealthCheckNodePort change", updateArgs{updateHealthCheckPort: true}, false, "spec.healthCheckNodePort: Forbidden: field is immutable", nil),
 	)
 
 	When("the update is performed while object deletion", func() {
@@ -0,0 +1,165 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	minQuiesceTimeout = 5 * time.Minute
+	maxQuiesceTimeout = 240 * time.Minute
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachinesnapshot,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinesnapshots,versions=v1alpha3,name=default.validating.virtualmachinesnapshot.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesnapshots,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesnapshots/status,verbs=get
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create VirtualMachineSnapshot validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineSnapshot{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	vmSnapshot, err := v.vmSnapshotFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+
+	fieldErrs = append(fieldErrs, v.validateVMRef(vmSnapshot)...)
+	fieldErrs = append(fieldErrs, v.validateQuiesce(vmSnapshot)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	vmSnapshot, err := v.vmSnapshotFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	oldVMSnapshot, err := v.vmSnapshotFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+
+	// A snapshot cannot be changed once it has been taken.
+	fieldErrs = append(fieldErrs, validation.ValidateImmutableField(
+		vmSnapshot.Spec, oldVMSnapshot.Spec, field.NewPath("spec"))...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateVMRef(vmSnapshot *vmopv1.VirtualMachineSnapshot) field.ErrorList {
+	var allErrs field.ErrorList
+
+	vmRefPath := field.NewPath("spec").Child("vmRef")
+	vmRef := vmSnapshot.Spec.VMRef
+	if vmRef == nil {
+		return append(allErrs, field.Required(vmRefPath, ""))
+	}
+
+	if vmRef.Name == "" {
+		allErrs = append(allErrs, field.Required(vmRefPath.Child("name"), ""))
+	}
+
+	if apiVersion := vmRef.APIVersion; apiVersion != "" {
+		gv, err := schema.ParseGroupVersion(apiVersion)
+		if err != nil || gv.Group != vmopv1.GroupVersion.Group {
+			allErrs = append(allErrs, field.Invalid(vmRefPath.Child("apiVersion"), apiVersion,
+				fmt.Sprintf("must be in the group %s", vmopv1.GroupVersion.Group)))
+		}
+	}
+
+	if kind := vmRef.Kind; kind != "" && kind != reflect.TypeOf(vmopv1.VirtualMachine{}).Name() {
+		allErrs = append(allErrs, field.NotSupported(vmRefPath.Child("kind"),
+			kind, []string{reflect.TypeOf(vmopv1.VirtualMachine{}).Name(), ""}))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateQuiesce(vmSnapshot *vmopv1.VirtualMachineSnapshot) field.ErrorList {
+	var allErrs field.ErrorList
+
+	quiesce := vmSnapshot.Spec.Quiesce
+	if quiesce == nil || quiesce.Timeout == nil {
+		return allErrs
+	}
+
+	timeoutPath := field.NewPath("spec").Child("quiesce").Child("timeout")
+	if timeout := quiesce.Timeout.Duration; timeout < minQuiesceTimeout || timeout > maxQuiesceTimeout {
+		allErrs = append(allErrs, field.Invalid(timeoutPath, timeout.String(),
+			fmt.Sprintf("must be between %s and %s", minQuiesceTimeout, maxQuiesceTimeout)))
+	}
+
+	return allErrs
+}
+
+// vmSnapshotFromUnstructured returns the VirtualMachineSnapshot from the unstructured object.
+func (v validator) vmSnapshotFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineSnapshot, error) {
+	vmSnapshot := &vmopv1.VirtualMachineSnapshot{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), vmSnapshot); err != nil {
+		return nil, err
+	}
+	return vmSnapshot, nil
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	vmSnapshot    *vmopv1.VirtualMachineSnapshot
+	oldVMSnapshot *vmopv1.VirtualMachineSnapshot
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	vmSnapshot := builder.DummyVirtualMachineSnapshot("dummy-ns", "dummy-snapshot", "dummy-vm")
+	obj, err := builder.ToUnstructured(vmSnapshot)
+	Expect(err).ToNot(HaveOccurred())
+
+	var oldVMSnapshot *vmopv1.VirtualMachineSnapshot
+	var oldObj *unstructured.Unstructured
+
+	if isUpdate {
+		oldVMSnapshot = vmSnapshot.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldVMSnapshot)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		vmSnapshot:                          vmSnapshot,
+		oldVMSnapshot:                       oldVMSnapshot,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		noVMRef             bool
+		emptyVMRefName      bool
+		invalidVMRefKind    bool
+		invalidVMRefVersion bool
+		quiesceTimeout      time.Duration
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string) {
+		if args.noVMRef {
+			ctx.vmSnapshot.Spec.VMRef = nil
+		}
+		if args.emptyVMRefName {
+			ctx.vmSnapshot.Spec.VMRef.Name = ""
+		}
+		if args.invalidVMRefKind {
+			ctx.vmSnapshot.Spec.VMRef.Kind = "Machine"
+		}
+		if args.invalidVMRefVersion {
+			ctx.vmSnapshot.Spec.VMRef.APIVersion = "cluster.x-k8s.io/v1beta1"
+		}
+		if args.quiesceTimeout != 0 {
+			ctx.vmSnapshot.Spec.Quiesce = &vmopv1.QuiesceSpec{
+				Timeout: &metav1.Duration{Duration: args.quiesceTimeout},
+			}
+		}
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmSnapshot)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow valid", createArgs{}, true, ""),
+		Entry("should allow valid quiesce timeout", createArgs{quiesceTimeout: 30 * time.Minute}, true, ""),
+		Entry("should deny missing vmRef", createArgs{noVMRef: true}, false,
+			"spec.vmRef: Required value"),
+		Entry("should deny empty vmRef name", createArgs{emptyVMRefName: true}, false,
+			"spec.vmRef.name: Required value"),
+		Entry("should deny invalid vmRef kind", createArgs{invalidVMRefKind: true}, false,
+			`spec.vmRef.kind: Unsupported value: "Machine"`),
+		Entry("should deny invalid vmRef apiVersion", createArgs{invalidVMRefVersion: true}, false,
+			`spec.vmRef.apiVersion: Invalid value: "cluster.x-k8s.io/v1beta1"`),
+		Entry("should deny quiesce timeout less than minimum", createArgs{quiesceTimeout: time.Minute}, false,
+			"spec.quiesce.timeout: Invalid value"),
+		Entry("should deny quiesce timeout greater than maximum", createArgs{quiesceTimeout: 5 * time.Hour}, false,
+			"spec.quiesce.timeout: Invalid value"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the spec is not updated", func() {
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the spec is updated", func() {
+		BeforeEach(func() {
+			var err error
+			ctx.vmSnapshot.Spec.Memory = true
+			ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmSnapshot)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should not allow the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result).ToNot(BeNil())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("field is immutable"))
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package mutation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	webconsolerequest "github.com/vmware-tanzu/vm-operator/controllers/virtualmachinewebconsolerequest/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+const (
+	webHookName = "default"
+)
+
+// +kubebuilder:webhook:path=/default-mutate-vmoperator-vmware-com-v1alpha3-virtualmachinewebconsolerequest,mutating=true,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinewebconsolerequests,verbs=create,versions=v1alpha3,name=default.mutating.virtualmachinewebconsolerequest.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewMutatingWebhook(ctx, mgr, webHookName, NewMutator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create virtualmachinewebconsolerequest mutation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewMutator returns the package's Mutator.
+func NewMutator(_ client.Client) builder.Mutator {
+	return mutator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type mutator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (m mutator) Mutate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	if ctx.Op != admissionv1.Create {
+		return admission.Allowed("")
+	}
+
+	modified, err := m.webConsoleRequestFromUnstructured(ctx.Obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if !SetRequester(ctx, modified) {
+		return admission.Allowed("")
+	}
+
+	rawModified, err := json.Marshal(modified)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(ctx.RawObj, rawModified)
+}
+
+func (m mutator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineWebConsoleRequest{}).Name())
+}
+
+// SetRequester records the name of the user that created the request in the
+// request's annotations, overwriting any value provided by the user. Returns
+// true if the request was mutated.
+func SetRequester(
+	ctx *pkgctx.WebhookRequestContext,
+	wcr *vmopv1.VirtualMachineWebConsoleRequest) bool {
+
+	requester := ctx.UserInfo.Username
+	if v, ok := wcr.Annotations[webconsolerequest.RequesterAnnotationKey]; ok && v == requester {
+		return false
+	}
+
+	if wcr.Annotations == nil {
+		wcr.Annotations = map[string]string{}
+	}
+	wcr.Annotations[webconsolerequest.RequesterAnnotationKey] = requester
+
+	return true
+}
+
+// webConsoleRequestFromUnstructured returns the wcr from the unstructured
+// object.
+func (m mutator) webConsoleRequestFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineWebConsoleRequest, error) {
+	wcr := &vmopv1.VirtualMachineWebConsoleRequest{}
+	if err := m.converter.FromUnstructured(obj.UnstructuredContent(), wcr); err != nil {
+		return nil, err
+	}
+	return wcr, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package mutation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	webconsolerequest "github.com/vmware-tanzu/vm-operator/controllers/virtualmachinewebconsolerequest/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Mutate",
+		Label(
+			testlabels.Create,
+			testlabels.EnvTest,
+			testlabels.V1Alpha3,
+			testlabels.Mutation,
+			testlabels.Webhook,
+		),
+		intgTestsMutating,
+	)
+}
+
+type intgMutatingWebhookContext struct {
+	builder.IntegrationTestContext
+	wcr *vmopv1.VirtualMachineWebConsoleRequest
+}
+
+func newIntgMutatingWebhookContext() *intgMutatingWebhookContext {
+	ctx := &intgMutatingWebhookContext{
+		IntegrationTestContext: *suite.NewIntegrationTestContext(),
+	}
+
+	_, publicKeyPem := builder.WebConsoleRequestKeyPair()
+
+	ctx.wcr = &vmopv1.VirtualMachineWebConsoleRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dummy-wcr",
+			Namespace: ctx.Namespace,
+			Annotations: map[string]string{
+				webconsolerequest.RequesterAnnotationKey: "someone-else",
+			},
+		},
+		Spec: vmopv1.VirtualMachineWebConsoleRequestSpec{
+			Name:      "dummy-vm",
+			PublicKey: publicKeyPem,
+		},
+	}
+
+	return ctx
+}
+
+func intgTestsMutating() {
+	var (
+		ctx *intgMutatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newIntgMutatingWebhookContext()
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	When("the request is created", func() {
+		It("should set the requester annotation to the name of the user", func() {
+			Expect(ctx.Client.Create(ctx, ctx.wcr)).To(Succeed())
+
+			wcr := &vmopv1.VirtualMachineWebConsoleRequest{}
+			Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(ctx.wcr), wcr)).To(Succeed())
+			Expect(wcr.Annotations).To(HaveKey(webconsolerequest.RequesterAnnotationKey))
+			Expect(wcr.Annotations[webconsolerequest.RequesterAnnotationKey]).ToNot(BeEmpty())
+			Expect(wcr.Annotations[webconsolerequest.RequesterAnnotationKey]).ToNot(Equal("someone-else"))
+		})
+	})
+}
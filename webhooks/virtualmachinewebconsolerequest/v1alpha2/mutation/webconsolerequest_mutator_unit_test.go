@@ -0,0 +1,143 @@
+// Copyright (c) 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package mutation_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	webconsolerequest "github.com/vmware-tanzu/vm-operator/controllers/virtualmachinewebconsolerequest/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinewebconsolerequest/v1alpha2/mutation"
+)
+
+func unitTests() {
+	Describe(
+		"Mutate",
+		Label(
+			testlabels.Create,
+			testlabels.V1Alpha3,
+			testlabels.Mutation,
+			testlabels.Webhook,
+		),
+		unitTestsMutating,
+	)
+}
+
+type unitMutationWebhookContext struct {
+	builder.UnitTestContextForMutatingWebhook
+	wcr *vmopv1.VirtualMachineWebConsoleRequest
+}
+
+func newUnitTestContextForMutatingWebhook() *unitMutationWebhookContext {
+	wcr := &vmopv1.VirtualMachineWebConsoleRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dummy-wcr",
+			Namespace: "dummy-ns",
+		},
+		Spec: vmopv1.VirtualMachineWebConsoleRequestSpec{
+			Name: "dummy-vm",
+		},
+	}
+	obj, err := builder.ToUnstructured(wcr)
+	Expect(err).ToNot(HaveOccurred())
+
+	return &unitMutationWebhookContext{
+		UnitTestContextForMutatingWebhook: *suite.NewUnitTestContextForMutatingWebhook(obj),
+		wcr:                               wcr,
+	}
+}
+
+func unitTestsMutating() {
+	const requester = "sso:jdoe@vsphere.local"
+
+	var (
+		ctx *unitMutationWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForMutatingWebhook()
+		ctx.UserInfo.Username = requester
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	Describe("Mutate", func() {
+		JustBeforeEach(func() {
+			var err error
+			ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.wcr)
+			Expect(err).ToNot(HaveOccurred())
+			ctx.WebhookRequestContext.RawObj, err = json.Marshal(ctx.wcr)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		When("the request is a create", func() {
+			BeforeEach(func() {
+				ctx.Op = admissionv1.Create
+			})
+
+			It("should patch the requester annotation", func() {
+				response := ctx.Mutate(&ctx.WebhookRequestContext)
+				Expect(response.Allowed).To(BeTrue())
+				Expect(response.Patches).To(HaveLen(1))
+				Expect(response.Patches[0].Path).To(Equal("/metadata/annotations"))
+			})
+		})
+
+		When("the request is an update", func() {
+			BeforeEach(func() {
+				ctx.Op = admissionv1.Update
+			})
+
+			It("should not patch the request", func() {
+				response := ctx.Mutate(&ctx.WebhookRequestContext)
+				Expect(response.Allowed).To(BeTrue())
+				Expect(response.Patches).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("SetRequester", func() {
+		When("the annotation is not set", func() {
+			It("should set the annotation to the user's name", func() {
+				Expect(mutation.SetRequester(&ctx.WebhookRequestContext, ctx.wcr)).To(BeTrue())
+				Expect(ctx.wcr.Annotations).To(HaveKeyWithValue(webconsolerequest.RequesterAnnotationKey, requester))
+			})
+		})
+
+		When("the annotation is set to another user", func() {
+			BeforeEach(func() {
+				ctx.wcr.Annotations = map[string]string{
+					webconsolerequest.RequesterAnnotationKey: "someone-else",
+				}
+			})
+
+			It("should overwrite the annotation", func() {
+				Expect(mutation.SetRequester(&ctx.WebhookRequestContext, ctx.wcr)).To(BeTrue())
+				Expect(ctx.wcr.Annotations).To(HaveKeyWithValue(webconsolerequest.RequesterAnnotationKey, requester))
+			})
+		})
+
+		When("the annotation is already set to the user's name", func() {
+			BeforeEach(func() {
+				ctx.wcr.Annotations = map[string]string{
+					webconsolerequest.RequesterAnnotationKey: requester,
+				}
+			})
+
+			It("should not mutate the request", func() {
+				Expect(mutation.SetRequester(&ctx.WebhookRequestContext, ctx.wcr)).To(BeFalse())
+			})
+		})
+	})
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2022-2023 VMware, Inc. All Rights Reserved.
+// Copyright (c) 2022-2024 VMware, Inc. All Rights Reserved.
 // SPDX-License-Identifier: Apache-2.0
 
 package validation
@@ -28,6 +28,8 @@ import (
 
 const (
 	webHookName = "default"
+
+	revokedCannotBeUnset = "cannot be set to false once the request is revoked"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachinewebconsolerequest,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinewebconsolerequests,versions=v1alpha3,name=default.validating.virtualmachinewebconsolerequest.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
@@ -96,6 +98,7 @@ func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.R
 	var fieldErrs field.ErrorList
 	fieldErrs = append(fieldErrs, v.validateImmutableFields(wcr, oldwcr)...)
 	fieldErrs = append(fieldErrs, v.validateUUIDLabel(wcr, oldwcr)...)
+	fieldErrs = append(fieldErrs, v.validateRequesterAnnotation(wcr, oldwcr)...)
 
 	validationErrs := make([]string, 0, len(fieldErrs))
 	for _, fieldErr := range fieldErrs {
@@ -161,6 +164,10 @@ func (v validator) validateImmutableFields(wcr, oldwcr *vmopv1.VirtualMachineWeb
 	allErrs = append(allErrs, validation.ValidateImmutableField(wcr.Spec.Name, oldwcr.Spec.Name, specPath.Child("Name"))...)
 	allErrs = append(allErrs, validation.ValidateImmutableField(wcr.Spec.PublicKey, oldwcr.Spec.PublicKey, specPath.Child("publicKey"))...)
 
+	if oldwcr.Spec.Revoked && !wcr.Spec.Revoked {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("revoked"), revokedCannotBeUnset))
+	}
+
 	return allErrs
 }
 
@@ -187,3 +194,18 @@ func (v validator) validateUUIDLabel(wcr, oldwcr *vmopv1.VirtualMachineWebConsol
 
 	return allErrs
 }
+
+func (v validator) validateRequesterAnnotation(wcr, oldwcr *vmopv1.VirtualMachineWebConsoleRequest) field.ErrorList {
+	var allErrs field.ErrorList
+
+	oldRequester, ok := oldwcr.Annotations[webconsolerequest.RequesterAnnotationKey]
+	if !ok {
+		return allErrs
+	}
+
+	newRequester := wcr.Annotations[webconsolerequest.RequesterAnnotationKey]
+	annotationsPath := field.NewPath("metadata", "annotations")
+	allErrs = append(allErrs, validation.ValidateImmutableField(newRequester, oldRequester, annotationsPath.Key(webconsolerequest.RequesterAnnotationKey))...)
+
+	return allErrs
+}
@@ -119,6 +119,26 @@ func intgTestsValidateUpdate() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	When("update is performed to revoke the request", func() {
+		BeforeEach(func() {
+			ctx.wcr.Spec.Revoked = true
+		})
+		It("should allow the request", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		When("update is performed to unset revoked", func() {
+			JustBeforeEach(func() {
+				ctx.wcr.Spec.Revoked = false
+				err = ctx.Client.Update(suite, ctx.wcr)
+			})
+			It("should deny the request", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("spec.revoked"))
+			})
+		})
+	})
 }
 
 func intgTestsValidateDelete() {
@@ -66,6 +66,9 @@ func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhoo
 	wcr.Labels = map[string]string{
 		v1alpha2.UUIDLabelKey: "some-uuid",
 	}
+	wcr.Annotations = map[string]string{
+		v1alpha2.RequesterAnnotationKey: "some-user",
+	}
 	obj, err := builder.ToUnstructured(wcr)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -148,6 +151,9 @@ func unitTestsValidateUpdate() {
 		updateVirtualMachineName bool
 		updatePublicKey          bool
 		updateUUIDLabel          bool
+		updateRequester          bool
+		revoke                   bool
+		unrevoke                 bool
 	}
 
 	validateUpdate := func(args updateArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
@@ -165,6 +171,20 @@ func unitTestsValidateUpdate() {
 			ctx.wcr.Labels[v1alpha2.UUIDLabelKey] = "new-uuid"
 		}
 
+		if args.updateRequester {
+			ctx.wcr.Annotations[v1alpha2.RequesterAnnotationKey] = "new-user"
+		}
+
+		if args.revoke {
+			ctx.wcr.Spec.Revoked = true
+		}
+
+		if args.unrevoke {
+			ctx.oldWcr.Spec.Revoked = true
+			ctx.WebhookRequestContext.OldObj, err = builder.ToUnstructured(ctx.oldWcr)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
 		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured((ctx.wcr))
 		Expect(err).ToNot(HaveOccurred())
 
@@ -190,6 +210,9 @@ func unitTestsValidateUpdate() {
 		Entry("should deny Virtualmachine Name change", updateArgs{updateVirtualMachineName: true}, false, "spec.Name: Invalid value: \"new-vm-name\": field is immutable", nil),
 		Entry("should deny PublicKey change", updateArgs{updatePublicKey: true}, false, "spec.publicKey: Invalid value: \"new-public-key\": field is immutable", nil),
 		Entry("should deny UUID label change", updateArgs{updateUUIDLabel: true}, false, "metadata.labels[vmoperator.vmware.com/webconsolerequest-uuid]: Invalid value: \"new-uuid\": field is immutable", nil),
+		Entry("should deny requester annotation change", updateArgs{updateRequester: true}, false, "metadata.annotations[vmoperator.vmware.com/webconsolerequest-requester]: Invalid value: \"new-user\": field is immutable", nil),
+		Entry("should allow revoke", updateArgs{revoke: true}, true, nil, nil),
+		Entry("should deny unrevoke", updateArgs{unrevoke: true}, false, "spec.revoked: Forbidden: cannot be set to false once the request is revoked", nil),
 	)
 
 	When("the update is performed while object deletion", func() {
@@ -15,10 +15,19 @@ import (
 	"github.com/vmware-tanzu/vm-operator/webhooks/unifiedstoragequota"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachine"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineclass"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineclone"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinedeployment"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinedisruptionbudget"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineexport"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineguestoperation"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineimageimportrequest"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinepublishrequest"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinepublishschedule"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinereplicaset"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineserialconsolerequest"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineservice"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinesetresourcepolicy"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinesnapshot"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinewebconsolerequest"
 )
 
@@ -53,6 +62,54 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) err
 		if err := virtualmachinereplicaset.AddToManager(ctx, mgr); err != nil {
 			return fmt.Errorf("failed to initialize VirtualMachineReplicaSet webhooks: %w", err)
 		}
+		if err := virtualmachinedeployment.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineDeployment webhooks: %w", err)
+		}
+		if err := virtualmachinedisruptionbudget.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineDisruptionBudget webhooks: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMClone {
+		if err := virtualmachineclone.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineClone webhooks: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMPublishSchedule {
+		if err := virtualmachinepublishschedule.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachinePublishSchedule webhooks: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMImageImport {
+		if err := virtualmachineimageimportrequest.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineImageImportRequest webhooks: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMExport {
+		if err := virtualmachineexport.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineExport webhooks: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMSerialConsole {
+		if err := virtualmachineserialconsolerequest.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineSerialConsoleRequest webhooks: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMGuestOperations {
+		if err := virtualmachineguestoperation.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineGuestOperation webhooks: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMSnapshots {
+		if err := virtualmachinesnapshot.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineSnapshot webhooks: %w", err)
+		}
 	}
 
 	if pkgcfg.FromContext(ctx).Features.UnifiedStorageQuota {